@@ -0,0 +1,371 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package client calls the operations of an OpenAPI document by their
+// operationId at runtime, for tools that do not want generated SDKs.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/genelet/oas/form"
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+	"github.com/genelet/oas/validate"
+)
+
+var (
+	// ErrUnknownOperation is returned for an operationId the document does
+	// not declare
+	ErrUnknownOperation = errors.New("unknown operation")
+	// ErrMissingParameter is returned when a required parameter or body is
+	// not given
+	ErrMissingParameter = errors.New("missing required parameter")
+	// ErrInvalidParameter is returned for a parameter value that cannot be
+	// sent as it is
+	ErrInvalidParameter = errors.New("invalid parameter")
+	// ErrNoServer is returned when neither Options.BaseURL nor an absolute
+	// server URL tells where to send requests
+	ErrNoServer = errors.New("no server URL")
+)
+
+// StatusError is returned for responses with a 4xx or 5xx status, with
+// their body
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	if len(e.Body) == 0 {
+		return e.Status
+	}
+	return fmt.Sprintf("%s: %s", e.Status, bytes.TrimSpace(e.Body))
+}
+
+// Options configures a Client
+type Options struct {
+	// BaseURL replaces the servers of the document, such as
+	// http://localhost:8080/v1 for a local instance
+	BaseURL string
+	// HTTPClient sends the requests; http.DefaultClient when nil
+	HTTPClient *http.Client
+	// Header is added to every request, such as an Authorization header
+	Header http.Header
+	// SkipValidation sends requests and accepts responses without
+	// validating them against the document
+	SkipValidation bool
+}
+
+// Client calls the operations of a document
+type Client struct {
+	ops  map[string]*operation
+	opts Options
+}
+
+// operation is an operation of the document with where it is served
+type operation struct {
+	path, method string
+	item         unified.PathItem
+	op           unified.Operation
+	base         *url.URL
+}
+
+// New returns a client for the operations of a document. Operations without
+// an operationId are called by their method and path, as "GET /pets".
+func New(doc unified.Document, opts Options) (*Client, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to call")
+	}
+	resolved, err := unified.Resolve(doc)
+	if err != nil {
+		return nil, err
+	}
+	var base *url.URL
+	if opts.BaseURL != "" {
+		if base, err = url.Parse(opts.BaseURL); err != nil {
+			return nil, fmt.Errorf("invalid base URL: %w", err)
+		}
+	}
+	c := &Client{ops: make(map[string]*operation), opts: opts}
+	paths := resolved.GetPaths()
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		item := paths[path]
+		if item == nil {
+			continue
+		}
+		for method, op := range item.GetAllOperations() {
+			o := &operation{path: path, method: strings.ToUpper(method), item: item, op: op, base: base}
+			id := op.GetOperationID()
+			if id == "" {
+				id = o.method + " " + path
+			}
+			if _, ok := c.ops[id]; ok {
+				return nil, fmt.Errorf("duplicate operationId %q", id)
+			}
+			if base == nil {
+				o.base = serverURL(unified.EffectiveServers(resolved, item, op))
+			}
+			c.ops[id] = o
+		}
+	}
+	return c, nil
+}
+
+// serverURL returns the URL of the first server, its variables replaced by
+// their defaults, or nil when it is not absolute
+func serverURL(servers []unified.Server) *url.URL {
+	if len(servers) == 0 || servers[0] == nil {
+		return nil
+	}
+	raw := servers[0].GetURL()
+	for name, v := range servers[0].GetVariables() {
+		if v != nil {
+			raw = strings.ReplaceAll(raw, "{"+name+"}", v.GetDefault())
+		}
+	}
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() {
+		return nil
+	}
+	return u
+}
+
+// Operations returns the operationIds the client can call, and the method
+// and path of operations without one, sorted
+func (c *Client) Operations() []string {
+	return slices.Sorted(maps.Keys(c.ops))
+}
+
+// Request builds the request of an operation. params holds the path, query,
+// header and cookie parameters by name, serialized by their style, and the
+// 2.0 formData parameters. body is encoded for the first JSON media type of
+// the request body, or else its first media type: JSON, form-urlencoded or
+// multipart fields following the encoding of the media type, or a string,
+// []byte or io.Reader sent as it is. Unless
+// Options.SkipValidation, the request is validated against the operation.
+func (c *Client) Request(ctx context.Context, operationID string, params map[string]any, body any) (*http.Request, error) {
+	o, ok := c.ops[operationID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownOperation, operationID)
+	}
+	if o.base == nil {
+		return nil, fmt.Errorf("%w for %s", ErrNoServer, operationID)
+	}
+	m := &router.Match{Path: o.path, Method: o.method, PathItem: o.item, Operation: o.op, PathParams: make(map[string]string)}
+
+	path, plain := o.path, o.path
+	var query []string
+	header := make(http.Header)
+	var cookies []*http.Cookie
+	formData := make(map[string]any)
+	for _, p := range parameters(o) {
+		name, in := p.GetName(), p.GetIn()
+		value, ok := params[name]
+		if !ok || value == nil {
+			if p.GetRequired() {
+				return nil, fmt.Errorf("%w %s %s", ErrMissingParameter, in, name)
+			}
+			continue
+		}
+		switch in {
+		case "path":
+			pairs := serialize(p, value, url.PathEscape)
+			path = strings.ReplaceAll(path, "{"+name+"}", pairs[0].value)
+			m.PathParams[name] = serialize(p, value, noEscape)[0].value
+			plain = strings.ReplaceAll(plain, "{"+name+"}", m.PathParams[name])
+		case "query":
+			for _, pair := range serialize(p, value, url.QueryEscape) {
+				query = append(query, url.QueryEscape(pair.name)+"="+pair.value)
+			}
+		case "header":
+			header.Set(name, serialize(p, value, noEscape)[0].value)
+		case "cookie":
+			cookies = append(cookies, &http.Cookie{Name: name, Value: serialize(p, value, noEscape)[0].value})
+		case "formData":
+			formData[name] = value
+		}
+	}
+
+	// A . or .. segment would name another resource once the path is
+	// normalized, by the client or the server
+	if slices.ContainsFunc(strings.Split(path, "/"), func(segment string) bool {
+		return segment == "." || segment == ".."
+	}) {
+		return nil, fmt.Errorf("%w: path %s of %s has a dot segment", ErrInvalidParameter, path, operationID)
+	}
+	u := *o.base
+	u.Path = strings.TrimSuffix(o.base.Path, "/") + plain
+	u.RawPath = strings.TrimSuffix(o.base.EscapedPath(), "/") + path
+	u.RawQuery = strings.Join(query, "&")
+	contentType, reader, err := encodeBody(o.op.GetRequestBody(), body, formData)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, o.method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range c.opts.Header {
+		req.Header[k] = slices.Clone(vs)
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if !c.opts.SkipValidation {
+		if err := validate.Request(req, m); err != nil {
+			return nil, fmt.Errorf("invalid request for %s: %w", operationID, err)
+		}
+	}
+	return req, nil
+}
+
+// parameters returns the parameters of an operation, including the formData
+// parameters of 2.0 but not the body parameter, which is the body
+func parameters(o *operation) []unified.Parameter {
+	params := validate.Parameters(&router.Match{PathItem: o.item, Operation: o.op})
+	for _, p := range append(o.item.GetParameters(), o.op.GetParameters()...) {
+		if p != nil && p.GetIn() == "formData" {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// encodeBody encodes the body of a request for its media type, or the
+// formData parameters of 2.0 as a form
+func encodeBody(rb unified.RequestBody, body any, formData map[string]any) (string, io.Reader, error) {
+	if len(formData) > 0 {
+		mediaType := form.URLEncoded
+		if rb != nil && rb.GetMediaType(form.URLEncoded) == nil && rb.GetMediaType(form.Multipart) != nil {
+			mediaType = form.Multipart
+		}
+		data, contentType, err := form.Encode(mediaType, nil, formData)
+		if err != nil {
+			return "", nil, err
+		}
+		return contentType, bytes.NewReader(data), nil
+	}
+	if rb == nil || rb.IsNil() {
+		return "", nil, nil
+	}
+	if body == nil {
+		if rb.GetRequired() {
+			return "", nil, fmt.Errorf("%w body", ErrMissingParameter)
+		}
+		return "", nil, nil
+	}
+	types := slices.Sorted(maps.Keys(rb.GetContent()))
+	if len(types) == 0 {
+		return "", nil, nil
+	}
+	contentType := types[0]
+	if i := slices.IndexFunc(types, validate.IsJSON); i >= 0 {
+		contentType = types[i]
+	}
+	switch x := body.(type) {
+	case io.Reader:
+		return contentType, x, nil
+	case []byte:
+		return contentType, bytes.NewReader(x), nil
+	case string:
+		if !validate.IsJSON(contentType) {
+			return contentType, strings.NewReader(x), nil
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case form.Supports(mediaType):
+		data, formType, err := form.Encode(mediaType, rb.GetContent()[contentType], body)
+		if err != nil {
+			return "", nil, fmt.Errorf("cannot encode the body: %w", err)
+		}
+		return formType, bytes.NewReader(data), nil
+	case validate.IsJSON(contentType) || mediaType == "" || strings.Contains(mediaType, "*"):
+		data, err := json.Marshal(body)
+		if err != nil {
+			return "", nil, fmt.Errorf("cannot encode the body: %w", err)
+		}
+		if mediaType == "" || strings.Contains(mediaType, "*") {
+			contentType = "application/json"
+		}
+		return contentType, bytes.NewReader(data), nil
+	}
+	return "", nil, fmt.Errorf("cannot encode a %T body as %s", body, contentType)
+}
+
+// Call calls an operation: it builds its request, sends it, validates the
+// response against the document and decodes a JSON body into target, which
+// may be nil. A *[]byte or *string target takes the body as it is. The
+// response is returned with a body that can be read again, along with a
+// *StatusError for 4xx and 5xx statuses, whose body is not decoded. A 4xx or
+// 5xx response that does not conform gives both errors, joined.
+func (c *Client) Call(ctx context.Context, operationID string, params map[string]any, body, target any) (*http.Response, error) {
+	req, err := c.Request(ctx, operationID, params, body)
+	if err != nil {
+		return nil, err
+	}
+	hc := c.opts.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return resp, err
+	}
+	var invalid error
+	if !c.opts.SkipValidation {
+		if err := validate.Response(resp, c.ops[operationID].op); err != nil {
+			invalid = fmt.Errorf("invalid response for %s: %w", operationID, err)
+		}
+	}
+	if resp.StatusCode >= 400 {
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: data}
+		if invalid != nil {
+			return resp, errors.Join(statusErr, invalid)
+		}
+		return resp, statusErr
+	}
+	if invalid != nil {
+		return resp, invalid
+	}
+	if target == nil || len(data) == 0 {
+		return resp, nil
+	}
+	switch t := target.(type) {
+	case *[]byte:
+		*t = data
+	case *string:
+		*t = string(data)
+	default:
+		if err := json.Unmarshal(data, target); err != nil {
+			return resp, fmt.Errorf("cannot decode the response of %s: %w", operationID, err)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/genelet/oas/mock"
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+	"github.com/genelet/oas/validate"
+)
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return doc
+}
+
+const pets = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Pets", "version": "1"},
+	"servers": [{"url": "https://{region}.example.com/v1", "variables": {"region": {"default": "eu"}}}],
+	"paths": {
+		"/pets": {
+			"get": {
+				"operationId": "listPets",
+				"parameters": [
+					{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 100}},
+					{"name": "tags", "in": "query", "style": "form", "explode": false, "schema": {"type": "array", "items": {"type": "string"}}},
+					{"name": "filter", "in": "query", "style": "deepObject", "schema": {"type": "object", "properties": {"age": {"type": "integer"}}}},
+					{"name": "kinds", "in": "query", "explode": false, "schema": {"type": "array", "items": {"type": "string"}}},
+					{"name": "ids", "in": "query", "style": "form", "schema": {"type": "array", "items": {"type": "string"}}},
+					{"name": "X-Trace", "in": "header", "schema": {"type": "string"}}
+				],
+				"responses": {"200": {"description": "OK", "content": {"application/json": {
+					"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+					"example": [{"id": 1, "name": "Rex"}]
+				}}}}
+			},
+			"post": {
+				"operationId": "createPet",
+				"requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+				"responses": {"201": {"description": "Created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}
+			}
+		},
+		"/pets/{petId}": {
+			"parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "integer"}}],
+			"get": {
+				"operationId": "getPet",
+				"responses": {
+					"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+					"404": {"description": "Not found"}
+				}
+			}
+		}
+	},
+	"components": {"schemas": {
+		"Pet": {"type": "object", "required": ["name"], "properties": {"id": {"type": "integer", "readOnly": true}, "name": {"type": "string"}}}
+	}}
+}`
+
+type pet struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+func TestCall(t *testing.T) {
+	doc := parse(t, pets)
+	server, err := mock.New(doc, mock.Options{Router: router.Options{IgnoreHost: true}})
+	if err != nil {
+		t.Fatalf("mock.New() error = %v", err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	c, err := New(doc, Options{BaseURL: ts.URL + "/v1"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := c.Operations(); !slices.Equal(got, []string{"createPet", "getPet", "listPets"}) {
+		t.Errorf("Operations() = %v", got)
+	}
+	ctx := context.Background()
+
+	var list []pet
+	if _, err := c.Call(ctx, "listPets", map[string]any{"limit": 10}, nil, &list); err != nil {
+		t.Fatalf("Call(listPets) error = %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "Rex" {
+		t.Errorf("Unexpected pets %v", list)
+	}
+	var created pet
+	resp, err := c.Call(ctx, "createPet", nil, pet{Name: "Tom"}, &created)
+	if err != nil || resp.StatusCode != 201 || created.Name == "" {
+		t.Errorf("Call(createPet) = %v %v, %v", resp, created, err)
+	}
+
+	tests := []struct {
+		name   string
+		id     string
+		params map[string]any
+		body   any
+		want   error
+	}{
+		{"unknown", "deletePet", nil, nil, ErrUnknownOperation},
+		{"missing parameter", "getPet", nil, nil, ErrMissingParameter},
+		{"missing body", "createPet", nil, nil, ErrMissingParameter},
+	}
+	for _, tt := range tests {
+		if _, err := c.Call(ctx, tt.id, tt.params, tt.body, nil); !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	var invalid validate.Errors
+	if _, err := c.Call(ctx, "listPets", map[string]any{"limit": 1000}, nil, nil); !errors.As(err, &invalid) || invalid[0].Name != "limit" {
+		t.Errorf("Expected the request to be invalid, got %v", err)
+	}
+	if _, err := c.Call(ctx, "getPet", map[string]any{"petId": 1}, nil, nil); err != nil {
+		t.Errorf("Call(getPet) error = %v", err)
+	}
+
+	c, err = New(doc, Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	req, err := c.Request(ctx, "getPet", map[string]any{"petId": 7}, nil)
+	if err != nil || req.URL.String() != "https://eu.example.com/v1/pets/7" {
+		t.Errorf("Expected the URL of the first server, got %v %v", req, err)
+	}
+}
+
+func TestRequest(t *testing.T) {
+	c, err := New(parse(t, pets), Options{BaseURL: "http://localhost/v1", Header: http.Header{"Authorization": {"Bearer x"}}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	params := map[string]any{"limit": 5, "tags": []string{"a b", "c"}, "filter": map[string]any{"age": 3}, "kinds": []string{"a", "b"}, "ids": []string{"x", "y"}, "X-Trace": "t1"}
+	req, err := c.Request(context.Background(), "listPets", params, nil)
+	if err != nil {
+		t.Fatalf("Request() error = %v", err)
+	}
+	if want := "http://localhost/v1/pets?limit=5&tags=a+b,c&filter%5Bage%5D=3&kinds=a,b&ids=x&ids=y"; req.URL.String() != want {
+		t.Errorf("URL = %s, want %s", req.URL, want)
+	}
+	if req.Header.Get("X-Trace") != "t1" || req.Header.Get("Authorization") != "Bearer x" {
+		t.Errorf("Unexpected headers %v", req.Header)
+	}
+	req, err = c.Request(context.Background(), "createPet", nil, map[string]any{"name": "Tom"})
+	if err != nil || req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected request %v, %v", req, err)
+	}
+}
+
+func TestCallResponse(t *testing.T) {
+	var status int
+	var body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+	c, err := New(parse(t, pets), Options{BaseURL: ts.URL})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+	params := map[string]any{"petId": 1}
+
+	status, body = 200, `{"id": "one"}`
+	var invalid validate.Errors
+	if _, err := c.Call(ctx, "getPet", params, nil, nil); !errors.As(err, &invalid) || len(invalid) != 2 {
+		t.Errorf("Expected an invalid response, got %v", err)
+	}
+	status, body = 500, `{"message": "boom"}`
+	var statusErr *StatusError
+	if _, err := c.Call(ctx, "getPet", params, nil, nil); !errors.As(err, &invalid) || invalid[0].In != "status" {
+		t.Errorf("Expected an undocumented status, got %v", err)
+	} else if !errors.As(err, &statusErr) || statusErr.StatusCode != 500 || string(statusErr.Body) != body {
+		t.Errorf("Expected the status error along, got %v", err)
+	}
+	status, body = 404, ``
+	if _, err := c.Call(ctx, "getPet", params, nil, nil); !errors.As(err, &statusErr) || statusErr.StatusCode != 404 {
+		t.Errorf("Expected a status error, got %v", err)
+	}
+
+	c, _ = New(parse(t, pets), Options{BaseURL: ts.URL, SkipValidation: true})
+	status, body = 200, `{"id": 2, "name": "Tom"}`
+	var raw string
+	if _, err := c.Call(ctx, "getPet", params, nil, &raw); err != nil || raw != body {
+		t.Errorf("Expected the raw body, got %q, %v", raw, err)
+	}
+	for _, id := range []string{"..", "."} {
+		if _, err := c.Request(ctx, "getPet", map[string]any{"petId": id}, nil); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("Expected a dot segment %q rejected, got %v", id, err)
+		}
+	}
+	req, err := c.Request(ctx, "getPet", map[string]any{"petId": "a/../b"}, nil)
+	if err != nil || req.URL.EscapedPath() != "/pets/a%2F..%2Fb" {
+		t.Errorf("Expected the value escaped, got %v, %v", req, err)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+	"github.com/genelet/oas/validate"
+)
+
+// pair is a serialized parameter: its name, which differs from the name of
+// the parameter for exploded query objects, and its value
+type pair struct {
+	name, value string
+}
+
+// field is a property of an object parameter: its key, raw and escaped,
+// and its escaped value
+type field struct {
+	raw, key, value string
+}
+
+func noEscape(s string) string { return s }
+
+// serialize serializes the value of a parameter by its style, escaping its
+// primitive values but not the separators of the style. A parameter with
+// content is serialized as JSON. Path, header and cookie parameters have a
+// single pair; query parameters may have several.
+func serialize(p unified.Parameter, value any, escape func(string) string) []pair {
+	name, in := p.GetName(), p.GetIn()
+	for mediaType := range p.GetContent() {
+		if validate.IsJSON(mediaType) {
+			data, _ := json.Marshal(value)
+			return []pair{{name, escape(string(data))}}
+		}
+	}
+	style := p.GetStyle()
+	if style == "" {
+		style = "simple"
+		if in == "query" || in == "cookie" {
+			style = "form"
+		}
+	}
+	explode := style == "form"
+	if p.HasExplode() {
+		explode = p.GetExplode()
+	}
+
+	value = normalize(value)
+	var items []string
+	var object []field
+	switch x := value.(type) {
+	case []any:
+		for _, item := range x {
+			items = append(items, escape(primitive(item)))
+		}
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(x)) {
+			object = append(object, field{key, escape(key), escape(primitive(x[key]))})
+		}
+	default:
+		scalar := escape(primitive(value))
+		switch style {
+		case "label":
+			scalar = "." + scalar
+		case "matrix":
+			scalar = ";" + name + "=" + scalar
+		}
+		return []pair{{name, scalar}}
+	}
+
+	// flat lists the keys and values of an object, key and value separated
+	// by one separator and properties by another
+	flat := func(keySeparator, separator string) string {
+		var parts []string
+		for _, f := range object {
+			parts = append(parts, f.key+keySeparator+f.value)
+		}
+		return strings.Join(parts, separator)
+	}
+	switch style {
+	case "form":
+		switch {
+		case object != nil && explode:
+			var pairs []pair
+			for _, f := range object {
+				pairs = append(pairs, pair{f.raw, f.value})
+			}
+			return pairs
+		case object != nil:
+			return []pair{{name, flat(",", ",")}}
+		case explode:
+			var pairs []pair
+			for _, item := range items {
+				pairs = append(pairs, pair{name, item})
+			}
+			return pairs
+		}
+		return []pair{{name, strings.Join(items, ",")}}
+	case "spaceDelimited":
+		return []pair{{name, strings.Join(items, escape(" "))}}
+	case "pipeDelimited":
+		return []pair{{name, strings.Join(items, "|")}}
+	case "deepObject":
+		var pairs []pair
+		for _, f := range object {
+			pairs = append(pairs, pair{name + "[" + f.raw + "]", f.value})
+		}
+		return pairs
+	case "label":
+		switch {
+		case object != nil && explode:
+			return []pair{{name, "." + flat("=", ".")}}
+		case object != nil:
+			return []pair{{name, "." + flat(",", ",")}}
+		case explode:
+			return []pair{{name, "." + strings.Join(items, ".")}}
+		}
+		return []pair{{name, "." + strings.Join(items, ",")}}
+	case "matrix":
+		switch {
+		case object != nil && explode:
+			return []pair{{name, ";" + flat("=", ";")}}
+		case object != nil:
+			return []pair{{name, ";" + name + "=" + flat(",", ",")}}
+		case explode:
+			var parts []string
+			for _, item := range items {
+				parts = append(parts, ";"+name+"="+item)
+			}
+			return []pair{{name, strings.Join(parts, "")}}
+		}
+		return []pair{{name, ";" + name + "=" + strings.Join(items, ",")}}
+	}
+	// simple
+	if object != nil && explode {
+		return []pair{{name, flat("=", ",")}}
+	}
+	if object != nil {
+		return []pair{{name, flat(",", ",")}}
+	}
+	return []pair{{name, strings.Join(items, ",")}}
+}
+
+// primitive serializes a primitive value, and other values as JSON
+func primitive(value any) string {
+	switch x := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// normalize converts a value to generic JSON, such as structs to maps and
+// ints to float64
+func normalize(value any) any {
+	switch value.(type) {
+	case nil, string, float64, bool, []any, map[string]any:
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return value
+	}
+	return generic
+}
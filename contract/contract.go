@@ -0,0 +1,243 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package contract replays the operations of an OpenAPI document against a
+// live server and checks that its responses conform to the document.
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/genelet/oas/client"
+	"github.com/genelet/oas/fake"
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+	"github.com/genelet/oas/validate"
+)
+
+// Options configures a contract test Run
+type Options struct {
+	// BaseURL is the server under test, such as http://localhost:8080/v1
+	BaseURL string
+	// HTTPClient sends the requests; http.DefaultClient when nil
+	HTTPClient *http.Client
+	// Header is added to every request, such as an Authorization header
+	Header http.Header
+	// Seed seeds the inputs generated for operations without Inputs
+	Seed uint64
+	// Inputs are the inputs of operations by operationId, or by method and
+	// path as "GET /pets" for operations without one; the inputs of other
+	// operations are generated from their examples and schemas
+	Inputs map[string]Input
+	// Operations restricts the run to these operations, by the keys of
+	// Inputs; every operation is run when empty. Skip operations that the
+	// server under test must not perform.
+	Operations []string
+}
+
+// Input is the input of an operation
+type Input struct {
+	// Params are the path, query, header and cookie parameters by name
+	Params map[string]any
+	Body   any
+	// Status is the status the response must have; any documented status
+	// passes when zero
+	Status int
+}
+
+// Report is the outcome of a contract test run
+type Report struct {
+	Passed  int      `json:"passed"`
+	Failed  int      `json:"failed"`
+	Results []Result `json:"results"`
+}
+
+// Result is the outcome of an operation
+type Result struct {
+	Operation string        `json:"operation"`
+	Method    string        `json:"method"`
+	Path      string        `json:"path"`
+	Passed    bool          `json:"passed"`
+	Status    int           `json:"status,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	// Errors are why the operation failed: a request that could not be
+	// built or sent, an unexpected status, or where the response does not
+	// conform
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Run calls each operation of a document against Options.BaseURL, with the
+// inputs given or generated, and checks that the status of each response is
+// documented and that its headers and body match their schemas. Operations
+// run one after the other, sorted by key.
+func Run(ctx context.Context, doc unified.Document, opts Options) (*Report, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to test")
+	}
+	if opts.BaseURL == "" {
+		return nil, fmt.Errorf("%w: no base URL to test", client.ErrNoServer)
+	}
+	resolved, err := unified.Resolve(doc)
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(resolved, client.Options{BaseURL: opts.BaseURL, HTTPClient: opts.HTTPClient, Header: opts.Header})
+	if err != nil {
+		return nil, err
+	}
+	g := fake.New(fake.Options{Seed: opts.Seed, Examples: true, Direction: validate.DirectionRequest})
+
+	ops := operations(resolved)
+	keys := opts.Operations
+	if len(keys) == 0 {
+		keys = slices.Sorted(maps.Keys(ops))
+	}
+	report := &Report{}
+	for _, key := range keys {
+		m, ok := ops[key]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", client.ErrUnknownOperation, key)
+		}
+		input, ok := opts.Inputs[key]
+		if !ok {
+			input = generate(g, m)
+		}
+		result := run(ctx, c, key, m, input)
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// operations returns the operations of a document by their key
+func operations(doc unified.Document) map[string]*router.Match {
+	ops := make(map[string]*router.Match)
+	for path, item := range doc.GetPaths() {
+		if item == nil {
+			continue
+		}
+		for method, op := range item.GetAllOperations() {
+			key := op.GetOperationID()
+			if key == "" {
+				key = strings.ToUpper(method) + " " + path
+			}
+			ops[key] = &router.Match{Path: path, Method: strings.ToUpper(method), PathItem: item, Operation: op}
+		}
+	}
+	return ops
+}
+
+// generate returns the input of an operation: its required parameters and
+// body, from their examples or generated from their schemas
+func generate(g *fake.Generator, m *router.Match) Input {
+	input := Input{Params: make(map[string]any)}
+	params := validate.Parameters(m)
+	for _, p := range append(slices.Clone(m.PathItem.GetParameters()), m.Operation.GetParameters()...) {
+		if p != nil && p.GetIn() == "formData" {
+			params = append(params, p)
+		}
+	}
+	for _, p := range params {
+		if !p.GetRequired() {
+			continue
+		}
+		if ex := p.GetExample(); ex != nil {
+			input.Params[p.GetName()] = ex
+			continue
+		}
+		if value, err := g.Generate(p.GetSchema()); err == nil {
+			input.Params[p.GetName()] = value
+		}
+	}
+	rb := m.Operation.GetRequestBody()
+	if rb == nil || rb.IsNil() {
+		return input
+	}
+	content := rb.GetContent()
+	types := slices.Sorted(maps.Keys(content))
+	if i := slices.IndexFunc(types, validate.IsJSON); i > 0 {
+		types[0] = types[i]
+	}
+	if len(types) == 0 || content[types[0]] == nil {
+		return input
+	}
+	mt := content[types[0]]
+	switch {
+	case mt.GetExample() != nil:
+		input.Body = mt.GetExample()
+	case mt.GetSchema() != nil && !mt.GetSchema().IsNil():
+		input.Body, _ = g.Generate(mt.GetSchema())
+	}
+	return input
+}
+
+// run calls an operation and checks its response
+func run(ctx context.Context, c *client.Client, key string, m *router.Match, input Input) Result {
+	result := Result{Operation: key, Method: m.Method, Path: m.Path}
+	start := time.Now()
+	resp, err := c.Call(ctx, key, input.Params, input.Body, nil)
+	result.Duration = time.Since(start)
+	if resp != nil {
+		result.Status = resp.StatusCode
+		io.Copy(io.Discard, resp.Body)
+	}
+
+	var statusErr *client.StatusError
+	var invalid validate.Errors
+	switch {
+	case errors.As(err, &invalid):
+		// Without a response, the inputs were invalid
+		prefix := ""
+		if resp == nil {
+			prefix = "request "
+		}
+		for _, e := range invalid {
+			result.Errors = append(result.Errors, prefix+e.Error())
+		}
+	case errors.As(err, &statusErr):
+		// An error status conforms when it is documented
+	case err != nil:
+		result.Errors = append(result.Errors, err.Error())
+	}
+	if input.Status != 0 && resp != nil && resp.StatusCode != input.Status {
+		result.Errors = append(result.Errors, fmt.Sprintf("status %d, expected %d", resp.StatusCode, input.Status))
+	}
+	result.Passed = len(result.Errors) == 0
+	return result
+}
+
+// WriteText writes a report for people: a line per operation, with the
+// errors of those that failed, then the totals
+func WriteText(w io.Writer, report *Report) error {
+	var b strings.Builder
+	for _, r := range report.Results {
+		outcome := "PASS"
+		if !r.Passed {
+			outcome = "FAIL"
+		}
+		fmt.Fprintf(&b, "%s %s (%s %s)", outcome, r.Operation, r.Method, r.Path)
+		if r.Status != 0 {
+			fmt.Fprintf(&b, " %d", r.Status)
+		}
+		b.WriteString("\n")
+		for _, e := range r.Errors {
+			fmt.Fprintf(&b, "    %s\n", e)
+		}
+	}
+	fmt.Fprintf(&b, "%d passed, %d failed\n", report.Passed, report.Failed)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+)
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return doc
+}
+
+const pets = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Pets", "version": "1"},
+	"paths": {
+		"/pets": {
+			"get": {
+				"operationId": "listPets",
+				"parameters": [{"name": "limit", "in": "query", "required": true, "schema": {"type": "integer", "minimum": 1, "maximum": 10}}],
+				"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}}
+			},
+			"post": {
+				"operationId": "createPet",
+				"requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+				"responses": {"201": {"description": "Created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}
+			}
+		},
+		"/pets/{petId}": {
+			"parameters": [{"name": "petId", "in": "path", "required": true, "example": 7, "schema": {"type": "integer"}}],
+			"get": {
+				"operationId": "getPet",
+				"responses": {
+					"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+					"404": {"description": "Not found"}
+				}
+			},
+			"delete": {"responses": {"204": {"description": "Deleted"}}}
+		}
+	},
+	"components": {"schemas": {
+		"Pet": {"type": "object", "required": ["name"], "properties": {"id": {"type": "integer", "readOnly": true}, "name": {"type": "string"}}}
+	}}
+}`
+
+func TestRun(t *testing.T) {
+	var created map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /pets":
+			io.WriteString(w, `[{"id": 1, "name": "Rex"}, {"id": 2}]`)
+		case "POST /pets":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(created)
+		case "GET /pets/7":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	report, err := Run(context.Background(), parse(t, pets), Options{BaseURL: ts.URL})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Passed != 2 || report.Failed != 2 || len(report.Results) != 4 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if created["name"] == nil {
+		t.Errorf("Expected a generated pet to be posted, got %v", created)
+	}
+
+	var b strings.Builder
+	WriteText(&b, report)
+	// Durations vary, and are not written
+	want := `FAIL DELETE /pets/{petId} (DELETE /pets/{petId}) 500
+    status: undocumented status 500
+PASS createPet (POST /pets) 201
+PASS getPet (GET /pets/{petId}) 404
+FAIL listPets (GET /pets) 200
+    body /1: missing required property 'name'
+2 passed, 2 failed
+`
+	if b.String() != want {
+		t.Errorf("WriteText() =\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	report, err = Run(context.Background(), parse(t, pets), Options{
+		BaseURL:    ts.URL,
+		Operations: []string{"getPet", "listPets"},
+		Inputs: map[string]Input{
+			"getPet":   {Params: map[string]any{"petId": 7}, Status: 200},
+			"listPets": {Params: map[string]any{"limit": 100}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	b.Reset()
+	WriteText(&b, report)
+	want = `FAIL getPet (GET /pets/{petId}) 404
+    status 404, expected 200
+FAIL listPets (GET /pets)
+    request query limit: 100 is greater than 10
+0 passed, 2 failed
+`
+	if b.String() != want {
+		t.Errorf("WriteText() =\n%s\nwant:\n%s", b.String(), want)
+	}
+
+	if _, err := Run(context.Background(), parse(t, pets), Options{BaseURL: ts.URL, Operations: []string{"updatePet"}}); err == nil {
+		t.Errorf("Expected an error for an unknown operation")
+	}
+}
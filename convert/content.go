@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"reflect"
+	"sort"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// DefaultMediaType is used when a 2.0 document declares no consumes or produces
+const DefaultMediaType = "application/json"
+
+// EffectiveMediaTypes returns the media types that apply to a 2.0 operation.
+// Operation-level consumes or produces replace the global ones; when neither
+// lists anything, DefaultMediaType is assumed.
+func EffectiveMediaTypes(operation, global []string) []string {
+	mediaTypes := global
+	if operation != nil {
+		mediaTypes = operation
+	}
+	if len(mediaTypes) == 0 {
+		return []string{DefaultMediaType}
+	}
+	return copyStrings(mediaTypes)
+}
+
+// SchemaToContent30 expands a 2.0 style schema and media type list into a
+// 3.0 content map in which every media type shares the schema
+func SchemaToContent30(mediaTypes []string, schema *oa3.Schema) map[string]*oa3.MediaType {
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+	content := make(map[string]*oa3.MediaType, len(mediaTypes))
+	for _, mt := range mediaTypes {
+		content[mt] = &oa3.MediaType{Schema: schema}
+	}
+	return content
+}
+
+// SchemaToContent31 expands a 2.0 style schema and media type list into a
+// 3.1 content map in which every media type shares the schema
+func SchemaToContent31(mediaTypes []string, schema *oa31.Schema) map[string]*oa31.MediaType {
+	if len(mediaTypes) == 0 {
+		return nil
+	}
+	content := make(map[string]*oa31.MediaType, len(mediaTypes))
+	for _, mt := range mediaTypes {
+		content[mt] = &oa31.MediaType{Schema: schema}
+	}
+	return content
+}
+
+// ContentToSchema30 collapses a 3.0 content map into the sorted list of its
+// media types and a single schema, as 2.0 requires. It returns ok false when
+// the media types use different schemas, in which case the schema of the
+// first media type is returned.
+func ContentToSchema30(content map[string]*oa3.MediaType) (mediaTypes []string, schema *oa3.Schema, ok bool) {
+	mediaTypes = ContentMediaTypes30(content)
+	ok = true
+	for i, mt := range mediaTypes {
+		var s *oa3.Schema
+		if content[mt] != nil {
+			s = content[mt].Schema
+		}
+		if i == 0 {
+			schema = s
+		} else if !reflect.DeepEqual(schema, s) {
+			ok = false
+		}
+	}
+	return mediaTypes, schema, ok
+}
+
+// ContentToSchema31 collapses a 3.1 content map into the sorted list of its
+// media types and a single schema, as 2.0 requires. It returns ok false when
+// the media types use different schemas, in which case the schema of the
+// first media type is returned.
+func ContentToSchema31(content map[string]*oa31.MediaType) (mediaTypes []string, schema *oa31.Schema, ok bool) {
+	mediaTypes = ContentMediaTypes31(content)
+	ok = true
+	for i, mt := range mediaTypes {
+		var s *oa31.Schema
+		if content[mt] != nil {
+			s = content[mt].Schema
+		}
+		if i == 0 {
+			schema = s
+		} else if !reflect.DeepEqual(schema, s) {
+			ok = false
+		}
+	}
+	return mediaTypes, schema, ok
+}
+
+// ContentMediaTypes30 returns the media types of a 3.0 content map in sorted order
+func ContentMediaTypes30(content map[string]*oa3.MediaType) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	mediaTypes := make([]string, 0, len(content))
+	for mt := range content {
+		mediaTypes = append(mediaTypes, mt)
+	}
+	sort.Strings(mediaTypes)
+	return mediaTypes
+}
+
+// ContentMediaTypes31 returns the media types of a 3.1 content map in sorted order
+func ContentMediaTypes31(content map[string]*oa31.MediaType) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	mediaTypes := make([]string, 0, len(content))
+	for mt := range content {
+		mediaTypes = append(mediaTypes, mt)
+	}
+	sort.Strings(mediaTypes)
+	return mediaTypes
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+func TestEffectiveMediaTypes(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation []string
+		global    []string
+		want      []string
+	}{
+		{"default", nil, nil, []string{DefaultMediaType}},
+		{"global", nil, []string{"application/xml"}, []string{"application/xml"}},
+		{"operation overrides", []string{"text/plain"}, []string{"application/xml"}, []string{"text/plain"}},
+		{"operation clears", []string{}, []string{"application/xml"}, []string{DefaultMediaType}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EffectiveMediaTypes(tc.operation, tc.global); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestContentRoundTrip30(t *testing.T) {
+	schema := &oa3.Schema{Type: "object"}
+	content := SchemaToContent30([]string{"application/xml", "application/json"}, schema)
+	if len(content) != 2 || content["application/json"].Schema != schema {
+		t.Fatalf("Expected two media types sharing the schema, got %v", content)
+	}
+
+	mediaTypes, got, ok := ContentToSchema30(content)
+	if !ok || got != schema {
+		t.Errorf("Expected the shared schema back, got %+v ok=%v", got, ok)
+	}
+	if !reflect.DeepEqual(mediaTypes, []string{"application/json", "application/xml"}) {
+		t.Errorf("Expected sorted media types, got %v", mediaTypes)
+	}
+
+	content["text/plain"] = &oa3.MediaType{Schema: &oa3.Schema{Type: "string"}}
+	if _, _, ok := ContentToSchema30(content); ok {
+		t.Error("Expected differing schemas to be reported")
+	}
+}
+
+func TestContentRoundTrip31(t *testing.T) {
+	schema := &oa31.Schema{Type: &oa31.StringOrStringArray{String: "object"}}
+	content := SchemaToContent31([]string{"application/json"}, schema)
+
+	mediaTypes, got, ok := ContentToSchema31(content)
+	if !ok || got != schema || len(mediaTypes) != 1 {
+		t.Errorf("Expected the schema back, got %v %+v ok=%v", mediaTypes, got, ok)
+	}
+	if SchemaToContent31(nil, schema) != nil {
+		t.Error("Expected nil content without media types")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package convert translates OpenAPI documents between specification versions.
+// Conversions are best-effort: every construct that cannot be represented
+// exactly in the target version is recorded in a ConversionReport.
+//
+// The exclusive bound helpers translate between the Draft 4 boolean
+// exclusiveMinimum and exclusiveMaximum of 2.0 and 3.0 and the numeric ones
+// of 3.1. ExclusiveBoundsToNumeric rewrites a whole JSON document, so that 3.1
+// documents written with 3.0 bounds, such as the schema-validation examples
+// of openapi31, can be parsed as 3.1.
+package convert
+
+import (
+	"strings"
+)
+
+// pointerEscaper escapes a reference token per RFC 6901
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// ptr appends reference tokens to a JSON pointer
+func ptr(base string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(t))
+	}
+	return b.String()
+}
+
+// copyExtensions returns a shallow copy of an extensions map
+func copyExtensions(ext map[string]any) map[string]any {
+	if len(ext) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(ext))
+	for k, v := range ext {
+		out[k] = v
+	}
+	return out
+}
+
+// copyStrings returns a copy of a string slice
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	out := make([]string, len(s))
+	copy(out, s)
+	return out
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"strings"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+const (
+	definitionsPrefix = "#/definitions/"
+	schemasPrefix     = "#/components/schemas/"
+)
+
+// InferDiscriminatorMapping infers the 3.x discriminator mapping of the 2.0
+// definition named base. In 2.0 the discriminator value is the name of the
+// subtype definition, and subtypes are the definitions whose allOf refers to
+// base. It returns nil when no subtype is found.
+func InferDiscriminatorMapping(definitions map[string]*oa2.Schema, base string) map[string]string {
+	var mapping map[string]string
+	for name, def := range definitions {
+		if name == base || def == nil {
+			continue
+		}
+		for _, s := range def.AllOf {
+			if s != nil && s.Ref == definitionsPrefix+base {
+				if mapping == nil {
+					mapping = make(map[string]string)
+				}
+				mapping[name] = schemasPrefix + name
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// DiscriminatorToObject30 converts the string discriminator of the 2.0
+// definition named base into a 3.0 Discriminator object, inferring its
+// mapping from the other definitions. It returns nil when the definition
+// has no discriminator.
+func DiscriminatorToObject30(definitions map[string]*oa2.Schema, base string) *oa3.Discriminator {
+	def := definitions[base]
+	if def == nil || def.Discriminator == "" {
+		return nil
+	}
+	return &oa3.Discriminator{
+		PropertyName: def.Discriminator,
+		Mapping:      InferDiscriminatorMapping(definitions, base),
+	}
+}
+
+// DiscriminatorToObject31 converts the string discriminator of the 2.0
+// definition named base into a 3.1 Discriminator object, inferring its
+// mapping from the other definitions. It returns nil when the definition
+// has no discriminator.
+func DiscriminatorToObject31(definitions map[string]*oa2.Schema, base string) *oa31.Discriminator {
+	def := definitions[base]
+	if def == nil || def.Discriminator == "" {
+		return nil
+	}
+	return &oa31.Discriminator{
+		PropertyName: def.Discriminator,
+		Mapping:      InferDiscriminatorMapping(definitions, base),
+	}
+}
+
+// DiscriminatorToString30 converts a 3.0 Discriminator object into the 2.0
+// property name. It returns ok false when the mapping or extensions cannot
+// be expressed in 2.0, where every value must be the name of its schema.
+func DiscriminatorToString30(d *oa3.Discriminator) (propertyName string, ok bool) {
+	if d == nil {
+		return "", true
+	}
+	return d.PropertyName, implicitMapping(d.Mapping) && len(d.Extensions) == 0
+}
+
+// DiscriminatorToString31 converts a 3.1 Discriminator object into the 2.0
+// property name. It returns ok false when the mapping or extensions cannot
+// be expressed in 2.0, where every value must be the name of its schema.
+func DiscriminatorToString31(d *oa31.Discriminator) (propertyName string, ok bool) {
+	if d == nil {
+		return "", true
+	}
+	return d.PropertyName, implicitMapping(d.Mapping) && len(d.Extensions) == 0
+}
+
+// implicitMapping returns true if every mapping value names the local schema
+// that matches its key, which is what 2.0 assumes
+func implicitMapping(mapping map[string]string) bool {
+	for value, ref := range mapping {
+		name := ref
+		if strings.HasPrefix(ref, "#/") {
+			name = ref[strings.LastIndex(ref, "/")+1:]
+			if ref != schemasPrefix+name {
+				return false
+			}
+		}
+		if name != value {
+			return false
+		}
+	}
+	return true
+}
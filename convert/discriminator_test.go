@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+func TestDiscriminatorToObject(t *testing.T) {
+	definitions := map[string]*oa2.Schema{
+		"Pet": {Type: "object", Discriminator: "petType", Required: []string{"petType"}},
+		"Cat": {AllOf: []*oa2.Schema{{Ref: "#/definitions/Pet"}, {Type: "object"}}},
+		"Dog": {AllOf: []*oa2.Schema{{Ref: "#/definitions/Pet"}}},
+		"Toy": {Type: "object"},
+	}
+	want := map[string]string{
+		"Cat": "#/components/schemas/Cat",
+		"Dog": "#/components/schemas/Dog",
+	}
+
+	d30 := DiscriminatorToObject30(definitions, "Pet")
+	if d30 == nil || d30.PropertyName != "petType" || !reflect.DeepEqual(d30.Mapping, want) {
+		t.Errorf("Expected petType with inferred mapping, got %+v", d30)
+	}
+	d31 := DiscriminatorToObject31(definitions, "Pet")
+	if d31 == nil || d31.PropertyName != "petType" || !reflect.DeepEqual(d31.Mapping, want) {
+		t.Errorf("Expected petType with inferred mapping, got %+v", d31)
+	}
+	if DiscriminatorToObject30(definitions, "Toy") != nil {
+		t.Error("Expected nil discriminator for a schema without one")
+	}
+	if m := InferDiscriminatorMapping(definitions, "Toy"); m != nil {
+		t.Errorf("Expected no mapping without subtypes, got %v", m)
+	}
+}
+
+func TestDiscriminatorToString(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping map[string]string
+		ok      bool
+	}{
+		{"no mapping", nil, true},
+		{"implicit refs", map[string]string{"Cat": "#/components/schemas/Cat"}, true},
+		{"schema names", map[string]string{"Cat": "Cat"}, true},
+		{"renamed value", map[string]string{"cat": "#/components/schemas/Cat"}, false},
+		{"external ref", map[string]string{"Cat": "other.yaml#/Cat"}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			name, ok := DiscriminatorToString30(&oa3.Discriminator{PropertyName: "petType", Mapping: tc.mapping})
+			if name != "petType" || ok != tc.ok {
+				t.Errorf("Expected petType ok=%v, got %q ok=%v", tc.ok, name, ok)
+			}
+			name, ok = DiscriminatorToString31(&oa31.Discriminator{PropertyName: "petType", Mapping: tc.mapping})
+			if name != "petType" || ok != tc.ok {
+				t.Errorf("Expected petType ok=%v, got %q ok=%v", tc.ok, name, ok)
+			}
+		})
+	}
+}
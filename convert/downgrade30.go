@@ -0,0 +1,877 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// Downgrade30To20 converts an OpenAPI 3.0 document to Swagger 2.0.
+// The first server becomes host, basePath and schemes, request bodies become
+// body or formData parameters, content maps become consumes and produces,
+// and components move to definitions, parameters, responses and
+// securityDefinitions. Everything that could not be represented is listed in
+// the report.
+func Downgrade30To20(doc *oa3.OpenAPI) (*oa2.Swagger, *ConversionReport) {
+	report := &ConversionReport{}
+	if doc == nil {
+		return nil, report
+	}
+	d := &downgrader30{src: doc, report: report, inlining: make(map[string]bool)}
+	return d.document(), report
+}
+
+// Downgrade31To20 converts an OpenAPI 3.1 document to Swagger 2.0 by way of
+// 3.0. Issues of the second stage point into the intermediate 3.0 document,
+// whose layout matches the 3.1 source. A nil opts uses the defaults.
+func Downgrade31To20(doc *oa31.OpenAPI, opts *DowngradeOptions) (*oa2.Swagger, *ConversionReport) {
+	doc30, report := Downgrade31To30(doc, opts)
+	doc20, report20 := Downgrade30To20(doc30)
+	report.merge(report20)
+	return doc20, report
+}
+
+// refPrefixes30 maps local 3.x reference prefixes to their 2.0 locations
+var refPrefixes30 = [][2]string{
+	{"#/components/schemas/", "#/definitions/"},
+	{"#/components/parameters/", "#/parameters/"},
+	{"#/components/responses/", "#/responses/"},
+}
+
+// downgradeRef30 rewrites a 3.x reference to the matching 2.0 location.
+// References into other documents keep their document part.
+func downgradeRef30(ref string) string {
+	i := strings.Index(ref, "#/")
+	if i < 0 {
+		return ref
+	}
+	for _, prefix := range refPrefixes30 {
+		if strings.HasPrefix(ref[i:], prefix[0]) {
+			return ref[:i] + prefix[1] + ref[i+len(prefix[0]):]
+		}
+	}
+	return ref
+}
+
+const componentsRequestBodiesPrefix = "#/components/requestBodies/"
+
+// isFormMediaType returns true for media types whose 2.0 equivalent is formData
+func isFormMediaType(mt string) bool {
+	return mt == "application/x-www-form-urlencoded" || mt == "multipart/form-data"
+}
+
+type downgrader30 struct {
+	src      *oa3.OpenAPI
+	report   *ConversionReport
+	inlining map[string]bool
+}
+
+func (d *downgrader30) document() *oa2.Swagger {
+	src := d.src
+	out := &oa2.Swagger{
+		Swagger:      "2.0",
+		Info:         d.info(src.Info),
+		Paths:        d.paths(src.Paths),
+		Security:     d.security(src.Security),
+		Tags:         d.tags(src.Tags),
+		ExternalDocs: d.externalDocs(src.ExternalDocs),
+		Extensions:   copyExtensions(src.Extensions),
+	}
+	d.servers(src.Servers, out)
+	d.components(src.Components, out)
+	return out
+}
+
+func (d *downgrader30) info(info *oa3.Info) *oa2.Info {
+	if info == nil {
+		return nil
+	}
+	out := &oa2.Info{
+		Title:          info.Title,
+		Description:    info.Description,
+		TermsOfService: info.TermsOfService,
+		Version:        info.Version,
+		Extensions:     copyExtensions(info.Extensions),
+	}
+	if info.Contact != nil {
+		out.Contact = &oa2.Contact{
+			Name:       info.Contact.Name,
+			URL:        info.Contact.URL,
+			Email:      info.Contact.Email,
+			Extensions: copyExtensions(info.Contact.Extensions),
+		}
+	}
+	if info.License != nil {
+		out.License = &oa2.License{
+			Name:       info.License.Name,
+			URL:        info.License.URL,
+			Extensions: copyExtensions(info.License.Extensions),
+		}
+	}
+	return out
+}
+
+// serverURL substitutes the default values of server variables into the url
+func (d *downgrader30) serverURL(s *oa3.Server, p string) string {
+	u := s.URL
+	for name, v := range s.Variables {
+		if v == nil {
+			continue
+		}
+		u = strings.ReplaceAll(u, "{"+name+"}", v.Default)
+		if len(v.Enum) > 1 {
+			d.report.add(ptr(p, "variables", name), SeverityWarning, "server variables are not supported in 2.0; the default value was used")
+		}
+	}
+	return u
+}
+
+// servers sets host, basePath and schemes from the servers that share the
+// host and path of the first one
+func (d *downgrader30) servers(servers []*oa3.Server, out *oa2.Swagger) {
+	var host, basePath string
+	for i, s := range servers {
+		if s == nil {
+			continue
+		}
+		p := ptr("/servers", fmt.Sprint(i))
+		parsed, err := url.Parse(d.serverURL(s, p))
+		if err != nil {
+			d.report.add(p+"/url", SeverityError, fmt.Sprintf("server url could not be parsed: %v", err))
+			continue
+		}
+		if host == "" && basePath == "" && out.Schemes == nil {
+			host, basePath = parsed.Host, parsed.Path
+		} else if parsed.Host != host || parsed.Path != basePath {
+			d.report.add(p, SeverityError, "2.0 supports a single host and basePath; the server was dropped")
+			continue
+		}
+		if parsed.Scheme != "" {
+			out.Schemes = append(out.Schemes, parsed.Scheme)
+		}
+		if s.Description != "" {
+			d.report.add(p+"/description", SeverityWarning, "server description is not supported in 2.0 and was dropped")
+		}
+	}
+	out.Host = host
+	if basePath != "" && basePath != "/" {
+		out.BasePath = strings.TrimSuffix(basePath, "/")
+	}
+}
+
+// schemes returns the schemes of operation or path servers, which in 2.0
+// can only differ from the document in their scheme
+func (d *downgrader30) schemes(servers []*oa3.Server, p string) []string {
+	if len(servers) == 0 {
+		return nil
+	}
+	var schemes []string
+	for i, s := range servers {
+		if s == nil {
+			continue
+		}
+		if parsed, err := url.Parse(d.serverURL(s, ptr(p, fmt.Sprint(i)))); err == nil && parsed.Scheme != "" {
+			schemes = append(schemes, parsed.Scheme)
+		}
+	}
+	d.report.add(p, SeverityWarning, "servers below the document level are not supported in 2.0; only their schemes were kept")
+	return schemes
+}
+
+func (d *downgrader30) paths(paths *oa3.Paths) *oa2.Paths {
+	out := &oa2.Paths{}
+	if paths == nil {
+		return out
+	}
+	out.Extensions = copyExtensions(paths.Extensions)
+	if paths.Paths != nil {
+		out.Paths = make(map[string]*oa2.PathItem, len(paths.Paths))
+		for key, item := range paths.Paths {
+			out.Paths[key] = d.pathItem(item, ptr("/paths", key))
+		}
+	}
+	return out
+}
+
+func (d *downgrader30) pathItem(item *oa3.PathItem, p string) *oa2.PathItem {
+	if item == nil {
+		return nil
+	}
+	out := &oa2.PathItem{
+		Ref:        item.Ref,
+		Parameters: d.parameters(item.Parameters, p+"/parameters"),
+		Extensions: copyExtensions(item.Extensions),
+	}
+	if item.Summary != "" {
+		d.report.add(p+"/summary", SeverityWarning, "path item summary is not supported in 2.0 and was dropped")
+	}
+	if item.Description != "" {
+		d.report.add(p+"/description", SeverityWarning, "path item description is not supported in 2.0 and was dropped")
+	}
+	schemes := d.schemes(item.Servers, p+"/servers")
+	out.Get = d.operation(item.Get, schemes, p+"/get")
+	out.Put = d.operation(item.Put, schemes, p+"/put")
+	out.Post = d.operation(item.Post, schemes, p+"/post")
+	out.Delete = d.operation(item.Delete, schemes, p+"/delete")
+	out.Options = d.operation(item.Options, schemes, p+"/options")
+	out.Head = d.operation(item.Head, schemes, p+"/head")
+	out.Patch = d.operation(item.Patch, schemes, p+"/patch")
+	if item.Trace != nil {
+		d.report.add(p+"/trace", SeverityError, "trace operations are not supported in 2.0 and were dropped")
+	}
+	return out
+}
+
+func (d *downgrader30) operation(op *oa3.Operation, schemes []string, p string) *oa2.Operation {
+	if op == nil {
+		return nil
+	}
+	out := &oa2.Operation{
+		Tags:         copyStrings(op.Tags),
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: d.externalDocs(op.ExternalDocs),
+		OperationID:  op.OperationID,
+		Parameters:   d.parameters(op.Parameters, p+"/parameters"),
+		Deprecated:   op.Deprecated,
+		Security:     d.security(op.Security),
+		Schemes:      schemes,
+		Extensions:   copyExtensions(op.Extensions),
+	}
+	if len(op.Servers) > 0 {
+		out.Schemes = d.schemes(op.Servers, p+"/servers")
+	}
+	if op.RequestBody != nil {
+		params, consumes := d.requestBody(op.RequestBody, p+"/requestBody")
+		out.Parameters = append(out.Parameters, params...)
+		out.Consumes = consumes
+	}
+	out.Responses, out.Produces = d.responses(op.Responses, p+"/responses")
+	if len(op.Callbacks) > 0 {
+		d.report.add(p+"/callbacks", SeverityError, "callbacks are not supported in 2.0 and were dropped")
+	}
+	return out
+}
+
+func (d *downgrader30) security(reqs []oa3.SecurityRequirement) []oa2.SecurityRequirement {
+	if reqs == nil {
+		return nil
+	}
+	out := make([]oa2.SecurityRequirement, 0, len(reqs))
+	for _, req := range reqs {
+		converted := make(oa2.SecurityRequirement, len(req))
+		for name, scopes := range req {
+			converted[name] = copyStrings(scopes)
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
+func (d *downgrader30) parameters(params []*oa3.Parameter, p string) []*oa2.Parameter {
+	if params == nil {
+		return nil
+	}
+	out := make([]*oa2.Parameter, 0, len(params))
+	for i, param := range params {
+		if converted := d.parameter(param, ptr(p, fmt.Sprint(i))); converted != nil {
+			out = append(out, converted)
+		}
+	}
+	return out
+}
+
+// parameter converts a 3.0 parameter, or returns nil when 2.0 cannot express it
+func (d *downgrader30) parameter(param *oa3.Parameter, p string) *oa2.Parameter {
+	if param == nil {
+		return nil
+	}
+	if param.IsReference() {
+		out := oa2.NewParameterReference(downgradeRef30(param.Ref))
+		out.Extensions = copyExtensions(param.Extensions)
+		return out
+	}
+	if param.In == "cookie" {
+		d.report.add(p, SeverityError, "cookie parameters are not supported in 2.0 and were dropped")
+		return nil
+	}
+
+	schema := param.Schema
+	if schema == nil && len(param.Content) > 0 {
+		mediaTypes := ContentMediaTypes30(param.Content)
+		if mt := param.Content[mediaTypes[0]]; mt != nil {
+			schema = mt.Schema
+		}
+		d.report.add(p+"/content", SeverityWarning, "parameter content is not supported in 2.0; its schema was used")
+	}
+
+	out := &oa2.Parameter{
+		Name:            param.Name,
+		In:              param.In,
+		Description:     param.Description,
+		Required:        param.Required,
+		AllowEmptyValue: param.AllowEmptyValue,
+		Extensions:      copyExtensions(param.Extensions),
+	}
+	d.simpleType(schema, (*simpleFields)(out), p+"/schema")
+	if param.Deprecated {
+		d.report.add(p+"/deprecated", SeverityWarning, "deprecated parameters are not supported in 2.0")
+	}
+	if param.Example != nil || len(param.Examples) > 0 {
+		d.report.add(p+"/examples", SeverityWarning, "parameter examples are not supported in 2.0 and were dropped")
+	}
+	if out.Type == "array" {
+		explode := param.Style == "" || param.Style == "form"
+		if param.Explode != nil {
+			explode = *param.Explode
+		}
+		if cf, ok := StyleToCollectionFormat(param.Style, explode, param.In); ok {
+			if cf != "csv" {
+				out.CollectionFormat = cf
+			}
+		} else {
+			d.report.add(p+"/style", SeverityError, fmt.Sprintf("style %s has no 2.0 collectionFormat and was dropped", param.Style))
+		}
+	} else if param.Style == "deepObject" || param.Style == "matrix" || param.Style == "label" {
+		d.report.add(p+"/style", SeverityError, fmt.Sprintf("style %s is not supported in 2.0 and was dropped", param.Style))
+	}
+	return out
+}
+
+// simpleFields is the shape shared by 2.0 non-body parameters, headers and items
+type simpleFields oa2.Parameter
+
+// simpleType fills the type fields of a parameter, header or items object from a schema
+func (d *downgrader30) simpleType(s *oa3.Schema, out *simpleFields, p string) {
+	if s == nil {
+		out.Type = "string"
+		d.report.add(p, SeverityWarning, "missing schema was approximated with type string")
+		return
+	}
+	if s.Ref != "" {
+		d.report.add(p+"/$ref", SeverityError, "schema references are not supported outside body parameters in 2.0 and were dropped")
+	}
+	out.Type = s.Type
+	out.Format = s.Format
+	out.Default = s.Default
+	out.Enum = s.Enum
+	out.Maximum = copyFloat(s.Maximum)
+	out.ExclusiveMaximum = s.ExclusiveMaximum
+	out.Minimum = copyFloat(s.Minimum)
+	out.ExclusiveMinimum = s.ExclusiveMinimum
+	out.MaxLength = copyInt(s.MaxLength)
+	out.MinLength = copyInt(s.MinLength)
+	out.Pattern = s.Pattern
+	out.MaxItems = copyInt(s.MaxItems)
+	out.MinItems = copyInt(s.MinItems)
+	out.UniqueItems = s.UniqueItems
+	out.MultipleOf = copyFloat(s.MultipleOf)
+	switch s.Type {
+	case "":
+		out.Type = "string"
+		d.report.add(p+"/type", SeverityWarning, "schema without a type was approximated with type string")
+	case "object":
+		out.Type = "string"
+		d.report.add(p+"/type", SeverityError, "object values are not supported outside body parameters in 2.0 and were approximated with type string")
+	case "array":
+		out.Items = d.items(s.Items, p+"/items")
+	}
+}
+
+func (d *downgrader30) items(s *oa3.Schema, p string) *oa2.Items {
+	var fields simpleFields
+	d.simpleType(s, &fields, p)
+	return &oa2.Items{
+		Type:             fields.Type,
+		Format:           fields.Format,
+		Items:            fields.Items,
+		Default:          fields.Default,
+		Maximum:          fields.Maximum,
+		ExclusiveMaximum: fields.ExclusiveMaximum,
+		Minimum:          fields.Minimum,
+		ExclusiveMinimum: fields.ExclusiveMinimum,
+		MaxLength:        fields.MaxLength,
+		MinLength:        fields.MinLength,
+		Pattern:          fields.Pattern,
+		MaxItems:         fields.MaxItems,
+		MinItems:         fields.MinItems,
+		UniqueItems:      fields.UniqueItems,
+		Enum:             fields.Enum,
+		MultipleOf:       fields.MultipleOf,
+	}
+}
+
+// resolveRequestBody returns the target of a local request body reference
+func (d *downgrader30) resolveRequestBody(rb *oa3.RequestBody) (*oa3.RequestBody, string) {
+	if !strings.HasPrefix(rb.Ref, componentsRequestBodiesPrefix) {
+		return rb, ""
+	}
+	name := strings.TrimPrefix(rb.Ref, componentsRequestBodiesPrefix)
+	if d.src.Components == nil || d.src.Components.RequestBodies[name] == nil {
+		return rb, name
+	}
+	return d.src.Components.RequestBodies[name], name
+}
+
+// requestBody converts a request body into a body parameter or formData
+// parameters, and returns them with the consumes they require
+func (d *downgrader30) requestBody(rb *oa3.RequestBody, p string) ([]*oa2.Parameter, []string) {
+	target, name := d.resolveRequestBody(rb)
+	if target.IsReference() {
+		d.report.add(p+"/$ref", SeverityError, fmt.Sprintf("request body reference %s could not be resolved", rb.Ref))
+		return nil, nil
+	}
+
+	mediaTypes, schema, ok := ContentToSchema30(target.Content)
+	if !ok {
+		d.report.add(p+"/content", SeverityError, "media types with different schemas are not supported in 2.0; the first schema was used")
+	}
+
+	var form, other []string
+	for _, mt := range mediaTypes {
+		if isFormMediaType(mt) {
+			form = append(form, mt)
+		} else {
+			other = append(other, mt)
+		}
+	}
+	if len(form) > 0 && len(other) > 0 {
+		d.report.add(p+"/content", SeverityError, "form and non-form media types cannot be combined in 2.0; the non-form media types were dropped")
+	}
+	if len(form) > 0 {
+		return d.formParameters(target, schema, p), form
+	}
+
+	// A referenced request body becomes a reference to a body parameter
+	if name != "" && !d.inlining[name] {
+		return []*oa2.Parameter{oa2.NewParameterReference(parametersPrefix + name)}, mediaTypes
+	}
+	return []*oa2.Parameter{d.bodyParameter(target, schema, p)}, mediaTypes
+}
+
+func (d *downgrader30) bodyParameter(rb *oa3.RequestBody, schema *oa3.Schema, p string) *oa2.Parameter {
+	out := &oa2.Parameter{
+		Name:        "body",
+		In:          "body",
+		Description: rb.Description,
+		Required:    rb.Required,
+		Schema:      d.schema(schema, p+"/content/schema"),
+		Extensions:  copyExtensions(rb.Extensions),
+	}
+	if out.Schema == nil {
+		out.Schema = &oa2.Schema{}
+	}
+	return out
+}
+
+// formParameters turns the properties of an object schema into formData parameters
+func (d *downgrader30) formParameters(rb *oa3.RequestBody, schema *oa3.Schema, p string) []*oa2.Parameter {
+	if schema != nil && schema.Ref != "" {
+		d.report.add(p+"/content", SeverityError, "referenced form schemas are not supported in 2.0 and were dropped")
+		return nil
+	}
+	if schema == nil || len(schema.Properties) == 0 {
+		return nil
+	}
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]*oa2.Parameter, 0, len(names))
+	for _, name := range names {
+		prop := schema.Properties[name]
+		param := &oa2.Parameter{Name: name, In: "formData", Required: required[name]}
+		pp := ptr(p, "content", "schema", "properties", name)
+		if prop != nil && prop.Type == "string" && prop.Format == "binary" {
+			param.Type = "file"
+		} else {
+			d.simpleType(prop, (*simpleFields)(param), pp)
+		}
+		if prop != nil {
+			param.Description = prop.Description
+		}
+		out = append(out, param)
+	}
+	return out
+}
+
+// responses converts responses and collects the media types they produce
+func (d *downgrader30) responses(r *oa3.Responses, p string) (*oa2.Responses, []string) {
+	if r == nil {
+		return nil, nil
+	}
+	produces := make(map[string]bool)
+	out := &oa2.Responses{
+		Default:    d.response(r.Default, produces, p+"/default"),
+		Extensions: copyExtensions(r.Extensions),
+	}
+	if r.StatusCode != nil {
+		out.StatusCode = make(map[string]*oa2.Response, len(r.StatusCode))
+		for code, resp := range r.StatusCode {
+			out.StatusCode[code] = d.response(resp, produces, ptr(p, code))
+		}
+	}
+	if len(produces) == 0 {
+		return out, nil
+	}
+	mediaTypes := make([]string, 0, len(produces))
+	for mt := range produces {
+		mediaTypes = append(mediaTypes, mt)
+	}
+	sort.Strings(mediaTypes)
+	return out, mediaTypes
+}
+
+func (d *downgrader30) response(r *oa3.Response, produces map[string]bool, p string) *oa2.Response {
+	if r == nil {
+		return nil
+	}
+	if r.IsReference() {
+		out := oa2.NewResponseReference(downgradeRef30(r.Ref))
+		out.Extensions = copyExtensions(r.Extensions)
+		return out
+	}
+	out := &oa2.Response{
+		Description: r.Description,
+		Extensions:  copyExtensions(r.Extensions),
+	}
+	if r.Headers != nil {
+		out.Headers = make(map[string]*oa2.Header, len(r.Headers))
+		for name, h := range r.Headers {
+			if converted := d.header(h, ptr(p, "headers", name)); converted != nil {
+				out.Headers[name] = converted
+			}
+		}
+	}
+	mediaTypes, schema, ok := ContentToSchema30(r.Content)
+	if !ok {
+		d.report.add(p+"/content", SeverityError, "media types with different schemas are not supported in 2.0; the first schema was used")
+	}
+	out.Schema = d.schema(schema, p+"/content/schema")
+	for _, mt := range mediaTypes {
+		if produces != nil {
+			produces[mt] = true
+		}
+		if media := r.Content[mt]; media != nil {
+			if media.Example != nil {
+				if out.Examples == nil {
+					out.Examples = make(map[string]any)
+				}
+				out.Examples[mt] = media.Example
+			}
+			if len(media.Examples) > 0 {
+				d.report.add(ptr(p, "content", mt, "examples"), SeverityWarning, "named examples are not supported in 2.0 and were dropped")
+			}
+		}
+	}
+	if len(r.Links) > 0 {
+		d.report.add(p+"/links", SeverityWarning, "links are not supported in 2.0 and were dropped")
+	}
+	return out
+}
+
+func (d *downgrader30) header(h *oa3.Header, p string) *oa2.Header {
+	if h == nil {
+		return nil
+	}
+	if h.IsReference() {
+		d.report.add(p+"/$ref", SeverityError, "header references are not supported in 2.0 and were dropped")
+		return nil
+	}
+	var fields simpleFields
+	d.simpleType(h.Schema, &fields, p+"/schema")
+	return &oa2.Header{
+		Type:             fields.Type,
+		Format:           fields.Format,
+		Description:      h.Description,
+		Items:            fields.Items,
+		Default:          fields.Default,
+		Maximum:          fields.Maximum,
+		ExclusiveMaximum: fields.ExclusiveMaximum,
+		Minimum:          fields.Minimum,
+		ExclusiveMinimum: fields.ExclusiveMinimum,
+		MaxLength:        fields.MaxLength,
+		MinLength:        fields.MinLength,
+		Pattern:          fields.Pattern,
+		MaxItems:         fields.MaxItems,
+		MinItems:         fields.MinItems,
+		UniqueItems:      fields.UniqueItems,
+		Enum:             fields.Enum,
+		MultipleOf:       fields.MultipleOf,
+		Extensions:       copyExtensions(h.Extensions),
+	}
+}
+
+func (d *downgrader30) securityScheme(ss *oa3.SecurityScheme, p string) *oa2.SecurityScheme {
+	if ss == nil {
+		return nil
+	}
+	if ss.IsReference() {
+		d.report.add(p+"/$ref", SeverityError, "security scheme references are not supported in 2.0 and were dropped")
+		return nil
+	}
+	out := &oa2.SecurityScheme{
+		Type:        ss.Type,
+		Description: ss.Description,
+		Name:        ss.Name,
+		In:          ss.In,
+		Extensions:  copyExtensions(ss.Extensions),
+	}
+	switch ss.Type {
+	case "apiKey":
+		if ss.In == "cookie" {
+			d.report.add(p+"/in", SeverityError, "cookie api keys are not supported in 2.0 and were dropped")
+			return nil
+		}
+	case "http":
+		if !strings.EqualFold(ss.Scheme, "basic") {
+			d.report.add(p, SeverityError, fmt.Sprintf("http %s authentication is not supported in 2.0 and was dropped", ss.Scheme))
+			return nil
+		}
+		out.Type = "basic"
+	case "oauth2":
+		if ss.Flows == nil {
+			d.report.add(p+"/flows", SeverityError, "oauth2 security scheme without flows was dropped")
+			return nil
+		}
+		flows := []struct {
+			name string
+			flow *oa3.OAuthFlow
+		}{
+			{"accessCode", ss.Flows.AuthorizationCode},
+			{"implicit", ss.Flows.Implicit},
+			{"password", ss.Flows.Password},
+			{"application", ss.Flows.ClientCredentials},
+		}
+		for _, f := range flows {
+			if f.flow == nil {
+				continue
+			}
+			if out.Flow != "" {
+				d.report.add(p+"/flows", SeverityError, fmt.Sprintf("2.0 supports a single oauth2 flow; the %s flow was dropped", f.name))
+				continue
+			}
+			out.Flow = f.name
+			out.AuthorizationUrl = f.flow.AuthorizationUrl
+			out.TokenUrl = f.flow.TokenUrl
+			out.Scopes = copyStringMap(f.flow.Scopes)
+			if f.flow.RefreshUrl != "" {
+				d.report.add(p+"/flows/refreshUrl", SeverityWarning, "refreshUrl is not supported in 2.0 and was dropped")
+			}
+		}
+	default:
+		d.report.add(p, SeverityError, fmt.Sprintf("%s security schemes are not supported in 2.0 and were dropped", ss.Type))
+		return nil
+	}
+	return out
+}
+
+func (d *downgrader30) components(c *oa3.Components, out *oa2.Swagger) {
+	if c == nil {
+		return
+	}
+	p := "/components"
+	if c.Schemas != nil {
+		out.Definitions = make(map[string]*oa2.Schema, len(c.Schemas))
+		for name, s := range c.Schemas {
+			out.Definitions[name] = d.schema(s, ptr(p, "schemas", name))
+		}
+	}
+	for name, param := range c.Parameters {
+		if converted := d.parameter(param, ptr(p, "parameters", name)); converted != nil {
+			if out.Parameters == nil {
+				out.Parameters = make(map[string]*oa2.Parameter)
+			}
+			out.Parameters[name] = converted
+		}
+	}
+	// Request bodies become body parameters, form bodies are inlined where referenced
+	for name, rb := range c.RequestBodies {
+		if rb == nil {
+			continue
+		}
+		pp := ptr(p, "requestBodies", name)
+		d.inlining[name] = true
+		params, _ := d.requestBody(rb, pp)
+		delete(d.inlining, name)
+		if len(params) == 1 && params[0].In == "body" {
+			if out.Parameters == nil {
+				out.Parameters = make(map[string]*oa2.Parameter)
+			}
+			if _, exists := out.Parameters[name]; exists {
+				d.report.add(pp, SeverityError, "request body name collides with a parameter and was dropped")
+				continue
+			}
+			out.Parameters[name] = params[0]
+		} else {
+			d.report.add(pp, SeverityInfo, "form request bodies cannot be components in 2.0 and were inlined where referenced")
+		}
+	}
+	if c.Responses != nil {
+		out.Responses = make(map[string]*oa2.Response, len(c.Responses))
+		for name, r := range c.Responses {
+			out.Responses[name] = d.response(r, nil, ptr(p, "responses", name))
+		}
+	}
+	if c.SecuritySchemes != nil {
+		out.SecurityDefinitions = make(map[string]*oa2.SecurityScheme, len(c.SecuritySchemes))
+		for name, ss := range c.SecuritySchemes {
+			if converted := d.securityScheme(ss, ptr(p, "securitySchemes", name)); converted != nil {
+				out.SecurityDefinitions[name] = converted
+			}
+		}
+	}
+	for _, section := range []struct {
+		name  string
+		count int
+	}{
+		{"examples", len(c.Examples)},
+		{"headers", len(c.Headers)},
+		{"links", len(c.Links)},
+		{"callbacks", len(c.Callbacks)},
+	} {
+		if section.count > 0 {
+			d.report.add(ptr(p, section.name), SeverityWarning, fmt.Sprintf("components %s are not supported in 2.0; references to them were dropped", section.name))
+		}
+	}
+}
+
+func (d *downgrader30) tags(tags []*oa3.Tag) []*oa2.Tag {
+	if tags == nil {
+		return nil
+	}
+	out := make([]*oa2.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t == nil {
+			out = append(out, nil)
+			continue
+		}
+		out = append(out, &oa2.Tag{
+			Name:         t.Name,
+			Description:  t.Description,
+			ExternalDocs: d.externalDocs(t.ExternalDocs),
+			Extensions:   copyExtensions(t.Extensions),
+		})
+	}
+	return out
+}
+
+func (d *downgrader30) externalDocs(ed *oa3.ExternalDocumentation) *oa2.ExternalDocumentation {
+	if ed == nil {
+		return nil
+	}
+	return &oa2.ExternalDocumentation{
+		Description: ed.Description,
+		URL:         ed.URL,
+		Extensions:  copyExtensions(ed.Extensions),
+	}
+}
+
+// schema converts a 3.0 schema to a 2.0 schema
+func (d *downgrader30) schema(s *oa3.Schema, p string) *oa2.Schema {
+	if s == nil {
+		return nil
+	}
+	if s.IsBooleanSchema() {
+		return oa2.NewBooleanSchema(*s.BooleanValue())
+	}
+
+	out := &oa2.Schema{
+		Ref:              downgradeRef30(s.Ref),
+		Title:            s.Title,
+		Description:      s.Description,
+		Default:          s.Default,
+		Format:           s.Format,
+		Type:             s.Type,
+		Enum:             s.Enum,
+		MultipleOf:       copyFloat(s.MultipleOf),
+		Maximum:          copyFloat(s.Maximum),
+		ExclusiveMaximum: s.ExclusiveMaximum,
+		Minimum:          copyFloat(s.Minimum),
+		ExclusiveMinimum: s.ExclusiveMinimum,
+		MaxLength:        copyInt(s.MaxLength),
+		MinLength:        copyInt(s.MinLength),
+		Pattern:          s.Pattern,
+		MaxItems:         copyInt(s.MaxItems),
+		MinItems:         copyInt(s.MinItems),
+		UniqueItems:      s.UniqueItems,
+		Items:            d.schema(s.Items, p+"/items"),
+		MaxProperties:    copyInt(s.MaxProperties),
+		MinProperties:    copyInt(s.MinProperties),
+		Required:         copyStrings(s.Required),
+		ReadOnly:         s.ReadOnly,
+		Example:          s.Example,
+		ExternalDocs:     d.externalDocs(s.ExternalDocs),
+		Extensions:       copyExtensions(s.Extensions),
+	}
+	if s.Discriminator != nil {
+		name, ok := DiscriminatorToString30(s.Discriminator)
+		out.Discriminator = name
+		if !ok {
+			d.report.add(p+"/discriminator/mapping", SeverityError, "discriminator mapping is not supported in 2.0, where values must be schema names")
+		}
+	}
+	if s.XML != nil {
+		out.XML = &oa2.XML{
+			Name:       s.XML.Name,
+			Namespace:  s.XML.Namespace,
+			Prefix:     s.XML.Prefix,
+			Attribute:  s.XML.Attribute,
+			Wrapped:    s.XML.Wrapped,
+			Extensions: copyExtensions(s.XML.Extensions),
+		}
+	}
+	if s.AllOf != nil {
+		out.AllOf = make([]*oa2.Schema, 0, len(s.AllOf))
+		for i, sub := range s.AllOf {
+			out.AllOf = append(out.AllOf, d.schema(sub, ptr(p, "allOf", fmt.Sprint(i))))
+		}
+	}
+	if s.Properties != nil {
+		out.Properties = make(map[string]*oa2.Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			out.Properties[name] = d.schema(prop, ptr(p, "properties", name))
+		}
+	}
+	out.AdditionalProperties = d.schema(s.AdditionalProperties, p+"/additionalProperties")
+
+	if s.Nullable {
+		if out.Extensions == nil {
+			out.Extensions = make(map[string]any)
+		}
+		out.Extensions["x-nullable"] = true
+		d.report.add(p+"/nullable", SeverityWarning, "nullable is not supported in 2.0 and was replaced by x-nullable")
+	}
+	dropped := []struct {
+		keyword  string
+		severity Severity
+		present  bool
+	}{
+		{"oneOf", SeverityError, len(s.OneOf) > 0},
+		{"anyOf", SeverityError, len(s.AnyOf) > 0},
+		{"not", SeverityError, s.Not != nil},
+		{"writeOnly", SeverityWarning, s.WriteOnly},
+		{"deprecated", SeverityWarning, s.Deprecated},
+	}
+	for _, k := range dropped {
+		if k.present {
+			d.report.add(ptr(p, k.keyword), k.severity, k.keyword+" is not supported in 2.0 and was dropped")
+		}
+	}
+	return out
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+)
+
+func TestDowngrade30To20(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"servers": [{"url": "https://api.example.com/v1"}, {"url": "https://other.example.com"}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{"name": "tags", "in": "query", "style": "pipeDelimited", "schema": {"type": "array", "items": {"type": "string"}}},
+						{"name": "session", "in": "cookie", "schema": {"type": "string"}}
+					],
+					"responses": {
+						"200": {
+							"description": "ok",
+							"content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}, "example": []}}
+						}
+					}
+				},
+				"post": {
+					"requestBody": {"$ref": "#/components/requestBodies/PetBody"},
+					"responses": {"201": {"description": "created"}}
+				}
+			},
+			"/pets/{id}/photo": {
+				"put": {
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+					"requestBody": {
+						"content": {
+							"multipart/form-data": {
+								"schema": {
+									"type": "object",
+									"required": ["file"],
+									"properties": {"file": {"type": "string", "format": "binary"}, "note": {"type": "string"}}
+								}
+							}
+						}
+					},
+					"responses": {"204": {"description": "stored"}}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {"type": "object", "properties": {"name": {"type": "string", "nullable": true}}},
+				"Either": {"oneOf": [{"type": "string"}, {"type": "integer"}]}
+			},
+			"requestBodies": {
+				"PetBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+			},
+			"securitySchemes": {
+				"bearer": {"type": "http", "scheme": "bearer"},
+				"basic": {"type": "http", "scheme": "basic"}
+			}
+		}
+	}`
+
+	var doc oa3.OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	out, report := Downgrade30To20(&doc)
+
+	if out.Host != "api.example.com" || out.BasePath != "/v1" || len(out.Schemes) != 1 || out.Schemes[0] != "https" {
+		t.Errorf("Expected host, basePath and schemes from first server, got %q %q %v", out.Host, out.BasePath, out.Schemes)
+	}
+	if !hasIssue(report, "/servers/1") {
+		t.Errorf("Expected dropped server issue, got %s", report)
+	}
+
+	get := out.Paths.Paths["/pets"].Get
+	if len(get.Parameters) != 1 || get.Parameters[0].CollectionFormat != "pipes" {
+		t.Errorf("Expected one pipes parameter, got %+v", get.Parameters)
+	}
+	if !hasIssue(report, "/paths/~1pets/get/parameters/1") {
+		t.Errorf("Expected cookie parameter issue, got %s", report)
+	}
+	resp := get.Responses.StatusCode["200"]
+	if resp.Schema == nil || resp.Schema.Items == nil || resp.Schema.Items.Ref != "#/definitions/Pet" {
+		t.Errorf("Expected rewritten response schema, got %+v", resp.Schema)
+	}
+	if len(get.Produces) != 1 || get.Produces[0] != "application/json" {
+		t.Errorf("Expected produces from content, got %v", get.Produces)
+	}
+	if _, ok := resp.Examples["application/json"]; !ok {
+		t.Errorf("Expected example keyed by media type, got %v", resp.Examples)
+	}
+
+	post := out.Paths.Paths["/pets"].Post
+	if len(post.Parameters) != 1 || post.Parameters[0].Ref != "#/parameters/PetBody" {
+		t.Errorf("Expected body parameter reference, got %+v", post.Parameters)
+	}
+	if body := out.Parameters["PetBody"]; body == nil || body.In != "body" || !body.Required {
+		t.Errorf("Expected PetBody body parameter, got %+v", body)
+	}
+
+	put := out.Paths.Paths["/pets/{id}/photo"].Put
+	if len(put.Parameters) != 3 || len(put.Consumes) != 1 || put.Consumes[0] != "multipart/form-data" {
+		t.Fatalf("Expected path and two formData parameters, got %+v consumes %v", put.Parameters, put.Consumes)
+	}
+	if file := put.Parameters[1]; file.Name != "file" || file.In != "formData" || file.Type != "file" || !file.Required {
+		t.Errorf("Expected required file parameter, got %+v", file)
+	}
+
+	if s := out.Definitions["Pet"].Properties["name"]; s.Extensions["x-nullable"] != true {
+		t.Errorf("Expected x-nullable, got %+v", s.Extensions)
+	}
+	if !hasIssue(report, "/components/schemas/Either/oneOf") {
+		t.Errorf("Expected oneOf issue, got %s", report)
+	}
+	if _, ok := out.SecurityDefinitions["bearer"]; ok {
+		t.Errorf("Expected bearer scheme to be dropped")
+	}
+	if s := out.SecurityDefinitions["basic"]; s == nil || s.Type != "basic" {
+		t.Errorf("Expected basic scheme, got %+v", s)
+	}
+}
+
+func TestDowngrade30To20ExampleFiles(t *testing.T) {
+	examplesDir := "../openapi30/oas-examples/json"
+	entries, err := os.ReadDir(examplesDir)
+	if err != nil {
+		t.Fatalf("Failed to read examples directory: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		t.Run(entry.Name(), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(examplesDir, entry.Name()))
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+			var doc oa3.OpenAPI
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("Failed to unmarshal: %v", err)
+			}
+
+			out, _ := Downgrade30To20(&doc)
+			converted, err := json.Marshal(out)
+			if err != nil {
+				t.Fatalf("Failed to marshal: %v", err)
+			}
+			var reparsed oa2.Swagger
+			if err := json.Unmarshal(converted, &reparsed); err != nil {
+				t.Fatalf("Failed to parse converted document: %v", err)
+			}
+			if reparsed.Swagger != "2.0" {
+				t.Errorf("Expected 2.0 document, got %s", reparsed.Swagger)
+			}
+		})
+	}
+}
@@ -0,0 +1,947 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// DowngradeOptions controls how constructs without a 3.0 equivalent are handled
+type DowngradeOptions struct {
+	// OpenAPIVersion is the version string written to the result (default
+	// "3.0.3", or "3.1.1" from 3.2)
+	OpenAPIVersion string
+
+	// WebhooksExtension, when set, names a root extension (e.g. "x-webhooks")
+	// that receives the converted webhooks instead of dropping them
+	WebhooksExtension string
+}
+
+// Downgrade31To30 converts an OpenAPI 3.1 document to OpenAPI 3.0.
+// Type arrays become nullable, numeric exclusive bounds become boolean flags,
+// prefixItems are approximated with items, and webhooks are dropped or moved
+// into an extension. Everything that could not be represented is listed in the report.
+// A nil opts uses the defaults.
+func Downgrade31To30(doc *oa31.OpenAPI, opts *DowngradeOptions) (*oa3.OpenAPI, *ConversionReport) {
+	report := &ConversionReport{}
+	if doc == nil {
+		return nil, report
+	}
+	d := &downgrader31{src: doc, report: report, inlining: make(map[string]bool)}
+	if opts != nil {
+		d.opts = *opts
+	}
+	if d.opts.OpenAPIVersion == "" {
+		d.opts.OpenAPIVersion = "3.0.3"
+	}
+	d.mutualTLS = make(map[string]bool)
+	if doc.Components != nil {
+		for name, ss := range doc.Components.SecuritySchemes {
+			if ss != nil && ss.Type == "mutualTLS" {
+				d.mutualTLS[name] = true
+			}
+		}
+	}
+	return d.document(), report
+}
+
+type downgrader31 struct {
+	src       *oa31.OpenAPI
+	opts      DowngradeOptions
+	report    *ConversionReport
+	mutualTLS map[string]bool
+	inlining  map[string]bool
+}
+
+func (d *downgrader31) document() *oa3.OpenAPI {
+	src := d.src
+	out := &oa3.OpenAPI{
+		OpenAPI:      d.opts.OpenAPIVersion,
+		Info:         d.info(src.Info),
+		Servers:      d.servers(src.Servers),
+		Paths:        d.paths(src.Paths),
+		Components:   d.components(src.Components),
+		Security:     d.security(src.Security, "/security"),
+		Tags:         d.tags(src.Tags),
+		ExternalDocs: d.externalDocs(src.ExternalDocs),
+		Extensions:   copyExtensions(src.Extensions),
+	}
+
+	if src.JsonSchemaDialect != "" {
+		d.report.add("/jsonSchemaDialect", SeverityWarning, "jsonSchemaDialect is not supported in 3.0 and was dropped")
+	}
+
+	if len(src.Webhooks) > 0 {
+		if d.opts.WebhooksExtension != "" {
+			webhooks := make(map[string]*oa3.PathItem, len(src.Webhooks))
+			for name, item := range src.Webhooks {
+				webhooks[name] = d.pathItem(item, ptr("/webhooks", name))
+			}
+			if out.Extensions == nil {
+				out.Extensions = make(map[string]any)
+			}
+			out.Extensions[d.opts.WebhooksExtension] = webhooks
+			d.report.add("/webhooks", SeverityInfo, fmt.Sprintf("webhooks are not supported in 3.0 and were moved to %s", d.opts.WebhooksExtension))
+		} else {
+			d.report.add("/webhooks", SeverityError, "webhooks are not supported in 3.0 and were dropped")
+		}
+	}
+
+	return out
+}
+
+func (d *downgrader31) info(info *oa31.Info) *oa3.Info {
+	if info == nil {
+		return nil
+	}
+	out := &oa3.Info{
+		Title:          info.Title,
+		Description:    info.Description,
+		TermsOfService: info.TermsOfService,
+		Version:        info.Version,
+		Extensions:     copyExtensions(info.Extensions),
+	}
+	if info.Summary != "" {
+		d.report.add("/info/summary", SeverityWarning, "info summary is not supported in 3.0 and was dropped")
+	}
+	if info.Contact != nil {
+		out.Contact = &oa3.Contact{
+			Name:       info.Contact.Name,
+			URL:        info.Contact.URL,
+			Email:      info.Contact.Email,
+			Extensions: copyExtensions(info.Contact.Extensions),
+		}
+	}
+	if info.License != nil {
+		out.License = &oa3.License{
+			Name:       info.License.Name,
+			URL:        info.License.URL,
+			Extensions: copyExtensions(info.License.Extensions),
+		}
+		if info.License.Identifier != "" {
+			if out.License.URL == "" {
+				out.License.URL = "https://spdx.org/licenses/" + info.License.Identifier + ".html"
+				d.report.add("/info/license/identifier", SeverityInfo, "license identifier is not supported in 3.0 and was replaced by its SPDX url")
+			} else {
+				d.report.add("/info/license/identifier", SeverityWarning, "license identifier is not supported in 3.0 and was dropped")
+			}
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) servers(servers []*oa31.Server) []*oa3.Server {
+	if servers == nil {
+		return nil
+	}
+	out := make([]*oa3.Server, 0, len(servers))
+	for _, s := range servers {
+		out = append(out, d.server(s))
+	}
+	return out
+}
+
+func (d *downgrader31) server(s *oa31.Server) *oa3.Server {
+	if s == nil {
+		return nil
+	}
+	out := &oa3.Server{
+		URL:         s.URL,
+		Description: s.Description,
+		Extensions:  copyExtensions(s.Extensions),
+	}
+	if s.Variables != nil {
+		out.Variables = make(map[string]*oa3.ServerVariable, len(s.Variables))
+		for name, v := range s.Variables {
+			if v == nil {
+				out.Variables[name] = nil
+				continue
+			}
+			out.Variables[name] = &oa3.ServerVariable{
+				Enum:        copyStrings(v.Enum),
+				Default:     v.Default,
+				Description: v.Description,
+				Extensions:  copyExtensions(v.Extensions),
+			}
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) paths(paths *oa31.Paths) *oa3.Paths {
+	// paths is required in 3.0
+	out := &oa3.Paths{}
+	if paths == nil {
+		return out
+	}
+	out.Extensions = copyExtensions(paths.Extensions)
+	if paths.Paths != nil {
+		out.Paths = make(map[string]*oa3.PathItem, len(paths.Paths))
+		for key, item := range paths.Paths {
+			out.Paths[key] = d.pathItem(item, ptr("/paths", key))
+		}
+	}
+	return out
+}
+
+const componentsPathItemsPrefix = "#/components/pathItems/"
+
+func (d *downgrader31) pathItem(item *oa31.PathItem, p string) *oa3.PathItem {
+	if item == nil {
+		return nil
+	}
+
+	// Path items referencing components.pathItems are inlined, because 3.0 has no such section
+	if strings.HasPrefix(item.Ref, componentsPathItemsPrefix) {
+		name := strings.TrimPrefix(item.Ref, componentsPathItemsPrefix)
+		var target *oa31.PathItem
+		if d.src.Components != nil {
+			target = d.src.Components.PathItems[name]
+		}
+		if target == nil || d.inlining[name] {
+			d.report.add(p+"/$ref", SeverityError, fmt.Sprintf("path item reference %s could not be inlined", item.Ref))
+			return &oa3.PathItem{Ref: item.Ref}
+		}
+		d.inlining[name] = true
+		out := d.pathItem(target, ptr("/components/pathItems", name))
+		delete(d.inlining, name)
+		return out
+	}
+
+	out := &oa3.PathItem{
+		Ref:         item.Ref,
+		Summary:     item.Summary,
+		Description: item.Description,
+		Servers:     d.servers(item.Servers),
+		Parameters:  d.parameters(item.Parameters, p+"/parameters"),
+		Get:         d.operation(item.Get, p+"/get"),
+		Put:         d.operation(item.Put, p+"/put"),
+		Post:        d.operation(item.Post, p+"/post"),
+		Delete:      d.operation(item.Delete, p+"/delete"),
+		Options:     d.operation(item.Options, p+"/options"),
+		Head:        d.operation(item.Head, p+"/head"),
+		Patch:       d.operation(item.Patch, p+"/patch"),
+		Trace:       d.operation(item.Trace, p+"/trace"),
+		Extensions:  copyExtensions(item.Extensions),
+	}
+	return out
+}
+
+func (d *downgrader31) operation(op *oa31.Operation, p string) *oa3.Operation {
+	if op == nil {
+		return nil
+	}
+	out := &oa3.Operation{
+		Tags:         copyStrings(op.Tags),
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: d.externalDocs(op.ExternalDocs),
+		OperationID:  op.OperationID,
+		Parameters:   d.parameters(op.Parameters, p+"/parameters"),
+		RequestBody:  d.requestBody(op.RequestBody, p+"/requestBody"),
+		Responses:    d.responses(op.Responses, p+"/responses"),
+		Deprecated:   op.Deprecated,
+		Security:     d.security(op.Security, p+"/security"),
+		Servers:      d.servers(op.Servers),
+		Extensions:   copyExtensions(op.Extensions),
+	}
+	if op.Callbacks != nil {
+		out.Callbacks = make(map[string]*oa3.Callback, len(op.Callbacks))
+		for name, cb := range op.Callbacks {
+			out.Callbacks[name] = d.callback(cb, ptr(p+"/callbacks", name))
+		}
+	}
+	// responses is required in 3.0, webhooks in 3.1 may omit it
+	if out.Responses == nil {
+		out.Responses = &oa3.Responses{Default: &oa3.Response{Description: "Default response"}}
+		d.report.add(p+"/responses", SeverityWarning, "operation without responses was given a default response")
+	}
+	return out
+}
+
+func (d *downgrader31) security(reqs []oa31.SecurityRequirement, p string) []oa3.SecurityRequirement {
+	if reqs == nil {
+		return nil
+	}
+	out := make([]oa3.SecurityRequirement, 0, len(reqs))
+	for i, req := range reqs {
+		converted := make(oa3.SecurityRequirement, len(req))
+		for name, scopes := range req {
+			if d.mutualTLS[name] {
+				d.report.add(ptr(p, fmt.Sprint(i), name), SeverityError, "mutualTLS security is not supported in 3.0 and was dropped")
+				continue
+			}
+			converted[name] = copyStrings(scopes)
+		}
+		// A requirement that only listed mutualTLS schemes is dropped, not turned into anonymous access
+		if len(converted) == 0 && len(req) > 0 {
+			continue
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
+func (d *downgrader31) parameters(params []*oa31.Parameter, p string) []*oa3.Parameter {
+	if params == nil {
+		return nil
+	}
+	out := make([]*oa3.Parameter, 0, len(params))
+	for i, param := range params {
+		out = append(out, d.parameter(param, ptr(p, fmt.Sprint(i))))
+	}
+	return out
+}
+
+func (d *downgrader31) parameter(param *oa31.Parameter, p string) *oa3.Parameter {
+	if param == nil {
+		return nil
+	}
+	if param.IsReference() {
+		d.refSiblings(param.Summary, param.Description, p)
+		out := oa3.NewParameterReference(param.Ref)
+		out.Extensions = copyExtensions(param.Extensions)
+		return out
+	}
+	return &oa3.Parameter{
+		Name:            param.Name,
+		In:              param.In,
+		Description:     param.Description,
+		Required:        param.Required,
+		Deprecated:      param.Deprecated,
+		AllowEmptyValue: param.AllowEmptyValue,
+		Style:           param.Style,
+		Explode:         copyBool(param.Explode),
+		AllowReserved:   param.AllowReserved,
+		Schema:          d.schema(param.Schema, p+"/schema"),
+		Content:         d.content(param.Content, p+"/content"),
+		Example:         param.Example,
+		Examples:        d.examples(param.Examples, p+"/examples"),
+		Extensions:      copyExtensions(param.Extensions),
+	}
+}
+
+// refSiblings reports summary and description next to a $ref, which 3.0 ignores
+func (d *downgrader31) refSiblings(summary, description, p string) {
+	if summary != "" {
+		d.report.add(p+"/summary", SeverityWarning, "summary next to $ref is not supported in 3.0 and was dropped")
+	}
+	if description != "" {
+		d.report.add(p+"/description", SeverityWarning, "description next to $ref is not supported in 3.0 and was dropped")
+	}
+}
+
+func (d *downgrader31) header(h *oa31.Header, p string) *oa3.Header {
+	if h == nil {
+		return nil
+	}
+	if h.IsReference() {
+		d.refSiblings(h.Summary, h.Description, p)
+		out := oa3.NewHeaderReference(h.Ref)
+		out.Extensions = copyExtensions(h.Extensions)
+		return out
+	}
+	return &oa3.Header{
+		Description: h.Description,
+		Required:    h.Required,
+		Deprecated:  h.Deprecated,
+		Style:       h.Style,
+		Explode:     copyBool(h.Explode),
+		Schema:      d.schema(h.Schema, p+"/schema"),
+		Content:     d.content(h.Content, p+"/content"),
+		Example:     h.Example,
+		Examples:    d.examples(h.Examples, p+"/examples"),
+		Extensions:  copyExtensions(h.Extensions),
+	}
+}
+
+func (d *downgrader31) headers(headers map[string]*oa31.Header, p string) map[string]*oa3.Header {
+	if headers == nil {
+		return nil
+	}
+	out := make(map[string]*oa3.Header, len(headers))
+	for name, h := range headers {
+		out[name] = d.header(h, ptr(p, name))
+	}
+	return out
+}
+
+func (d *downgrader31) requestBody(rb *oa31.RequestBody, p string) *oa3.RequestBody {
+	if rb == nil {
+		return nil
+	}
+	if rb.IsReference() {
+		d.refSiblings(rb.Summary, rb.Description, p)
+		out := oa3.NewRequestBodyReference(rb.Ref)
+		out.Extensions = copyExtensions(rb.Extensions)
+		return out
+	}
+	return &oa3.RequestBody{
+		Description: rb.Description,
+		Content:     d.content(rb.Content, p+"/content"),
+		Required:    rb.Required,
+		Extensions:  copyExtensions(rb.Extensions),
+	}
+}
+
+func (d *downgrader31) content(content map[string]*oa31.MediaType, p string) map[string]*oa3.MediaType {
+	if content == nil {
+		return nil
+	}
+	out := make(map[string]*oa3.MediaType, len(content))
+	for name, mt := range content {
+		out[name] = d.mediaType(mt, ptr(p, name))
+	}
+	return out
+}
+
+func (d *downgrader31) mediaType(mt *oa31.MediaType, p string) *oa3.MediaType {
+	if mt == nil {
+		return nil
+	}
+	out := &oa3.MediaType{
+		Schema:     d.schema(mt.Schema, p+"/schema"),
+		Example:    mt.Example,
+		Examples:   d.examples(mt.Examples, p+"/examples"),
+		Extensions: copyExtensions(mt.Extensions),
+	}
+	if mt.Encoding != nil {
+		out.Encoding = make(map[string]*oa3.Encoding, len(mt.Encoding))
+		for name, enc := range mt.Encoding {
+			if enc == nil {
+				out.Encoding[name] = nil
+				continue
+			}
+			out.Encoding[name] = &oa3.Encoding{
+				ContentType:   enc.ContentType,
+				Headers:       d.headers(enc.Headers, ptr(p, "encoding", name, "headers")),
+				Style:         enc.Style,
+				Explode:       copyBool(enc.Explode),
+				AllowReserved: enc.AllowReserved,
+				Extensions:    copyExtensions(enc.Extensions),
+			}
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) examples(examples map[string]*oa31.Example, p string) map[string]*oa3.Example {
+	if examples == nil {
+		return nil
+	}
+	out := make(map[string]*oa3.Example, len(examples))
+	for name, ex := range examples {
+		out[name] = d.example(ex, ptr(p, name))
+	}
+	return out
+}
+
+func (d *downgrader31) example(ex *oa31.Example, p string) *oa3.Example {
+	if ex == nil {
+		return nil
+	}
+	if ex.IsReference() {
+		d.refSiblings(ex.Summary, "", p)
+		out := oa3.NewExampleReference(ex.Ref)
+		out.Extensions = copyExtensions(ex.Extensions)
+		return out
+	}
+	return &oa3.Example{
+		Summary:       ex.Summary,
+		Description:   ex.Description,
+		Value:         ex.Value,
+		ExternalValue: ex.ExternalValue,
+		Extensions:    copyExtensions(ex.Extensions),
+	}
+}
+
+func (d *downgrader31) responses(r *oa31.Responses, p string) *oa3.Responses {
+	if r == nil {
+		return nil
+	}
+	out := &oa3.Responses{
+		Default:    d.response(r.Default, p+"/default"),
+		Extensions: copyExtensions(r.Extensions),
+	}
+	if r.StatusCode != nil {
+		out.StatusCode = make(map[string]*oa3.Response, len(r.StatusCode))
+		for code, resp := range r.StatusCode {
+			out.StatusCode[code] = d.response(resp, ptr(p, code))
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) response(r *oa31.Response, p string) *oa3.Response {
+	if r == nil {
+		return nil
+	}
+	if r.IsReference() {
+		d.refSiblings(r.Summary, r.Description, p)
+		out := oa3.NewResponseReference(r.Ref)
+		out.Extensions = copyExtensions(r.Extensions)
+		return out
+	}
+	out := &oa3.Response{
+		Description: r.Description,
+		Headers:     d.headers(r.Headers, p+"/headers"),
+		Content:     d.content(r.Content, p+"/content"),
+		Extensions:  copyExtensions(r.Extensions),
+	}
+	if r.Links != nil {
+		out.Links = make(map[string]*oa3.Link, len(r.Links))
+		for name, l := range r.Links {
+			out.Links[name] = d.link(l, ptr(p, "links", name))
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) link(l *oa31.Link, p string) *oa3.Link {
+	if l == nil {
+		return nil
+	}
+	if l.IsReference() {
+		d.refSiblings(l.Summary, l.Description, p)
+		out := oa3.NewLinkReference(l.Ref)
+		out.Extensions = copyExtensions(l.Extensions)
+		return out
+	}
+	out := &oa3.Link{
+		OperationRef: l.OperationRef,
+		OperationId:  l.OperationId,
+		RequestBody:  l.RequestBody,
+		Description:  l.Description,
+		Server:       d.server(l.Server),
+		Extensions:   copyExtensions(l.Extensions),
+	}
+	if l.Parameters != nil {
+		out.Parameters = make(map[string]any, len(l.Parameters))
+		for k, v := range l.Parameters {
+			out.Parameters[k] = v
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) callback(cb *oa31.Callback, p string) *oa3.Callback {
+	if cb == nil {
+		return nil
+	}
+	if cb.IsReference() {
+		d.refSiblings(cb.Summary, cb.Description, p)
+		out := oa3.NewCallbackReference(cb.Ref)
+		out.Extensions = copyExtensions(cb.Extensions)
+		return out
+	}
+	out := &oa3.Callback{Extensions: copyExtensions(cb.Extensions)}
+	if cb.Paths != nil {
+		out.Paths = make(map[string]*oa3.PathItem, len(cb.Paths))
+		for expr, item := range cb.Paths {
+			out.Paths[expr] = d.pathItem(item, ptr(p, expr))
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) securityScheme(ss *oa31.SecurityScheme, p string) *oa3.SecurityScheme {
+	if ss == nil {
+		return nil
+	}
+	if ss.IsReference() {
+		d.refSiblings(ss.Summary, ss.Description, p)
+		out := oa3.NewSecuritySchemeReference(ss.Ref)
+		out.Extensions = copyExtensions(ss.Extensions)
+		return out
+	}
+	out := &oa3.SecurityScheme{
+		Type:             ss.Type,
+		Description:      ss.Description,
+		Name:             ss.Name,
+		In:               ss.In,
+		Scheme:           ss.Scheme,
+		BearerFormat:     ss.BearerFormat,
+		OpenIdConnectUrl: ss.OpenIdConnectUrl,
+		Extensions:       copyExtensions(ss.Extensions),
+	}
+	if ss.Flows != nil {
+		out.Flows = &oa3.OAuthFlows{
+			Implicit:          oauthFlow31To30(ss.Flows.Implicit),
+			Password:          oauthFlow31To30(ss.Flows.Password),
+			ClientCredentials: oauthFlow31To30(ss.Flows.ClientCredentials),
+			AuthorizationCode: oauthFlow31To30(ss.Flows.AuthorizationCode),
+			Extensions:        copyExtensions(ss.Flows.Extensions),
+		}
+	}
+	return out
+}
+
+func oauthFlow31To30(f *oa31.OAuthFlow) *oa3.OAuthFlow {
+	if f == nil {
+		return nil
+	}
+	out := &oa3.OAuthFlow{
+		AuthorizationUrl: f.AuthorizationUrl,
+		TokenUrl:         f.TokenUrl,
+		RefreshUrl:       f.RefreshUrl,
+		Extensions:       copyExtensions(f.Extensions),
+	}
+	if f.Scopes != nil {
+		out.Scopes = make(map[string]string, len(f.Scopes))
+		for k, v := range f.Scopes {
+			out.Scopes[k] = v
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) components(c *oa31.Components) *oa3.Components {
+	if c == nil {
+		return nil
+	}
+	p := "/components"
+	out := &oa3.Components{Extensions: copyExtensions(c.Extensions)}
+	if c.Schemas != nil {
+		out.Schemas = make(map[string]*oa3.Schema, len(c.Schemas))
+		for name, s := range c.Schemas {
+			out.Schemas[name] = d.schema(s, ptr(p, "schemas", name))
+		}
+	}
+	if c.Responses != nil {
+		out.Responses = make(map[string]*oa3.Response, len(c.Responses))
+		for name, r := range c.Responses {
+			out.Responses[name] = d.response(r, ptr(p, "responses", name))
+		}
+	}
+	if c.Parameters != nil {
+		out.Parameters = make(map[string]*oa3.Parameter, len(c.Parameters))
+		for name, param := range c.Parameters {
+			out.Parameters[name] = d.parameter(param, ptr(p, "parameters", name))
+		}
+	}
+	out.Examples = d.examples(c.Examples, p+"/examples")
+	if c.RequestBodies != nil {
+		out.RequestBodies = make(map[string]*oa3.RequestBody, len(c.RequestBodies))
+		for name, rb := range c.RequestBodies {
+			out.RequestBodies[name] = d.requestBody(rb, ptr(p, "requestBodies", name))
+		}
+	}
+	out.Headers = d.headers(c.Headers, p+"/headers")
+	if c.SecuritySchemes != nil {
+		out.SecuritySchemes = make(map[string]*oa3.SecurityScheme, len(c.SecuritySchemes))
+		for name, ss := range c.SecuritySchemes {
+			if d.mutualTLS[name] {
+				d.report.add(ptr(p, "securitySchemes", name), SeverityError, "mutualTLS security scheme is not supported in 3.0 and was dropped")
+				continue
+			}
+			out.SecuritySchemes[name] = d.securityScheme(ss, ptr(p, "securitySchemes", name))
+		}
+	}
+	if c.Links != nil {
+		out.Links = make(map[string]*oa3.Link, len(c.Links))
+		for name, l := range c.Links {
+			out.Links[name] = d.link(l, ptr(p, "links", name))
+		}
+	}
+	if c.Callbacks != nil {
+		out.Callbacks = make(map[string]*oa3.Callback, len(c.Callbacks))
+		for name, cb := range c.Callbacks {
+			out.Callbacks[name] = d.callback(cb, ptr(p, "callbacks", name))
+		}
+	}
+	if len(c.PathItems) > 0 {
+		d.report.add(p+"/pathItems", SeverityInfo, "components pathItems are not supported in 3.0; references to them were inlined")
+	}
+	return out
+}
+
+func (d *downgrader31) tags(tags []*oa31.Tag) []*oa3.Tag {
+	if tags == nil {
+		return nil
+	}
+	out := make([]*oa3.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t == nil {
+			out = append(out, nil)
+			continue
+		}
+		out = append(out, &oa3.Tag{
+			Name:         t.Name,
+			Description:  t.Description,
+			ExternalDocs: d.externalDocs(t.ExternalDocs),
+			Extensions:   copyExtensions(t.Extensions),
+		})
+	}
+	return out
+}
+
+func (d *downgrader31) externalDocs(ed *oa31.ExternalDocumentation) *oa3.ExternalDocumentation {
+	if ed == nil {
+		return nil
+	}
+	return &oa3.ExternalDocumentation{
+		Description: ed.Description,
+		URL:         ed.URL,
+		Extensions:  copyExtensions(ed.Extensions),
+	}
+}
+
+// schema converts a 3.1 (JSON Schema 2020-12) schema to a 3.0 (Draft 4 subset) schema
+func (d *downgrader31) schema(s *oa31.Schema, p string) *oa3.Schema {
+	if s == nil {
+		return nil
+	}
+	// Boolean schemas are only allowed for additionalProperties in 3.0
+	if s.IsBooleanSchema() {
+		if *s.BooleanValue() {
+			return &oa3.Schema{}
+		}
+		return &oa3.Schema{Not: &oa3.Schema{}}
+	}
+
+	out := &oa3.Schema{
+		Title:         s.Title,
+		Description:   s.Description,
+		Default:       s.Default,
+		Format:        s.Format,
+		Enum:          s.Enum,
+		MultipleOf:    copyFloat(s.MultipleOf),
+		MaxLength:     copyInt(s.MaxLength),
+		MinLength:     copyInt(s.MinLength),
+		Pattern:       s.Pattern,
+		MaxItems:      copyInt(s.MaxItems),
+		MinItems:      copyInt(s.MinItems),
+		UniqueItems:   s.UniqueItems,
+		MaxProperties: copyInt(s.MaxProperties),
+		MinProperties: copyInt(s.MinProperties),
+		Required:      copyStrings(s.Required),
+		Not:           d.schema(s.Not, p+"/not"),
+		ReadOnly:      s.ReadOnly,
+		WriteOnly:     s.WriteOnly,
+		Example:       s.Example,
+		Deprecated:    s.Deprecated,
+		Extensions:    copyExtensions(s.Extensions),
+	}
+	if s.Discriminator != nil {
+		out.Discriminator = &oa3.Discriminator{
+			PropertyName: s.Discriminator.PropertyName,
+			Mapping:      copyStringMap(s.Discriminator.Mapping),
+			Extensions:   copyExtensions(s.Discriminator.Extensions),
+		}
+	}
+	if s.XML != nil {
+		out.XML = &oa3.XML{
+			Name:       s.XML.Name,
+			Namespace:  s.XML.Namespace,
+			Prefix:     s.XML.Prefix,
+			Attribute:  s.XML.Attribute,
+			Wrapped:    s.XML.Wrapped,
+			Extensions: copyExtensions(s.XML.Extensions),
+		}
+	}
+	out.ExternalDocs = d.externalDocs(s.ExternalDocs)
+
+	out.AllOf = d.schemaList(s.AllOf, p+"/allOf")
+	out.AnyOf = d.schemaList(s.AnyOf, p+"/anyOf")
+	out.OneOf = d.schemaList(s.OneOf, p+"/oneOf")
+
+	if s.Properties != nil {
+		out.Properties = make(map[string]*oa3.Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			out.Properties[name] = d.schema(prop, ptr(p, "properties", name))
+		}
+	}
+	if s.AdditionalProperties != nil {
+		if s.AdditionalProperties.IsBooleanSchema() {
+			out.AdditionalProperties = oa3.NewBooleanSchema(*s.AdditionalProperties.BooleanValue())
+		} else {
+			out.AdditionalProperties = d.schema(s.AdditionalProperties, p+"/additionalProperties")
+		}
+	}
+
+	d.schemaType(s, out, p)
+	ApplyExclusiveBounds30(out, s)
+	d.schemaItems(s, out, p)
+	d.schemaContent(s, out, p)
+
+	if s.Const != nil {
+		if out.Enum == nil {
+			out.Enum = []any{s.Const}
+		} else {
+			d.report.add(p+"/const", SeverityError, "const next to enum is not supported in 3.0 and was dropped")
+		}
+	}
+	if len(s.Examples) > 0 {
+		if out.Example == nil {
+			out.Example = s.Examples[0]
+		}
+		if len(s.Examples) > 1 || s.Example != nil {
+			d.report.add(p+"/examples", SeverityWarning, "schema examples are not supported in 3.0; only a single example was kept")
+		}
+	}
+
+	d.droppedKeywords(s, p)
+
+	// Keywords next to $ref are ignored in 3.0, so they are moved next to an allOf
+	if s.Ref != "" {
+		if reflect.ValueOf(*out).IsZero() {
+			out.Ref = s.Ref
+		} else {
+			out.AllOf = append([]*oa3.Schema{{Ref: s.Ref}}, out.AllOf...)
+		}
+	}
+	return out
+}
+
+func (d *downgrader31) schemaList(list []*oa31.Schema, p string) []*oa3.Schema {
+	if list == nil {
+		return nil
+	}
+	out := make([]*oa3.Schema, 0, len(list))
+	for i, s := range list {
+		out = append(out, d.schema(s, ptr(p, fmt.Sprint(i))))
+	}
+	return out
+}
+
+// schemaType converts type arrays into a single type plus nullable
+func (d *downgrader31) schemaType(s *oa31.Schema, out *oa3.Schema, p string) {
+	if ApplyNullable30(out, s) {
+		if out.Type == "" && out.Nullable {
+			d.report.add(p+"/type", SeverityWarning, "type null has no 3.0 equivalent and was approximated with nullable")
+		}
+		return
+	}
+
+	types, _ := TypeArrayToNullable(s.Type)
+	alternatives := make([]*oa3.Schema, 0, len(types))
+	for _, t := range types {
+		alternatives = append(alternatives, &oa3.Schema{Type: t})
+	}
+	if out.AnyOf == nil {
+		out.AnyOf = alternatives
+	} else {
+		out.AllOf = append(out.AllOf, &oa3.Schema{AnyOf: alternatives})
+	}
+	d.report.add(p+"/type", SeverityWarning, "multiple types are not supported in 3.0 and were approximated with anyOf")
+}
+
+// schemaItems approximates prefixItems, which 3.0 cannot express, with items
+func (d *downgrader31) schemaItems(s *oa31.Schema, out *oa3.Schema, p string) {
+	if len(s.PrefixItems) == 0 {
+		out.Items = d.schema(s.Items, p+"/items")
+		return
+	}
+
+	alternatives := d.schemaList(s.PrefixItems, p+"/prefixItems")
+	closed := s.Items.IsBooleanSchema() && !*s.Items.BooleanValue()
+	if s.Items != nil && !closed {
+		alternatives = append(alternatives, d.schema(s.Items, p+"/items"))
+	}
+	if len(alternatives) == 1 {
+		out.Items = alternatives[0]
+	} else {
+		out.Items = &oa3.Schema{AnyOf: alternatives}
+	}
+	if closed && out.MaxItems == nil {
+		n := len(s.PrefixItems)
+		out.MaxItems = &n
+	}
+	d.report.add(p+"/prefixItems", SeverityWarning, "prefixItems are not supported in 3.0 and were approximated with items")
+}
+
+// schemaContent maps contentEncoding and contentMediaType to the 3.0 binary formats
+func (d *downgrader31) schemaContent(s *oa31.Schema, out *oa3.Schema, p string) {
+	if s.ContentEncoding != "" {
+		if s.ContentEncoding == "base64" && out.Format == "" {
+			out.Format = "byte"
+		} else {
+			d.report.add(p+"/contentEncoding", SeverityWarning, "contentEncoding is not supported in 3.0 and was dropped")
+		}
+	}
+	if s.ContentMediaType != "" {
+		if s.ContentEncoding == "" && s.ContentMediaType == "application/octet-stream" && out.Format == "" {
+			out.Format = "binary"
+		} else {
+			d.report.add(p+"/contentMediaType", SeverityWarning, "contentMediaType is not supported in 3.0 and was dropped")
+		}
+	}
+}
+
+// droppedKeywords reports 2020-12 keywords that have no 3.0 counterpart
+func (d *downgrader31) droppedKeywords(s *oa31.Schema, p string) {
+	dropped := []struct {
+		keyword  string
+		severity Severity
+		present  bool
+	}{
+		{"$id", SeverityWarning, s.ID != ""},
+		{"$schema", SeverityWarning, s.Schema != ""},
+		{"$anchor", SeverityWarning, s.Anchor != ""},
+		{"$dynamicRef", SeverityError, s.DynamicRef != ""},
+		{"$dynamicAnchor", SeverityWarning, s.DynamicAnchor != ""},
+		{"$defs", SeverityError, len(s.Defs) > 0},
+		{"$comment", SeverityWarning, s.Comment != ""},
+		{"$vocabulary", SeverityWarning, len(s.Vocabulary) > 0},
+		{"if", SeverityError, s.If != nil},
+		{"then", SeverityError, s.Then != nil},
+		{"else", SeverityError, s.Else != nil},
+		{"dependentSchemas", SeverityError, len(s.DependentSchemas) > 0},
+		{"dependentRequired", SeverityError, len(s.DependentRequired) > 0},
+		{"contains", SeverityError, s.Contains != nil},
+		{"minContains", SeverityError, s.MinContains != nil},
+		{"maxContains", SeverityError, s.MaxContains != nil},
+		{"patternProperties", SeverityError, len(s.PatternProperties) > 0},
+		{"propertyNames", SeverityError, s.PropertyNames != nil},
+		{"unevaluatedItems", SeverityError, s.UnevaluatedItems != nil},
+		{"unevaluatedProperties", SeverityError, s.UnevaluatedProperties != nil},
+		{"contentSchema", SeverityWarning, s.ContentSchema != nil},
+	}
+	for _, k := range dropped {
+		if k.present {
+			d.report.add(ptr(p, k.keyword), k.severity, k.keyword+" is not supported in 3.0 and was dropped")
+		}
+	}
+}
+
+func copyBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	v := *b
+	return &v
+}
+
+func copyInt(i *int) *int {
+	if i == nil {
+		return nil
+	}
+	v := *i
+	return &v
+}
+
+func copyFloat(f *float64) *float64 {
+	if f == nil {
+		return nil
+	}
+	v := *f
+	return &v
+}
+
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
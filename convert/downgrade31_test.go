@@ -0,0 +1,226 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+func hasIssue(report *ConversionReport, pointer string) bool {
+	for _, i := range report.Issues {
+		if i.Pointer == pointer {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDowngrade31To30Schema(t *testing.T) {
+	data := `{
+		"openapi": "3.1.0",
+		"info": {"title": "Test", "version": "1.0", "summary": "short"},
+		"paths": {},
+		"components": {
+			"schemas": {
+				"Nullable": {"type": ["string", "null"]},
+				"Multi": {"type": ["string", "integer"]},
+				"Bounds": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 10},
+				"Tuple": {"type": "array", "prefixItems": [{"type": "string"}, {"type": "integer"}], "items": false},
+				"Const": {"const": "fixed", "examples": ["a", "b"]},
+				"Conditional": {"if": {"type": "string"}, "then": {"minLength": 1}},
+				"RefWithSiblings": {"$ref": "#/components/schemas/Nullable", "description": "wrapped"},
+				"Binary": {"type": "string", "contentMediaType": "application/octet-stream"},
+				"Closed": {"type": "object", "additionalProperties": false}
+			}
+		}
+	}`
+
+	var doc oa31.OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	out, report := Downgrade31To30(&doc, nil)
+	if out.OpenAPI != "3.0.3" {
+		t.Errorf("Expected openapi 3.0.3, got %s", out.OpenAPI)
+	}
+	schemas := out.Components.Schemas
+
+	if s := schemas["Nullable"]; s.Type != "string" || !s.Nullable {
+		t.Errorf("Expected nullable string, got type=%q nullable=%v", s.Type, s.Nullable)
+	}
+	if s := schemas["Multi"]; s.Type != "" || len(s.AnyOf) != 2 {
+		t.Errorf("Expected anyOf with 2 alternatives, got %+v", s)
+	}
+	if s := schemas["Bounds"]; s.Minimum == nil || *s.Minimum != 0 || !s.ExclusiveMinimum ||
+		s.Maximum == nil || *s.Maximum != 10 || !s.ExclusiveMaximum {
+		t.Errorf("Expected boolean exclusive bounds, got %+v", s)
+	}
+	if s := schemas["Tuple"]; s.Items == nil || len(s.Items.AnyOf) != 2 || s.MaxItems == nil || *s.MaxItems != 2 {
+		t.Errorf("Expected tuple approximated with anyOf items and maxItems, got %+v", s)
+	}
+	if s := schemas["Const"]; len(s.Enum) != 1 || s.Enum[0] != "fixed" || s.Example != "a" {
+		t.Errorf("Expected const as enum and first example, got %+v", s)
+	}
+	if s := schemas["RefWithSiblings"]; s.Ref != "" || len(s.AllOf) != 1 || s.AllOf[0].Ref == "" || s.Description != "wrapped" {
+		t.Errorf("Expected $ref siblings wrapped in allOf, got %+v", s)
+	}
+	if s := schemas["Binary"]; s.Format != "binary" {
+		t.Errorf("Expected binary format, got %q", s.Format)
+	}
+	if s := schemas["Closed"]; !s.AdditionalProperties.IsBooleanSchema() {
+		t.Error("Expected additionalProperties to stay a boolean schema")
+	}
+
+	for _, pointer := range []string{
+		"/info/summary",
+		"/components/schemas/Multi/type",
+		"/components/schemas/Tuple/prefixItems",
+		"/components/schemas/Const/examples",
+		"/components/schemas/Conditional/if",
+		"/components/schemas/Conditional/then",
+	} {
+		if !hasIssue(report, pointer) {
+			t.Errorf("Expected loss at %s, got: %s", pointer, report.String())
+		}
+	}
+	if hasIssue(report, "/components/schemas/Nullable/type") {
+		t.Error("Nullable type array should convert without loss")
+	}
+	if !report.HasSeverity(SeverityError) {
+		t.Error("Expected dropped conditional keywords to be errors")
+	}
+}
+
+func TestDowngrade31To30Webhooks(t *testing.T) {
+	doc := &oa31.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    &oa31.Info{Title: "Hooks", Version: "1.0"},
+		Webhooks: map[string]*oa31.PathItem{
+			"newPet": {Post: &oa31.Operation{
+				Responses: &oa31.Responses{StatusCode: map[string]*oa31.Response{"200": {Description: "OK"}}},
+			}},
+		},
+	}
+
+	out, report := Downgrade31To30(doc, nil)
+	if _, ok := out.Extensions["x-webhooks"]; ok {
+		t.Error("Webhooks should be dropped by default")
+	}
+	if !hasIssue(report, "/webhooks") {
+		t.Error("Expected webhooks loss")
+	}
+	if out.Paths == nil {
+		t.Error("Expected paths to be present, it is required in 3.0")
+	}
+
+	out, _ = Downgrade31To30(doc, &DowngradeOptions{WebhooksExtension: "x-webhooks"})
+	hooks, ok := out.Extensions["x-webhooks"].(map[string]*oa3.PathItem)
+	if !ok || hooks["newPet"] == nil || hooks["newPet"].Post == nil {
+		t.Errorf("Expected webhooks moved to x-webhooks, got %v", out.Extensions)
+	}
+}
+
+func TestDowngrade31To30Security(t *testing.T) {
+	doc := &oa31.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    &oa31.Info{Title: "Sec", Version: "1.0", License: &oa31.License{Name: "MIT", Identifier: "MIT"}},
+		Paths:   &oa31.Paths{},
+		Components: &oa31.Components{
+			SecuritySchemes: map[string]*oa31.SecurityScheme{
+				"mtls":   {Type: "mutualTLS"},
+				"apiKey": {Type: "apiKey", Name: "key", In: "header"},
+			},
+		},
+		Security: []oa31.SecurityRequirement{{"mtls": {}}, {"apiKey": {}}},
+	}
+
+	out, report := Downgrade31To30(doc, nil)
+	if _, ok := out.Components.SecuritySchemes["mtls"]; ok {
+		t.Error("Expected mutualTLS scheme to be dropped")
+	}
+	if len(out.Security) != 1 || out.Security[0]["apiKey"] == nil {
+		t.Errorf("Expected only the apiKey requirement, got %v", out.Security)
+	}
+	if out.Info.License.URL != "https://spdx.org/licenses/MIT.html" {
+		t.Errorf("Expected SPDX url, got %q", out.Info.License.URL)
+	}
+	if !hasIssue(report, "/components/securitySchemes/mtls") {
+		t.Errorf("Expected mutualTLS loss, got: %s", report.String())
+	}
+}
+
+func TestDowngrade31To30PathItemReference(t *testing.T) {
+	doc := &oa31.OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    &oa31.Info{Title: "Refs", Version: "1.0"},
+		Paths: &oa31.Paths{Paths: map[string]*oa31.PathItem{
+			"/pets": {Ref: "#/components/pathItems/Pets"},
+		}},
+		Components: &oa31.Components{
+			PathItems: map[string]*oa31.PathItem{
+				"Pets": {Get: &oa31.Operation{
+					OperationID: "listPets",
+					Responses:   &oa31.Responses{StatusCode: map[string]*oa31.Response{"200": {Description: "OK"}}},
+				}},
+			},
+		},
+	}
+
+	out, _ := Downgrade31To30(doc, nil)
+	item := out.Paths.Paths["/pets"]
+	if item.Ref != "" || item.Get == nil || item.Get.OperationID != "listPets" {
+		t.Errorf("Expected path item reference to be inlined, got %+v", item)
+	}
+}
+
+func TestDowngrade31To30ExampleFiles(t *testing.T) {
+	examplesDir := "../openapi31/oas-examples/json"
+	entries, err := os.ReadDir(examplesDir)
+	if err != nil {
+		t.Fatalf("Failed to read examples directory: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		t.Run(entry.Name(), func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(examplesDir, entry.Name()))
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+			// The schema-validation files mix in Draft 4 boolean exclusive bounds
+			data, err = ExclusiveBoundsToNumeric(data)
+			if err != nil {
+				t.Fatalf("Failed to rewrite bounds: %v", err)
+			}
+			var doc oa31.OpenAPI
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("Failed to unmarshal: %v", err)
+			}
+
+			out, _ := Downgrade31To30(&doc, nil)
+			converted, err := json.Marshal(out)
+			if err != nil {
+				t.Fatalf("Failed to marshal: %v", err)
+			}
+			var reparsed oa3.OpenAPI
+			if err := json.Unmarshal(converted, &reparsed); err != nil {
+				t.Fatalf("Failed to parse converted document: %v", err)
+			}
+			if !strings.HasPrefix(reparsed.OpenAPI, "3.0") {
+				t.Errorf("Expected 3.0 document, got %s", reparsed.OpenAPI)
+			}
+		})
+	}
+}
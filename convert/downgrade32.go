@@ -0,0 +1,454 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	oa31 "github.com/genelet/oas/openapi31"
+	oa32 "github.com/genelet/oas/openapi32"
+)
+
+// Downgrade32To31 converts an OpenAPI 3.2 document to OpenAPI 3.1.
+// References to media type components are inlined, itemSchema is approximated
+// with an array schema, dataValue examples become values, and the query and
+// additional operations, querystring parameters, $self and the new fields of
+// tags, servers, security schemes and schemas are dropped. Everything that
+// could not be represented is listed in the report. The OpenAPIVersion of
+// opts defaults to "3.1.1"; a nil opts uses the defaults.
+func Downgrade32To31(doc *oa32.OpenAPI, opts *DowngradeOptions) (*oa31.OpenAPI, *ConversionReport) {
+	report := &ConversionReport{}
+	if doc == nil {
+		return nil, report
+	}
+	d := &downgrader32{doc: doc.Clone(), report: report, inlining: make(map[string]bool)}
+	version := "3.1.1"
+	if opts != nil && opts.OpenAPIVersion != "" {
+		version = opts.OpenAPIVersion
+	}
+	d.document()
+	d.doc.OpenAPI = version
+
+	data, err := json.Marshal(d.doc)
+	if err != nil {
+		report.add("", SeverityError, fmt.Sprintf("the document could not be written: %v", err))
+		return nil, report
+	}
+	out := &oa31.OpenAPI{}
+	if err := json.Unmarshal(data, out); err != nil {
+		report.add("", SeverityError, fmt.Sprintf("the document could not be read as 3.1: %v", err))
+		return nil, report
+	}
+	return out, report
+}
+
+// downgrader32 strips the constructs 3.1 lacks from a copy of a 3.2 document,
+// which then reads as 3.1
+type downgrader32 struct {
+	doc      *oa32.OpenAPI
+	report   *ConversionReport
+	inlining map[string]bool
+}
+
+// drop reports a field without a 3.1 equivalent
+func (d *downgrader32) drop(p string, severity Severity, what string) {
+	d.report.add(p, severity, what+" is not supported in 3.1 and was dropped")
+}
+
+func (d *downgrader32) document() {
+	doc := d.doc
+	if doc.Self != "" {
+		d.drop("/$self", SeverityWarning, "$self")
+		doc.Self = ""
+	}
+	d.servers(doc.Servers, "/servers")
+	if doc.Paths != nil {
+		for _, path := range slices.Sorted(maps.Keys(doc.Paths.Paths)) {
+			d.pathItem(doc.Paths.Paths[path], ptr("/paths", path))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(doc.Webhooks)) {
+		d.pathItem(doc.Webhooks[name], ptr("/webhooks", name))
+	}
+	d.components(doc.Components)
+	for i, tag := range doc.Tags {
+		d.tag(tag, ptr("/tags", strconv.Itoa(i)))
+	}
+	if doc.Components != nil && len(doc.Components.MediaTypes) > 0 {
+		d.report.add("/components/mediaTypes", SeverityInfo, "mediaTypes components are not supported in 3.1; references to them were inlined")
+		doc.Components.MediaTypes = nil
+	}
+}
+
+func (d *downgrader32) servers(servers []*oa32.Server, p string) {
+	for i, s := range servers {
+		if s != nil && s.Name != "" {
+			d.drop(ptr(p, strconv.Itoa(i), "name"), SeverityWarning, "server name")
+			s.Name = ""
+		}
+	}
+}
+
+func (d *downgrader32) pathItem(item *oa32.PathItem, p string) {
+	if item == nil {
+		return
+	}
+	d.servers(item.Servers, ptr(p, "servers"))
+	item.Parameters = d.parameters(item.Parameters, ptr(p, "parameters"))
+	if item.Query != nil {
+		d.drop(ptr(p, "query"), SeverityError, "the query operation")
+		item.Query = nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(item.AdditionalOperations)) {
+		d.drop(ptr(p, "additionalOperations", name), SeverityError, "the additional operation")
+	}
+	item.AdditionalOperations = nil
+	for method, op := range item.Operations() {
+		d.operation(op, ptr(p, method))
+	}
+}
+
+func (d *downgrader32) operation(op *oa32.Operation, p string) {
+	op.Parameters = d.parameters(op.Parameters, ptr(p, "parameters"))
+	d.requestBody(op.RequestBody, ptr(p, "requestBody"))
+	d.responses(op.Responses, ptr(p, "responses"))
+	for _, name := range slices.Sorted(maps.Keys(op.Callbacks)) {
+		d.callback(op.Callbacks[name], ptr(p, "callbacks", name))
+	}
+	d.servers(op.Servers, ptr(p, "servers"))
+}
+
+func (d *downgrader32) callback(cb *oa32.Callback, p string) {
+	if cb == nil {
+		return
+	}
+	for _, expression := range slices.Sorted(maps.Keys(cb.Paths)) {
+		d.pathItem(cb.Paths[expression], ptr(p, expression))
+	}
+}
+
+// parameters drops the querystring parameters, which 3.1 cannot express
+func (d *downgrader32) parameters(params []*oa32.Parameter, p string) []*oa32.Parameter {
+	var out []*oa32.Parameter
+	for i, param := range params {
+		if param != nil && param.In == "querystring" {
+			d.drop(ptr(p, strconv.Itoa(i)), SeverityError, "the querystring parameter")
+			continue
+		}
+		d.parameter(param, ptr(p, strconv.Itoa(i)))
+		out = append(out, param)
+	}
+	return out
+}
+
+func (d *downgrader32) parameter(param *oa32.Parameter, p string) {
+	if param == nil {
+		return
+	}
+	d.schema(param.Schema, ptr(p, "schema"))
+	d.content(param.Content, ptr(p, "content"))
+	d.examples(param.Examples, ptr(p, "examples"))
+}
+
+func (d *downgrader32) header(h *oa32.Header, p string) {
+	if h == nil {
+		return
+	}
+	d.schema(h.Schema, ptr(p, "schema"))
+	d.content(h.Content, ptr(p, "content"))
+	d.examples(h.Examples, ptr(p, "examples"))
+}
+
+func (d *downgrader32) headers(headers map[string]*oa32.Header, p string) {
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		d.header(headers[name], ptr(p, name))
+	}
+}
+
+func (d *downgrader32) requestBody(rb *oa32.RequestBody, p string) {
+	if rb != nil {
+		d.content(rb.Content, ptr(p, "content"))
+	}
+}
+
+func (d *downgrader32) responses(r *oa32.Responses, p string) {
+	if r == nil {
+		return
+	}
+	d.response(r.Default, ptr(p, "default"))
+	for _, code := range slices.Sorted(maps.Keys(r.StatusCode)) {
+		d.response(r.StatusCode[code], ptr(p, code))
+	}
+}
+
+func (d *downgrader32) response(r *oa32.Response, p string) {
+	if r == nil {
+		return
+	}
+	if r.Ref == "" && r.Summary != "" {
+		d.drop(ptr(p, "summary"), SeverityWarning, "response summary")
+		r.Summary = ""
+	}
+	d.headers(r.Headers, ptr(p, "headers"))
+	d.content(r.Content, ptr(p, "content"))
+	for _, name := range slices.Sorted(maps.Keys(r.Links)) {
+		if l := r.Links[name]; l != nil && l.Server != nil {
+			d.servers([]*oa32.Server{l.Server}, ptr(p, "links", name, "server"))
+		}
+	}
+}
+
+// content inlines the media types that reference components, and strips the
+// others
+func (d *downgrader32) content(content map[string]*oa32.MediaType, p string) {
+	for _, name := range slices.Sorted(maps.Keys(content)) {
+		mt := content[name]
+		if mt != nil && mt.Ref != "" {
+			if inlined := d.inline(mt.Ref, ptr(p, name)); inlined != nil {
+				content[name] = inlined
+			} else {
+				delete(content, name)
+			}
+			continue
+		}
+		d.mediaType(mt, ptr(p, name))
+	}
+}
+
+// inline returns a copy of the media type component a reference points to,
+// stripped, or nil when it cannot be resolved
+func (d *downgrader32) inline(ref, p string) *oa32.MediaType {
+	name, ok := strings.CutPrefix(ref, "#/components/mediaTypes/")
+	var target *oa32.MediaType
+	if ok && d.doc.Components != nil {
+		name = strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~")
+		target = d.doc.Components.MediaTypes[name]
+	}
+	if target == nil || d.inlining[name] {
+		d.report.add(p, SeverityError, "media type reference "+ref+" cannot be inlined and was dropped")
+		return nil
+	}
+	d.report.add(p, SeverityInfo, "media type reference "+ref+" was inlined")
+	d.inlining[name] = true
+	defer delete(d.inlining, name)
+	if target.Ref != "" {
+		return d.inline(target.Ref, p)
+	}
+	inlined := target.Clone()
+	d.mediaType(inlined, p)
+	return inlined
+}
+
+func (d *downgrader32) mediaType(mt *oa32.MediaType, p string) {
+	if mt == nil {
+		return
+	}
+	mt.Summary = ""
+	if mt.Description != "" {
+		d.drop(ptr(p, "description"), SeverityWarning, "media type description")
+		mt.Description = ""
+	}
+	d.schema(mt.Schema, ptr(p, "schema"))
+	if mt.ItemSchema != nil {
+		d.schema(mt.ItemSchema, ptr(p, "itemSchema"))
+		if mt.Schema == nil {
+			d.report.add(ptr(p, "itemSchema"), SeverityWarning, "itemSchema was approximated with the schema of an array of its items")
+			mt.Schema = oa32.NewArraySchema(mt.ItemSchema)
+		} else {
+			d.drop(ptr(p, "itemSchema"), SeverityWarning, "itemSchema next to schema")
+		}
+		mt.ItemSchema = nil
+	}
+	d.examples(mt.Examples, ptr(p, "examples"))
+	for _, name := range slices.Sorted(maps.Keys(mt.Encoding)) {
+		d.encoding(mt.Encoding[name], ptr(p, "encoding", name))
+	}
+	d.positionalEncoding(&mt.PrefixEncoding, &mt.ItemEncoding, p)
+}
+
+func (d *downgrader32) encoding(enc *oa32.Encoding, p string) {
+	if enc == nil {
+		return
+	}
+	d.headers(enc.Headers, ptr(p, "headers"))
+	if len(enc.Encoding) > 0 {
+		d.drop(ptr(p, "encoding"), SeverityWarning, "nested encoding")
+		enc.Encoding = nil
+	}
+	d.positionalEncoding(&enc.PrefixEncoding, &enc.ItemEncoding, p)
+}
+
+// positionalEncoding drops prefixEncoding and itemEncoding
+func (d *downgrader32) positionalEncoding(prefix *[]*oa32.Encoding, item **oa32.Encoding, p string) {
+	if len(*prefix) > 0 {
+		d.drop(ptr(p, "prefixEncoding"), SeverityWarning, "prefixEncoding")
+		*prefix = nil
+	}
+	if *item != nil {
+		d.drop(ptr(p, "itemEncoding"), SeverityWarning, "itemEncoding")
+		*item = nil
+	}
+}
+
+func (d *downgrader32) examples(examples map[string]*oa32.Example, p string) {
+	for _, name := range slices.Sorted(maps.Keys(examples)) {
+		d.example(examples[name], ptr(p, name))
+	}
+}
+
+func (d *downgrader32) example(ex *oa32.Example, p string) {
+	if ex == nil {
+		return
+	}
+	if ex.DataValue != nil {
+		if ex.Value == nil && ex.ExternalValue == "" {
+			d.report.add(ptr(p, "dataValue"), SeverityInfo, "dataValue became value")
+			ex.Value = ex.DataValue
+		} else {
+			d.drop(ptr(p, "dataValue"), SeverityWarning, "dataValue next to value")
+		}
+		ex.DataValue = nil
+	}
+	if ex.SerializedValue != "" {
+		d.drop(ptr(p, "serializedValue"), SeverityWarning, "serializedValue")
+		ex.SerializedValue = ""
+	}
+}
+
+func (d *downgrader32) components(c *oa32.Components) {
+	if c == nil {
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Schemas)) {
+		d.schema(c.Schemas[name], ptr("/components/schemas", name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Responses)) {
+		d.response(c.Responses[name], ptr("/components/responses", name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Parameters)) {
+		param, p := c.Parameters[name], ptr("/components/parameters", name)
+		if param != nil && param.In == "querystring" {
+			d.drop(p, SeverityError, "the querystring parameter")
+			delete(c.Parameters, name)
+			continue
+		}
+		d.parameter(param, p)
+	}
+	d.examples(c.Examples, "/components/examples")
+	for _, name := range slices.Sorted(maps.Keys(c.RequestBodies)) {
+		d.requestBody(c.RequestBodies[name], ptr("/components/requestBodies", name))
+	}
+	d.headers(c.Headers, "/components/headers")
+	for _, name := range slices.Sorted(maps.Keys(c.SecuritySchemes)) {
+		d.securityScheme(c.SecuritySchemes[name], ptr("/components/securitySchemes", name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Links)) {
+		if l := c.Links[name]; l != nil && l.Server != nil {
+			d.servers([]*oa32.Server{l.Server}, ptr("/components/links", name, "server"))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Callbacks)) {
+		d.callback(c.Callbacks[name], ptr("/components/callbacks", name))
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.PathItems)) {
+		d.pathItem(c.PathItems[name], ptr("/components/pathItems", name))
+	}
+}
+
+func (d *downgrader32) securityScheme(ss *oa32.SecurityScheme, p string) {
+	if ss == nil {
+		return
+	}
+	if ss.Deprecated {
+		d.drop(ptr(p, "deprecated"), SeverityWarning, "deprecated")
+		ss.Deprecated = false
+	}
+	if ss.Flows != nil && ss.Flows.DeviceAuthorization != nil {
+		d.drop(ptr(p, "flows", "deviceAuthorization"), SeverityError, "the device authorization flow")
+		ss.Flows.DeviceAuthorization = nil
+	}
+}
+
+func (d *downgrader32) tag(tag *oa32.Tag, p string) {
+	if tag == nil {
+		return
+	}
+	if tag.Summary != "" {
+		d.drop(ptr(p, "summary"), SeverityWarning, "tag summary")
+		tag.Summary = ""
+	}
+	if tag.Parent != "" {
+		d.drop(ptr(p, "parent"), SeverityWarning, "tag parent")
+		tag.Parent = ""
+	}
+	if tag.Kind != "" {
+		d.drop(ptr(p, "kind"), SeverityWarning, "tag kind")
+		tag.Kind = ""
+	}
+}
+
+// schema strips defaultMapping and nodeType, which 3.1 lacks, from a schema
+// and its subschemas
+func (d *downgrader32) schema(s *oa32.Schema, p string) {
+	if s == nil {
+		return
+	}
+	if s.Discriminator != nil && s.Discriminator.DefaultMapping != "" {
+		d.drop(ptr(p, "discriminator", "defaultMapping"), SeverityWarning, "defaultMapping")
+		s.Discriminator.DefaultMapping = ""
+	}
+	if s.XML != nil && s.XML.NodeType != "" {
+		switch s.XML.NodeType {
+		case "attribute":
+			d.report.add(ptr(p, "xml", "nodeType"), SeverityInfo, "nodeType attribute became attribute: true")
+			s.XML.Attribute = true
+		case "element":
+			if s.Type != nil && (s.Type.String == "array" || slices.Contains(s.Type.Array, "array")) {
+				d.report.add(ptr(p, "xml", "nodeType"), SeverityInfo, "nodeType element became wrapped: true")
+				s.XML.Wrapped = true
+			}
+		default:
+			d.drop(ptr(p, "xml", "nodeType"), SeverityWarning, "nodeType "+s.XML.NodeType)
+		}
+		s.XML.NodeType = ""
+	}
+	for _, k := range slices.Sorted(maps.Keys(s.Defs)) {
+		d.schema(s.Defs[k], ptr(p, "$defs", k))
+	}
+	lists := []struct {
+		keyword string
+		list    []*oa32.Schema
+	}{{"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}, {"prefixItems", s.PrefixItems}}
+	for _, l := range lists {
+		for i, sub := range l.list {
+			d.schema(sub, ptr(p, l.keyword, strconv.Itoa(i)))
+		}
+	}
+	subs := []struct {
+		keyword string
+		sub     *oa32.Schema
+	}{
+		{"not", s.Not}, {"if", s.If}, {"then", s.Then}, {"else", s.Else}, {"items", s.Items}, {"contains", s.Contains},
+		{"additionalProperties", s.AdditionalProperties}, {"propertyNames", s.PropertyNames},
+		{"unevaluatedItems", s.UnevaluatedItems}, {"unevaluatedProperties", s.UnevaluatedProperties}, {"contentSchema", s.ContentSchema},
+	}
+	for _, sub := range subs {
+		d.schema(sub.sub, ptr(p, sub.keyword))
+	}
+	named := []struct {
+		keyword string
+		subs    map[string]*oa32.Schema
+	}{{"properties", s.Properties}, {"patternProperties", s.PatternProperties}, {"dependentSchemas", s.DependentSchemas}}
+	for _, n := range named {
+		for _, k := range slices.Sorted(maps.Keys(n.subs)) {
+			d.schema(n.subs[k], ptr(p, n.keyword, k))
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+	"testing"
+
+	oa32 "github.com/genelet/oas/openapi32"
+)
+
+func TestDowngrade32To31(t *testing.T) {
+	data := `{
+		"openapi": "3.2.0",
+		"$self": "https://example.com/openapi.json",
+		"info": {"title": "Test", "version": "1.0"},
+		"servers": [{"url": "https://api.example.com", "name": "production"}],
+		"tags": [{"name": "cats", "summary": "Cats", "parent": "pets", "kind": "nav"}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{"name": "filter", "in": "querystring", "content": {"application/json": {"schema": {"type": "object"}}}},
+						{"name": "limit", "in": "query", "schema": {"type": "integer"}}
+					],
+					"responses": {"200": {
+						"summary": "Pets",
+						"description": "OK",
+						"content": {
+							"application/json": {"$ref": "#/components/mediaTypes/Pets"},
+							"application/jsonl": {"itemSchema": {"$ref": "#/components/schemas/Pet"}}
+						}
+					}}
+				},
+				"query": {"responses": {"200": {"description": "OK"}}},
+				"additionalOperations": {"LINK": {"responses": {"204": {"description": "Linked"}}}}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"discriminator": {"propertyName": "kind", "defaultMapping": "#/components/schemas/Pet"},
+					"properties": {
+						"id": {"type": "integer", "xml": {"nodeType": "attribute"}},
+						"tags": {"type": "array", "items": {"type": "string"}, "xml": {"nodeType": "element"}}
+					}
+				}
+			},
+			"mediaTypes": {
+				"Pets": {
+					"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+					"examples": {"one": {"dataValue": [{"id": 1}], "serializedValue": "[{\"id\":1}]"}}
+				}
+			},
+			"securitySchemes": {
+				"oauth": {"type": "oauth2", "deprecated": true, "flows": {
+					"deviceAuthorization": {"deviceAuthorizationUrl": "https://example.com/device", "tokenUrl": "https://example.com/token", "scopes": {}},
+					"clientCredentials": {"tokenUrl": "https://example.com/token", "scopes": {}}
+				}}
+			}
+		}
+	}`
+	var doc oa32.OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	out, report := Downgrade32To31(&doc, nil)
+	if out == nil || out.OpenAPI != "3.1.1" {
+		t.Fatalf("Expected openapi 3.1.1, got %+v", out)
+	}
+	if doc.Paths.Get("/pets").Query == nil || doc.Components.MediaTypes == nil {
+		t.Error("Expected the source document left alone")
+	}
+
+	item := out.Paths.Get("/pets")
+	if len(item.Get.Parameters) != 1 || item.Get.Parameters[0].Name != "limit" {
+		t.Errorf("Expected only the limit parameter, got %v", item.Get.Parameters)
+	}
+	content := item.Get.Responses.StatusCode["200"].Content
+	mt := content["application/json"]
+	if mt == nil || mt.Schema == nil || mt.Schema.Items == nil || mt.Schema.Items.Ref != "#/components/schemas/Pet" {
+		t.Errorf("Expected the media type component inlined, got %+v", mt)
+	} else if ex := mt.Examples["one"]; ex == nil || ex.Value == nil {
+		t.Errorf("Expected dataValue as value, got %+v", ex)
+	}
+	if jsonl := content["application/jsonl"]; jsonl == nil || jsonl.Schema == nil || jsonl.Schema.Items == nil {
+		t.Errorf("Expected itemSchema approximated with an array, got %+v", jsonl)
+	}
+	properties := out.Components.Schemas["Pet"].Properties
+	if !properties["id"].XML.Attribute || !properties["tags"].XML.Wrapped {
+		t.Errorf("Expected nodeType as attribute and wrapped, got %+v %+v", properties["id"].XML, properties["tags"].XML)
+	}
+	if flows := out.Components.SecuritySchemes["oauth"].Flows; flows.ClientCredentials == nil {
+		t.Error("Expected the client credentials flow kept")
+	}
+
+	for _, pointer := range []string{
+		"/$self",
+		"/servers/0/name",
+		"/tags/0/summary",
+		"/tags/0/parent",
+		"/tags/0/kind",
+		"/paths/~1pets/get/parameters/0",
+		"/paths/~1pets/get/responses/200/summary",
+		"/paths/~1pets/get/responses/200/content/application~1json",
+		"/paths/~1pets/get/responses/200/content/application~1json/examples/one/dataValue",
+		"/paths/~1pets/get/responses/200/content/application~1json/examples/one/serializedValue",
+		"/paths/~1pets/get/responses/200/content/application~1jsonl/itemSchema",
+		"/paths/~1pets/query",
+		"/paths/~1pets/additionalOperations/LINK",
+		"/components/schemas/Pet/discriminator/defaultMapping",
+		"/components/schemas/Pet/properties/id/xml/nodeType",
+		"/components/securitySchemes/oauth/deprecated",
+		"/components/securitySchemes/oauth/flows/deviceAuthorization",
+		"/components/mediaTypes",
+	} {
+		if !hasIssue(report, pointer) {
+			t.Errorf("Expected an issue at %s", pointer)
+		}
+	}
+	if !report.HasSeverity(SeverityError) {
+		t.Error("Expected the dropped operations reported as errors")
+	}
+
+	if out, _ := Downgrade32To31(&doc, &DowngradeOptions{OpenAPIVersion: "3.1.0"}); out.OpenAPI != "3.1.0" {
+		t.Errorf("Expected openapi 3.1.0, got %s", out.OpenAPI)
+	}
+	if out, report := Downgrade32To31(nil, nil); out != nil || !report.Lossless() {
+		t.Error("Expected nothing for no document")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// ExclusiveToNumeric converts a Draft 4 bound and its boolean exclusive flag,
+// as used by 2.0 and 3.0, into the 2020-12 form used by 3.1. Exactly one of
+// the returned inclusive and exclusive bounds is set when bound is not nil.
+func ExclusiveToNumeric(bound *float64, exclusive bool) (inclusive, exclusiveBound *float64) {
+	if bound == nil {
+		return nil, nil
+	}
+	if exclusive {
+		return nil, copyFloat(bound)
+	}
+	return copyFloat(bound), nil
+}
+
+// NumericMinimumToExclusive converts 2020-12 minimum and exclusiveMinimum into
+// a Draft 4 minimum and boolean flag, keeping whichever bound is stricter.
+func NumericMinimumToExclusive(minimum, exclusiveMinimum *float64) (bound *float64, exclusive bool) {
+	if exclusiveMinimum != nil && (minimum == nil || *minimum <= *exclusiveMinimum) {
+		return copyFloat(exclusiveMinimum), true
+	}
+	return copyFloat(minimum), false
+}
+
+// NumericMaximumToExclusive converts 2020-12 maximum and exclusiveMaximum into
+// a Draft 4 maximum and boolean flag, keeping whichever bound is stricter.
+func NumericMaximumToExclusive(maximum, exclusiveMaximum *float64) (bound *float64, exclusive bool) {
+	if exclusiveMaximum != nil && (maximum == nil || *maximum >= *exclusiveMaximum) {
+		return copyFloat(exclusiveMaximum), true
+	}
+	return copyFloat(maximum), false
+}
+
+// ApplyExclusiveBounds31 sets the numeric bounds of a 3.1 schema from the
+// Draft 4 bounds of a 3.0 schema
+func ApplyExclusiveBounds31(dst *oa31.Schema, src *oa3.Schema) {
+	if dst == nil || src == nil {
+		return
+	}
+	dst.Minimum, dst.ExclusiveMinimum = ExclusiveToNumeric(src.Minimum, src.ExclusiveMinimum)
+	dst.Maximum, dst.ExclusiveMaximum = ExclusiveToNumeric(src.Maximum, src.ExclusiveMaximum)
+}
+
+// ApplyExclusiveBounds30 sets the Draft 4 bounds of a 3.0 schema from the
+// numeric bounds of a 3.1 schema
+func ApplyExclusiveBounds30(dst *oa3.Schema, src *oa31.Schema) {
+	if dst == nil || src == nil {
+		return
+	}
+	dst.Minimum, dst.ExclusiveMinimum = NumericMinimumToExclusive(src.Minimum, src.ExclusiveMinimum)
+	dst.Maximum, dst.ExclusiveMaximum = NumericMaximumToExclusive(src.Maximum, src.ExclusiveMaximum)
+}
+
+// ExclusiveBoundsToNumeric rewrites every boolean exclusiveMinimum and
+// exclusiveMaximum in a JSON document into the 2020-12 numeric form. It lets
+// documents that mix 3.0 schema syntax into a 3.1 document be parsed as 3.1.
+func ExclusiveBoundsToNumeric(data []byte) ([]byte, error) {
+	return rewriteBounds(data, boundsToNumeric)
+}
+
+// ExclusiveBoundsToBoolean rewrites every numeric exclusiveMinimum and
+// exclusiveMaximum in a JSON document into the Draft 4 boolean form, so that
+// the document can be parsed as 2.0 or 3.0.
+func ExclusiveBoundsToBoolean(data []byte) ([]byte, error) {
+	return rewriteBounds(data, boundsToBoolean)
+}
+
+// literalKeywords hold instance data rather than schemas and are not rewritten
+var literalKeywords = map[string]bool{
+	"default": true, "enum": true, "const": true, "example": true, "examples": true,
+}
+
+func rewriteBounds(data []byte, rewrite func(map[string]any)) ([]byte, error) {
+	var root any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	walkBounds(root, rewrite)
+	return json.Marshal(root)
+}
+
+func walkBounds(node any, rewrite func(map[string]any)) {
+	switch v := node.(type) {
+	case map[string]any:
+		rewrite(v)
+		for k, child := range v {
+			if !literalKeywords[k] {
+				walkBounds(child, rewrite)
+			}
+		}
+	case []any:
+		for _, child := range v {
+			walkBounds(child, rewrite)
+		}
+	}
+}
+
+func boundsToNumeric(m map[string]any) {
+	for _, kw := range [][2]string{{"minimum", "exclusiveMinimum"}, {"maximum", "exclusiveMaximum"}} {
+		exclusive, ok := m[kw[1]].(bool)
+		if !ok {
+			continue
+		}
+		delete(m, kw[1])
+		if bound, ok := m[kw[0]].(float64); ok && exclusive {
+			delete(m, kw[0])
+			m[kw[1]] = bound
+		}
+	}
+}
+
+func boundsToBoolean(m map[string]any) {
+	exclusiveMinimum, okMin := m["exclusiveMinimum"].(float64)
+	if okMin {
+		bound, exclusive := NumericMinimumToExclusive(jsonFloat(m["minimum"]), &exclusiveMinimum)
+		m["minimum"] = *bound
+		if exclusive {
+			m["exclusiveMinimum"] = true
+		} else {
+			delete(m, "exclusiveMinimum")
+		}
+	}
+	exclusiveMaximum, okMax := m["exclusiveMaximum"].(float64)
+	if okMax {
+		bound, exclusive := NumericMaximumToExclusive(jsonFloat(m["maximum"]), &exclusiveMaximum)
+		m["maximum"] = *bound
+		if exclusive {
+			m["exclusiveMaximum"] = true
+		} else {
+			delete(m, "exclusiveMaximum")
+		}
+	}
+}
+
+func jsonFloat(v any) *float64 {
+	if f, ok := v.(float64); ok {
+		return &f
+	}
+	return nil
+}
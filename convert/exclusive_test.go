@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+func TestExclusiveBoundsSchema(t *testing.T) {
+	tests := []struct {
+		name string
+		src  *oa3.Schema
+		min  *float64
+		xmin *float64
+		max  *float64
+		xmax *float64
+	}{
+		{"inclusive", &oa3.Schema{Minimum: float64Ptr(1), Maximum: float64Ptr(9)}, float64Ptr(1), nil, float64Ptr(9), nil},
+		{"exclusive", &oa3.Schema{Minimum: float64Ptr(1), ExclusiveMinimum: true, Maximum: float64Ptr(9), ExclusiveMaximum: true}, nil, float64Ptr(1), nil, float64Ptr(9)},
+		{"flag without bound", &oa3.Schema{ExclusiveMinimum: true}, nil, nil, nil, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s31 := &oa31.Schema{}
+			ApplyExclusiveBounds31(s31, tc.src)
+			if !floatEqual(s31.Minimum, tc.min) || !floatEqual(s31.ExclusiveMinimum, tc.xmin) ||
+				!floatEqual(s31.Maximum, tc.max) || !floatEqual(s31.ExclusiveMaximum, tc.xmax) {
+				t.Errorf("Unexpected 3.1 bounds: %+v", s31)
+			}
+
+			back := &oa3.Schema{}
+			ApplyExclusiveBounds30(back, s31)
+			if !floatEqual(back.Minimum, tc.src.Minimum) || !floatEqual(back.Maximum, tc.src.Maximum) ||
+				back.ExclusiveMinimum != (tc.src.ExclusiveMinimum && tc.src.Minimum != nil) ||
+				back.ExclusiveMaximum != (tc.src.ExclusiveMaximum && tc.src.Maximum != nil) {
+				t.Errorf("Expected %+v after round trip, got %+v", tc.src, back)
+			}
+		})
+	}
+}
+
+func TestNumericToExclusiveStricterBound(t *testing.T) {
+	bound, exclusive := NumericMinimumToExclusive(float64Ptr(5), float64Ptr(3))
+	if *bound != 5 || exclusive {
+		t.Errorf("Expected inclusive minimum 5, got %v exclusive=%v", *bound, exclusive)
+	}
+	bound, exclusive = NumericMaximumToExclusive(float64Ptr(5), float64Ptr(3))
+	if *bound != 3 || !exclusive {
+		t.Errorf("Expected exclusive maximum 3, got %v exclusive=%v", *bound, exclusive)
+	}
+}
+
+func TestExclusiveBoundsMixedSyntaxFiles(t *testing.T) {
+	for _, name := range []string{"schema-validation-local.json", "schema-validation-top-level.json"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("../openapi31/oas-examples/json", name))
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+			var doc oa31.OpenAPI
+			if err := json.Unmarshal(data, &doc); err == nil {
+				t.Fatal("Expected mixed syntax to fail as 3.1")
+			}
+
+			numeric, err := ExclusiveBoundsToNumeric(data)
+			if err != nil {
+				t.Fatalf("Failed to rewrite bounds: %v", err)
+			}
+			if err := json.Unmarshal(numeric, &doc); err != nil {
+				t.Fatalf("Failed to unmarshal rewritten document as 3.1: %v", err)
+			}
+
+			boolean, err := ExclusiveBoundsToBoolean(numeric)
+			if err != nil {
+				t.Fatalf("Failed to rewrite bounds: %v", err)
+			}
+			var doc30 oa3.OpenAPI
+			if err := json.Unmarshal(boolean, &doc30); err != nil {
+				t.Fatalf("Failed to unmarshal rewritten document as 3.0: %v", err)
+			}
+		})
+	}
+}
+
+func TestExclusiveBoundsToNumeric(t *testing.T) {
+	data := []byte(`{"minimum": 10, "exclusiveMinimum": true, "maximum": 20, "exclusiveMaximum": false,
+		"example": {"minimum": 1, "exclusiveMinimum": true}}`)
+	out, err := ExclusiveBoundsToNumeric(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["minimum"]; ok || got["exclusiveMinimum"] != float64(10) {
+		t.Errorf("Expected exclusiveMinimum 10, got %v", got)
+	}
+	if _, ok := got["exclusiveMaximum"]; ok || got["maximum"] != float64(20) {
+		t.Errorf("Expected inclusive maximum 20, got %v", got)
+	}
+	if example := got["example"].(map[string]any); example["exclusiveMinimum"] != true {
+		t.Errorf("Expected example values to be left alone, got %v", example)
+	}
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func floatEqual(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// NullableToTypeArray converts a 3.0 type and nullable flag into a 3.1 type.
+// A nullable type becomes a [type, "null"] array; a nullable schema without
+// a type becomes ["null"]. It returns nil when there is nothing to express.
+func NullableToTypeArray(typ string, nullable bool) *oa31.StringOrStringArray {
+	switch {
+	case typ == "" && !nullable:
+		return nil
+	case typ == "":
+		return &oa31.StringOrStringArray{Array: []string{"null"}}
+	case !nullable:
+		return &oa31.StringOrStringArray{String: typ}
+	}
+	return &oa31.StringOrStringArray{Array: []string{typ, "null"}}
+}
+
+// TypeArrayToNullable splits a 3.1 type into its non-null types and a nullable flag.
+// More than one returned type means the schema has no single-type 3.0 equivalent.
+func TypeArrayToNullable(t *oa31.StringOrStringArray) (types []string, nullable bool) {
+	if t.IsEmpty() {
+		return nil, false
+	}
+	all := t.Array
+	if t.String != "" {
+		all = []string{t.String}
+	}
+	for _, typ := range all {
+		if typ == "null" {
+			nullable = true
+		} else {
+			types = append(types, typ)
+		}
+	}
+	return types, nullable
+}
+
+// IsNullable30 returns true if a 3.0 schema allows null
+func IsNullable30(s *oa3.Schema) bool {
+	return s != nil && s.Nullable
+}
+
+// IsNullable31 returns true if a 3.1 schema's type allows null
+func IsNullable31(s *oa31.Schema) bool {
+	return s != nil && s.Type.Contains("null")
+}
+
+// ApplyNullable31 sets the type of a 3.1 schema from the type and nullable
+// keywords of a 3.0 schema. Because a 3.1 enum is checked independently of
+// the type, null is added to the enum of a nullable enum schema.
+func ApplyNullable31(dst *oa31.Schema, src *oa3.Schema) {
+	if dst == nil || src == nil {
+		return
+	}
+	dst.Type = NullableToTypeArray(src.Type, src.Nullable)
+	if src.Nullable && len(dst.Enum) > 0 && !containsNil(dst.Enum) {
+		dst.Enum = append(append([]any(nil), dst.Enum...), nil)
+	}
+}
+
+// ApplyNullable30 sets the type and nullable keywords of a 3.0 schema from
+// the type of a 3.1 schema. It returns false, leaving the type unset, when
+// the 3.1 schema lists more than one non-null type.
+func ApplyNullable30(dst *oa3.Schema, src *oa31.Schema) bool {
+	if dst == nil || src == nil {
+		return true
+	}
+	types, nullable := TypeArrayToNullable(src.Type)
+	dst.Nullable = nullable
+	switch len(types) {
+	case 0:
+		dst.Type = ""
+	case 1:
+		dst.Type = types[0]
+	default:
+		dst.Type = ""
+		return false
+	}
+	return true
+}
+
+func containsNil(values []any) bool {
+	for _, v := range values {
+		if v == nil {
+			return true
+		}
+	}
+	return false
+}
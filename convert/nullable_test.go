@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"reflect"
+	"testing"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+func TestNullableToTypeArray(t *testing.T) {
+	tests := []struct {
+		typ      string
+		nullable bool
+		want     *oa31.StringOrStringArray
+	}{
+		{"", false, nil},
+		{"", true, &oa31.StringOrStringArray{Array: []string{"null"}}},
+		{"string", false, &oa31.StringOrStringArray{String: "string"}},
+		{"string", true, &oa31.StringOrStringArray{Array: []string{"string", "null"}}},
+	}
+
+	for _, tc := range tests {
+		got := NullableToTypeArray(tc.typ, tc.nullable)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("NullableToTypeArray(%q, %v) = %+v, want %+v", tc.typ, tc.nullable, got, tc.want)
+		}
+	}
+}
+
+func TestTypeArrayToNullable(t *testing.T) {
+	tests := []struct {
+		in       *oa31.StringOrStringArray
+		types    []string
+		nullable bool
+	}{
+		{nil, nil, false},
+		{&oa31.StringOrStringArray{String: "integer"}, []string{"integer"}, false},
+		{&oa31.StringOrStringArray{Array: []string{"null", "integer"}}, []string{"integer"}, true},
+		{&oa31.StringOrStringArray{Array: []string{"string", "integer", "null"}}, []string{"string", "integer"}, true},
+	}
+
+	for _, tc := range tests {
+		types, nullable := TypeArrayToNullable(tc.in)
+		if !reflect.DeepEqual(types, tc.types) || nullable != tc.nullable {
+			t.Errorf("TypeArrayToNullable(%+v) = %v, %v; want %v, %v", tc.in, types, nullable, tc.types, tc.nullable)
+		}
+	}
+}
+
+func TestApplyNullableRoundTrip(t *testing.T) {
+	src := &oa3.Schema{Type: "string", Nullable: true, Enum: []any{"a", "b"}}
+
+	s31 := &oa31.Schema{Enum: src.Enum}
+	ApplyNullable31(s31, src)
+	if !IsNullable31(s31) || !s31.Type.Contains("string") {
+		t.Errorf("Expected nullable string, got %+v", s31.Type)
+	}
+	if len(s31.Enum) != 3 || s31.Enum[2] != nil {
+		t.Errorf("Expected null appended to enum, got %v", s31.Enum)
+	}
+	if len(src.Enum) != 2 {
+		t.Error("Source enum must not be modified")
+	}
+
+	s30 := &oa3.Schema{}
+	if !ApplyNullable30(s30, s31) {
+		t.Fatal("Expected single type conversion to succeed")
+	}
+	if !IsNullable30(s30) || s30.Type != "string" {
+		t.Errorf("Expected nullable string, got type=%q nullable=%v", s30.Type, s30.Nullable)
+	}
+
+	multi := &oa31.Schema{Type: &oa31.StringOrStringArray{Array: []string{"string", "integer"}}}
+	if ApplyNullable30(&oa3.Schema{}, multi) {
+		t.Error("Expected multiple types to be reported as not representable")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity classifies how much of the source meaning a conversion issue loses
+type Severity int
+
+const (
+	// SeverityInfo means the construct was expressed differently without losing meaning
+	SeverityInfo Severity = iota
+	// SeverityWarning means documentation was dropped or the construct was approximated
+	SeverityWarning
+	// SeverityError means the converted document accepts or describes different data
+	SeverityError
+)
+
+var severityNames = []string{"info", "warning", "error"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// MarshalText encodes the severity by name
+func (s Severity) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(severityNames) {
+		return nil, fmt.Errorf("invalid severity %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity name
+func (s *Severity) UnmarshalText(text []byte) error {
+	sev, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sev
+	return nil
+}
+
+// ParseSeverity returns the severity with the given name
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(name, n) {
+			return Severity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// ConversionIssue describes a construct that could not be represented exactly in the target version
+type ConversionIssue struct {
+	Pointer  string   `json:"pointer"` // JSON pointer into the source document
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (i ConversionIssue) String() string {
+	if i.Pointer == "" {
+		return fmt.Sprintf("[%s] %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s", i.Severity, i.Pointer, i.Message)
+}
+
+// ConversionReport lists everything that was dropped or approximated during a conversion
+type ConversionReport struct {
+	Issues []ConversionIssue `json:"issues"`
+}
+
+// Lossless returns true if the conversion did not drop or approximate anything
+func (r *ConversionReport) Lossless() bool {
+	return r == nil || len(r.Issues) == 0
+}
+
+// HasSeverity returns true if any issue is at least as severe as min.
+// CI jobs can use it to fail on unacceptable losses.
+func (r *ConversionReport) HasSeverity(min Severity) bool {
+	return len(r.Filter(min)) > 0
+}
+
+// Filter returns the issues that are at least as severe as min
+func (r *ConversionReport) Filter(min Severity) []ConversionIssue {
+	if r == nil {
+		return nil
+	}
+	var issues []ConversionIssue
+	for _, i := range r.Issues {
+		if i.Severity >= min {
+			issues = append(issues, i)
+		}
+	}
+	return issues
+}
+
+// String returns a combined description of all issues
+func (r *ConversionReport) String() string {
+	if r.Lossless() {
+		return ""
+	}
+	var msgs []string
+	for _, i := range r.Issues {
+		msgs = append(msgs, i.String())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (r *ConversionReport) add(pointer string, severity Severity, message string) {
+	r.Issues = append(r.Issues, ConversionIssue{Pointer: pointer, Severity: severity, Message: message})
+}
+
+// merge appends the issues of other reports
+func (r *ConversionReport) merge(others ...*ConversionReport) {
+	for _, o := range others {
+		if o != nil {
+			r.Issues = append(r.Issues, o.Issues...)
+		}
+	}
+}
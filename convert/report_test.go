@@ -0,0 +1,55 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConversionReport(t *testing.T) {
+	var empty *ConversionReport
+	if !empty.Lossless() || empty.HasSeverity(SeverityInfo) || empty.String() != "" {
+		t.Error("Expected nil report to be lossless")
+	}
+
+	r := &ConversionReport{}
+	r.add("/info/summary", SeverityWarning, "dropped")
+	r.add("", SeverityInfo, "renamed")
+
+	if r.Lossless() {
+		t.Error("Expected report with issues to be lossy")
+	}
+	if !r.HasSeverity(SeverityWarning) || r.HasSeverity(SeverityError) {
+		t.Error("Expected highest severity to be warning")
+	}
+	if got := r.Filter(SeverityWarning); len(got) != 1 || got[0].Pointer != "/info/summary" {
+		t.Errorf("Expected one warning, got %v", got)
+	}
+	if got := r.String(); got != "[warning] /info/summary: dropped; [info] renamed" {
+		t.Errorf("Unexpected report string: %s", got)
+	}
+}
+
+func TestSeverityJSON(t *testing.T) {
+	data, err := json.Marshal(ConversionIssue{Pointer: "/webhooks", Severity: SeverityError, Message: "dropped"})
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if string(data) != `{"pointer":"/webhooks","severity":"error","message":"dropped"}` {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	var issue ConversionIssue
+	if err := json.Unmarshal(data, &issue); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if issue.Severity != SeverityError {
+		t.Errorf("Expected error severity, got %s", issue.Severity)
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Error("Expected unknown severity to fail")
+	}
+}
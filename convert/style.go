@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+// DefaultStyle returns the 3.x serialization style used when a parameter in
+// the given location does not declare one
+func DefaultStyle(in string) string {
+	switch in {
+	case "path", "header":
+		return "simple"
+	case "query", "cookie", "formData":
+		return "form"
+	}
+	return ""
+}
+
+// CollectionFormatToStyle maps a 2.0 collectionFormat of a parameter in the
+// given location to the equivalent 3.x style and explode values. An empty
+// collectionFormat means the 2.0 default csv. It returns ok false for tsv,
+// which has no 3.x equivalent, and for unknown formats.
+func CollectionFormatToStyle(collectionFormat, in string) (style string, explode bool, ok bool) {
+	switch collectionFormat {
+	case "", "csv":
+		if in == "path" || in == "header" {
+			return "simple", false, true
+		}
+		return "form", false, true
+	case "ssv":
+		return "spaceDelimited", false, true
+	case "pipes":
+		return "pipeDelimited", false, true
+	case "multi":
+		return "form", true, true
+	}
+	return "", false, false
+}
+
+// StyleToCollectionFormat maps a 3.x style and explode of an array parameter
+// in the given location to the equivalent 2.0 collectionFormat. An empty style
+// means the default style for the location. It returns ok false for styles
+// that 2.0 cannot express: label, matrix and deepObject, and exploded
+// arrays outside query and formData parameters.
+func StyleToCollectionFormat(style string, explode bool, in string) (collectionFormat string, ok bool) {
+	if style == "" {
+		style = DefaultStyle(in)
+	}
+	switch style {
+	case "simple":
+		// Exploding an array in simple style does not change its serialization
+		return "csv", true
+	case "form", "spaceDelimited", "pipeDelimited":
+		if explode {
+			if in != "query" && in != "formData" {
+				return "", false
+			}
+			return "multi", true
+		}
+		switch style {
+		case "spaceDelimited":
+			return "ssv", true
+		case "pipeDelimited":
+			return "pipes", true
+		}
+		return "csv", true
+	}
+	return "", false
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import "testing"
+
+func TestCollectionFormatToStyle(t *testing.T) {
+	tests := []struct {
+		format  string
+		in      string
+		style   string
+		explode bool
+		ok      bool
+	}{
+		{"", "query", "form", false, true},
+		{"csv", "query", "form", false, true},
+		{"csv", "path", "simple", false, true},
+		{"csv", "header", "simple", false, true},
+		{"ssv", "query", "spaceDelimited", false, true},
+		{"pipes", "query", "pipeDelimited", false, true},
+		{"multi", "query", "form", true, true},
+		{"multi", "formData", "form", true, true},
+		{"tsv", "query", "", false, false},
+	}
+
+	for _, tc := range tests {
+		style, explode, ok := CollectionFormatToStyle(tc.format, tc.in)
+		if style != tc.style || explode != tc.explode || ok != tc.ok {
+			t.Errorf("CollectionFormatToStyle(%q, %q) = %q, %v, %v; want %q, %v, %v",
+				tc.format, tc.in, style, explode, ok, tc.style, tc.explode, tc.ok)
+		}
+	}
+}
+
+func TestStyleToCollectionFormat(t *testing.T) {
+	tests := []struct {
+		style   string
+		explode bool
+		in      string
+		format  string
+		ok      bool
+	}{
+		{"", false, "query", "csv", true},
+		{"", true, "query", "multi", true},
+		{"", false, "path", "csv", true},
+		{"simple", true, "header", "csv", true},
+		{"form", false, "query", "csv", true},
+		{"form", true, "query", "multi", true},
+		{"spaceDelimited", false, "query", "ssv", true},
+		{"pipeDelimited", false, "query", "pipes", true},
+		{"form", true, "cookie", "", false},
+		{"matrix", false, "path", "", false},
+		{"label", false, "path", "", false},
+		{"deepObject", true, "query", "", false},
+	}
+
+	for _, tc := range tests {
+		format, ok := StyleToCollectionFormat(tc.style, tc.explode, tc.in)
+		if format != tc.format || ok != tc.ok {
+			t.Errorf("StyleToCollectionFormat(%q, %v, %q) = %q, %v; want %q, %v",
+				tc.style, tc.explode, tc.in, format, ok, tc.format, tc.ok)
+		}
+	}
+}
+
+func TestCollectionFormatRoundTrip(t *testing.T) {
+	for _, format := range []string{"csv", "ssv", "pipes", "multi"} {
+		style, explode, _ := CollectionFormatToStyle(format, "query")
+		if got, ok := StyleToCollectionFormat(style, explode, "query"); !ok || got != format {
+			t.Errorf("Expected %q after round trip, got %q", format, got)
+		}
+	}
+}
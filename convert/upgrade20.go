@@ -0,0 +1,717 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"fmt"
+	"strings"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// Upgrade20To30 converts a Swagger 2.0 document to OpenAPI 3.0.
+// Host, basePath and schemes become servers, body and formData parameters
+// become request bodies, consumes and produces become content maps, and
+// definitions, parameters, responses and securityDefinitions move into
+// components. A nil opts uses the defaults.
+func Upgrade20To30(doc *oa2.Swagger, opts *UpgradeOptions) (*oa3.OpenAPI, *ConversionReport) {
+	report := &ConversionReport{}
+	if doc == nil {
+		return nil, report
+	}
+	u := &upgrader20{src: doc, report: report}
+	if opts != nil {
+		u.opts = *opts
+	}
+	if u.opts.OpenAPIVersion == "" {
+		u.opts.OpenAPIVersion = "3.0.3"
+	}
+	return u.document(), report
+}
+
+// Upgrade20To31 converts a Swagger 2.0 document to OpenAPI 3.1 by way of 3.0.
+// A nil opts uses the defaults.
+func Upgrade20To31(doc *oa2.Swagger, opts *UpgradeOptions) (*oa31.OpenAPI, *ConversionReport) {
+	doc30, report := Upgrade20To30(doc, nil)
+	doc31, report31 := Upgrade30To31(doc30, opts)
+	report.merge(report31)
+	return doc31, report
+}
+
+// refPrefixes20 maps local 2.0 reference prefixes to their 3.x locations
+var refPrefixes20 = [][2]string{
+	{"#/definitions/", "#/components/schemas/"},
+	{"#/parameters/", "#/components/parameters/"},
+	{"#/responses/", "#/components/responses/"},
+}
+
+// upgradeRef20 rewrites a 2.0 reference to the matching 3.x location.
+// References into other documents keep their document part.
+func upgradeRef20(ref string) string {
+	i := strings.Index(ref, "#/")
+	if i < 0 {
+		return ref
+	}
+	for _, prefix := range refPrefixes20 {
+		if strings.HasPrefix(ref[i:], prefix[0]) {
+			return ref[:i] + prefix[1] + ref[i+len(prefix[0]):]
+		}
+	}
+	return ref
+}
+
+const parametersPrefix = "#/parameters/"
+
+type upgrader20 struct {
+	src    *oa2.Swagger
+	opts   UpgradeOptions
+	report *ConversionReport
+}
+
+func (u *upgrader20) document() *oa3.OpenAPI {
+	src := u.src
+	out := &oa3.OpenAPI{
+		OpenAPI:      u.opts.OpenAPIVersion,
+		Info:         u.info(src.Info),
+		Servers:      u.servers(src.Schemes),
+		Paths:        u.paths(src.Paths),
+		Components:   u.components(),
+		Security:     u.security(src.Security),
+		Tags:         u.tags(src.Tags),
+		ExternalDocs: u.externalDocs(src.ExternalDocs),
+		Extensions:   copyExtensions(src.Extensions),
+	}
+	return out
+}
+
+func (u *upgrader20) info(info *oa2.Info) *oa3.Info {
+	if info == nil {
+		return nil
+	}
+	out := &oa3.Info{
+		Title:          info.Title,
+		Description:    info.Description,
+		TermsOfService: info.TermsOfService,
+		Version:        info.Version,
+		Extensions:     copyExtensions(info.Extensions),
+	}
+	if info.Contact != nil {
+		out.Contact = &oa3.Contact{
+			Name:       info.Contact.Name,
+			URL:        info.Contact.URL,
+			Email:      info.Contact.Email,
+			Extensions: copyExtensions(info.Contact.Extensions),
+		}
+	}
+	if info.License != nil {
+		out.License = &oa3.License{
+			Name:       info.License.Name,
+			URL:        info.License.URL,
+			Extensions: copyExtensions(info.License.Extensions),
+		}
+	}
+	return out
+}
+
+// servers builds one server per scheme from host and basePath. Without
+// schemes the url is scheme-relative, and without a host it is a path.
+func (u *upgrader20) servers(schemes []string) []*oa3.Server {
+	if u.src.Host == "" && u.src.BasePath == "" && len(schemes) == 0 {
+		return nil
+	}
+	if u.src.Host == "" {
+		base := u.src.BasePath
+		if base == "" {
+			base = "/"
+		}
+		return []*oa3.Server{{URL: base}}
+	}
+	if len(schemes) == 0 {
+		return []*oa3.Server{{URL: "//" + u.src.Host + u.src.BasePath}}
+	}
+	out := make([]*oa3.Server, 0, len(schemes))
+	for _, scheme := range schemes {
+		out = append(out, &oa3.Server{URL: scheme + "://" + u.src.Host + u.src.BasePath})
+	}
+	return out
+}
+
+func (u *upgrader20) paths(paths *oa2.Paths) *oa3.Paths {
+	out := &oa3.Paths{}
+	if paths == nil {
+		return out
+	}
+	out.Extensions = copyExtensions(paths.Extensions)
+	if paths.Paths != nil {
+		out.Paths = make(map[string]*oa3.PathItem, len(paths.Paths))
+		for key, item := range paths.Paths {
+			out.Paths[key] = u.pathItem(item, ptr("/paths", key))
+		}
+	}
+	return out
+}
+
+func (u *upgrader20) pathItem(item *oa2.PathItem, p string) *oa3.PathItem {
+	if item == nil {
+		return nil
+	}
+	out := &oa3.PathItem{
+		Ref:        item.Ref,
+		Extensions: copyExtensions(item.Extensions),
+	}
+	// Body and formData parameters become part of each operation's request body
+	for i, param := range item.Parameters {
+		if !u.isPayload(param) {
+			out.Parameters = append(out.Parameters, u.parameter(param, ptr(p, "parameters", fmt.Sprint(i))))
+		}
+	}
+	out.Get = u.operation(item.Get, item.Parameters, p, "get")
+	out.Put = u.operation(item.Put, item.Parameters, p, "put")
+	out.Post = u.operation(item.Post, item.Parameters, p, "post")
+	out.Delete = u.operation(item.Delete, item.Parameters, p, "delete")
+	out.Options = u.operation(item.Options, item.Parameters, p, "options")
+	out.Head = u.operation(item.Head, item.Parameters, p, "head")
+	out.Patch = u.operation(item.Patch, item.Parameters, p, "patch")
+	return out
+}
+
+// resolveParameter returns the target of a local parameter reference
+func (u *upgrader20) resolveParameter(param *oa2.Parameter) *oa2.Parameter {
+	if param == nil || !strings.HasPrefix(param.Ref, parametersPrefix) {
+		return param
+	}
+	if target := u.src.Parameters[strings.TrimPrefix(param.Ref, parametersPrefix)]; target != nil {
+		return target
+	}
+	return param
+}
+
+// isPayload returns true for body and formData parameters
+func (u *upgrader20) isPayload(param *oa2.Parameter) bool {
+	param = u.resolveParameter(param)
+	return param != nil && (param.In == "body" || param.In == "formData")
+}
+
+func (u *upgrader20) operation(op *oa2.Operation, pathParams []*oa2.Parameter, itemPointer, method string) *oa3.Operation {
+	if op == nil {
+		return nil
+	}
+	p := ptr(itemPointer, method)
+	out := &oa3.Operation{
+		Tags:         copyStrings(op.Tags),
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: u.externalDocs(op.ExternalDocs),
+		OperationID:  op.OperationID,
+		Responses:    u.responses(op.Responses, EffectiveMediaTypes(op.Produces, u.src.Produces), p+"/responses"),
+		Deprecated:   op.Deprecated,
+		Security:     u.security(op.Security),
+		Extensions:   copyExtensions(op.Extensions),
+	}
+	if op.Schemes != nil {
+		out.Servers = u.servers(op.Schemes)
+	}
+
+	var body *oa2.Parameter
+	var bodyPointer string
+	var form []*oa2.Parameter
+	var formPointers []string
+	// Path level payload parameters apply unless the operation overrides them
+	for i, param := range pathParams {
+		if !u.isPayload(param) || u.overridden(param, op.Parameters) {
+			continue
+		}
+		if u.resolveParameter(param).In == "body" {
+			body, bodyPointer = param, ptr(itemPointer, "parameters", fmt.Sprint(i))
+		} else {
+			form = append(form, param)
+			formPointers = append(formPointers, ptr(itemPointer, "parameters", fmt.Sprint(i)))
+		}
+	}
+	for i, param := range op.Parameters {
+		pp := ptr(p, "parameters", fmt.Sprint(i))
+		switch {
+		case !u.isPayload(param):
+			out.Parameters = append(out.Parameters, u.parameter(param, pp))
+		case u.resolveParameter(param).In == "body":
+			body, bodyPointer = param, pp
+		default:
+			form = append(form, param)
+			formPointers = append(formPointers, pp)
+		}
+	}
+
+	consumes := EffectiveMediaTypes(op.Consumes, u.src.Consumes)
+	switch {
+	case body != nil:
+		out.RequestBody = u.bodyParameter(body, op.Consumes != nil, consumes, bodyPointer)
+	case len(form) > 0:
+		out.RequestBody = u.formParameters(form, consumes, formPointers)
+	}
+	return out
+}
+
+// overridden returns true if params redefine param by name and location
+func (u *upgrader20) overridden(param *oa2.Parameter, params []*oa2.Parameter) bool {
+	param = u.resolveParameter(param)
+	for _, other := range params {
+		other = u.resolveParameter(other)
+		if other != nil && other.Name == param.Name && other.In == param.In {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyParameter converts a body parameter into a request body. A reference
+// to a body parameter becomes a request body reference, unless the operation
+// declares its own consumes, which components request bodies cannot follow.
+func (u *upgrader20) bodyParameter(param *oa2.Parameter, ownConsumes bool, consumes []string, p string) *oa3.RequestBody {
+	if param.Ref != "" && !ownConsumes && strings.HasPrefix(param.Ref, parametersPrefix) {
+		return oa3.NewRequestBodyReference("#/components/requestBodies/" + strings.TrimPrefix(param.Ref, parametersPrefix))
+	}
+	target := u.resolveParameter(param)
+	if target.Ref != "" {
+		u.report.add(p+"/$ref", SeverityError, fmt.Sprintf("body parameter reference %s could not be resolved", target.Ref))
+		return nil
+	}
+	return &oa3.RequestBody{
+		Description: target.Description,
+		Required:    target.Required,
+		Content:     SchemaToContent30(consumes, u.schema(target.Schema, p+"/schema")),
+		Extensions:  copyExtensions(target.Extensions),
+	}
+}
+
+// formParameters combines formData parameters into an object schema
+func (u *upgrader20) formParameters(params []*oa2.Parameter, consumes []string, pointers []string) *oa3.RequestBody {
+	schema := &oa3.Schema{Type: "object", Properties: make(map[string]*oa3.Schema, len(params))}
+	hasFile := false
+	for i, param := range params {
+		if param.Ref != "" && strings.HasPrefix(param.Ref, parametersPrefix) {
+			u.report.add(pointers[i], SeverityInfo, "formData parameter reference was inlined into the request body")
+		}
+		target := u.resolveParameter(param)
+		if target.Ref != "" {
+			u.report.add(pointers[i]+"/$ref", SeverityError, fmt.Sprintf("formData parameter reference %s could not be resolved", target.Ref))
+			continue
+		}
+		prop := u.items(paramItems(target), pointers[i])
+		prop.Description = target.Description
+		schema.Properties[target.Name] = prop
+		if target.Required {
+			schema.Required = append(schema.Required, target.Name)
+		}
+		if target.Type == "file" {
+			hasFile = true
+		}
+	}
+
+	var mediaTypes []string
+	for _, mt := range consumes {
+		if mt == "multipart/form-data" || mt == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, mt)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		if hasFile {
+			mediaTypes = []string{"multipart/form-data"}
+		} else {
+			mediaTypes = []string{"application/x-www-form-urlencoded"}
+		}
+	}
+	return &oa3.RequestBody{
+		Required: len(schema.Required) > 0,
+		Content:  SchemaToContent30(mediaTypes, schema),
+	}
+}
+
+func (u *upgrader20) security(reqs []oa2.SecurityRequirement) []oa3.SecurityRequirement {
+	if reqs == nil {
+		return nil
+	}
+	out := make([]oa3.SecurityRequirement, 0, len(reqs))
+	for _, req := range reqs {
+		converted := make(oa3.SecurityRequirement, len(req))
+		for name, scopes := range req {
+			converted[name] = copyStrings(scopes)
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
+// parameter converts a non-body parameter
+func (u *upgrader20) parameter(param *oa2.Parameter, p string) *oa3.Parameter {
+	if param == nil {
+		return nil
+	}
+	if param.IsReference() {
+		out := oa3.NewParameterReference(upgradeRef20(param.Ref))
+		out.Extensions = copyExtensions(param.Extensions)
+		return out
+	}
+	out := &oa3.Parameter{
+		Name:            param.Name,
+		In:              param.In,
+		Description:     param.Description,
+		Required:        param.Required,
+		AllowEmptyValue: param.AllowEmptyValue,
+		Schema:          u.items(paramItems(param), p),
+		Extensions:      copyExtensions(param.Extensions),
+	}
+	if param.Type == "array" {
+		style, explode, ok := CollectionFormatToStyle(param.CollectionFormat, param.In)
+		if ok {
+			out.Style = style
+			out.Explode = &explode
+		} else {
+			u.report.add(p+"/collectionFormat", SeverityError, fmt.Sprintf("collectionFormat %s has no 3.0 style and was dropped", param.CollectionFormat))
+		}
+	}
+	return out
+}
+
+// paramItems views the type fields of a non-body parameter as Items
+func paramItems(param *oa2.Parameter) *oa2.Items {
+	return &oa2.Items{
+		Type:             param.Type,
+		Format:           param.Format,
+		Items:            param.Items,
+		Default:          param.Default,
+		Maximum:          param.Maximum,
+		ExclusiveMaximum: param.ExclusiveMaximum,
+		Minimum:          param.Minimum,
+		ExclusiveMinimum: param.ExclusiveMinimum,
+		MaxLength:        param.MaxLength,
+		MinLength:        param.MinLength,
+		Pattern:          param.Pattern,
+		MaxItems:         param.MaxItems,
+		MinItems:         param.MinItems,
+		UniqueItems:      param.UniqueItems,
+		Enum:             param.Enum,
+		MultipleOf:       param.MultipleOf,
+	}
+}
+
+// headerItems views the type fields of a header as Items
+func headerItems(h *oa2.Header) *oa2.Items {
+	return &oa2.Items{
+		Type:             h.Type,
+		Format:           h.Format,
+		Items:            h.Items,
+		CollectionFormat: h.CollectionFormat,
+		Default:          h.Default,
+		Maximum:          h.Maximum,
+		ExclusiveMaximum: h.ExclusiveMaximum,
+		Minimum:          h.Minimum,
+		ExclusiveMinimum: h.ExclusiveMinimum,
+		MaxLength:        h.MaxLength,
+		MinLength:        h.MinLength,
+		Pattern:          h.Pattern,
+		MaxItems:         h.MaxItems,
+		MinItems:         h.MinItems,
+		UniqueItems:      h.UniqueItems,
+		Enum:             h.Enum,
+		MultipleOf:       h.MultipleOf,
+	}
+}
+
+// items converts the type fields of a parameter, header or items object into a schema
+func (u *upgrader20) items(it *oa2.Items, p string) *oa3.Schema {
+	if it == nil {
+		return nil
+	}
+	out := &oa3.Schema{
+		Type:             it.Type,
+		Format:           it.Format,
+		Default:          it.Default,
+		Maximum:          copyFloat(it.Maximum),
+		ExclusiveMaximum: it.ExclusiveMaximum,
+		Minimum:          copyFloat(it.Minimum),
+		ExclusiveMinimum: it.ExclusiveMinimum,
+		MaxLength:        copyInt(it.MaxLength),
+		MinLength:        copyInt(it.MinLength),
+		Pattern:          it.Pattern,
+		MaxItems:         copyInt(it.MaxItems),
+		MinItems:         copyInt(it.MinItems),
+		UniqueItems:      it.UniqueItems,
+		Enum:             it.Enum,
+		MultipleOf:       copyFloat(it.MultipleOf),
+		Extensions:       copyExtensions(it.Extensions),
+	}
+	if it.Type == "file" {
+		out.Type, out.Format = "string", "binary"
+	}
+	if it.Items != nil {
+		out.Items = u.items(it.Items, p+"/items")
+		if cf := it.Items.CollectionFormat; cf != "" && cf != "csv" {
+			u.report.add(p+"/items/collectionFormat", SeverityError, "collectionFormat of nested arrays has no 3.0 equivalent and was dropped")
+		}
+	}
+	return out
+}
+
+func (u *upgrader20) responses(r *oa2.Responses, produces []string, p string) *oa3.Responses {
+	if r == nil {
+		return &oa3.Responses{}
+	}
+	out := &oa3.Responses{
+		Default:    u.response(r.Default, produces, p+"/default"),
+		Extensions: copyExtensions(r.Extensions),
+	}
+	if r.StatusCode != nil {
+		out.StatusCode = make(map[string]*oa3.Response, len(r.StatusCode))
+		for code, resp := range r.StatusCode {
+			out.StatusCode[code] = u.response(resp, produces, ptr(p, code))
+		}
+	}
+	return out
+}
+
+func (u *upgrader20) response(r *oa2.Response, produces []string, p string) *oa3.Response {
+	if r == nil {
+		return nil
+	}
+	if r.IsReference() {
+		out := oa3.NewResponseReference(upgradeRef20(r.Ref))
+		out.Extensions = copyExtensions(r.Extensions)
+		return out
+	}
+	out := &oa3.Response{
+		Description: r.Description,
+		Extensions:  copyExtensions(r.Extensions),
+	}
+	if r.Headers != nil {
+		out.Headers = make(map[string]*oa3.Header, len(r.Headers))
+		for name, h := range r.Headers {
+			out.Headers[name] = u.header(h, ptr(p, "headers", name))
+		}
+	}
+	if r.Schema != nil {
+		out.Content = SchemaToContent30(produces, u.schema(r.Schema, p+"/schema"))
+	}
+	// Examples are keyed by media type in 2.0
+	for mt, example := range r.Examples {
+		if out.Content == nil {
+			out.Content = make(map[string]*oa3.MediaType)
+		}
+		if media, ok := out.Content[mt]; ok {
+			// Media types share the schema, so each gets its own media type object
+			out.Content[mt] = &oa3.MediaType{Schema: media.Schema, Example: example}
+		} else {
+			out.Content[mt] = &oa3.MediaType{Example: example}
+		}
+	}
+	return out
+}
+
+func (u *upgrader20) header(h *oa2.Header, p string) *oa3.Header {
+	if h == nil {
+		return nil
+	}
+	out := &oa3.Header{
+		Description: h.Description,
+		Schema:      u.items(headerItems(h), p),
+		Extensions:  copyExtensions(h.Extensions),
+	}
+	if h.Type == "array" && h.CollectionFormat != "" && h.CollectionFormat != "csv" {
+		u.report.add(p+"/collectionFormat", SeverityError, fmt.Sprintf("collectionFormat %s has no 3.0 header style and was dropped", h.CollectionFormat))
+	}
+	return out
+}
+
+func (u *upgrader20) securityScheme(ss *oa2.SecurityScheme, p string) *oa3.SecurityScheme {
+	if ss == nil {
+		return nil
+	}
+	out := &oa3.SecurityScheme{
+		Type:        ss.Type,
+		Description: ss.Description,
+		Name:        ss.Name,
+		In:          ss.In,
+		Extensions:  copyExtensions(ss.Extensions),
+	}
+	switch ss.Type {
+	case "basic":
+		out.Type, out.Scheme = "http", "basic"
+	case "oauth2":
+		flow := &oa3.OAuthFlow{
+			AuthorizationUrl: ss.AuthorizationUrl,
+			TokenUrl:         ss.TokenUrl,
+			Scopes:           copyStringMap(ss.Scopes),
+		}
+		if flow.Scopes == nil {
+			flow.Scopes = map[string]string{}
+		}
+		out.Flows = &oa3.OAuthFlows{}
+		switch ss.Flow {
+		case "implicit":
+			out.Flows.Implicit = flow
+		case "password":
+			out.Flows.Password = flow
+		case "application":
+			out.Flows.ClientCredentials = flow
+		case "accessCode":
+			out.Flows.AuthorizationCode = flow
+		default:
+			u.report.add(p+"/flow", SeverityError, fmt.Sprintf("unknown oauth2 flow %q was dropped", ss.Flow))
+		}
+	}
+	return out
+}
+
+func (u *upgrader20) components() *oa3.Components {
+	src := u.src
+	if len(src.Definitions) == 0 && len(src.Parameters) == 0 && len(src.Responses) == 0 && len(src.SecurityDefinitions) == 0 {
+		return nil
+	}
+	out := &oa3.Components{}
+	if src.Definitions != nil {
+		out.Schemas = make(map[string]*oa3.Schema, len(src.Definitions))
+		for name, s := range src.Definitions {
+			schema := u.schema(s, ptr("/definitions", name))
+			if schema != nil && s.Discriminator != "" {
+				schema.Discriminator = DiscriminatorToObject30(src.Definitions, name)
+			}
+			out.Schemas[name] = schema
+		}
+	}
+	for name, param := range src.Parameters {
+		p := ptr("/parameters", name)
+		switch {
+		case param == nil:
+			continue
+		case param.In == "body":
+			if out.RequestBodies == nil {
+				out.RequestBodies = make(map[string]*oa3.RequestBody)
+			}
+			out.RequestBodies[name] = u.bodyParameter(param, false, EffectiveMediaTypes(nil, src.Consumes), p)
+		case param.In == "formData":
+			u.report.add(p, SeverityInfo, "formData parameters cannot be components in 3.0 and were inlined where referenced")
+		default:
+			if out.Parameters == nil {
+				out.Parameters = make(map[string]*oa3.Parameter)
+			}
+			out.Parameters[name] = u.parameter(param, p)
+		}
+	}
+	if src.Responses != nil {
+		produces := EffectiveMediaTypes(nil, src.Produces)
+		out.Responses = make(map[string]*oa3.Response, len(src.Responses))
+		for name, r := range src.Responses {
+			out.Responses[name] = u.response(r, produces, ptr("/responses", name))
+		}
+	}
+	if src.SecurityDefinitions != nil {
+		out.SecuritySchemes = make(map[string]*oa3.SecurityScheme, len(src.SecurityDefinitions))
+		for name, ss := range src.SecurityDefinitions {
+			out.SecuritySchemes[name] = u.securityScheme(ss, ptr("/securityDefinitions", name))
+		}
+	}
+	return out
+}
+
+func (u *upgrader20) tags(tags []*oa2.Tag) []*oa3.Tag {
+	if tags == nil {
+		return nil
+	}
+	out := make([]*oa3.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t == nil {
+			out = append(out, nil)
+			continue
+		}
+		out = append(out, &oa3.Tag{
+			Name:         t.Name,
+			Description:  t.Description,
+			ExternalDocs: u.externalDocs(t.ExternalDocs),
+			Extensions:   copyExtensions(t.Extensions),
+		})
+	}
+	return out
+}
+
+func (u *upgrader20) externalDocs(ed *oa2.ExternalDocumentation) *oa3.ExternalDocumentation {
+	if ed == nil {
+		return nil
+	}
+	return &oa3.ExternalDocumentation{
+		Description: ed.Description,
+		URL:         ed.URL,
+		Extensions:  copyExtensions(ed.Extensions),
+	}
+}
+
+// schema converts a 2.0 schema to a 3.0 schema
+func (u *upgrader20) schema(s *oa2.Schema, p string) *oa3.Schema {
+	if s == nil {
+		return nil
+	}
+	if s.IsBooleanSchema() {
+		return oa3.NewBooleanSchema(*s.BooleanValue())
+	}
+
+	out := &oa3.Schema{
+		Ref:              upgradeRef20(s.Ref),
+		Title:            s.Title,
+		Description:      s.Description,
+		Default:          s.Default,
+		Format:           s.Format,
+		Type:             s.Type,
+		Enum:             s.Enum,
+		MultipleOf:       copyFloat(s.MultipleOf),
+		Maximum:          copyFloat(s.Maximum),
+		ExclusiveMaximum: s.ExclusiveMaximum,
+		Minimum:          copyFloat(s.Minimum),
+		ExclusiveMinimum: s.ExclusiveMinimum,
+		MaxLength:        copyInt(s.MaxLength),
+		MinLength:        copyInt(s.MinLength),
+		Pattern:          s.Pattern,
+		MaxItems:         copyInt(s.MaxItems),
+		MinItems:         copyInt(s.MinItems),
+		UniqueItems:      s.UniqueItems,
+		Items:            u.schema(s.Items, p+"/items"),
+		MaxProperties:    copyInt(s.MaxProperties),
+		MinProperties:    copyInt(s.MinProperties),
+		Required:         copyStrings(s.Required),
+		ReadOnly:         s.ReadOnly,
+		Example:          s.Example,
+		ExternalDocs:     u.externalDocs(s.ExternalDocs),
+		Extensions:       copyExtensions(s.Extensions),
+	}
+	if s.Type == "file" {
+		out.Type, out.Format = "string", "binary"
+	}
+	if s.Discriminator != "" {
+		out.Discriminator = &oa3.Discriminator{PropertyName: s.Discriminator}
+	}
+	if s.XML != nil {
+		out.XML = &oa3.XML{
+			Name:       s.XML.Name,
+			Namespace:  s.XML.Namespace,
+			Prefix:     s.XML.Prefix,
+			Attribute:  s.XML.Attribute,
+			Wrapped:    s.XML.Wrapped,
+			Extensions: copyExtensions(s.XML.Extensions),
+		}
+	}
+	if s.AllOf != nil {
+		out.AllOf = make([]*oa3.Schema, 0, len(s.AllOf))
+		for i, sub := range s.AllOf {
+			out.AllOf = append(out.AllOf, u.schema(sub, ptr(p, "allOf", fmt.Sprint(i))))
+		}
+	}
+	if s.Properties != nil {
+		out.Properties = make(map[string]*oa3.Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			out.Properties[name] = u.schema(prop, ptr(p, "properties", name))
+		}
+	}
+	out.AdditionalProperties = u.schema(s.AdditionalProperties, p+"/additionalProperties")
+	return out
+}
@@ -0,0 +1,667 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// UpgradeOptions controls how a document is converted to a newer version
+type UpgradeOptions struct {
+	// OpenAPIVersion is written to the openapi field of the result.
+	// It defaults to "3.0.3" for 3.0 results and "3.1.0" for 3.1 results.
+	OpenAPIVersion string
+}
+
+// Upgrade30To31 converts an OpenAPI 3.0 document to OpenAPI 3.1.
+// Nullable types become type arrays and boolean exclusive bounds become
+// numeric ones. Every 3.0 construct has a 3.1 equivalent, so the report is
+// normally empty. A nil opts uses the defaults.
+func Upgrade30To31(doc *oa3.OpenAPI, opts *UpgradeOptions) (*oa31.OpenAPI, *ConversionReport) {
+	report := &ConversionReport{}
+	if doc == nil {
+		return nil, report
+	}
+	u := &upgrader30{report: report}
+	if opts != nil {
+		u.opts = *opts
+	}
+	if u.opts.OpenAPIVersion == "" {
+		u.opts.OpenAPIVersion = "3.1.0"
+	}
+	return u.document(doc), report
+}
+
+type upgrader30 struct {
+	opts   UpgradeOptions
+	report *ConversionReport
+}
+
+func (u *upgrader30) document(src *oa3.OpenAPI) *oa31.OpenAPI {
+	return &oa31.OpenAPI{
+		OpenAPI:      u.opts.OpenAPIVersion,
+		Info:         u.info(src.Info),
+		Servers:      u.servers(src.Servers),
+		Paths:        u.paths(src.Paths),
+		Components:   u.components(src.Components),
+		Security:     u.security(src.Security),
+		Tags:         u.tags(src.Tags),
+		ExternalDocs: u.externalDocs(src.ExternalDocs),
+		Extensions:   copyExtensions(src.Extensions),
+	}
+}
+
+func (u *upgrader30) info(info *oa3.Info) *oa31.Info {
+	if info == nil {
+		return nil
+	}
+	out := &oa31.Info{
+		Title:          info.Title,
+		Description:    info.Description,
+		TermsOfService: info.TermsOfService,
+		Version:        info.Version,
+		Extensions:     copyExtensions(info.Extensions),
+	}
+	if info.Contact != nil {
+		out.Contact = &oa31.Contact{
+			Name:       info.Contact.Name,
+			URL:        info.Contact.URL,
+			Email:      info.Contact.Email,
+			Extensions: copyExtensions(info.Contact.Extensions),
+		}
+	}
+	if info.License != nil {
+		out.License = &oa31.License{
+			Name:       info.License.Name,
+			URL:        info.License.URL,
+			Extensions: copyExtensions(info.License.Extensions),
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) servers(servers []*oa3.Server) []*oa31.Server {
+	if servers == nil {
+		return nil
+	}
+	out := make([]*oa31.Server, 0, len(servers))
+	for _, s := range servers {
+		out = append(out, u.server(s))
+	}
+	return out
+}
+
+func (u *upgrader30) server(s *oa3.Server) *oa31.Server {
+	if s == nil {
+		return nil
+	}
+	out := &oa31.Server{
+		URL:         s.URL,
+		Description: s.Description,
+		Extensions:  copyExtensions(s.Extensions),
+	}
+	if s.Variables != nil {
+		out.Variables = make(map[string]*oa31.ServerVariable, len(s.Variables))
+		for name, v := range s.Variables {
+			if v == nil {
+				out.Variables[name] = nil
+				continue
+			}
+			out.Variables[name] = &oa31.ServerVariable{
+				Enum:        copyStrings(v.Enum),
+				Default:     v.Default,
+				Description: v.Description,
+				Extensions:  copyExtensions(v.Extensions),
+			}
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) paths(paths *oa3.Paths) *oa31.Paths {
+	if paths == nil {
+		return nil
+	}
+	out := &oa31.Paths{Extensions: copyExtensions(paths.Extensions)}
+	if paths.Paths != nil {
+		out.Paths = make(map[string]*oa31.PathItem, len(paths.Paths))
+		for key, item := range paths.Paths {
+			out.Paths[key] = u.pathItem(item, ptr("/paths", key))
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) pathItem(item *oa3.PathItem, p string) *oa31.PathItem {
+	if item == nil {
+		return nil
+	}
+	return &oa31.PathItem{
+		Ref:         item.Ref,
+		Summary:     item.Summary,
+		Description: item.Description,
+		Servers:     u.servers(item.Servers),
+		Parameters:  u.parameters(item.Parameters, p+"/parameters"),
+		Get:         u.operation(item.Get, p+"/get"),
+		Put:         u.operation(item.Put, p+"/put"),
+		Post:        u.operation(item.Post, p+"/post"),
+		Delete:      u.operation(item.Delete, p+"/delete"),
+		Options:     u.operation(item.Options, p+"/options"),
+		Head:        u.operation(item.Head, p+"/head"),
+		Patch:       u.operation(item.Patch, p+"/patch"),
+		Trace:       u.operation(item.Trace, p+"/trace"),
+		Extensions:  copyExtensions(item.Extensions),
+	}
+}
+
+func (u *upgrader30) operation(op *oa3.Operation, p string) *oa31.Operation {
+	if op == nil {
+		return nil
+	}
+	out := &oa31.Operation{
+		Tags:         copyStrings(op.Tags),
+		Summary:      op.Summary,
+		Description:  op.Description,
+		ExternalDocs: u.externalDocs(op.ExternalDocs),
+		OperationID:  op.OperationID,
+		Parameters:   u.parameters(op.Parameters, p+"/parameters"),
+		RequestBody:  u.requestBody(op.RequestBody, p+"/requestBody"),
+		Responses:    u.responses(op.Responses, p+"/responses"),
+		Deprecated:   op.Deprecated,
+		Security:     u.security(op.Security),
+		Servers:      u.servers(op.Servers),
+		Extensions:   copyExtensions(op.Extensions),
+	}
+	if op.Callbacks != nil {
+		out.Callbacks = make(map[string]*oa31.Callback, len(op.Callbacks))
+		for name, cb := range op.Callbacks {
+			out.Callbacks[name] = u.callback(cb, ptr(p+"/callbacks", name))
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) security(reqs []oa3.SecurityRequirement) []oa31.SecurityRequirement {
+	if reqs == nil {
+		return nil
+	}
+	out := make([]oa31.SecurityRequirement, 0, len(reqs))
+	for _, req := range reqs {
+		converted := make(oa31.SecurityRequirement, len(req))
+		for name, scopes := range req {
+			converted[name] = copyStrings(scopes)
+		}
+		out = append(out, converted)
+	}
+	return out
+}
+
+func (u *upgrader30) parameters(params []*oa3.Parameter, p string) []*oa31.Parameter {
+	if params == nil {
+		return nil
+	}
+	out := make([]*oa31.Parameter, 0, len(params))
+	for i, param := range params {
+		out = append(out, u.parameter(param, ptr(p, fmt.Sprint(i))))
+	}
+	return out
+}
+
+func (u *upgrader30) parameter(param *oa3.Parameter, p string) *oa31.Parameter {
+	if param == nil {
+		return nil
+	}
+	if param.IsReference() {
+		out := oa31.NewParameterReference(param.Ref)
+		out.Extensions = copyExtensions(param.Extensions)
+		return out
+	}
+	return &oa31.Parameter{
+		Name:            param.Name,
+		In:              param.In,
+		Description:     param.Description,
+		Required:        param.Required,
+		Deprecated:      param.Deprecated,
+		AllowEmptyValue: param.AllowEmptyValue,
+		Style:           param.Style,
+		Explode:         copyBool(param.Explode),
+		AllowReserved:   param.AllowReserved,
+		Schema:          u.schema(param.Schema, p+"/schema"),
+		Content:         u.content(param.Content, p+"/content"),
+		Example:         param.Example,
+		Examples:        u.examples(param.Examples, p+"/examples"),
+		Extensions:      copyExtensions(param.Extensions),
+	}
+}
+
+func (u *upgrader30) header(h *oa3.Header, p string) *oa31.Header {
+	if h == nil {
+		return nil
+	}
+	if h.IsReference() {
+		out := oa31.NewHeaderReference(h.Ref)
+		out.Extensions = copyExtensions(h.Extensions)
+		return out
+	}
+	return &oa31.Header{
+		Description: h.Description,
+		Required:    h.Required,
+		Deprecated:  h.Deprecated,
+		Style:       h.Style,
+		Explode:     copyBool(h.Explode),
+		Schema:      u.schema(h.Schema, p+"/schema"),
+		Content:     u.content(h.Content, p+"/content"),
+		Example:     h.Example,
+		Examples:    u.examples(h.Examples, p+"/examples"),
+		Extensions:  copyExtensions(h.Extensions),
+	}
+}
+
+func (u *upgrader30) headers(headers map[string]*oa3.Header, p string) map[string]*oa31.Header {
+	if headers == nil {
+		return nil
+	}
+	out := make(map[string]*oa31.Header, len(headers))
+	for name, h := range headers {
+		out[name] = u.header(h, ptr(p, name))
+	}
+	return out
+}
+
+func (u *upgrader30) requestBody(rb *oa3.RequestBody, p string) *oa31.RequestBody {
+	if rb == nil {
+		return nil
+	}
+	if rb.IsReference() {
+		out := oa31.NewRequestBodyReference(rb.Ref)
+		out.Extensions = copyExtensions(rb.Extensions)
+		return out
+	}
+	return &oa31.RequestBody{
+		Description: rb.Description,
+		Content:     u.content(rb.Content, p+"/content"),
+		Required:    rb.Required,
+		Extensions:  copyExtensions(rb.Extensions),
+	}
+}
+
+func (u *upgrader30) content(content map[string]*oa3.MediaType, p string) map[string]*oa31.MediaType {
+	if content == nil {
+		return nil
+	}
+	out := make(map[string]*oa31.MediaType, len(content))
+	for name, mt := range content {
+		out[name] = u.mediaType(mt, ptr(p, name))
+	}
+	return out
+}
+
+func (u *upgrader30) mediaType(mt *oa3.MediaType, p string) *oa31.MediaType {
+	if mt == nil {
+		return nil
+	}
+	out := &oa31.MediaType{
+		Schema:     u.schema(mt.Schema, p+"/schema"),
+		Example:    mt.Example,
+		Examples:   u.examples(mt.Examples, p+"/examples"),
+		Extensions: copyExtensions(mt.Extensions),
+	}
+	if mt.Encoding != nil {
+		out.Encoding = make(map[string]*oa31.Encoding, len(mt.Encoding))
+		for name, enc := range mt.Encoding {
+			if enc == nil {
+				out.Encoding[name] = nil
+				continue
+			}
+			out.Encoding[name] = &oa31.Encoding{
+				ContentType:   enc.ContentType,
+				Headers:       u.headers(enc.Headers, ptr(p, "encoding", name, "headers")),
+				Style:         enc.Style,
+				Explode:       copyBool(enc.Explode),
+				AllowReserved: enc.AllowReserved,
+				Extensions:    copyExtensions(enc.Extensions),
+			}
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) examples(examples map[string]*oa3.Example, p string) map[string]*oa31.Example {
+	if examples == nil {
+		return nil
+	}
+	out := make(map[string]*oa31.Example, len(examples))
+	for name, ex := range examples {
+		out[name] = u.example(ex, ptr(p, name))
+	}
+	return out
+}
+
+func (u *upgrader30) example(ex *oa3.Example, p string) *oa31.Example {
+	if ex == nil {
+		return nil
+	}
+	if ex.IsReference() {
+		out := oa31.NewExampleReference(ex.Ref)
+		out.Extensions = copyExtensions(ex.Extensions)
+		return out
+	}
+	return &oa31.Example{
+		Summary:       ex.Summary,
+		Description:   ex.Description,
+		Value:         ex.Value,
+		ExternalValue: ex.ExternalValue,
+		Extensions:    copyExtensions(ex.Extensions),
+	}
+}
+
+func (u *upgrader30) responses(r *oa3.Responses, p string) *oa31.Responses {
+	if r == nil {
+		return nil
+	}
+	out := &oa31.Responses{
+		Default:    u.response(r.Default, p+"/default"),
+		Extensions: copyExtensions(r.Extensions),
+	}
+	if r.StatusCode != nil {
+		out.StatusCode = make(map[string]*oa31.Response, len(r.StatusCode))
+		for code, resp := range r.StatusCode {
+			out.StatusCode[code] = u.response(resp, ptr(p, code))
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) response(r *oa3.Response, p string) *oa31.Response {
+	if r == nil {
+		return nil
+	}
+	if r.IsReference() {
+		out := oa31.NewResponseReference(r.Ref)
+		out.Extensions = copyExtensions(r.Extensions)
+		return out
+	}
+	out := &oa31.Response{
+		Description: r.Description,
+		Headers:     u.headers(r.Headers, p+"/headers"),
+		Content:     u.content(r.Content, p+"/content"),
+		Extensions:  copyExtensions(r.Extensions),
+	}
+	if r.Links != nil {
+		out.Links = make(map[string]*oa31.Link, len(r.Links))
+		for name, l := range r.Links {
+			out.Links[name] = u.link(l, ptr(p, "links", name))
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) link(l *oa3.Link, p string) *oa31.Link {
+	if l == nil {
+		return nil
+	}
+	if l.IsReference() {
+		out := oa31.NewLinkReference(l.Ref)
+		out.Extensions = copyExtensions(l.Extensions)
+		return out
+	}
+	out := &oa31.Link{
+		OperationRef: l.OperationRef,
+		OperationId:  l.OperationId,
+		RequestBody:  l.RequestBody,
+		Description:  l.Description,
+		Server:       u.server(l.Server),
+		Extensions:   copyExtensions(l.Extensions),
+	}
+	if l.Parameters != nil {
+		out.Parameters = make(map[string]string, len(l.Parameters))
+		for k, v := range l.Parameters {
+			if str, ok := v.(string); ok {
+				out.Parameters[k] = str
+				continue
+			}
+			// Constant values are written as their JSON text
+			data, err := json.Marshal(v)
+			if err != nil {
+				data = []byte(fmt.Sprint(v))
+			}
+			out.Parameters[k] = string(data)
+			u.report.add(ptr(p, "parameters", k), SeverityWarning, "non-string link parameter was converted to a string")
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) callback(cb *oa3.Callback, p string) *oa31.Callback {
+	if cb == nil {
+		return nil
+	}
+	if cb.IsReference() {
+		out := oa31.NewCallbackReference(cb.Ref)
+		out.Extensions = copyExtensions(cb.Extensions)
+		return out
+	}
+	out := &oa31.Callback{Extensions: copyExtensions(cb.Extensions)}
+	if cb.Paths != nil {
+		out.Paths = make(map[string]*oa31.PathItem, len(cb.Paths))
+		for expr, item := range cb.Paths {
+			out.Paths[expr] = u.pathItem(item, ptr(p, expr))
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) securityScheme(ss *oa3.SecurityScheme) *oa31.SecurityScheme {
+	if ss == nil {
+		return nil
+	}
+	if ss.IsReference() {
+		out := oa31.NewSecuritySchemeReference(ss.Ref)
+		out.Extensions = copyExtensions(ss.Extensions)
+		return out
+	}
+	out := &oa31.SecurityScheme{
+		Type:             ss.Type,
+		Description:      ss.Description,
+		Name:             ss.Name,
+		In:               ss.In,
+		Scheme:           ss.Scheme,
+		BearerFormat:     ss.BearerFormat,
+		OpenIdConnectUrl: ss.OpenIdConnectUrl,
+		Extensions:       copyExtensions(ss.Extensions),
+	}
+	if ss.Flows != nil {
+		out.Flows = &oa31.OAuthFlows{
+			Implicit:          oauthFlow30To31(ss.Flows.Implicit),
+			Password:          oauthFlow30To31(ss.Flows.Password),
+			ClientCredentials: oauthFlow30To31(ss.Flows.ClientCredentials),
+			AuthorizationCode: oauthFlow30To31(ss.Flows.AuthorizationCode),
+			Extensions:        copyExtensions(ss.Flows.Extensions),
+		}
+	}
+	return out
+}
+
+func oauthFlow30To31(f *oa3.OAuthFlow) *oa31.OAuthFlow {
+	if f == nil {
+		return nil
+	}
+	return &oa31.OAuthFlow{
+		AuthorizationUrl: f.AuthorizationUrl,
+		TokenUrl:         f.TokenUrl,
+		RefreshUrl:       f.RefreshUrl,
+		Scopes:           copyStringMap(f.Scopes),
+		Extensions:       copyExtensions(f.Extensions),
+	}
+}
+
+func (u *upgrader30) components(c *oa3.Components) *oa31.Components {
+	if c == nil {
+		return nil
+	}
+	p := "/components"
+	out := &oa31.Components{Extensions: copyExtensions(c.Extensions)}
+	if c.Schemas != nil {
+		out.Schemas = make(map[string]*oa31.Schema, len(c.Schemas))
+		for name, s := range c.Schemas {
+			out.Schemas[name] = u.schema(s, ptr(p, "schemas", name))
+		}
+	}
+	if c.Responses != nil {
+		out.Responses = make(map[string]*oa31.Response, len(c.Responses))
+		for name, r := range c.Responses {
+			out.Responses[name] = u.response(r, ptr(p, "responses", name))
+		}
+	}
+	if c.Parameters != nil {
+		out.Parameters = make(map[string]*oa31.Parameter, len(c.Parameters))
+		for name, param := range c.Parameters {
+			out.Parameters[name] = u.parameter(param, ptr(p, "parameters", name))
+		}
+	}
+	out.Examples = u.examples(c.Examples, p+"/examples")
+	if c.RequestBodies != nil {
+		out.RequestBodies = make(map[string]*oa31.RequestBody, len(c.RequestBodies))
+		for name, rb := range c.RequestBodies {
+			out.RequestBodies[name] = u.requestBody(rb, ptr(p, "requestBodies", name))
+		}
+	}
+	out.Headers = u.headers(c.Headers, p+"/headers")
+	if c.SecuritySchemes != nil {
+		out.SecuritySchemes = make(map[string]*oa31.SecurityScheme, len(c.SecuritySchemes))
+		for name, ss := range c.SecuritySchemes {
+			out.SecuritySchemes[name] = u.securityScheme(ss)
+		}
+	}
+	if c.Links != nil {
+		out.Links = make(map[string]*oa31.Link, len(c.Links))
+		for name, l := range c.Links {
+			out.Links[name] = u.link(l, ptr(p, "links", name))
+		}
+	}
+	if c.Callbacks != nil {
+		out.Callbacks = make(map[string]*oa31.Callback, len(c.Callbacks))
+		for name, cb := range c.Callbacks {
+			out.Callbacks[name] = u.callback(cb, ptr(p, "callbacks", name))
+		}
+	}
+	return out
+}
+
+func (u *upgrader30) tags(tags []*oa3.Tag) []*oa31.Tag {
+	if tags == nil {
+		return nil
+	}
+	out := make([]*oa31.Tag, 0, len(tags))
+	for _, t := range tags {
+		if t == nil {
+			out = append(out, nil)
+			continue
+		}
+		out = append(out, &oa31.Tag{
+			Name:         t.Name,
+			Description:  t.Description,
+			ExternalDocs: u.externalDocs(t.ExternalDocs),
+			Extensions:   copyExtensions(t.Extensions),
+		})
+	}
+	return out
+}
+
+func (u *upgrader30) externalDocs(ed *oa3.ExternalDocumentation) *oa31.ExternalDocumentation {
+	if ed == nil {
+		return nil
+	}
+	return &oa31.ExternalDocumentation{
+		Description: ed.Description,
+		URL:         ed.URL,
+		Extensions:  copyExtensions(ed.Extensions),
+	}
+}
+
+// schema converts a 3.0 (Draft 4 subset) schema to a 3.1 (JSON Schema 2020-12) schema
+func (u *upgrader30) schema(s *oa3.Schema, p string) *oa31.Schema {
+	if s == nil {
+		return nil
+	}
+	if s.IsBooleanSchema() {
+		return oa31.NewBooleanSchema(*s.BooleanValue())
+	}
+
+	out := &oa31.Schema{
+		Ref:           s.Ref,
+		Title:         s.Title,
+		Description:   s.Description,
+		Default:       s.Default,
+		Format:        s.Format,
+		Enum:          s.Enum,
+		MultipleOf:    copyFloat(s.MultipleOf),
+		MaxLength:     copyInt(s.MaxLength),
+		MinLength:     copyInt(s.MinLength),
+		Pattern:       s.Pattern,
+		MaxItems:      copyInt(s.MaxItems),
+		MinItems:      copyInt(s.MinItems),
+		UniqueItems:   s.UniqueItems,
+		Items:         u.schema(s.Items, p+"/items"),
+		MaxProperties: copyInt(s.MaxProperties),
+		MinProperties: copyInt(s.MinProperties),
+		Required:      copyStrings(s.Required),
+		Not:           u.schema(s.Not, p+"/not"),
+		ReadOnly:      s.ReadOnly,
+		WriteOnly:     s.WriteOnly,
+		Example:       s.Example,
+		Deprecated:    s.Deprecated,
+		ExternalDocs:  u.externalDocs(s.ExternalDocs),
+		Extensions:    copyExtensions(s.Extensions),
+	}
+	ApplyNullable31(out, s)
+	ApplyExclusiveBounds31(out, s)
+
+	if s.Discriminator != nil {
+		out.Discriminator = &oa31.Discriminator{
+			PropertyName: s.Discriminator.PropertyName,
+			Mapping:      copyStringMap(s.Discriminator.Mapping),
+			Extensions:   copyExtensions(s.Discriminator.Extensions),
+		}
+	}
+	if s.XML != nil {
+		out.XML = &oa31.XML{
+			Name:       s.XML.Name,
+			Namespace:  s.XML.Namespace,
+			Prefix:     s.XML.Prefix,
+			Attribute:  s.XML.Attribute,
+			Wrapped:    s.XML.Wrapped,
+			Extensions: copyExtensions(s.XML.Extensions),
+		}
+	}
+
+	out.AllOf = u.schemaList(s.AllOf, p+"/allOf")
+	out.AnyOf = u.schemaList(s.AnyOf, p+"/anyOf")
+	out.OneOf = u.schemaList(s.OneOf, p+"/oneOf")
+
+	if s.Properties != nil {
+		out.Properties = make(map[string]*oa31.Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			out.Properties[name] = u.schema(prop, ptr(p, "properties", name))
+		}
+	}
+	out.AdditionalProperties = u.schema(s.AdditionalProperties, p+"/additionalProperties")
+	return out
+}
+
+func (u *upgrader30) schemaList(list []*oa3.Schema, p string) []*oa31.Schema {
+	if list == nil {
+		return nil
+	}
+	out := make([]*oa31.Schema, 0, len(list))
+	for i, s := range list {
+		out = append(out, u.schema(s, ptr(p, fmt.Sprint(i))))
+	}
+	return out
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package convert
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+func loadSwagger(t *testing.T, name string) *oa2.Swagger {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("../openapi20/oas-examples/json", name))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	var doc oa2.Swagger
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	return &doc
+}
+
+func TestUpgrade20To30Petstore(t *testing.T) {
+	out, report := Upgrade20To30(loadSwagger(t, "petstore.json"), nil)
+	if out.OpenAPI != "3.0.3" {
+		t.Errorf("Expected openapi 3.0.3, got %s", out.OpenAPI)
+	}
+	if len(out.Servers) != 1 || out.Servers[0].URL != "http://petstore.swagger.io/v2" {
+		t.Errorf("Expected server from host and basePath, got %+v", out.Servers)
+	}
+
+	addPet := out.Paths.Paths["/pet"].Post
+	if addPet.RequestBody == nil || len(addPet.RequestBody.Content) != 2 || !addPet.RequestBody.Required {
+		t.Fatalf("Expected required body with 2 media types, got %+v", addPet.RequestBody)
+	}
+	if s := addPet.RequestBody.Content["application/json"].Schema; s == nil || s.Ref != "#/components/schemas/Pet" {
+		t.Errorf("Expected rewritten schema reference, got %+v", s)
+	}
+	for _, p := range addPet.Parameters {
+		if p.In == "body" {
+			t.Errorf("Expected body parameter to be removed")
+		}
+	}
+
+	upload := out.Paths.Paths["/pet/{petId}/uploadImage"].Post
+	media := upload.RequestBody.Content["multipart/form-data"]
+	if media == nil || media.Schema == nil {
+		t.Fatalf("Expected multipart form body, got %+v", upload.RequestBody.Content)
+	}
+	if file := media.Schema.Properties["file"]; file == nil || file.Type != "string" || file.Format != "binary" {
+		t.Errorf("Expected file as binary string, got %+v", file)
+	}
+
+	findByStatus := out.Paths.Paths["/pet/findByStatus"].Get
+	if p := findByStatus.Parameters[0]; p.Style != "form" || p.Explode == nil || !*p.Explode {
+		t.Errorf("Expected multi as exploded form, got style=%q explode=%v", p.Style, p.Explode)
+	}
+	if resp := findByStatus.Responses.StatusCode["200"]; len(resp.Content) != 2 {
+		t.Errorf("Expected content from produces, got %+v", resp.Content)
+	}
+
+	auth := out.Components.SecuritySchemes["petstore_auth"]
+	if auth.Flows == nil || auth.Flows.Implicit == nil || auth.Flows.Implicit.AuthorizationUrl == "" {
+		t.Errorf("Expected implicit flow, got %+v", auth.Flows)
+	}
+	if report.HasSeverity(SeverityError) {
+		t.Errorf("Expected no errors, got %s", report)
+	}
+}
+
+func TestUpgrade20To30Unsupported(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/items": {
+				"get": {
+					"parameters": [{"name": "ids", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "tsv"}],
+					"responses": {"200": {"description": "ok"}}
+				}
+			}
+		}
+	}`
+	var doc oa2.Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	_, report := Upgrade20To30(&doc, nil)
+	if !hasIssue(report, "/paths/~1items/get/parameters/0/collectionFormat") {
+		t.Errorf("Expected tsv issue, got %s", report)
+	}
+}
+
+func TestUpgrade30To31(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {},
+		"components": {
+			"schemas": {
+				"Name": {"type": "string", "nullable": true},
+				"Bounded": {"type": "number", "minimum": 0, "exclusiveMinimum": true}
+			}
+		}
+	}`
+	var doc oa3.OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	out, report := Upgrade30To31(&doc, nil)
+	if out.OpenAPI != "3.1.0" {
+		t.Errorf("Expected openapi 3.1.0, got %s", out.OpenAPI)
+	}
+	if s := out.Components.Schemas["Name"]; s.Type == nil || len(s.Type.Array) != 2 {
+		t.Errorf("Expected type array, got %+v", s.Type)
+	}
+	if s := out.Components.Schemas["Bounded"]; s.ExclusiveMinimum == nil || *s.ExclusiveMinimum != 0 || s.Minimum != nil {
+		t.Errorf("Expected numeric exclusiveMinimum, got %+v", s)
+	}
+	if !report.Lossless() {
+		t.Errorf("Expected lossless upgrade, got %s", report)
+	}
+}
+
+func TestUpgradeExampleFiles(t *testing.T) {
+	for _, dir := range []string{"../openapi20/oas-examples/json", "../openapi30/oas-examples/json"} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("Failed to read examples directory: %v", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			t.Run(path, func(t *testing.T) {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("Failed to read file: %v", err)
+				}
+				var out *oa31.OpenAPI
+				if dir == "../openapi20/oas-examples/json" {
+					var doc oa2.Swagger
+					if err := json.Unmarshal(data, &doc); err != nil {
+						t.Fatalf("Failed to unmarshal: %v", err)
+					}
+					out, _ = Upgrade20To31(&doc, nil)
+				} else {
+					var doc oa3.OpenAPI
+					if err := json.Unmarshal(data, &doc); err != nil {
+						t.Fatalf("Failed to unmarshal: %v", err)
+					}
+					out, _ = Upgrade30To31(&doc, nil)
+				}
+				converted, err := json.Marshal(out)
+				if err != nil {
+					t.Fatalf("Failed to marshal: %v", err)
+				}
+				var reparsed oa31.OpenAPI
+				if err := json.Unmarshal(converted, &reparsed); err != nil {
+					t.Fatalf("Failed to parse converted document: %v", err)
+				}
+				if reparsed.OpenAPI != "3.1.0" {
+					t.Errorf("Expected 3.1.0 document, got %s", reparsed.OpenAPI)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package coverage records which operations, response statuses and media
+// types of an OpenAPI document requests exercise, and reports the parts of
+// the document they leave uncovered: spec coverage next to code coverage.
+package coverage
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	oa3 "github.com/genelet/oas/openapi30"
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+)
+
+// Options configures a Recorder
+type Options struct {
+	Router router.Options
+}
+
+// Recorder records the exchanges of requests and responses against a
+// document. It is safe for concurrent use, so that one recorder can serve
+// parallel tests or a proxy.
+type Recorder struct {
+	doc    unified.Document
+	router *router.Router
+
+	mu         sync.Mutex
+	operations map[string]*hits
+	unmatched  map[string]int
+}
+
+// hits are the exchanges recorded for an operation
+type hits struct {
+	calls    int
+	requests map[string]int
+	statuses map[string]*statusHits
+}
+
+// statusHits are the exchanges recorded for a response key of an operation,
+// such as 200, 4XX or default, or an undocumented status
+type statusHits struct {
+	calls      int
+	mediaTypes map[string]int
+}
+
+// New returns a recorder for a document
+func New(doc unified.Document, opts Options) (*Recorder, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to cover")
+	}
+	resolved, err := unified.Resolve(doc)
+	if err != nil {
+		return nil, err
+	}
+	r, err := router.New(resolved, opts.Router)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{doc: resolved, router: r, operations: make(map[string]*hits), unmatched: make(map[string]int)}, nil
+}
+
+// Record records an exchange: the request, and the status and Content-Type
+// of its response. Requests that match no operation are reported as
+// undocumented.
+func (r *Recorder) Record(req *http.Request, status int, contentType string) {
+	m, err := r.router.Find(req)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.unmatched[strings.ToUpper(req.Method)+" "+req.URL.Path]++
+		return
+	}
+	key := m.Method + " " + m.Path
+	h := r.operations[key]
+	if h == nil {
+		h = &hits{requests: make(map[string]int), statuses: make(map[string]*statusHits)}
+		r.operations[key] = h
+	}
+	h.calls++
+
+	if rb := m.Operation.GetRequestBody(); rb != nil && !rb.IsNil() {
+		if t := req.Header.Get("Content-Type"); t != "" {
+			h.requests[mediaTypeKey(rb.GetContent(), t)]++
+		}
+	}
+	responseKey, resp := responseOf(m.Operation.GetResponses(), status)
+	s := h.statuses[responseKey]
+	if s == nil {
+		s = &statusHits{mediaTypes: make(map[string]int)}
+		h.statuses[responseKey] = s
+	}
+	s.calls++
+	if contentType != "" {
+		var content map[string]unified.MediaType
+		if resp != nil {
+			content = resp.GetContent()
+		}
+		s.mediaTypes[mediaTypeKey(content, contentType)]++
+	}
+}
+
+// responseOf returns the key of the response documenting a status: its
+// code, its range or default, or the status itself when it is undocumented
+func responseOf(responses unified.Responses, status int) (string, unified.Response) {
+	code := strconv.Itoa(status)
+	if responses == nil {
+		return code, nil
+	}
+	codes := responses.GetStatusCodes()
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx"} {
+		if resp, ok := codes[key]; ok && resp != nil {
+			return key, resp
+		}
+	}
+	if resp := responses.GetDefault(); resp != nil && !resp.IsNil() {
+		return "default", resp
+	}
+	return code, nil
+}
+
+// mediaTypeKey returns the key of the content a media type matches, or the
+// media type itself when it matches none
+func mediaTypeKey(content map[string]unified.MediaType, mediaType string) string {
+	if key, ok := oa3.MatchMediaType(slices.Collect(maps.Keys(content)), mediaType); ok {
+		return key
+	}
+	return strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+}
+
+// Middleware records the exchanges of a handler, such as a server under
+// test or a proxy
+func (r *Recorder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		sw := &statusWriter{ResponseWriter: w}
+		next.ServeHTTP(sw, req)
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+		r.Record(req, sw.status, w.Header().Get("Content-Type"))
+	})
+}
+
+// statusWriter remembers the status a handler writes
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Transport returns a round tripper recording the exchanges it sends through
+// base, or through http.DefaultTransport when base is nil, for the clients of
+// tests
+func (r *Recorder) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return roundTripper(func(req *http.Request) (*http.Response, error) {
+		resp, err := base.RoundTrip(req)
+		if err == nil {
+			r.Record(req, resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+		return resp, err
+	})
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
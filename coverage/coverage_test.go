@@ -0,0 +1,129 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package coverage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+)
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return doc
+}
+
+const pets = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Pets", "version": "1"},
+	"paths": {
+		"/pets": {
+			"get": {
+				"operationId": "listPets",
+				"responses": {
+					"200": {"description": "OK", "content": {"application/json": {}, "text/csv": {}}},
+					"4XX": {"description": "Client error", "content": {"application/problem+json": {}}}
+				}
+			},
+			"post": {
+				"operationId": "createPet",
+				"requestBody": {"content": {"application/json": {}, "application/x-www-form-urlencoded": {}}},
+				"responses": {"201": {"description": "Created"}, "default": {"description": "Error"}}
+			}
+		},
+		"/pets/{petId}": {
+			"delete": {"responses": {"204": {"description": "Deleted"}}}
+		}
+	}
+}`
+
+func TestRecorder(t *testing.T) {
+	r, err := New(parse(t, pets), Options{Router: router.Options{IgnoreHost: true}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	handler := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/toys":
+			http.NotFound(w, req)
+		case req.Method == "POST":
+			w.WriteHeader(http.StatusInternalServerError)
+		case req.URL.Query().Get("bad") != "":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Write([]byte("[]"))
+		}
+	}))
+	ts := httptest.NewServer(handler)
+	defer ts.Close()
+
+	http.Get(ts.URL + "/pets")
+	http.Get(ts.URL + "/pets?bad=1")
+	http.Post(ts.URL+"/pets", "application/json", strings.NewReader("{}"))
+	http.Get(ts.URL + "/toys")
+	// The transport records the exchanges of clients too
+	c := &http.Client{Transport: r.Transport(nil)}
+	c.Post(ts.URL+"/pets", "text/plain", strings.NewReader("Rex"))
+
+	report := r.Report()
+	if got := report.Summary.Operations.String(); got != "2/3 (66.7%)" {
+		t.Errorf("Operations = %s", got)
+	}
+	if got := report.Summary.Responses.String(); got != "3/5 (60.0%)" {
+		t.Errorf("Responses = %s", got)
+	}
+	if got := report.Summary.MediaTypes; got.Covered != 3 || got.Total != 5 {
+		t.Errorf("MediaTypes = %s", got)
+	}
+	if report.Operations[0].Calls != 2 || report.Operations[0].Responses[1].Calls != 1 {
+		t.Errorf("Unexpected get coverage %+v", report.Operations[0])
+	}
+	wantUncovered := []string{
+		"GET /pets 200 text/csv",
+		"POST /pets request application/x-www-form-urlencoded",
+		"POST /pets 201",
+		"DELETE /pets/{petId}",
+	}
+	if got := report.Uncovered(); !slices.Equal(got, wantUncovered) {
+		t.Errorf("Uncovered() = %q, want %q", got, wantUncovered)
+	}
+	wantUndocumented := []string{"POST /pets request text/plain", "GET /toys"}
+	if !slices.Equal(report.Undocumented, wantUndocumented) {
+		t.Errorf("Undocumented = %q, want %q", report.Undocumented, wantUndocumented)
+	}
+
+	var b strings.Builder
+	if err := WriteText(&b, report); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	want := `Operations:  2/3 (66.7%)
+Responses:   3/5 (60.0%)
+Media types: 3/5 (60.0%)
+
+Uncovered:
+  GET /pets 200 text/csv
+  POST /pets request application/x-www-form-urlencoded
+  POST /pets 201
+  DELETE /pets/{petId}
+
+Undocumented:
+  POST /pets request text/plain
+  GET /toys
+`
+	if b.String() != want {
+		t.Errorf("WriteText() =\n%s\nwant:\n%s", b.String(), want)
+	}
+}
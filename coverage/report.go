@@ -0,0 +1,229 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package coverage
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Report is the coverage of a document by the exchanges a Recorder recorded
+type Report struct {
+	Summary    Summary             `json:"summary"`
+	Operations []OperationCoverage `json:"operations"`
+	// Undocumented lists the exchanges the document does not describe: a
+	// request matching no operation, as "GET /toys", or a status or media
+	// type an operation does not document, as "GET /pets 500"
+	Undocumented []string `json:"undocumented,omitempty"`
+}
+
+// Summary counts the covered parts of a document
+type Summary struct {
+	Operations Count `json:"operations"`
+	Responses  Count `json:"responses"`
+	MediaTypes Count `json:"mediaTypes"`
+}
+
+// Count is how many of the parts of a kind are covered
+type Count struct {
+	Covered int `json:"covered"`
+	Total   int `json:"total"`
+}
+
+// Percent returns the covered share, 100 when there is nothing to cover
+func (c Count) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return 100 * float64(c.Covered) / float64(c.Total)
+}
+
+func (c Count) String() string {
+	return fmt.Sprintf("%d/%d (%.1f%%)", c.Covered, c.Total, c.Percent())
+}
+
+// OperationCoverage is the coverage of an operation
+type OperationCoverage struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+	Calls       int    `json:"calls"`
+	// RequestBodies are the media types of the request body
+	RequestBodies []MediaTypeCoverage `json:"requestBodies,omitempty"`
+	Responses     []ResponseCoverage  `json:"responses,omitempty"`
+}
+
+// ResponseCoverage is the coverage of a documented response, by its key
+type ResponseCoverage struct {
+	Status     string              `json:"status"`
+	Calls      int                 `json:"calls"`
+	MediaTypes []MediaTypeCoverage `json:"mediaTypes,omitempty"`
+}
+
+// MediaTypeCoverage is the coverage of a documented media type
+type MediaTypeCoverage struct {
+	MediaType string `json:"mediaType"`
+	Calls     int    `json:"calls"`
+}
+
+// Report returns the coverage recorded so far. Operations are sorted by
+// path and method, responses by status and media types by name.
+func (r *Recorder) Report() *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	report := &Report{}
+	paths := r.doc.GetPaths()
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		item := paths[path]
+		if item == nil {
+			continue
+		}
+		operations := item.GetAllOperations()
+		for _, method := range slices.Sorted(maps.Keys(operations)) {
+			op := operations[method]
+			method = strings.ToUpper(method)
+			key := method + " " + path
+			h := r.operations[key]
+			if h == nil {
+				h = &hits{}
+			}
+			oc := OperationCoverage{Method: method, Path: path, OperationID: op.GetOperationID(), Calls: h.calls}
+			report.Summary.Operations.add(h.calls)
+
+			if rb := op.GetRequestBody(); rb != nil && !rb.IsNil() {
+				oc.RequestBodies = mediaTypes(rb.GetContent(), h.requests, &report.Summary.MediaTypes)
+				for _, t := range undocumented(rb.GetContent(), h.requests) {
+					report.Undocumented = append(report.Undocumented, key+" request "+t)
+				}
+			}
+			documented := make(map[string]bool)
+			if responses := op.GetResponses(); responses != nil {
+				codes := make(map[string]unified.Response)
+				maps.Copy(codes, responses.GetStatusCodes())
+				if d := responses.GetDefault(); d != nil && !d.IsNil() {
+					codes["default"] = d
+				}
+				for _, status := range slices.Sorted(maps.Keys(codes)) {
+					resp := codes[status]
+					if resp == nil {
+						continue
+					}
+					documented[status] = true
+					s := h.statuses[status]
+					if s == nil {
+						s = &statusHits{}
+					}
+					report.Summary.Responses.add(s.calls)
+					oc.Responses = append(oc.Responses, ResponseCoverage{
+						Status:     status,
+						Calls:      s.calls,
+						MediaTypes: mediaTypes(resp.GetContent(), s.mediaTypes, &report.Summary.MediaTypes),
+					})
+					for _, t := range undocumented(resp.GetContent(), s.mediaTypes) {
+						report.Undocumented = append(report.Undocumented, key+" "+status+" "+t)
+					}
+				}
+			}
+			for _, status := range slices.Sorted(maps.Keys(h.statuses)) {
+				if !documented[status] {
+					report.Undocumented = append(report.Undocumented, key+" "+status)
+				}
+			}
+			report.Operations = append(report.Operations, oc)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(r.unmatched)) {
+		report.Undocumented = append(report.Undocumented, key)
+	}
+	return report
+}
+
+// add counts a part, covered when it has calls
+func (c *Count) add(calls int) {
+	c.Total++
+	if calls > 0 {
+		c.Covered++
+	}
+}
+
+// mediaTypes returns the coverage of documented media types, counting them
+func mediaTypes[T any](content map[string]T, calls map[string]int, count *Count) []MediaTypeCoverage {
+	var covered []MediaTypeCoverage
+	for _, t := range slices.Sorted(maps.Keys(content)) {
+		count.add(calls[t])
+		covered = append(covered, MediaTypeCoverage{MediaType: t, Calls: calls[t]})
+	}
+	return covered
+}
+
+// undocumented returns the media types recorded that are not documented
+func undocumented[T any](content map[string]T, calls map[string]int) []string {
+	var found []string
+	for _, t := range slices.Sorted(maps.Keys(calls)) {
+		if _, ok := content[t]; !ok {
+			found = append(found, t)
+		}
+	}
+	return found
+}
+
+// Uncovered lists the documented parts without calls, as "GET /pets",
+// "GET /pets 404" or "GET /pets 200 text/csv", in the order of the report.
+// The media types and responses of an uncovered operation are not listed.
+func (r *Report) Uncovered() []string {
+	var found []string
+	for _, oc := range r.Operations {
+		key := oc.Method + " " + oc.Path
+		if oc.Calls == 0 {
+			found = append(found, key)
+			continue
+		}
+		for _, mt := range oc.RequestBodies {
+			if mt.Calls == 0 {
+				found = append(found, key+" request "+mt.MediaType)
+			}
+		}
+		for _, rc := range oc.Responses {
+			if rc.Calls == 0 {
+				found = append(found, key+" "+rc.Status)
+				continue
+			}
+			for _, mt := range rc.MediaTypes {
+				if mt.Calls == 0 {
+					found = append(found, key+" "+rc.Status+" "+mt.MediaType)
+				}
+			}
+		}
+	}
+	return found
+}
+
+// WriteText writes a report for people: the summary, then the uncovered and
+// the undocumented parts
+func WriteText(w io.Writer, report *Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Operations:  %s\n", report.Summary.Operations)
+	fmt.Fprintf(&b, "Responses:   %s\n", report.Summary.Responses)
+	fmt.Fprintf(&b, "Media types: %s\n", report.Summary.MediaTypes)
+	if uncovered := report.Uncovered(); len(uncovered) > 0 {
+		b.WriteString("\nUncovered:\n")
+		for _, u := range uncovered {
+			fmt.Fprintf(&b, "  %s\n", u)
+		}
+	}
+	if len(report.Undocumented) > 0 {
+		b.WriteString("\nUndocumented:\n")
+		for _, u := range report.Undocumented {
+			fmt.Fprintf(&b, "  %s\n", u)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Baseline lists the breaking changes already communicated to clients, so
+// that checks stop failing on them. It marshals to JSON, to be kept in a
+// file next to the API description.
+type Baseline struct {
+	Entries []BaselineEntry `json:"entries"`
+}
+
+// BaselineEntry is an accepted change, identified by the rule that
+// classified it, its pointer and a hash of the change, so that a different
+// change at the same place is not accepted with it
+type BaselineEntry struct {
+	Rule    string `json:"rule"`
+	Pointer string `json:"pointer"` // To for an addition, From otherwise
+	Hash    string `json:"hash"`
+	Message string `json:"message,omitempty"` // for readers of the file
+}
+
+// NewBaseline returns a baseline accepting the breaking changes of a
+// report. Regenerating the baseline from the latest report drops the
+// entries that no longer apply.
+func NewBaseline(report *Report) *Baseline {
+	b := &Baseline{}
+	for _, c := range report.Filter(CompatibilityBreaking) {
+		b.Entries = append(b.Entries, baselineEntry(c))
+	}
+	return b
+}
+
+// ParseBaseline decodes a baseline from its JSON form
+func ParseBaseline(data []byte) (*Baseline, error) {
+	b := &Baseline{}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("invalid baseline: %w", err)
+	}
+	for i, e := range b.Entries {
+		if e.Rule == "" || e.Hash == "" {
+			return nil, fmt.Errorf("invalid baseline: entry %d has no rule or hash", i)
+		}
+	}
+	return b, nil
+}
+
+// baselineEntry returns the entry accepting a change
+func baselineEntry(c Classified) BaselineEntry {
+	pointer := c.From
+	if c.Action == ActionAdded {
+		pointer = c.To
+	}
+	return BaselineEntry{Rule: c.Rule, Pointer: pointer, Hash: changeHash(c.Change), Message: c.Message}
+}
+
+// changeHash returns a short hash of the content of a change
+func changeHash(c Change) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		c.Action.String(), c.Element.String(), c.Aspect, c.Direction,
+		c.Operation.String(), c.From, c.To, c.Message,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Accepts reports whether the baseline accepts a change
+func (b *Baseline) Accepts(c Classified) bool {
+	if b == nil {
+		return false
+	}
+	return slices.ContainsFunc(b.Entries, baselineEntry(c).matches)
+}
+
+// matches reports whether two entries accept the same change
+func (e BaselineEntry) matches(other BaselineEntry) bool {
+	return e.Rule == other.Rule && e.Pointer == other.Pointer && e.Hash == other.Hash
+}
+
+// Apply returns the report without the changes the baseline accepts, and
+// the entries of the baseline that match no change of the report anymore
+func (b *Baseline) Apply(report *Report) (*Report, []BaselineEntry) {
+	result := &Report{}
+	var used []BaselineEntry
+	if report != nil {
+		for _, c := range report.Changes {
+			if b.Accepts(c) {
+				used = append(used, baselineEntry(c))
+				continue
+			}
+			result.Changes = append(result.Changes, c)
+		}
+	}
+	var stale []BaselineEntry
+	if b != nil {
+		for _, e := range b.Entries {
+			if !slices.ContainsFunc(used, e.matches) {
+				stale = append(stale, e)
+			}
+		}
+	}
+	return result, stale
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBaseline(t *testing.T) {
+	removedGet := Change{Action: ActionRemoved, Element: ElementOperation, Operation: Operation{Path: "/pets", Method: "GET"},
+		From: "/paths/~1pets/get", To: "/paths/~1pets/get", Message: "GET /pets removed"}
+	removedPost := Change{Action: ActionRemoved, Element: ElementOperation, Operation: Operation{Path: "/pets", Method: "POST"},
+		From: "/paths/~1pets/post", To: "/paths/~1pets/post", Message: "POST /pets removed"}
+	added := Change{Action: ActionAdded, Element: ElementOperation, Operation: Operation{Path: "/toys", Method: "GET"},
+		From: "/paths/~1toys/get", To: "/paths/~1toys/get", Message: "GET /toys added"}
+
+	first := Classify(&ChangeSet{Changes: []Change{removedGet, added}})
+	baseline := NewBaseline(first)
+	if len(baseline.Entries) != 1 || baseline.Entries[0].Rule != "removed-operation" || baseline.Entries[0].Pointer != "/paths/~1pets/get" {
+		t.Fatalf("Unexpected baseline: %+v", baseline)
+	}
+
+	data, err := json.Marshal(baseline)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	loaded, err := ParseBaseline(data)
+	if err != nil {
+		t.Fatalf("ParseBaseline() error = %v", err)
+	}
+
+	// The accepted change no longer fails the check, a new one does
+	second := Classify(&ChangeSet{Changes: []Change{removedGet, removedPost, added}})
+	remaining, stale := loaded.Apply(second)
+	if len(stale) != 0 {
+		t.Errorf("Expected no stale entries, got %v", stale)
+	}
+	if len(remaining.Changes) != 2 || !remaining.HasBreaking() || remaining.Changes[0].Message != "POST /pets removed" {
+		t.Errorf("Unexpected remaining changes: %s", remaining)
+	}
+
+	// A change at the same place that differs is not accepted
+	changed := removedGet
+	changed.Message = "GET /pets removed for good"
+	if loaded.Accepts(Classify(&ChangeSet{Changes: []Change{changed}}).Changes[0]) {
+		t.Error("Expected a different change not to be accepted")
+	}
+
+	// Once the change is gone, its entry is stale
+	remaining, stale = loaded.Apply(Classify(&ChangeSet{Changes: []Change{added}}))
+	if len(remaining.Changes) != 1 || len(stale) != 1 || stale[0].Message != "GET /pets removed" {
+		t.Errorf("Expected the entry to be stale, got %s and %v", remaining, stale)
+	}
+
+	if _, err := ParseBaseline([]byte(`{"entries": [{"pointer": "/paths"}]}`)); err == nil {
+		t.Error("Expected an error for an entry without rule or hash")
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Grouping decides how a changelog groups the changes
+type Grouping int
+
+const (
+	// GroupByPath groups the changes of operations by path, then webhook
+	GroupByPath Grouping = iota
+	// GroupByTag groups the changes of operations by tag; an operation with
+	// several tags is listed under each
+	GroupByTag
+)
+
+// ChangelogOptions configures WriteChangelog
+type ChangelogOptions struct {
+	Title   string // the heading, "Changelog" by default
+	GroupBy Grouping
+
+	// Before and After are the documents compared. GroupByTag takes the tags
+	// of an operation from After, or from Before for a removed one.
+	Before, After unified.Document
+
+	// Template renders a Changelog; the default is DefaultChangelogTemplate.
+	// Use ParseChangelogTemplate to have the markdown function.
+	Template *template.Template
+}
+
+// Changelog is what a changelog template renders
+type Changelog struct {
+	Title    string
+	Breaking int // the number of breaking changes
+	Groups   []ChangelogGroup
+}
+
+// ChangelogGroup is a path, webhook or tag and its changes. The changes of
+// components come last, in a group named "Components"; with GroupByTag,
+// those of operations without tags come before, in a group named
+// "Untagged".
+type ChangelogGroup struct {
+	Name    string
+	Changes []Classified
+}
+
+// DefaultChangelogTemplate lists the changes of each group, breaking ones
+// marked
+const DefaultChangelogTemplate = `# {{markdown .Title}}
+{{if .Breaking}}
+**{{.Breaking}} breaking {{if eq .Breaking 1}}change{{else}}changes{{end}}**
+{{end}}{{range .Groups}}
+## {{markdown .Name}}
+{{range .Changes}}
+- {{if .Breaking}}**Breaking:** {{end}}{{markdown .Message}}{{end}}
+{{end}}`
+
+var defaultChangelog = template.Must(ParseChangelogTemplate(DefaultChangelogTemplate))
+
+// ParseChangelogTemplate parses a changelog template. Besides the builtin
+// functions, it can call markdown, which escapes text for Markdown.
+func ParseChangelogTemplate(text string) (*template.Template, error) {
+	return template.New("changelog").Funcs(template.FuncMap{"markdown": markdownEscaper.Replace}).Parse(text)
+}
+
+// markdownEscaper escapes the characters Markdown gives a meaning to in text
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "|", `\|`, "#", `\#`,
+)
+
+// WriteChangelog renders the classified changes of a report as a Markdown
+// changelog, for release notes
+func WriteChangelog(w io.Writer, report *Report, opts ChangelogOptions) error {
+	log := Changelog{Title: opts.Title}
+	if log.Title == "" {
+		log.Title = "Changelog"
+	}
+	groups := make(map[string][]Classified)
+	var components []Classified
+	if report != nil {
+		for _, c := range report.Changes {
+			if c.Breaking() {
+				log.Breaking++
+			}
+			if c.Operation == (Operation{}) {
+				components = append(components, c)
+				continue
+			}
+			for _, name := range opts.groups(c) {
+				groups[name] = append(groups[name], c)
+			}
+		}
+	}
+	// Paths start with a slash, so they sort before webhooks
+	names := slices.Sorted(maps.Keys(groups))
+	if i := slices.Index(names, "Untagged"); i >= 0 && opts.GroupBy == GroupByTag {
+		names = append(slices.Delete(names, i, i+1), "Untagged")
+	}
+	for _, name := range names {
+		log.Groups = append(log.Groups, ChangelogGroup{Name: name, Changes: groups[name]})
+	}
+	if components != nil {
+		log.Groups = append(log.Groups, ChangelogGroup{Name: "Components", Changes: components})
+	}
+	tmpl := opts.Template
+	if tmpl == nil {
+		tmpl = defaultChangelog
+	}
+	return tmpl.Execute(w, log)
+}
+
+// groups returns the names of the groups a change of an operation is in
+func (o ChangelogOptions) groups(c Classified) []string {
+	if o.GroupBy != GroupByTag {
+		if c.Operation.Webhook {
+			return []string{"webhook " + c.Operation.Path}
+		}
+		return []string{c.Operation.Path}
+	}
+	tags, ok := operationTags(o.After, c.Operation)
+	if !ok {
+		tags, _ = operationTags(o.Before, c.Operation)
+	}
+	if len(tags) == 0 {
+		return []string{"Untagged"}
+	}
+	return tags
+}
+
+// operationTags returns the tags of an operation of a document, and false
+// if the document does not have it
+func operationTags(doc unified.Document, op Operation) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	items := doc.GetPaths()
+	if op.Webhook {
+		items = doc.GetWebhooks()
+	}
+	item := items[op.Path]
+	if item == nil {
+		return nil, false
+	}
+	o := item.GetOperation(strings.ToLower(op.Method))
+	if o == nil || o.IsNil() {
+		return nil, false
+	}
+	return o.GetTags(), true
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteChangelog(t *testing.T) {
+	a := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {
+			"/pets": {"get": {"tags": ["pets"], "responses": {"200": {"description": "OK"}, "404": {"description": "Not found"}}}},
+			"/stores": {"get": {"tags": ["stores"], "responses": {"200": {"description": "OK"}}}}
+		},
+		"components": {"schemas": {"Pet": {"type": "object", "properties": {"pet_name": {"type": "string"}}}}}
+	}`)
+	b := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "2"},
+		"paths": {
+			"/pets": {"get": {"tags": ["pets"], "responses": {"200": {"description": "OK"}}}},
+			"/toys": {"get": {"responses": {"200": {"description": "OK"}}}}
+		},
+		"components": {"schemas": {"Pet": {"type": "object", "properties": {}}}}
+	}`)
+	set, err := Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	report := Classify(set)
+
+	var out strings.Builder
+	if err := WriteChangelog(&out, report, ChangelogOptions{Title: "v2"}); err != nil {
+		t.Fatalf("WriteChangelog() error = %v", err)
+	}
+	want := `# v2
+
+**3 breaking changes**
+
+## /pets
+
+- **Breaking:** GET /pets: response 404 removed
+
+## /stores
+
+- **Breaking:** GET /stores removed
+
+## /toys
+
+- GET /toys added
+
+## Components
+
+- **Breaking:** Pet: property pet\_name removed
+`
+	if out.String() != want {
+		t.Errorf("Unexpected changelog:\n%s\nwant:\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := WriteChangelog(&out, report, ChangelogOptions{GroupBy: GroupByTag, Before: a, After: b}); err != nil {
+		t.Fatalf("WriteChangelog() error = %v", err)
+	}
+	for _, heading := range []string{"# Changelog", "## pets", "## stores", "## Untagged", "## Components"} {
+		if !strings.Contains(out.String(), heading+"\n") {
+			t.Errorf("Expected heading %q in:\n%s", heading, out.String())
+		}
+	}
+	if strings.Index(out.String(), "## Untagged") > strings.Index(out.String(), "## Components") {
+		t.Errorf("Expected Untagged before Components:\n%s", out.String())
+	}
+
+	tmpl, err := ParseChangelogTemplate(`{{range .Groups}}{{.Name}}:{{range .Changes}} {{.Compatibility}}{{end}}
+{{end}}`)
+	if err != nil {
+		t.Fatalf("ParseChangelogTemplate() error = %v", err)
+	}
+	out.Reset()
+	if err := WriteChangelog(&out, report, ChangelogOptions{Template: tmpl}); err != nil {
+		t.Fatalf("WriteChangelog() error = %v", err)
+	}
+	want = "/pets: breaking\n/stores: breaking\n/toys: non-breaking\nComponents: breaking\n"
+	if out.String() != want {
+		t.Errorf("Expected %q, got %q", want, out.String())
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Compatibility tells whether a change breaks existing clients
+type Compatibility int
+
+const (
+	// CompatibilityUnknown marks a change no rule recognizes
+	CompatibilityUnknown Compatibility = iota
+	// CompatibilityNonBreaking marks a change existing clients keep working with
+	CompatibilityNonBreaking
+	// CompatibilityBreaking marks a change that breaks existing clients
+	CompatibilityBreaking
+)
+
+var compatibilityNames = []string{"unknown", "non-breaking", "breaking"}
+
+func (c Compatibility) String() string {
+	if c < 0 || int(c) >= len(compatibilityNames) {
+		return fmt.Sprintf("Compatibility(%d)", int(c))
+	}
+	return compatibilityNames[c]
+}
+
+// MarshalText encodes the compatibility by name
+func (c Compatibility) MarshalText() ([]byte, error) {
+	if c < 0 || int(c) >= len(compatibilityNames) {
+		return nil, fmt.Errorf("invalid compatibility %d", int(c))
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a compatibility name
+func (c *Compatibility) UnmarshalText(text []byte) error {
+	compat, err := ParseCompatibility(string(text))
+	if err != nil {
+		return err
+	}
+	*c = compat
+	return nil
+}
+
+// ParseCompatibility returns the compatibility with the given name
+func ParseCompatibility(name string) (Compatibility, error) {
+	i, err := parseName(compatibilityNames, "compatibility", name)
+	return Compatibility(i), err
+}
+
+// Rule recognizes a kind of change and tells whether it breaks clients
+type Rule interface {
+	// Name identifies the rule, e.g. "removed-operation"
+	Name() string
+	// Compatibility is the default compatibility of the changes of the rule
+	Compatibility() Compatibility
+	// Matches reports whether the rule recognizes a change
+	Matches(c Change) bool
+}
+
+// funcRule adapts a function to the Rule interface
+type funcRule struct {
+	name    string
+	compat  Compatibility
+	matches func(c Change) bool
+}
+
+func (r funcRule) Name() string                 { return r.name }
+func (r funcRule) Compatibility() Compatibility { return r.compat }
+func (r funcRule) Matches(c Change) bool        { return r.matches(c) }
+
+// NewRule returns a rule recognizing the changes matches returns true for
+func NewRule(name string, compat Compatibility, matches func(c Change) bool) Rule {
+	return funcRule{name: name, compat: compat, matches: matches}
+}
+
+// DefaultRules returns the built-in rules, breaking ones first:
+//
+//   - removed-operation, removed-response, removed-media-type and
+//     removed-parameter: clients use what is gone
+//   - new-required-parameter and required-request-body: clients do not send
+//     what is now required
+//   - new-required-property: request properties, or of schemas that can be
+//     sent, clients do not send
+//   - removed-property: response properties, or of schemas that can be
+//     received, clients read
+//   - narrowed-enum: values clients can send are refused
+//   - type-change: values no longer have the type clients use
+//   - removed-security: clients authenticate in a way no longer accepted
+//   - deprecation: deprecating, or undeprecating, does not change behavior
+//   - relaxed-request: requests ask for less
+//   - addition: new operations, responses, media types, optional parameters
+//     and properties, request enum values, schemas and security schemes
+func DefaultRules() []Rule {
+	removed := func(element Element) func(Change) bool {
+		return func(c Change) bool { return c.Action == ActionRemoved && c.Element == element }
+	}
+	return []Rule{
+		NewRule("removed-operation", CompatibilityBreaking, removed(ElementOperation)),
+		NewRule("removed-response", CompatibilityBreaking, removed(ElementResponse)),
+		NewRule("removed-media-type", CompatibilityBreaking, removed(ElementMediaType)),
+		NewRule("removed-parameter", CompatibilityBreaking, removed(ElementParameter)),
+		NewRule("new-required-parameter", CompatibilityBreaking, func(c Change) bool {
+			return c.Element == ElementParameter && c.Aspect == AspectRequired
+		}),
+		NewRule("required-request-body", CompatibilityBreaking, func(c Change) bool {
+			return c.Element == ElementRequestBody && c.Aspect == AspectRequired
+		}),
+		NewRule("new-required-property", CompatibilityBreaking, func(c Change) bool {
+			return c.Element == ElementProperty && c.Aspect == AspectRequired && c.Direction != DirectionResponse
+		}),
+		NewRule("removed-property", CompatibilityBreaking, func(c Change) bool {
+			return removed(ElementProperty)(c) && c.Direction != DirectionRequest
+		}),
+		NewRule("narrowed-enum", CompatibilityBreaking, func(c Change) bool {
+			return removed(ElementEnum)(c) && c.Direction != DirectionResponse
+		}),
+		NewRule("type-change", CompatibilityBreaking, func(c Change) bool {
+			return c.Element == ElementSchema && c.Aspect == AspectType
+		}),
+		NewRule("removed-security", CompatibilityBreaking, removed(ElementSecurity)),
+		NewRule("deprecation", CompatibilityNonBreaking, func(c Change) bool {
+			return c.Aspect == AspectDeprecated
+		}),
+		NewRule("relaxed-request", CompatibilityNonBreaking, func(c Change) bool {
+			return c.Aspect == AspectOptional && c.Direction == DirectionRequest
+		}),
+		NewRule("addition", CompatibilityNonBreaking, func(c Change) bool {
+			if c.Action != ActionAdded || c.Aspect != "" {
+				return false
+			}
+			switch c.Element {
+			case ElementSecurity:
+				return false
+			case ElementEnum:
+				return c.Direction == DirectionRequest
+			}
+			return true
+		}),
+	}
+}
+
+// Classifier classifies changes with a set of rules. The first rule that
+// matches a change decides its compatibility.
+type Classifier struct {
+	rules     []Rule
+	disabled  map[string]bool
+	overrides map[string]Compatibility
+}
+
+// NewClassifier returns a classifier running the given rules, or the
+// DefaultRules when there are none
+func NewClassifier(rules ...Rule) (*Classifier, error) {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	c := &Classifier{disabled: make(map[string]bool), overrides: make(map[string]Compatibility)}
+	if err := c.Register(rules...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Register adds rules after the others. Rule names must be unique.
+func (c *Classifier) Register(rules ...Rule) error {
+	for _, r := range rules {
+		if r == nil || r.Name() == "" {
+			return fmt.Errorf("rule must have a name")
+		}
+		if c.Rule(r.Name()) != nil {
+			return fmt.Errorf("rule %q is already registered", r.Name())
+		}
+		c.rules = append(c.rules, r)
+	}
+	return nil
+}
+
+// Rules returns the registered rules in registration order
+func (c *Classifier) Rules() []Rule {
+	return append([]Rule(nil), c.rules...)
+}
+
+// Rule returns the registered rule with the given name, or nil
+func (c *Classifier) Rule(name string) Rule {
+	for _, r := range c.rules {
+		if r.Name() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Disable turns rules off by name
+func (c *Classifier) Disable(names ...string) {
+	for _, name := range names {
+		c.disabled[name] = true
+	}
+}
+
+// Enable turns disabled rules back on
+func (c *Classifier) Enable(names ...string) {
+	for _, name := range names {
+		delete(c.disabled, name)
+	}
+}
+
+// SetCompatibility overrides the default compatibility of a rule
+func (c *Classifier) SetCompatibility(name string, compat Compatibility) {
+	c.overrides[name] = compat
+}
+
+// Classify classifies every change of a change set, in its order
+func (c *Classifier) Classify(set *ChangeSet) *Report {
+	report := &Report{}
+	if set == nil {
+		return report
+	}
+	for _, change := range set.Changes {
+		classified := Classified{Change: change}
+		for _, r := range c.rules {
+			name := r.Name()
+			if c.disabled[name] || !r.Matches(change) {
+				continue
+			}
+			compat, ok := c.overrides[name]
+			if !ok {
+				compat = r.Compatibility()
+			}
+			classified.Compatibility, classified.Rule = compat, name
+			break
+		}
+		report.Changes = append(report.Changes, classified)
+	}
+	return report
+}
+
+// Classify classifies the changes of a change set with the DefaultRules
+func Classify(set *ChangeSet) *Report {
+	c, _ := NewClassifier()
+	return c.Classify(set)
+}
+
+// Classified is a change with its compatibility, and the rule that decided
+// it, empty when it is unknown
+type Classified struct {
+	Change
+	Compatibility Compatibility `json:"compatibility"`
+	Rule          string        `json:"rule,omitempty"`
+}
+
+// Breaking reports whether the change breaks clients
+func (c Classified) Breaking() bool {
+	return c.Compatibility == CompatibilityBreaking
+}
+
+func (c Classified) String() string {
+	if c.Rule == "" {
+		return fmt.Sprintf("[%s] %s", c.Compatibility, c.Change)
+	}
+	return fmt.Sprintf("[%s] %s: %s", c.Compatibility, c.Rule, c.Change)
+}
+
+// Report lists the classified changes from one document to another
+type Report struct {
+	Changes []Classified `json:"changes"`
+}
+
+// HasBreaking returns true if any change breaks clients. CI jobs can use it
+// to block a release.
+func (r *Report) HasBreaking() bool {
+	return len(r.Filter(CompatibilityBreaking)) > 0
+}
+
+// Filter returns the changes with the compatibility
+func (r *Report) Filter(compat Compatibility) []Classified {
+	if r == nil {
+		return nil
+	}
+	var changes []Classified
+	for _, c := range r.Changes {
+		if c.Compatibility == compat {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String returns a combined description of all changes
+func (r *Report) String() string {
+	if r == nil || len(r.Changes) == 0 {
+		return ""
+	}
+	var msgs []string
+	for _, c := range r.Changes {
+		msgs = append(msgs, c.String())
+	}
+	return strings.Join(msgs, "; ")
+}
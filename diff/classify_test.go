@@ -0,0 +1,131 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	a := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [{"name": "status", "in": "query", "schema": {"type": "string", "enum": ["available", "sold"]}}],
+					"responses": {
+						"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "object", "properties": {"id": {"type": "integer"}, "name": {"type": "string"}}}}}},
+						"404": {"description": "Not found"}
+					}
+				},
+				"post": {
+					"requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {"name": {"type": "string"}}}}}},
+					"responses": {"201": {"description": "Created"}}
+				}
+			},
+			"/stores": {"get": {"responses": {"200": {"description": "OK"}}}}
+		}
+	}`)
+	b := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "2"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"deprecated": true,
+					"parameters": [{"name": "status", "in": "query", "schema": {"type": "string", "enum": ["available"]}}],
+					"responses": {
+						"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "object", "properties": {"id": {"type": "string"}, "name": {"type": "string"}, "tag": {"type": "string"}}}}}}
+					}
+				},
+				"post": {
+					"requestBody": {"content": {"application/json": {"schema": {"type": "object", "required": ["owner"], "properties": {"name": {"type": "string"}, "owner": {"type": "string"}}}}}},
+					"responses": {"201": {"description": "Created"}}
+				}
+			},
+			"/toys": {"get": {"responses": {"200": {"description": "OK"}}}}
+		}
+	}`)
+	set, err := Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	report := Classify(set)
+	got := make(map[string]string)
+	for _, c := range report.Changes {
+		got[c.Message] = c.Compatibility.String() + " " + c.Rule
+	}
+	want := map[string]string{
+		"GET /pets deprecated": "non-breaking deprecation",
+		"GET /pets: query parameter status: enum value \"sold\" removed":                   "breaking narrowed-enum",
+		"GET /pets: response 200 application/json.id: type changed from integer to string": "breaking type-change",
+		"GET /pets: response 200 application/json: property tag added":                     "non-breaking addition",
+		"GET /pets: response 404 removed":                                                  "breaking removed-response",
+		"POST /pets: request body application/json: required property owner added":         "breaking new-required-property",
+		"GET /stores removed": "breaking removed-operation",
+		"GET /toys added":     "non-breaking addition",
+	}
+	if len(got) != len(want) {
+		t.Errorf("Expected %d changes, got %d: %s", len(want), len(got), report)
+	}
+	for msg, w := range want {
+		if got[msg] != w {
+			t.Errorf("%s: expected %q, got %q", msg, w, got[msg])
+		}
+	}
+	if !report.HasBreaking() {
+		t.Error("Expected breaking changes")
+	}
+	if n := len(report.Filter(CompatibilityBreaking)); n != 5 {
+		t.Errorf("Expected 5 breaking changes, got %d", n)
+	}
+}
+
+func TestClassifierConfiguration(t *testing.T) {
+	set := &ChangeSet{Changes: []Change{
+		{Action: ActionRemoved, Element: ElementOperation, From: "/paths/~1pets/get", To: "/paths/~1pets/get", Message: "GET /pets removed"},
+		{Action: ActionModified, Element: ElementSchema, Aspect: AspectNullable, Message: "Pet.id became nullable"},
+	}}
+	c, err := NewClassifier()
+	if err != nil {
+		t.Fatalf("NewClassifier() error = %v", err)
+	}
+	c.SetCompatibility("removed-operation", CompatibilityNonBreaking)
+	report := c.Classify(set)
+	if report.HasBreaking() {
+		t.Errorf("Expected the override to apply, got %s", report)
+	}
+	if report.Changes[1].Compatibility != CompatibilityUnknown || report.Changes[1].Rule != "" {
+		t.Errorf("Expected an unknown change, got %s", report.Changes[1])
+	}
+
+	c.Disable("removed-operation")
+	if err := c.Register(NewRule("nullable", CompatibilityBreaking, func(change Change) bool {
+		return change.Aspect == AspectNullable
+	})); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	report = c.Classify(set)
+	if report.Changes[0].Compatibility != CompatibilityUnknown || report.Changes[1].Rule != "nullable" {
+		t.Errorf("Unexpected classification: %s", report)
+	}
+	if err := c.Register(NewRule("nullable", CompatibilityBreaking, nil)); err == nil {
+		t.Error("Expected an error for a duplicate rule")
+	}
+
+	data, err := json.Marshal(report.Changes[1])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"aspect":"nullable"`) || !strings.Contains(string(data), `"compatibility":"breaking","rule":"nullable"`) {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+	if _, err := ParseCompatibility("fatal"); err == nil {
+		t.Error("Expected an error for an unknown compatibility")
+	}
+}
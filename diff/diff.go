@@ -0,0 +1,384 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package diff compares two OpenAPI documents, of any versions, through the
+// unified.Document interface. The result is a structured change set: the
+// operations, parameters, request and response bodies, schemas, schema
+// properties, enum values and security that were added, removed or modified.
+// Every change is located in both documents with JSON pointers in the layout
+// of their own version, so a 2.0 document can be compared with a 3.1 one.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Action tells how an element changed
+type Action int
+
+const (
+	// ActionAdded marks an element only in the second document
+	ActionAdded Action = iota
+	// ActionRemoved marks an element only in the first document
+	ActionRemoved
+	// ActionModified marks an element in both documents that differs
+	ActionModified
+)
+
+var actionNames = []string{"added", "removed", "modified"}
+
+func (a Action) String() string {
+	if a < 0 || int(a) >= len(actionNames) {
+		return fmt.Sprintf("Action(%d)", int(a))
+	}
+	return actionNames[a]
+}
+
+// MarshalText encodes the action by name
+func (a Action) MarshalText() ([]byte, error) {
+	if a < 0 || int(a) >= len(actionNames) {
+		return nil, fmt.Errorf("invalid action %d", int(a))
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes an action name
+func (a *Action) UnmarshalText(text []byte) error {
+	i, err := parseName(actionNames, "action", string(text))
+	if err != nil {
+		return err
+	}
+	*a = Action(i)
+	return nil
+}
+
+// Element tells what kind of element changed
+type Element int
+
+const (
+	// ElementOperation is an operation of a path or a webhook
+	ElementOperation Element = iota
+	// ElementParameter is a parameter of an operation or its path item
+	ElementParameter
+	// ElementRequestBody is the request body of an operation
+	ElementRequestBody
+	// ElementResponse is a response of an operation, by status code
+	ElementResponse
+	// ElementMediaType is a media type of a request or response body
+	ElementMediaType
+	// ElementSchema is a component schema, or the type of any schema
+	ElementSchema
+	// ElementProperty is a property of a schema
+	ElementProperty
+	// ElementEnum is a value of an enum
+	ElementEnum
+	// ElementSecurity is a security requirement of an operation
+	ElementSecurity
+	// ElementSecurityScheme is a security scheme definition
+	ElementSecurityScheme
+)
+
+var elementNames = []string{
+	"operation", "parameter", "request-body", "response", "media-type",
+	"schema", "property", "enum", "security", "security-scheme",
+}
+
+func (e Element) String() string {
+	if e < 0 || int(e) >= len(elementNames) {
+		return fmt.Sprintf("Element(%d)", int(e))
+	}
+	return elementNames[e]
+}
+
+// MarshalText encodes the element by name
+func (e Element) MarshalText() ([]byte, error) {
+	if e < 0 || int(e) >= len(elementNames) {
+		return nil, fmt.Errorf("invalid element %d", int(e))
+	}
+	return []byte(e.String()), nil
+}
+
+// UnmarshalText decodes an element name
+func (e *Element) UnmarshalText(text []byte) error {
+	i, err := parseName(elementNames, "element", string(text))
+	if err != nil {
+		return err
+	}
+	*e = Element(i)
+	return nil
+}
+
+// parseName returns the index of a name in names
+func parseName(names []string, kind, name string) (int, error) {
+	for i, n := range names {
+		if strings.EqualFold(name, n) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s %q", kind, name)
+}
+
+// The aspects of a Change, telling what changed about an element
+const (
+	AspectRequired    = "required"    // it became required, or was added as required
+	AspectOptional    = "optional"    // it became optional
+	AspectDeprecated  = "deprecated"  // it became deprecated, or no longer is
+	AspectOperationID = "operationId" // the operationId of an operation changed
+	AspectType        = "type"        // the type or format of a schema, or the kind of a security scheme, changed
+	AspectNullable    = "nullable"    // a schema became nullable, or no longer is
+	AspectReference   = "reference"   // a reference that is not followed changed
+	AspectComposition = "composition" // a schema of allOf, anyOf or oneOf was added or removed
+	AspectScope       = "scope"       // an oauth2 scope was added or removed
+)
+
+// The directions of a Change, telling whether clients send or receive what
+// changed. Changes to operations, security and component schemas, which
+// requests and responses can share, have no direction.
+const (
+	DirectionRequest  = "request"
+	DirectionResponse = "response"
+)
+
+// Operation identifies an operation: a method of a path, or of a webhook
+type Operation struct {
+	Path    string `json:"path"` // or the webhook name
+	Method  string `json:"method"`
+	Webhook bool   `json:"webhook,omitempty"`
+}
+
+func (o Operation) String() string {
+	if o.Webhook {
+		return "webhook " + o.Path + " " + o.Method
+	}
+	return o.Method + " " + o.Path
+}
+
+// Change is a difference between two documents. From and To are JSON
+// pointers into the first and the second document. An added element has the
+// From pointer it would have in the first document, and a removed one the To
+// pointer it would have in the second; an element of a list, such as an enum
+// value, then points at the list. Elements reached through a reference are
+// located where the reference leads.
+type Change struct {
+	Action    Action    `json:"action"`
+	Element   Element   `json:"element"`
+	Aspect    string    `json:"aspect,omitempty"`
+	Direction string    `json:"direction,omitempty"`
+	Operation Operation `json:"operation,omitzero"` // the operation changed, zero for components
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Message   string    `json:"message"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("[%s %s] %s (%s -> %s)", c.Action, c.Element, c.Message, c.From, c.To)
+}
+
+// ChangeSet lists the changes from one document to another, ordered by
+// operation, then by component
+type ChangeSet struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty returns true if the documents describe the same elements
+func (s *ChangeSet) Empty() bool {
+	return s == nil || len(s.Changes) == 0
+}
+
+// ByAction returns the changes with the action
+func (s *ChangeSet) ByAction(action Action) []Change {
+	return s.filter(func(c Change) bool { return c.Action == action })
+}
+
+// ByElement returns the changes to the kind of element
+func (s *ChangeSet) ByElement(element Element) []Change {
+	return s.filter(func(c Change) bool { return c.Element == element })
+}
+
+func (s *ChangeSet) filter(keep func(Change) bool) []Change {
+	if s == nil {
+		return nil
+	}
+	var changes []Change
+	for _, c := range s.Changes {
+		if keep(c) {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String returns a combined description of all changes
+func (s *ChangeSet) String() string {
+	if s.Empty() {
+		return ""
+	}
+	var msgs []string
+	for _, c := range s.Changes {
+		msgs = append(msgs, c.String())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Compare returns the changes from document a to document b. Operations are
+// matched by path and method, parameters by location and name, responses by
+// status code, and schemas and security schemes by component name. Schemas
+// are followed through local references; a schema both documents reference
+// under the same component name is compared once, with the components.
+func Compare(a, b unified.Document) (*ChangeSet, error) {
+	if a == nil || b == nil {
+		return nil, fmt.Errorf("no document to compare")
+	}
+	left, err := newSide(a)
+	if err != nil {
+		return nil, err
+	}
+	right, err := newSide(b)
+	if err != nil {
+		return nil, err
+	}
+	c := &comparer{a: left, b: right, set: &ChangeSet{}, seen: make(map[string]bool)}
+	c.operations("paths")
+	c.operations("webhooks")
+	c.schemas()
+	c.securitySchemes()
+	return c.set, nil
+}
+
+// side is a document being compared
+type side struct {
+	doc  unified.Document // resolving local references
+	root map[string]any   // the generic JSON form, to locate elements
+	v20  bool
+}
+
+func newSide(doc unified.Document) (*side, error) {
+	resolved, err := unified.Resolve(doc)
+	if err != nil {
+		return nil, err
+	}
+	var raw any
+	switch d := doc.(type) {
+	case *unified.Document20:
+		raw = d.GetRaw()
+	case *unified.Document30:
+		raw = d.GetRaw()
+	case *unified.Document31:
+		raw = d.GetRaw()
+	case *unified.Document32:
+		raw = d.GetRaw()
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	s := &side{doc: resolved, v20: strings.HasPrefix(doc.Version(), "2.")}
+	if err := json.Unmarshal(data, &s.root); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// node returns the value of the generic JSON form at the tokens, or nil
+func (s *side) node(tokens ...string) any {
+	var v any = s.root
+	for _, t := range tokens {
+		switch x := v.(type) {
+		case map[string]any:
+			v = x[t]
+		case []any:
+			i, err := strconv.Atoi(t)
+			if err != nil || i < 0 || i >= len(x) {
+				return nil
+			}
+			v = x[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// component returns the tokens of a component section, such as "schemas",
+// or of its 2.0 counterpart
+func (s *side) component(section string, tokens ...string) []string {
+	if s.v20 {
+		switch section {
+		case "schemas":
+			section = "definitions"
+		case "securitySchemes":
+			section = "securityDefinitions"
+		}
+		return at([]string{section}, tokens...)
+	}
+	return at([]string{"components", section}, tokens...)
+}
+
+// located returns the tokens of a value reached through a local reference,
+// or else the tokens it has where it is used
+func located(value any, tokens []string) []string {
+	if ref := refTokens(unified.RawRef(value)); ref != nil {
+		return ref
+	}
+	return tokens
+}
+
+// refTokens returns the tokens of a local reference, or nil for others
+func refTokens(ref string) []string {
+	ptr, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil
+	}
+	tokens := strings.Split(ptr, "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+// at returns the tokens followed by more, without sharing storage
+func at(tokens []string, more ...string) []string {
+	return append(append(make([]string, 0, len(tokens)+len(more)), tokens...), more...)
+}
+
+// pointerEscaper escapes a reference token per RFC 6901
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointer builds a JSON pointer from reference tokens
+func pointer(tokens []string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(t))
+	}
+	return b.String()
+}
+
+// comparer collects the changes between two documents
+type comparer struct {
+	a, b      *side
+	set       *ChangeSet
+	seen      map[string]bool // the schema pairs compared, against cycles
+	op        Operation       // of the changes being collected
+	direction string
+}
+
+// add records a change between the elements at two token lists
+func (c *comparer) add(action Action, element Element, aspect string, from, to []string, format string, args ...any) {
+	c.set.Changes = append(c.set.Changes, Change{
+		Action:    action,
+		Element:   element,
+		Aspect:    aspect,
+		Direction: c.direction,
+		Operation: c.op,
+		From:      pointer(from),
+		To:        pointer(to),
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+)
+
+const swagger = `{
+	"swagger": "2.0",
+	"info": {"title": "Pets", "version": "1"},
+	"produces": ["application/json"],
+	"securityDefinitions": {"api_key": {"type": "apiKey", "name": "X-Key", "in": "header"}},
+	"security": [{"api_key": []}],
+	"paths": {
+		"/pets": {
+			"get": {
+				"parameters": [
+					{"name": "limit", "in": "query", "type": "integer"},
+					{"name": "status", "in": "query", "type": "string", "enum": ["available", "sold"]}
+				],
+				"responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}}}
+			},
+			"post": {
+				"consumes": ["application/json"],
+				"parameters": [{"name": "pet", "in": "body", "schema": {"$ref": "#/definitions/Pet"}}],
+				"responses": {"201": {"description": "Created"}}
+			}
+		}
+	},
+	"definitions": {
+		"Pet": {"type": "object", "required": ["name"], "properties": {"id": {"type": "integer"}, "name": {"type": "string"}, "nickname": {"type": "string"}}}
+	}
+}`
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return doc
+}
+
+func TestCompareAcrossVersions(t *testing.T) {
+	a := parse(t, swagger)
+	b := parse(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "2"},
+		"security": [{"api_key": []}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{"name": "limit", "in": "query", "required": true, "schema": {"type": "string"}},
+						{"name": "status", "in": "query", "schema": {"type": "string", "enum": ["available", "pending"]}},
+						{"name": "X-Trace", "in": "header", "schema": {"type": "string"}}
+					],
+					"security": [{"oauth": ["read"]}],
+					"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}}
+				}
+			},
+			"/pets/{id}": {
+				"delete": {"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}], "responses": {"204": {"description": "Deleted"}}}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {"type": "object", "required": ["name", "tag"], "properties": {"id": {"type": ["integer", "null"]}, "name": {"type": "string"}, "tag": {"type": "string"}}}
+			},
+			"securitySchemes": {
+				"api_key": {"type": "apiKey", "name": "X-Key", "in": "header"},
+				"oauth": {"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://example.com/token", "scopes": {"read": "Read"}}}}
+			}
+		}
+	}`)
+
+	set, err := Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	want := []Change{
+		{ActionAdded, ElementParameter, "", DirectionRequest, Operation{"/pets", "GET", false}, "/paths/~1pets/get/parameters", "/paths/~1pets/get/parameters/2", "GET /pets: header parameter X-Trace added"},
+		{ActionModified, ElementParameter, AspectRequired, DirectionRequest, Operation{"/pets", "GET", false}, "/paths/~1pets/get/parameters/0", "/paths/~1pets/get/parameters/0", "GET /pets: query parameter limit became required"},
+		{ActionModified, ElementSchema, AspectType, DirectionRequest, Operation{"/pets", "GET", false}, "/paths/~1pets/get/parameters/0", "/paths/~1pets/get/parameters/0/schema", "GET /pets: query parameter limit: type changed from integer to string"},
+		{ActionRemoved, ElementEnum, "", DirectionRequest, Operation{"/pets", "GET", false}, "/paths/~1pets/get/parameters/1/enum/1", "/paths/~1pets/get/parameters/1/schema/enum", "GET /pets: query parameter status: enum value \"sold\" removed"},
+		{ActionAdded, ElementEnum, "", DirectionRequest, Operation{"/pets", "GET", false}, "/paths/~1pets/get/parameters/1/enum", "/paths/~1pets/get/parameters/1/schema/enum/1", "GET /pets: query parameter status: enum value \"pending\" added"},
+		{ActionRemoved, ElementSecurity, "", "", Operation{"/pets", "GET", false}, "/security/0", "/paths/~1pets/get/security", "GET /pets: security requirement api_key removed"},
+		{ActionAdded, ElementSecurity, "", "", Operation{"/pets", "GET", false}, "/security", "/paths/~1pets/get/security/0", "GET /pets: security requirement oauth[read] added"},
+		{ActionRemoved, ElementOperation, "", "", Operation{"/pets", "POST", false}, "/paths/~1pets/post", "/paths/~1pets/post", "POST /pets removed"},
+		{ActionAdded, ElementOperation, "", "", Operation{"/pets/{id}", "DELETE", false}, "/paths/~1pets~1{id}/delete", "/paths/~1pets~1{id}/delete", "DELETE /pets/{id} added"},
+		{ActionModified, ElementSchema, AspectNullable, "", Operation{}, "/definitions/Pet/properties/id", "/components/schemas/Pet/properties/id", "Pet.id became nullable"},
+		{ActionRemoved, ElementProperty, "", "", Operation{}, "/definitions/Pet/properties/nickname", "/components/schemas/Pet/properties/nickname", "Pet: property nickname removed"},
+		{ActionAdded, ElementProperty, AspectRequired, "", Operation{}, "/definitions/Pet/properties/tag", "/components/schemas/Pet/properties/tag", "Pet: required property tag added"},
+		{ActionAdded, ElementSecurityScheme, "", "", Operation{}, "/securityDefinitions/oauth", "/components/securitySchemes/oauth", "security scheme oauth added"},
+	}
+	if len(set.Changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %d:\n%s", len(want), len(set.Changes), set)
+	}
+	for i, c := range set.Changes {
+		if c != want[i] {
+			t.Errorf("Change %d:\n got %s\nwant %s", i, c, want[i])
+		}
+	}
+	if got := len(set.ByElement(ElementEnum)); got != 2 {
+		t.Errorf("Expected 2 enum changes, got %d", got)
+	}
+	if got := len(set.ByAction(ActionRemoved)); got != 4 {
+		t.Errorf("Expected 4 removals, got %d", got)
+	}
+}
+
+func TestCompareConverted(t *testing.T) {
+	a := parse(t, swagger)
+	b, _, err := a.ConvertTo("3.1")
+	if err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	set, err := Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if !set.Empty() {
+		t.Errorf("Expected no changes after conversion, got %s", set)
+	}
+	if _, err := Compare(a, nil); err == nil {
+		t.Error("Expected an error without a second document")
+	}
+}
+
+func TestChangeJSON(t *testing.T) {
+	c := Change{Action: ActionAdded, Element: ElementRequestBody, From: "/paths/~1pets/post/requestBody", To: "/paths/~1pets/post/requestBody", Message: "POST /pets: request body added"}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"action":"added","element":"request-body","from":"/paths/~1pets/post/requestBody","to":"/paths/~1pets/post/requestBody","message":"POST /pets: request body added"}`
+	if string(data) != want {
+		t.Errorf("Expected %s, got %s", want, data)
+	}
+	var back Change
+	if err := json.Unmarshal(data, &back); err != nil || back != c {
+		t.Errorf("Unmarshal() = %v, %v; want %v", back, err, c)
+	}
+	if err := json.Unmarshal([]byte(`{"action":"renamed"}`), &back); err == nil {
+		t.Error("Expected an error for an unknown action")
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/genelet/oas/unified"
+)
+
+// JSONOptions configures WriteJSON
+type JSONOptions struct {
+	// Before and After are the documents compared. Given them, each change
+	// carries the values at its pointers.
+	Before, After unified.Document
+
+	Indent string // indents the output when not empty
+}
+
+// JSONReport is the machine-readable form of a report, for tools such as
+// bots commenting on pull requests
+type JSONReport struct {
+	Summary JSONSummary  `json:"summary"`
+	Changes []JSONChange `json:"changes"`
+}
+
+// JSONSummary counts the changes by compatibility
+type JSONSummary struct {
+	Breaking    int `json:"breaking"`
+	NonBreaking int `json:"nonBreaking"`
+	Unknown     int `json:"unknown"`
+}
+
+// JSONChange is a classified change with the value at From in the first
+// document, unless it was added, and at To in the second, unless it was
+// removed
+type JSONChange struct {
+	Classified
+	Before any `json:"before,omitempty"`
+	After  any `json:"after,omitempty"`
+}
+
+// NewJSONReport returns the machine-readable form of a report
+func NewJSONReport(report *Report, opts JSONOptions) (*JSONReport, error) {
+	var before, after *side
+	var err error
+	if opts.Before != nil {
+		if before, err = newSide(opts.Before); err != nil {
+			return nil, err
+		}
+	}
+	if opts.After != nil {
+		if after, err = newSide(opts.After); err != nil {
+			return nil, err
+		}
+	}
+	r := &JSONReport{Changes: []JSONChange{}}
+	if report == nil {
+		return r, nil
+	}
+	for _, c := range report.Changes {
+		switch c.Compatibility {
+		case CompatibilityBreaking:
+			r.Summary.Breaking++
+		case CompatibilityNonBreaking:
+			r.Summary.NonBreaking++
+		default:
+			r.Summary.Unknown++
+		}
+		change := JSONChange{Classified: c}
+		if before != nil && c.Action != ActionAdded {
+			change.Before = before.node(refTokens("#" + c.From)...)
+		}
+		if after != nil && c.Action != ActionRemoved {
+			change.After = after.node(refTokens("#" + c.To)...)
+		}
+		r.Changes = append(r.Changes, change)
+	}
+	return r, nil
+}
+
+// WriteJSON writes the machine-readable form of a report as JSON
+func WriteJSON(w io.Writer, report *Report, opts JSONOptions) error {
+	r, err := NewJSONReport(report, opts)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", opts.Indent)
+	return enc.Encode(r)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWriteJSON(t *testing.T) {
+	a := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}},
+		"components": {"schemas": {"Pet": {"type": "object", "properties": {"age": {"type": "integer"}}}}}
+	}`)
+	b := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "2"},
+		"paths": {
+			"/pets": {"get": {"responses": {"200": {"description": "OK"}}}},
+			"/toys": {"get": {"responses": {"200": {"description": "OK"}}}}
+		},
+		"components": {"schemas": {"Pet": {"type": "object", "properties": {"age": {"type": "string"}}}}}
+	}`)
+	set, err := Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	var out strings.Builder
+	if err := WriteJSON(&out, Classify(set), JSONOptions{Before: a, After: b}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var got JSONReport
+	if err := json.Unmarshal([]byte(out.String()), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v in %s", err, out.String())
+	}
+	if got.Summary != (JSONSummary{Breaking: 1, NonBreaking: 1}) || len(got.Changes) != 2 {
+		t.Fatalf("Unexpected report: %s", out.String())
+	}
+	added := got.Changes[0]
+	if added.Rule != "addition" || added.To != "/paths/~1toys/get" || added.Before != nil || added.After == nil {
+		t.Errorf("Unexpected addition: %+v", added)
+	}
+	typed := got.Changes[1]
+	if typed.Rule != "type-change" || typed.Compatibility != CompatibilityBreaking || typed.From != "/components/schemas/Pet/properties/age" {
+		t.Errorf("Unexpected type change: %+v", typed)
+	}
+	before, _ := typed.Before.(map[string]any)
+	after, _ := typed.After.(map[string]any)
+	if before["type"] != "integer" || after["type"] != "string" {
+		t.Errorf("Expected the values of both schemas, got %v and %v", typed.Before, typed.After)
+	}
+
+	// Without documents, only the changes and their pointers are written
+	out.Reset()
+	if err := WriteJSON(&out, nil, JSONOptions{}); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	if want := `{"summary":{"breaking":0,"nonBreaking":0,"unknown":0},"changes":[]}` + "\n"; out.String() != want {
+		t.Errorf("Expected %s, got %s", want, out.String())
+	}
+}
@@ -0,0 +1,447 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// fixedMethods lists the operations with their own field in a path item
+var fixedMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true,
+	"head": true, "patch": true, "trace": true, "query": true,
+}
+
+// endpoint is an operation of one of the documents
+type endpoint struct {
+	item       unified.PathItem
+	op         unified.Operation
+	itemTokens []string
+	tokens     []string
+}
+
+// union returns the keys of two maps in order
+func union[V any](a, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// operations compares the operations of the paths, or of the webhooks
+func (c *comparer) operations(section string) {
+	items := func(s *side) map[string]unified.PathItem {
+		if section == "webhooks" {
+			return s.doc.GetWebhooks()
+		}
+		return s.doc.GetPaths()
+	}
+	itemsA, itemsB := items(c.a), items(c.b)
+	for _, path := range union(itemsA, itemsB) {
+		a := endpoint{item: itemsA[path], itemTokens: []string{section, path}}
+		b := endpoint{item: itemsB[path], itemTokens: []string{section, path}}
+		var opsA, opsB map[string]unified.Operation
+		if a.item != nil {
+			a.itemTokens = located(a.item, a.itemTokens)
+			opsA = a.item.GetAllOperations()
+		}
+		if b.item != nil {
+			b.itemTokens = located(b.item, b.itemTokens)
+			opsB = b.item.GetAllOperations()
+		}
+		for _, method := range union(opsA, opsB) {
+			c.op = Operation{Path: path, Method: strings.ToUpper(method), Webhook: section == "webhooks"}
+			name := c.op.String()
+			a.op, b.op = opsA[method], opsB[method]
+			a.tokens, b.tokens = operationTokens(a.itemTokens, method), operationTokens(b.itemTokens, method)
+			switch {
+			case b.op == nil:
+				c.add(ActionRemoved, ElementOperation, "", a.tokens, b.tokens, "%s removed", name)
+			case a.op == nil:
+				c.add(ActionAdded, ElementOperation, "", a.tokens, b.tokens, "%s added", name)
+			default:
+				c.operation(name, a, b)
+			}
+		}
+	}
+	c.op = Operation{}
+}
+
+// operationTokens locates an operation returned by GetAllOperations. Other
+// methods are 3.2 additionalOperations, which are keyed in upper case.
+func operationTokens(item []string, method string) []string {
+	if fixedMethods[method] {
+		return at(item, method)
+	}
+	return at(item, "additionalOperations", strings.ToUpper(method))
+}
+
+// operation compares an operation of both documents
+func (c *comparer) operation(name string, a, b endpoint) {
+	if a.op.GetDeprecated() != b.op.GetDeprecated() {
+		if b.op.GetDeprecated() {
+			c.add(ActionModified, ElementOperation, AspectDeprecated, a.tokens, b.tokens, "%s deprecated", name)
+		} else {
+			c.add(ActionModified, ElementOperation, AspectDeprecated, a.tokens, b.tokens, "%s no longer deprecated", name)
+		}
+	}
+	if idA, idB := a.op.GetOperationID(), b.op.GetOperationID(); idA != idB {
+		c.add(ActionModified, ElementOperation, AspectOperationID, at(a.tokens, "operationId"), at(b.tokens, "operationId"),
+			"%s: operationId changed from %q to %q", name, idA, idB)
+	}
+	c.direction = DirectionRequest
+	c.parameters(name, a, b)
+	c.requestBody(name, a, b)
+	c.direction = DirectionResponse
+	c.responses(name, a, b)
+	c.direction = ""
+	c.security(name, a, b)
+}
+
+// parameter is a parameter of an operation or its path item
+type parameter struct {
+	p      unified.Parameter
+	tokens []string
+}
+
+// parameters returns the parameters of an operation, those of its path item
+// it does not override included, by location and name. The 2.0 body and
+// formData parameters are compared as the request body.
+func (s *side) parameters(e endpoint) map[string]parameter {
+	result := make(map[string]parameter)
+	add := func(params []unified.Parameter, list []string) {
+		for _, p := range params {
+			in, name := p.GetIn(), p.GetName()
+			if in == "body" || in == "formData" {
+				continue
+			}
+			if in == "header" {
+				name = strings.ToLower(name)
+			}
+			result[in+" "+name] = parameter{p: p, tokens: located(p, s.entry(list, p))}
+		}
+	}
+	add(e.item.GetParameters(), at(e.itemTokens, "parameters"))
+	add(e.op.GetParameters(), at(e.tokens, "parameters"))
+	return result
+}
+
+// entry returns the tokens of the entry of a parameter list that is, or
+// refers to, a parameter, or of the list if there is none
+func (s *side) entry(list []string, p unified.Parameter) []string {
+	entries, _ := s.node(list...).([]any)
+	ref := unified.RawRef(p)
+	for i, entry := range entries {
+		m, _ := entry.(map[string]any)
+		if ref != "" && m["$ref"] == ref || ref == "" && m["name"] == p.GetName() && m["in"] == p.GetIn() {
+			return at(list, strconv.Itoa(i))
+		}
+	}
+	return list
+}
+
+// parameterSchema returns the place of the schema of a parameter, which 2.0
+// parameters other than body describe inline
+func (s *side) parameterSchema(p parameter) place {
+	if s.v20 {
+		return place{tokens: p.tokens}
+	}
+	return place{tokens: at(p.tokens, "schema")}
+}
+
+// parameters compares the parameters of an operation of both documents
+func (c *comparer) parameters(name string, a, b endpoint) {
+	paramsA, paramsB := c.a.parameters(a), c.b.parameters(b)
+	for _, key := range union(paramsA, paramsB) {
+		x, inA := paramsA[key]
+		y, inB := paramsB[key]
+		switch {
+		case !inB:
+			c.add(ActionRemoved, ElementParameter, "", x.tokens, at(b.tokens, "parameters"),
+				"%s: %s parameter %s removed", name, x.p.GetIn(), x.p.GetName())
+			continue
+		case !inA:
+			aspect := ""
+			if y.p.GetRequired() {
+				aspect = AspectRequired
+			}
+			c.add(ActionAdded, ElementParameter, aspect, at(a.tokens, "parameters"), y.tokens,
+				"%s: %s parameter %s added", name, y.p.GetIn(), y.p.GetName())
+			continue
+		}
+		label := fmt.Sprintf("%s: %s parameter %s", name, x.p.GetIn(), x.p.GetName())
+		if x.p.GetRequired() != y.p.GetRequired() {
+			if y.p.GetRequired() {
+				c.add(ActionModified, ElementParameter, AspectRequired, x.tokens, y.tokens, "%s became required", label)
+			} else {
+				c.add(ActionModified, ElementParameter, AspectOptional, x.tokens, y.tokens, "%s became optional", label)
+			}
+		}
+		if x.p.GetDeprecated() != y.p.GetDeprecated() {
+			if y.p.GetDeprecated() {
+				c.add(ActionModified, ElementParameter, AspectDeprecated, x.tokens, y.tokens, "%s deprecated", label)
+			} else {
+				c.add(ActionModified, ElementParameter, AspectDeprecated, x.tokens, y.tokens, "%s no longer deprecated", label)
+			}
+		}
+		c.schema(label, x.p.GetSchema(), y.p.GetSchema(), c.a.parameterSchema(x), c.b.parameterSchema(y))
+	}
+}
+
+// body locates the media types of a request body or a response
+type body struct {
+	tokens []string
+	v20    bool                // media types share the 2.0 body parameter or response
+	form   map[string][]string // the 2.0 formData parameters, by name
+}
+
+func (b body) media(mediaType string) []string {
+	if b.v20 {
+		return b.tokens
+	}
+	return at(b.tokens, "content", mediaType)
+}
+
+func (b body) schema(mediaType string) place {
+	if b.form != nil {
+		return place{tokens: b.tokens, form: b.form}
+	}
+	return place{tokens: at(b.media(mediaType), "schema")}
+}
+
+// requestBody locates the request body of an operation: the 3.x one, or the
+// 2.0 body parameter, or the 2.0 formData parameters
+func (s *side) requestBody(e endpoint, rb unified.RequestBody) body {
+	if !s.v20 {
+		return body{tokens: located(rb, at(e.tokens, "requestBody"))}
+	}
+	form := make(map[string][]string)
+	for _, list := range [][]string{at(e.itemTokens, "parameters"), at(e.tokens, "parameters")} {
+		entries, _ := s.node(list...).([]any)
+		for i, entry := range entries {
+			tokens := at(list, strconv.Itoa(i))
+			m, _ := entry.(map[string]any)
+			if ref, ok := m["$ref"].(string); ok {
+				if target := refTokens(ref); target != nil {
+					tokens = target
+					m, _ = s.node(tokens...).(map[string]any)
+				}
+			}
+			switch m["in"] {
+			case "body":
+				return body{tokens: tokens, v20: true}
+			case "formData":
+				if name, ok := m["name"].(string); ok {
+					form[name] = tokens
+				}
+			}
+		}
+	}
+	return body{tokens: at(e.tokens, "parameters"), v20: true, form: form}
+}
+
+// requestBody compares the request body of an operation of both documents
+func (c *comparer) requestBody(name string, a, b endpoint) {
+	x, y := a.op.GetRequestBody(), b.op.GetRequestBody()
+	nilA, nilB := x == nil || x.IsNil(), y == nil || y.IsNil()
+	if nilA && nilB {
+		return
+	}
+	var bodyA, bodyB body
+	if !nilA {
+		bodyA = c.a.requestBody(a, x)
+	} else {
+		bodyA = body{tokens: at(a.tokens, "requestBody")}
+	}
+	if !nilB {
+		bodyB = c.b.requestBody(b, y)
+	} else {
+		bodyB = body{tokens: at(b.tokens, "requestBody")}
+	}
+	switch {
+	case nilB:
+		c.add(ActionRemoved, ElementRequestBody, "", bodyA.tokens, bodyB.tokens, "%s: request body removed", name)
+		return
+	case nilA:
+		aspect := ""
+		if y.GetRequired() {
+			aspect = AspectRequired
+		}
+		c.add(ActionAdded, ElementRequestBody, aspect, bodyA.tokens, bodyB.tokens, "%s: request body added", name)
+		return
+	}
+	label := name + ": request body"
+	if x.GetRequired() != y.GetRequired() {
+		if y.GetRequired() {
+			c.add(ActionModified, ElementRequestBody, AspectRequired, bodyA.tokens, bodyB.tokens, "%s became required", label)
+		} else {
+			c.add(ActionModified, ElementRequestBody, AspectOptional, bodyA.tokens, bodyB.tokens, "%s became optional", label)
+		}
+	}
+	c.content(label, x.GetContent(), y.GetContent(), bodyA, bodyB)
+}
+
+// content compares the media types of a request body or a response
+func (c *comparer) content(label string, a, b map[string]unified.MediaType, bodyA, bodyB body) {
+	for _, mediaType := range union(a, b) {
+		x, inA := a[mediaType]
+		y, inB := b[mediaType]
+		switch {
+		case !inB:
+			c.add(ActionRemoved, ElementMediaType, "", bodyA.media(mediaType), bodyB.media(mediaType),
+				"%s: media type %s removed", label, mediaType)
+		case !inA:
+			c.add(ActionAdded, ElementMediaType, "", bodyA.media(mediaType), bodyB.media(mediaType),
+				"%s: media type %s added", label, mediaType)
+		default:
+			c.schema(label+" "+mediaType, x.GetSchema(), y.GetSchema(), bodyA.schema(mediaType), bodyB.schema(mediaType))
+		}
+	}
+}
+
+// statusCodes returns the responses of an operation by status code, the
+// default response under "default"
+func statusCodes(responses unified.Responses) map[string]unified.Response {
+	result := make(map[string]unified.Response)
+	if responses == nil {
+		return result
+	}
+	maps.Copy(result, responses.GetStatusCodes())
+	if def := responses.GetDefault(); def != nil && !def.IsNil() {
+		result["default"] = def
+	}
+	return result
+}
+
+// responses compares the responses of an operation of both documents
+func (c *comparer) responses(name string, a, b endpoint) {
+	codesA, codesB := statusCodes(a.op.GetResponses()), statusCodes(b.op.GetResponses())
+	for _, code := range union(codesA, codesB) {
+		x, inA := codesA[code]
+		y, inB := codesB[code]
+		tokensA, tokensB := at(a.tokens, "responses", code), at(b.tokens, "responses", code)
+		switch {
+		case !inB:
+			c.add(ActionRemoved, ElementResponse, "", located(x, tokensA), tokensB, "%s: response %s removed", name, code)
+		case !inA:
+			c.add(ActionAdded, ElementResponse, "", tokensA, located(y, tokensB), "%s: response %s added", name, code)
+		default:
+			bodyA := body{tokens: located(x, tokensA), v20: c.a.v20}
+			bodyB := body{tokens: located(y, tokensB), v20: c.b.v20}
+			c.content(name+": response "+code, x.GetContent(), y.GetContent(), bodyA, bodyB)
+		}
+	}
+}
+
+// requirements returns the security requirements that apply to an operation,
+// with the tokens of each, and the tokens of their list
+func (s *side) requirements(e endpoint) (map[string][]string, []string) {
+	list := []string{"security"}
+	if e.op.GetSecurity() != nil {
+		list = at(e.tokens, "security")
+	}
+	result := make(map[string][]string)
+	for i, req := range e.op.EffectiveSecurity(s.doc) {
+		result[requirementName(req)] = at(list, strconv.Itoa(i))
+	}
+	return result, list
+}
+
+// requirementName describes a security requirement, such as "oauth[read]
+// and apiKey"
+func requirementName(req unified.EffectiveRequirement) string {
+	if len(req) == 0 {
+		return "anonymous access"
+	}
+	names := make([]string, 0, len(req))
+	for _, scheme := range req {
+		name := scheme.Name
+		if len(scheme.Scopes) > 0 {
+			name += "[" + strings.Join(slices.Sorted(slices.Values(scheme.Scopes)), ", ") + "]"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, " and ")
+}
+
+// security compares the security that applies to an operation of both
+// documents
+func (c *comparer) security(name string, a, b endpoint) {
+	reqsA, listA := c.a.requirements(a)
+	reqsB, listB := c.b.requirements(b)
+	for _, req := range union(reqsA, reqsB) {
+		x, inA := reqsA[req]
+		y, inB := reqsB[req]
+		switch {
+		case !inB:
+			c.add(ActionRemoved, ElementSecurity, "", x, listB, "%s: security requirement %s removed", name, req)
+		case !inA:
+			c.add(ActionAdded, ElementSecurity, "", listA, y, "%s: security requirement %s added", name, req)
+		}
+	}
+}
+
+// securitySchemes compares the security scheme definitions of both documents
+func (c *comparer) securitySchemes() {
+	schemesA, schemesB := c.a.doc.GetSecuritySchemes(), c.b.doc.GetSecuritySchemes()
+	for _, name := range union(schemesA, schemesB) {
+		x, inA := schemesA[name]
+		y, inB := schemesB[name]
+		tokensA, tokensB := c.a.component("securitySchemes", name), c.b.component("securitySchemes", name)
+		switch {
+		case !inB:
+			c.add(ActionRemoved, ElementSecurityScheme, "", tokensA, tokensB, "security scheme %s removed", name)
+			continue
+		case !inA:
+			c.add(ActionAdded, ElementSecurityScheme, "", tokensA, tokensB, "security scheme %s added", name)
+			continue
+		}
+		if kindA, kindB := schemeKind(x), schemeKind(y); kindA != kindB {
+			c.add(ActionModified, ElementSecurityScheme, AspectType, tokensA, tokensB,
+				"security scheme %s changed from %s to %s", name, kindA, kindB)
+		}
+		scopesA, scopesB := x.GetScopes(), y.GetScopes()
+		for _, scope := range union(scopesA, scopesB) {
+			if _, ok := scopesB[scope]; !ok {
+				c.add(ActionRemoved, ElementSecurityScheme, AspectScope, tokensA, tokensB, "security scheme %s: scope %s removed", name, scope)
+			} else if _, ok := scopesA[scope]; !ok {
+				c.add(ActionAdded, ElementSecurityScheme, AspectScope, tokensA, tokensB, "security scheme %s: scope %s added", name, scope)
+			}
+		}
+	}
+}
+
+// schemeKind describes what a security scheme is, in the same terms for
+// every version
+func schemeKind(s unified.SecurityScheme) string {
+	switch s.GetType() {
+	case "basic":
+		return "http basic"
+	case "http":
+		return "http " + strings.ToLower(s.GetScheme())
+	case "apiKey":
+		return fmt.Sprintf("apiKey %s in %s", s.GetName(), s.GetIn())
+	case "oauth2":
+		flow := s.GetFlow()
+		switch flow {
+		case "application":
+			flow = "clientCredentials"
+		case "accessCode":
+			flow = "authorizationCode"
+		}
+		return "oauth2 " + flow
+	}
+	return s.GetType()
+}
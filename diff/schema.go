@@ -0,0 +1,209 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// place locates a schema: by its tokens, or, for the schema standing for the
+// formData parameters of a 2.0 operation, by the tokens of each parameter
+type place struct {
+	tokens []string
+	form   map[string][]string
+}
+
+// child returns the place of a value in the schema at p. The formData
+// parameters describe their value inline, so their nested values stay at the
+// parameter.
+func (p place) child(tokens ...string) place {
+	if p.form == nil {
+		return place{tokens: at(p.tokens, tokens...)}
+	}
+	if len(tokens) == 2 && tokens[0] == "properties" {
+		if param, ok := p.form[tokens[1]]; ok {
+			return place{tokens: param}
+		}
+	}
+	return place{tokens: p.tokens}
+}
+
+// schemas compares the component schemas of both documents
+func (c *comparer) schemas() {
+	schemasA, schemasB := c.a.doc.GetComponents().GetSchemas(), c.b.doc.GetComponents().GetSchemas()
+	for _, name := range union(schemasA, schemasB) {
+		x, inA := schemasA[name]
+		y, inB := schemasB[name]
+		tokensA, tokensB := c.a.component("schemas", name), c.b.component("schemas", name)
+		switch {
+		case !inB:
+			c.add(ActionRemoved, ElementSchema, "", tokensA, tokensB, "schema %s removed", name)
+		case !inA:
+			c.add(ActionAdded, ElementSchema, "", tokensA, tokensB, "schema %s added", name)
+		default:
+			c.schema(name, x, y, place{tokens: tokensA}, place{tokens: tokensB})
+		}
+	}
+}
+
+// componentName returns the name of the component a reference points at
+func componentName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// schema compares two schemas, which label names in messages: their type,
+// enum values and properties, and the schemas nested in them
+func (c *comparer) schema(label string, a, b unified.Schema, pa, pb place) {
+	nilA, nilB := a == nil || a.IsNil(), b == nil || b.IsNil()
+	switch {
+	case nilA && nilB:
+		return
+	case nilB:
+		c.add(ActionRemoved, ElementSchema, "", pa.tokens, pb.tokens, "%s: schema removed", label)
+		return
+	case nilA:
+		c.add(ActionAdded, ElementSchema, "", pa.tokens, pb.tokens, "%s: schema added", label)
+		return
+	}
+	refA, refB := unified.RawRef(a), unified.RawRef(b)
+	if refA != "" && refB != "" && componentName(refA) == componentName(refB) {
+		return
+	}
+	if refA != "" {
+		pa = place{tokens: located(a, pa.tokens)}
+	}
+	if refB != "" {
+		pb = place{tokens: located(b, pb.tokens)}
+	}
+	// References into other documents or in a cycle are not followed
+	if a.GetRef() != "" || b.GetRef() != "" {
+		if componentName(a.GetRef()) != componentName(b.GetRef()) {
+			c.add(ActionModified, ElementSchema, AspectReference, pa.tokens, pb.tokens,
+				"%s: reference changed from %q to %q", label, a.GetRef(), b.GetRef())
+		}
+		return
+	}
+	key := c.direction + " " + pointer(pa.tokens) + " " + pointer(pb.tokens)
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+
+	if typeA, typeB := typeName(a), typeName(b); typeA != typeB {
+		c.add(ActionModified, ElementSchema, AspectType, pa.tokens, pb.tokens, "%s: type changed from %s to %s", label, typeA, typeB)
+	}
+	if a.IsNullable() != b.IsNullable() {
+		if b.IsNullable() {
+			c.add(ActionModified, ElementSchema, AspectNullable, pa.tokens, pb.tokens, "%s became nullable", label)
+		} else {
+			c.add(ActionModified, ElementSchema, AspectNullable, pa.tokens, pb.tokens, "%s is no longer nullable", label)
+		}
+	}
+	c.enum(label, a, b, pa, pb)
+	c.properties(label, a, b, pa, pb)
+	c.schema(label+"[]", a.GetItems(), b.GetItems(), pa.child("items"), pb.child("items"))
+	for _, keyword := range []struct {
+		name    string
+		schemas func(unified.Schema) []unified.Schema
+	}{{"allOf", unified.Schema.GetAllOf}, {"anyOf", unified.Schema.GetAnyOf}, {"oneOf", unified.Schema.GetOneOf}} {
+		listA, listB := keyword.schemas(a), keyword.schemas(b)
+		for i := 0; i < len(listA) || i < len(listB); i++ {
+			childA, childB := pa.child(keyword.name, strconv.Itoa(i)), pb.child(keyword.name, strconv.Itoa(i))
+			switch {
+			case i >= len(listB):
+				c.add(ActionRemoved, ElementSchema, AspectComposition, childA.tokens, pb.child(keyword.name).tokens,
+					"%s: %s schema %d removed", label, keyword.name, i+1)
+			case i >= len(listA):
+				c.add(ActionAdded, ElementSchema, AspectComposition, pa.child(keyword.name).tokens, childB.tokens,
+					"%s: %s schema %d added", label, keyword.name, i+1)
+			default:
+				c.schema(fmt.Sprintf("%s.%s[%d]", label, keyword.name, i), listA[i], listB[i], childA, childB)
+			}
+		}
+	}
+}
+
+// typeName describes the type of a schema, such as "integer (int64)" or
+// "string or integer", leaving nullability out
+func typeName(s unified.Schema) string {
+	types := slices.DeleteFunc(slices.Clone(s.GetTypes()), func(t string) bool { return t == "null" })
+	slices.Sort(types)
+	name := strings.Join(types, " or ")
+	if name == "" {
+		name = "any"
+	}
+	if format := s.GetFormat(); format != "" {
+		name += " (" + format + ")"
+	}
+	return name
+}
+
+// enum compares the enum values of two schemas
+func (c *comparer) enum(label string, a, b unified.Schema, pa, pb place) {
+	valuesA, valuesB := enumValues(a), enumValues(b)
+	for i, value := range valuesA {
+		if !slices.Contains(valuesB, value) {
+			c.add(ActionRemoved, ElementEnum, "", pa.child("enum", strconv.Itoa(i)).tokens, pb.child("enum").tokens,
+				"%s: enum value %s removed", label, value)
+		}
+	}
+	for i, value := range valuesB {
+		if !slices.Contains(valuesA, value) {
+			c.add(ActionAdded, ElementEnum, "", pa.child("enum").tokens, pb.child("enum", strconv.Itoa(i)).tokens,
+				"%s: enum value %s added", label, value)
+		}
+	}
+}
+
+// enumValues returns the JSON text of the enum values of a schema
+func enumValues(s unified.Schema) []string {
+	var values []string
+	for _, value := range s.GetEnum() {
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(fmt.Sprint(value))
+		}
+		values = append(values, string(data))
+	}
+	return values
+}
+
+// properties compares the properties of two schemas, and whether they are
+// required
+func (c *comparer) properties(label string, a, b unified.Schema, pa, pb place) {
+	propsA, propsB := a.GetProperties(), b.GetProperties()
+	requiredA, requiredB := a.GetRequired(), b.GetRequired()
+	for _, name := range union(propsA, propsB) {
+		childA, childB := pa.child("properties", name), pb.child("properties", name)
+		x, inA := propsA[name]
+		y, inB := propsB[name]
+		switch {
+		case !inB:
+			c.add(ActionRemoved, ElementProperty, "", childA.tokens, childB.tokens, "%s: property %s removed", label, name)
+			continue
+		case !inA:
+			if slices.Contains(requiredB, name) {
+				c.add(ActionAdded, ElementProperty, AspectRequired, childA.tokens, childB.tokens, "%s: required property %s added", label, name)
+			} else {
+				c.add(ActionAdded, ElementProperty, "", childA.tokens, childB.tokens, "%s: property %s added", label, name)
+			}
+			continue
+		}
+		if isA, isB := slices.Contains(requiredA, name), slices.Contains(requiredB, name); isA != isB {
+			if isB {
+				c.add(ActionModified, ElementProperty, AspectRequired, childA.tokens, childB.tokens, "%s: property %s became required", label, name)
+			} else {
+				c.add(ActionModified, ElementProperty, AspectOptional, childA.tokens, childB.tokens, "%s: property %s became optional", label, name)
+			}
+		}
+		c.schema(label+"."+name, x, y, childA, childB)
+	}
+}
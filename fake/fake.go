@@ -0,0 +1,395 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package fake generates instance data matching the schemas of an OpenAPI
+// document of any version, for mock servers, example backfilling and fuzz
+// tests.
+package fake
+
+import (
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/genelet/oas/unified"
+	"github.com/genelet/oas/validate"
+)
+
+// Options configures a Generator
+type Options struct {
+	// Seed seeds the randomness: generators with the same seed generate the
+	// same values for the same schemas
+	Seed uint64
+
+	// Examples takes the const, default, example or first enum value of a
+	// schema where it has one, instead of random values
+	Examples bool
+
+	// Direction leaves out the readOnly properties of requests and the
+	// writeOnly properties of responses
+	Direction validate.Direction
+
+	// MaxDepth bounds the nesting of values, against recursive schemas; 8
+	// when zero. Beyond it only required properties are generated.
+	MaxDepth int
+
+	// MaxItems bounds the items of arrays without maxItems, and the extra
+	// items beyond minItems; 3 when zero
+	MaxItems int
+}
+
+// Generator generates values matching schemas. It is safe for concurrent
+// use.
+type Generator struct {
+	opts Options
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// New returns a generator
+func New(opts Options) *Generator {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 8
+	}
+	if opts.MaxItems <= 0 {
+		opts.MaxItems = 3
+	}
+	return &Generator{opts: opts, rand: rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))}
+}
+
+// maxAttempts bounds the values generated for a schema until one matches
+const maxAttempts = 20
+
+// Generate returns a value matching a schema, as generic JSON. Values are
+// checked with validate.Value, and generated again when they do not match,
+// as may happen with oneOf, not or conflicting keywords; an error is
+// returned when no attempt matches. Pass schemas of a document returned by
+// unified.Resolve for references to be followed.
+func (g *Generator) Generate(schema unified.Schema) (any, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var err error
+	for attempt := range maxAttempts {
+		// Examples that do not match are given up after the first attempt
+		examples := g.opts.Examples && attempt == 0
+		value := g.value(schema, 0, examples)
+		if err = validate.Value(schema, value, g.opts.Direction); err == nil {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("no value generated matches the schema: %w", err)
+}
+
+// value generates a value for a schema
+func (g *Generator) value(s unified.Schema, depth int, examples bool) any {
+	if s == nil || s.IsNil() || s.GetRef() != "" {
+		return g.word(1, 8)
+	}
+	if s.IsBooleanSchema() {
+		return nil
+	}
+	if c := s.GetConst(); c != nil {
+		return c
+	}
+	if examples {
+		if d := s.GetDefault(); d != nil {
+			return d
+		}
+		if e := s.GetExample(); e != nil {
+			return e
+		}
+	}
+	if enum := s.GetEnum(); len(enum) > 0 {
+		if examples {
+			return enum[0]
+		}
+		return enum[g.rand.IntN(len(enum))]
+	}
+	if allOf := s.GetAllOf(); len(allOf) > 0 {
+		return g.allOf(s, allOf, depth, examples)
+	}
+	for _, choices := range [][]unified.Schema{s.GetOneOf(), s.GetAnyOf()} {
+		if len(choices) > 0 {
+			return g.value(choices[g.rand.IntN(len(choices))], depth+1, examples)
+		}
+	}
+
+	switch typeOf(s, g.rand) {
+	case "object":
+		return g.object(s, depth, examples)
+	case "array":
+		return g.array(s, depth, examples)
+	case "integer":
+		return g.number(s, true)
+	case "number":
+		return g.number(s, false)
+	case "boolean":
+		return g.rand.IntN(2) == 0
+	case "null":
+		return nil
+	}
+	return g.string(s)
+}
+
+// typeOf picks a type of a schema, other than null when there is one, or
+// infers it from the keywords of a schema without type
+func typeOf(s unified.Schema, r *rand.Rand) string {
+	var types []string
+	for _, t := range s.GetTypes() {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	switch {
+	case len(types) > 0:
+		return types[r.IntN(len(types))]
+	case slices.Contains(s.GetTypes(), "null"):
+		return "null"
+	case len(s.GetProperties()) > 0 || len(s.GetRequired()) > 0:
+		return "object"
+	case s.GetItems() != nil && !s.GetItems().IsNil() || len(s.GetPrefixItems()) > 0:
+		return "array"
+	case s.GetMinimum() != nil || s.GetMaximum() != nil || s.GetMultipleOf() != nil:
+		return "number"
+	}
+	return "string"
+}
+
+// allOf merges the objects generated for the schemas of allOf and for the
+// schema itself; a schema that is not an object takes the value of its own
+// keywords
+func (g *Generator) allOf(s unified.Schema, allOf []unified.Schema, depth int, examples bool) any {
+	own := g.value(withoutAllOf{s}, depth+1, examples)
+	object, ok := own.(map[string]any)
+	if !ok {
+		return own
+	}
+	merged := make(map[string]any)
+	for _, sub := range allOf {
+		if o, ok := g.value(sub, depth+1, examples).(map[string]any); ok {
+			maps.Copy(merged, o)
+		}
+	}
+	maps.Copy(merged, object)
+	return merged
+}
+
+// withoutAllOf is a schema without its allOf, to generate its own keywords
+type withoutAllOf struct {
+	unified.Schema
+}
+
+func (s withoutAllOf) GetAllOf() []unified.Schema { return nil }
+
+// object generates the required properties of an object schema and, with
+// examples or by chance, the others, leaving out those of the other direction
+func (g *Generator) object(s unified.Schema, depth int, examples bool) map[string]any {
+	object := make(map[string]any)
+	properties := s.GetProperties()
+	required := s.GetRequired()
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		prop := properties[name]
+		if prop == nil || g.opts.Direction == validate.DirectionRequest && prop.GetReadOnly() ||
+			g.opts.Direction == validate.DirectionResponse && prop.GetWriteOnly() {
+			continue
+		}
+		if !slices.Contains(required, name) && (depth >= g.opts.MaxDepth || !examples && g.rand.IntN(2) == 0) {
+			continue
+		}
+		object[name] = g.value(prop, depth+1, examples)
+	}
+	for _, name := range required {
+		if _, ok := object[name]; !ok && properties[name] == nil {
+			object[name] = g.value(s.GetAdditionalProperties(), depth+1, examples)
+		}
+	}
+	return object
+}
+
+// array generates the items of an array schema
+func (g *Generator) array(s unified.Schema, depth int, examples bool) []any {
+	low, high := 0, g.opts.MaxItems
+	if m := s.GetMinItems(); m != nil {
+		low = *m
+		high = low + g.opts.MaxItems
+	}
+	if m := s.GetMaxItems(); m != nil {
+		high = min(high, *m)
+	}
+	prefix := s.GetPrefixItems()
+	low = max(low, len(prefix))
+	if depth >= g.opts.MaxDepth {
+		high = low
+	}
+	n := low
+	if high > low {
+		n += g.rand.IntN(high - low + 1)
+	}
+	array := make([]any, 0, n)
+	for i := 0; i < n; i++ {
+		item := s.GetItems()
+		if i < len(prefix) {
+			item = prefix[i]
+		} else if contains := s.GetContains(); i == len(prefix) && contains != nil && !contains.IsNil() {
+			item = contains
+		}
+		// Unique items are generated again a few times on collisions
+		var value any
+		for range maxAttempts {
+			value = g.value(item, depth+1, examples && i == 0)
+			if !s.GetUniqueItems() || !slices.ContainsFunc(array, func(v any) bool { return fmt.Sprint(v) == fmt.Sprint(value) }) {
+				break
+			}
+		}
+		array = append(array, value)
+	}
+	return array
+}
+
+// number generates a number within the bounds of a schema, a multiple of
+// its multipleOf
+func (g *Generator) number(s unified.Schema, integer bool) float64 {
+	low, high := math.Inf(-1), math.Inf(1)
+	if m := s.GetMinimum(); m != nil {
+		low = *m
+	}
+	if m := s.GetMaximum(); m != nil {
+		high = *m
+	}
+	switch {
+	case math.IsInf(low, -1) && math.IsInf(high, 1):
+		low, high = 0, 1000
+	case math.IsInf(low, -1):
+		low = high - 1000
+	case math.IsInf(high, 1):
+		high = low + 1000
+	}
+	step := 0.0
+	if integer {
+		step = 1
+	}
+	if m := s.GetMultipleOf(); m != nil && *m > 0 {
+		step = *m
+		if integer && step != math.Trunc(step) {
+			step = math.Ceil(step)
+		}
+	}
+	if s.GetFormat() == "int32" {
+		low, high = max(low, math.MinInt32), min(high, math.MaxInt32)
+	}
+	if step == 0 {
+		// Exclusive bounds are kept clear of by a fraction of the range
+		margin := (high - low) / 100
+		if s.GetExclusiveMinimum() {
+			low += margin
+		}
+		if s.GetExclusiveMaximum() {
+			high -= margin
+		}
+		return math.Round((low+g.rand.Float64()*(high-low))*100) / 100
+	}
+	first, last := math.Ceil(low/step), math.Floor(high/step)
+	if s.GetExclusiveMinimum() && first*step <= low {
+		first++
+	}
+	if s.GetExclusiveMaximum() && last*step >= high {
+		last--
+	}
+	if last < first {
+		return first * step
+	}
+	return (first + float64(g.rand.Int64N(int64(last-first)+1))) * step
+}
+
+// letters are the characters of random words
+const letters = "abcdefghijklmnopqrstuvwxyz"
+
+// word returns random letters, between low and high of them
+func (g *Generator) word(low, high int) string {
+	n := low
+	if high > low {
+		n += g.rand.IntN(high - low + 1)
+	}
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = letters[g.rand.IntN(len(letters))]
+	}
+	return string(b)
+}
+
+// string generates a string of the format or pattern of a schema, or random
+// letters within its length bounds
+func (g *Generator) string(s unified.Schema) string {
+	if value, ok := g.format(s.GetFormat()); ok {
+		return value
+	}
+	if p := s.GetPattern(); p != "" {
+		if value, ok := g.pattern(p); ok {
+			return value
+		}
+	}
+	low, high := 1, 12
+	if m := s.GetMinLength(); m != nil {
+		low = *m
+		high = max(high, low+8)
+	}
+	if m := s.GetMaxLength(); m != nil {
+		high = min(high, *m)
+		low = min(low, high)
+	}
+	return g.word(low, high)
+}
+
+// format generates a string of a well-known format
+func (g *Generator) format(format string) (string, bool) {
+	r := g.rand
+	switch format {
+	case "date-time":
+		return g.time().Format(time.RFC3339), true
+	case "date":
+		return g.time().Format(time.DateOnly), true
+	case "time":
+		return g.time().Format("15:04:05Z07:00"), true
+	case "email":
+		return g.word(3, 8) + "@example.com", true
+	case "hostname":
+		return g.word(3, 8) + ".example.com", true
+	case "uri", "url":
+		return "https://example.com/" + g.word(3, 8), true
+	case "uuid":
+		b := make([]byte, 16)
+		for i := range b {
+			b[i] = byte(r.IntN(256))
+		}
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), true
+	case "ipv4":
+		return fmt.Sprintf("%d.%d.%d.%d", r.IntN(223)+1, r.IntN(256), r.IntN(256), r.IntN(254)+1), true
+	case "ipv6":
+		return fmt.Sprintf("2001:db8::%x:%x", r.IntN(0x10000), r.IntN(0x10000)), true
+	case "byte":
+		return base64.StdEncoding.EncodeToString([]byte(g.word(4, 12))), true
+	case "password":
+		return g.word(12, 16), true
+	}
+	return "", false
+}
+
+// time returns a random time, to the second, between 2000 and 2030
+func (g *Generator) time() time.Time {
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	return start.Add(time.Duration(g.rand.Int64N(30*365*24*3600)) * time.Second)
+}
+
+// Value is a convenience for a single value of a schema from a generator
+// with the options
+func Value(schema unified.Schema, opts Options) (any, error) {
+	return New(opts).Generate(schema)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package fake
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+	"github.com/genelet/oas/validate"
+)
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	resolved, err := unified.Resolve(doc)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	return resolved
+}
+
+const schemas = `{
+	"openapi": "3.1.0",
+	"info": {"title": "Pets", "version": "1"},
+	"paths": {},
+	"components": {"schemas": {
+		"Pet": {
+			"type": "object",
+			"required": ["id", "name", "kind", "code"],
+			"properties": {
+				"id": {"type": "integer", "minimum": 10, "exclusiveMaximum": 20, "readOnly": true},
+				"name": {"type": "string", "minLength": 2, "maxLength": 5, "example": "Rex"},
+				"kind": {"type": "string", "enum": ["cat", "dog"]},
+				"code": {"type": "string", "pattern": "^[A-Z]{3}-\\d{2,4}$"},
+				"weight": {"type": "number", "minimum": 0.5, "maximum": 1, "multipleOf": 0.25},
+				"email": {"type": "string", "format": "email"},
+				"id2": {"type": "string", "format": "uuid"},
+				"born": {"type": "string", "format": "date-time"},
+				"tags": {"type": "array", "items": {"type": "string", "maxLength": 3}, "minItems": 2, "maxItems": 3, "uniqueItems": true},
+				"owner": {"$ref": "#/components/schemas/Owner"},
+				"parent": {"$ref": "#/components/schemas/Pet"},
+				"password": {"type": "string", "writeOnly": true}
+			},
+			"additionalProperties": false
+		},
+		"Owner": {"oneOf": [
+			{"type": "object", "required": ["email"], "properties": {"email": {"type": "string", "format": "email"}}, "additionalProperties": false},
+			{"type": "object", "required": ["phone"], "properties": {"phone": {"type": "string", "pattern": "^\\+[0-9]{10}$"}}, "additionalProperties": false}
+		]},
+		"Never": {"type": "integer", "minimum": 5, "maximum": 1}
+	}}
+}`
+
+func TestGenerate(t *testing.T) {
+	doc := parse(t, schemas)
+	pet := doc.GetComponents().GetSchemas()["Pet"]
+
+	for seed := range uint64(50) {
+		g := New(Options{Seed: seed})
+		value, err := g.Generate(pet)
+		if err != nil {
+			t.Fatalf("seed %d: Generate() error = %v", seed, err)
+		}
+		if err := validate.Value(pet, value, validate.DirectionNone); err != nil {
+			t.Errorf("seed %d: generated an invalid pet %v: %v", seed, value, err)
+		}
+		object := value.(map[string]any)
+		if !regexp.MustCompile(`^[A-Z]{3}-\d{2,4}$`).MatchString(object["code"].(string)) {
+			t.Errorf("seed %d: code %q does not match the pattern", seed, object["code"])
+		}
+	}
+
+	a, _ := New(Options{Seed: 1}).Generate(pet)
+	b, _ := New(Options{Seed: 1}).Generate(pet)
+	c, _ := New(Options{Seed: 2}).Generate(pet)
+	if fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Errorf("Expected the same value for the same seed, got %v and %v", a, b)
+	}
+	if fmt.Sprint(a) == fmt.Sprint(c) {
+		t.Errorf("Expected other values for another seed, got %v", c)
+	}
+}
+
+func TestGenerateOptions(t *testing.T) {
+	doc := parse(t, schemas)
+	pet := doc.GetComponents().GetSchemas()["Pet"]
+
+	value, err := New(Options{Examples: true, Direction: validate.DirectionResponse}).Generate(pet)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	object := value.(map[string]any)
+	if object["name"] != "Rex" || object["kind"] != "cat" {
+		t.Errorf("Expected the example and the first enum value, got %v", object)
+	}
+	if _, ok := object["password"]; ok {
+		t.Errorf("Expected no writeOnly property in a response, got %v", object)
+	}
+	if _, ok := object["email"]; !ok {
+		t.Errorf("Expected all properties with examples, got %v", object)
+	}
+
+	for seed := range uint64(20) {
+		value, err := New(Options{Seed: seed, Direction: validate.DirectionRequest}).Generate(pet)
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if _, ok := value.(map[string]any)["id"]; ok {
+			t.Errorf("Expected no readOnly property in a request, got %v", value)
+		}
+	}
+
+	if _, err := New(Options{}).Generate(doc.GetComponents().GetSchemas()["Never"]); err == nil {
+		t.Errorf("Expected an error for a schema no value matches")
+	}
+}
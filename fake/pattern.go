@@ -0,0 +1,82 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package fake
+
+import (
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// maxRepeat bounds the repetitions of unbounded quantifiers such as * and +
+const maxRepeat = 3
+
+// pattern generates a string matching a regular expression, or false when
+// the expression does not parse
+func (g *Generator) pattern(p string) (string, bool) {
+	re, err := syntax.Parse(p, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	var b strings.Builder
+	g.regex(&b, re.Simplify())
+	return b.String(), true
+}
+
+// regex writes a string matching a parsed expression. Anchors and word
+// boundaries write nothing, which is enough for most patterns of schemas.
+func (g *Generator) regex(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(g.class(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteByte(letters[g.rand.IntN(len(letters))])
+	case syntax.OpCapture, syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.regex(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.regex(b, re.Sub[g.rand.IntN(len(re.Sub))])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		low, high := re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			low, high = 0, maxRepeat
+		case syntax.OpPlus:
+			low, high = 1, maxRepeat
+		case syntax.OpQuest:
+			low, high = 0, 1
+		}
+		if high < 0 {
+			high = low + maxRepeat
+		}
+		n := low + g.rand.IntN(high-low+1)
+		for range n {
+			g.regex(b, re.Sub[0])
+		}
+	}
+}
+
+// class picks a character of a class given as ranges, a printable ASCII one
+// when the class has any
+func (g *Generator) class(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		for r := max(ranges[i], ' '); r <= min(ranges[i+1], '~'); r++ {
+			printable = append(printable, r)
+		}
+	}
+	if len(printable) > 0 {
+		return printable[g.rand.IntN(len(printable))]
+	}
+	if len(ranges) > 0 && unicode.IsPrint(ranges[0]) {
+		return ranges[0]
+	}
+	return 'x'
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Decode decodes a body of a form media type into an object of fields,
+// following the encoding of mt, which may be nil, and converting the fields
+// to the types of the properties of its schema. Fields without a property
+// are kept as strings, or arrays of strings when repeated. Files are
+// decoded as strings.
+func Decode(contentType string, mt unified.MediaType, body []byte) (map[string]any, error) {
+	var schema unified.Schema = unified.NilSchema{}
+	var encoding map[string]unified.Encoding
+	if mt != nil {
+		if s := mt.GetSchema(); s != nil {
+			schema = s
+		}
+		encoding = mt.GetEncoding()
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q", ErrUnsupported, contentType)
+	}
+	switch mediaType {
+	case URLEncoded:
+		fields, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		return decodeURL(fields, schema, encoding)
+	case Multipart:
+		return decodeMultipart(body, params["boundary"], schema, encoding)
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnsupported, contentType)
+}
+
+// decodeURL converts the fields of a URL-encoded form. A property of an
+// object type is gathered from the fields its style makes of it.
+func decodeURL(fields url.Values, schema unified.Schema, encoding map[string]unified.Encoding) (map[string]any, error) {
+	object := make(map[string]any)
+	used := make(map[string]bool)
+	properties := schema.GetProperties()
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		prop := properties[name]
+		enc := encoding[name]
+		if contentType := partType(enc); contentType != "" && isJSON(contentType) {
+			if values, ok := fields[name]; ok {
+				var value any
+				if err := json.Unmarshal([]byte(values[0]), &value); err != nil {
+					return nil, fmt.Errorf("field %s: %w", name, err)
+				}
+				object[name], used[name] = value, true
+			}
+			continue
+		}
+		style, explode := styleOf(enc)
+		if has(prop, "object") && (style == "deepObject" || explode) {
+			// The properties of the field are fields of their own
+			sub := make(map[string]any)
+			for key, values := range fields {
+				field := key
+				if style == "deepObject" {
+					inner, ok := strings.CutPrefix(key, name+"[")
+					if !ok || !strings.HasSuffix(inner, "]") {
+						continue
+					}
+					field = strings.TrimSuffix(inner, "]")
+				} else if _, ok := prop.GetProperties()[key]; !ok {
+					continue
+				}
+				sub[field], used[key] = coerce(propertyOf(prop, field), values[0]), true
+			}
+			if len(sub) > 0 {
+				object[name] = sub
+			}
+			continue
+		}
+		values, ok := fields[name]
+		if !ok {
+			continue
+		}
+		used[name] = true
+		object[name] = decodeField(prop, style, explode, values)
+	}
+	for name, values := range fields {
+		if used[name] {
+			continue
+		}
+		prop := schema.GetAdditionalProperties()
+		if prop == nil || prop.IsNil() {
+			object[name] = uncoerced(values)
+			continue
+		}
+		object[name] = decodeField(prop, "form", true, values)
+	}
+	return object, nil
+}
+
+// decodeField converts the values of a field by its style
+func decodeField(prop unified.Schema, style string, explode bool, values []string) any {
+	switch {
+	case has(prop, "array"):
+		parts := values
+		if len(values) == 1 {
+			switch {
+			case style == "spaceDelimited":
+				parts = strings.Split(values[0], " ")
+			case style == "pipeDelimited":
+				parts = strings.Split(values[0], "|")
+			case !explode:
+				parts = strings.Split(values[0], ",")
+			}
+		}
+		array := make([]any, len(parts))
+		for i, part := range parts {
+			array[i] = coerce(prop.GetItems(), part)
+		}
+		return array
+	case has(prop, "object"):
+		object := make(map[string]any)
+		parts := strings.Split(values[0], ",")
+		for i := 0; i+1 < len(parts); i += 2 {
+			object[parts[i]] = coerce(propertyOf(prop, parts[i]), parts[i+1])
+		}
+		return object
+	}
+	return coerce(prop, values[0])
+}
+
+// decodeMultipart converts the parts of a multipart form: JSON parts are
+// decoded, others converted to the type of their property, and the parts of
+// an array property gathered
+func decodeMultipart(body []byte, boundary string, schema unified.Schema, encoding map[string]unified.Encoding) (map[string]any, error) {
+	if boundary == "" {
+		return nil, fmt.Errorf("multipart body without boundary")
+	}
+	object := make(map[string]any)
+	seen := make(map[string]bool)
+	r := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := part.FormName()
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		if enc := encoding[name]; enc != nil && !seen[name] {
+			for key, header := range enc.GetHeaders() {
+				if header != nil && header.GetRequired() && part.Header.Get(key) == "" {
+					return nil, fmt.Errorf("part %s: missing required header %s", name, key)
+				}
+			}
+		}
+		seen[name] = true
+
+		prop := propertyOf(schema, name)
+		item := prop
+		if has(prop, "array") {
+			item = prop.GetItems()
+		}
+		var value any
+		if isJSON(part.Header.Get("Content-Type")) {
+			if err := json.Unmarshal(data, &value); err != nil {
+				return nil, fmt.Errorf("part %s: %w", name, err)
+			}
+		} else {
+			value = coerce(item, string(data))
+		}
+
+		if has(prop, "array") {
+			if array, ok := value.([]any); ok && object[name] == nil {
+				object[name] = array
+				continue
+			}
+			array, _ := object[name].([]any)
+			object[name] = append(array, value)
+			continue
+		}
+		if previous, ok := object[name]; ok && (prop == nil || prop.IsNil()) {
+			// Repeated fields without a property make an array
+			array, ok := previous.([]any)
+			if !ok {
+				array = []any{previous}
+			}
+			object[name] = append(array, value)
+			continue
+		}
+		object[name] = value
+	}
+	return object, nil
+}
+
+// propertyOf returns the schema of a property, or of additional properties
+func propertyOf(schema unified.Schema, name string) unified.Schema {
+	if schema == nil || schema.IsNil() {
+		return nil
+	}
+	if prop := schema.GetProperties()[name]; prop != nil {
+		return prop
+	}
+	return schema.GetAdditionalProperties()
+}
+
+// has reports whether a schema has a type
+func has(schema unified.Schema, t string) bool {
+	return schema != nil && !schema.IsNil() && slices.Contains(schema.GetTypes(), t)
+}
+
+// uncoerced returns the values of a field without a property: a string, or
+// strings when repeated
+func uncoerced(values []string) any {
+	if len(values) == 1 {
+		return values[0]
+	}
+	array := make([]any, len(values))
+	for i, v := range values {
+		array[i] = v
+	}
+	return array
+}
+
+// coerce converts a serialized value to the first type of a schema it can
+// be read as, or keeps it as a string for the schema to reject
+func coerce(schema unified.Schema, raw string) any {
+	if schema == nil || schema.IsNil() {
+		return raw
+	}
+	for _, t := range schema.GetTypes() {
+		switch t {
+		case "integer", "number":
+			if f, err := strconv.ParseFloat(raw, 64); err == nil {
+				return f
+			}
+		case "boolean":
+			if raw == "true" || raw == "false" {
+				return raw == "true"
+			}
+		case "null":
+			if raw == "" || raw == "null" {
+				return nil
+			}
+		case "string":
+			return raw
+		}
+	}
+	return raw
+}
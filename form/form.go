@@ -0,0 +1,328 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package form encodes and decodes application/x-www-form-urlencoded and
+// multipart/form-data bodies as the Encoding objects of their media type
+// describe: the content type and headers of each part, and the style,
+// explode and allowReserved of each form field.
+package form
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+var (
+	// ErrUnsupported is returned for media types other than
+	// application/x-www-form-urlencoded and multipart/form-data
+	ErrUnsupported = errors.New("unsupported media type")
+	// ErrNotObject is returned for a value that is not an object of fields
+	ErrNotObject = errors.New("form value is not an object")
+)
+
+const (
+	// URLEncoded is the media type of URL-encoded forms
+	URLEncoded = "application/x-www-form-urlencoded"
+	// Multipart is the media type of multipart forms
+	Multipart = "multipart/form-data"
+)
+
+// Supports reports whether a media type, parameters allowed, is a form this
+// package encodes and decodes
+func Supports(mediaType string) bool {
+	t := baseType(mediaType)
+	return t == URLEncoded || t == Multipart
+}
+
+// Encode encodes an object of fields as a body of a form media type,
+// following the encoding of mt, which may be nil. Values may be generic
+// JSON or any value that marshals to a JSON object. In multipart bodies,
+// []byte and io.Reader fields are sent as they are, as files. It returns the
+// body and its Content-Type, with the boundary of multipart bodies.
+func Encode(mediaType string, mt unified.MediaType, value any) ([]byte, string, error) {
+	fields, files, err := object(value)
+	if err != nil {
+		return nil, "", err
+	}
+	var encoding map[string]unified.Encoding
+	if mt != nil {
+		encoding = mt.GetEncoding()
+	}
+	switch baseType(mediaType) {
+	case URLEncoded:
+		for name, data := range files {
+			fields[name] = string(data)
+		}
+		return []byte(encodeURL(fields, encoding)), URLEncoded, nil
+	case Multipart:
+		return encodeMultipart(fields, files, encoding)
+	}
+	return nil, "", fmt.Errorf("%w %q", ErrUnsupported, mediaType)
+}
+
+// object splits a value into its JSON fields and its binary ones
+func object(value any) (map[string]any, map[string][]byte, error) {
+	files := make(map[string][]byte)
+	fields := make(map[string]any)
+	if m, ok := value.(map[string]any); ok {
+		for key, v := range m {
+			switch x := v.(type) {
+			case []byte:
+				files[key] = x
+			case io.Reader:
+				data, err := io.ReadAll(x)
+				if err != nil {
+					return nil, nil, err
+				}
+				files[key] = data
+			default:
+				fields[key] = v
+			}
+		}
+		value = fields
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields = nil
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return nil, nil, fmt.Errorf("%w: %T", ErrNotObject, value)
+	}
+	return fields, files, nil
+}
+
+// encodeURL encodes the fields of a URL-encoded form, sorted by name
+func encodeURL(fields map[string]any, encoding map[string]unified.Encoding) string {
+	var pairs []string
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		value := fields[name]
+		if value == nil {
+			continue
+		}
+		enc := encoding[name]
+		escape := url.QueryEscape
+		if enc != nil && enc.GetAllowReserved() {
+			escape = escapeUnreserved
+		}
+		if contentType := partType(enc); contentType != "" && isJSON(contentType) {
+			data, _ := json.Marshal(value)
+			pairs = append(pairs, url.QueryEscape(name)+"="+escape(string(data)))
+			continue
+		}
+		style, explode := styleOf(enc)
+		for _, pair := range serialize(name, style, explode, value, escape) {
+			pairs = append(pairs, url.QueryEscape(pair[0])+"="+pair[1])
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// reserved are the reserved characters allowReserved keeps, but for those
+// separating the fields of a form
+var reserved = strings.NewReplacer("%3A", ":", "%2F", "/", "%3F", "?", "%5B", "[", "%5D", "]", "%40", "@",
+	"%21", "!", "%24", "$", "%27", "'", "%28", "(", "%29", ")", "%2A", "*", "%2C", ",", "%3B", ";")
+
+// escapeUnreserved escapes a value but for reserved characters
+func escapeUnreserved(s string) string {
+	return reserved.Replace(url.QueryEscape(s))
+}
+
+// styleOf returns the style and explode of a form field: form by default,
+// and exploded by default only when its style is form
+func styleOf(enc unified.Encoding) (string, bool) {
+	style := "form"
+	if enc != nil && enc.GetStyle() != "" {
+		style = enc.GetStyle()
+	}
+	if enc != nil && enc.HasExplode() {
+		return style, enc.GetExplode()
+	}
+	return style, style == "form"
+}
+
+// serialize serializes a field by its style, as name and value pairs whose
+// values are escaped
+func serialize(name, style string, explode bool, value any, escape func(string) string) [][2]string {
+	switch x := value.(type) {
+	case []any:
+		items := make([]string, len(x))
+		for i, item := range x {
+			items[i] = escape(primitive(item))
+		}
+		switch {
+		case style == "spaceDelimited":
+			return [][2]string{{name, strings.Join(items, "%20")}}
+		case style == "pipeDelimited":
+			return [][2]string{{name, strings.Join(items, "|")}}
+		case explode:
+			pairs := make([][2]string, len(items))
+			for i, item := range items {
+				pairs[i] = [2]string{name, item}
+			}
+			return pairs
+		}
+		return [][2]string{{name, strings.Join(items, ",")}}
+	case map[string]any:
+		var pairs [][2]string
+		var flat []string
+		for _, key := range slices.Sorted(maps.Keys(x)) {
+			v := escape(primitive(x[key]))
+			switch {
+			case style == "deepObject":
+				pairs = append(pairs, [2]string{name + "[" + key + "]", v})
+			case explode:
+				pairs = append(pairs, [2]string{key, v})
+			default:
+				flat = append(flat, escape(key), v)
+			}
+		}
+		if flat != nil {
+			return [][2]string{{name, strings.Join(flat, ",")}}
+		}
+		return pairs
+	}
+	return [][2]string{{name, escape(primitive(value))}}
+}
+
+// primitive serializes a primitive value, and other values as JSON
+func primitive(value any) string {
+	switch x := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// encodeMultipart encodes the fields and files of a multipart form, a part
+// per field and per item of an array field, sorted by name
+func encodeMultipart(fields map[string]any, files map[string][]byte, encoding map[string]unified.Encoding) ([]byte, string, error) {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	names := slices.Sorted(maps.Keys(fields))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		enc := encoding[name]
+		if data, ok := files[name]; ok {
+			contentType := partType(enc)
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+			if err := writePart(w, name, name, contentType, enc, data); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+		values := []any{fields[name]}
+		if array, ok := fields[name].([]any); ok && !isJSON(partType(enc)) {
+			values = array
+		}
+		for _, value := range values {
+			if value == nil {
+				continue
+			}
+			contentType := partType(enc)
+			if contentType == "" {
+				contentType = "text/plain"
+				if _, ok := value.(map[string]any); ok {
+					contentType = "application/json"
+				} else if _, ok := value.([]any); ok {
+					contentType = "application/json"
+				}
+			}
+			var data []byte
+			if isJSON(contentType) {
+				data, _ = json.Marshal(value)
+			} else {
+				data = []byte(primitive(value))
+			}
+			if err := writePart(w, name, "", contentType, enc, data); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return b.Bytes(), w.FormDataContentType(), nil
+}
+
+// writePart writes a part of a multipart form, with the headers of its
+// encoding that have an example or a default
+func writePart(w *multipart.Writer, name, filename, contentType string, enc unified.Encoding, data []byte) error {
+	h := make(textproto.MIMEHeader)
+	disposition := map[string]string{"name": name}
+	if filename != "" {
+		disposition["filename"] = filename
+	}
+	h.Set("Content-Disposition", mime.FormatMediaType("form-data", disposition))
+	h.Set("Content-Type", contentType)
+	if enc != nil {
+		for key, header := range enc.GetHeaders() {
+			if header == nil || strings.EqualFold(key, "Content-Type") || header.GetSchema() == nil {
+				continue
+			}
+			value := header.GetSchema().GetExample()
+			if value == nil {
+				value = header.GetSchema().GetDefault()
+			}
+			if value != nil {
+				h.Set(key, primitive(value))
+			}
+		}
+	}
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(data)
+	return err
+}
+
+// partType returns the content type of an encoding, the first of a list
+// such as "image/png, image/jpeg"
+func partType(enc unified.Encoding) string {
+	if enc == nil {
+		return ""
+	}
+	first, _, _ := strings.Cut(enc.GetContentType(), ",")
+	return strings.TrimSpace(first)
+}
+
+// baseType returns a media type without its parameters, lowercased
+func baseType(mediaType string) string {
+	if t, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return t
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// isJSON reports whether a media type is application/json or a +json type
+func isJSON(mediaType string) bool {
+	t := baseType(mediaType)
+	return t == "application/json" || strings.HasSuffix(t, "+json")
+}
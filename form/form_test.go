@@ -0,0 +1,217 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package form
+
+import (
+	"errors"
+	"mime"
+	"mime/multipart"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+)
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return doc
+}
+
+const upload = `{
+	"openapi": "3.1.0",
+	"info": {"title": "Pets", "version": "1"},
+	"paths": {"/pets": {"post": {
+		"requestBody": {"content": {
+			"application/x-www-form-urlencoded": {
+				"schema": {"type": "object", "properties": {
+					"name": {"type": "string"},
+					"age": {"type": "integer"},
+					"tags": {"type": "array", "items": {"type": "string"}},
+					"ids": {"type": "array", "items": {"type": "integer"}},
+					"filter": {"type": "object", "properties": {"color": {"type": "string"}, "size": {"type": "integer"}}},
+					"meta": {"type": "object"},
+					"link": {"type": "string"}
+				}},
+				"encoding": {
+					"ids": {"style": "pipeDelimited"},
+					"filter": {"style": "deepObject", "explode": true},
+					"meta": {"contentType": "application/json"},
+					"link": {"allowReserved": true}
+				}
+			},
+			"multipart/form-data": {
+				"schema": {"type": "object", "properties": {
+					"name": {"type": "string"},
+					"age": {"type": "integer"},
+					"photo": {"type": "string", "contentMediaType": "image/png"},
+					"tags": {"type": "array", "items": {"type": "string"}},
+					"owner": {"type": "object", "properties": {"email": {"type": "string"}}}
+				}},
+				"encoding": {
+					"photo": {"contentType": "image/png, image/jpeg", "headers": {"X-Rate": {"required": true, "schema": {"type": "integer", "example": 3}}}}
+				}
+			}
+		}},
+		"responses": {"200": {"description": "OK"}}
+	}}}
+}`
+
+func TestURLEncoded(t *testing.T) {
+	mt := parse(t, upload).GetPaths()["/pets"].GetAllOperations()["post"].GetRequestBody().GetMediaType(URLEncoded)
+	value := map[string]any{
+		"name":   "Rex Junior",
+		"age":    3,
+		"tags":   []string{"a", "b"},
+		"ids":    []int{1, 2},
+		"filter": map[string]any{"color": "red", "size": 2},
+		"meta":   map[string]any{"k": "v"},
+		"link":   "https://example.com/a?b",
+	}
+	body, contentType, err := Encode(URLEncoded, mt, value)
+	if err != nil || contentType != URLEncoded {
+		t.Fatalf("Encode() = %s, %v", contentType, err)
+	}
+	want := "age=3&filter%5Bcolor%5D=red&filter%5Bsize%5D=2&ids=1|2&link=https://example.com/a?b&meta=%7B%22k%22%3A%22v%22%7D&name=Rex+Junior&tags=a&tags=b"
+	if string(body) != want {
+		t.Errorf("Encode() =\n%s\nwant:\n%s", body, want)
+	}
+
+	decoded, err := Decode(URLEncoded+"; charset=utf-8", mt, body)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	wantDecoded := map[string]any{
+		"name":   "Rex Junior",
+		"age":    3.0,
+		"tags":   []any{"a", "b"},
+		"ids":    []any{1.0, 2.0},
+		"filter": map[string]any{"color": "red", "size": 2.0},
+		"meta":   map[string]any{"k": "v"},
+		"link":   "https://example.com/a?b",
+	}
+	if !reflect.DeepEqual(decoded, wantDecoded) {
+		t.Errorf("Decode() = %v, want %v", decoded, wantDecoded)
+	}
+
+	if decoded, _ := Decode(URLEncoded, nil, []byte("a=1&b=2&b=3")); !reflect.DeepEqual(decoded, map[string]any{"a": "1", "b": []any{"2", "3"}}) {
+		t.Errorf("Expected strings without a schema, got %v", decoded)
+	}
+	if _, _, err := Encode("text/plain", nil, value); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected an unsupported media type, got %v", err)
+	}
+	if _, _, err := Encode(URLEncoded, nil, []int{1}); !errors.Is(err, ErrNotObject) {
+		t.Errorf("Expected a value that is not an object, got %v", err)
+	}
+}
+
+func TestExplode(t *testing.T) {
+	doc := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"post": {
+			"requestBody": {"content": {"application/x-www-form-urlencoded": {
+				"schema": {"type": "object", "properties": {
+					"a": {"type": "array", "items": {"type": "integer"}},
+					"b": {"type": "array", "items": {"type": "integer"}},
+					"c": {"type": "array", "items": {"type": "integer"}},
+					"d": {"type": "array", "items": {"type": "integer"}}
+				}},
+				"encoding": {
+					"a": {"style": "form"},
+					"b": {"explode": false},
+					"c": {"style": "spaceDelimited"},
+					"d": {"style": "form", "explode": true}
+				}
+			}}},
+			"responses": {"200": {"description": "OK"}}
+		}}}
+	}`)
+	mt := doc.GetPaths()["/pets"].GetAllOperations()["post"].GetRequestBody().GetMediaType(URLEncoded)
+	value := map[string]any{"a": []int{1, 2}, "b": []int{3, 4}, "c": []int{5, 6}, "d": []int{7, 8}}
+	body, _, err := Encode(URLEncoded, mt, value)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if want := "a=1&a=2&b=3,4&c=5%206&d=7&d=8"; string(body) != want {
+		t.Errorf("Encode() = %s, want %s", body, want)
+	}
+	decoded, err := Decode(URLEncoded, mt, body)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	want := map[string]any{"a": []any{1.0, 2.0}, "b": []any{3.0, 4.0}, "c": []any{5.0, 6.0}, "d": []any{7.0, 8.0}}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("Decode() = %v, want %v", decoded, want)
+	}
+}
+
+func TestMultipart(t *testing.T) {
+	mt := parse(t, upload).GetPaths()["/pets"].GetAllOperations()["post"].GetRequestBody().GetMediaType(Multipart)
+	value := map[string]any{
+		"name":  "Rex",
+		"age":   3,
+		"photo": []byte("\x89PNG"),
+		"tags":  []any{"a", "b"},
+		"owner": map[string]any{"email": "a@example.com"},
+	}
+	body, contentType, err := Encode(Multipart, mt, value)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil || params["boundary"] == "" {
+		t.Fatalf("Unexpected Content-Type %q", contentType)
+	}
+
+	r := multipart.NewReader(strings.NewReader(string(body)), params["boundary"])
+	var parts []string
+	for {
+		part, err := r.NextPart()
+		if err != nil {
+			break
+		}
+		parts = append(parts, part.FormName()+" "+part.Header.Get("Content-Type")+" "+part.FileName()+" "+part.Header.Get("X-Rate"))
+	}
+	wantParts := []string{
+		"age text/plain  ",
+		"name text/plain  ",
+		"owner application/json  ",
+		"photo image/png photo 3",
+		"tags text/plain  ",
+		"tags text/plain  ",
+	}
+	if !reflect.DeepEqual(parts, wantParts) {
+		t.Errorf("Unexpected parts %q, want %q", parts, wantParts)
+	}
+
+	decoded, err := Decode(contentType, mt, body)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	wantDecoded := map[string]any{
+		"name":  "Rex",
+		"age":   3.0,
+		"photo": "\x89PNG",
+		"tags":  []any{"a", "b"},
+		"owner": map[string]any{"email": "a@example.com"},
+	}
+	if !reflect.DeepEqual(decoded, wantDecoded) {
+		t.Errorf("Decode() = %v, want %v", decoded, wantDecoded)
+	}
+
+	// A part without the required header of its encoding is rejected
+	var b strings.Builder
+	w := multipart.NewWriter(&b)
+	w.CreateFormFile("photo", "photo.png")
+	w.Close()
+	if _, err := Decode(w.FormDataContentType(), mt, []byte(b.String())); err == nil {
+		t.Errorf("Expected an error for a missing part header")
+	}
+}
@@ -0,0 +1,460 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package har infers OpenAPI 3.1 skeletons from HAR captures, the HTTP
+// Archive files browsers and proxies export, to bootstrap the documents of
+// undocumented services: paths, methods, path and query parameters, and the
+// schemas of request and response payloads.
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	oa31 "github.com/genelet/oas/openapi31"
+	"github.com/genelet/oas/unified"
+)
+
+// ErrNoEntries is returned for a capture without a request to infer from
+var ErrNoEntries = errors.New("no API requests in the capture")
+
+// HAR is an HTTP Archive, with the fields inference reads
+type HAR struct {
+	Log Log `json:"log"`
+}
+
+// Log is the log of a HAR
+type Log struct {
+	Version string  `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Entry is an exchange of a HAR
+type Entry struct {
+	StartedDateTime string   `json:"startedDateTime"`
+	Request         Request  `json:"request"`
+	Response        Response `json:"response"`
+}
+
+// Request is the request of an Entry
+type Request struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	Headers     []NameValue `json:"headers"`
+	QueryString []NameValue `json:"queryString"`
+	PostData    *PostData   `json:"postData,omitempty"`
+}
+
+// Response is the response of an Entry
+type Response struct {
+	Status     int         `json:"status"`
+	StatusText string      `json:"statusText"`
+	Headers    []NameValue `json:"headers"`
+	Content    Content     `json:"content"`
+}
+
+// Content is the body of a Response, base64 encoded when Encoding says so
+type Content struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// PostData is the body of a Request, with the fields of forms in Params
+type PostData struct {
+	MimeType string  `json:"mimeType"`
+	Text     string  `json:"text,omitempty"`
+	Params   []Param `json:"params,omitempty"`
+}
+
+// Param is a field of a form body; a file when it has a FileName
+type Param struct {
+	Name        string `json:"name"`
+	Value       string `json:"value,omitempty"`
+	FileName    string `json:"fileName,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// NameValue is a header or a query parameter
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Options configures the inference
+type Options struct {
+	// Title and Version are those of the document; "Inferred API" and
+	// "1.0.0" by default
+	Title, Version string
+	// Hosts restricts the inference to requests to these hosts, such as
+	// api.example.com; every host is a server of the document when empty
+	Hosts []string
+	// Assets keeps the requests for pages, scripts, styles, images, fonts
+	// and media, which are skipped by default
+	Assets bool
+	// Examples keeps the first value of each parameter and payload as its
+	// example
+	Examples bool
+}
+
+// Parse parses a HAR file
+func Parse(data []byte) (*HAR, error) {
+	var h HAR
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("invalid HAR: %w", err)
+	}
+	return &h, nil
+}
+
+// Import parses a HAR file and infers a document from it
+func Import(data []byte, opts Options) (*oa31.OpenAPI, error) {
+	h, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return Infer(h, opts)
+}
+
+// Infer infers a document from the requests of a capture. Each host is a
+// server, sorted by the number of requests. Path segments that look like
+// identifiers, such as numbers and UUIDs, become path parameters named
+// after the segment before them: /pets/42 is /pets/{petId}. Query
+// parameters are required when every request of their operation has them,
+// and so are request bodies. The schemas of parameters and of JSON and form
+// payloads are inferred from their values, merged over the requests.
+// Headers and cookies are not inferred, as captures are full of those of
+// browsers. Operations are named as unified.OperationIDMethodPath does.
+func Infer(h *HAR, opts Options) (*oa31.OpenAPI, error) {
+	if h == nil {
+		return nil, ErrNoEntries
+	}
+	if opts.Title == "" {
+		opts.Title = "Inferred API"
+	}
+	if opts.Version == "" {
+		opts.Version = "1.0.0"
+	}
+	servers := make(map[string]int)
+	ops := make(map[string]*operation)
+	for _, e := range h.Log.Entries {
+		u, err := url.Parse(e.Request.URL)
+		if err != nil || !u.IsAbs() {
+			continue
+		}
+		if len(opts.Hosts) > 0 && !slices.Contains(opts.Hosts, u.Host) {
+			continue
+		}
+		method := strings.ToLower(e.Request.Method)
+		if !oa31.IsFixedMethod(method) || e.Response.Status == 0 {
+			continue
+		}
+		if !opts.Assets && asset(e.Response.Content.MimeType) {
+			continue
+		}
+		path, values := template(u.Path)
+		servers[u.Scheme+"://"+u.Host]++
+		key := method + " " + path
+		if ops[key] == nil {
+			ops[key] = &operation{method: method, path: path, params: make(map[string]*param),
+				responses: make(map[int]content)}
+		}
+		ops[key].add(e, u, values)
+	}
+	if len(ops) == 0 {
+		return nil, ErrNoEntries
+	}
+
+	b := oa31.NewDocument(opts.Title, opts.Version)
+	urls := slices.Sorted(maps.Keys(servers))
+	slices.SortStableFunc(urls, func(a, b string) int { return servers[b] - servers[a] })
+	for _, u := range urls {
+		b.Server(u, "")
+	}
+	doc := b.Build()
+	for _, key := range slices.Sorted(maps.Keys(ops)) {
+		o := ops[key]
+		item := doc.Paths.Get(o.path)
+		if item == nil {
+			item = &oa31.PathItem{}
+			doc.Paths.Set(o.path, item)
+		}
+		item.SetOperation(o.method, o.build(opts.Examples))
+	}
+	if _, err := unified.GenerateOperationIDs(unified.NewDocument31(doc), unified.OperationIDMethodPath); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// asset reports whether a response media type is that of a page, script,
+// style, image, font or media file
+func asset(mimeType string) bool {
+	t := baseType(mimeType)
+	switch {
+	case t == "text/html", t == "text/css", strings.Contains(t, "javascript"):
+		return true
+	}
+	for _, prefix := range []string{"image/", "font/", "audio/", "video/"} {
+		if strings.HasPrefix(t, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// operation gathers the requests of a method and path template
+type operation struct {
+	method, path string
+	count        int
+	params       map[string]*param
+	order        []string // path parameters, in path order
+	bodies       int
+	request      content
+	responses    map[int]content
+}
+
+// param gathers the values of a parameter, a list per request
+type param struct {
+	in, name string
+	values   [][]any
+}
+
+// content gathers the payloads of a body by media type
+type content map[string]*media
+
+// media gathers the payloads of a media type
+type media struct {
+	shape   *shape
+	example any
+}
+
+// add adds a payload, structured when it could be decoded
+func (c content) add(mediaType string, value any, structured bool) {
+	m := c[mediaType]
+	if m == nil {
+		m = &media{shape: &shape{}}
+		c[mediaType] = m
+	}
+	if !structured {
+		// Opaque payloads are text or binary strings
+		value = binary{}
+		if strings.HasPrefix(mediaType, "text/") {
+			value = ""
+		}
+	} else if m.example == nil && !hasBinary(value) {
+		m.example = value
+	}
+	m.shape.add(value)
+}
+
+// hasBinary reports whether a payload has files, which examples cannot show
+func hasBinary(value any) bool {
+	switch x := value.(type) {
+	case binary:
+		return true
+	case []any:
+		return slices.ContainsFunc(x, hasBinary)
+	case map[string]any:
+		for _, v := range x {
+			if hasBinary(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// add adds a request to an operation
+func (o *operation) add(e Entry, u *url.URL, values []pathValue) {
+	o.count++
+	for _, v := range values {
+		p := o.param("path", v.name)
+		if len(p.values) == 0 {
+			o.order = append(o.order, v.name)
+		}
+		p.values = append(p.values, []any{scalar(v.raw)})
+	}
+	for name, raw := range u.Query() {
+		p := o.param("query", name)
+		list := make([]any, len(raw))
+		for i, r := range raw {
+			list[i] = scalar(r)
+		}
+		p.values = append(p.values, list)
+	}
+
+	if pd := e.Request.PostData; pd != nil && (pd.Text != "" || len(pd.Params) > 0) {
+		o.bodies++
+		if o.request == nil {
+			o.request = make(content)
+		}
+		mediaType := baseType(pd.MimeType)
+		value, ok := payload(mediaType, pd.Text, pd.Params)
+		o.request.add(mediaType, value, ok)
+	}
+
+	status := e.Response.Status
+	if o.responses[status] == nil {
+		o.responses[status] = make(content)
+	}
+	c := e.Response.Content
+	text := c.Text
+	if c.Encoding == "base64" {
+		data, err := base64.StdEncoding.DecodeString(text)
+		if err != nil {
+			return
+		}
+		text = string(data)
+	}
+	if mediaType := baseType(c.MimeType); mediaType != "" && text != "" {
+		value, ok := payload(mediaType, text, nil)
+		o.responses[status].add(mediaType, value, ok)
+	}
+}
+
+// param returns a parameter of an operation, adding it if needed
+func (o *operation) param(in, name string) *param {
+	key := in + ":" + name
+	if o.params[key] == nil {
+		o.params[key] = &param{in: in, name: name}
+	}
+	return o.params[key]
+}
+
+// build builds the operation
+func (o *operation) build(examples bool) *oa31.Operation {
+	op := oa31.NewOperation("")
+	var query []string
+	for key, p := range o.params {
+		if p.in == "query" {
+			query = append(query, key)
+		}
+	}
+	slices.Sort(query)
+	for _, name := range o.order {
+		op.Parameters = append(op.Parameters, o.params["path:"+name].build(true, examples))
+	}
+	for _, key := range query {
+		p := o.params[key]
+		op.Parameters = append(op.Parameters, p.build(len(p.values) == o.count, examples))
+	}
+	if o.bodies > 0 {
+		op.RequestBody = &oa31.RequestBody{Content: o.request.build(examples), Required: o.bodies == o.count}
+	}
+	for status, c := range o.responses {
+		description := http.StatusText(status)
+		if description == "" {
+			description = "Status " + strconv.Itoa(status)
+		}
+		resp := oa31.NewResponse(description)
+		if len(c) > 0 {
+			resp.Content = c.build(examples)
+		}
+		op.Responses.StatusCode[strconv.Itoa(status)] = resp
+	}
+	return op
+}
+
+// build builds a parameter: an array when a request repeats it
+func (p *param) build(required, examples bool) *oa31.Parameter {
+	repeated := slices.ContainsFunc(p.values, func(list []any) bool { return len(list) > 1 })
+	s := &shape{}
+	for _, list := range p.values {
+		if repeated {
+			s.add(list)
+		} else {
+			s.add(list[0])
+		}
+	}
+	parameter := &oa31.Parameter{Name: p.name, In: p.in, Required: required, Schema: s.schema()}
+	if examples {
+		parameter.Example = p.values[0][0]
+		if repeated {
+			parameter.Example = p.values[0]
+		}
+	}
+	return parameter
+}
+
+// build builds the media types of a body
+func (c content) build(examples bool) map[string]*oa31.MediaType {
+	built := make(map[string]*oa31.MediaType, len(c))
+	for mediaType, m := range c {
+		mt := &oa31.MediaType{Schema: m.shape.schema()}
+		if examples {
+			mt.Example = m.example
+		}
+		built[mediaType] = mt
+	}
+	return built
+}
+
+// payload decodes a JSON or form payload; it reports false for others
+func payload(mediaType, text string, params []Param) (any, bool) {
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var value any
+		if err := json.Unmarshal([]byte(text), &value); err != nil {
+			return nil, false
+		}
+		return value, true
+	case mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data":
+		fields := make(map[string][]any)
+		var names []string
+		add := func(name string, value any) {
+			if _, ok := fields[name]; !ok {
+				names = append(names, name)
+			}
+			fields[name] = append(fields[name], value)
+		}
+		if len(params) == 0 && mediaType != "multipart/form-data" {
+			query, err := url.ParseQuery(text)
+			if err != nil {
+				return nil, false
+			}
+			for name, values := range query {
+				for _, v := range values {
+					add(name, scalar(v))
+				}
+			}
+		}
+		for _, p := range params {
+			if p.FileName != "" {
+				add(p.Name, binary{})
+			} else {
+				add(p.Name, scalar(p.Value))
+			}
+		}
+		if len(names) == 0 {
+			return nil, false
+		}
+		object := make(map[string]any, len(fields))
+		for _, name := range names {
+			object[name] = fields[name][0]
+			if len(fields[name]) > 1 {
+				object[name] = fields[name]
+			}
+		}
+		return object, true
+	}
+	return nil, false
+}
+
+// baseType returns a media type without its parameters, lowercased
+func baseType(mediaType string) string {
+	if t, _, err := mime.ParseMediaType(mediaType); err == nil {
+		return t
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
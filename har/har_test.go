@@ -0,0 +1,155 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package har
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+)
+
+const capture = `{"log": {"version": "1.2", "entries": [
+	{"request": {"method": "GET", "url": "https://api.example.com/v1/pets?limit=10&tag=a&tag=b"},
+	 "response": {"status": 200, "content": {"mimeType": "application/json; charset=utf-8",
+		"text": "[{\"id\": 1, \"name\": \"Rex\", \"born\": \"2020-01-02\"}]"}}},
+	{"request": {"method": "GET", "url": "https://api.example.com/v1/pets?limit=5"},
+	 "response": {"status": 200, "content": {"mimeType": "application/json",
+		"text": "[{\"id\": 2, \"name\": \"Tom\", \"born\": null}]"}}},
+	{"request": {"method": "GET", "url": "https://api.example.com/v1/pets/42"},
+	 "response": {"status": 200, "content": {"mimeType": "application/json",
+		"text": "{\"id\": 42, \"name\": \"Rex\", \"weight\": 3.5}"}}},
+	{"request": {"method": "GET", "url": "https://api.example.com/v1/pets/7"},
+	 "response": {"status": 404, "content": {"mimeType": "application/json",
+		"text": "eyJtZXNzYWdlIjoibm90IGZvdW5kIn0=", "encoding": "base64"}}},
+	{"request": {"method": "POST", "url": "https://api.example.com/v1/pets",
+		"postData": {"mimeType": "application/json", "text": "{\"name\": \"Rex\", \"tags\": [\"good\"]}"}},
+	 "response": {"status": 201, "content": {"mimeType": "application/json", "text": "{\"id\": 43}"}}},
+	{"request": {"method": "PUT", "url": "https://api.example.com/v1/pets/42/photos/0f8fad5b-d9cb-469f-a165-70867728950e",
+		"postData": {"mimeType": "multipart/form-data; boundary=x",
+			"params": [{"name": "photo", "fileName": "rex.png"}, {"name": "caption", "value": "Rex"}]}},
+	 "response": {"status": 204, "content": {"mimeType": ""}}},
+	{"request": {"method": "GET", "url": "https://cdn.example.com/app.js"},
+	 "response": {"status": 200, "content": {"mimeType": "application/javascript"}}},
+	{"request": {"method": "GET", "url": "https://api.example.com/v1/blocked"},
+	 "response": {"status": 0, "content": {}}}
+]}}`
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	return string(data)
+}
+
+func TestImport(t *testing.T) {
+	doc, err := Import([]byte(capture), Options{Title: "Pets", Examples: true})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result := doc.Validate(); !result.Valid() {
+		t.Errorf("Import() is not valid: %v", result.Error())
+	}
+	if doc.OpenAPI != "3.1.0" || doc.Info.Title != "Pets" || doc.Info.Version != "1.0.0" {
+		t.Errorf("Import() header = %s %s %s", doc.OpenAPI, doc.Info.Title, doc.Info.Version)
+	}
+	if len(doc.Servers) != 1 || doc.Servers[0].URL != "https://api.example.com" {
+		t.Errorf("Import() servers = %s", marshal(t, doc.Servers))
+	}
+	paths := slices.Sorted(maps.Keys(doc.Paths.Paths))
+	want := []string{"/v1/pets", "/v1/pets/{petId}", "/v1/pets/{petId}/photos/{photoId}"}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("Import() paths = %v, want %v", paths, want)
+	}
+
+	list := doc.Paths.Get("/v1/pets").Get
+	if list.OperationID != "getV1Pets" {
+		t.Errorf("operationId = %q", list.OperationID)
+	}
+	if got := marshal(t, list.Parameters); got != `[{"name":"limit","in":"query","required":true,"schema":{"type":"integer"},"example":10},`+
+		`{"name":"tag","in":"query","schema":{"items":{"type":"string"},"type":"array"},"example":["a","b"]}]` {
+		t.Errorf("parameters = %s", got)
+	}
+	if got := marshal(t, list.Responses.Get("200").Content["application/json"].Schema); got != `{"items":{"properties":{"born":{"type":["string","null"],"format":"date"},`+
+		`"id":{"type":"integer"},"name":{"type":"string"}},"type":"object","required":["born","id","name"]},"type":"array"}` {
+		t.Errorf("response schema = %s", got)
+	}
+
+	get := doc.Paths.Get("/v1/pets/{petId}").Get
+	if got := marshal(t, get.Parameters); got != `[{"name":"petId","in":"path","required":true,"schema":{"type":"integer"},"example":42}]` {
+		t.Errorf("path parameters = %s", got)
+	}
+	if got := marshal(t, get.Responses.Get("404")); got != `{"description":"Not Found","content":{"application/json":`+
+		`{"schema":{"properties":{"message":{"type":"string"}},"type":"object","required":["message"]},"example":{"message":"not found"}}}}` {
+		t.Errorf("404 response = %s", got)
+	}
+
+	create := doc.Paths.Get("/v1/pets").Post
+	if rb := create.RequestBody; rb == nil || !rb.Required || marshal(t, rb.Content["application/json"].Schema) !=
+		`{"properties":{"name":{"type":"string"},"tags":{"items":{"type":"string"},"type":"array"}},"type":"object","required":["name","tags"]}` {
+		t.Errorf("request body = %s", marshal(t, rb))
+	}
+
+	upload := doc.Paths.Get("/v1/pets/{petId}/photos/{photoId}").Put
+	if got := marshal(t, upload.Parameters[1].Schema); got != `{"type":"string","format":"uuid"}` {
+		t.Errorf("uuid parameter = %s", got)
+	}
+	mt := upload.RequestBody.Content["multipart/form-data"]
+	if got := marshal(t, mt.Schema); got != `{"properties":{"caption":{"type":"string"},`+
+		`"photo":{"type":"string","format":"binary"}},"type":"object","required":["caption","photo"]}` || mt.Example != nil {
+		t.Errorf("multipart body = %s", marshal(t, mt))
+	}
+	if resp := upload.Responses.Get("204"); resp == nil || resp.Content != nil {
+		t.Errorf("204 response = %s", marshal(t, resp))
+	}
+}
+
+func TestInferOptions(t *testing.T) {
+	h, err := Parse([]byte(capture))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	doc, err := Infer(h, Options{Assets: true})
+	if err != nil {
+		t.Fatalf("Infer() error = %v", err)
+	}
+	if urls := []string{doc.Servers[0].URL, doc.Servers[1].URL}; len(doc.Servers) != 2 || urls[1] != "https://cdn.example.com" {
+		t.Errorf("Infer() servers = %s", marshal(t, doc.Servers))
+	}
+	if doc.Paths.Get("/app.js") == nil {
+		t.Errorf("Expected /app.js with Assets")
+	}
+	if ex := doc.Paths.Get("/v1/pets").Get.Parameters[0].Example; ex != nil {
+		t.Errorf("Expected no examples, got %v", ex)
+	}
+
+	if _, err := Infer(h, Options{Hosts: []string{"other.example.com"}}); !errors.Is(err, ErrNoEntries) {
+		t.Errorf("Infer() error = %v, want ErrNoEntries", err)
+	}
+	if _, err := Import([]byte(`{"log":`), Options{}); err == nil {
+		t.Errorf("Expected an error for an invalid HAR")
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"", "/"},
+		{"/users/alice/repos", "/users/alice/repos"},
+		{"/categories/12/line-items/3", "/categories/{categoryId}/line-items/{lineItemId}"},
+		{"/status/5/5", "/status/{statusId}/{id}"},
+		{"/42/sessions/a1b2c3d4e5f6a7b8c9d0", "/{id}/sessions/{sessionId}"},
+		{"/pets/1/pets/2", "/pets/{petId}/pets/{petId2}"},
+	}
+	for _, tt := range tests {
+		if got, _ := template(tt.path); got != tt.want {
+			t.Errorf("template(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package har
+
+import (
+	"maps"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// binary is the value of a file or of an opaque binary payload
+type binary struct{}
+
+// shape gathers the values of a schema
+type shape struct {
+	types      map[string]bool
+	strings    int
+	format     string // the format of every string, if they share one
+	objects    int
+	properties map[string]*shape
+	present    map[string]int // how many objects have each property
+	items      *shape
+}
+
+// types are the JSON types in the order schemas list them
+var types = []string{"object", "array", "string", "number", "integer", "boolean", "null"}
+
+// add adds a value to a shape
+func (s *shape) add(value any) {
+	if s.types == nil {
+		s.types = make(map[string]bool)
+	}
+	switch x := value.(type) {
+	case nil:
+		s.types["null"] = true
+	case bool:
+		s.types["boolean"] = true
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+			s.types["integer"] = true
+		} else {
+			s.types["number"] = true
+		}
+	case string:
+		s.types["string"] = true
+		s.addFormat(formatOf(x))
+	case binary:
+		s.types["string"] = true
+		s.addFormat("binary")
+	case []any:
+		s.types["array"] = true
+		if s.items == nil {
+			s.items = &shape{}
+		}
+		for _, item := range x {
+			s.items.add(item)
+		}
+	case map[string]any:
+		s.types["object"] = true
+		s.objects++
+		if s.properties == nil {
+			s.properties = make(map[string]*shape)
+			s.present = make(map[string]int)
+		}
+		for key, v := range x {
+			if s.properties[key] == nil {
+				s.properties[key] = &shape{}
+			}
+			s.properties[key].add(v)
+			s.present[key]++
+		}
+	}
+}
+
+// addFormat adds the format of a string, keeping it only while every string
+// has it
+func (s *shape) addFormat(format string) {
+	if s.strings == 0 || s.format == format {
+		s.format = format
+	} else {
+		s.format = ""
+	}
+	s.strings++
+}
+
+// schema returns the schema of the values of a shape. Integers and numbers
+// together are numbers, and properties are required when every object has
+// them.
+func (s *shape) schema() *oa31.Schema {
+	var seen []string
+	for _, t := range types {
+		if s.types[t] && !(t == "integer" && s.types["number"]) {
+			seen = append(seen, t)
+		}
+	}
+	schema := &oa31.Schema{}
+	switch len(seen) {
+	case 0:
+		return schema
+	case 1:
+		schema.Type = &oa31.StringOrStringArray{String: seen[0]}
+	default:
+		schema.Type = &oa31.StringOrStringArray{Array: seen}
+	}
+	if s.types["string"] {
+		schema.Format = s.format
+	}
+	if s.types["array"] && s.items != nil {
+		schema.Items = s.items.schema()
+	}
+	if s.types["object"] {
+		schema.Properties = make(map[string]*oa31.Schema, len(s.properties))
+		for _, key := range slices.Sorted(maps.Keys(s.properties)) {
+			schema.Properties[key] = s.properties[key].schema()
+			if s.present[key] == s.objects {
+				schema.Required = append(schema.Required, key)
+			}
+		}
+	}
+	return schema
+}
+
+// uuid matches UUIDs
+var uuid = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// formatOf returns the format of a string, or "" when it has none known
+func formatOf(s string) string {
+	switch {
+	case s == "":
+		return ""
+	case uuid.MatchString(s):
+		return "uuid"
+	}
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		return "date-time"
+	}
+	if _, err := time.Parse(time.DateOnly, s); err == nil {
+		return "date"
+	}
+	if addr, err := mail.ParseAddress(s); err == nil && addr.Address == s {
+		return "email"
+	}
+	if u, err := url.Parse(s); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return "uri"
+	}
+	return ""
+}
+
+// scalar returns the value a query, path or form string stands for: a
+// boolean, a number, or the string. Numbers with leading zeros, such as
+// postal codes, stay strings.
+func scalar(raw string) any {
+	switch raw {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	digits := strings.TrimPrefix(raw, "-")
+	if digits == "" || len(digits) > 1 && digits[0] == '0' && digits[1] != '.' {
+		return raw
+	}
+	if f, err := strconv.ParseFloat(raw, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) && !strings.ContainsAny(raw, "xXpP_") {
+		return f
+	}
+	return raw
+}
+
+// pathValue is the value of a path parameter of a request
+type pathValue struct {
+	name, raw string
+}
+
+// template returns the template of a path, its segments that look like
+// identifiers made parameters, with their values
+func template(path string) (string, []pathValue) {
+	if path == "" {
+		return "/", nil
+	}
+	segments := strings.Split(path, "/")
+	var values []pathValue
+	used := make(map[string]bool)
+	for i, segment := range segments {
+		if !identifier(segment) {
+			continue
+		}
+		name := "id"
+		if i > 0 && !strings.HasPrefix(segments[i-1], "{") {
+			name = paramName(segments[i-1])
+		}
+		base := name
+		for n := 2; used[name]; n++ {
+			name = base + strconv.Itoa(n)
+		}
+		used[name] = true
+		values = append(values, pathValue{name: name, raw: segment})
+		segments[i] = "{" + name + "}"
+	}
+	return strings.Join(segments, "/"), values
+}
+
+// identifier reports whether a path segment looks like an identifier: a
+// number, a UUID, or a long token of letters and digits
+func identifier(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	if uuid.MatchString(segment) || strings.IndexFunc(segment, func(r rune) bool { return r < '0' || r > '9' }) < 0 {
+		return true
+	}
+	if len(segment) < 16 {
+		return false
+	}
+	var letter, digit bool
+	for _, r := range segment {
+		switch {
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			letter = true
+		case r != '-' && r != '_':
+			return false
+		}
+	}
+	return letter && digit
+}
+
+// paramName names a path parameter after the segment before it, in camel
+// case and singular: line-items is lineItemId
+func paramName(segment string) string {
+	words := strings.FieldsFunc(segment, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "id"
+	}
+	words[len(words)-1] = singular(words[len(words)-1])
+	var b strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			b.WriteString(strings.ToLower(w[:1]) + w[1:])
+		} else {
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	b.WriteString("Id")
+	return b.String()
+}
+
+// singular returns the singular of a plural English word, for the common
+// plurals
+func singular(word string) string {
+	lower := strings.ToLower(word)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(word) > 4:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"), len(word) <= 3:
+		return word
+	case strings.HasSuffix(lower, "s"):
+		return word[:len(word)-1]
+	}
+	return word
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package lint runs style and governance rules over OpenAPI documents of any
+// version. Rules work against the unified.Document interface, so a rule
+// written once applies to 2.0, 3.0, 3.1 and 3.2 documents. Organizations can
+// ship their own rules next to the built-in ones by implementing Rule.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Severity classifies how serious a lint finding is
+type Severity int
+
+const (
+	// SeverityInfo marks a suggestion
+	SeverityInfo Severity = iota
+	// SeverityWarning marks a practice that should be fixed
+	SeverityWarning
+	// SeverityError marks a violation that fails the lint run
+	SeverityError
+)
+
+var severityNames = []string{"info", "warning", "error"}
+
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// MarshalText encodes the severity by name
+func (s Severity) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(severityNames) {
+		return nil, fmt.Errorf("invalid severity %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity name
+func (s *Severity) UnmarshalText(text []byte) error {
+	sev, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sev
+	return nil
+}
+
+// ParseSeverity returns the severity with the given name
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(name, n) {
+			return Severity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// Finding is a rule violation in a document
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Pointer  string   `json:"pointer"` // JSON pointer into the document
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	if f.Pointer == "" {
+		return fmt.Sprintf("[%s] %s: %s", f.Severity, f.Rule, f.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s: %s", f.Severity, f.Rule, f.Pointer, f.Message)
+}
+
+// Rule checks a document for one kind of problem.
+// Apply only needs to fill Pointer and Message of its findings; the Linter
+// sets Rule and Severity from Name and the configured severity.
+type Rule interface {
+	// Name identifies the rule, e.g. "operation-id-casing"
+	Name() string
+	// Severity is the default severity of the findings of the rule
+	Severity() Severity
+	// Apply returns the violations of the rule in the document
+	Apply(doc unified.Document) []Finding
+}
+
+// funcRule adapts a function to the Rule interface
+type funcRule struct {
+	name     string
+	severity Severity
+	apply    func(doc unified.Document) []Finding
+}
+
+func (r funcRule) Name() string                         { return r.name }
+func (r funcRule) Severity() Severity                   { return r.severity }
+func (r funcRule) Apply(doc unified.Document) []Finding { return r.apply(doc) }
+
+// NewRule returns a rule that calls apply
+func NewRule(name string, severity Severity, apply func(doc unified.Document) []Finding) Rule {
+	return funcRule{name: name, severity: severity, apply: apply}
+}
+
+// pointerEscaper escapes a reference token per RFC 6901
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Pointer builds a JSON pointer from reference tokens, so rules can locate
+// their findings: Pointer("paths", "/pets", "get") is /paths/~1pets/get
+func Pointer(tokens ...string) string {
+	var b strings.Builder
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(t))
+	}
+	return b.String()
+}
+
+// fixedMethods lists the operations with their own field in a path item
+var fixedMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true,
+	"head": true, "patch": true, "trace": true, "query": true,
+}
+
+// operationPointer locates an operation returned by GetAllOperations. Other
+// methods are 3.2 additionalOperations, which are keyed in upper case.
+func operationPointer(path, method string, tokens ...string) string {
+	if fixedMethods[method] {
+		return Pointer(append([]string{"paths", path, method}, tokens...)...)
+	}
+	return Pointer(append([]string{"paths", path, "additionalOperations", strings.ToUpper(method)}, tokens...)...)
+}
+
+// documentJSON returns the document as generic JSON, for rules that need parts
+// of the document the unified interface does not expose. It returns nil for
+// documents that are not one of the unified adapters.
+func documentJSON(doc unified.Document) map[string]any {
+	var raw any
+	switch d := doc.(type) {
+	case *unified.Document20:
+		raw = d.GetRaw()
+	case *unified.Document30:
+		raw = d.GetRaw()
+	case *unified.Document31:
+		raw = d.GetRaw()
+	case *unified.Document32:
+		raw = d.GetRaw()
+	default:
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil
+	}
+	return root
+}
+
+// sections20 names the 2.0 counterparts of the component sections
+var sections20 = map[string]string{"schemas": "definitions", "securitySchemes": "securityDefinitions"}
+
+// componentPointer locates a component, such as a schema or a security
+// scheme, in components or in the 2.0 top-level sections
+func componentPointer(doc unified.Document, section, name string, tokens ...string) string {
+	if strings.HasPrefix(doc.Version(), "2.") {
+		if s, ok := sections20[section]; ok {
+			section = s
+		}
+		return Pointer(append([]string{section, name}, tokens...)...)
+	}
+	return Pointer(append([]string{"components", section, name}, tokens...)...)
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+)
+
+// operationSummary reports operations without a summary
+var operationSummary = NewRule("operation-summary", SeverityWarning, func(doc unified.Document) []Finding {
+	var findings []Finding
+	for path, item := range doc.GetPaths() {
+		for method, op := range item.GetAllOperations() {
+			if op.GetSummary() == "" {
+				findings = append(findings, Finding{Pointer: Pointer("paths", path, method), Message: "operation has no summary"})
+			}
+		}
+	}
+	return findings
+})
+
+// infoDescription reports a missing API description
+var infoDescription = NewRule("info-description", SeverityInfo, func(doc unified.Document) []Finding {
+	if doc.GetInfo().GetDescription() == "" {
+		return []Finding{{Pointer: "/info", Message: "API has no description"}}
+	}
+	return nil
+})
+
+func lintDocument(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return doc
+}
+
+func TestLinterAllVersions(t *testing.T) {
+	docs := map[string]string{
+		"2.0": `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}}}`,
+		"3.0": `{"openapi": "3.0.3", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}}}`,
+		"3.1": `{"openapi": "3.1.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}}}`,
+		"3.2": `{"openapi": "3.2.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}}}`,
+	}
+
+	linter, err := New(operationSummary, infoDescription)
+	if err != nil {
+		t.Fatalf("Failed to create linter: %v", err)
+	}
+	for version, data := range docs {
+		report := linter.Lint(lintDocument(t, data))
+		want := "[warning] operation-summary: /paths/~1pets/get: operation has no summary; [info] info-description: /info: API has no description"
+		if got := report.String(); got != want {
+			t.Errorf("%s: unexpected report:\n%s\nwant:\n%s", version, got, want)
+		}
+	}
+}
+
+func TestLinterConfiguration(t *testing.T) {
+	doc := lintDocument(t, `{"openapi": "3.1.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}}}`)
+
+	linter, err := New(operationSummary, infoDescription)
+	if err != nil {
+		t.Fatalf("Failed to create linter: %v", err)
+	}
+	if err := linter.Register(NewRule("operation-summary", SeverityError, nil)); err == nil {
+		t.Error("Expected an error for a duplicate rule name")
+	}
+	if linter.Rule("info-description") == nil || len(linter.Rules()) != 2 {
+		t.Errorf("Expected 2 registered rules, got %d", len(linter.Rules()))
+	}
+
+	linter.SetSeverity("operation-summary", SeverityError)
+	linter.Disable("info-description")
+	report := linter.Lint(doc)
+	if len(report.Findings) != 1 || !report.HasSeverity(SeverityError) {
+		t.Fatalf("Expected one error, got %s", report)
+	}
+	if got := report.ByRule("operation-summary"); len(got) != 1 || got[0].Severity != SeverityError {
+		t.Errorf("Expected the overridden severity, got %v", got)
+	}
+
+	linter.Enable("info-description")
+	report = linter.Lint(doc)
+	if got := report.Filter(SeverityWarning); len(got) != 1 {
+		t.Errorf("Expected one finding at warning or above, got %v", got)
+	}
+	if len(report.Findings) != 2 {
+		t.Errorf("Expected the re-enabled rule to run, got %s", report)
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	report := &Report{Findings: []Finding{{Rule: "info-description", Severity: SeverityInfo, Pointer: "/info", Message: "API has no description"}}}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	want := `{"findings":[{"rule":"info-description","severity":"info","pointer":"/info","message":"API has no description"}]}`
+	if string(data) != want {
+		t.Errorf("Unexpected JSON: %s", data)
+	}
+
+	var empty *Report
+	if !empty.Clean() || empty.HasSeverity(SeverityInfo) || empty.String() != "" {
+		t.Error("Expected nil report to be clean")
+	}
+}
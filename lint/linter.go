@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Linter runs a set of rules over documents
+type Linter struct {
+	rules      []Rule
+	disabled   map[string]bool
+	severities map[string]Severity
+}
+
+// New returns a linter running the given rules
+func New(rules ...Rule) (*Linter, error) {
+	l := &Linter{disabled: make(map[string]bool), severities: make(map[string]Severity)}
+	if err := l.Register(rules...); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Register adds rules to the linter. Rule names must be unique.
+func (l *Linter) Register(rules ...Rule) error {
+	for _, r := range rules {
+		if r == nil || r.Name() == "" {
+			return fmt.Errorf("rule must have a name")
+		}
+		if l.Rule(r.Name()) != nil {
+			return fmt.Errorf("rule %q is already registered", r.Name())
+		}
+		l.rules = append(l.rules, r)
+	}
+	return nil
+}
+
+// Rules returns the registered rules in registration order
+func (l *Linter) Rules() []Rule {
+	return append([]Rule(nil), l.rules...)
+}
+
+// Rule returns the registered rule with the given name, or nil
+func (l *Linter) Rule(name string) Rule {
+	for _, r := range l.rules {
+		if r.Name() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Disable turns rules off by name
+func (l *Linter) Disable(names ...string) {
+	for _, name := range names {
+		l.disabled[name] = true
+	}
+}
+
+// Enable turns disabled rules back on
+func (l *Linter) Enable(names ...string) {
+	for _, name := range names {
+		delete(l.disabled, name)
+	}
+}
+
+// SetSeverity overrides the default severity of a rule
+func (l *Linter) SetSeverity(name string, severity Severity) {
+	l.severities[name] = severity
+}
+
+// Lint runs every enabled rule over the document. Findings are grouped by
+// rule in registration order, and ordered by pointer within a rule.
+func (l *Linter) Lint(doc unified.Document) *Report {
+	report := &Report{}
+	if doc == nil {
+		return report
+	}
+	for _, r := range l.rules {
+		name := r.Name()
+		if l.disabled[name] {
+			continue
+		}
+		severity, ok := l.severities[name]
+		if !ok {
+			severity = r.Severity()
+		}
+		// Rules often walk maps; order their findings by location
+		findings := r.Apply(doc)
+		sort.SliceStable(findings, func(i, j int) bool { return findings[i].Pointer < findings[j].Pointer })
+		for _, f := range findings {
+			f.Rule = name
+			f.Severity = severity
+			report.Findings = append(report.Findings, f)
+		}
+	}
+	return report
+}
+
+// Report lists the findings of a lint run
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Clean returns true if no rule reported anything
+func (r *Report) Clean() bool {
+	return r == nil || len(r.Findings) == 0
+}
+
+// HasSeverity returns true if any finding is at least as severe as min.
+// CI jobs can use it to fail the build.
+func (r *Report) HasSeverity(min Severity) bool {
+	return len(r.Filter(min)) > 0
+}
+
+// Filter returns the findings that are at least as severe as min
+func (r *Report) Filter(min Severity) []Finding {
+	if r == nil {
+		return nil
+	}
+	var findings []Finding
+	for _, f := range r.Findings {
+		if f.Severity >= min {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// ByRule returns the findings of the named rule
+func (r *Report) ByRule(name string) []Finding {
+	if r == nil {
+		return nil
+	}
+	var findings []Finding
+	for _, f := range r.Findings {
+		if f.Rule == name {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// String returns a combined description of all findings
+func (r *Report) String() string {
+	if r.Clean() {
+		return ""
+	}
+	var msgs []string
+	for _, f := range r.Findings {
+		msgs = append(msgs, f.String())
+	}
+	return strings.Join(msgs, "; ")
+}
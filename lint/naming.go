@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Case is a naming convention
+type Case string
+
+const (
+	CamelCase  Case = "camelCase"  // getPetById
+	PascalCase Case = "PascalCase" // GetPetById
+	SnakeCase  Case = "snake_case" // get_pet_by_id
+	KebabCase  Case = "kebab-case" // get-pet-by-id
+)
+
+var casePatterns = map[Case]*regexp.Regexp{
+	CamelCase:  regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+	PascalCase: regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`),
+	SnakeCase:  regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`),
+	KebabCase:  regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`),
+}
+
+// Matches reports whether name follows the convention.
+// Unknown conventions match every name.
+func (c Case) Matches(name string) bool {
+	pattern, ok := casePatterns[c]
+	return !ok || pattern.MatchString(name)
+}
+
+// OperationIDCasing returns a rule reporting operationIds that do not follow
+// the convention. Operations without an operationId are not reported.
+func OperationIDCasing(c Case) Rule {
+	return NewRule("operation-id-casing", SeverityWarning, func(doc unified.Document) []Finding {
+		var findings []Finding
+		for path, item := range doc.GetPaths() {
+			if item == nil || item.HasRef() {
+				continue
+			}
+			for method, op := range item.GetAllOperations() {
+				if id := op.GetOperationID(); id != "" && !c.Matches(id) {
+					findings = append(findings, Finding{
+						Pointer: operationPointer(path, method, "operationId"),
+						Message: fmt.Sprintf("operationId '%s' is not %s", id, c),
+					})
+				}
+			}
+		}
+		return findings
+	})
+}
+
+// PathSegmentCasing returns a rule reporting literal path segments that do
+// not follow the convention. Templated segments such as {petId} and file
+// extensions such as .json are not checked.
+func PathSegmentCasing(c Case) Rule {
+	return NewRule("path-segment-casing", SeverityWarning, func(doc unified.Document) []Finding {
+		var findings []Finding
+		for path := range doc.GetPaths() {
+			for _, segment := range strings.Split(path, "/") {
+				if segment == "" || strings.Contains(segment, "{") {
+					continue
+				}
+				if i := strings.LastIndexByte(segment, '.'); i > 0 {
+					segment = segment[:i]
+				}
+				if !c.Matches(segment) {
+					findings = append(findings, Finding{
+						Pointer: Pointer("paths", path),
+						Message: fmt.Sprintf("path segment '%s' is not %s", segment, c),
+					})
+				}
+			}
+		}
+		return findings
+	})
+}
+
+// SchemaNameCasing returns a rule reporting named schemas, in
+// components/schemas or 2.0 definitions, that do not follow the convention
+func SchemaNameCasing(c Case) Rule {
+	return NewRule("schema-name-casing", SeverityWarning, func(doc unified.Document) []Finding {
+		var findings []Finding
+		for name := range doc.GetComponents().GetSchemas() {
+			if !c.Matches(name) {
+				findings = append(findings, Finding{
+					Pointer: componentPointer(doc, "schemas", name),
+					Message: fmt.Sprintf("schema name '%s' is not %s", name, c),
+				})
+			}
+		}
+		return findings
+	})
+}
+
+// PropertyCasing returns a rule reporting schema property names that do not
+// follow the convention, in named and inline schemas alike
+func PropertyCasing(c Case) Rule {
+	return NewRule("property-casing", SeverityWarning, func(doc unified.Document) []Finding {
+		var findings []Finding
+		walkProperties(documentJSON(doc), "", "", func(ptr, name string) {
+			if !c.Matches(name) {
+				findings = append(findings, Finding{
+					Pointer: ptr,
+					Message: fmt.Sprintf("property '%s' is not %s", name, c),
+				})
+			}
+		})
+		return findings
+	})
+}
+
+// valueKeys hold example and default values rather than schemas, so their
+// properties are not schema properties
+var valueKeys = map[string]bool{
+	"example": true, "examples": true, "default": true, "enum": true, "const": true,
+}
+
+// namedMaps hold objects by name, where value keys such as default are names
+var namedMaps = map[string]bool{
+	"paths": true, "webhooks": true, "responses": true, "schemas": true, "definitions": true,
+	"parameters": true, "requestBodies": true, "headers": true, "securitySchemes": true,
+	"links": true, "callbacks": true, "pathItems": true, "mediaTypes": true, "content": true,
+	"encoding": true, "$defs": true, "patternProperties": true, "dependentSchemas": true,
+}
+
+// walkProperties calls fn with the pointer and name of every schema property
+// under node, the value of the parent key. No other OpenAPI object has a
+// properties field, so every properties object outside of example values and
+// extensions is a schema's.
+func walkProperties(node any, ptr, parent string, fn func(ptr, name string)) {
+	switch v := node.(type) {
+	case []any:
+		for i, item := range v {
+			walkProperties(item, fmt.Sprintf("%s/%d", ptr, i), "", fn)
+		}
+	case map[string]any:
+		for key, value := range v {
+			if strings.HasPrefix(key, "x-") || (valueKeys[key] && !namedMaps[parent]) {
+				continue
+			}
+			child := ptr + Pointer(key)
+			if properties, ok := value.(map[string]any); ok && key == "properties" && !namedMaps[parent] {
+				for name, schema := range properties {
+					fn(child+Pointer(name), name)
+					walkProperties(schema, child+Pointer(name), "", fn)
+				}
+				continue
+			}
+			walkProperties(value, child, key, fn)
+		}
+	}
+}
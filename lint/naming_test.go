@@ -0,0 +1,124 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package lint
+
+import "testing"
+
+func TestCaseMatches(t *testing.T) {
+	tests := []struct {
+		c    Case
+		name string
+		want bool
+	}{
+		{CamelCase, "getPetById", true},
+		{CamelCase, "GetPetById", false},
+		{CamelCase, "get_pet", false},
+		{PascalCase, "PetOwner", true},
+		{PascalCase, "petOwner", false},
+		{SnakeCase, "pet_owner", true},
+		{SnakeCase, "pet__owner", false},
+		{KebabCase, "pet-owners", true},
+		{KebabCase, "petOwners", false},
+		{Case("unknown"), "Any_Thing", true},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Matches(tt.name); got != tt.want {
+			t.Errorf("%s.Matches(%q) = %v, want %v", tt.c, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNamingRules(t *testing.T) {
+	docs := map[string]string{
+		"3.1": `{
+			"openapi": "3.1.0",
+			"info": {"title": "T", "version": "1"},
+			"paths": {
+				"/pet-owners/{ownerId}/petList.json": {
+					"get": {
+						"operationId": "list_pets",
+						"responses": {
+							"200": {"description": "OK", "content": {"application/json": {
+								"schema": {"type": "object", "properties": {"next_page": {"type": "string"}}},
+								"example": {"Not_A_Property": 1}
+							}}},
+							"default": {"description": "Error", "content": {"application/json": {
+								"schema": {"type": "object", "properties": {"errorCode": {"type": "integer"}}}
+							}}}
+						}
+					}
+				}
+			},
+			"components": {
+				"schemas": {
+					"petOwner": {
+						"type": "object",
+						"properties": {
+							"firstName": {"type": "string"},
+							"home_address": {"type": "object", "properties": {"ZipCode": {"type": "string"}}},
+							"properties": {"type": "string", "default": {"Ignored_Value": true}}
+						}
+					}
+				}
+			}
+		}`,
+		"2.0": `{
+			"swagger": "2.0",
+			"info": {"title": "T", "version": "1"},
+			"paths": {
+				"/pet-owners/{ownerId}/petList.json": {
+					"get": {
+						"operationId": "list_pets",
+						"responses": {
+							"200": {"description": "OK", "schema": {"type": "object", "properties": {"next_page": {"type": "string"}}}},
+							"default": {"description": "Error", "schema": {"type": "object", "properties": {"errorCode": {"type": "integer"}}}}
+						}
+					}
+				}
+			},
+			"definitions": {
+				"petOwner": {
+					"type": "object",
+					"properties": {
+						"firstName": {"type": "string"},
+						"home_address": {"type": "object", "properties": {"ZipCode": {"type": "string"}}},
+						"properties": {"type": "string", "default": {"Ignored_Value": true}}
+					}
+				}
+			}
+		}`,
+	}
+
+	wants := map[string]string{
+		"3.1": "[warning] operation-id-casing: /paths/~1pet-owners~1{ownerId}~1petList.json/get/operationId: operationId 'list_pets' is not camelCase; " +
+			"[warning] path-segment-casing: /paths/~1pet-owners~1{ownerId}~1petList.json: path segment 'petList' is not kebab-case; " +
+			"[warning] schema-name-casing: /components/schemas/petOwner: schema name 'petOwner' is not PascalCase; " +
+			"[warning] property-casing: /components/schemas/petOwner/properties/home_address: property 'home_address' is not camelCase; " +
+			"[warning] property-casing: /components/schemas/petOwner/properties/home_address/properties/ZipCode: property 'ZipCode' is not camelCase; " +
+			"[warning] property-casing: /paths/~1pet-owners~1{ownerId}~1petList.json/get/responses/200/content/application~1json/schema/properties/next_page: property 'next_page' is not camelCase",
+		"2.0": "[warning] operation-id-casing: /paths/~1pet-owners~1{ownerId}~1petList.json/get/operationId: operationId 'list_pets' is not camelCase; " +
+			"[warning] path-segment-casing: /paths/~1pet-owners~1{ownerId}~1petList.json: path segment 'petList' is not kebab-case; " +
+			"[warning] schema-name-casing: /definitions/petOwner: schema name 'petOwner' is not PascalCase; " +
+			"[warning] property-casing: /definitions/petOwner/properties/home_address: property 'home_address' is not camelCase; " +
+			"[warning] property-casing: /definitions/petOwner/properties/home_address/properties/ZipCode: property 'ZipCode' is not camelCase; " +
+			"[warning] property-casing: /paths/~1pet-owners~1{ownerId}~1petList.json/get/responses/200/schema/properties/next_page: property 'next_page' is not camelCase",
+	}
+
+	linter, err := New(
+		OperationIDCasing(CamelCase),
+		PathSegmentCasing(KebabCase),
+		SchemaNameCasing(PascalCase),
+		PropertyCasing(CamelCase),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create linter: %v", err)
+	}
+	for version, data := range docs {
+		report := linter.Lint(lintDocument(t, data))
+		if got := report.String(); got != wants[version] {
+			t.Errorf("%s: unexpected report:\n%s\nwant:\n%s", version, got, wants[version])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// ErrorResponses returns a rule reporting operations that only describe
+// successful responses: no 4xx or 5xx status code, range or default response
+func ErrorResponses() Rule {
+	return NewRule("error-responses", SeverityWarning, func(doc unified.Document) []Finding {
+		return operationFindings(doc, func(op unified.Operation) []string {
+			responses := op.GetResponses()
+			if !responses.GetDefault().IsNil() {
+				return nil
+			}
+			for code := range responses.GetStatusCodes() {
+				if strings.HasPrefix(code, "4") || strings.HasPrefix(code, "5") {
+					return nil
+				}
+			}
+			return []string{"operation has no error responses"}
+		})
+	})
+}
+
+// DefaultResponse returns a rule reporting operations without a default
+// response
+func DefaultResponse() Rule {
+	return NewRule("default-response", SeverityWarning, func(doc unified.Document) []Finding {
+		return operationFindings(doc, func(op unified.Operation) []string {
+			if !op.GetResponses().GetDefault().IsNil() {
+				return nil
+			}
+			return []string{"operation has no default response"}
+		})
+	})
+}
+
+// AuthResponses returns a rule reporting secured operations that do not
+// describe a 401 or a 403 response. Operations are secured by their own
+// security requirements, or else by the document ones; a 4XX range covers both.
+func AuthResponses() Rule {
+	return NewRule("auth-responses", SeverityWarning, func(doc unified.Document) []Finding {
+		global := doc.GetGlobalSecurity()
+		return operationFindings(doc, func(op unified.Operation) []string {
+			requirements := op.GetSecurity()
+			if requirements == nil {
+				requirements = global
+			}
+			if !secured(requirements) {
+				return nil
+			}
+			codes := op.GetResponses().GetStatusCodes()
+			if _, ok := codes["4XX"]; ok {
+				return nil
+			}
+			var messages []string
+			for _, code := range []string{"401", "403"} {
+				if _, ok := codes[code]; !ok {
+					messages = append(messages, fmt.Sprintf("secured operation has no %s response", code))
+				}
+			}
+			return messages
+		})
+	})
+}
+
+// secured reports whether the requirements ask for at least one scheme
+func secured(requirements []unified.SecurityRequirement) bool {
+	for _, req := range requirements {
+		if len(req) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// operationFindings reports the messages of check for every operation, at its
+// responses
+func operationFindings(doc unified.Document, check func(op unified.Operation) []string) []Finding {
+	var findings []Finding
+	for path, item := range doc.GetPaths() {
+		if item == nil || item.HasRef() {
+			continue
+		}
+		for method, op := range item.GetAllOperations() {
+			for _, msg := range check(op) {
+				findings = append(findings, Finding{Pointer: operationPointer(path, method, "responses"), Message: msg})
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package lint
+
+import "testing"
+
+func TestResponseRules(t *testing.T) {
+	docs := map[string]string{
+		"2.0": `{
+			"swagger": "2.0",
+			"info": {"title": "T", "version": "1"},
+			"securityDefinitions": {"key": {"type": "apiKey", "name": "key", "in": "header"}},
+			"security": [{"key": []}],
+			"paths": {
+				"/pets": {
+					"get": {"responses": {"200": {"description": "OK"}}},
+					"post": {"security": [], "responses": {"201": {"description": "Created"}, "400": {"description": "Bad"}}}
+				},
+				"/pets/{id}": {
+					"get": {"responses": {"200": {"description": "OK"}, "401": {"description": "Unauthorized"}, "default": {"description": "Error"}}}
+				}
+			}
+		}`,
+		"3.1": `{
+			"openapi": "3.1.0",
+			"info": {"title": "T", "version": "1"},
+			"components": {"securitySchemes": {"key": {"type": "apiKey", "name": "key", "in": "header"}}},
+			"security": [{"key": []}],
+			"paths": {
+				"/pets": {
+					"get": {"responses": {"200": {"description": "OK"}}},
+					"post": {"security": [], "responses": {"201": {"description": "Created"}, "400": {"description": "Bad"}}}
+				},
+				"/pets/{id}": {
+					"get": {"responses": {"200": {"description": "OK"}, "401": {"description": "Unauthorized"}, "default": {"description": "Error"}}}
+				}
+			}
+		}`,
+	}
+
+	linter, err := New(ErrorResponses(), DefaultResponse(), AuthResponses())
+	if err != nil {
+		t.Fatalf("Failed to create linter: %v", err)
+	}
+	want := "[warning] error-responses: /paths/~1pets/get/responses: operation has no error responses; " +
+		"[warning] default-response: /paths/~1pets/get/responses: operation has no default response; " +
+		"[warning] default-response: /paths/~1pets/post/responses: operation has no default response; " +
+		"[warning] auth-responses: /paths/~1pets/get/responses: secured operation has no 401 response; " +
+		"[warning] auth-responses: /paths/~1pets/get/responses: secured operation has no 403 response; " +
+		"[warning] auth-responses: /paths/~1pets~1{id}/get/responses: secured operation has no 403 response"
+	for version, data := range docs {
+		if got := linter.Lint(lintDocument(t, data)).String(); got != want {
+			t.Errorf("%s: unexpected report:\n%s\nwant:\n%s", version, got, want)
+		}
+	}
+}
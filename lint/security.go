@@ -0,0 +1,152 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// SecurityRules returns the security audit rules
+func SecurityRules() []Rule {
+	return []Rule{
+		APIKeyInQuery(),
+		HTTPSServers(),
+		MutatingOperationSecurity(),
+		OAuth2ImplicitFlow(),
+		GlobalSecurity(),
+	}
+}
+
+// APIKeyInQuery returns a rule reporting apiKey security schemes sent in the
+// query string, where they end up in server and proxy logs
+func APIKeyInQuery() Rule {
+	return NewRule("api-key-in-query", SeverityWarning, func(doc unified.Document) []Finding {
+		var findings []Finding
+		for name, scheme := range doc.GetSecuritySchemes() {
+			if scheme != nil && scheme.GetType() == "apiKey" && scheme.GetIn() == "query" {
+				findings = append(findings, Finding{
+					Pointer: componentPointer(doc, "securitySchemes", name, "in"),
+					Message: fmt.Sprintf("API key '%s' is sent in the query string", name),
+				})
+			}
+		}
+		return findings
+	})
+}
+
+// HTTPSServers returns a rule reporting server URLs, at the document, path and
+// operation levels, and 2.0 schemes that do not use https
+func HTTPSServers() Rule {
+	return NewRule("https-servers", SeverityError, func(doc unified.Document) []Finding {
+		root := documentJSON(doc)
+		var findings []Finding
+		schemes, _ := root["schemes"].([]any)
+		for i, scheme := range schemes {
+			if s, ok := scheme.(string); ok && strings.EqualFold(s, "http") {
+				findings = append(findings, Finding{
+					Pointer: Pointer("schemes", strconv.Itoa(i)),
+					Message: "scheme 'http' is not https",
+				})
+			}
+		}
+		check := func(node any, tokens ...string) {
+			object, _ := node.(map[string]any)
+			servers, _ := object["servers"].([]any)
+			for i, server := range servers {
+				s, _ := server.(map[string]any)
+				if url, ok := s["url"].(string); ok && strings.HasPrefix(strings.ToLower(url), "http://") {
+					findings = append(findings, Finding{
+						Pointer: Pointer(append(tokens, "servers", strconv.Itoa(i), "url")...),
+						Message: fmt.Sprintf("server URL '%s' is not https", url),
+					})
+				}
+			}
+		}
+		check(root)
+		paths, _ := root["paths"].(map[string]any)
+		for path, item := range paths {
+			check(item, "paths", path)
+			operations, _ := item.(map[string]any)
+			for method, op := range operations {
+				if fixedMethods[method] {
+					check(op, "paths", path, method)
+				}
+			}
+		}
+		return findings
+	})
+}
+
+// mutatingMethods change server state
+var mutatingMethods = map[string]bool{"post": true, "put": true, "patch": true, "delete": true}
+
+// MutatingOperationSecurity returns a rule reporting post, put, patch and
+// delete operations that anyone can call: neither they nor the document
+// require a security scheme
+func MutatingOperationSecurity() Rule {
+	return NewRule("mutating-operation-security", SeverityWarning, func(doc unified.Document) []Finding {
+		global := doc.GetGlobalSecurity()
+		var findings []Finding
+		for path, item := range doc.GetPaths() {
+			if item == nil || item.HasRef() {
+				continue
+			}
+			for method, op := range item.GetAllOperations() {
+				if !mutatingMethods[method] {
+					continue
+				}
+				requirements := op.GetSecurity()
+				if requirements == nil {
+					requirements = global
+				}
+				if !secured(requirements) {
+					findings = append(findings, Finding{
+						Pointer: operationPointer(path, method),
+						Message: fmt.Sprintf("%s operation has no security requirement", strings.ToUpper(method)),
+					})
+				}
+			}
+		}
+		return findings
+	})
+}
+
+// OAuth2ImplicitFlow returns a rule reporting oauth2 security schemes with an
+// implicit flow, which OAuth 2.0 Security Best Current Practice discourages
+func OAuth2ImplicitFlow() Rule {
+	return NewRule("oauth2-implicit-flow", SeverityWarning, func(doc unified.Document) []Finding {
+		var findings []Finding
+		for name, scheme := range doc.GetSecuritySchemes() {
+			if scheme == nil || scheme.GetType() != "oauth2" || scheme.GetFlow() != "implicit" {
+				continue
+			}
+			ptr := componentPointer(doc, "securitySchemes", name, "flows", "implicit")
+			if strings.HasPrefix(doc.Version(), "2.") {
+				ptr = componentPointer(doc, "securitySchemes", name, "flow")
+			}
+			findings = append(findings, Finding{
+				Pointer: ptr,
+				Message: fmt.Sprintf("OAuth2 scheme '%s' uses the implicit flow", name),
+			})
+		}
+		return findings
+	})
+}
+
+// GlobalSecurity returns a rule reporting documents without a top-level
+// security requirement, which leaves every operation open unless it declares
+// its own
+func GlobalSecurity() Rule {
+	return NewRule("global-security", SeverityWarning, func(doc unified.Document) []Finding {
+		if secured(doc.GetGlobalSecurity()) {
+			return nil
+		}
+		return []Finding{{Pointer: Pointer("security"), Message: "document has no global security requirement"}}
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package lint
+
+import "testing"
+
+func TestSecurityRules(t *testing.T) {
+	tests := []struct {
+		version string
+		data    string
+		want    string
+	}{
+		{
+			"2.0",
+			`{
+				"swagger": "2.0",
+				"info": {"title": "T", "version": "1"},
+				"schemes": ["https", "http"],
+				"securityDefinitions": {
+					"key": {"type": "apiKey", "name": "key", "in": "query"},
+					"oauth": {"type": "oauth2", "flow": "implicit", "authorizationUrl": "https://example.com/auth", "scopes": {}}
+				},
+				"paths": {
+					"/pets": {
+						"get": {"responses": {"200": {"description": "OK"}}},
+						"post": {"responses": {"201": {"description": "Created"}}},
+						"delete": {"security": [{"oauth": []}], "responses": {"204": {"description": "Deleted"}}}
+					}
+				}
+			}`,
+			"[warning] api-key-in-query: /securityDefinitions/key/in: API key 'key' is sent in the query string; " +
+				"[error] https-servers: /schemes/1: scheme 'http' is not https; " +
+				"[warning] mutating-operation-security: /paths/~1pets/post: POST operation has no security requirement; " +
+				"[warning] oauth2-implicit-flow: /securityDefinitions/oauth/flow: OAuth2 scheme 'oauth' uses the implicit flow; " +
+				"[warning] global-security: /security: document has no global security requirement",
+		},
+		{
+			"3.1",
+			`{
+				"openapi": "3.1.0",
+				"info": {"title": "T", "version": "1"},
+				"servers": [{"url": "https://api.example.com"}, {"url": "HTTP://staging.example.com"}],
+				"security": [{"bearer": []}],
+				"components": {
+					"securitySchemes": {
+						"bearer": {"type": "http", "scheme": "bearer"},
+						"oauth": {"type": "oauth2", "flows": {"implicit": {"authorizationUrl": "https://example.com/auth", "scopes": {}}}}
+					}
+				},
+				"paths": {
+					"/pets": {
+						"servers": [{"url": "http://pets.example.com"}],
+						"get": {"security": [], "responses": {"200": {"description": "OK"}}},
+						"put": {"security": [{}], "servers": [{"url": "http://put.example.com"}], "responses": {"200": {"description": "OK"}}},
+						"patch": {"responses": {"200": {"description": "OK"}}}
+					}
+				}
+			}`,
+			"[error] https-servers: /paths/~1pets/put/servers/0/url: server URL 'http://put.example.com' is not https; " +
+				"[error] https-servers: /paths/~1pets/servers/0/url: server URL 'http://pets.example.com' is not https; " +
+				"[error] https-servers: /servers/1/url: server URL 'HTTP://staging.example.com' is not https; " +
+				"[warning] mutating-operation-security: /paths/~1pets/put: PUT operation has no security requirement; " +
+				"[warning] oauth2-implicit-flow: /components/securitySchemes/oauth/flows/implicit: OAuth2 scheme 'oauth' uses the implicit flow",
+		},
+	}
+
+	linter, err := New(SecurityRules()...)
+	if err != nil {
+		t.Fatalf("Failed to create linter: %v", err)
+	}
+	for _, tt := range tests {
+		if got := linter.Lint(lintDocument(t, tt.data)).String(); got != tt.want {
+			t.Errorf("%s: unexpected report:\n%s\nwant:\n%s", tt.version, got, tt.want)
+		}
+	}
+}
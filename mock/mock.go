@@ -0,0 +1,400 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package mock serves the responses an OpenAPI document describes, so that
+// clients can be built before the API exists.
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/fake"
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+	"github.com/genelet/oas/validate"
+)
+
+// Options configures a mock Server
+type Options struct {
+	Router router.Options
+	// SkipValidation serves requests without validating them
+	SkipValidation bool
+	// Seed seeds the data generated for responses without examples
+	Seed uint64
+}
+
+// Server is an http.Handler answering the requests of the operations of a
+// document.
+//
+// It picks the response asked for by a Prefer: code=404 header, or else the
+// first success response, or else the default one, and its media type by
+// the Accept header. The body is the example asked for by Prefer:
+// example=name, or else the first named example, the example of the media
+// type, the example of its schema, or else data generated from the schema.
+//
+// Requests that do not match the document are answered with an
+// application/problem+json body: 404 or 405 when no operation matches, 415
+// for a request body of an unsupported media type, 400 for other invalid
+// requests and 406 when no media type of the response is acceptable. A
+// named example whose reference cannot be resolved is answered with 500.
+type Server struct {
+	doc    unified.Document
+	router *router.Router
+	fake   *fake.Generator
+	opts   Options
+}
+
+// New returns a mock server for a document
+func New(doc unified.Document, opts Options) (*Server, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to mock")
+	}
+	resolved, err := unified.Resolve(doc)
+	if err != nil {
+		return nil, err
+	}
+	r, err := router.New(resolved, opts.Router)
+	if err != nil {
+		return nil, err
+	}
+	g := fake.New(fake.Options{Seed: opts.Seed, Examples: true, Direction: validate.DirectionResponse})
+	return &Server{doc: resolved, router: r, fake: g, opts: opts}, nil
+}
+
+// problem is an RFC 9457 problem detail
+type problem struct {
+	Title  string           `json:"title"`
+	Status int              `json:"status"`
+	Detail string           `json:"detail,omitempty"`
+	Errors []validate.Error `json:"errors,omitempty"`
+}
+
+// writeProblem answers a request the server cannot serve
+func writeProblem(w http.ResponseWriter, status int, err error) {
+	p := problem{Title: http.StatusText(status), Status: status, Detail: err.Error()}
+	var found validate.Errors
+	if errors.As(err, &found) {
+		p.Errors = found
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(p)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	m, err := s.router.Find(req)
+	var notAllowed *router.MethodNotAllowedError
+	switch {
+	case errors.As(err, &notAllowed):
+		w.Header().Set("Allow", strings.Join(notAllowed.Allowed, ", "))
+		writeProblem(w, http.StatusMethodNotAllowed, err)
+		return
+	case err != nil:
+		writeProblem(w, http.StatusNotFound, err)
+		return
+	}
+	if !s.opts.SkipValidation {
+		if err := validate.Request(req, m); err != nil {
+			status := http.StatusBadRequest
+			var found validate.Errors
+			if errors.As(err, &found) && slices.ContainsFunc(found, func(e validate.Error) bool { return e.Name == "Content-Type" }) {
+				status = http.StatusUnsupportedMediaType
+			}
+			writeProblem(w, status, err)
+			return
+		}
+	}
+
+	prefer := preferences(req.Header.Values("Prefer"))
+	status, resp, err := response(m.Operation.GetResponses(), prefer["code"])
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, err)
+		return
+	}
+	content := resp.GetContent()
+	if len(content) == 0 {
+		s.writeHeaders(w, resp)
+		w.WriteHeader(status)
+		return
+	}
+	mediaType, ok := negotiate(req.Header.Get("Accept"), slices.Collect(maps.Keys(content)))
+	if !ok {
+		writeProblem(w, http.StatusNotAcceptable, fmt.Errorf("no media type of the response is acceptable: %s", req.Header.Get("Accept")))
+		return
+	}
+	value, err := s.example(content[mediaType], prefer["example"])
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errUnresolvedExample) {
+			status = http.StatusInternalServerError
+		}
+		writeProblem(w, status, err)
+		return
+	}
+	body, err := encode(mediaType, value)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.writeHeaders(w, resp)
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// preferences parses the preferences of Prefer headers, such as code=404
+func preferences(headers []string) map[string]string {
+	prefer := make(map[string]string)
+	for _, header := range headers {
+		for _, token := range strings.FieldsFunc(header, func(r rune) bool { return r == ',' || r == ';' }) {
+			key, value, _ := strings.Cut(strings.TrimSpace(token), "=")
+			prefer[strings.ToLower(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return prefer
+}
+
+// response picks the response with the code asked for, or the first success
+// response, or the default one, or the first response. A range such as 2XX
+// answers with its first code.
+func response(responses unified.Responses, code string) (int, unified.Response, error) {
+	if responses == nil {
+		return http.StatusOK, unified.NilResponse{}, nil
+	}
+	if code != "" {
+		status, err := strconv.Atoi(code)
+		if err != nil || status < 100 || status > 599 {
+			return 0, nil, fmt.Errorf("invalid status %q preferred", code)
+		}
+		resp := responses.GetByStatus(status)
+		if resp == nil || resp.IsNil() {
+			return 0, nil, fmt.Errorf("the operation has no response for status %d", status)
+		}
+		return status, resp, nil
+	}
+	codes := responses.GetStatusCodes()
+	keys := slices.Sorted(maps.Keys(codes))
+	for _, key := range keys {
+		if strings.HasPrefix(key, "2") {
+			return statusOf(key), codes[key], nil
+		}
+	}
+	if resp := responses.GetDefault(); resp != nil && !resp.IsNil() {
+		return http.StatusOK, resp, nil
+	}
+	if len(keys) > 0 {
+		return statusOf(keys[0]), codes[keys[0]], nil
+	}
+	return http.StatusOK, unified.NilResponse{}, nil
+}
+
+// statusOf returns the status of a response key, the first of a range
+func statusOf(key string) int {
+	status, err := strconv.Atoi(strings.NewReplacer("X", "0", "x", "0").Replace(key))
+	if err != nil {
+		return http.StatusOK
+	}
+	return status
+}
+
+// negotiate picks the available media type the Accept header prefers, JSON
+// first on ties and without Accept
+func negotiate(accept string, available []string) (string, bool) {
+	slices.SortFunc(available, func(a, b string) int {
+		if ja, jb := validate.IsJSON(a), validate.IsJSON(b); ja != jb {
+			if ja {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	if strings.TrimSpace(accept) == "" {
+		return available[0], true
+	}
+	best, bestQ := "", 0.0
+	for _, mediaType := range available {
+		t, _, err := mime.ParseMediaType(mediaType)
+		if err != nil {
+			t = mediaType
+		}
+		t = strings.ToLower(t)
+		// The most specific range of the media type decides its quality
+		q, specificity := 0.0, -1
+		for _, part := range strings.Split(accept, ",") {
+			r, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil {
+				continue
+			}
+			n := rangeSpecificity(r, t)
+			if n <= specificity {
+				continue
+			}
+			rq := 1.0
+			if v, ok := params["q"]; ok {
+				if rq, err = strconv.ParseFloat(v, 64); err != nil {
+					continue
+				}
+			}
+			q, specificity = rq, n
+		}
+		if q > bestQ {
+			best, bestQ = mediaType, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// rangeSpecificity returns how specifically a media range of an Accept
+// header matches a media type: 2 for the type itself, 1 for type/*, 0 for
+// */* and -1 when it does not match
+func rangeSpecificity(mediaRange, mediaType string) int {
+	switch {
+	case mediaRange == mediaType:
+		return 2
+	case mediaRange == "*/*":
+		return 0
+	}
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+		return 1
+	}
+	return -1
+}
+
+// errUnresolvedExample is returned for a named example whose reference
+// does not lead to an example of the components
+var errUnresolvedExample = errors.New("unresolved example reference")
+
+// maxExampleRefs bounds the references followed through a chain of examples
+const maxExampleRefs = 64
+
+// resolveExample follows the references of a named example to the examples
+// of the components
+func (s *Server) resolveExample(name string, ex unified.Example) (unified.Example, error) {
+	for depth := 0; ex.HasRef(); depth++ {
+		ref := ex.GetRef()
+		escaped, ok := strings.CutPrefix(ref, "#/components/examples/")
+		if !ok || depth == maxExampleRefs {
+			return nil, fmt.Errorf("%w %s for example %q", errUnresolvedExample, ref, name)
+		}
+		component, err := url.PathUnescape(escaped)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s for example %q", errUnresolvedExample, ref, name)
+		}
+		component = strings.NewReplacer("~1", "/", "~0", "~").Replace(component)
+		next := s.doc.GetComponents().GetExamples()[component]
+		if next == nil {
+			return nil, fmt.Errorf("%w %s for example %q", errUnresolvedExample, ref, name)
+		}
+		ex = next
+	}
+	return ex, nil
+}
+
+// example returns the value to answer with for a media type
+func (s *Server) example(mt unified.MediaType, name string) (any, error) {
+	if mt == nil {
+		return nil, nil
+	}
+	examples := mt.GetExamples()
+	if name != "" {
+		ex, ok := examples[name]
+		if !ok || ex == nil {
+			return nil, fmt.Errorf("the response has no example %q", name)
+		}
+		ex, err := s.resolveExample(name, ex)
+		if err != nil {
+			return nil, err
+		}
+		return ex.GetValue(), nil
+	}
+	for _, key := range slices.Sorted(maps.Keys(examples)) {
+		if examples[key] == nil {
+			continue
+		}
+		ex, err := s.resolveExample(key, examples[key])
+		if err != nil {
+			return nil, err
+		}
+		if ex.GetValue() != nil {
+			return ex.GetValue(), nil
+		}
+	}
+	if ex := mt.GetExample(); ex != nil {
+		return ex, nil
+	}
+	schema := mt.GetSchema()
+	if schema == nil || schema.IsNil() {
+		return nil, nil
+	}
+	if ex := schema.GetExample(); ex != nil {
+		return ex, nil
+	}
+	return s.fake.Generate(schema)
+}
+
+// encode writes a value for a media type: JSON for JSON types, and strings
+// as they are for the others
+func encode(mediaType string, value any) ([]byte, error) {
+	if s, ok := value.(string); ok && !validate.IsJSON(mediaType) {
+		return []byte(s), nil
+	}
+	if value == nil && !validate.IsJSON(mediaType) {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+// writeHeaders sets the headers of a response that have an example, a
+// default or are required
+func (s *Server) writeHeaders(w http.ResponseWriter, resp unified.Response) {
+	for name, header := range resp.GetHeaders() {
+		if header == nil || strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		schema := header.GetSchema()
+		if schema == nil || schema.IsNil() {
+			continue
+		}
+		value := schema.GetExample()
+		if value == nil {
+			value = schema.GetDefault()
+		}
+		if value == nil && header.GetRequired() {
+			value, _ = s.fake.Generate(schema)
+		}
+		if value == nil {
+			continue
+		}
+		w.Header().Set(name, headerValue(value))
+	}
+}
+
+// headerValue serializes a header in the simple style
+func headerValue(value any) string {
+	switch x := value.(type) {
+	case []any:
+		parts := make([]string, len(x))
+		for i, item := range x {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		var parts []string
+		for _, key := range slices.Sorted(maps.Keys(x)) {
+			parts = append(parts, key, fmt.Sprint(x[key]))
+		}
+		return strings.Join(parts, ",")
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+)
+
+const pets = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Pets", "version": "1"},
+	"paths": {
+		"/pets": {
+			"get": {
+				"parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 100}}],
+				"responses": {
+					"200": {
+						"description": "OK",
+						"headers": {"X-Total": {"schema": {"type": "integer", "example": 2}}},
+						"content": {
+							"application/json": {
+								"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}},
+								"examples": {
+									"one": {"value": [{"id": 1, "name": "Rex"}]},
+									"two": {"value": [{"id": 1, "name": "Rex"}, {"id": 2, "name": "Tom"}]}
+								}
+							},
+							"text/csv": {"example": "id,name\n1,Rex\n"}
+						}
+					},
+					"404": {"description": "Not found", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}}
+				}
+			},
+			"post": {
+				"requestBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+				"responses": {"201": {"description": "Created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}
+			}
+		},
+		"/pets/{petId}": {
+			"delete": {"responses": {"204": {"description": "Deleted"}}}
+		}
+	},
+	"components": {"schemas": {
+		"Pet": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"id": {"type": "integer", "minimum": 1, "readOnly": true},
+				"name": {"type": "string", "example": "Rex"},
+				"born": {"type": "string", "format": "date"},
+				"secret": {"type": "string", "writeOnly": true}
+			}
+		},
+		"Error": {"type": "object", "properties": {"code": {"type": "integer", "default": 404}, "message": {"type": "string"}}}
+	}}
+}`
+
+func TestServer(t *testing.T) {
+	doc, err := unified.NewDocument([]byte(pets))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	s, err := New(doc, Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tests := []struct {
+		name, method, url, body string
+		header                  map[string]string
+		status                  int
+		contentType, want       string
+	}{
+		{"first named example", "GET", "/pets", "", nil, 200, "application/json", `[{"id":1,"name":"Rex"}]`},
+		{"named example", "GET", "/pets", "", map[string]string{"Prefer": "example=two"}, 200, "application/json",
+			`[{"id":1,"name":"Rex"},{"id":2,"name":"Tom"}]`},
+		{"accept", "GET", "/pets", "", map[string]string{"Accept": "application/json;q=0.5, text/*"}, 200, "text/csv", "id,name\n1,Rex\n"},
+		{"generated", "GET", "/pets", "", map[string]string{"Prefer": "code=404"}, 404, "application/json", ""},
+		{"schema", "POST", "/pets", `{"name": "Tom"}`, map[string]string{"Content-Type": "application/json"}, 201, "application/json", ""},
+		{"no content", "DELETE", "/pets/1", "", nil, 204, "", ""},
+		{"invalid", "GET", "/pets?limit=1000", "", nil, 400, "application/problem+json", ""},
+		{"unsupported", "POST", "/pets", "name=Tom", map[string]string{"Content-Type": "application/x-www-form-urlencoded"}, 415, "application/problem+json", ""},
+		{"not acceptable", "GET", "/pets", "", map[string]string{"Accept": "application/xml"}, 406, "application/problem+json", ""},
+		{"unknown status", "GET", "/pets", "", map[string]string{"Prefer": "code=500"}, 400, "application/problem+json", ""},
+		{"not found", "GET", "/toys", "", nil, 404, "application/problem+json", ""},
+		{"not allowed", "PUT", "/pets", "", nil, 405, "application/problem+json", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+		for k, v := range tt.header {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		if w.Code != tt.status || w.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("%s: got %d %s, want %d %s: %s", tt.name, w.Code, w.Header().Get("Content-Type"), tt.status, tt.contentType, w.Body.String())
+			continue
+		}
+		if tt.want != "" && strings.TrimSpace(w.Body.String()) != strings.TrimSpace(tt.want) {
+			t.Errorf("%s: body = %s, want %s", tt.name, w.Body.String(), tt.want)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/pets?limit=1000", nil))
+	var p problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil || len(p.Errors) != 1 || p.Errors[0].Name != "limit" {
+		t.Errorf("Unexpected problem %s: %v", w.Body.String(), err)
+	}
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/pets", nil))
+	if w.Header().Get("X-Total") != "2" {
+		t.Errorf("Expected the example of the X-Total header, got %q", w.Header().Get("X-Total"))
+	}
+
+	// Generated bodies take the examples and defaults of the schema, and are
+	// the same for the same seed
+	post := func(s *Server) map[string]any {
+		req := httptest.NewRequest("POST", "/pets", strings.NewReader(`{"name": "Tom"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		var pet map[string]any
+		if err := json.Unmarshal(w.Body.Bytes(), &pet); err != nil {
+			t.Fatalf("Unexpected body %s: %v", w.Body.String(), err)
+		}
+		return pet
+	}
+	first, _ := New(doc, Options{Seed: 7})
+	second, _ := New(doc, Options{Seed: 7})
+	pet := post(first)
+	if pet["name"] != "Rex" || pet["id"].(float64) < 1 || pet["secret"] != nil {
+		t.Errorf("Unexpected generated pet %v", pet)
+	}
+	if again := post(second); fmt.Sprint(again) != fmt.Sprint(pet) {
+		t.Errorf("Expected the same pet for the same seed, got %v and %v", again, pet)
+	}
+
+	s, err = New(doc, Options{SkipValidation: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/pets?limit=1000", nil))
+	if w.Code != 200 {
+		t.Errorf("Expected the request not to be validated, got %d", w.Code)
+	}
+}
+
+func TestServerExampleRefs(t *testing.T) {
+	doc, err := unified.NewDocument([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {
+			"/pets": {"get": {"responses": {"200": {"description": "OK", "content": {"application/json": {
+				"schema": {"type": "object", "properties": {"name": {"type": "string"}}},
+				"examples": {"cat": {"$ref": "#/components/examples/Cat"}, "dog": {"$ref": "#/components/examples/Dog"}}
+			}}}}}},
+			"/toys": {"get": {"responses": {"200": {"description": "OK", "content": {"application/json": {
+				"examples": {"ball": {"$ref": "#/components/examples/Ball"}}
+			}}}}}}
+		},
+		"components": {"examples": {
+			"Cat": {"value": {"name": "Tom"}},
+			"Dog": {"$ref": "#/components/examples/Rex"},
+			"Rex": {"value": {"name": "Rex"}}
+		}}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	s, err := New(doc, Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tests := []struct {
+		name, url, prefer string
+		status            int
+		want              string
+	}{
+		{"first named example", "/pets", "", 200, `{"name":"Tom"}`},
+		{"named example", "/pets", "example=cat", 200, `{"name":"Tom"}`},
+		{"chain", "/pets", "example=dog", 200, `{"name":"Rex"}`},
+		{"missing component", "/toys", "", 500, ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.url, nil)
+		if tt.prefer != "" {
+			req.Header.Set("Prefer", tt.prefer)
+		}
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		if w.Code != tt.status {
+			t.Errorf("%s: got %d, want %d: %s", tt.name, w.Code, tt.status, w.Body.String())
+			continue
+		}
+		if tt.want != "" && strings.TrimSpace(w.Body.String()) != tt.want {
+			t.Errorf("%s: body = %s, want %s", tt.name, w.Body.String(), tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import "reflect"
+
+// copier makes deep copies of the values of this package. Pointers already
+// copied map to their copies, so shared values stay shared and cycles built in
+// Go code end.
+type copier struct {
+	seen map[any]reflect.Value
+}
+
+// clone returns a deep copy of v, a value of this package
+func clone(v any) any {
+	c := &copier{seen: make(map[any]reflect.Value)}
+	return c.copy(reflect.ValueOf(v)).Interface()
+}
+
+func (c *copier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		if copied, ok := c.seen[v.Interface()]; ok {
+			return copied
+		}
+		copied := reflect.New(v.Type().Elem())
+		c.seen[v.Interface()] = copied
+		copied.Elem().Set(c.copy(v.Elem()))
+		return copied
+	case reflect.Struct:
+		// Setting the whole struct carries the unexported fields, such as the
+		// reference markers; the exported ones are then copied deeply
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				copied.Field(i).Set(c.copy(v.Field(i)))
+			}
+		}
+		if s, ok := copied.Addr().Interface().(*Schema); ok && s.boolValue != nil {
+			value := *s.boolValue
+			s.boolValue = &value
+		}
+		return copied
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			copied.SetMapIndex(iter.Key(), c.copy(iter.Value()))
+		}
+		return copied
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			copied.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return copied
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(c.copy(v.Elem()))
+		return copied
+	}
+	return v
+}
+
+// Clone returns a deep copy of the info
+func (i *Info) Clone() *Info {
+	return clone(i).(*Info)
+}
+
+// Clone returns a deep copy of the contact
+func (c *Contact) Clone() *Contact {
+	return clone(c).(*Contact)
+}
+
+// Clone returns a deep copy of the license
+func (l *License) Clone() *License {
+	return clone(l).(*License)
+}
+
+// Clone returns a deep copy of the external documentation
+func (e *ExternalDocumentation) Clone() *ExternalDocumentation {
+	return clone(e).(*ExternalDocumentation)
+}
+
+// Clone returns a deep copy of the operation
+func (o *Operation) Clone() *Operation {
+	return clone(o).(*Operation)
+}
+
+// Clone returns a deep copy of the parameter
+func (p *Parameter) Clone() *Parameter {
+	return clone(p).(*Parameter)
+}
+
+// Clone returns a deep copy of the items
+func (i *Items) Clone() *Items {
+	return clone(i).(*Items)
+}
+
+// Clone returns a deep copy of the header
+func (h *Header) Clone() *Header {
+	return clone(h).(*Header)
+}
+
+// Clone returns a deep copy of the paths
+func (p *Paths) Clone() *Paths {
+	return clone(p).(*Paths)
+}
+
+// Clone returns a deep copy of the path item
+func (pi *PathItem) Clone() *PathItem {
+	return clone(pi).(*PathItem)
+}
+
+// Clone returns a deep copy of the responses
+func (r *Responses) Clone() *Responses {
+	return clone(r).(*Responses)
+}
+
+// Clone returns a deep copy of the response
+func (r *Response) Clone() *Response {
+	return clone(r).(*Response)
+}
+
+// Clone returns a deep copy of the schema, a boolean schema included
+func (s *Schema) Clone() *Schema {
+	return clone(s).(*Schema)
+}
+
+// Clone returns a deep copy of the XML object
+func (x *XML) Clone() *XML {
+	return clone(x).(*XML)
+}
+
+// Clone returns a deep copy of the security scheme
+func (ss *SecurityScheme) Clone() *SecurityScheme {
+	return clone(ss).(*SecurityScheme)
+}
+
+// Clone returns a deep copy of the security requirement
+func (r SecurityRequirement) Clone() SecurityRequirement {
+	return clone(r).(SecurityRequirement)
+}
+
+// Clone returns a deep copy of the document, with its extensions and boolean
+// schemas, without a JSON round trip
+func (s *Swagger) Clone() *Swagger {
+	return clone(s).(*Swagger)
+}
+
+// Clone returns a deep copy of the tag
+func (t *Tag) Clone() *Tag {
+	return clone(t).(*Tag)
+}
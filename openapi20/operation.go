@@ -23,6 +23,15 @@ type Operation struct {
 	Extensions   map[string]any         `json:"-"`
 }
 
+// NewOperation creates an operation with the operationId and no responses
+// yet, ready for Responses.StatusCode to be filled in
+func NewOperation(id string) *Operation {
+	return &Operation{
+		OperationID: id,
+		Responses:   &Responses{StatusCode: make(map[string]*Response)},
+	}
+}
+
 var operationKnownFields = []string{
 	"tags", "summary", "description", "externalDocs", "operationId",
 	"consumes", "produces", "parameters", "responses", "schemes",
@@ -48,5 +57,17 @@ func (o *Operation) UnmarshalJSON(data []byte) error {
 
 func (o Operation) MarshalJSON() ([]byte, error) {
 	alias := operationAlias(o)
-	return marshalWithExtensions(&alias, o.Extensions)
+	data, err := marshalWithExtensions(&alias, o.Extensions)
+	if err != nil || o.Security == nil || len(o.Security) > 0 {
+		return data, err
+	}
+
+	// An empty security list removes the document security, so unlike a
+	// missing one it is kept
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	m["security"] = json.RawMessage("[]")
+	return json.Marshal(m)
 }
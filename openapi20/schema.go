@@ -128,6 +128,23 @@ func NewBooleanSchema(value bool) *Schema {
 	return &Schema{boolValue: &value}
 }
 
+// NewObjectSchema creates an object schema with the properties, of which the
+// named ones are required
+func NewObjectSchema(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// NewStringSchema creates a string schema with the format, such as
+// "date-time", or none when format is empty
+func NewStringSchema(format string) *Schema {
+	return &Schema{Type: "string", Format: format}
+}
+
+// NewArraySchema creates an array schema of the items
+func NewArraySchema(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
 // IsReference checks if this schema is actually a reference ($ref)
 func (s *Schema) IsReference() bool {
 	return s != nil && s.Ref != ""
@@ -6,6 +6,7 @@ package openapi20
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -557,3 +558,82 @@ func TestSecuritySchemeTypes(t *testing.T) {
 		t.Error("Expected oauth2 scheme")
 	}
 }
+
+func TestConstructors(t *testing.T) {
+	op := NewOperation("listEvents")
+	resp := NewResponse("Events")
+	resp.Schema = NewArraySchema(NewObjectSchema(map[string]*Schema{
+		"at":   NewStringSchema("date-time"),
+		"name": NewStringSchema(""),
+	}, "at"))
+	op.Responses.StatusCode["200"] = resp
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var got, want map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	expected := `{"operationId": "listEvents", "responses": {"200": {"description": "Events", "schema": {"type": "array", "items": {"type": "object", "required": ["at"], "properties": {"at": {"type": "string", "format": "date-time"}, "name": {"type": "string"}}}}}}}`
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestClone(t *testing.T) {
+	node := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	node.Properties["next"] = node
+	shared := NewStringSchema("")
+	doc := &Swagger{
+		Swagger:     "2.0",
+		Info:        &Info{Title: "Clone API", Version: "1.0.0"},
+		Paths:       &Paths{Paths: map[string]*PathItem{}},
+		Definitions: map[string]*Schema{"Node": node, "A": shared, "B": shared},
+		Security:    []SecurityRequirement{{"key": {}}},
+	}
+
+	cloned := doc.Clone()
+	copied := cloned.Definitions["Node"]
+	if copied == node || copied.Properties["next"] != copied {
+		t.Error("Expected the cycle to be copied onto the clone")
+	}
+	if cloned.Definitions["A"] != cloned.Definitions["B"] || cloned.Definitions["A"] == shared {
+		t.Error("Expected the shared schema to stay shared in the clone")
+	}
+	cloned.Security[0]["key"] = append(cloned.Security[0]["key"], "read")
+	if len(doc.Security[0]["key"]) != 0 {
+		t.Error("Expected changes to the cloned requirement to leave the original alone")
+	}
+	if !reflect.DeepEqual(doc.Info, cloned.Info) {
+		t.Errorf("Expected equal info, got %+v", cloned.Info)
+	}
+}
+
+func TestResponsesHelpers(t *testing.T) {
+	responses := &Responses{}
+	responses.Set("404", NewResponse("Not found"))
+	responses.Set("200", NewResponse("OK"))
+	responses.Set("default", NewResponse("Error"))
+
+	if resp := responses.GetByStatus(404); resp == nil || resp.Description != "Not found" {
+		t.Errorf("Expected the 404 response, got %v", resp)
+	}
+	if resp := responses.GetByStatus(409); resp != responses.Default {
+		t.Errorf("Expected the default response for 409, got %v", resp)
+	}
+	if responses.EnsureDefault().Description != "Error" {
+		t.Error("Expected EnsureDefault to keep the default response")
+	}
+	var codes []string
+	for code := range responses.All() {
+		codes = append(codes, code)
+	}
+	if !reflect.DeepEqual(codes, []string{"200", "404", "default"}) {
+		t.Errorf("Expected codes in order, got %v", codes)
+	}
+}
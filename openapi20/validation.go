@@ -0,0 +1,648 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Severity classifies a validation finding. The zero value is SeverityError,
+// so findings are errors unless a rule is downgraded in ValidationOptions.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// Rule identifiers reported in ValidationError.Rule. They can be disabled or
+// downgraded through ValidationOptions.
+const (
+	RuleDocument              = "document"                // document-level structure
+	RuleRequired              = "required"                // required fields
+	RuleVersion               = "version"                 // swagger version string
+	RuleServerURL             = "server-url"              // host, basePath and schemes
+	RuleMediaType             = "media-type"              // media type syntax (RFC 6838)
+	RulePathFormat            = "path-format"             // path keys starting with /
+	RulePathParameters        = "path-parameters"         // path template placeholders matching path parameters
+	RuleResponses             = "responses"               // non-empty responses object
+	RuleStatusCode            = "status-code"             // response status code keys
+	RuleParameterIn           = "parameter-in"            // parameter locations
+	RulePathParameterRequired = "path-parameter-required" // path parameters marked required
+	RuleParameterType         = "parameter-type"          // types of non-body parameters, items and headers
+	RuleCollectionFormat      = "collection-format"       // collectionFormat values
+	RuleDuplicateParameter    = "duplicate-parameter"     // parameters unique by name and location
+	RuleBodyParameter         = "body-parameter"          // a single body parameter, not mixed with formData
+	RuleSchemaType            = "schema-type"             // schema type names
+	RuleArrayItems            = "array-items"             // items on array schemas and parameters
+	RuleSchemaRange           = "schema-range"            // min/max constraint ordering
+	RulePattern               = "pattern"                 // regular expression syntax
+	RuleRequiredProperty      = "required-property"       // required properties defined in properties
+	RuleRequiredReadOnly      = "required-read-only"      // readOnly properties left out of required (warning)
+	RuleDiscriminator         = "discriminator"           // discriminator property
+	RuleRef                   = "ref"                     // references that resolve to the expected kind
+	RuleSecuritySchemeType    = "security-scheme-type"    // security scheme types
+	RuleSecurityScheme        = "security-scheme"         // type-specific security scheme fields
+	RuleOAuthFlow             = "oauth-flow"              // OAuth flow fields
+	RuleSecurityRequirement   = "security-requirement"    // security requirements naming declared schemes
+	RuleOAuthScope            = "oauth-scope"             // oauth2 scopes declared by the scheme
+	RuleUndeclaredTag         = "undeclared-tag"          // operation tags declared in the top-level tags (warning)
+	RuleUnusedTag             = "unused-tag"              // declared tags used by an operation (warning, see UnusedTags)
+	RuleUnusedComponent       = "unused-component"        // definitions, parameters, responses and security definitions referenced from the document (warning, see UnusedComponents)
+	RuleFormat                = "format"                  // formats known to the registry (warning, see RegisterFormat)
+)
+
+// defaultSeverities lists the rules that are not reported as SeverityError
+// unless overridden
+var defaultSeverities = map[string]Severity{
+	RuleRequiredReadOnly: SeverityWarning,
+	RuleUndeclaredTag:    SeverityWarning,
+	RuleUnusedTag:        SeverityWarning,
+	RuleUnusedComponent:  SeverityWarning,
+	RuleFormat:           SeverityWarning,
+}
+
+// ValidationError represents a validation finding with path context
+type ValidationError struct {
+	Path     string
+	Message  string
+	Rule     string
+	Severity Severity
+	// Line and Column locate the finding in ValidationOptions.Source,
+	// starting at 1. They are zero when no source is given.
+	Line   int
+	Column int
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationOptions configures ValidateWithOptions
+type ValidationOptions struct {
+	// Disabled lists rules that are not checked
+	Disabled []string
+	// Severities overrides the severity of rules, which is SeverityError by
+	// default and SeverityWarning for the rules documented as warnings
+	Severities map[string]Severity
+	// ResolveRefs checks that every local $ref resolves and points at a
+	// component of the expected kind. References to other documents are not loaded.
+	ResolveRefs bool
+	// Source is the JSON text the document was parsed from. When set, findings
+	// carry the line and column of the value they are about, or of the closest
+	// enclosing value for missing fields.
+	Source []byte
+	// UnusedTags reports declared tags that no operation uses
+	UnusedTags bool
+	// UnusedComponents reports definitions, parameters, responses and security
+	// definitions that are never used, see UnusedComponents
+	UnusedComponents bool
+	// Workers bounds the goroutines of ValidateContext. Zero uses
+	// runtime.GOMAXPROCS.
+	Workers int
+}
+
+// ValidationResult contains all validation findings.
+// Errors holds every finding whatever its severity; only findings with
+// SeverityError make the result invalid.
+type ValidationResult struct {
+	Errors []ValidationError
+	opts   *ValidationOptions
+	root   any              // the document as generic JSON when references are resolved
+	source *sourceMap       // the positions of the values of ValidationOptions.Source
+	tasks  *validationTasks // runs the parts of ValidateContext concurrently
+	// definitions resolves schema references
+	definitions map[string]*Schema
+}
+
+// Valid returns true if there are no findings with SeverityError
+func (r *ValidationResult) Valid() bool {
+	return len(r.BySeverity(SeverityError)) == 0
+}
+
+// BySeverity returns the findings with the given severity
+func (r *ValidationResult) BySeverity(severity Severity) []ValidationError {
+	var found []ValidationError
+	for _, e := range r.Errors {
+		if e.Severity == severity {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// Warnings returns the findings with SeverityWarning
+func (r *ValidationResult) Warnings() []ValidationError {
+	return r.BySeverity(SeverityWarning)
+}
+
+// Error returns a combined message of the findings with SeverityError
+func (r *ValidationResult) Error() string {
+	var msgs []string
+	for _, e := range r.BySeverity(SeverityError) {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (r *ValidationResult) addError(rule, path, message string) {
+	severity, ok := defaultSeverities[rule]
+	if !ok {
+		severity = SeverityError
+	}
+	if r.opts != nil {
+		for _, disabled := range r.opts.Disabled {
+			if disabled == rule {
+				return
+			}
+		}
+		if s, ok := r.opts.Severities[rule]; ok {
+			severity = s
+		}
+	}
+	e := ValidationError{Path: path, Message: message, Rule: rule, Severity: severity}
+	if r.source != nil {
+		e.Line, e.Column = r.source.position(e.Pointer())
+	}
+	r.Errors = append(r.Errors, e)
+}
+
+// Validate validates the Swagger document against the Swagger 2.0 specification
+func (s *Swagger) Validate() *ValidationResult {
+	return s.ValidateWithOptions(nil)
+}
+
+// ValidateWithOptions validates the Swagger document like Validate, skipping
+// disabled rules and reporting rules with the configured severities.
+// A nil opts checks every rule at its default severity.
+func (s *Swagger) ValidateWithOptions(opts *ValidationOptions) *ValidationResult {
+	result := &ValidationResult{opts: opts}
+	s.validateDocument(result)
+	return result
+}
+
+// validateDocument runs every check of the document into result
+func (s *Swagger) validateDocument(result *ValidationResult) {
+	if s == nil {
+		result.addError(RuleDocument, "", "Swagger document is nil")
+		return
+	}
+	if result.opts != nil && result.opts.Source != nil {
+		result.source = newSourceMap(result.opts.Source)
+	}
+	if result.opts != nil && result.opts.ResolveRefs {
+		result.loadRoot(s)
+	}
+	result.definitions = s.Definitions
+
+	// Required: swagger
+	if s.Swagger == "" {
+		result.addError(RuleRequired, "swagger", "required field is missing")
+	} else if s.Swagger != "2.0" {
+		result.addError(RuleVersion, "swagger", fmt.Sprintf("expected 2.0, got %s", s.Swagger))
+	}
+
+	// Required: info
+	if s.Info == nil {
+		result.addError(RuleRequired, "info", "required field is missing")
+	} else {
+		s.Info.validate("info", result)
+	}
+
+	// Optional: host, basePath and schemes
+	s.validateServer(result)
+
+	// Optional: consumes and produces
+	validateMediaTypes("consumes", s.Consumes, result)
+	validateMediaTypes("produces", s.Produces, result)
+
+	// Required: paths
+	if s.Paths == nil {
+		result.addError(RuleRequired, "paths", "required field is missing")
+	} else {
+		s.Paths.validate("paths", result)
+	}
+	s.validatePathParameters(result)
+	s.validateOperationParameters(result)
+	s.validateSecurityRequirements(result)
+	s.validateTags(result)
+	s.validateUnusedComponents(result)
+
+	// Optional: definitions
+	for name, schema := range s.Definitions {
+		if schema != nil {
+			result.run(func(result *ValidationResult) {
+				schema.validate(fmt.Sprintf("definitions[%s]", name), result)
+			})
+		}
+	}
+
+	// Optional: parameters
+	for name, param := range s.Parameters {
+		if param != nil {
+			param.validate(fmt.Sprintf("parameters[%s]", name), result)
+		}
+	}
+
+	// Optional: responses
+	for name, resp := range s.Responses {
+		if resp != nil {
+			resp.validate(fmt.Sprintf("responses[%s]", name), result)
+		}
+	}
+
+	// Optional: securityDefinitions
+	for name, scheme := range s.SecurityDefinitions {
+		if scheme != nil {
+			scheme.validate(fmt.Sprintf("securityDefinitions[%s]", name), result)
+		}
+	}
+
+	// Optional: tags
+	for i, tag := range s.Tags {
+		if tag != nil && tag.Name == "" {
+			result.addError(RuleRequired, fmt.Sprintf("tags[%d].name", i), "required field is missing")
+		}
+	}
+}
+
+func (i *Info) validate(path string, result *ValidationResult) {
+	// Required: title
+	if i.Title == "" {
+		result.addError(RuleRequired, path+".title", "required field is missing")
+	}
+	// Required: version
+	if i.Version == "" {
+		result.addError(RuleRequired, path+".version", "required field is missing")
+	}
+	// Optional: license
+	if i.License != nil && i.License.Name == "" {
+		result.addError(RuleRequired, path+".license.name", "required field is missing")
+	}
+}
+
+func (p *Paths) validate(path string, result *ValidationResult) {
+	for pathPattern, pathItem := range p.Paths {
+		// Path must start with /
+		if !strings.HasPrefix(pathPattern, "/") {
+			result.addError(RulePathFormat, path+"."+pathPattern, "path must start with /")
+		}
+		if pathItem != nil {
+			result.run(func(result *ValidationResult) {
+				pathItem.validate(fmt.Sprintf("%s[%s]", path, pathPattern), result)
+			})
+		}
+	}
+}
+
+func (p *PathItem) validate(path string, result *ValidationResult) {
+	// 2.0 has no path item definitions, so a reference is only checked to resolve
+	if p.Ref != "" {
+		result.checkRef(path, p.Ref, "")
+	}
+
+	// Validate parameters at path level
+	for i, param := range p.Parameters {
+		if param != nil {
+			param.validate(fmt.Sprintf("%s.parameters[%d]", path, i), result)
+		}
+	}
+
+	// Validate operations
+	if p.Get != nil {
+		p.Get.validate(path+".get", result)
+	}
+	if p.Put != nil {
+		p.Put.validate(path+".put", result)
+	}
+	if p.Post != nil {
+		p.Post.validate(path+".post", result)
+	}
+	if p.Delete != nil {
+		p.Delete.validate(path+".delete", result)
+	}
+	if p.Options != nil {
+		p.Options.validate(path+".options", result)
+	}
+	if p.Head != nil {
+		p.Head.validate(path+".head", result)
+	}
+	if p.Patch != nil {
+		p.Patch.validate(path+".patch", result)
+	}
+}
+
+func (o *Operation) validate(path string, result *ValidationResult) {
+	validateMediaTypes(path+".consumes", o.Consumes, result)
+	validateMediaTypes(path+".produces", o.Produces, result)
+	validateSchemes(path+".schemes", o.Schemes, result)
+
+	// Validate parameters
+	for i, param := range o.Parameters {
+		if param != nil {
+			param.validate(fmt.Sprintf("%s.parameters[%d]", path, i), result)
+		}
+	}
+
+	// Required: responses
+	if o.Responses == nil {
+		result.addError(RuleRequired, path+".responses", "required field is missing")
+	} else {
+		o.Responses.validate(path+".responses", result)
+	}
+}
+
+func (r *Responses) validate(path string, result *ValidationResult) {
+	// Must have at least one response
+	if r.Default == nil && len(r.StatusCode) == 0 {
+		result.addError(RuleResponses, path, "must contain at least one response")
+	}
+
+	// Validate status codes; 2.0 has no ranges like 2XX
+	statusCodePattern := regexp.MustCompile(`^[1-5][0-9][0-9]$`)
+	for code, resp := range r.StatusCode {
+		if !statusCodePattern.MatchString(code) {
+			result.addError(RuleStatusCode, path+"."+code, "invalid status code, must be a 3-digit code")
+		}
+		if resp != nil {
+			resp.validate(path+"."+code, result)
+		}
+	}
+	if r.Default != nil {
+		r.Default.validate(path+".default", result)
+	}
+}
+
+func (r *Response) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if r.Ref != "" {
+		result.checkRef(path, r.Ref, "responses")
+		return
+	}
+
+	// Required: description
+	if r.Description == "" {
+		result.addError(RuleRequired, path+".description", "required field is missing")
+	}
+
+	// Validate schema
+	if r.Schema != nil {
+		r.Schema.validate(path+".schema", result)
+	}
+
+	// Validate headers
+	for name, header := range r.Headers {
+		if header != nil {
+			header.validate(fmt.Sprintf("%s.headers[%s]", path, name), result)
+		}
+	}
+}
+
+func (h *Header) validate(path string, result *ValidationResult) {
+	validateSimpleType(path, h.Type, h.Format, h.Items, false, result)
+	validateCollectionFormat(path, h.CollectionFormat, false, result)
+}
+
+func (p *Parameter) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if p.Ref != "" {
+		result.checkRef(path, p.Ref, "parameters")
+		return
+	}
+
+	// Required: name and in
+	if p.Name == "" {
+		result.addError(RuleRequired, path+".name", "required field is missing")
+	}
+	switch p.In {
+	case "":
+		result.addError(RuleRequired, path+".in", "required field is missing")
+	case "query", "header", "path", "formData", "body":
+	default:
+		result.addError(RuleParameterIn, path+".in", fmt.Sprintf("must be one of: query, header, path, formData, body; got %s", p.In))
+	}
+
+	// Path parameters must be required
+	if p.In == "path" && !p.Required {
+		result.addError(RulePathParameterRequired, path+".required", "path parameters must have required: true")
+	}
+
+	// Body parameters have a schema, the others a type
+	if p.In == "body" {
+		if p.Schema == nil {
+			result.addError(RuleRequired, path+".schema", "required field is missing")
+		} else {
+			p.Schema.validate(path+".schema", result)
+		}
+		return
+	}
+	validateSimpleType(path, p.Type, p.Format, p.Items, true, result)
+	if p.Type == "file" && p.In != "formData" {
+		result.addError(RuleParameterType, path+".type", "file parameters must be in formData")
+	}
+	validateCollectionFormat(path, p.CollectionFormat, p.In == "query" || p.In == "formData", result)
+	validateRanges(path, p.Minimum, p.Maximum, p.MinLength, p.MaxLength, p.MinItems, p.MaxItems, result)
+	validatePattern(path, p.Pattern, result)
+}
+
+// validateSimpleType checks the type, format and items of a non-body
+// parameter or a header; file is only allowed for parameters
+func validateSimpleType(path, typ, format string, items *Items, allowFile bool, result *ValidationResult) {
+	switch typ {
+	case "":
+		result.addError(RuleRequired, path+".type", "required field is missing")
+	case "string", "number", "integer", "boolean", "array":
+	case "file":
+		if !allowFile {
+			result.addError(RuleParameterType, path+".type", "file is only allowed for parameters")
+		}
+	default:
+		result.addError(RuleParameterType, path+".type", fmt.Sprintf("invalid type '%s'", typ))
+	}
+	result.checkFormat(path+".format", format)
+	if typ == "array" {
+		if items == nil {
+			result.addError(RuleArrayItems, path+".items", "array type must have items defined")
+		} else {
+			items.validate(path+".items", result)
+		}
+	}
+}
+
+func (i *Items) validate(path string, result *ValidationResult) {
+	validateSimpleType(path, i.Type, i.Format, i.Items, false, result)
+	validateCollectionFormat(path, i.CollectionFormat, false, result)
+	validateRanges(path, i.Minimum, i.Maximum, i.MinLength, i.MaxLength, i.MinItems, i.MaxItems, result)
+	validatePattern(path, i.Pattern, result)
+}
+
+// validateCollectionFormat checks a collectionFormat; multi is only allowed
+// for query and formData parameters
+func validateCollectionFormat(path, format string, allowMulti bool, result *ValidationResult) {
+	switch format {
+	case "", "csv", "ssv", "tsv", "pipes":
+	case "multi":
+		if !allowMulti {
+			result.addError(RuleCollectionFormat, path+".collectionFormat", "multi is only allowed for query and formData parameters")
+		}
+	default:
+		result.addError(RuleCollectionFormat, path+".collectionFormat", fmt.Sprintf("must be one of: csv, ssv, tsv, pipes, multi; got %s", format))
+	}
+}
+
+// validateRanges checks that minimums do not exceed maximums
+func validateRanges(path string, minimum, maximum *float64, minLength, maxLength, minItems, maxItems *int, result *ValidationResult) {
+	if minimum != nil && maximum != nil && *minimum > *maximum {
+		result.addError(RuleSchemaRange, path, "minimum cannot be greater than maximum")
+	}
+	if minLength != nil && maxLength != nil && *minLength > *maxLength {
+		result.addError(RuleSchemaRange, path, "minLength cannot be greater than maxLength")
+	}
+	if minItems != nil && maxItems != nil && *minItems > *maxItems {
+		result.addError(RuleSchemaRange, path, "minItems cannot be greater than maxItems")
+	}
+}
+
+// validatePattern checks that a pattern is a valid regular expression
+func validatePattern(path, pattern string, result *ValidationResult) {
+	if pattern == "" {
+		return
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		result.addError(RulePattern, path+".pattern", fmt.Sprintf("invalid regex pattern: %v", err))
+	}
+}
+
+func (s *Schema) validate(path string, result *ValidationResult) {
+	// Boolean schemas are always valid
+	if s.IsBooleanSchema() {
+		return
+	}
+
+	// References are checked when resolution is requested
+	if s.Ref != "" {
+		result.checkRef(path, s.Ref, "definitions")
+		return
+	}
+
+	// Validate type; file is allowed for response schemas
+	if s.Type != "" {
+		validTypes := map[string]bool{
+			"string": true, "number": true, "integer": true,
+			"boolean": true, "array": true, "object": true, "file": true,
+		}
+		if !validTypes[s.Type] {
+			result.addError(RuleSchemaType, path+".type", fmt.Sprintf("invalid type '%s'", s.Type))
+		}
+	}
+	result.checkFormat(path+".format", s.Format)
+
+	// Array type must have items
+	if s.Type == "array" && s.Items == nil {
+		result.addError(RuleArrayItems, path+".items", "array type must have items defined")
+	}
+
+	// Validate constraints
+	validateRanges(path, s.Minimum, s.Maximum, s.MinLength, s.MaxLength, s.MinItems, s.MaxItems, result)
+	if s.MinProperties != nil && s.MaxProperties != nil && *s.MinProperties > *s.MaxProperties {
+		result.addError(RuleSchemaRange, path, "minProperties cannot be greater than maxProperties")
+	}
+	validatePattern(path, s.Pattern, result)
+
+	// Validate required fields exist in properties
+	if len(s.Required) > 0 && len(s.Properties) > 0 {
+		for _, req := range s.Required {
+			prop, exists := s.Properties[req]
+			if !exists {
+				result.addError(RuleRequiredProperty, path+".required", fmt.Sprintf("required property '%s' not defined in properties", req))
+			} else if prop != nil && prop.ReadOnly {
+				// Clients cannot send readOnly properties, so they should not be required
+				result.addError(RuleRequiredReadOnly, path+".required", fmt.Sprintf("required property '%s' is readOnly", req))
+			}
+		}
+	}
+
+	// Validate discriminator
+	if s.Discriminator != "" {
+		s.validateDiscriminator(path, result)
+	}
+
+	// Validate nested schemas
+	if s.Items != nil {
+		s.Items.validate(path+".items", result)
+	}
+	for name, prop := range s.Properties {
+		if prop != nil {
+			prop.validate(fmt.Sprintf("%s.properties[%s]", path, name), result)
+		}
+	}
+	if s.AdditionalProperties != nil {
+		s.AdditionalProperties.validate(path+".additionalProperties", result)
+	}
+	for i, schema := range s.AllOf {
+		if schema != nil {
+			schema.validate(fmt.Sprintf("%s.allOf[%d]", path, i), result)
+		}
+	}
+}
+
+func (ss *SecurityScheme) validate(path string, result *ValidationResult) {
+	switch ss.Type {
+	case "":
+		result.addError(RuleRequired, path+".type", "required field is missing")
+	case "basic":
+	case "apiKey":
+		if ss.Name == "" {
+			result.addError(RuleSecurityScheme, path+".name", "required for apiKey type")
+		}
+		if ss.In == "" {
+			result.addError(RuleSecurityScheme, path+".in", "required for apiKey type")
+		} else if ss.In != "query" && ss.In != "header" {
+			result.addError(RuleSecurityScheme, path+".in", "must be one of: query, header")
+		}
+	case "oauth2":
+		ss.validateOAuth2(path, result)
+	default:
+		result.addError(RuleSecuritySchemeType, path+".type", fmt.Sprintf("must be one of: basic, apiKey, oauth2; got %s", ss.Type))
+	}
+}
+
+func (ss *SecurityScheme) validateOAuth2(path string, result *ValidationResult) {
+	switch ss.Flow {
+	case "":
+		result.addError(RuleOAuthFlow, path+".flow", "required for oauth2 type")
+	case "implicit", "password", "application", "accessCode":
+	default:
+		result.addError(RuleOAuthFlow, path+".flow", fmt.Sprintf("must be one of: implicit, password, application, accessCode; got %s", ss.Flow))
+	}
+	if (ss.Flow == "implicit" || ss.Flow == "accessCode") && ss.AuthorizationUrl == "" {
+		result.addError(RuleOAuthFlow, path+".authorizationUrl", fmt.Sprintf("required for %s flow", ss.Flow))
+	}
+	if (ss.Flow == "password" || ss.Flow == "application" || ss.Flow == "accessCode") && ss.TokenUrl == "" {
+		result.addError(RuleOAuthFlow, path+".tokenUrl", fmt.Sprintf("required for %s flow", ss.Flow))
+	}
+	if ss.Scopes == nil {
+		result.addError(RuleOAuthFlow, path+".scopes", "required for oauth2 type")
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// componentsPrefix starts the local references to reusable objects
+const componentsPrefix = "#/"
+
+// componentSections lists the top-level sections holding reusable objects
+var componentSections = []string{"definitions", "parameters", "responses", "securityDefinitions"}
+
+// componentRef names a reusable object by its section and name
+type componentRef struct {
+	kind string // definitions, parameters, responses or securityDefinitions
+	name string
+}
+
+func (c componentRef) String() string {
+	return componentsPrefix + escapePointer(c.kind) + "/" + escapePointer(c.name)
+}
+
+// UnusedComponents returns references to the definitions, parameters,
+// responses and security definitions that cannot be reached from the paths or
+// any other part of the document outside those sections, sorted, such as
+// "#/definitions/Pet". Objects only referenced by unused objects are unused as
+// well. Security definitions are used when a security requirement names them,
+// and schemas extending a used schema with a discriminator through allOf are used.
+func (s *Swagger) UnusedComponents() []string {
+	var unused []string
+	for _, c := range s.unusedComponents() {
+		unused = append(unused, c.String())
+	}
+	return unused
+}
+
+func (s *Swagger) unusedComponents() []componentRef {
+	if s == nil {
+		return nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil
+	}
+
+	u := &componentUsage{components: make(map[string]any), used: make(map[componentRef]bool)}
+	for _, section := range componentSections {
+		if value, ok := root[section]; ok {
+			u.components[section] = value
+			delete(root, section)
+		}
+	}
+	u.walk(root)
+	u.useSubschemas()
+
+	var unused []componentRef
+	for kind, section := range u.components {
+		entries, ok := section.(map[string]any)
+		if !ok {
+			continue
+		}
+		for name := range entries {
+			if c := (componentRef{kind, name}); !u.used[c] {
+				unused = append(unused, c)
+			}
+		}
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].kind != unused[j].kind {
+			return unused[i].kind < unused[j].kind
+		}
+		return unused[i].name < unused[j].name
+	})
+	return unused
+}
+
+// validateUnusedComponents reports, with UnusedComponents, the reusable
+// objects that are never used
+func (s *Swagger) validateUnusedComponents(result *ValidationResult) {
+	if result.opts == nil || !result.opts.UnusedComponents {
+		return
+	}
+	for _, c := range s.unusedComponents() {
+		result.addError(RuleUnusedComponent, fmt.Sprintf("%s[%s]", c.kind, c.name),
+			fmt.Sprintf("'%s' is never referenced", c.name))
+	}
+}
+
+// componentUsage collects the reusable objects reachable from a document as generic JSON
+type componentUsage struct {
+	components map[string]any
+	used       map[componentRef]bool
+}
+
+// use marks a reusable object as used and follows its references
+func (u *componentUsage) use(c componentRef) {
+	if u.used[c] {
+		return
+	}
+	section, _ := u.components[c.kind].(map[string]any)
+	value, ok := section[c.name]
+	if !ok {
+		return
+	}
+	u.used[c] = true
+	u.walk(value)
+}
+
+// useRef marks the reusable object a local reference points into
+func (u *componentUsage) useRef(ref string) {
+	if c, ok := parseComponentRef(ref); ok {
+		u.use(c)
+	}
+}
+
+// parseComponentRef returns the reusable object a local reference points into
+func parseComponentRef(ref string) (componentRef, bool) {
+	fragment, err := url.PathUnescape(ref)
+	if err != nil || !strings.HasPrefix(fragment, componentsPrefix) {
+		return componentRef{}, false
+	}
+	tokens := strings.SplitN(strings.TrimPrefix(fragment, componentsPrefix), "/", 3)
+	if len(tokens) < 2 || !slices.Contains(componentSections, tokens[0]) {
+		return componentRef{}, false
+	}
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	return componentRef{unescape.Replace(tokens[0]), unescape.Replace(tokens[1])}, true
+}
+
+func (u *componentUsage) walk(node any) {
+	switch v := node.(type) {
+	case []any:
+		for _, item := range v {
+			u.walk(item)
+		}
+	case map[string]any:
+		for key, value := range v {
+			switch key {
+			case "$ref":
+				if ref, ok := value.(string); ok {
+					u.useRef(ref)
+				}
+			case "security":
+				// Security requirements name their schemes
+				requirements, _ := value.([]any)
+				for _, req := range requirements {
+					if names, ok := req.(map[string]any); ok {
+						for name := range names {
+							u.use(componentRef{"securityDefinitions", name})
+						}
+					}
+				}
+			}
+			u.walk(value)
+		}
+	}
+}
+
+// useSubschemas marks the definitions that extend a used schema having a
+// discriminator, since the discriminator value selects them by name
+func (u *componentUsage) useSubschemas() {
+	schemas, _ := u.components["definitions"].(map[string]any)
+	for changed := true; changed; {
+		changed = false
+		for name, schema := range schemas {
+			c := componentRef{"definitions", name}
+			if u.used[c] || !u.extendsDiscriminator(schema) {
+				continue
+			}
+			u.use(c)
+			changed = true
+		}
+	}
+}
+
+// extendsDiscriminator reports whether a schema lists a used schema with a
+// discriminator in its allOf
+func (u *componentUsage) extendsDiscriminator(schema any) bool {
+	s, _ := schema.(map[string]any)
+	allOf, _ := s["allOf"].([]any)
+	for _, part := range allOf {
+		p, _ := part.(map[string]any)
+		ref, _ := p["$ref"].(string)
+		c, ok := parseComponentRef(ref)
+		if !ok || c.kind != "definitions" || !u.used[c] {
+			continue
+		}
+		schemas, _ := u.components["definitions"].(map[string]any)
+		parent, _ := schemas[c.name].(map[string]any)
+		if _, ok := parent["discriminator"]; ok {
+			return true
+		}
+	}
+	return false
+}
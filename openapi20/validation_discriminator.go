@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxRefDepth bounds the references followed while walking schemas
+const maxRefDepth = 64
+
+// propertyLookup is what is known about a property of a schema and its allOf
+type propertyLookup struct {
+	schema   *Schema
+	found    bool
+	required bool
+	unknown  bool // a reference could not be followed
+}
+
+// localSchema resolves a local reference to definitions
+func (r *ValidationResult) localSchema(ref string) *Schema {
+	const prefix = "#/definitions/"
+	if !strings.HasPrefix(ref, prefix) {
+		return nil
+	}
+	return r.definitions[strings.TrimPrefix(ref, prefix)]
+}
+
+// lookupProperty finds a property in s, its allOf members and the
+// definitions they reference
+func (r *ValidationResult) lookupProperty(s *Schema, name string, depth int) propertyLookup {
+	var l propertyLookup
+	if s == nil || depth == maxRefDepth {
+		return l
+	}
+	if s.Ref != "" {
+		target := r.localSchema(s.Ref)
+		if target == nil {
+			l.unknown = true
+			return l
+		}
+		return r.lookupProperty(target, name, depth+1)
+	}
+	if prop, ok := s.Properties[name]; ok {
+		l.schema, l.found = prop, true
+	}
+	for _, req := range s.Required {
+		if req == name {
+			l.required = true
+		}
+	}
+	for _, sub := range s.AllOf {
+		m := r.lookupProperty(sub, name, depth+1)
+		if m.found && !l.found {
+			l.schema, l.found = m.schema, true
+		}
+		l.required = l.required || m.required
+		l.unknown = l.unknown || m.unknown
+	}
+	return l
+}
+
+// validateDiscriminator checks that the discriminator names a required
+// string property of the schema
+func (s *Schema) validateDiscriminator(path string, result *ValidationResult) {
+	path += ".discriminator"
+	name := s.Discriminator
+	l := result.lookupProperty(s, name, 0)
+	switch {
+	case l.unknown:
+		return
+	case !l.found:
+		result.addError(RuleDiscriminator, path, fmt.Sprintf("property '%s' is not defined in the schema", name))
+		return
+	case !l.required:
+		result.addError(RuleDiscriminator, path, fmt.Sprintf("property '%s' must be required", name))
+	}
+	prop := l.schema
+	if prop != nil && prop.Ref != "" {
+		prop = result.localSchema(prop.Ref)
+	}
+	if prop != nil && prop.Type != "" && prop.Type != "string" {
+		result.addError(RuleDiscriminator, path, fmt.Sprintf("property '%s' must be a string; got %s", name, prop.Type))
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+)
+
+// mediaTypeNamePattern matches an RFC 6838 restricted-name
+var mediaTypeNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+
+// checkMediaType reports a media type string that is not a valid RFC 6838
+// type/subtype with optional parameters. Wildcards are accepted as a whole
+// type (*/*), a whole subtype (image/*) or a suffixed subtype (application/*+json).
+func (r *ValidationResult) checkMediaType(path, mediaType string) {
+	if msg := mediaTypeProblem(mediaType); msg != "" {
+		r.addError(RuleMediaType, path, fmt.Sprintf("invalid media type '%s': %s", mediaType, msg))
+	}
+}
+
+// validateMediaTypes checks a consumes or produces list
+func validateMediaTypes(path string, mediaTypes []string, result *ValidationResult) {
+	for i, mediaType := range mediaTypes {
+		result.checkMediaType(fmt.Sprintf("%s[%d]", path, i), mediaType)
+	}
+}
+
+// mediaTypeProblem describes what is wrong with a media type, or returns ""
+func mediaTypeProblem(mediaType string) string {
+	essence, params, hasParams := strings.Cut(mediaType, ";")
+	essence = strings.TrimSpace(essence)
+	typ, subtype, ok := strings.Cut(essence, "/")
+	if !ok {
+		return "expected type/subtype"
+	}
+	switch {
+	case typ == "*":
+		if subtype != "*" {
+			return "a wildcard type requires a wildcard subtype"
+		}
+	case !mediaTypeNamePattern.MatchString(typ):
+		return fmt.Sprintf("invalid type '%s'", typ)
+	case subtype == "*":
+	case strings.HasPrefix(subtype, "*+"):
+		if !mediaTypeNamePattern.MatchString(subtype[2:]) {
+			return fmt.Sprintf("invalid suffix '%s'", subtype[2:])
+		}
+	case !mediaTypeNamePattern.MatchString(subtype):
+		return fmt.Sprintf("invalid subtype '%s'", subtype)
+	}
+	if hasParams {
+		if _, _, err := mime.ParseMediaType("type/subtype;" + params); err != nil {
+			return "invalid parameters"
+		}
+	}
+	return ""
+}
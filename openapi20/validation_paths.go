@@ -0,0 +1,209 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pathTemplatePattern matches the {name} placeholders of a path template
+var pathTemplatePattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// pathOperation is an operation together with its field name in the path item
+type pathOperation struct {
+	field string
+	op    *Operation
+}
+
+// operations returns the operations of the path item in a fixed order
+func (p *PathItem) operations() []pathOperation {
+	var ops []pathOperation
+	for _, candidate := range []pathOperation{
+		{"get", p.Get}, {"put", p.Put}, {"post", p.Post}, {"delete", p.Delete},
+		{"options", p.Options}, {"head", p.Head}, {"patch", p.Patch},
+	} {
+		if candidate.op != nil {
+			ops = append(ops, candidate)
+		}
+	}
+	return ops
+}
+
+// eachOperation calls fn for every operation under paths,
+// with the path of the operation
+func (s *Swagger) eachOperation(fn func(path string, op *Operation)) {
+	if s.Paths != nil {
+		for template, item := range s.Paths.Paths {
+			if item == nil || item.Ref != "" {
+				continue
+			}
+			for _, po := range item.operations() {
+				fn(fmt.Sprintf("paths[%s].%s", template, po.field), po.op)
+			}
+		}
+	}
+}
+
+// resolveParameter follows a local parameter reference into the parameters section.
+// It returns nil when the reference cannot be followed.
+func (s *Swagger) resolveParameter(p *Parameter) *Parameter {
+	const prefix = "#/parameters/"
+	if p.Ref == "" {
+		return p
+	}
+	if !strings.HasPrefix(p.Ref, prefix) {
+		return nil
+	}
+	target := s.Parameters[strings.TrimPrefix(p.Ref, prefix)]
+	if target == nil || target.Ref != "" {
+		return nil
+	}
+	return target
+}
+
+// validatePathParameters checks that every placeholder of a path template is
+// declared as a path parameter of each operation, at path or operation level,
+// and that no path parameter is declared for a placeholder that does not exist
+func (s *Swagger) validatePathParameters(result *ValidationResult) {
+	if s.Paths == nil {
+		return
+	}
+	for template, item := range s.Paths.Paths {
+		if item == nil || item.Ref != "" {
+			continue
+		}
+		itemPath := fmt.Sprintf("paths[%s]", template)
+		placeholders := make(map[string]bool)
+		for _, m := range pathTemplatePattern.FindAllStringSubmatch(template, -1) {
+			placeholders[m[1]] = true
+		}
+
+		// Path-level parameters apply to every operation
+		declared := make(map[string]bool)
+		complete := true
+		for i, param := range item.Parameters {
+			if param == nil {
+				continue
+			}
+			resolved := s.resolveParameter(param)
+			if resolved == nil {
+				complete = false
+				continue
+			}
+			if resolved.In == "path" {
+				declared[resolved.Name] = true
+				if !placeholders[resolved.Name] {
+					result.addError(RulePathParameters, fmt.Sprintf("%s.parameters[%d]", itemPath, i),
+						fmt.Sprintf("path parameter '%s' does not match a placeholder in the path", resolved.Name))
+				}
+			}
+		}
+
+		for _, po := range item.operations() {
+			opPath := itemPath + "." + po.field
+			opDeclared := make(map[string]bool, len(declared))
+			for name := range declared {
+				opDeclared[name] = true
+			}
+			opComplete := complete
+			for i, param := range po.op.Parameters {
+				if param == nil {
+					continue
+				}
+				resolved := s.resolveParameter(param)
+				if resolved == nil {
+					opComplete = false
+					continue
+				}
+				if resolved.In == "path" {
+					opDeclared[resolved.Name] = true
+					if !placeholders[resolved.Name] {
+						result.addError(RulePathParameters, fmt.Sprintf("%s.parameters[%d]", opPath, i),
+							fmt.Sprintf("path parameter '%s' does not match a placeholder in the path", resolved.Name))
+					}
+				}
+			}
+			// Unresolved references may declare the missing parameters
+			if !opComplete {
+				continue
+			}
+			for _, m := range pathTemplatePattern.FindAllStringSubmatch(template, -1) {
+				if !opDeclared[m[1]] {
+					result.addError(RulePathParameters, opPath,
+						fmt.Sprintf("placeholder '{%s}' has no path parameter", m[1]))
+					opDeclared[m[1]] = true
+				}
+			}
+		}
+	}
+}
+
+// validateOperationParameters checks the parameters in effect for each
+// operation: parameters are unique by name and location within a list, and
+// an operation has at most one body parameter, which excludes formData ones.
+// Operation-level parameters override path-level ones of the same name and location.
+func (s *Swagger) validateOperationParameters(result *ValidationResult) {
+	if s.Paths == nil {
+		return
+	}
+	for template, item := range s.Paths.Paths {
+		if item == nil || item.Ref != "" {
+			continue
+		}
+		itemPath := fmt.Sprintf("paths[%s]", template)
+		inherited := s.uniqueParameters(itemPath, item.Parameters, result)
+
+		for _, po := range item.operations() {
+			opPath := itemPath + "." + po.field
+			effective := s.uniqueParameters(opPath, po.op.Parameters, result)
+			for key, param := range inherited {
+				if _, ok := effective[key]; !ok {
+					effective[key] = param
+				}
+			}
+
+			bodies, formData := 0, false
+			for _, param := range effective {
+				switch param.In {
+				case "body":
+					bodies++
+				case "formData":
+					formData = true
+				}
+			}
+			if bodies > 1 {
+				result.addError(RuleBodyParameter, opPath+".parameters", "operation must have at most one body parameter")
+			}
+			if bodies > 0 && formData {
+				result.addError(RuleBodyParameter, opPath+".parameters", "body and formData parameters cannot be used together")
+			}
+		}
+	}
+}
+
+// uniqueParameters resolves a parameter list keyed by location and name,
+// reporting parameters that appear more than once
+func (s *Swagger) uniqueParameters(path string, params []*Parameter, result *ValidationResult) map[string]*Parameter {
+	unique := make(map[string]*Parameter, len(params))
+	for i, param := range params {
+		if param == nil {
+			continue
+		}
+		resolved := s.resolveParameter(param)
+		if resolved == nil || resolved.Name == "" {
+			continue
+		}
+		key := resolved.In + ":" + resolved.Name
+		if _, ok := unique[key]; ok {
+			result.addError(RuleDuplicateParameter, fmt.Sprintf("%s.parameters[%d]", path, i),
+				fmt.Sprintf("duplicate %s parameter '%s'", resolved.In, resolved.Name))
+			continue
+		}
+		unique[key] = resolved
+	}
+	return unique
+}
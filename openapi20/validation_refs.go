@@ -0,0 +1,84 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// loadRoot keeps the document as generic JSON so that references can be
+// resolved as JSON pointers
+func (r *ValidationResult) loadRoot(s *Swagger) {
+	data, err := json.Marshal(s)
+	if err == nil {
+		err = json.Unmarshal(data, &r.root)
+	}
+	if err != nil {
+		r.addError(RuleRef, "", fmt.Sprintf("document could not be encoded to resolve references: %v", err))
+		r.root = nil
+	}
+}
+
+// sections are the root objects that hold reusable definitions
+var sections = map[string]bool{"definitions": true, "parameters": true, "responses": true}
+
+// checkRef reports a local reference that does not resolve, or that points
+// directly at a definition, parameter or response when another kind is expected. An empty kind only
+// checks that the reference resolves. References into other documents and
+// plain-name fragments are skipped. It does nothing unless references are resolved.
+func (r *ValidationResult) checkRef(path, ref, kind string) {
+	if r.root == nil || !strings.HasPrefix(ref, "#") {
+		return
+	}
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		r.addError(RuleRef, path+".$ref", fmt.Sprintf("invalid reference %s: %v", ref, err))
+		return
+	}
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		return
+	}
+
+	var tokens []string
+	if fragment != "" {
+		for _, token := range strings.Split(fragment[1:], "/") {
+			tokens = append(tokens, strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~"))
+		}
+	}
+	if kind != "" && len(tokens) == 2 && sections[tokens[0]] && tokens[0] != kind {
+		r.addError(RuleRef, path+".$ref", fmt.Sprintf("reference %s points at %s, expected %s", ref, tokens[0], kind))
+		return
+	}
+	if _, ok := resolvePointer(r.root, tokens); !ok {
+		r.addError(RuleRef, path+".$ref", fmt.Sprintf("reference %s does not resolve", ref))
+	}
+}
+
+// resolvePointer follows unescaped JSON pointer tokens through generic JSON
+func resolvePointer(node any, tokens []string) (any, bool) {
+	for _, token := range tokens {
+		switch v := node.(type) {
+		case map[string]any:
+			next, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			node = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			node = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
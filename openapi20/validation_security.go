@@ -0,0 +1,47 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import "fmt"
+
+// validateSecurityRequirements checks the document-level and operation-level
+// security requirements against securityDefinitions
+func (s *Swagger) validateSecurityRequirements(result *ValidationResult) {
+	s.validateSecurity("security", s.Security, result)
+	s.eachOperation(func(path string, op *Operation) {
+		s.validateSecurity(path+".security", op.Security, result)
+	})
+}
+
+// validateSecurity checks that each scheme named in the requirements is
+// declared, and that scopes are only listed for oauth2 schemes that declare them
+func (s *Swagger) validateSecurity(path string, requirements []SecurityRequirement, result *ValidationResult) {
+	for i, requirement := range requirements {
+		for name, scopes := range requirement {
+			reqPath := fmt.Sprintf("%s[%d][%s]", path, i, name)
+			scheme, declared := s.SecurityDefinitions[name]
+			if !declared {
+				result.addError(RuleSecurityRequirement, reqPath,
+					fmt.Sprintf("security scheme '%s' is not declared in securityDefinitions", name))
+				continue
+			}
+			if scheme == nil || len(scopes) == 0 {
+				continue
+			}
+			if scheme.Type != "" && scheme.Type != "oauth2" {
+				result.addError(RuleSecurityRequirement, reqPath,
+					fmt.Sprintf("scopes are only allowed for oauth2 schemes; '%s' is %s", name, scheme.Type))
+			}
+			if scheme.Type == "oauth2" {
+				for j, scope := range scopes {
+					if _, ok := scheme.Scopes[scope]; !ok {
+						result.addError(RuleOAuthScope, fmt.Sprintf("%s[%d]", reqPath, j),
+							fmt.Sprintf("scope '%s' is not declared by '%s'", scope, name))
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// validateServer checks the host, basePath and schemes that make up the
+// server url
+func (s *Swagger) validateServer(result *ValidationResult) {
+	if s.Host != "" {
+		switch {
+		case strings.Contains(s.Host, "://"):
+			result.addError(RuleServerURL, "host", "must not include a scheme")
+		case strings.ContainsAny(s.Host, "/?#"):
+			result.addError(RuleServerURL, "host", "must not include a path")
+		default:
+			u, err := url.Parse("//" + s.Host)
+			if err != nil || u.Host != s.Host || u.User != nil || strings.HasSuffix(s.Host, ":") {
+				result.addError(RuleServerURL, "host", "must be a host name or IP address, optionally followed by a port")
+			}
+		}
+	}
+
+	if s.BasePath != "" {
+		switch {
+		case !strings.HasPrefix(s.BasePath, "/"):
+			result.addError(RuleServerURL, "basePath", "must start with a leading slash")
+		case strings.ContainsAny(s.BasePath, "?#"):
+			result.addError(RuleServerURL, "basePath", "must not include a query or fragment")
+		case strings.ContainsAny(s.BasePath, "{}"):
+			result.addError(RuleServerURL, "basePath", "path templating is not supported")
+		}
+	}
+
+	validateSchemes("schemes", s.Schemes, result)
+}
+
+// validateSchemes checks a schemes list
+func validateSchemes(path string, schemes []string, result *ValidationResult) {
+	for i, scheme := range schemes {
+		switch scheme {
+		case "http", "https", "ws", "wss":
+		default:
+			result.addError(RuleServerURL, fmt.Sprintf("%s[%d]", path, i),
+				fmt.Sprintf("must be one of: http, https, ws, wss; got %s", scheme))
+		}
+	}
+}
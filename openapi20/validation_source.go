@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// sourceMap locates the values of a JSON text by their JSON pointers
+type sourceMap struct {
+	text    []byte
+	offsets map[string]int // JSON pointer to the offset of its value
+}
+
+// newSourceMap scans a JSON text. It returns nil when the text is not valid
+// JSON, in which case findings carry no position.
+func newSourceMap(text []byte) *sourceMap {
+	s := &sourceScanner{text: text, offsets: make(map[string]int)}
+	if err := s.value(""); err != nil {
+		return nil
+	}
+	s.skipSpace()
+	if s.pos != len(text) {
+		return nil
+	}
+	return &sourceMap{text: text, offsets: s.offsets}
+}
+
+// position returns the 1-based line and column of the value at the pointer.
+// Findings about a missing field are located at the closest enclosing value.
+func (m *sourceMap) position(ptr string) (line, column int) {
+	offset, ok := m.offsets[ptr]
+	for !ok && ptr != "" {
+		ptr = ptr[:strings.LastIndexByte(ptr, '/')]
+		offset, ok = m.offsets[ptr]
+	}
+	if !ok {
+		return 0, 0
+	}
+	before := m.text[:offset]
+	lineStart := strings.LastIndexByte(string(before), '\n') + 1
+	return strings.Count(string(before), "\n") + 1, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+var errSourceSyntax = errors.New("invalid JSON")
+
+// sourceScanner records the offset of every value of a JSON text
+type sourceScanner struct {
+	text    []byte
+	pos     int
+	offsets map[string]int
+}
+
+func (s *sourceScanner) skipSpace() {
+	for s.pos < len(s.text) {
+		switch s.text[s.pos] {
+		case ' ', '\t', '\n', '\r':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+// expect consumes the byte c after optional whitespace
+func (s *sourceScanner) expect(c byte) bool {
+	s.skipSpace()
+	if s.pos < len(s.text) && s.text[s.pos] == c {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+func (s *sourceScanner) value(ptr string) error {
+	s.skipSpace()
+	if s.pos >= len(s.text) {
+		return errSourceSyntax
+	}
+	s.offsets[ptr] = s.pos
+
+	switch s.text[s.pos] {
+	case '{':
+		s.pos++
+		if s.expect('}') {
+			return nil
+		}
+		for {
+			s.skipSpace()
+			key, err := s.str()
+			if err != nil {
+				return err
+			}
+			if !s.expect(':') {
+				return errSourceSyntax
+			}
+			if err := s.value(ptr + "/" + escapePointer(key)); err != nil {
+				return err
+			}
+			if s.expect('}') {
+				return nil
+			}
+			if !s.expect(',') {
+				return errSourceSyntax
+			}
+		}
+	case '[':
+		s.pos++
+		if s.expect(']') {
+			return nil
+		}
+		for i := 0; ; i++ {
+			if err := s.value(ptr + "/" + strconv.Itoa(i)); err != nil {
+				return err
+			}
+			if s.expect(']') {
+				return nil
+			}
+			if !s.expect(',') {
+				return errSourceSyntax
+			}
+		}
+	case '"':
+		_, err := s.str()
+		return err
+	default:
+		// Numbers, true, false and null end at a delimiter
+		start := s.pos
+		for s.pos < len(s.text) && !strings.ContainsRune(",]} \t\n\r", rune(s.text[s.pos])) {
+			s.pos++
+		}
+		if !json.Valid(s.text[start:s.pos]) {
+			return errSourceSyntax
+		}
+		return nil
+	}
+}
+
+// str consumes a string and returns its value
+func (s *sourceScanner) str() (string, error) {
+	if s.pos >= len(s.text) || s.text[s.pos] != '"' {
+		return "", errSourceSyntax
+	}
+	start := s.pos
+	for s.pos++; s.pos < len(s.text); s.pos++ {
+		switch s.text[s.pos] {
+		case '\\':
+			s.pos++
+		case '"':
+			s.pos++
+			var value string
+			if err := json.Unmarshal(s.text[start:s.pos], &value); err != nil {
+				return "", errSourceSyntax
+			}
+			return value, nil
+		}
+	}
+	return "", errSourceSyntax
+}
@@ -0,0 +1,913 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi20
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestValidateResolveRefs(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{"$ref": "#/parameters/limit"},
+						{"$ref": "#/definitions/Pet"}
+					],
+					"responses": {
+						"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Missing"}}},
+						"default": {"$ref": "#/responses/Error"}
+					}
+				}
+			}
+		},
+		"definitions": {"Pet": {"type": "object"}},
+		"parameters": {"limit": {"name": "limit", "in": "query", "type": "integer"}},
+		"responses": {"Error": {"description": "Error", "schema": {"$ref": "#/definitions/Pet"}}}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if result := doc.Validate(); len(result.Errors) != 0 {
+		t.Errorf("Expected refs to be unchecked by default, got: %v", result.Error())
+	}
+
+	result := doc.ValidateWithOptions(&ValidationOptions{ResolveRefs: true})
+	if len(result.Errors) != 2 {
+		t.Fatalf("Expected 2 reference errors, got %v", result.Errors)
+	}
+	msg := result.Error()
+	if !strings.Contains(msg, "paths[/pets].get.parameters[1].$ref: reference #/definitions/Pet points at definitions, expected parameters") {
+		t.Errorf("Expected kind mismatch, got %s", msg)
+	}
+	if !strings.Contains(msg, "#/definitions/Missing does not resolve") {
+		t.Errorf("Expected unresolved definition, got %s", msg)
+	}
+}
+
+func TestValidateResolveRefsExampleFiles(t *testing.T) {
+	files, err := filepath.Glob("oas-examples/json/*.json")
+	if err != nil {
+		t.Fatalf("Failed to list examples: %v", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		var doc Swagger
+		if err := json.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("Failed to parse %s: %v", file, err)
+		}
+		if result := doc.ValidateWithOptions(&ValidationOptions{ResolveRefs: true}); !result.Valid() {
+			t.Errorf("%s: unexpected errors: %v", file, result.Error())
+		}
+	}
+}
+
+func TestValidatePathParameters(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets/{id}": {
+				"parameters": [{"$ref": "#/parameters/Id"}],
+				"get": {"responses": {"200": {"description": "OK"}}}
+			},
+			"/owners/{ownerId}/pets/{petId}": {
+				"get": {
+					"parameters": [
+						{"name": "ownerId", "in": "path", "required": true, "type": "string"},
+						{"name": "id", "in": "path", "required": true, "type": "string"}
+					],
+					"responses": {"200": {"description": "OK"}}
+				}
+			},
+			"/stores/{storeId}": {
+				"get": {
+					"parameters": [{"$ref": "#/parameters/Missing"}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		},
+		"parameters": {"Id": {"name": "id", "in": "path", "required": true, "type": "string"}}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, e := range doc.Validate().Errors {
+		if e.Rule == RulePathParameters {
+			messages = append(messages, e.Error())
+		}
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 path parameter errors, got %v", messages)
+	}
+	joined := strings.Join(messages, "; ")
+	if !strings.Contains(joined, "paths[/owners/{ownerId}/pets/{petId}].get: placeholder '{petId}' has no path parameter") {
+		t.Errorf("Expected missing placeholder parameter, got %s", joined)
+	}
+	if !strings.Contains(joined, "paths[/owners/{ownerId}/pets/{petId}].get.parameters[1]: path parameter 'id' does not match a placeholder in the path") {
+		t.Errorf("Expected unmatched path parameter, got %s", joined)
+	}
+}
+
+func TestValidateSecurityRequirements(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"security": [{"basic": ["read"]}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"security": [{"oauth": ["read"]}, {"missing": []}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		},
+		"securityDefinitions": {
+			"basic": {"type": "basic"},
+			"oauth": {"type": "oauth2", "flow": "implicit", "authorizationUrl": "https://example.com/auth", "scopes": {"read": "Read"}}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	result := doc.Validate()
+	if len(result.Errors) != 2 {
+		t.Fatalf("Expected 2 security requirement errors, got %v", result.Errors)
+	}
+	msg := result.Error()
+	if !strings.Contains(msg, "security[0][basic]: scopes are only allowed for oauth2 schemes; 'basic' is basic") {
+		t.Errorf("Expected scope error, got %s", msg)
+	}
+	if !strings.Contains(msg, "paths[/pets].get.security[1][missing]: security scheme 'missing' is not declared in securityDefinitions") {
+		t.Errorf("Expected undeclared scheme error, got %s", msg)
+	}
+}
+
+func TestValidateOAuthScopes(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"security": [{"oauth": ["read", "admin"]}],
+		"paths": {},
+		"securityDefinitions": {
+			"oauth": {"type": "oauth2", "flow": "implicit", "authorizationUrl": "https://example.com/auth", "scopes": {"read": "Read"}}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	result := doc.Validate()
+	if len(result.Errors) != 1 {
+		t.Fatalf("Expected 1 scope error, got %v", result.Errors)
+	}
+	if e := result.Errors[0]; e.Rule != RuleOAuthScope || e.Path != "security[0][oauth][1]" {
+		t.Errorf("Expected unknown scope at security[0][oauth][1], got %v", e)
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"tags": [{"name": "pets"}, {"name": "owners"}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"tags": ["pets", "stores"],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	result := doc.Validate()
+	if !result.Valid() {
+		t.Fatalf("Expected undeclared tags to be warnings, got: %v", result.Error())
+	}
+	warnings := result.Warnings()
+	if len(warnings) != 1 || warnings[0].Rule != RuleUndeclaredTag || !strings.Contains(warnings[0].Message, "tag 'stores' is not declared") {
+		t.Errorf("Expected undeclared tag warning for 'stores', got %v", warnings)
+	}
+
+	result = doc.ValidateWithOptions(&ValidationOptions{UnusedTags: true})
+	var unused []string
+	for _, e := range result.Warnings() {
+		if e.Rule == RuleUnusedTag {
+			unused = append(unused, e.Error())
+		}
+	}
+	if len(unused) != 1 || !strings.Contains(unused[0], "tag 'owners' is not used by any operation") {
+		t.Errorf("Expected unused tag warning for 'owners', got %v", unused)
+	}
+}
+
+func TestValidateMediaTypes(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"consumes": ["application/json", "application/vnd.api+json"],
+		"produces": ["application/json; charset"],
+		"paths": {
+			"/pets": {
+				"get": {
+					"produces": ["text/plain", "json"],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	result := doc.Validate()
+	if len(result.Errors) != 2 {
+		t.Fatalf("Expected 2 media type errors, got %v", result.Errors)
+	}
+	msg := result.Error()
+	if !strings.Contains(msg, "produces[0]: invalid media type 'application/json; charset'") {
+		t.Errorf("Expected top-level produces error, got %s", msg)
+	}
+	if !strings.Contains(msg, "paths[/pets].get.produces[1]: invalid media type 'json'") {
+		t.Errorf("Expected operation produces error, got %s", msg)
+	}
+}
+
+func TestValidateHostAndBasePath(t *testing.T) {
+	tests := []struct {
+		name      string
+		doc       Swagger
+		wantError string
+	}{
+		{name: "valid", doc: Swagger{Host: "api.example.com:8080", BasePath: "/v1", Schemes: []string{"https", "wss"}}},
+		{name: "ipv6 host", doc: Swagger{Host: "[::1]:8080"}},
+		{name: "host with scheme", doc: Swagger{Host: "https://api.example.com"}, wantError: "host: must not include a scheme"},
+		{name: "host with path", doc: Swagger{Host: "api.example.com/v1"}, wantError: "host: must not include a path"},
+		{name: "host with bad port", doc: Swagger{Host: "api.example.com:http"}, wantError: "host: must be a host name or IP address"},
+		{name: "host with template", doc: Swagger{Host: "{env}.example.com"}, wantError: "host: must be a host name or IP address"},
+		{name: "relative basePath", doc: Swagger{BasePath: "v1"}, wantError: "basePath: must start with a leading slash"},
+		{name: "basePath with query", doc: Swagger{BasePath: "/v1?x=1"}, wantError: "basePath: must not include a query or fragment"},
+		{name: "templated basePath", doc: Swagger{BasePath: "/{version}"}, wantError: "basePath: path templating is not supported"},
+		{name: "unknown scheme", doc: Swagger{Schemes: []string{"ftp"}}, wantError: "schemes[0]: must be one of: http, https, ws, wss; got ftp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs []string
+			for _, e := range tt.doc.Validate().Errors {
+				if e.Rule == RuleServerURL {
+					errs = append(errs, e.Error())
+				}
+			}
+			msg := strings.Join(errs, "; ")
+			if tt.wantError == "" {
+				if len(errs) != 0 {
+					t.Errorf("Expected no errors, got: %v", msg)
+				}
+				return
+			}
+			if !strings.Contains(msg, tt.wantError) {
+				t.Errorf("Expected %q, got: %v", tt.wantError, msg)
+			}
+		})
+	}
+}
+
+func TestValidateDiscriminator(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {},
+		"definitions": {
+			"Pet": {"type": "object", "discriminator": "petType", "required": ["petType"], "properties": {"petType": {"type": "string"}}},
+			"Dog": {"allOf": [{"$ref": "#/definitions/Pet"}], "discriminator": "petType"},
+			"Shape": {"type": "object", "discriminator": "kind", "properties": {"kind": {"type": "integer"}}},
+			"Vehicle": {"type": "object", "discriminator": "vehicleType"}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	result := doc.Validate()
+	msg := result.Error()
+	wants := []string{
+		"definitions[Shape].discriminator: property 'kind' must be required",
+		"definitions[Shape].discriminator: property 'kind' must be a string; got integer",
+		"definitions[Vehicle].discriminator: property 'vehicleType' is not defined in the schema",
+	}
+	for _, want := range wants {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected %q, got %s", want, msg)
+		}
+	}
+	if len(result.Errors) != len(wants) {
+		t.Errorf("Expected %d discriminator errors, got %v", len(wants), result.Errors)
+	}
+}
+
+func TestValidateFormats(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/events": {
+				"get": {
+					"parameters": [
+						{"name": "ids", "in": "query", "type": "array", "items": {"type": "string", "format": "snowflake-id"}},
+						{"name": "since", "in": "query", "type": "string", "format": "date-time"}
+					],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		},
+		"definitions": {
+			"Event": {"type": "object", "properties": {"email": {"type": "string", "format": "e-mail"}}}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	result := doc.Validate()
+	if !result.Valid() {
+		t.Fatalf("Expected unknown formats to be warnings, got: %v", result.Error())
+	}
+	if len(result.Warnings()) != 2 {
+		t.Fatalf("Expected 2 format warnings, got %v", result.Warnings())
+	}
+
+	RegisterFormat("snowflake-id")
+	warnings := doc.Validate().Warnings()
+	if len(warnings) != 1 || warnings[0].Path != "definitions[Event].properties[email].format" {
+		t.Errorf("Expected only the e-mail format warning, got %v", warnings)
+	}
+}
+
+func TestValidateRequiredFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		wantError string
+	}{
+		{name: "missing swagger", data: `{"info": {"title": "T", "version": "1"}, "paths": {}}`, wantError: "swagger: required field is missing"},
+		{name: "wrong version", data: `{"swagger": "3.0", "info": {"title": "T", "version": "1"}, "paths": {}}`, wantError: "swagger: expected 2.0, got 3.0"},
+		{name: "missing info", data: `{"swagger": "2.0", "paths": {}}`, wantError: "info: required field is missing"},
+		{name: "missing title", data: `{"swagger": "2.0", "info": {"version": "1"}, "paths": {}}`, wantError: "info.title: required field is missing"},
+		{name: "missing license name", data: `{"swagger": "2.0", "info": {"title": "T", "version": "1", "license": {"url": "https://example.com"}}, "paths": {}}`, wantError: "info.license.name: required field is missing"},
+		{name: "missing paths", data: `{"swagger": "2.0", "info": {"title": "T", "version": "1"}}`, wantError: "paths: required field is missing"},
+		{name: "missing responses", data: `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {}}}}`, wantError: "paths[/pets].get.responses: required field is missing"},
+		{name: "empty responses", data: `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {}}}}}`, wantError: "paths[/pets].get.responses: must contain at least one response"},
+		{name: "range status code", data: `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {"2XX": {"description": "OK"}}}}}}`, wantError: "paths[/pets].get.responses.2XX: invalid status code"},
+		{name: "missing description", data: `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {"200": {}}}}}}`, wantError: "paths[/pets].get.responses.200.description: required field is missing"},
+		{name: "array header without items", data: `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {}, "responses": {"Ok": {"description": "OK", "headers": {"X-Ids": {"type": "array"}}}}}`, wantError: "responses[Ok].headers[X-Ids].items: array type must have items defined"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc Swagger
+			if err := json.Unmarshal([]byte(tt.data), &doc); err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if msg := doc.Validate().Error(); !strings.Contains(msg, tt.wantError) {
+				t.Errorf("Expected %q, got: %v", tt.wantError, msg)
+			}
+		})
+	}
+
+	// Unmarshal only keeps keys starting with /, so build the path directly
+	doc := Swagger{
+		Swagger: "2.0",
+		Info:    &Info{Title: "T", Version: "1"},
+		Paths:   &Paths{Paths: map[string]*PathItem{"pets": {}}},
+	}
+	if msg := doc.Validate().Error(); !strings.Contains(msg, "paths.pets: path must start with /") {
+		t.Errorf("Expected path format error, got: %v", msg)
+	}
+}
+
+func TestValidateParameters(t *testing.T) {
+	tests := []struct {
+		name      string
+		param     string
+		wantError string
+	}{
+		{name: "missing name", param: `{"in": "query", "type": "string"}`, wantError: "parameters[P].name: required field is missing"},
+		{name: "invalid in", param: `{"name": "p", "in": "cookie", "type": "string"}`, wantError: "parameters[P].in: must be one of: query, header, path, formData, body; got cookie"},
+		{name: "optional path", param: `{"name": "p", "in": "path", "type": "string"}`, wantError: "parameters[P].required: path parameters must have required: true"},
+		{name: "body without schema", param: `{"name": "p", "in": "body"}`, wantError: "parameters[P].schema: required field is missing"},
+		{name: "missing type", param: `{"name": "p", "in": "query"}`, wantError: "parameters[P].type: required field is missing"},
+		{name: "invalid type", param: `{"name": "p", "in": "query", "type": "object"}`, wantError: "parameters[P].type: invalid type 'object'"},
+		{name: "file outside formData", param: `{"name": "p", "in": "query", "type": "file"}`, wantError: "parameters[P].type: file parameters must be in formData"},
+		{name: "array without items", param: `{"name": "p", "in": "query", "type": "array"}`, wantError: "parameters[P].items: array type must have items defined"},
+		{name: "file items", param: `{"name": "p", "in": "formData", "type": "array", "items": {"type": "file"}}`, wantError: "parameters[P].items.type: file is only allowed for parameters"},
+		{name: "unknown collectionFormat", param: `{"name": "p", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "json"}`, wantError: "parameters[P].collectionFormat: must be one of: csv, ssv, tsv, pipes, multi; got json"},
+		{name: "multi in header", param: `{"name": "p", "in": "header", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"}`, wantError: "parameters[P].collectionFormat: multi is only allowed for query and formData parameters"},
+		{name: "inverted range", param: `{"name": "p", "in": "query", "type": "integer", "minimum": 10, "maximum": 1}`, wantError: "parameters[P]: minimum cannot be greater than maximum"},
+		{name: "bad pattern", param: `{"name": "p", "in": "query", "type": "string", "pattern": "[a-"}`, wantError: "parameters[P].pattern: invalid regex pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {}, "parameters": {"P": ` + tt.param + `}}`
+			var doc Swagger
+			if err := json.Unmarshal([]byte(data), &doc); err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if msg := doc.Validate().Error(); !strings.Contains(msg, tt.wantError) {
+				t.Errorf("Expected %q, got: %v", tt.wantError, msg)
+			}
+		})
+	}
+}
+
+func TestValidateBodyParameters(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"parameters": [{"name": "pet", "in": "body", "schema": {"type": "object"}}],
+				"post": {
+					"parameters": [{"name": "name", "in": "formData", "type": "string"}],
+					"responses": {"200": {"description": "OK"}}
+				},
+				"put": {
+					"parameters": [
+						{"name": "other", "in": "body", "schema": {"type": "object"}},
+						{"name": "limit", "in": "query", "type": "integer"},
+						{"name": "limit", "in": "query", "type": "integer"}
+					],
+					"responses": {"200": {"description": "OK"}}
+				},
+				"patch": {
+					"parameters": [{"name": "pet", "in": "body", "schema": {"type": "string"}}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, e := range doc.Validate().Errors {
+		if e.Rule == RuleBodyParameter || e.Rule == RuleDuplicateParameter {
+			messages = append(messages, e.Error())
+		}
+	}
+	if len(messages) != 3 {
+		t.Fatalf("Expected 3 parameter errors, got %v", messages)
+	}
+	joined := strings.Join(messages, "; ")
+	if !strings.Contains(joined, "paths[/pets].post.parameters: body and formData parameters cannot be used together") {
+		t.Errorf("Expected body and formData error, got %s", joined)
+	}
+	if !strings.Contains(joined, "paths[/pets].put.parameters: operation must have at most one body parameter") {
+		t.Errorf("Expected multiple body error, got %s", joined)
+	}
+	if !strings.Contains(joined, "paths[/pets].put.parameters[2]: duplicate query parameter 'limit'") {
+		t.Errorf("Expected duplicate parameter error, got %s", joined)
+	}
+}
+
+func TestValidateSecurityDefinitions(t *testing.T) {
+	tests := []struct {
+		name      string
+		scheme    string
+		wantError string
+	}{
+		{name: "invalid type", scheme: `{"type": "http"}`, wantError: "securityDefinitions[S].type: must be one of: basic, apiKey, oauth2; got http"},
+		{name: "apiKey without name", scheme: `{"type": "apiKey", "in": "header"}`, wantError: "securityDefinitions[S].name: required for apiKey type"},
+		{name: "apiKey in cookie", scheme: `{"type": "apiKey", "name": "k", "in": "cookie"}`, wantError: "securityDefinitions[S].in: must be one of: query, header"},
+		{name: "oauth2 without flow", scheme: `{"type": "oauth2", "scopes": {}}`, wantError: "securityDefinitions[S].flow: required for oauth2 type"},
+		{name: "implicit without authorizationUrl", scheme: `{"type": "oauth2", "flow": "implicit", "scopes": {}}`, wantError: "securityDefinitions[S].authorizationUrl: required for implicit flow"},
+		{name: "accessCode without tokenUrl", scheme: `{"type": "oauth2", "flow": "accessCode", "authorizationUrl": "https://example.com/auth", "scopes": {}}`, wantError: "securityDefinitions[S].tokenUrl: required for accessCode flow"},
+		{name: "oauth2 without scopes", scheme: `{"type": "oauth2", "flow": "application", "tokenUrl": "https://example.com/token"}`, wantError: "securityDefinitions[S].scopes: required for oauth2 type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {}, "securityDefinitions": {"S": ` + tt.scheme + `}}`
+			var doc Swagger
+			if err := json.Unmarshal([]byte(data), &doc); err != nil {
+				t.Fatalf("Failed to parse: %v", err)
+			}
+			if msg := doc.Validate().Error(); !strings.Contains(msg, tt.wantError) {
+				t.Errorf("Expected %q, got: %v", tt.wantError, msg)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaConstraints(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {},
+		"definitions": {
+			"Bad": {
+				"type": "object",
+				"required": ["id", "missing"],
+				"minProperties": 3,
+				"maxProperties": 1,
+				"properties": {
+					"id": {"type": "uuid"},
+					"tags": {"type": "array"},
+					"code": {"type": "string", "pattern": "(", "minLength": 5, "maxLength": 2}
+				}
+			}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	msg := doc.Validate().Error()
+	for _, want := range []string{
+		"definitions[Bad].required: required property 'missing' not defined in properties",
+		"definitions[Bad]: minProperties cannot be greater than maxProperties",
+		"definitions[Bad].properties[id].type: invalid type 'uuid'",
+		"definitions[Bad].properties[tags].items: array type must have items defined",
+		"definitions[Bad].properties[code].pattern: invalid regex pattern",
+		"definitions[Bad].properties[code]: minLength cannot be greater than maxLength",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected %q, got: %v", want, msg)
+		}
+	}
+}
+
+func TestValidationErrorPointer(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "", want: ""},
+		{path: "info.title", want: "/info/title"},
+		{path: "paths[/pets/{id}].get.parameters[0]", want: "/paths/~1pets~1{id}/get/parameters/0"},
+		{path: "paths[/v1.0/pets].get", want: "/paths/~1v1.0~1pets/get"},
+		{path: "security[0][oauth~1]", want: "/security/0/oauth~01"},
+		{path: "paths[/a[b]].get", want: "/paths/~1a[b]/get"},
+	}
+	for _, tt := range tests {
+		if got := (ValidationError{Path: tt.path}).Pointer(); got != tt.want {
+			t.Errorf("Pointer(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestValidationResultReport(t *testing.T) {
+	result := &ValidationResult{Errors: []ValidationError{
+		{Path: "info.title", Message: "required field is missing", Rule: "required", Severity: SeverityError},
+		{Path: "paths[/pets].get.tags[0]", Message: "tag 'pets' is not declared", Rule: "undeclared-tag", Severity: SeverityWarning},
+	}}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	want := `{"valid":false,"errors":[` +
+		`{"rule":"required","severity":"error","path":"info.title","pointer":"/info/title","message":"required field is missing"},` +
+		`{"rule":"undeclared-tag","severity":"warning","path":"paths[/pets].get.tags[0]","pointer":"/paths/~1pets/get/tags/0","message":"tag 'pets' is not declared"}]}`
+	if string(data) != want {
+		t.Errorf("Unexpected JSON report:\n%s\nwant:\n%s", data, want)
+	}
+
+	data, err = result.SARIF("api.json")
+	if err != nil {
+		t.Fatalf("Failed to write SARIF: %v", err)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Failed to parse SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log: %s", data)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || len(run.Results) != 2 {
+		t.Fatalf("Expected 2 rules and 2 results, got %s", data)
+	}
+	warning := run.Results[1]
+	if warning.RuleID != "undeclared-tag" || warning.Level != "warning" {
+		t.Errorf("Unexpected SARIF result: %+v", warning)
+	}
+	if len(warning.Locations) != 1 ||
+		warning.Locations[0].PhysicalLocation.ArtifactLocation.URI != "api.json" ||
+		warning.Locations[0].LogicalLocations[0].FullyQualifiedName != "/paths/~1pets/get/tags/0" {
+		t.Errorf("Unexpected SARIF location: %+v", warning.Locations)
+	}
+}
+
+func TestValidateSourcePositions(t *testing.T) {
+	source := `{
+  "swagger": "2.0",
+  "info": {"version": "1.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "tags": ["pets"],
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  }
+}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(source), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	result := doc.ValidateWithOptions(&ValidationOptions{Source: []byte(source)})
+
+	want := map[string][2]int{
+		"info.title":               {3, 11}, // missing field, located at info
+		"paths[/pets].get.tags[0]": {7, 18},
+	}
+	for _, e := range result.Errors {
+		pos, ok := want[e.Path]
+		if !ok {
+			continue
+		}
+		delete(want, e.Path)
+		if e.Line != pos[0] || e.Column != pos[1] {
+			t.Errorf("%s: expected line %d column %d, got line %d column %d", e.Path, pos[0], pos[1], e.Line, e.Column)
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("Expected findings at %v, got %v", want, result.Errors)
+	}
+
+	// Without a valid source, findings carry no position
+	result = doc.ValidateWithOptions(&ValidationOptions{Source: []byte("{")})
+	for _, e := range result.Errors {
+		if e.Line != 0 || e.Column != 0 {
+			t.Errorf("%s: expected no position, got line %d column %d", e.Path, e.Line, e.Column)
+		}
+	}
+}
+
+func TestValidateUnusedComponents(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"security": [{"apiKey": []}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [{"$ref": "#/parameters/Limit"}],
+					"responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet"}}}
+				}
+			},
+			"/animals": {
+				"get": {
+					"responses": {"200": {"$ref": "#/responses/Animals"}}
+				}
+			}
+		},
+		"definitions": {
+			"Pet": {"type": "object", "properties": {"owner": {"$ref": "#/definitions/Owner"}}},
+			"Owner": {"type": "object"},
+			"Orphan": {"type": "object", "properties": {"child": {"$ref": "#/definitions/OrphanChild"}}},
+			"OrphanChild": {"type": "object"},
+			"Animal": {"type": "object", "required": ["kind"], "properties": {"kind": {"type": "string"}}, "discriminator": "kind"},
+			"Dog": {"allOf": [{"$ref": "#/definitions/Animal"}]}
+		},
+		"parameters": {
+			"Limit": {"name": "limit", "in": "query", "type": "integer"},
+			"Offset": {"name": "offset", "in": "query", "type": "integer"}
+		},
+		"responses": {
+			"Animals": {"description": "OK", "schema": {"$ref": "#/definitions/Animal"}},
+			"NotFound": {"description": "Not found"}
+		},
+		"securityDefinitions": {
+			"apiKey": {"type": "apiKey", "name": "key", "in": "header"},
+			"basic": {"type": "basic"}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	want := []string{
+		"#/definitions/Orphan",
+		"#/definitions/OrphanChild",
+		"#/parameters/Offset",
+		"#/responses/NotFound",
+		"#/securityDefinitions/basic",
+	}
+	if got := doc.UnusedComponents(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected unused %v, got %v", want, got)
+	}
+
+	result := doc.ValidateWithOptions(&ValidationOptions{UnusedComponents: true})
+	var messages []string
+	for _, w := range result.Warnings() {
+		if w.Rule == RuleUnusedComponent {
+			messages = append(messages, w.Error())
+		}
+	}
+	if len(messages) != 5 {
+		t.Fatalf("Expected 5 unused warnings, got %v", messages)
+	}
+	if !strings.Contains(strings.Join(messages, "; "), "responses[NotFound]: 'NotFound' is never referenced") {
+		t.Errorf("Expected unused response to be reported, got %v", messages)
+	}
+}
+
+func TestValidateRequiredReadOnly(t *testing.T) {
+	data := `{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {},
+		"definitions": {
+			"Pet": {
+				"type": "object",
+				"required": ["id", "name"],
+				"properties": {
+					"id": {"type": "integer", "readOnly": true},
+					"name": {"type": "string"}
+				}
+			}
+		}
+	}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var warnings []ValidationError
+	for _, w := range doc.Validate().Warnings() {
+		if w.Rule == RuleRequiredReadOnly {
+			warnings = append(warnings, w)
+		}
+	}
+	if len(warnings) != 1 || warnings[0].Error() != "definitions[Pet].required: required property 'id' is readOnly" {
+		t.Errorf("Expected one required readOnly warning, got %v", warnings)
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	// Every path misses its responses and every schema its array items
+	var paths, schemas []string
+	for i := 0; i < 200; i++ {
+		paths = append(paths, fmt.Sprintf(`"/pets%d": {"get": {}}`, i))
+		schemas = append(schemas, fmt.Sprintf(`"Pet%d": {"type": "array"}`, i))
+	}
+	data := `{"swagger": "2.0", "info": {"title": "Test", "version": "1.0"}, "paths": {` +
+		strings.Join(paths, ", ") + `}, "definitions": {` + strings.Join(schemas, ", ") + `}}`
+
+	var doc Swagger
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	messages := func(result *ValidationResult) []string {
+		var msgs []string
+		for _, e := range result.Errors {
+			msgs = append(msgs, e.Error())
+		}
+		sort.Strings(msgs)
+		return msgs
+	}
+	opts := &ValidationOptions{ResolveRefs: true, Source: []byte(data), Workers: 4}
+	want := messages(doc.ValidateWithOptions(opts))
+	if len(want) < 400 {
+		t.Fatalf("Expected findings for every path and schema, got %d", len(want))
+	}
+
+	result, err := doc.ValidateContext(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := messages(result); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected the findings of ValidateWithOptions, got %d of %d", len(got), len(want))
+	}
+
+	// The findings of a task come where ValidateWithOptions finds them, after
+	// the info and before the undeclared tag
+	single := `{"swagger": "2.0", "info": {"title": "", "version": "1.0"}, "paths": {"/pets": {"get": {"tags": ["dogs"]}}}}`
+	var one Swagger
+	if err := json.Unmarshal([]byte(single), &one); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	var ordered []string
+	for _, e := range one.Validate().Errors {
+		ordered = append(ordered, e.Error())
+	}
+	if len(ordered) < 3 {
+		t.Fatalf("Expected findings before, in and after the path, got %v", ordered)
+	}
+	result, err = one.ValidateContext(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, e := range result.Errors {
+		if i >= len(ordered) || e.Error() != ordered[i] {
+			t.Fatalf("Expected the findings in the order of ValidateWithOptions %v, got %v", ordered, result.Errors)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result, err := doc.ValidateContext(ctx, nil); err != context.Canceled || result != nil {
+		t.Errorf("Expected context.Canceled, got %v, %v", result, err)
+	}
+}
+
+func TestValidationResultErrors(t *testing.T) {
+	doc := &Swagger{Swagger: "2.0", Info: &Info{Title: "Test", Version: "1.0"}, Paths: &Paths{}}
+	doc.Info.Title = ""
+	err := doc.Validate().Err()
+	if err == nil {
+		t.Fatal("Expected an error for a missing title")
+	}
+	if !errors.Is(err, RuleError(RuleRequired)) || errors.Is(err, RuleError(RuleVersion)) {
+		t.Errorf("Expected only the required rule to match, got %v", err)
+	}
+	var finding ValidationError
+	if !errors.As(err, &finding) || finding.Path != "info.title" {
+		t.Errorf("Expected the info.title finding, got %v", finding)
+	}
+	joined := errors.Join(doc.Validate().Unwrap()...)
+	if !errors.Is(joined, RuleError(RuleRequired)) || joined.Error() != "info.title: required field is missing" {
+		t.Errorf("Unexpected joined error: %v", joined)
+	}
+
+	valid := &Swagger{Swagger: "2.0", Info: &Info{Title: "Test", Version: "1.0"}, Paths: &Paths{}}
+	if err := valid.Validate().Err(); err != nil {
+		t.Errorf("Expected no error for a valid document, got %v", err)
+	}
+}
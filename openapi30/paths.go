@@ -6,6 +6,8 @@ package openapi30
 
 import (
 	"encoding/json"
+	"iter"
+	"slices"
 	"strings"
 )
 
@@ -130,3 +132,87 @@ func (pi *PathItem) GetRef() string {
 	}
 	return pi.Ref
 }
+
+// The HTTP methods with a fixed field in a PathItem
+const (
+	MethodGet     = "get"
+	MethodPut     = "put"
+	MethodPost    = "post"
+	MethodDelete  = "delete"
+	MethodOptions = "options"
+	MethodHead    = "head"
+	MethodPatch   = "patch"
+	MethodTrace   = "trace"
+)
+
+// fixedMethods lists the fixed-field methods in specification order
+var fixedMethods = []string{MethodGet, MethodPut, MethodPost, MethodDelete, MethodOptions, MethodHead, MethodPatch, MethodTrace}
+
+// IsFixedMethod reports whether a PathItem has a field for the method,
+// matched case-insensitively
+func IsFixedMethod(method string) bool {
+	return slices.Contains(fixedMethods, strings.ToLower(method))
+}
+
+// operationField returns the field holding the operation for the method, or
+// nil if the PathItem has no field for it
+func (pi *PathItem) operationField(method string) **Operation {
+	switch strings.ToLower(method) {
+	case MethodGet:
+		return &pi.Get
+	case MethodPut:
+		return &pi.Put
+	case MethodPost:
+		return &pi.Post
+	case MethodDelete:
+		return &pi.Delete
+	case MethodOptions:
+		return &pi.Options
+	case MethodHead:
+		return &pi.Head
+	case MethodPatch:
+		return &pi.Patch
+	case MethodTrace:
+		return &pi.Trace
+	}
+	return nil
+}
+
+// GetOperation returns the operation for the method, matched
+// case-insensitively, or nil
+func (pi *PathItem) GetOperation(method string) *Operation {
+	if pi == nil {
+		return nil
+	}
+	if field := pi.operationField(method); field != nil {
+		return *field
+	}
+	return nil
+}
+
+// SetOperation sets the operation for the method, matched case-insensitively;
+// a nil operation removes it. It reports false, changing nothing, when the
+// PathItem has no field for the method.
+func (pi *PathItem) SetOperation(method string, op *Operation) bool {
+	field := pi.operationField(method)
+	if field == nil {
+		return false
+	}
+	*field = op
+	return true
+}
+
+// Operations iterates the operations of the PathItem by method, in
+// specification order
+func (pi *PathItem) Operations() iter.Seq2[string, *Operation] {
+	return func(yield func(string, *Operation) bool) {
+		if pi == nil {
+			return
+		}
+		for _, method := range fixedMethods {
+			if op := *pi.operationField(method); op != nil && !yield(method, op) {
+				return
+			}
+		}
+	}
+}
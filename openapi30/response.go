@@ -6,7 +6,11 @@ package openapi30
 
 import (
 	"encoding/json"
+	"iter"
+	"maps"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -36,6 +40,11 @@ func NewResponseReference(ref string) *Response {
 	return &Response{Ref: ref}
 }
 
+// NewResponse creates a response with the description
+func NewResponse(description string) *Response {
+	return &Response{Description: description}
+}
+
 type responseAlias Response
 
 func (r *Response) UnmarshalJSON(data []byte) error {
@@ -138,3 +147,64 @@ func (r *Responses) GetDefault() *Response {
 	}
 	return r.Default
 }
+
+// Set sets the response for a status code, a range such as "4XX", or
+// "default". A nil resp removes the response.
+func (r *Responses) Set(code string, resp *Response) {
+	if code == "default" {
+		r.Default = resp
+		return
+	}
+	if resp == nil {
+		delete(r.StatusCode, code)
+		return
+	}
+	if r.StatusCode == nil {
+		r.StatusCode = make(map[string]*Response)
+	}
+	r.StatusCode[code] = resp
+}
+
+// GetByStatus returns the response that describes an HTTP status: the one
+// for its code, else the one for its range, else the default response
+func (r *Responses) GetByStatus(status int) *Response {
+	if r == nil {
+		return nil
+	}
+	if resp := r.StatusCode[strconv.Itoa(status)]; resp != nil {
+		return resp
+	}
+	if status >= 100 && status < 600 {
+		if resp := r.StatusCode[strconv.Itoa(status/100)+"XX"]; resp != nil {
+			return resp
+		}
+	}
+	return r.Default
+}
+
+// EnsureDefault returns the default response, first adding one described
+// as "Unexpected error" when there is none
+func (r *Responses) EnsureDefault() *Response {
+	if r.Default == nil {
+		r.Default = NewResponse("Unexpected error")
+	}
+	return r.Default
+}
+
+// All returns an iterator over the responses by status code in order, a
+// range such as "4XX" after the codes it covers, and the default last
+func (r *Responses) All() iter.Seq2[string, *Response] {
+	return func(yield func(string, *Response) bool) {
+		if r == nil {
+			return
+		}
+		for _, code := range slices.Sorted(maps.Keys(r.StatusCode)) {
+			if !yield(code, r.StatusCode[code]) {
+				return
+			}
+		}
+		if r.Default != nil {
+			yield("default", r.Default)
+		}
+	}
+}
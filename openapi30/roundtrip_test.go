@@ -6,6 +6,7 @@ package openapi30
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -294,6 +295,55 @@ func TestExtensionsPreserved(t *testing.T) {
 	}
 }
 
+// TestExtensionHelpers tests the typed extension accessors
+func TestExtensionHelpers(t *testing.T) {
+	var op Operation
+	if err := json.Unmarshal([]byte(`{
+		"responses": {"200": {"description": "OK"}},
+		"x-name": "pets",
+		"x-internal": true,
+		"x-limit": 25,
+		"x-ratio": 2.5,
+		"x-meta": {"owner": "team", "tags": ["a", "b"]}
+	}`), &op); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if s, err := GetExtensionString(op.Extensions, "x-name"); err != nil || s != "pets" {
+		t.Errorf("GetExtensionString() = %q, %v", s, err)
+	}
+	if b, err := GetExtensionBool(op.Extensions, "x-internal"); err != nil || !b {
+		t.Errorf("GetExtensionBool() = %v, %v", b, err)
+	}
+	if n, err := GetExtensionInt(op.Extensions, "x-limit"); err != nil || n != 25 {
+		t.Errorf("GetExtensionInt() = %d, %v", n, err)
+	}
+	var meta struct {
+		Owner string   `json:"owner"`
+		Tags  []string `json:"tags"`
+	}
+	if err := GetExtensionObject(op.Extensions, "x-meta", &meta); err != nil || meta.Owner != "team" || len(meta.Tags) != 2 {
+		t.Errorf("GetExtensionObject() = %+v, %v", meta, err)
+	}
+
+	if _, err := GetExtensionString(op.Extensions, "x-missing"); !errors.Is(err, ErrExtensionNotFound) {
+		t.Errorf("Expected ErrExtensionNotFound, got %v", err)
+	}
+	if _, err := GetExtensionString(op.Extensions, "x-limit"); err == nil || err.Error() != "extension x-limit is a number, not a string" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := GetExtensionInt(op.Extensions, "x-ratio"); err == nil || err.Error() != "extension x-ratio is 2.5, not an integer" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := GetExtensionBool(op.Extensions, "x-meta"); err == nil || err.Error() != "extension x-meta is an object, not a boolean" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	var count int
+	if err := GetExtensionObject(op.Extensions, "x-meta", &count); err == nil {
+		t.Error("Expected an error decoding an object into an int")
+	}
+}
+
 // TestAllExampleFilesParseSuccessfully ensures all example files can be parsed
 func TestAllExampleFilesParseSuccessfully(t *testing.T) {
 	examplesDir := "oas-examples/json"
@@ -336,3 +386,166 @@ func TestAllExampleFilesParseSuccessfully(t *testing.T) {
 		})
 	}
 }
+
+// TestMatchMediaType tests the precedence of media type lookups
+func TestMatchMediaType(t *testing.T) {
+	keys := []string{"application/json", "application/*+json", "application/*", "*/*", "text/plain; charset=utf-8", "text/plain"}
+	tests := []struct {
+		mediaType string
+		want      string
+	}{
+		{"application/json", "application/json"},
+		{"Application/JSON; charset=utf-8", "application/json"},
+		{"application/merge-patch+json", "application/*+json"},
+		{"application/xml", "application/*"},
+		{"text/plain;charset=UTF-8", "text/plain; charset=utf-8"},
+		{"text/plain; charset=ascii", "text/plain"},
+		{"image/png", "*/*"},
+	}
+	for _, tt := range tests {
+		if got, ok := MatchMediaType(keys, tt.mediaType); !ok || got != tt.want {
+			t.Errorf("MatchMediaType(%q) = %q, want %q", tt.mediaType, got, tt.want)
+		}
+	}
+	if _, ok := MatchMediaType([]string{"application/json"}, "text/plain"); ok {
+		t.Error("Expected no match for text/plain")
+	}
+	if _, ok := MatchMediaType(keys, ""); ok {
+		t.Error("Expected no match for an empty media type")
+	}
+
+	rb := &RequestBody{Content: map[string]*MediaType{"application/*": {Example: "any"}}}
+	if mt := rb.GetMediaType("application/json"); mt == nil || mt.Example != "any" {
+		t.Error("Expected the application/* request body content")
+	}
+	var resp *Response
+	if resp.GetMediaType("application/json") != nil {
+		t.Error("Expected nil for a nil response")
+	}
+}
+
+func TestConstructors(t *testing.T) {
+	op := NewOperation("listEvents")
+	resp := NewResponse("Events")
+	resp.Content = map[string]*MediaType{"application/json": {Schema: NewArraySchema(NewObjectSchema(map[string]*Schema{
+		"at":   NewStringSchema("date-time"),
+		"name": NewStringSchema(""),
+	}, "at"))}}
+	op.Responses.StatusCode["200"] = resp
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var got, want map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	expected := `{"operationId": "listEvents", "responses": {"200": {"description": "Events", "content": {"application/json": {"schema": {"type": "array", "items": {"type": "object", "required": ["at"], "properties": {"at": {"type": "string", "format": "date-time"}, "name": {"type": "string"}}}}}}}}}`
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestPathItemOperations(t *testing.T) {
+	item := &PathItem{}
+	if !item.SetOperation(MethodPost, NewOperation("create")) || !item.SetOperation("GET", NewOperation("list")) {
+		t.Fatal("Expected fixed methods to be set")
+	}
+	if item.SetOperation("query", NewOperation("search")) {
+		t.Error("Expected a method without a field to be rejected")
+	}
+	if op := item.GetOperation("get"); op != item.Get || op.OperationID != "list" {
+		t.Errorf("Expected the get operation, got %+v", op)
+	}
+
+	var methods []string
+	for method := range item.Operations() {
+		methods = append(methods, method)
+	}
+	if want := []string{"get", "post"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("Expected %v, got %v", want, methods)
+	}
+
+	item.SetOperation(MethodGet, nil)
+	if item.Get != nil || item.GetOperation(MethodGet) != nil {
+		t.Error("Expected get to be removed")
+	}
+}
+
+func TestOperationEmptySecurity(t *testing.T) {
+	for input, want := range map[string]string{
+		`{"responses":{},"security":[]}`: `{"responses":{},"security":[]}`,
+		`{"responses":{}}`:               `{"responses":{}}`,
+	} {
+		var op Operation
+		if err := json.Unmarshal([]byte(input), &op); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", input, err)
+		}
+		data, err := json.Marshal(op)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if string(data) != want {
+			t.Errorf("Expected %s, got %s", want, data)
+		}
+	}
+}
+
+func TestResponsesHelpers(t *testing.T) {
+	responses := &Responses{}
+	responses.Set("200", NewResponse("OK"))
+	responses.Set("404", NewResponse("Not found"))
+	responses.Set("4XX", NewResponse("Client error"))
+	responses.Set("201", NewResponse("Created"))
+	responses.Set("201", nil)
+
+	for status, want := range map[int]string{200: "OK", 404: "Not found", 409: "Client error"} {
+		if resp := responses.GetByStatus(status); resp == nil || resp.Description != want {
+			t.Errorf("GetByStatus(%d) = %v, want %q", status, resp, want)
+		}
+	}
+	if resp := responses.GetByStatus(500); resp != nil {
+		t.Errorf("Expected no response for 500, got %v", resp)
+	}
+	if responses.EnsureDefault() != responses.EnsureDefault() || responses.Default.Description != "Unexpected error" {
+		t.Errorf("Expected one default response, got %v", responses.Default)
+	}
+	if resp := responses.GetByStatus(500); resp != responses.Default {
+		t.Errorf("Expected the default response for 500, got %v", resp)
+	}
+
+	var codes []string
+	for code := range responses.All() {
+		codes = append(codes, code)
+	}
+	if !reflect.DeepEqual(codes, []string{"200", "404", "4XX", "default"}) {
+		t.Errorf("Expected codes in order, got %v", codes)
+	}
+}
+
+func TestComponentsAdd(t *testing.T) {
+	var c Components
+	ref, err := c.AddSchema("Pet", NewObjectSchema(nil))
+	if err != nil {
+		t.Fatalf("AddSchema() error = %v", err)
+	}
+	if ref != "#/components/schemas/Pet" || c.Schemas["Pet"] == nil {
+		t.Errorf("Expected the schema added under its reference, got %q", ref)
+	}
+	if ref, err := c.AddResponse("NotFound", NewResponse("Not found")); err != nil || ref != "#/components/responses/NotFound" {
+		t.Errorf("AddResponse() = %q, %v", ref, err)
+	}
+	if _, err := c.AddSchema("Pet", NewStringSchema("")); err == nil {
+		t.Error("Expected an error for a name in use")
+	}
+	if _, err := c.AddParameter("pet id", &Parameter{Name: "id", In: "path"}); err == nil {
+		t.Error("Expected an error for an invalid name")
+	}
+	if c.Parameters != nil {
+		t.Errorf("Expected no parameters after a rejected name, got %v", c.Parameters)
+	}
+}
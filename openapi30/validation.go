@@ -10,10 +10,94 @@ import (
 	"strings"
 )
 
-// ValidationError represents a validation error with path context
+// Severity classifies a validation finding. The zero value is SeverityError,
+// so findings are errors unless a rule is downgraded in ValidationOptions.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// Rule identifiers reported in ValidationError.Rule. They can be disabled or
+// downgraded through ValidationOptions.
+const (
+	RuleDocument              = "document"                // document-level structure
+	RuleRequired              = "required"                // required fields
+	RuleVersion               = "version"                 // openapi version string
+	RuleServerURL             = "server-url"              // server url templates
+	RuleServerVariable        = "server-variable"         // server variable defaults and usage
+	RulePaths                 = "paths"                   // non-empty paths object
+	RulePathFormat            = "path-format"             // path keys starting with /
+	RuleResponses             = "responses"               // non-empty responses object
+	RuleStatusCode            = "status-code"             // response status code keys
+	RuleParameterIn           = "parameter-in"            // parameter locations
+	RulePathParameterRequired = "path-parameter-required" // path parameters marked required
+	RulePathParameters        = "path-parameters"         // path template placeholders matching path parameters
+	RuleStyle                 = "style"                   // parameter, header and encoding styles
+	RuleIgnoredHeader         = "ignored-header"          // headers the specification says to ignore (warning)
+	RuleSchemaOrContent       = "schema-or-content"       // schema and content exclusion
+	RuleExampleOrExamples     = "example-or-examples"     // example and examples exclusion
+	RuleExampleSchema         = "example-schema"          // example values matching their schema (see ValidateExamples)
+	RuleMediaType             = "media-type"              // media type syntax (RFC 6838)
+	RuleEncoding              = "encoding"                // encoding properties and media types
+	RuleSchemaType            = "schema-type"             // schema type names
+	RuleArrayItems            = "array-items"             // items on array schemas
+	RuleSchemaRange           = "schema-range"            // consistent schema bounds
+	RulePattern               = "pattern"                 // valid regular expressions
+	RuleRequiredProperty      = "required-property"       // required properties defined in properties
+	RuleReadWrite             = "read-write"              // readOnly and writeOnly exclusion
+	RuleRequiredReadWrite     = "required-read-write"     // required properties a request or response can carry (warning)
+	RuleDiscriminator         = "discriminator"           // discriminator property and mapping
+	RuleLinkOperation         = "link-operation"          // link operationId and operationRef exclusion
+	RuleRef                   = "ref"                     // references that resolve to the expected kind
+	RuleComponentName         = "component-name"          // component name characters
+	RuleSecuritySchemeType    = "security-scheme-type"    // security scheme types
+	RuleSecurityScheme        = "security-scheme"         // type-specific security scheme fields
+	RuleSecurityRequirement   = "security-requirement"    // security requirements naming declared schemes
+	RuleOAuthFlow             = "oauth-flow"              // OAuth flow fields
+	RuleOAuthScope            = "oauth-scope"             // oauth2 scopes declared by a flow
+	RuleUndeclaredTag         = "undeclared-tag"          // operation tags declared in the top-level tags (warning)
+	RuleUnusedTag             = "unused-tag"              // declared tags used by an operation (warning, see UnusedTags)
+	RuleUnusedComponent       = "unused-component"        // components referenced from the document (warning, see UnusedComponents)
+	RuleFormat                = "format"                  // formats known to the registry (warning, see RegisterFormat)
+)
+
+// defaultSeverities lists the rules that are not reported as SeverityError
+// unless overridden
+var defaultSeverities = map[string]Severity{
+	RuleRequiredReadWrite: SeverityWarning,
+	RuleIgnoredHeader:     SeverityWarning,
+	RuleUndeclaredTag:     SeverityWarning,
+	RuleUnusedTag:         SeverityWarning,
+	RuleUnusedComponent:   SeverityWarning,
+	RuleFormat:            SeverityWarning,
+}
+
+// ValidationError represents a validation finding with path context
 type ValidationError struct {
-	Path    string
-	Message string
+	Path     string
+	Message  string
+	Rule     string
+	Severity Severity
+	// Line and Column locate the finding in ValidationOptions.Source,
+	// starting at 1. They are zero when no source is given.
+	Line   int
+	Column int
 }
 
 func (e ValidationError) Error() string {
@@ -23,61 +107,150 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Path, e.Message)
 }
 
-// ValidationResult contains all validation errors
+// ValidationOptions configures ValidateWithOptions
+type ValidationOptions struct {
+	// Disabled lists rules that are not checked
+	Disabled []string
+	// Severities overrides the severity of rules, which is SeverityError by
+	// default and SeverityWarning for the rules documented as warnings
+	Severities map[string]Severity
+	// ResolveRefs checks that every local $ref resolves and points at a
+	// component of the expected kind. References to other documents are not loaded.
+	ResolveRefs bool
+	// Source is the JSON text the document was parsed from. When set, findings
+	// carry the line and column of the value they are about, or of the closest
+	// enclosing value for missing fields.
+	Source []byte
+	// ValidateExamples checks example and examples values of parameters,
+	// headers and media types against their schema
+	ValidateExamples bool
+	// UnusedTags reports declared tags that no operation uses
+	UnusedTags bool
+	// UnusedComponents reports components that cannot be reached from the
+	// paths or other parts of the document, see UnusedComponents
+	UnusedComponents bool
+	// Workers bounds the goroutines of ValidateContext. Zero uses
+	// runtime.GOMAXPROCS.
+	Workers int
+}
+
+// ValidationResult contains all validation findings.
+// Errors holds every finding whatever its severity; only findings with
+// SeverityError make the result invalid.
 type ValidationResult struct {
 	Errors []ValidationError
+	opts   *ValidationOptions
+	root   any              // the document as generic JSON when references are resolved
+	source *sourceMap       // the positions of the values of ValidationOptions.Source
+	tasks  *validationTasks // runs the parts of ValidateContext concurrently
+	// components resolves the schemas and examples of the document
+	components *Components
 }
 
-// Valid returns true if there are no validation errors
+// Valid returns true if there are no findings with SeverityError
 func (r *ValidationResult) Valid() bool {
-	return len(r.Errors) == 0
+	return len(r.BySeverity(SeverityError)) == 0
 }
 
-// Error returns a combined error message
-func (r *ValidationResult) Error() string {
-	if r.Valid() {
-		return ""
+// BySeverity returns the findings with the given severity
+func (r *ValidationResult) BySeverity(severity Severity) []ValidationError {
+	var found []ValidationError
+	for _, e := range r.Errors {
+		if e.Severity == severity {
+			found = append(found, e)
+		}
 	}
+	return found
+}
+
+// Warnings returns the findings with SeverityWarning
+func (r *ValidationResult) Warnings() []ValidationError {
+	return r.BySeverity(SeverityWarning)
+}
+
+// Error returns a combined message of the findings with SeverityError
+func (r *ValidationResult) Error() string {
 	var msgs []string
-	for _, e := range r.Errors {
+	for _, e := range r.BySeverity(SeverityError) {
 		msgs = append(msgs, e.Error())
 	}
 	return strings.Join(msgs, "; ")
 }
 
-func (r *ValidationResult) addError(path, message string) {
-	r.Errors = append(r.Errors, ValidationError{Path: path, Message: message})
+func (r *ValidationResult) addError(rule, path, message string) {
+	severity, ok := defaultSeverities[rule]
+	if !ok {
+		severity = SeverityError
+	}
+	if r.opts != nil {
+		for _, disabled := range r.opts.Disabled {
+			if disabled == rule {
+				return
+			}
+		}
+		if s, ok := r.opts.Severities[rule]; ok {
+			severity = s
+		}
+	}
+	e := ValidationError{Path: path, Message: message, Rule: rule, Severity: severity}
+	if r.source != nil {
+		e.Line, e.Column = r.source.position(e.Pointer())
+	}
+	r.Errors = append(r.Errors, e)
 }
 
 // Validate validates the OpenAPI document against the OpenAPI 3.0 specification
 func (o *OpenAPI) Validate() *ValidationResult {
-	result := &ValidationResult{}
+	return o.ValidateWithOptions(nil)
+}
+
+// ValidateWithOptions validates the OpenAPI document like Validate, skipping
+// disabled rules and reporting rules with the configured severities.
+// A nil opts checks every rule at its default severity.
+func (o *OpenAPI) ValidateWithOptions(opts *ValidationOptions) *ValidationResult {
+	result := &ValidationResult{opts: opts}
+	o.validateDocument(result)
+	return result
+}
 
+// validateDocument runs every check of the document into result
+func (o *OpenAPI) validateDocument(result *ValidationResult) {
 	if o == nil {
-		result.addError("", "OpenAPI document is nil")
-		return result
+		result.addError(RuleDocument, "", "OpenAPI document is nil")
+		return
+	}
+	if result.opts != nil && result.opts.Source != nil {
+		result.source = newSourceMap(result.opts.Source)
+	}
+	if result.opts != nil && result.opts.ResolveRefs {
+		result.loadRoot(o)
 	}
+	result.components = o.Components
 
 	// Required: openapi
 	if o.OpenAPI == "" {
-		result.addError("openapi", "required field is missing")
+		result.addError(RuleRequired, "openapi", "required field is missing")
 	} else if !strings.HasPrefix(o.OpenAPI, "3.0") {
-		result.addError("openapi", fmt.Sprintf("expected 3.0.x version, got %s", o.OpenAPI))
+		result.addError(RuleVersion, "openapi", fmt.Sprintf("expected 3.0.x version, got %s", o.OpenAPI))
 	}
 
 	// Required: info
 	if o.Info == nil {
-		result.addError("info", "required field is missing")
+		result.addError(RuleRequired, "info", "required field is missing")
 	} else {
 		o.Info.validate("info", result)
 	}
 
 	// Required: paths
 	if o.Paths == nil {
-		result.addError("paths", "required field is missing")
+		result.addError(RuleRequired, "paths", "required field is missing")
 	} else {
 		o.Paths.validate("paths", result)
 	}
+	o.validatePathParameters(result)
+	o.validateSecurityRequirements(result)
+	o.validateTags(result)
+	o.validateUnusedComponents(result)
 
 	// Optional: servers
 	for i, server := range o.Servers {
@@ -97,18 +270,16 @@ func (o *OpenAPI) Validate() *ValidationResult {
 			tag.validate(fmt.Sprintf("tags[%d]", i), result)
 		}
 	}
-
-	return result
 }
 
 func (i *Info) validate(path string, result *ValidationResult) {
 	// Required: title
 	if i.Title == "" {
-		result.addError(path+".title", "required field is missing")
+		result.addError(RuleRequired, path+".title", "required field is missing")
 	}
 	// Required: version
 	if i.Version == "" {
-		result.addError(path+".version", "required field is missing")
+		result.addError(RuleRequired, path+".version", "required field is missing")
 	}
 	// Optional: license
 	if i.License != nil {
@@ -119,14 +290,14 @@ func (i *Info) validate(path string, result *ValidationResult) {
 func (l *License) validate(path string, result *ValidationResult) {
 	// Required: name
 	if l.Name == "" {
-		result.addError(path+".name", "required field is missing")
+		result.addError(RuleRequired, path+".name", "required field is missing")
 	}
 }
 
 func (s *Server) validate(path string, result *ValidationResult) {
 	// Required: url
 	if s.URL == "" {
-		result.addError(path+".url", "required field is missing")
+		result.addError(RuleRequired, path+".url", "required field is missing")
 	}
 	// Validate variables
 	for name, v := range s.Variables {
@@ -134,12 +305,13 @@ func (s *Server) validate(path string, result *ValidationResult) {
 			v.validate(fmt.Sprintf("%s.variables[%s]", path, name), result)
 		}
 	}
+	s.validateTemplate(path, result)
 }
 
 func (v *ServerVariable) validate(path string, result *ValidationResult) {
 	// Required: default
 	if v.Default == "" {
-		result.addError(path+".default", "required field is missing")
+		result.addError(RuleRequired, path+".default", "required field is missing")
 	}
 	// If enum is provided, default must be in enum
 	if len(v.Enum) > 0 {
@@ -151,7 +323,7 @@ func (v *ServerVariable) validate(path string, result *ValidationResult) {
 			}
 		}
 		if !found {
-			result.addError(path+".default", "default value must be one of the enum values")
+			result.addError(RuleServerVariable, path+".default", "default value must be one of the enum values")
 		}
 	}
 }
@@ -159,21 +331,28 @@ func (v *ServerVariable) validate(path string, result *ValidationResult) {
 func (p *Paths) validate(path string, result *ValidationResult) {
 	// minProperties: 1 - must have at least one path
 	if len(p.Paths) == 0 && len(p.Extensions) == 0 {
-		result.addError(path, "must contain at least one path")
+		result.addError(RulePaths, path, "must contain at least one path")
 	}
 
 	for pathPattern, pathItem := range p.Paths {
 		// Path must start with /
 		if !strings.HasPrefix(pathPattern, "/") {
-			result.addError(path+"."+pathPattern, "path must start with /")
+			result.addError(RulePathFormat, path+"."+pathPattern, "path must start with /")
 		}
 		if pathItem != nil {
-			pathItem.validate(fmt.Sprintf("%s[%s]", path, pathPattern), result)
+			result.run(func(result *ValidationResult) {
+				pathItem.validate(fmt.Sprintf("%s[%s]", path, pathPattern), result)
+			})
 		}
 	}
 }
 
 func (p *PathItem) validate(path string, result *ValidationResult) {
+	// 3.0 has no path item components, so a reference is only checked to resolve
+	if p.Ref != "" {
+		result.checkRef(path, p.Ref, "")
+	}
+
 	// Validate parameters at path level
 	for i, param := range p.Parameters {
 		if param != nil {
@@ -181,6 +360,13 @@ func (p *PathItem) validate(path string, result *ValidationResult) {
 		}
 	}
 
+	// Validate servers at path level
+	for i, server := range p.Servers {
+		if server != nil {
+			server.validate(fmt.Sprintf("%s.servers[%d]", path, i), result)
+		}
+	}
+
 	// Validate operations
 	if p.Get != nil {
 		p.Get.validate(path+".get", result)
@@ -211,7 +397,7 @@ func (p *PathItem) validate(path string, result *ValidationResult) {
 func (o *Operation) validate(path string, result *ValidationResult) {
 	// Required: responses
 	if o.Responses == nil {
-		result.addError(path+".responses", "required field is missing")
+		result.addError(RuleRequired, path+".responses", "required field is missing")
 	} else {
 		o.Responses.validate(path+".responses", result)
 	}
@@ -228,6 +414,13 @@ func (o *Operation) validate(path string, result *ValidationResult) {
 		o.RequestBody.validate(path+".requestBody", result)
 	}
 
+	// Validate servers
+	for i, server := range o.Servers {
+		if server != nil {
+			server.validate(fmt.Sprintf("%s.servers[%d]", path, i), result)
+		}
+	}
+
 	// Validate callbacks
 	for name, callback := range o.Callbacks {
 		if callback != nil {
@@ -240,14 +433,14 @@ func (r *Responses) validate(path string, result *ValidationResult) {
 	// minProperties: 1 - must have at least one response
 	hasResponse := r.Default != nil || len(r.StatusCode) > 0
 	if !hasResponse {
-		result.addError(path, "must contain at least one response")
+		result.addError(RuleResponses, path, "must contain at least one response")
 	}
 
 	// Validate status code pattern
 	statusCodePattern := regexp.MustCompile(`^[1-5][0-9][0-9]$|^[1-5]XX$`)
 	for code, resp := range r.StatusCode {
 		if !statusCodePattern.MatchString(code) {
-			result.addError(path+"."+code, "invalid status code pattern, must be 3-digit code or pattern like 2XX")
+			result.addError(RuleStatusCode, path+"."+code, "invalid status code pattern, must be 3-digit code or pattern like 2XX")
 		}
 		if resp != nil {
 			resp.validate(path+"."+code, result)
@@ -262,15 +455,17 @@ func (r *Responses) validate(path string, result *ValidationResult) {
 func (r *Response) validate(path string, result *ValidationResult) {
 	// Skip validation for references
 	if r.IsReference() {
+		result.checkRef(path, r.Ref, "responses")
 		return
 	}
 
 	// Required: description
 	if r.Description == "" {
-		result.addError(path+".description", "required field is missing")
+		result.addError(RuleRequired, path+".description", "required field is missing")
 	}
 
 	// Validate headers
+	result.checkContentTypeHeader(path, r.Headers, "content")
 	for name, header := range r.Headers {
 		if header != nil {
 			header.validate(fmt.Sprintf("%s.headers[%s]", path, name), result)
@@ -279,8 +474,11 @@ func (r *Response) validate(path string, result *ValidationResult) {
 
 	// Validate content
 	for mediaType, mt := range r.Content {
+		result.checkMediaType(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType)
 		if mt != nil {
 			mt.validate(fmt.Sprintf("%s.content[%s]", path, mediaType), result)
+			result.checkEncoding(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType, mt)
+			result.checkDirection(fmt.Sprintf("%s.content[%s].schema", path, mediaType), mt.Schema, false)
 		}
 	}
 
@@ -295,27 +493,33 @@ func (r *Response) validate(path string, result *ValidationResult) {
 func (p *Parameter) validate(path string, result *ValidationResult) {
 	// Skip validation for references
 	if p.IsReference() {
+		result.checkRef(path, p.Ref, "parameters")
 		return
 	}
 
 	// Required: name
 	if p.Name == "" {
-		result.addError(path+".name", "required field is missing")
+		result.addError(RuleRequired, path+".name", "required field is missing")
 	}
 
 	// Required: in
 	if p.In == "" {
-		result.addError(path+".in", "required field is missing")
+		result.addError(RuleRequired, path+".in", "required field is missing")
 	} else {
 		validIn := map[string]bool{"query": true, "header": true, "path": true, "cookie": true}
 		if !validIn[p.In] {
-			result.addError(path+".in", fmt.Sprintf("must be one of: query, header, path, cookie; got %s", p.In))
+			result.addError(RuleParameterIn, path+".in", fmt.Sprintf("must be one of: query, header, path, cookie; got %s", p.In))
 		}
 	}
 
+	// Accept, Content-Type and Authorization header parameters are ignored
+	if p.In == "header" {
+		result.checkHeaderParameter(path, p.Name)
+	}
+
 	// Path parameters must have required: true
 	if p.In == "path" && !p.Required {
-		result.addError(path+".required", "path parameters must have required: true")
+		result.addError(RulePathParameterRequired, path+".required", "path parameters must have required: true")
 	}
 
 	// Validate style based on 'in' value
@@ -335,7 +539,7 @@ func (p *Parameter) validate(path string, result *ValidationResult) {
 				}
 			}
 			if !valid {
-				result.addError(path+".style", fmt.Sprintf("invalid style '%s' for parameter in '%s'", p.Style, p.In))
+				result.addError(RuleStyle, path+".style", fmt.Sprintf("invalid style '%s' for parameter in '%s'", p.Style, p.In))
 			}
 		}
 	}
@@ -344,26 +548,36 @@ func (p *Parameter) validate(path string, result *ValidationResult) {
 	hasSchema := p.Schema != nil
 	hasContent := len(p.Content) > 0
 	if !hasSchema && !hasContent {
-		result.addError(path, "must have either 'schema' or 'content'")
+		result.addError(RuleSchemaOrContent, path, "must have either 'schema' or 'content'")
 	}
 	if hasSchema && hasContent {
-		result.addError(path, "cannot have both 'schema' and 'content'")
+		result.addError(RuleSchemaOrContent, path, "cannot have both 'schema' and 'content'")
 	}
 
 	// Example XOR Examples - cannot have both
 	if p.Example != nil && len(p.Examples) > 0 {
-		result.addError(path, "cannot have both 'example' and 'examples'")
+		result.addError(RuleExampleOrExamples, path, "cannot have both 'example' and 'examples'")
 	}
+	result.checkExampleRefs(path, p.Examples)
+	result.checkExamples(path, p.Schema, p.Example, p.Examples)
 
-	// Validate schema
+	// Validate schema or content
 	if p.Schema != nil {
 		p.Schema.validate(path+".schema", result)
 	}
+	for mediaType, mt := range p.Content {
+		result.checkMediaType(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType)
+		if mt != nil {
+			mt.validate(fmt.Sprintf("%s.content[%s]", path, mediaType), result)
+			result.checkEncoding(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType, mt)
+		}
+	}
 }
 
 func (h *Header) validate(path string, result *ValidationResult) {
 	// Skip validation for references
 	if h.IsReference() {
+		result.checkRef(path, h.Ref, "headers")
 		return
 	}
 
@@ -371,38 +585,56 @@ func (h *Header) validate(path string, result *ValidationResult) {
 	hasSchema := h.Schema != nil
 	hasContent := len(h.Content) > 0
 	if !hasSchema && !hasContent {
-		result.addError(path, "must have either 'schema' or 'content'")
+		result.addError(RuleSchemaOrContent, path, "must have either 'schema' or 'content'")
 	}
 	if hasSchema && hasContent {
-		result.addError(path, "cannot have both 'schema' and 'content'")
+		result.addError(RuleSchemaOrContent, path, "cannot have both 'schema' and 'content'")
 	}
 
 	// Example XOR Examples
 	if h.Example != nil && len(h.Examples) > 0 {
-		result.addError(path, "cannot have both 'example' and 'examples'")
+		result.addError(RuleExampleOrExamples, path, "cannot have both 'example' and 'examples'")
 	}
 
 	// Style must be 'simple' for headers
 	if h.Style != "" && h.Style != "simple" {
-		result.addError(path+".style", "header style must be 'simple'")
+		result.addError(RuleStyle, path+".style", "header style must be 'simple'")
+	}
+	result.checkExampleRefs(path, h.Examples)
+	result.checkExamples(path, h.Schema, h.Example, h.Examples)
+
+	// Validate schema or content
+	if h.Schema != nil {
+		h.Schema.validate(path+".schema", result)
+	}
+	for mediaType, mt := range h.Content {
+		result.checkMediaType(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType)
+		if mt != nil {
+			mt.validate(fmt.Sprintf("%s.content[%s]", path, mediaType), result)
+			result.checkEncoding(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType, mt)
+		}
 	}
 }
 
 func (r *RequestBody) validate(path string, result *ValidationResult) {
 	// Skip validation for references
 	if r.IsReference() {
+		result.checkRef(path, r.Ref, "requestBodies")
 		return
 	}
 
 	// Required: content
 	if len(r.Content) == 0 {
-		result.addError(path+".content", "required field is missing")
+		result.addError(RuleRequired, path+".content", "required field is missing")
 	}
 
 	// Validate content
 	for mediaType, mt := range r.Content {
+		result.checkMediaType(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType)
 		if mt != nil {
 			mt.validate(fmt.Sprintf("%s.content[%s]", path, mediaType), result)
+			result.checkEncoding(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType, mt)
+			result.checkDirection(fmt.Sprintf("%s.content[%s].schema", path, mediaType), mt.Schema, true)
 		}
 	}
 }
@@ -410,8 +642,10 @@ func (r *RequestBody) validate(path string, result *ValidationResult) {
 func (m *MediaType) validate(path string, result *ValidationResult) {
 	// Example XOR Examples
 	if m.Example != nil && len(m.Examples) > 0 {
-		result.addError(path, "cannot have both 'example' and 'examples'")
+		result.addError(RuleExampleOrExamples, path, "cannot have both 'example' and 'examples'")
 	}
+	result.checkExampleRefs(path, m.Examples)
+	result.checkExamples(path, m.Schema, m.Example, m.Examples)
 
 	// Validate schema
 	if m.Schema != nil {
@@ -427,6 +661,13 @@ func (m *MediaType) validate(path string, result *ValidationResult) {
 }
 
 func (e *Encoding) validate(path string, result *ValidationResult) {
+	// Validate content types, a comma-separated list
+	if e.ContentType != "" {
+		for _, contentType := range strings.Split(e.ContentType, ",") {
+			result.checkMediaType(path+".contentType", strings.TrimSpace(contentType))
+		}
+	}
+
 	// Validate style
 	if e.Style != "" {
 		validStyles := []string{"form", "spaceDelimited", "pipeDelimited", "deepObject"}
@@ -438,11 +679,12 @@ func (e *Encoding) validate(path string, result *ValidationResult) {
 			}
 		}
 		if !valid {
-			result.addError(path+".style", fmt.Sprintf("invalid encoding style '%s'", e.Style))
+			result.addError(RuleStyle, path+".style", fmt.Sprintf("invalid encoding style '%s'", e.Style))
 		}
 	}
 
 	// Validate headers
+	result.checkContentTypeHeader(path, e.Headers, "contentType")
 	for name, header := range e.Headers {
 		if header != nil {
 			header.validate(fmt.Sprintf("%s.headers[%s]", path, name), result)
@@ -458,6 +700,7 @@ func (s *Schema) validate(path string, result *ValidationResult) {
 
 	// References are valid (resolution is separate concern)
 	if s.Ref != "" {
+		result.checkRef(path, s.Ref, "schemas")
 		return
 	}
 
@@ -468,53 +711,64 @@ func (s *Schema) validate(path string, result *ValidationResult) {
 			"boolean": true, "array": true, "object": true,
 		}
 		if !validTypes[s.Type] {
-			result.addError(path+".type", fmt.Sprintf("invalid type '%s'", s.Type))
+			result.addError(RuleSchemaType, path+".type", fmt.Sprintf("invalid type '%s'", s.Type))
 		}
 	}
 
 	// Array type must have items
 	if s.Type == "array" && s.Items == nil {
-		result.addError(path+".items", "array type must have items defined")
+		result.addError(RuleArrayItems, path+".items", "array type must have items defined")
 	}
 
 	// Validate numeric constraints
 	if s.Minimum != nil && s.Maximum != nil {
 		if *s.Minimum > *s.Maximum {
-			result.addError(path, "minimum cannot be greater than maximum")
+			result.addError(RuleSchemaRange, path, "minimum cannot be greater than maximum")
 		}
 	}
 	if s.MinLength != nil && s.MaxLength != nil {
 		if *s.MinLength > *s.MaxLength {
-			result.addError(path, "minLength cannot be greater than maxLength")
+			result.addError(RuleSchemaRange, path, "minLength cannot be greater than maxLength")
 		}
 	}
 	if s.MinItems != nil && s.MaxItems != nil {
 		if *s.MinItems > *s.MaxItems {
-			result.addError(path, "minItems cannot be greater than maxItems")
+			result.addError(RuleSchemaRange, path, "minItems cannot be greater than maxItems")
 		}
 	}
 	if s.MinProperties != nil && s.MaxProperties != nil {
 		if *s.MinProperties > *s.MaxProperties {
-			result.addError(path, "minProperties cannot be greater than maxProperties")
+			result.addError(RuleSchemaRange, path, "minProperties cannot be greater than maxProperties")
 		}
 	}
 
 	// Validate pattern is valid regex
 	if s.Pattern != "" {
 		if _, err := regexp.Compile(s.Pattern); err != nil {
-			result.addError(path+".pattern", fmt.Sprintf("invalid regex pattern: %v", err))
+			result.addError(RulePattern, path+".pattern", fmt.Sprintf("invalid regex pattern: %v", err))
 		}
 	}
 
+	if s.ReadOnly && s.WriteOnly {
+		result.addError(RuleReadWrite, path, "schema cannot be both readOnly and writeOnly")
+	}
+
 	// Validate required fields exist in properties
 	if len(s.Required) > 0 && len(s.Properties) > 0 {
 		for _, req := range s.Required {
 			if _, exists := s.Properties[req]; !exists {
-				result.addError(path+".required", fmt.Sprintf("required property '%s' not defined in properties", req))
+				result.addError(RuleRequiredProperty, path+".required", fmt.Sprintf("required property '%s' not defined in properties", req))
 			}
 		}
 	}
 
+	result.checkFormat(path+".format", s.Format)
+
+	// Validate discriminator
+	if s.Discriminator != nil {
+		s.validateDiscriminator(path, result)
+	}
+
 	// Validate nested schemas
 	if s.Items != nil {
 		s.Items.validate(path+".items", result)
@@ -550,18 +804,20 @@ func (s *Schema) validate(path string, result *ValidationResult) {
 func (l *Link) validate(path string, result *ValidationResult) {
 	// Skip validation for references
 	if l.IsReference() {
+		result.checkRef(path, l.Ref, "links")
 		return
 	}
 
 	// operationId XOR operationRef - cannot have both
 	if l.OperationId != "" && l.OperationRef != "" {
-		result.addError(path, "cannot have both 'operationId' and 'operationRef'")
+		result.addError(RuleLinkOperation, path, "cannot have both 'operationId' and 'operationRef'")
 	}
 }
 
 func (c *Callback) validate(path string, result *ValidationResult) {
 	// Skip validation for references
 	if c.IsReference() {
+		result.checkRef(path, c.Ref, "callbacks")
 		return
 	}
 
@@ -575,28 +831,27 @@ func (c *Callback) validate(path string, result *ValidationResult) {
 func (t *Tag) validate(path string, result *ValidationResult) {
 	// Required: name
 	if t.Name == "" {
-		result.addError(path+".name", "required field is missing")
+		result.addError(RuleRequired, path+".name", "required field is missing")
 	}
 }
 
 func (c *Components) validate(path string, result *ValidationResult) {
-	// Validate component name pattern
-	namePattern := regexp.MustCompile(`^[a-zA-Z0-9\.\-_]+$`)
-
 	// Validate schemas
 	for name, schema := range c.Schemas {
-		if !namePattern.MatchString(name) {
-			result.addError(fmt.Sprintf("%s.schemas[%s]", path, name), "component name contains invalid characters")
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.schemas[%s]", path, name), "component name contains invalid characters")
 		}
 		if schema != nil {
-			schema.validate(fmt.Sprintf("%s.schemas[%s]", path, name), result)
+			result.run(func(result *ValidationResult) {
+				schema.validate(fmt.Sprintf("%s.schemas[%s]", path, name), result)
+			})
 		}
 	}
 
 	// Validate responses
 	for name, resp := range c.Responses {
-		if !namePattern.MatchString(name) {
-			result.addError(fmt.Sprintf("%s.responses[%s]", path, name), "component name contains invalid characters")
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.responses[%s]", path, name), "component name contains invalid characters")
 		}
 		if resp != nil {
 			resp.validate(fmt.Sprintf("%s.responses[%s]", path, name), result)
@@ -605,8 +860,8 @@ func (c *Components) validate(path string, result *ValidationResult) {
 
 	// Validate parameters
 	for name, param := range c.Parameters {
-		if !namePattern.MatchString(name) {
-			result.addError(fmt.Sprintf("%s.parameters[%s]", path, name), "component name contains invalid characters")
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.parameters[%s]", path, name), "component name contains invalid characters")
 		}
 		if param != nil {
 			param.validate(fmt.Sprintf("%s.parameters[%s]", path, name), result)
@@ -615,18 +870,28 @@ func (c *Components) validate(path string, result *ValidationResult) {
 
 	// Validate requestBodies
 	for name, rb := range c.RequestBodies {
-		if !namePattern.MatchString(name) {
-			result.addError(fmt.Sprintf("%s.requestBodies[%s]", path, name), "component name contains invalid characters")
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.requestBodies[%s]", path, name), "component name contains invalid characters")
 		}
 		if rb != nil {
 			rb.validate(fmt.Sprintf("%s.requestBodies[%s]", path, name), result)
 		}
 	}
 
+	// Validate examples
+	for name, ex := range c.Examples {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.examples[%s]", path, name), "component name contains invalid characters")
+		}
+		if ex != nil && ex.IsReference() {
+			result.checkRef(fmt.Sprintf("%s.examples[%s]", path, name), ex.Ref, "examples")
+		}
+	}
+
 	// Validate headers
 	for name, header := range c.Headers {
-		if !namePattern.MatchString(name) {
-			result.addError(fmt.Sprintf("%s.headers[%s]", path, name), "component name contains invalid characters")
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.headers[%s]", path, name), "component name contains invalid characters")
 		}
 		if header != nil {
 			header.validate(fmt.Sprintf("%s.headers[%s]", path, name), result)
@@ -635,8 +900,8 @@ func (c *Components) validate(path string, result *ValidationResult) {
 
 	// Validate securitySchemes
 	for name, ss := range c.SecuritySchemes {
-		if !namePattern.MatchString(name) {
-			result.addError(fmt.Sprintf("%s.securitySchemes[%s]", path, name), "component name contains invalid characters")
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.securitySchemes[%s]", path, name), "component name contains invalid characters")
 		}
 		if ss != nil {
 			ss.validate(fmt.Sprintf("%s.securitySchemes[%s]", path, name), result)
@@ -645,8 +910,8 @@ func (c *Components) validate(path string, result *ValidationResult) {
 
 	// Validate links
 	for name, link := range c.Links {
-		if !namePattern.MatchString(name) {
-			result.addError(fmt.Sprintf("%s.links[%s]", path, name), "component name contains invalid characters")
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.links[%s]", path, name), "component name contains invalid characters")
 		}
 		if link != nil {
 			link.validate(fmt.Sprintf("%s.links[%s]", path, name), result)
@@ -655,8 +920,8 @@ func (c *Components) validate(path string, result *ValidationResult) {
 
 	// Validate callbacks
 	for name, cb := range c.Callbacks {
-		if !namePattern.MatchString(name) {
-			result.addError(fmt.Sprintf("%s.callbacks[%s]", path, name), "component name contains invalid characters")
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.callbacks[%s]", path, name), "component name contains invalid characters")
 		}
 		if cb != nil {
 			cb.validate(fmt.Sprintf("%s.callbacks[%s]", path, name), result)
@@ -667,16 +932,17 @@ func (c *Components) validate(path string, result *ValidationResult) {
 func (ss *SecurityScheme) validate(path string, result *ValidationResult) {
 	// Skip validation for references
 	if ss.IsReference() {
+		result.checkRef(path, ss.Ref, "securitySchemes")
 		return
 	}
 
 	// Required: type
 	if ss.Type == "" {
-		result.addError(path+".type", "required field is missing")
+		result.addError(RuleRequired, path+".type", "required field is missing")
 	} else {
 		validTypes := map[string]bool{"apiKey": true, "http": true, "oauth2": true, "openIdConnect": true}
 		if !validTypes[ss.Type] {
-			result.addError(path+".type", fmt.Sprintf("must be one of: apiKey, http, oauth2, openIdConnect; got %s", ss.Type))
+			result.addError(RuleSecuritySchemeType, path+".type", fmt.Sprintf("must be one of: apiKey, http, oauth2, openIdConnect; got %s", ss.Type))
 		}
 	}
 
@@ -684,29 +950,29 @@ func (ss *SecurityScheme) validate(path string, result *ValidationResult) {
 	switch ss.Type {
 	case "apiKey":
 		if ss.Name == "" {
-			result.addError(path+".name", "required for apiKey type")
+			result.addError(RuleSecurityScheme, path+".name", "required for apiKey type")
 		}
 		if ss.In == "" {
-			result.addError(path+".in", "required for apiKey type")
+			result.addError(RuleSecurityScheme, path+".in", "required for apiKey type")
 		} else {
 			validIn := map[string]bool{"query": true, "header": true, "cookie": true}
 			if !validIn[ss.In] {
-				result.addError(path+".in", "must be one of: query, header, cookie")
+				result.addError(RuleSecurityScheme, path+".in", "must be one of: query, header, cookie")
 			}
 		}
 	case "http":
 		if ss.Scheme == "" {
-			result.addError(path+".scheme", "required for http type")
+			result.addError(RuleSecurityScheme, path+".scheme", "required for http type")
 		}
 	case "oauth2":
 		if ss.Flows == nil {
-			result.addError(path+".flows", "required for oauth2 type")
+			result.addError(RuleSecurityScheme, path+".flows", "required for oauth2 type")
 		} else {
 			ss.Flows.validate(path+".flows", result)
 		}
 	case "openIdConnect":
 		if ss.OpenIdConnectUrl == "" {
-			result.addError(path+".openIdConnectUrl", "required for openIdConnect type")
+			result.addError(RuleSecurityScheme, path+".openIdConnectUrl", "required for openIdConnect type")
 		}
 	}
 }
@@ -715,49 +981,49 @@ func (f *OAuthFlows) validate(path string, result *ValidationResult) {
 	// At least one flow must be defined
 	hasFlow := f.Implicit != nil || f.Password != nil || f.ClientCredentials != nil || f.AuthorizationCode != nil
 	if !hasFlow {
-		result.addError(path, "at least one OAuth flow must be defined")
+		result.addError(RuleOAuthFlow, path, "at least one OAuth flow must be defined")
 	}
 
 	if f.Implicit != nil {
 		// Implicit requires authorizationUrl
 		if f.Implicit.AuthorizationUrl == "" {
-			result.addError(path+".implicit.authorizationUrl", "required for implicit flow")
+			result.addError(RuleOAuthFlow, path+".implicit.authorizationUrl", "required for implicit flow")
 		}
 		if f.Implicit.Scopes == nil {
-			result.addError(path+".implicit.scopes", "required field is missing")
+			result.addError(RuleRequired, path+".implicit.scopes", "required field is missing")
 		}
 	}
 
 	if f.Password != nil {
 		// Password requires tokenUrl
 		if f.Password.TokenUrl == "" {
-			result.addError(path+".password.tokenUrl", "required for password flow")
+			result.addError(RuleOAuthFlow, path+".password.tokenUrl", "required for password flow")
 		}
 		if f.Password.Scopes == nil {
-			result.addError(path+".password.scopes", "required field is missing")
+			result.addError(RuleRequired, path+".password.scopes", "required field is missing")
 		}
 	}
 
 	if f.ClientCredentials != nil {
 		// ClientCredentials requires tokenUrl
 		if f.ClientCredentials.TokenUrl == "" {
-			result.addError(path+".clientCredentials.tokenUrl", "required for clientCredentials flow")
+			result.addError(RuleOAuthFlow, path+".clientCredentials.tokenUrl", "required for clientCredentials flow")
 		}
 		if f.ClientCredentials.Scopes == nil {
-			result.addError(path+".clientCredentials.scopes", "required field is missing")
+			result.addError(RuleRequired, path+".clientCredentials.scopes", "required field is missing")
 		}
 	}
 
 	if f.AuthorizationCode != nil {
 		// AuthorizationCode requires both authorizationUrl and tokenUrl
 		if f.AuthorizationCode.AuthorizationUrl == "" {
-			result.addError(path+".authorizationCode.authorizationUrl", "required for authorizationCode flow")
+			result.addError(RuleOAuthFlow, path+".authorizationCode.authorizationUrl", "required for authorizationCode flow")
 		}
 		if f.AuthorizationCode.TokenUrl == "" {
-			result.addError(path+".authorizationCode.tokenUrl", "required for authorizationCode flow")
+			result.addError(RuleOAuthFlow, path+".authorizationCode.tokenUrl", "required for authorizationCode flow")
 		}
 		if f.AuthorizationCode.Scopes == nil {
-			result.addError(path+".authorizationCode.scopes", "required field is missing")
+			result.addError(RuleRequired, path+".authorizationCode.scopes", "required field is missing")
 		}
 	}
 }
@@ -0,0 +1,326 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi30
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxRefDepth bounds the references followed while walking schemas and examples
+const maxRefDepth = 64
+
+// schemaMismatch is a place where an example does not match its schema
+type schemaMismatch struct {
+	instance string // JSON pointer into the example
+	schema   string // JSON pointer into the schema
+	reason   string
+}
+
+// checkExamples matches the example and the named examples of a parameter,
+// header or media type against its schema when ValidateExamples is set
+func (r *ValidationResult) checkExamples(path string, schema *Schema, example any, examples map[string]*Example) {
+	if r.opts == nil || !r.opts.ValidateExamples || schema == nil {
+		return
+	}
+	if example != nil {
+		r.checkExample(path+".example", schema, example)
+	}
+	for name, ex := range examples {
+		if ex = r.resolveExample(ex); ex != nil && ex.Value != nil {
+			r.checkExample(fmt.Sprintf("%s.examples[%s].value", path, name), schema, ex.Value)
+		}
+	}
+}
+
+// resolveExample follows local references to components.examples
+func (r *ValidationResult) resolveExample(ex *Example) *Example {
+	const prefix = "#/components/examples/"
+	for depth := 0; ex != nil && ex.Ref != ""; depth++ {
+		if depth == maxRefDepth || r.components == nil || !strings.HasPrefix(ex.Ref, prefix) {
+			return nil
+		}
+		ex = r.components.Examples[strings.TrimPrefix(ex.Ref, prefix)]
+	}
+	return ex
+}
+
+// checkExample reports every place where value does not match schema.
+// Schema pointers are relative to the schema of the finding, or absolute
+// once a $ref into components.schemas has been followed.
+func (r *ValidationResult) checkExample(path string, schema *Schema, value any) {
+	instance, ok := normalizeJSON(value)
+	if !ok {
+		return
+	}
+	for _, m := range r.matchSchema(schema, instance, "", "#", 0) {
+		r.addError(RuleExampleSchema, path,
+			fmt.Sprintf("value at #%s does not match schema at %s: %s", m.instance, m.schema, m.reason))
+	}
+}
+
+// localSchema resolves a local reference to components.schemas
+func (r *ValidationResult) localSchema(ref string) *Schema {
+	const prefix = "#/components/schemas/"
+	if r.components == nil || !strings.HasPrefix(ref, prefix) {
+		return nil
+	}
+	return r.components.Schemas[strings.TrimPrefix(ref, prefix)]
+}
+
+// matchSchema returns the places where value does not match s. instance and
+// schema are the JSON pointers of value and s.
+func (r *ValidationResult) matchSchema(s *Schema, value any, instance, schema string, depth int) []schemaMismatch {
+	if s == nil {
+		return nil
+	}
+	if b := s.BooleanValue(); b != nil {
+		if !*b {
+			return []schemaMismatch{{instance, schema, "no value is allowed"}}
+		}
+		return nil
+	}
+
+	var found []schemaMismatch
+	fail := func(keyword, format string, args ...any) {
+		found = append(found, schemaMismatch{instance, schema + "/" + keyword, fmt.Sprintf(format, args...)})
+	}
+
+	// Siblings of $ref are ignored. References that cannot be followed are
+	// left to ResolveRefs.
+	if s.Ref != "" {
+		if target := r.localSchema(s.Ref); target != nil && depth < maxRefDepth {
+			return r.matchSchema(target, value, instance, s.Ref, depth+1)
+		}
+		return nil
+	}
+
+	// nullable allows null whatever the type
+	if value == nil && s.Nullable {
+		return nil
+	}
+	if s.Type != "" && !matchesAnyType([]string{s.Type}, value) {
+		fail("type", "expected %s, got %s", s.Type, jsonType(value))
+		return found
+	}
+	if len(s.Enum) > 0 && !containsJSON(s.Enum, value) {
+		fail("enum", "value is not one of the enum values")
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.MultipleOf != nil && *s.MultipleOf > 0 {
+			if q := v / *s.MultipleOf; math.Abs(q-math.Round(q)) > 1e-9 {
+				fail("multipleOf", "%v is not a multiple of %v", v, *s.MultipleOf)
+			}
+		}
+		// exclusiveMaximum and exclusiveMinimum are booleans in Draft 4
+		if s.Maximum != nil {
+			if s.ExclusiveMaximum && v >= *s.Maximum {
+				fail("maximum", "%v is not less than %v", v, *s.Maximum)
+			} else if v > *s.Maximum {
+				fail("maximum", "%v is greater than %v", v, *s.Maximum)
+			}
+		}
+		if s.Minimum != nil {
+			if s.ExclusiveMinimum && v <= *s.Minimum {
+				fail("minimum", "%v is not greater than %v", v, *s.Minimum)
+			} else if v < *s.Minimum {
+				fail("minimum", "%v is less than %v", v, *s.Minimum)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MaxLength != nil && length > *s.MaxLength {
+			fail("maxLength", "length %d is greater than %d", length, *s.MaxLength)
+		}
+		if s.MinLength != nil && length < *s.MinLength {
+			fail("minLength", "length %d is less than %d", length, *s.MinLength)
+		}
+		// Invalid patterns are reported by RulePattern
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(v) {
+				fail("pattern", "does not match pattern '%s'", s.Pattern)
+			}
+		}
+	case []any:
+		found = append(found, r.matchArray(s, v, instance, schema, depth)...)
+	case map[string]any:
+		found = append(found, r.matchObject(s, v, instance, schema, depth)...)
+	}
+
+	// Composition
+	for i, sub := range s.AllOf {
+		found = append(found, r.matchSchema(sub, value, instance, fmt.Sprintf("%s/allOf/%d", schema, i), depth)...)
+	}
+	if len(s.AnyOf) > 0 && r.countMatches(s.AnyOf, value, instance, schema, depth) == 0 {
+		fail("anyOf", "value matches none of the anyOf schemas")
+	}
+	if len(s.OneOf) > 0 {
+		if n := r.countMatches(s.OneOf, value, instance, schema, depth); n != 1 {
+			fail("oneOf", "value matches %d of the oneOf schemas, expected exactly 1", n)
+		}
+	}
+	if s.Not != nil && len(r.matchSchema(s.Not, value, instance, schema+"/not", depth)) == 0 {
+		fail("not", "value matches the not schema")
+	}
+	return found
+}
+
+// countMatches returns how many of the schemas value matches
+func (r *ValidationResult) countMatches(schemas []*Schema, value any, instance, schema string, depth int) int {
+	n := 0
+	for i, sub := range schemas {
+		if len(r.matchSchema(sub, value, instance, fmt.Sprintf("%s/%d", schema, i), depth)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// matchArray applies the array keywords of s
+func (r *ValidationResult) matchArray(s *Schema, v []any, instance, schema string, depth int) []schemaMismatch {
+	var found []schemaMismatch
+	fail := func(keyword, format string, args ...any) {
+		found = append(found, schemaMismatch{instance, schema + "/" + keyword, fmt.Sprintf(format, args...)})
+	}
+
+	if s.MaxItems != nil && len(v) > *s.MaxItems {
+		fail("maxItems", "%d items is more than %d", len(v), *s.MaxItems)
+	}
+	if s.MinItems != nil && len(v) < *s.MinItems {
+		fail("minItems", "%d items is fewer than %d", len(v), *s.MinItems)
+	}
+	if s.UniqueItems {
+		for i := range v {
+			for j := i + 1; j < len(v); j++ {
+				if equalJSON(v[i], v[j]) {
+					fail("uniqueItems", "items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+	if s.Items != nil {
+		for i, item := range v {
+			found = append(found, r.matchSchema(s.Items, item, fmt.Sprintf("%s/%d", instance, i), schema+"/items", depth)...)
+		}
+	}
+	return found
+}
+
+// matchObject applies the object keywords of s
+func (r *ValidationResult) matchObject(s *Schema, v map[string]any, instance, schema string, depth int) []schemaMismatch {
+	var found []schemaMismatch
+	fail := func(keyword, format string, args ...any) {
+		found = append(found, schemaMismatch{instance, schema + "/" + keyword, fmt.Sprintf(format, args...)})
+	}
+
+	if s.MaxProperties != nil && len(v) > *s.MaxProperties {
+		fail("maxProperties", "%d properties is more than %d", len(v), *s.MaxProperties)
+	}
+	if s.MinProperties != nil && len(v) < *s.MinProperties {
+		fail("minProperties", "%d properties is fewer than %d", len(v), *s.MinProperties)
+	}
+	for _, name := range s.Required {
+		if _, ok := v[name]; !ok {
+			fail("required", "missing required property '%s'", name)
+		}
+	}
+
+	names := make([]string, 0, len(v))
+	for name := range v {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propInstance := instance + "/" + escapePointer(name)
+		if prop, ok := s.Properties[name]; ok {
+			found = append(found, r.matchSchema(prop, v[name], propInstance, schema+"/properties/"+escapePointer(name), depth)...)
+			continue
+		}
+		if s.AdditionalProperties != nil {
+			if b := s.AdditionalProperties.BooleanValue(); b != nil && !*b {
+				fail("additionalProperties", "property '%s' is not allowed", name)
+				continue
+			}
+			found = append(found, r.matchSchema(s.AdditionalProperties, v[name], propInstance, schema+"/additionalProperties", depth)...)
+		}
+	}
+	return found
+}
+
+// normalizeJSON converts a Go value to its generic JSON form
+func normalizeJSON(value any) (any, bool) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, false
+	}
+	return normalized, true
+}
+
+// equalJSON compares two values by their JSON encoding
+func equalJSON(a, b any) bool {
+	da, errA := json.Marshal(a)
+	db, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(da, db)
+}
+
+// containsJSON reports whether value equals one of values
+func containsJSON(values []any, value any) bool {
+	for _, candidate := range values {
+		if equalJSON(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType names the JSON type of a generic JSON value
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// matchesAnyType reports whether value has one of the JSON Schema types
+func matchesAnyType(types []string, value any) bool {
+	actual := jsonType(value)
+	for _, typ := range types {
+		if typ == actual || (typ == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// escapePointer escapes a JSON pointer reference token
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
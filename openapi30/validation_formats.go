@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi30
+
+import "sync"
+
+// formats is the registry of known formats, see RegisterFormat
+var formats = struct {
+	sync.RWMutex
+	names map[string]bool
+}{names: make(map[string]bool)}
+
+func init() {
+	for _, name := range []string{
+		// OpenAPI data type formats
+		"int32", "int64", "float", "double", "byte", "binary", "date", "date-time", "password",
+		// JSON Schema formats
+		"time", "duration", "email", "idn-email", "hostname", "idn-hostname", "ipv4", "ipv6",
+		"uri", "uri-reference", "iri", "iri-reference", "uri-template", "uuid",
+		"json-pointer", "relative-json-pointer", "regex",
+		// OpenAPI format registry
+		"char", "commonmark", "decimal", "decimal128", "double-int", "html", "int8", "int16",
+		"media-range", "sf-binary", "sf-boolean", "sf-decimal", "sf-integer", "sf-string", "sf-token",
+		"uint8", "unixtime",
+	} {
+		formats.names[name] = true
+	}
+}
+
+// RegisterFormat adds custom formats to the registry of known formats, so
+// that internal conventions such as "snowflake-id" are not reported by RuleFormat
+func RegisterFormat(names ...string) {
+	formats.Lock()
+	defer formats.Unlock()
+	for _, name := range names {
+		formats.names[name] = true
+	}
+}
+
+// IsKnownFormat reports whether the format is registered
+func IsKnownFormat(name string) bool {
+	formats.RLock()
+	defer formats.RUnlock()
+	return formats.names[name]
+}
+
+// checkFormat reports a format that is not registered
+func (r *ValidationResult) checkFormat(path, format string) {
+	if format != "" && !IsKnownFormat(format) {
+		r.addError(RuleFormat, path, "unknown format '"+format+"'")
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi30
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pathTemplatePattern matches the {name} placeholders of a path template
+var pathTemplatePattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// pathOperation is an operation together with its field name in the path item
+type pathOperation struct {
+	field string
+	op    *Operation
+}
+
+// operations returns the operations of the path item in a fixed order
+func (p *PathItem) operations() []pathOperation {
+	var ops []pathOperation
+	for _, candidate := range []pathOperation{
+		{"get", p.Get}, {"put", p.Put}, {"post", p.Post}, {"delete", p.Delete},
+		{"options", p.Options}, {"head", p.Head}, {"patch", p.Patch}, {"trace", p.Trace},
+	} {
+		if candidate.op != nil {
+			ops = append(ops, candidate)
+		}
+	}
+	return ops
+}
+
+// eachOperation calls fn for every operation under paths,
+// with the path of the operation
+func (o *OpenAPI) eachOperation(fn func(path string, op *Operation)) {
+	if o.Paths != nil {
+		for template, item := range o.Paths.Paths {
+			if item == nil || item.Ref != "" {
+				continue
+			}
+			for _, po := range item.operations() {
+				fn(fmt.Sprintf("paths[%s].%s", template, po.field), po.op)
+			}
+		}
+	}
+}
+
+// resolveParameter follows a local parameter reference into components.
+// It returns nil when the reference cannot be followed.
+func (o *OpenAPI) resolveParameter(p *Parameter) *Parameter {
+	const prefix = "#/components/parameters/"
+	if !p.IsReference() {
+		return p
+	}
+	if !strings.HasPrefix(p.Ref, prefix) || o.Components == nil {
+		return nil
+	}
+	target := o.Components.Parameters[strings.TrimPrefix(p.Ref, prefix)]
+	if target == nil || target.IsReference() {
+		return nil
+	}
+	return target
+}
+
+// validatePathParameters checks that every placeholder of a path template is
+// declared as a path parameter of each operation, at path or operation level,
+// and that no path parameter is declared for a placeholder that does not exist
+func (o *OpenAPI) validatePathParameters(result *ValidationResult) {
+	if o.Paths == nil {
+		return
+	}
+	for template, item := range o.Paths.Paths {
+		if item == nil || item.Ref != "" {
+			continue
+		}
+		itemPath := fmt.Sprintf("paths[%s]", template)
+		placeholders := make(map[string]bool)
+		for _, m := range pathTemplatePattern.FindAllStringSubmatch(template, -1) {
+			placeholders[m[1]] = true
+		}
+
+		// Path-level parameters apply to every operation
+		declared := make(map[string]bool)
+		complete := true
+		for i, param := range item.Parameters {
+			if param == nil {
+				continue
+			}
+			resolved := o.resolveParameter(param)
+			if resolved == nil {
+				complete = false
+				continue
+			}
+			if resolved.In == "path" {
+				declared[resolved.Name] = true
+				if !placeholders[resolved.Name] {
+					result.addError(RulePathParameters, fmt.Sprintf("%s.parameters[%d]", itemPath, i),
+						fmt.Sprintf("path parameter '%s' does not match a placeholder in the path", resolved.Name))
+				}
+			}
+		}
+
+		for _, po := range item.operations() {
+			opPath := itemPath + "." + po.field
+			opDeclared := make(map[string]bool, len(declared))
+			for name := range declared {
+				opDeclared[name] = true
+			}
+			opComplete := complete
+			for i, param := range po.op.Parameters {
+				if param == nil {
+					continue
+				}
+				resolved := o.resolveParameter(param)
+				if resolved == nil {
+					opComplete = false
+					continue
+				}
+				if resolved.In == "path" {
+					opDeclared[resolved.Name] = true
+					if !placeholders[resolved.Name] {
+						result.addError(RulePathParameters, fmt.Sprintf("%s.parameters[%d]", opPath, i),
+							fmt.Sprintf("path parameter '%s' does not match a placeholder in the path", resolved.Name))
+					}
+				}
+			}
+			// Unresolved references may declare the missing parameters
+			if !opComplete {
+				continue
+			}
+			for _, m := range pathTemplatePattern.FindAllStringSubmatch(template, -1) {
+				if !opDeclared[m[1]] {
+					result.addError(RulePathParameters, opPath,
+						fmt.Sprintf("placeholder '{%s}' has no path parameter", m[1]))
+					opDeclared[m[1]] = true
+				}
+			}
+		}
+	}
+}
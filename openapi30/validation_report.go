@@ -0,0 +1,174 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi30
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Pointer returns the location of the finding as a JSON pointer (RFC 6901),
+// so paths[/pets].get.parameters[0] becomes /paths/~1pets/get/parameters/0.
+// Findings about the whole document have an empty pointer.
+func (e ValidationError) Pointer() string {
+	var b, token strings.Builder
+	flush := func() {
+		b.WriteByte('/')
+		b.WriteString(escapePointer(token.String()))
+		token.Reset()
+	}
+
+	// Keys in brackets are taken as they are, and may contain dots and brackets
+	depth := 0
+	for i := 0; i < len(e.Path); i++ {
+		c := e.Path[i]
+		switch {
+		case c == '[' && depth == 0:
+			if token.Len() > 0 {
+				flush()
+			}
+			depth++
+		case c == ']' && depth == 1:
+			flush()
+			depth--
+		case c == '.' && depth == 0:
+			if token.Len() > 0 {
+				flush()
+			}
+		default:
+			if c == '[' {
+				depth++
+			} else if c == ']' {
+				depth--
+			}
+			token.WriteByte(c)
+		}
+	}
+	if token.Len() > 0 {
+		flush()
+	}
+	return b.String()
+}
+
+// reportFinding is a finding in the JSON report
+type reportFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Pointer  string `json:"pointer"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// MarshalJSON reports the findings with their rule, severity, path, JSON
+// pointer and message, and their line and column when the source is known,
+// so they can be consumed without parsing Error
+func (r *ValidationResult) MarshalJSON() ([]byte, error) {
+	findings := make([]reportFinding, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		findings = append(findings, reportFinding{
+			Rule:     e.Rule,
+			Severity: e.Severity.String(),
+			Path:     e.Path,
+			Pointer:  e.Pointer(),
+			Message:  e.Message,
+			Line:     e.Line,
+			Column:   e.Column,
+		})
+	}
+	return json.Marshal(struct {
+		Valid  bool            `json:"valid"`
+		Errors []reportFinding `json:"errors"`
+	}{r.Valid(), findings})
+}
+
+// SARIF returns the findings as a SARIF 2.1.0 log, the format read by code
+// scanning tools and editors. uri names the validated document in the
+// locations of the results, with the line and column of findings when the
+// source is known; the JSON pointer of a finding is reported as its logical location.
+func (r *ValidationResult) SARIF(uri string) ([]byte, error) {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type rule struct {
+		ID string `json:"id"`
+	}
+	type artifactLocation struct {
+		URI string `json:"uri"`
+	}
+	type region struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn"`
+	}
+	type physicalLocation struct {
+		ArtifactLocation artifactLocation `json:"artifactLocation"`
+		Region           *region          `json:"region,omitempty"`
+	}
+	type logicalLocation struct {
+		FullyQualifiedName string `json:"fullyQualifiedName"`
+	}
+	type location struct {
+		PhysicalLocation *physicalLocation `json:"physicalLocation,omitempty"`
+		LogicalLocations []logicalLocation `json:"logicalLocations,omitempty"`
+	}
+	type result struct {
+		RuleID    string     `json:"ruleId"`
+		Level     string     `json:"level"`
+		Message   message    `json:"message"`
+		Locations []location `json:"locations,omitempty"`
+	}
+
+	rules := []rule{}
+	seen := make(map[string]bool)
+	results := make([]result, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		if !seen[e.Rule] {
+			seen[e.Rule] = true
+			rules = append(rules, rule{ID: e.Rule})
+		}
+
+		var loc location
+		if uri != "" {
+			loc.PhysicalLocation = &physicalLocation{ArtifactLocation: artifactLocation{URI: uri}}
+			if e.Line > 0 {
+				loc.PhysicalLocation.Region = &region{StartLine: e.Line, StartColumn: e.Column}
+			}
+		}
+		if ptr := e.Pointer(); ptr != "" {
+			loc.LogicalLocations = []logicalLocation{{FullyQualifiedName: ptr}}
+		}
+		res := result{RuleID: e.Rule, Level: sarifLevel(e.Severity), Message: message{Text: e.Error()}}
+		if loc.PhysicalLocation != nil || loc.LogicalLocations != nil {
+			res.Locations = []location{loc}
+		}
+		results = append(results, res)
+	}
+
+	log := map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []any{map[string]any{
+			"tool": map[string]any{"driver": map[string]any{
+				"name":           "genelet/oas",
+				"informationUri": "https://github.com/genelet/oas",
+				"rules":          rules,
+			}},
+			"results": results,
+		}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "note"
+	}
+	return "error"
+}
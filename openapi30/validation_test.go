@@ -5,9 +5,13 @@
 package openapi30
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -678,3 +682,1135 @@ func TestValidateExampleFilesHaveExpectedErrors(t *testing.T) {
 // Helper functions for creating pointers
 func float64Ptr(v float64) *float64 { return &v }
 func intPtr(v int) *int             { return &v }
+
+func TestValidateWithOptions(t *testing.T) {
+	newDoc := func() *OpenAPI {
+		return &OpenAPI{
+			OpenAPI: "3.0.3",
+			Info:    &Info{Title: "Test API", Version: "1.0.0"},
+			Paths: &Paths{
+				Paths: map[string]*PathItem{
+					"/test": {
+						Get: &Operation{
+							Responses: &Responses{
+								StatusCode: map[string]*Response{
+									"200": {
+										Description: "OK",
+										Content: map[string]*MediaType{
+											"application/json": {Schema: &Schema{Type: "array"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	result := newDoc().Validate()
+	if result.Valid() {
+		t.Fatal("Expected array without items to be invalid by default")
+	}
+	if e := result.Errors[0]; e.Rule != RuleArrayItems || e.Severity != SeverityError {
+		t.Errorf("Expected array-items error, got rule=%s severity=%s", e.Rule, e.Severity)
+	}
+
+	result = newDoc().ValidateWithOptions(&ValidationOptions{
+		Severities: map[string]Severity{RuleArrayItems: SeverityWarning},
+	})
+	if !result.Valid() {
+		t.Errorf("Expected downgraded rule to keep document valid, got: %v", result.Error())
+	}
+	if len(result.Warnings()) != 1 {
+		t.Errorf("Expected 1 warning, got %d", len(result.Warnings()))
+	}
+	if result.Error() != "" {
+		t.Errorf("Expected empty error message, got %q", result.Error())
+	}
+
+	result = newDoc().ValidateWithOptions(&ValidationOptions{Disabled: []string{RuleArrayItems}})
+	if len(result.Errors) != 0 {
+		t.Errorf("Expected disabled rule to report nothing, got %v", result.Errors)
+	}
+}
+
+func TestValidateResolveRefs(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{"$ref": "#/components/parameters/Limit"},
+						{"$ref": "#/components/schemas/Pet"}
+					],
+					"responses": {
+						"200": {
+							"description": "OK",
+							"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Missing"}}}
+						},
+						"default": {"$ref": "#/components/responses/Error"}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {"type": "object", "properties": {"owner": {"$ref": "#/components/schemas/Pet/properties/name"}, "name": {"type": "string"}}}
+			},
+			"parameters": {
+				"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}
+			},
+			"responses": {
+				"Error": {"description": "Error"}
+			}
+		}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(data), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if result := api.Validate(); !result.Valid() {
+		t.Errorf("Expected refs to be unchecked by default, got: %v", result.Error())
+	}
+
+	result := api.ValidateWithOptions(&ValidationOptions{ResolveRefs: true})
+	var refErrors []string
+	for _, e := range result.Errors {
+		if e.Rule == RuleRef {
+			refErrors = append(refErrors, e.Error())
+		}
+	}
+	if len(refErrors) != 2 {
+		t.Fatalf("Expected 2 reference errors, got %v", refErrors)
+	}
+	joined := strings.Join(refErrors, "; ")
+	if !strings.Contains(joined, "points at schemas, expected parameters") {
+		t.Errorf("Expected kind mismatch, got %s", joined)
+	}
+	if !strings.Contains(joined, "#/components/schemas/Missing does not resolve") {
+		t.Errorf("Expected unresolved schema, got %s", joined)
+	}
+}
+
+func TestValidateResolveRefsExampleFiles(t *testing.T) {
+	files, err := filepath.Glob("oas-examples/json/*.json")
+	if err != nil {
+		t.Fatalf("Failed to list examples: %v", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		var api OpenAPI
+		if err := json.Unmarshal(data, &api); err != nil {
+			t.Fatalf("Failed to parse %s: %v", file, err)
+		}
+		result := api.ValidateWithOptions(&ValidationOptions{ResolveRefs: true})
+		for _, e := range result.Errors {
+			if e.Rule == RuleRef {
+				t.Errorf("%s: unexpected reference error: %v", file, e)
+			}
+		}
+	}
+}
+
+func TestValidatePathParameters(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets/{id}": {
+				"parameters": [{"$ref": "#/components/parameters/Id"}],
+				"get": {"responses": {"200": {"description": "OK"}}}
+			},
+			"/owners/{ownerId}/pets/{petId}": {
+				"get": {
+					"parameters": [
+						{"name": "ownerId", "in": "path", "required": true, "schema": {"type": "string"}},
+						{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}
+					],
+					"responses": {"200": {"description": "OK"}}
+				}
+			},
+			"/stores/{storeId}": {
+				"get": {
+					"parameters": [{"$ref": "#/components/parameters/Missing"}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		},
+		"components": {"parameters": {"Id": {"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}}}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(data), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, e := range api.Validate().Errors {
+		if e.Rule == RulePathParameters {
+			messages = append(messages, e.Error())
+		}
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 path parameter errors, got %v", messages)
+	}
+	joined := strings.Join(messages, "; ")
+	if !strings.Contains(joined, "paths[/owners/{ownerId}/pets/{petId}].get: placeholder '{petId}' has no path parameter") {
+		t.Errorf("Expected missing placeholder parameter, got %s", joined)
+	}
+	if !strings.Contains(joined, "paths[/owners/{ownerId}/pets/{petId}].get.parameters[1]: path parameter 'id' does not match a placeholder in the path") {
+		t.Errorf("Expected unmatched path parameter, got %s", joined)
+	}
+}
+
+func TestValidateSecurityRequirements(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"security": [{"apiKey": ["read"]}, {}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"security": [{"oauth": ["read"]}, {"missing": []}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		},
+		"components": {
+			"securitySchemes": {
+				"apiKey": {"type": "apiKey", "name": "X-API-Key", "in": "header"},
+				"oauth": {"type": "oauth2", "flows": {"implicit": {"authorizationUrl": "https://example.com/auth", "scopes": {"read": "Read"}}}}
+			}
+		}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(data), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, e := range api.Validate().Errors {
+		if e.Rule == RuleSecurityRequirement {
+			messages = append(messages, e.Error())
+		}
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 security requirement errors, got %v", messages)
+	}
+	joined := strings.Join(messages, "; ")
+	if !strings.Contains(joined, "security[0][apiKey]: scopes are only allowed for oauth2 and openIdConnect schemes; 'apiKey' is apiKey") {
+		t.Errorf("Expected scope error, got %s", joined)
+	}
+	if !strings.Contains(joined, "paths[/pets].get.security[1][missing]: security scheme 'missing' is not declared") {
+		t.Errorf("Expected undeclared scheme error, got %s", joined)
+	}
+}
+
+func TestValidateOAuthScopes(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"security": [{"oauth": ["read", "write", "admin"]}],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		},
+		"components": {
+			"securitySchemes": {
+				"oauth": {
+					"type": "oauth2",
+					"flows": {
+						"implicit": {"authorizationUrl": "https://example.com/auth", "scopes": {"read": "Read"}},
+						"clientCredentials": {"tokenUrl": "https://example.com/token", "scopes": {"write": "Write"}}
+					}
+				}
+			}
+		}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(data), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var scopeErrors []ValidationError
+	for _, e := range api.Validate().Errors {
+		if e.Rule == RuleOAuthScope {
+			scopeErrors = append(scopeErrors, e)
+		}
+	}
+	if len(scopeErrors) != 1 {
+		t.Fatalf("Expected 1 scope error, got %v", scopeErrors)
+	}
+	if scopeErrors[0].Path != "paths[/pets].get.security[0][oauth][2]" {
+		t.Errorf("Expected unknown scope at index 2, got %s", scopeErrors[0].Path)
+	}
+	if !strings.Contains(scopeErrors[0].Message, "scope 'admin' is not declared") {
+		t.Errorf("Expected unknown scope message, got %s", scopeErrors[0].Message)
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"tags": [{"name": "pets"}, {"name": "owners"}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"tags": ["pets", "stores"],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(data), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	result := api.Validate()
+	if !result.Valid() {
+		t.Fatalf("Expected undeclared tags to be warnings, got: %v", result.Error())
+	}
+	warnings := result.Warnings()
+	if len(warnings) != 1 || warnings[0].Rule != RuleUndeclaredTag || !strings.Contains(warnings[0].Message, "tag 'stores' is not declared") {
+		t.Errorf("Expected undeclared tag warning for 'stores', got %v", warnings)
+	}
+
+	result = api.ValidateWithOptions(&ValidationOptions{UnusedTags: true})
+	var unused []string
+	for _, e := range result.Warnings() {
+		if e.Rule == RuleUnusedTag {
+			unused = append(unused, e.Error())
+		}
+	}
+	if len(unused) != 1 || !strings.Contains(unused[0], "tag 'owners' is not used by any operation") {
+		t.Errorf("Expected unused tag warning for 'owners', got %v", unused)
+	}
+}
+
+func TestMediaTypeProblem(t *testing.T) {
+	tests := []struct {
+		mediaType string
+		valid     bool
+	}{
+		{"application/json", true},
+		{"application/json; charset=utf-8", true},
+		{"application/vnd.api+json", true},
+		{"application/*+json", true},
+		{"image/*", true},
+		{"*/*", true},
+		{"text/plain; charset=\"utf-8\"", true},
+		{"application/json; charset", false},
+		{"application", false},
+		{"*/json", false},
+		{"application/", false},
+		{"application/json/x", false},
+		{"application/*+", false},
+		{"appli cation/json", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			problem := mediaTypeProblem(tt.mediaType)
+			if tt.valid && problem != "" {
+				t.Errorf("Expected %q to be valid, got %s", tt.mediaType, problem)
+			}
+			if !tt.valid && problem == "" {
+				t.Errorf("Expected %q to be invalid", tt.mediaType)
+			}
+		})
+	}
+}
+
+func TestValidateMediaTypes(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"post": {
+					"requestBody": {
+						"content": {
+							"application/json; charset": {"schema": {"type": "object"}},
+							"multipart/form-data": {
+								"schema": {"type": "object"},
+								"encoding": {"photo": {"contentType": "image/png, image"}}
+							}
+						}
+					},
+					"responses": {
+						"200": {"description": "OK", "content": {"application/*+json": {}}}
+					}
+				}
+			}
+		}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(data), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, e := range api.Validate().Errors {
+		if e.Rule == RuleMediaType {
+			messages = append(messages, e.Error())
+		}
+	}
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 media type errors, got %v", messages)
+	}
+	joined := strings.Join(messages, "; ")
+	if !strings.Contains(joined, "requestBody.content[application/json; charset]: invalid media type") {
+		t.Errorf("Expected content key error, got %s", joined)
+	}
+	if !strings.Contains(joined, "encoding[photo].contentType: invalid media type 'image'") {
+		t.Errorf("Expected encoding contentType error, got %s", joined)
+	}
+}
+
+func TestValidateServerURLTemplate(t *testing.T) {
+	tests := []struct {
+		name      string
+		server    *Server
+		wantError string
+	}{
+		{
+			name: "valid template",
+			server: &Server{
+				URL:       "https://{env}.example.com:{port}/v1",
+				Variables: map[string]*ServerVariable{"env": {Default: "api"}, "port": {Default: "443"}},
+			},
+		},
+		{name: "relative url", server: &Server{URL: "/v1"}},
+		{name: "unmatched brace", server: &Server{URL: "https://{env.example.com"}, wantError: "malformed url template: unmatched '{'"},
+		{name: "empty variable", server: &Server{URL: "https://{}.example.com"}, wantError: "empty variable name"},
+		{name: "undeclared variable", server: &Server{URL: "https://{env}.example.com"}, wantError: "variable 'env' is not declared in variables"},
+		{
+			name: "unused variable",
+			server: &Server{
+				URL:       "https://example.com",
+				Variables: map[string]*ServerVariable{"env": {Default: "api"}},
+			},
+			wantError: "servers[0].variables[env]: variable is not used in the url",
+		},
+		{
+			name: "invalid after substitution",
+			server: &Server{
+				URL:       "https://example.com:{port}",
+				Variables: map[string]*ServerVariable{"port": {Default: "https"}},
+			},
+			wantError: "invalid url after substituting defaults",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := minimalServerDocument()
+			api.Servers = []*Server{tt.server}
+			result := api.Validate()
+			if tt.wantError == "" {
+				if !result.Valid() {
+					t.Errorf("Expected valid server, got: %v", result.Error())
+				}
+				return
+			}
+			if !strings.Contains(result.Error(), tt.wantError) {
+				t.Errorf("Expected %q, got: %v", tt.wantError, result.Error())
+			}
+		})
+	}
+}
+
+func TestValidateOperationServers(t *testing.T) {
+	api := minimalServerDocument()
+	api.Paths.Paths["/pets"] = &PathItem{
+		Servers: []*Server{{URL: "https://{region}.example.com"}},
+		Get: &Operation{
+			Servers:   []*Server{{URL: "https://example.com}"}},
+			Responses: &Responses{StatusCode: map[string]*Response{"200": {Description: "OK"}}},
+		},
+	}
+
+	msg := api.Validate().Error()
+	if !strings.Contains(msg, "paths[/pets].servers[0].url: variable 'region' is not declared") {
+		t.Errorf("Expected path-level server error, got: %v", msg)
+	}
+	if !strings.Contains(msg, "paths[/pets].get.servers[0].url: malformed url template: unmatched '}'") {
+		t.Errorf("Expected operation server error, got: %v", msg)
+	}
+}
+
+func minimalServerDocument() *OpenAPI {
+	return &OpenAPI{
+		OpenAPI: "3.0.3",
+		Info:    &Info{Title: "Test", Version: "1.0"},
+		Paths:   &Paths{Paths: map[string]*PathItem{"/": {}}},
+	}
+}
+
+func TestValidateExamples(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [
+						{"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 1, "exclusiveMinimum": true}, "example": 1}
+					],
+					"responses": {
+						"200": {
+							"description": "OK",
+							"headers": {
+								"X-Rate-Limit": {"schema": {"type": "integer"}, "example": "many"}
+							},
+							"content": {
+								"application/json": {
+									"schema": {"$ref": "#/components/schemas/Pet"},
+									"example": {"name": "Rex", "tag": null, "status": "lost"}
+								}
+							}
+						}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"tag": {"type": "string", "nullable": true},
+						"status": {"type": "string", "enum": ["available", "sold"]}
+					}
+				}
+			}
+		}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(data), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, e := range api.ValidateWithOptions(&ValidationOptions{ValidateExamples: true}).Errors {
+		if e.Rule == RuleExampleSchema {
+			messages = append(messages, e.Error())
+		}
+	}
+	joined := strings.Join(messages, "; ")
+	wants := []string{
+		"parameters[0].example: value at # does not match schema at #/minimum: 1 is not greater than 1",
+		"headers[X-Rate-Limit].example: value at # does not match schema at #/type: expected integer, got string",
+		"content[application/json].example: value at #/status does not match schema at #/components/schemas/Pet/properties/status/enum",
+	}
+	for _, want := range wants {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected %q, got %s", want, joined)
+		}
+	}
+	if len(messages) != len(wants) {
+		t.Errorf("Expected %d example errors, got %v", len(wants), messages)
+	}
+}
+
+func TestValidateDiscriminator(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {},
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"required": ["petType"],
+					"properties": {"petType": {"type": "string"}},
+					"discriminator": {"propertyName": "petType", "mapping": {"dog": "#/components/schemas/Dog", "cat": "Cat", "bird": "#/components/schemas/Bird"}}
+				},
+				"Dog": {"allOf": [{"$ref": "#/components/schemas/Pet"}]},
+				"Cat": {"allOf": [{"$ref": "#/components/schemas/Pet"}]},
+				"Shape": {
+					"oneOf": [{"$ref": "#/components/schemas/Circle"}, {"$ref": "#/components/schemas/Square"}],
+					"discriminator": {"propertyName": "kind"}
+				},
+				"Circle": {"type": "object", "required": ["kind"], "properties": {"kind": {"type": "string"}}},
+				"Square": {"type": "object", "properties": {"kind": {"type": "integer"}}},
+				"Vehicle": {"type": "object", "properties": {"wheels": {"type": "integer"}}, "discriminator": {"propertyName": "vehicleType"}}
+			}
+		}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(data), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, e := range api.Validate().Errors {
+		if e.Rule == RuleDiscriminator {
+			messages = append(messages, e.Error())
+		}
+	}
+	joined := strings.Join(messages, "; ")
+	wants := []string{
+		"components.schemas[Pet].discriminator.mapping[bird]: mapping target '#/components/schemas/Bird' does not resolve to a schema",
+		"components.schemas[Shape].discriminator.propertyName: property 'kind' must be required in oneOf[1]",
+		"components.schemas[Shape].discriminator.propertyName: property 'kind' must be a string in oneOf[1]",
+		"components.schemas[Vehicle].discriminator.propertyName: property 'vehicleType' is not defined in the schema",
+	}
+	for _, want := range wants {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected %q, got %s", want, joined)
+		}
+	}
+	if len(messages) != len(wants) {
+		t.Errorf("Expected %d discriminator errors, got %v", len(wants), messages)
+	}
+}
+
+func TestValidateFormats(t *testing.T) {
+	api := minimalServerDocument()
+	api.Components = &Components{
+		Schemas: map[string]*Schema{
+			"Event": {
+				Properties: map[string]*Schema{
+					"at":    {Type: "string", Format: "date-time"},
+					"id":    {Type: "string", Format: "snowflake-id"},
+					"email": {Type: "string", Format: "e-mail"},
+				},
+			},
+		},
+	}
+
+	result := api.Validate()
+	if !result.Valid() {
+		t.Fatalf("Expected unknown formats to be warnings, got: %v", result.Error())
+	}
+	if len(result.Warnings()) != 2 {
+		t.Fatalf("Expected 2 format warnings, got %v", result.Warnings())
+	}
+
+	RegisterFormat("snowflake-id")
+	warnings := api.Validate().Warnings()
+	if len(warnings) != 1 || warnings[0].Rule != RuleFormat || warnings[0].Path != "components.schemas[Event].properties[email].format" {
+		t.Errorf("Expected only the e-mail format warning, got %v", warnings)
+	}
+}
+
+func TestValidationErrorPointer(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "", want: ""},
+		{path: "info.title", want: "/info/title"},
+		{path: "paths[/pets/{id}].get.parameters[0]", want: "/paths/~1pets~1{id}/get/parameters/0"},
+		{path: "paths[/v1.0/pets].get", want: "/paths/~1v1.0~1pets/get"},
+		{path: "security[0][oauth~1]", want: "/security/0/oauth~01"},
+		{path: "paths[/a[b]].get", want: "/paths/~1a[b]/get"},
+	}
+	for _, tt := range tests {
+		if got := (ValidationError{Path: tt.path}).Pointer(); got != tt.want {
+			t.Errorf("Pointer(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestValidationResultReport(t *testing.T) {
+	result := &ValidationResult{Errors: []ValidationError{
+		{Path: "info.title", Message: "required field is missing", Rule: "required", Severity: SeverityError},
+		{Path: "paths[/pets].get.tags[0]", Message: "tag 'pets' is not declared", Rule: "undeclared-tag", Severity: SeverityWarning},
+	}}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	want := `{"valid":false,"errors":[` +
+		`{"rule":"required","severity":"error","path":"info.title","pointer":"/info/title","message":"required field is missing"},` +
+		`{"rule":"undeclared-tag","severity":"warning","path":"paths[/pets].get.tags[0]","pointer":"/paths/~1pets/get/tags/0","message":"tag 'pets' is not declared"}]}`
+	if string(data) != want {
+		t.Errorf("Unexpected JSON report:\n%s\nwant:\n%s", data, want)
+	}
+
+	data, err = result.SARIF("api.json")
+	if err != nil {
+		t.Fatalf("Failed to write SARIF: %v", err)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("Failed to parse SARIF: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected SARIF log: %s", data)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || len(run.Results) != 2 {
+		t.Fatalf("Expected 2 rules and 2 results, got %s", data)
+	}
+	warning := run.Results[1]
+	if warning.RuleID != "undeclared-tag" || warning.Level != "warning" {
+		t.Errorf("Unexpected SARIF result: %+v", warning)
+	}
+	if len(warning.Locations) != 1 ||
+		warning.Locations[0].PhysicalLocation.ArtifactLocation.URI != "api.json" ||
+		warning.Locations[0].LogicalLocations[0].FullyQualifiedName != "/paths/~1pets/get/tags/0" {
+		t.Errorf("Unexpected SARIF location: %+v", warning.Locations)
+	}
+}
+
+func TestValidateSourcePositions(t *testing.T) {
+	source := `{
+  "openapi": "3.0.3",
+  "info": {"version": "1.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "tags": ["pets"],
+        "responses": {"200": {"description": "OK"}}
+      }
+    }
+  }
+}`
+
+	var doc OpenAPI
+	if err := json.Unmarshal([]byte(source), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	result := doc.ValidateWithOptions(&ValidationOptions{Source: []byte(source)})
+
+	want := map[string][2]int{
+		"info.title":               {3, 11}, // missing field, located at info
+		"paths[/pets].get.tags[0]": {7, 18},
+	}
+	for _, e := range result.Errors {
+		pos, ok := want[e.Path]
+		if !ok {
+			continue
+		}
+		delete(want, e.Path)
+		if e.Line != pos[0] || e.Column != pos[1] {
+			t.Errorf("%s: expected line %d column %d, got line %d column %d", e.Path, pos[0], pos[1], e.Line, e.Column)
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("Expected findings at %v, got %v", want, result.Errors)
+	}
+
+	// Without a valid source, findings carry no position
+	result = doc.ValidateWithOptions(&ValidationOptions{Source: []byte("{")})
+	for _, e := range result.Errors {
+		if e.Line != 0 || e.Column != 0 {
+			t.Errorf("%s: expected no position, got line %d column %d", e.Path, e.Line, e.Column)
+		}
+	}
+}
+
+func TestValidateUnusedComponents(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"security": [{"apiKey": []}],
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+					}
+				}
+			},
+			"/animals": {
+				"get": {
+					"responses": {
+						"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Animal"}}}}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {"type": "object", "properties": {"owner": {"$ref": "#/components/schemas/Owner"}}},
+				"Owner": {"type": "object"},
+				"Orphan": {"type": "object", "properties": {"child": {"$ref": "#/components/schemas/Orphan~1Child"}}},
+				"Orphan/Child": {"type": "object"},
+				"Animal": {
+					"type": "object",
+					"required": ["kind"],
+					"properties": {"kind": {"type": "string"}},
+					"discriminator": {"propertyName": "kind", "mapping": {"cat": "Cat"}}
+				},
+				"Dog": {"allOf": [{"$ref": "#/components/schemas/Animal"}]},
+				"Cat": {"type": "object"}
+			},
+			"parameters": {
+				"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}
+			},
+			"securitySchemes": {
+				"apiKey": {"type": "apiKey", "name": "key", "in": "header"},
+				"basic": {"type": "http", "scheme": "basic"}
+			}
+		}
+	}`
+
+	var doc OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	want := []string{
+		"#/components/parameters/Limit",
+		"#/components/schemas/Orphan",
+		"#/components/schemas/Orphan~1Child",
+		"#/components/securitySchemes/basic",
+	}
+	if got := doc.UnusedComponents(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Expected unused %v, got %v", want, got)
+	}
+
+	// The rule is opt-in and reports warnings
+	for _, e := range doc.Validate().Errors {
+		if e.Rule == RuleUnusedComponent {
+			t.Errorf("Unexpected finding without UnusedComponents: %v", e)
+		}
+	}
+	result := doc.ValidateWithOptions(&ValidationOptions{UnusedComponents: true})
+	warnings := result.Warnings()
+	var messages []string
+	for _, w := range warnings {
+		if w.Rule == RuleUnusedComponent {
+			messages = append(messages, w.Error())
+		}
+	}
+	if len(messages) != 4 {
+		t.Fatalf("Expected 4 unused component warnings, got %v", messages)
+	}
+	if !strings.Contains(strings.Join(messages, "; "), "components.schemas[Orphan/Child]: component 'Orphan/Child' is never referenced") {
+		t.Errorf("Expected nested orphan to be reported, got %v", messages)
+	}
+}
+
+func TestValidateReadWrite(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"post": {
+					"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+					"responses": {
+						"201": {"description": "Created", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"required": ["id", "name", "password"],
+					"properties": {
+						"id": {"$ref": "#/components/schemas/Id"},
+						"name": {"type": "string"},
+						"password": {"type": "string", "writeOnly": true},
+						"owner": {"type": "object", "required": ["token"], "properties": {"token": {"type": "string", "readOnly": true, "writeOnly": true}}}
+					}
+				},
+				"Id": {"type": "integer", "readOnly": true}
+			}
+		}
+	}`
+
+	var doc OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	result := doc.Validate()
+	var conflicts, directions []string
+	for _, e := range result.Errors {
+		switch e.Rule {
+		case RuleReadWrite:
+			conflicts = append(conflicts, e.Error())
+		case RuleRequiredReadWrite:
+			if e.Severity != SeverityWarning {
+				t.Errorf("Expected a warning, got %v", e)
+			}
+			directions = append(directions, e.Error())
+		}
+	}
+	if len(conflicts) != 1 || !strings.Contains(conflicts[0], "components.schemas[Pet].properties[owner].properties[token]") {
+		t.Errorf("Expected one readOnly and writeOnly conflict, got %v", conflicts)
+	}
+
+	got := strings.Join(directions, "; ")
+	for _, want := range []string{
+		"requestBody.content[application/json].schema.required: required property 'id' is readOnly and cannot be sent in a request",
+		"requestBody.content[application/json].schema.properties[owner].required: required property 'token' is readOnly",
+		"responses.201.content[application/json].schema.required: required property 'password' is writeOnly and cannot be returned in a response",
+		"responses.201.content[application/json].schema.properties[owner].required: required property 'token' is writeOnly",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q, got %v", want, directions)
+		}
+	}
+	if len(directions) != 4 || strings.Contains(got, "'name'") {
+		t.Errorf("Expected 4 direction warnings, got %v", directions)
+	}
+}
+
+func TestValidateEncoding(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"post": {
+					"requestBody": {
+						"content": {
+							"multipart/form-data": {
+								"schema": {
+									"allOf": [{"$ref": "#/components/schemas/Upload"}],
+									"properties": {"name": {"type": "string"}}
+								},
+								"encoding": {
+									"name": {"contentType": "text/plain"},
+									"file": {"contentType": "image/png"},
+									"photo": {"contentType": "image/png"}
+								}
+							},
+							"application/json": {
+								"schema": {"type": "object", "properties": {"name": {"type": "string"}}},
+								"encoding": {"name": {"contentType": "text/plain"}}
+							}
+						}
+					},
+					"responses": {"201": {"description": "Created"}}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Upload": {"type": "object", "properties": {"file": {"type": "string", "format": "binary"}}}
+			}
+		}
+	}`
+
+	var doc OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, e := range doc.Validate().Errors {
+		if e.Rule == RuleEncoding {
+			messages = append(messages, e.Error())
+		}
+	}
+	got := strings.Join(messages, "; ")
+	for _, want := range []string{
+		"requestBody.content[multipart/form-data].encoding[photo]: property 'photo' is not defined in the schema",
+		"requestBody.content[application/json].encoding: encoding only applies to multipart and application/x-www-form-urlencoded content, not 'application/json'",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q, got %v", want, messages)
+		}
+	}
+	if len(messages) != 2 {
+		t.Errorf("Expected 2 encoding errors, got %v", messages)
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	// Every path misses its responses and every schema its array items
+	var paths, schemas []string
+	for i := 0; i < 200; i++ {
+		paths = append(paths, fmt.Sprintf(`"/pets%d": {"get": {}}`, i))
+		schemas = append(schemas, fmt.Sprintf(`"Pet%d": {"type": "array"}`, i))
+	}
+	data := `{"openapi": "3.0.3", "info": {"title": "Test", "version": "1.0"}, "paths": {` +
+		strings.Join(paths, ", ") + `}, "components": {"schemas": {` + strings.Join(schemas, ", ") + `}}}`
+
+	var doc OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	messages := func(result *ValidationResult) []string {
+		var msgs []string
+		for _, e := range result.Errors {
+			msgs = append(msgs, e.Error())
+		}
+		sort.Strings(msgs)
+		return msgs
+	}
+	opts := &ValidationOptions{ResolveRefs: true, Source: []byte(data), Workers: 4}
+	want := messages(doc.ValidateWithOptions(opts))
+	if len(want) < 400 {
+		t.Fatalf("Expected findings for every path and schema, got %d", len(want))
+	}
+
+	result, err := doc.ValidateContext(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := messages(result); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected the findings of ValidateWithOptions, got %d of %d", len(got), len(want))
+	}
+
+	// The findings of a task come where ValidateWithOptions finds them, after
+	// the info and before the undeclared tag
+	single := `{"openapi": "3.0.3", "info": {"title": "", "version": "1.0"}, "paths": {"/pets": {"get": {"tags": ["dogs"]}}}}`
+	var one OpenAPI
+	if err := json.Unmarshal([]byte(single), &one); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	var ordered []string
+	for _, e := range one.Validate().Errors {
+		ordered = append(ordered, e.Error())
+	}
+	if len(ordered) < 3 {
+		t.Fatalf("Expected findings before, in and after the path, got %v", ordered)
+	}
+	result, err = one.ValidateContext(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, e := range result.Errors {
+		if i >= len(ordered) || e.Error() != ordered[i] {
+			t.Fatalf("Expected the findings in the order of ValidateWithOptions %v, got %v", ordered, result.Errors)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if result, err := doc.ValidateContext(ctx, nil); err != context.Canceled || result != nil {
+		t.Errorf("Expected context.Canceled, got %v, %v", result, err)
+	}
+}
+
+func TestValidationResultErrors(t *testing.T) {
+	doc := minimalServerDocument()
+	doc.Info.Title = ""
+	err := doc.Validate().Err()
+	if err == nil {
+		t.Fatal("Expected an error for a missing title")
+	}
+	if !errors.Is(err, RuleError(RuleRequired)) || errors.Is(err, RuleError(RuleVersion)) {
+		t.Errorf("Expected only the required rule to match, got %v", err)
+	}
+	var finding ValidationError
+	if !errors.As(err, &finding) || finding.Path != "info.title" {
+		t.Errorf("Expected the info.title finding, got %v", finding)
+	}
+	joined := errors.Join(doc.Validate().Unwrap()...)
+	if !errors.Is(joined, RuleError(RuleRequired)) || joined.Error() != "info.title: required field is missing" {
+		t.Errorf("Unexpected joined error: %v", joined)
+	}
+
+	if err := minimalServerDocument().Validate().Err(); err != nil {
+		t.Errorf("Expected no error for a valid document, got %v", err)
+	}
+}
+
+func TestValidateIgnoredHeaders(t *testing.T) {
+	data := `{
+		"openapi": "3.0.3",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"post": {
+					"parameters": [
+						{"name": "authorization", "in": "header", "schema": {"type": "string"}},
+						{"name": "Accept", "in": "query", "schema": {"type": "string"}},
+						{"name": "X-Request-ID", "in": "header", "schema": {"type": "string"}}
+					],
+					"requestBody": {
+						"content": {
+							"multipart/form-data": {
+								"schema": {"type": "object", "properties": {"file": {"type": "string"}}},
+								"encoding": {"file": {"headers": {"Content-Type": {"schema": {"type": "string"}}}}}
+							}
+						}
+					},
+					"responses": {
+						"201": {
+							"description": "Created",
+							"headers": {
+								"Content-Type": {"schema": {"type": "string"}},
+								"Location": {"schema": {"type": "string"}}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	var doc OpenAPI
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	var messages []string
+	for _, w := range doc.Validate().Warnings() {
+		if w.Rule == RuleIgnoredHeader {
+			messages = append(messages, w.Error())
+		}
+	}
+	got := strings.Join(messages, "; ")
+	for _, want := range []string{
+		"parameters[0].name: header parameter 'authorization' is ignored, use a security scheme instead",
+		"responses.201.headers[Content-Type]: header 'Content-Type' is ignored, content describes the media type",
+		"encoding[file].headers[Content-Type]: header 'Content-Type' is ignored, contentType describes the media type",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q, got %v", want, messages)
+		}
+	}
+	if len(messages) != 3 {
+		t.Errorf("Expected 3 ignored header warnings, got %v", messages)
+	}
+}
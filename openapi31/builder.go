@@ -0,0 +1,310 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi31
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DocumentBuilder builds an OpenAPI 3.1 document with chained calls:
+//
+//	doc := NewDocument("Pets", "1.0").
+//		AddPath("/pets").Get().OperationID("listPets").
+//		ResponseContent(200, "application/json", NewArraySchema(RefSchema("Pet"))).
+//		Build()
+//
+// Every call changes the document in place, so Build may be called at any
+// point and the builders keep working on the same document afterwards.
+type DocumentBuilder struct {
+	doc *OpenAPI
+}
+
+// NewDocument starts a 3.1.0 document for the title and version of the API
+func NewDocument(title, version string) *DocumentBuilder {
+	return &DocumentBuilder{doc: &OpenAPI{
+		OpenAPI: "3.1.0",
+		Info:    &Info{Title: title, Version: version},
+		Paths:   &Paths{Paths: make(map[string]*PathItem)},
+	}}
+}
+
+// Build returns the document
+func (b *DocumentBuilder) Build() *OpenAPI {
+	return b.doc
+}
+
+// Description sets the description of the API
+func (b *DocumentBuilder) Description(description string) *DocumentBuilder {
+	b.doc.Info.Description = description
+	return b
+}
+
+// Server adds a server
+func (b *DocumentBuilder) Server(url, description string) *DocumentBuilder {
+	b.doc.Servers = append(b.doc.Servers, &Server{URL: url, Description: description})
+	return b
+}
+
+// Tag declares a tag
+func (b *DocumentBuilder) Tag(name, description string) *DocumentBuilder {
+	b.doc.Tags = append(b.doc.Tags, &Tag{Name: name, Description: description})
+	return b
+}
+
+// Security adds a document-level security requirement for one scheme
+func (b *DocumentBuilder) Security(scheme string, scopes ...string) *DocumentBuilder {
+	b.doc.Security = append(b.doc.Security, SecurityRequirement{scheme: nonNilScopes(scopes)})
+	return b
+}
+
+// components returns the components of the document, creating them if needed
+func (b *DocumentBuilder) components() *Components {
+	if b.doc.Components == nil {
+		b.doc.Components = &Components{}
+	}
+	return b.doc.Components
+}
+
+// Schema adds a schema to the components, for RefSchema
+func (b *DocumentBuilder) Schema(name string, schema *Schema) *DocumentBuilder {
+	c := b.components()
+	if c.Schemas == nil {
+		c.Schemas = make(map[string]*Schema)
+	}
+	c.Schemas[name] = schema
+	return b
+}
+
+// SecurityScheme adds a security scheme to the components
+func (b *DocumentBuilder) SecurityScheme(name string, scheme *SecurityScheme) *DocumentBuilder {
+	c := b.components()
+	if c.SecuritySchemes == nil {
+		c.SecuritySchemes = make(map[string]*SecurityScheme)
+	}
+	c.SecuritySchemes[name] = scheme
+	return b
+}
+
+// AddPath returns the builder of the path item for path, adding the path item
+// if the document does not have it yet
+func (b *DocumentBuilder) AddPath(path string) *PathBuilder {
+	item := b.doc.Paths.Get(path)
+	if item == nil {
+		item = &PathItem{}
+		b.doc.Paths.Set(path, item)
+	}
+	return &PathBuilder{doc: b, item: item}
+}
+
+// PathBuilder builds a path item of a DocumentBuilder
+type PathBuilder struct {
+	doc  *DocumentBuilder
+	item *PathItem
+}
+
+// Doc returns the builder of the document
+func (b *PathBuilder) Doc() *DocumentBuilder {
+	return b.doc
+}
+
+// Build returns the document
+func (b *PathBuilder) Build() *OpenAPI {
+	return b.doc.Build()
+}
+
+// Summary sets the summary of the path item
+func (b *PathBuilder) Summary(summary string) *PathBuilder {
+	b.item.Summary = summary
+	return b
+}
+
+// Param adds a parameter shared by the operations of the path item. Path
+// parameters are required.
+func (b *PathBuilder) Param(in, name string, schema *Schema) *PathBuilder {
+	b.item.Parameters = append(b.item.Parameters, newParameter(in, name, schema))
+	return b
+}
+
+// operation returns the builder of the operation in slot, adding it if needed
+func (b *PathBuilder) operation(slot **Operation) *OperationBuilder {
+	if *slot == nil {
+		*slot = NewOperation("")
+	}
+	return &OperationBuilder{path: b, op: *slot}
+}
+
+// Get returns the builder of the GET operation
+func (b *PathBuilder) Get() *OperationBuilder { return b.operation(&b.item.Get) }
+
+// Put returns the builder of the PUT operation
+func (b *PathBuilder) Put() *OperationBuilder { return b.operation(&b.item.Put) }
+
+// Post returns the builder of the POST operation
+func (b *PathBuilder) Post() *OperationBuilder { return b.operation(&b.item.Post) }
+
+// Delete returns the builder of the DELETE operation
+func (b *PathBuilder) Delete() *OperationBuilder { return b.operation(&b.item.Delete) }
+
+// Options returns the builder of the OPTIONS operation
+func (b *PathBuilder) Options() *OperationBuilder { return b.operation(&b.item.Options) }
+
+// Head returns the builder of the HEAD operation
+func (b *PathBuilder) Head() *OperationBuilder { return b.operation(&b.item.Head) }
+
+// Patch returns the builder of the PATCH operation
+func (b *PathBuilder) Patch() *OperationBuilder { return b.operation(&b.item.Patch) }
+
+// Trace returns the builder of the TRACE operation
+func (b *PathBuilder) Trace() *OperationBuilder { return b.operation(&b.item.Trace) }
+
+// OperationBuilder builds an operation of a PathBuilder
+type OperationBuilder struct {
+	path *PathBuilder
+	op   *Operation
+}
+
+// Path returns the builder of the path item, to add its other operations
+func (b *OperationBuilder) Path() *PathBuilder {
+	return b.path
+}
+
+// Doc returns the builder of the document, to add other paths
+func (b *OperationBuilder) Doc() *DocumentBuilder {
+	return b.path.doc
+}
+
+// Build returns the document
+func (b *OperationBuilder) Build() *OpenAPI {
+	return b.path.Build()
+}
+
+// OperationID sets the operationId
+func (b *OperationBuilder) OperationID(id string) *OperationBuilder {
+	b.op.OperationID = id
+	return b
+}
+
+// Summary sets the summary of the operation
+func (b *OperationBuilder) Summary(summary string) *OperationBuilder {
+	b.op.Summary = summary
+	return b
+}
+
+// Description sets the description of the operation
+func (b *OperationBuilder) Description(description string) *OperationBuilder {
+	b.op.Description = description
+	return b
+}
+
+// Tags adds tags to the operation
+func (b *OperationBuilder) Tags(tags ...string) *OperationBuilder {
+	b.op.Tags = append(b.op.Tags, tags...)
+	return b
+}
+
+// Deprecated marks the operation as deprecated
+func (b *OperationBuilder) Deprecated() *OperationBuilder {
+	b.op.Deprecated = true
+	return b
+}
+
+// Security adds a security requirement for one scheme to the operation
+func (b *OperationBuilder) Security(scheme string, scopes ...string) *OperationBuilder {
+	b.op.Security = append(b.op.Security, SecurityRequirement{scheme: nonNilScopes(scopes)})
+	return b
+}
+
+// Param adds a parameter to the operation. Path parameters are required.
+func (b *OperationBuilder) Param(in, name string, schema *Schema) *OperationBuilder {
+	b.op.Parameters = append(b.op.Parameters, newParameter(in, name, schema))
+	return b
+}
+
+// RequestBody adds a media type to the request body of the operation
+func (b *OperationBuilder) RequestBody(mediaType string, schema *Schema, required bool) *OperationBuilder {
+	if b.op.RequestBody == nil {
+		b.op.RequestBody = &RequestBody{}
+	}
+	if b.op.RequestBody.Content == nil {
+		b.op.RequestBody.Content = make(map[string]*MediaType)
+	}
+	b.op.RequestBody.Content[mediaType] = &MediaType{Schema: schema}
+	b.op.RequestBody.Required = required
+	return b
+}
+
+// Response sets the description of the response for an HTTP status code,
+// adding the response if needed
+func (b *OperationBuilder) Response(status int, description string) *OperationBuilder {
+	b.response(status).Description = description
+	return b
+}
+
+// ResponseContent adds a media type to the response for an HTTP status code.
+// A response it adds is described by the status text, as Response can change.
+func (b *OperationBuilder) ResponseContent(status int, mediaType string, schema *Schema) *OperationBuilder {
+	resp := b.response(status)
+	if resp.Content == nil {
+		resp.Content = make(map[string]*MediaType)
+	}
+	resp.Content[mediaType] = &MediaType{Schema: schema}
+	return b
+}
+
+// DefaultResponse sets the description of the default response
+func (b *OperationBuilder) DefaultResponse(description string) *OperationBuilder {
+	if b.op.Responses.Default == nil {
+		b.op.Responses.Default = NewResponse("")
+	}
+	b.op.Responses.Default.Description = description
+	return b
+}
+
+// response returns the response for an HTTP status code, adding it if needed
+func (b *OperationBuilder) response(status int) *Response {
+	responses := b.op.Responses
+	if responses.StatusCode == nil {
+		responses.StatusCode = make(map[string]*Response)
+	}
+	code := strconv.Itoa(status)
+	if responses.StatusCode[code] == nil {
+		responses.StatusCode[code] = NewResponse(http.StatusText(status))
+	}
+	return responses.StatusCode[code]
+}
+
+// newParameter creates a parameter of the builders
+func newParameter(in, name string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: in, Required: in == "path", Schema: schema}
+}
+
+// nonNilScopes returns scopes, or an empty list so that a requirement
+// marshals as [] rather than null
+func nonNilScopes(scopes []string) []string {
+	if scopes == nil {
+		return []string{}
+	}
+	return scopes
+}
+
+// TypeSchema returns a schema of one type, such as "string", or of several,
+// such as "string" and "null"
+func TypeSchema(types ...string) *Schema {
+	if len(types) == 1 {
+		return &Schema{Type: &StringOrStringArray{String: types[0]}}
+	}
+	return &Schema{Type: &StringOrStringArray{Array: types}}
+}
+
+// RefSchema returns a reference to a schema of the components, by name, or to
+// any target when name starts with #
+func RefSchema(name string) *Schema {
+	if strings.HasPrefix(name, "#") {
+		return &Schema{Ref: name}
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
@@ -4,7 +4,11 @@
 
 package openapi31
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
 
 // Components holds a set of reusable objects for different aspects of the OAS
 type Components struct {
@@ -47,3 +51,83 @@ func (c Components) MarshalJSON() ([]byte, error) {
 	alias := componentsAlias(c)
 	return marshalWithExtensions(&alias, c.Extensions)
 }
+
+// componentNamePattern matches the names the specification allows for
+// components, which need no escaping in a reference
+var componentNamePattern = regexp.MustCompile(`^[a-zA-Z0-9\.\-_]+$`)
+
+// addComponent adds a component under a new valid name to a section of the
+// components, creating the map of the section, and returns its reference
+func addComponent[T any](section *map[string]T, key, name string, value T) (string, error) {
+	if !componentNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid component name %q", name)
+	}
+	if _, ok := (*section)[name]; ok {
+		return "", fmt.Errorf("component %s/%s already exists", key, name)
+	}
+	if *section == nil {
+		*section = make(map[string]T)
+	}
+	(*section)[name] = value
+	return "#/components/" + key + "/" + name, nil
+}
+
+// AddSchema adds a schema under a new name and returns its reference, as in
+// "#/components/schemas/" + name
+func (c *Components) AddSchema(name string, value *Schema) (string, error) {
+	return addComponent(&c.Schemas, "schemas", name, value)
+}
+
+// AddResponse adds a response under a new name and returns its reference, as in
+// "#/components/responses/" + name
+func (c *Components) AddResponse(name string, value *Response) (string, error) {
+	return addComponent(&c.Responses, "responses", name, value)
+}
+
+// AddParameter adds a parameter under a new name and returns its reference, as in
+// "#/components/parameters/" + name
+func (c *Components) AddParameter(name string, value *Parameter) (string, error) {
+	return addComponent(&c.Parameters, "parameters", name, value)
+}
+
+// AddExample adds an example under a new name and returns its reference, as in
+// "#/components/examples/" + name
+func (c *Components) AddExample(name string, value *Example) (string, error) {
+	return addComponent(&c.Examples, "examples", name, value)
+}
+
+// AddRequestBody adds a request body under a new name and returns its reference, as in
+// "#/components/requestBodies/" + name
+func (c *Components) AddRequestBody(name string, value *RequestBody) (string, error) {
+	return addComponent(&c.RequestBodies, "requestBodies", name, value)
+}
+
+// AddHeader adds a header under a new name and returns its reference, as in
+// "#/components/headers/" + name
+func (c *Components) AddHeader(name string, value *Header) (string, error) {
+	return addComponent(&c.Headers, "headers", name, value)
+}
+
+// AddSecurityScheme adds a security scheme under a new name and returns its reference, as in
+// "#/components/securitySchemes/" + name
+func (c *Components) AddSecurityScheme(name string, value *SecurityScheme) (string, error) {
+	return addComponent(&c.SecuritySchemes, "securitySchemes", name, value)
+}
+
+// AddLink adds a link under a new name and returns its reference, as in
+// "#/components/links/" + name
+func (c *Components) AddLink(name string, value *Link) (string, error) {
+	return addComponent(&c.Links, "links", name, value)
+}
+
+// AddCallback adds a callback under a new name and returns its reference, as in
+// "#/components/callbacks/" + name
+func (c *Components) AddCallback(name string, value *Callback) (string, error) {
+	return addComponent(&c.Callbacks, "callbacks", name, value)
+}
+
+// AddPathItem adds a path item under a new name and returns its reference, as in
+// "#/components/pathItems/" + name
+func (c *Components) AddPathItem(name string, value *PathItem) (string, error) {
+	return addComponent(&c.PathItems, "pathItems", name, value)
+}
@@ -4,7 +4,12 @@
 
 package openapi31
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+)
 
 // MediaType provides schema and examples for a media type
 type MediaType struct {
@@ -72,3 +77,71 @@ func (e Encoding) MarshalJSON() ([]byte, error) {
 	alias := encodingAlias(e)
 	return marshalWithExtensions(&alias, e.Extensions)
 }
+
+// MatchMediaType returns the key that applies to mediaType, such as the
+// Content-Type of a message, among the keys of a content map. Following the
+// precedence of the specification it prefers an exact match, then the same
+// type and subtype with other parameters, then a type/*+suffix range for a
+// structured syntax suffix such as +json, then type/* and last */*. Case and
+// whitespace are ignored.
+func MatchMediaType(keys []string, mediaType string) (string, bool) {
+	full, base := splitMediaType(mediaType)
+	if base == "" {
+		return "", false
+	}
+	typ, subtype, _ := strings.Cut(base, "/")
+	ranges := []string{base}
+	if i := strings.LastIndexByte(subtype, '+'); i >= 0 {
+		ranges = append(ranges, typ+"/*"+subtype[i:])
+	}
+	ranges = append(ranges, typ+"/*", "*/*")
+
+	sorted := slices.Sorted(slices.Values(keys))
+	for _, key := range sorted {
+		if keyFull, _ := splitMediaType(key); keyFull == full {
+			return key, true
+		}
+	}
+	for _, r := range ranges {
+		for _, key := range sorted {
+			if _, keyBase := splitMediaType(key); keyBase == r {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitMediaType returns a media type, and its type and subtype without
+// parameters, in lower case and without whitespace
+func splitMediaType(mediaType string) (full, base string) {
+	full = strings.ToLower(strings.Join(strings.Fields(mediaType), ""))
+	base, _, _ = strings.Cut(full, ";")
+	return full, base
+}
+
+// GetMediaType returns the content of the request body for mediaType, matched
+// with MatchMediaType, or nil
+func (rb *RequestBody) GetMediaType(mediaType string) *MediaType {
+	if rb == nil {
+		return nil
+	}
+	key, ok := MatchMediaType(slices.Collect(maps.Keys(rb.Content)), mediaType)
+	if !ok {
+		return nil
+	}
+	return rb.Content[key]
+}
+
+// GetMediaType returns the content of the response for mediaType, matched
+// with MatchMediaType, or nil
+func (r *Response) GetMediaType(mediaType string) *MediaType {
+	if r == nil {
+		return nil
+	}
+	key, ok := MatchMediaType(slices.Collect(maps.Keys(r.Content)), mediaType)
+	if !ok {
+		return nil
+	}
+	return r.Content[key]
+}
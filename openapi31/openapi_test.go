@@ -294,3 +294,133 @@ func TestReference(t *testing.T) {
 		t.Errorf("Expected name 'offset', got %s", params[1].Name)
 	}
 }
+
+func TestBuilder(t *testing.T) {
+	api := NewDocument("Pets", "1.0.0").
+		Server("https://api.example.com", "production").
+		Schema("Pet", NewObjectSchema(map[string]*Schema{
+			"id":   TypeSchema("integer"),
+			"name": TypeSchema("string", "null"),
+		}, "id")).
+		AddPath("/pets").Get().OperationID("listPets").
+		Param("query", "limit", TypeSchema("integer")).
+		ResponseContent(200, "application/json", NewArraySchema(RefSchema("Pet"))).
+		Path().Post().OperationID("createPet").
+		RequestBody("application/json", RefSchema("Pet"), true).
+		Response(201, "Created").
+		Doc().AddPath("/pets/{petId}").Param("path", "petId", TypeSchema("string")).
+		Get().OperationID("showPet").
+		Response(200, "A pet").
+		DefaultResponse("Error").
+		Build()
+
+	if result := api.Validate(); !result.Valid() {
+		t.Fatalf("Expected valid document, got errors: %v", result.Error())
+	}
+	if api.OpenAPI != "3.1.0" || api.Info.Title != "Pets" {
+		t.Errorf("Unexpected document header: %s %s", api.OpenAPI, api.Info.Title)
+	}
+
+	pets := api.Paths.Get("/pets")
+	if pets.Get.OperationID != "listPets" || pets.Post.OperationID != "createPet" {
+		t.Errorf("Expected listPets and createPet on /pets, got %+v", pets)
+	}
+	if desc := pets.Get.Responses.StatusCode["200"].Description; desc != "OK" {
+		t.Errorf("Expected status text description 'OK', got %q", desc)
+	}
+	if !pets.Post.RequestBody.Required {
+		t.Error("Expected required request body")
+	}
+	pet := api.Paths.Get("/pets/{petId}")
+	if len(pet.Parameters) != 1 || !pet.Parameters[0].Required {
+		t.Errorf("Expected one required path parameter, got %+v", pet.Parameters)
+	}
+	if pet.Get.Responses.Default.Description != "Error" {
+		t.Error("Expected default response")
+	}
+
+	// AddPath returns the existing path item
+	if NewDocument("A", "1").AddPath("/a").Get().Doc().AddPath("/a").Get().Path().Build().Paths.Get("/a").Get == nil {
+		t.Error("Expected the GET operation to be kept")
+	}
+
+	data, err := json.Marshal(api)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var parsed OpenAPI
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	items := parsed.Paths.Get("/pets").Get.Responses.StatusCode["200"].Content["application/json"].Schema.Items
+	if items.Ref != "#/components/schemas/Pet" {
+		t.Errorf("Expected Pet reference, got %q", items.Ref)
+	}
+}
+
+func TestClone(t *testing.T) {
+	doc := `{
+		"openapi": "3.1.0",
+		"info": {"title": "Clone API", "version": "1.0.0", "x-owner": {"team": "api"}},
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {
+						"200": {"$ref": "#/components/responses/Pets"}
+					}
+				}
+			}
+		},
+		"components": {
+			"schemas": {
+				"Pet": {
+					"type": ["object", "null"],
+					"properties": {"name": {"type": "string"}},
+					"additionalProperties": false
+				}
+			},
+			"responses": {
+				"Pets": {"description": "Pets"}
+			}
+		}
+	}`
+
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(doc), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	cloned := api.Clone()
+
+	original, _ := json.Marshal(&api)
+	data, err := json.Marshal(cloned)
+	if err != nil {
+		t.Fatalf("Failed to marshal clone: %v", err)
+	}
+	if string(data) != string(original) {
+		t.Errorf("Expected clone to marshal as\n%s\ngot\n%s", original, data)
+	}
+
+	resp := cloned.Paths.Get("/pets").Get.Responses.StatusCode["200"]
+	if !resp.IsReference() {
+		t.Error("Expected the reference marker to be kept")
+	}
+	pet := cloned.Components.Schemas["Pet"]
+	if !pet.AdditionalProperties.IsBooleanSchema() {
+		t.Error("Expected the boolean schema to be kept")
+	}
+
+	pet.Type.Array[0] = "array"
+	pet.Properties["name"].Type.String = "integer"
+	cloned.Info.Extensions["x-owner"].(map[string]any)["team"] = "web"
+	if api.Components.Schemas["Pet"].Type.Array[0] != "object" || api.Components.Schemas["Pet"].Properties["name"].Type.String != "string" {
+		t.Error("Expected changes to the cloned schema to leave the original alone")
+	}
+	if api.Info.Extensions["x-owner"].(map[string]any)["team"] != "api" {
+		t.Error("Expected changes to the cloned extensions to leave the original alone")
+	}
+
+	var none *Schema
+	if none.Clone() != nil {
+		t.Error("Expected the clone of a nil schema to be nil")
+	}
+}
@@ -167,6 +167,23 @@ func NewBooleanSchema(value bool) *Schema {
 	return &Schema{boolValue: &value}
 }
 
+// NewObjectSchema creates an object schema with the properties, of which the
+// named ones are required
+func NewObjectSchema(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: &StringOrStringArray{String: "object"}, Properties: properties, Required: required}
+}
+
+// NewStringSchema creates a string schema with the format, such as
+// "date-time", or none when format is empty
+func NewStringSchema(format string) *Schema {
+	return &Schema{Type: &StringOrStringArray{String: "string"}, Format: format}
+}
+
+// NewArraySchema creates an array schema of the items
+func NewArraySchema(items *Schema) *Schema {
+	return &Schema{Type: &StringOrStringArray{String: "array"}, Items: items}
+}
+
 // Discriminator adds support for polymorphism
 type Discriminator struct {
 	PropertyName string            `json:"propertyName"`
@@ -0,0 +1,125 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi31
+
+import (
+	"fmt"
+	"strings"
+)
+
+// propertyLookup is what is known about a property of a schema and its allOf
+type propertyLookup struct {
+	schema   *Schema
+	found    bool
+	required bool
+	unknown  bool // a reference could not be followed
+}
+
+// lookupProperty finds a property in s, its allOf members and the local
+// schemas they reference
+func (r *ValidationResult) lookupProperty(s *Schema, name string, depth int) propertyLookup {
+	var l propertyLookup
+	if s == nil || depth == maxRefDepth {
+		return l
+	}
+	if s.Ref != "" {
+		target := r.localSchema(s.Ref)
+		if target == nil {
+			l.unknown = true
+			return l
+		}
+		return r.lookupProperty(target, name, depth+1)
+	}
+	if prop, ok := s.Properties[name]; ok {
+		l.schema, l.found = prop, true
+	}
+	for _, req := range s.Required {
+		if req == name {
+			l.required = true
+		}
+	}
+	for _, sub := range s.AllOf {
+		m := r.lookupProperty(sub, name, depth+1)
+		if m.found && !l.found {
+			l.schema, l.found = m.schema, true
+		}
+		l.required = l.required || m.required
+		l.unknown = l.unknown || m.unknown
+	}
+	return l
+}
+
+// validateDiscriminator checks that the discriminating property is a required
+// string property of the schema, or of each oneOf and anyOf alternative when
+// the schema declares no properties itself, and that mapping targets resolve
+func (s *Schema) validateDiscriminator(path string, result *ValidationResult) {
+	d := s.Discriminator
+	path += ".discriminator"
+	if d.PropertyName == "" {
+		result.addError(RuleRequired, path+".propertyName", "required field is missing")
+		return
+	}
+
+	type candidate struct {
+		where  string
+		schema *Schema
+	}
+	candidates := []candidate{{"the schema", s}}
+	if len(s.Properties) == 0 && len(s.AllOf) == 0 && len(s.OneOf)+len(s.AnyOf) > 0 {
+		candidates = nil
+		for i, alt := range s.OneOf {
+			candidates = append(candidates, candidate{fmt.Sprintf("oneOf[%d]", i), alt})
+		}
+		for i, alt := range s.AnyOf {
+			candidates = append(candidates, candidate{fmt.Sprintf("anyOf[%d]", i), alt})
+		}
+	}
+
+	name := d.PropertyName
+	for _, c := range candidates {
+		l := result.lookupProperty(c.schema, name, 0)
+		switch {
+		case l.unknown:
+			continue
+		case !l.found:
+			result.addError(RuleDiscriminator, path+".propertyName",
+				fmt.Sprintf("property '%s' is not defined in %s", name, c.where))
+			continue
+		case !l.required:
+			result.addError(RuleDiscriminator, path+".propertyName",
+				fmt.Sprintf("property '%s' must be required in %s", name, c.where))
+		}
+		prop := l.schema
+		if prop != nil && prop.Ref != "" {
+			prop = result.localSchema(prop.Ref)
+		}
+		if prop != nil && !prop.Type.IsEmpty() && !prop.Type.Contains("string") {
+			result.addError(RuleDiscriminator, path+".propertyName",
+				fmt.Sprintf("property '%s' must be a string in %s", name, c.where))
+		}
+	}
+
+	for value, target := range d.Mapping {
+		result.checkMappingTarget(fmt.Sprintf("%s.mapping[%s]", path, value), target)
+	}
+}
+
+// checkMappingTarget reports a discriminator mapping target, a schema name
+// or a reference, that does not resolve to a schema. Targets in other
+// documents are not checked.
+func (r *ValidationResult) checkMappingTarget(path, target string) {
+	switch {
+	case strings.HasPrefix(target, "#"):
+		if r.localSchema(target) != nil {
+			return
+		}
+	case strings.ContainsAny(target, "/:"), strings.HasSuffix(target, ".json"),
+		strings.HasSuffix(target, ".yaml"), strings.HasSuffix(target, ".yml"):
+		return
+	case r.components != nil && r.components.Schemas[target] != nil:
+		return
+	}
+	r.addError(RuleDiscriminator, path, fmt.Sprintf("mapping target '%s' does not resolve to a schema", target))
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi31
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ignoredHeaderParameters are the header parameters the specification says to
+// ignore, with what describes them instead
+var ignoredHeaderParameters = map[string]string{
+	"accept":        "the media types of the responses",
+	"content-type":  "the media types of the request body",
+	"authorization": "a security scheme",
+}
+
+// checkHeaderParameter warns about a header parameter that is ignored
+func (r *ValidationResult) checkHeaderParameter(path, name string) {
+	if instead, ok := ignoredHeaderParameters[strings.ToLower(name)]; ok {
+		r.addError(RuleIgnoredHeader, path+".name", fmt.Sprintf("header parameter '%s' is ignored, use %s instead", name, instead))
+	}
+}
+
+// checkContentTypeHeader warns about a Content-Type header of a response or
+// encoding, which is ignored since field describes the media type
+func (r *ValidationResult) checkContentTypeHeader(path string, headers map[string]*Header, field string) {
+	for name := range headers {
+		if strings.EqualFold(name, "Content-Type") {
+			r.addError(RuleIgnoredHeader, fmt.Sprintf("%s.headers[%s]", path, name),
+				fmt.Sprintf("header '%s' is ignored, %s describes the media type", name, field))
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi31
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	spdxLicenses   = spdxIndex(spdxLicenseIDs)
+	spdxExceptions = spdxIndex(spdxExceptionIDs)
+)
+
+// spdxIndex maps lower-case identifiers to their canonical spelling
+func spdxIndex(ids []string) map[string]string {
+	index := make(map[string]string, len(ids))
+	for _, id := range ids {
+		index[strings.ToLower(id)] = id
+	}
+	return index
+}
+
+// validateIdentifier checks that the identifier is an SPDX license expression
+// of known licenses, and warns when a well-known license has no identifier
+func (l *License) validateIdentifier(path string, result *ValidationResult) {
+	if l.Identifier != "" {
+		if problem := spdxExpressionProblem(l.Identifier); problem != "" {
+			result.addError(RuleLicense, path+".identifier",
+				fmt.Sprintf("invalid SPDX license expression '%s': %s", l.Identifier, problem))
+		}
+		return
+	}
+	if l.URL != "" || l.Name == "" {
+		return
+	}
+	name := strings.ToLower(strings.TrimSpace(l.Name))
+	id, ok := wellKnownLicenseNames[name]
+	if !ok {
+		id, ok = spdxLicenses[name]
+	}
+	if ok {
+		result.addError(RuleLicenseName, path+".identifier",
+			fmt.Sprintf("license '%s' has the SPDX identifier %s, which should be set", l.Name, id))
+	}
+}
+
+// spdxExpressionProblem returns why expr is not a valid SPDX license
+// expression, or "" when it is valid. An expression combines license
+// identifiers, optionally followed by + or WITH an exception, with AND, OR
+// and parentheses. LicenseRef- and DocumentRef- identifiers are accepted.
+func spdxExpressionProblem(expr string) string {
+	p := &spdxParser{tokens: spdxTokens(expr)}
+	if len(p.tokens) == 0 {
+		return "expression is empty"
+	}
+	if problem := p.expression(); problem != "" {
+		return problem
+	}
+	if p.pos < len(p.tokens) {
+		return fmt.Sprintf("unexpected '%s'", p.tokens[p.pos])
+	}
+	return ""
+}
+
+// spdxTokens splits an expression into identifiers, operators and parentheses
+func spdxTokens(expr string) []string {
+	return strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr))
+}
+
+// spdxParser parses the tokens of an SPDX license expression
+type spdxParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *spdxParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// operator reports whether the next token is the operator op. Operators are
+// all upper case or all lower case.
+func (p *spdxParser) operator(op string) bool {
+	t := p.peek()
+	return t == op || t == strings.ToLower(op)
+}
+
+// expression := term { (AND | OR) term }
+func (p *spdxParser) expression() string {
+	if problem := p.term(); problem != "" {
+		return problem
+	}
+	for p.operator("AND") || p.operator("OR") {
+		p.pos++
+		if problem := p.term(); problem != "" {
+			return problem
+		}
+	}
+	return ""
+}
+
+// term := "(" expression ")" | license [WITH exception]
+func (p *spdxParser) term() string {
+	t := p.peek()
+	switch {
+	case t == "":
+		return "expression ends unexpectedly"
+	case t == "(":
+		p.pos++
+		if problem := p.expression(); problem != "" {
+			return problem
+		}
+		if p.peek() != ")" {
+			return "missing ')'"
+		}
+		p.pos++
+		return ""
+	case t == ")" || p.operator("AND") || p.operator("OR") || p.operator("WITH"):
+		return fmt.Sprintf("unexpected '%s'", t)
+	}
+
+	p.pos++
+	if problem := spdxLicenseProblem(t); problem != "" {
+		return problem
+	}
+	if p.operator("WITH") {
+		p.pos++
+		exception := p.peek()
+		if exception == "" || exception == "(" || exception == ")" {
+			return "WITH must be followed by an exception"
+		}
+		p.pos++
+		if _, ok := spdxExceptions[strings.ToLower(exception)]; !ok {
+			return fmt.Sprintf("unknown license exception '%s'", exception)
+		}
+	}
+	return ""
+}
+
+// spdxLicenseProblem checks a single license identifier
+func spdxLicenseProblem(id string) string {
+	if strings.HasPrefix(id, "LicenseRef-") || strings.HasPrefix(id, "DocumentRef-") {
+		return ""
+	}
+	if _, ok := spdxLicenses[strings.ToLower(strings.TrimSuffix(id, "+"))]; !ok {
+		return fmt.Sprintf("unknown license '%s'", id)
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi31
+
+import "fmt"
+
+// validateTags checks that operation tags are declared in the top-level tags
+// and, with UnusedTags, that every declared tag is used by an operation
+func (o *OpenAPI) validateTags(result *ValidationResult) {
+	declared := make(map[string]bool, len(o.Tags))
+	for _, tag := range o.Tags {
+		if tag != nil {
+			declared[tag.Name] = true
+		}
+	}
+
+	used := make(map[string]bool)
+	o.eachOperation(func(path string, op *Operation) {
+		for i, name := range op.Tags {
+			used[name] = true
+			if !declared[name] {
+				result.addError(RuleUndeclaredTag, fmt.Sprintf("%s.tags[%d]", path, i),
+					fmt.Sprintf("tag '%s' is not declared in the top-level tags", name))
+			}
+		}
+	})
+
+	if result.opts == nil || !result.opts.UnusedTags {
+		return
+	}
+	for i, tag := range o.Tags {
+		if tag != nil && !used[tag.Name] {
+			result.addError(RuleUnusedTag, fmt.Sprintf("tags[%d]", i),
+				fmt.Sprintf("tag '%s' is not used by any operation", tag.Name))
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// extractExtensions extracts x-* extension fields from a raw JSON map
+func extractExtensions(raw map[string]json.RawMessage, knownFields []string) map[string]any {
+	known := make(map[string]bool)
+	for _, f := range knownFields {
+		known[f] = true
+	}
+
+	extensions := make(map[string]any)
+	for key, value := range raw {
+		if strings.HasPrefix(key, "x-") && !known[key] {
+			var v any
+			if err := json.Unmarshal(value, &v); err == nil {
+				extensions[key] = v
+			}
+		}
+	}
+
+	if len(extensions) == 0 {
+		return nil
+	}
+	return extensions
+}
+
+// marshalWithExtensions marshals a struct along with its extensions
+func marshalWithExtensions(v any, extensions map[string]any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(extensions) == 0 {
+		return data, nil
+	}
+
+	// Merge extensions into the JSON object
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	for key, value := range extensions {
+		extData, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = extData
+	}
+
+	return json.Marshal(m)
+}
+
+// ErrExtensionNotFound is returned by the GetExtension helpers when the
+// extensions have no such key
+var ErrExtensionNotFound = errors.New("extension not found")
+
+// GetExtensionString returns the extension key of extensions as a string
+func GetExtensionString(extensions map[string]any, key string) (string, error) {
+	v, ok := extensions[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrExtensionNotFound, key)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("extension %s is %s, not a string", key, jsonKind(v))
+	}
+	return s, nil
+}
+
+// GetExtensionBool returns the extension key of extensions as a bool
+func GetExtensionBool(extensions map[string]any, key string) (bool, error) {
+	v, ok := extensions[key]
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrExtensionNotFound, key)
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("extension %s is %s, not a boolean", key, jsonKind(v))
+	}
+	return b, nil
+}
+
+// GetExtensionInt returns the extension key of extensions as an int. Decoded
+// JSON numbers are float64, so the value must be a whole number in range.
+func GetExtensionInt(extensions map[string]any, key string) (int, error) {
+	v, ok := extensions[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrExtensionNotFound, key)
+	}
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int64:
+		if int64(int(n)) == n {
+			return int(n), nil
+		}
+	case float64:
+		if n == math.Trunc(n) && n >= math.MinInt && n < math.MaxInt {
+			return int(n), nil
+		}
+	case json.Number:
+		if i, err := n.Int64(); err == nil && int64(int(i)) == i {
+			return int(i), nil
+		}
+	default:
+		return 0, fmt.Errorf("extension %s is %s, not an integer", key, jsonKind(v))
+	}
+	return 0, fmt.Errorf("extension %s is %v, not an integer", key, v)
+}
+
+// GetExtensionObject decodes the extension key of extensions into out, which
+// must be a pointer, through its JSON form
+func GetExtensionObject(extensions map[string]any, key string, out any) error {
+	v, ok := extensions[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExtensionNotFound, key)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("extension %s: %w", key, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("extension %s: %w", key, err)
+	}
+	return nil
+}
+
+// jsonKind names the JSON type of a decoded extension value
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case float64, int, int64, json.Number:
+		return "a number"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// MediaType provides schema and examples for a media type.
+// ItemSchema describes each item of a sequential media type such as
+// application/jsonl or text/event-stream.
+// It can also represent a Reference (when isReference is true).
+type MediaType struct {
+	// Internal marker for reference
+	isReference bool
+
+	// Reference fields (used when isReference is true)
+	Ref         string `json:"$ref,omitempty"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"` // Shared with media type description
+
+	// MediaType fields
+	Schema         *Schema              `json:"schema,omitempty"`
+	ItemSchema     *Schema              `json:"itemSchema,omitempty"`
+	Example        any                  `json:"example,omitempty"`
+	Examples       map[string]*Example  `json:"examples,omitempty"`
+	Encoding       map[string]*Encoding `json:"encoding,omitempty"`
+	PrefixEncoding []*Encoding          `json:"prefixEncoding,omitempty"`
+	ItemEncoding   *Encoding            `json:"itemEncoding,omitempty"`
+	Extensions     map[string]any       `json:"-"`
+}
+
+var mediaTypeKnownFields = []string{
+	"$ref", "summary", "description", "schema", "itemSchema", "example", "examples",
+	"encoding", "prefixEncoding", "itemEncoding",
+}
+
+// IsReference checks if this media type is actually a reference ($ref)
+func (mt *MediaType) IsReference() bool {
+	if mt == nil {
+		return false
+	}
+	return mt.isReference
+}
+
+// NewMediaTypeReference creates a media type that is actually a reference
+func NewMediaTypeReference(ref string) *MediaType {
+	return &MediaType{isReference: true, Ref: ref}
+}
+
+type mediaTypeAlias MediaType
+
+type mediaTypeRefOnly struct {
+	Ref         string `json:"$ref"`
+	Summary     string `json:"summary,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (mt *MediaType) UnmarshalJSON(data []byte) error {
+	var alias mediaTypeAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*mt = MediaType(alias)
+	if mt.Ref != "" {
+		mt.isReference = true
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	mt.Extensions = extractExtensions(raw, mediaTypeKnownFields)
+	return nil
+}
+
+func (mt MediaType) MarshalJSON() ([]byte, error) {
+	if mt.IsReference() {
+		ref := mediaTypeRefOnly{
+			Ref:         mt.Ref,
+			Summary:     mt.Summary,
+			Description: mt.Description,
+		}
+		return marshalWithExtensions(&ref, mt.Extensions)
+	}
+	alias := mediaTypeAlias(mt)
+	return marshalWithExtensions(&alias, mt.Extensions)
+}
+
+// Encoding defines encoding for a single property or item.
+// Nested encodings apply to multipart parts that are themselves multipart.
+type Encoding struct {
+	ContentType    string               `json:"contentType,omitempty"`
+	Headers        map[string]*Header   `json:"headers,omitempty"`
+	Style          string               `json:"style,omitempty"`
+	Explode        *bool                `json:"explode,omitempty"`
+	AllowReserved  bool                 `json:"allowReserved,omitempty"`
+	Encoding       map[string]*Encoding `json:"encoding,omitempty"`
+	PrefixEncoding []*Encoding          `json:"prefixEncoding,omitempty"`
+	ItemEncoding   *Encoding            `json:"itemEncoding,omitempty"`
+	Extensions     map[string]any       `json:"-"`
+}
+
+var encodingKnownFields = []string{
+	"contentType", "headers", "style", "explode", "allowReserved",
+	"encoding", "prefixEncoding", "itemEncoding",
+}
+
+type encodingAlias Encoding
+
+func (e *Encoding) UnmarshalJSON(data []byte) error {
+	var alias encodingAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*e = Encoding(alias)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	e.Extensions = extractExtensions(raw, encodingKnownFields)
+	return nil
+}
+
+func (e Encoding) MarshalJSON() ([]byte, error) {
+	alias := encodingAlias(e)
+	return marshalWithExtensions(&alias, e.Extensions)
+}
+
+// MatchMediaType returns the key that applies to mediaType, such as the
+// Content-Type of a message, among the keys of a content map. Following the
+// precedence of the specification it prefers an exact match, then the same
+// type and subtype with other parameters, then a type/*+suffix range for a
+// structured syntax suffix such as +json, then type/* and last */*. Case and
+// whitespace are ignored.
+func MatchMediaType(keys []string, mediaType string) (string, bool) {
+	full, base := splitMediaType(mediaType)
+	if base == "" {
+		return "", false
+	}
+	typ, subtype, _ := strings.Cut(base, "/")
+	ranges := []string{base}
+	if i := strings.LastIndexByte(subtype, '+'); i >= 0 {
+		ranges = append(ranges, typ+"/*"+subtype[i:])
+	}
+	ranges = append(ranges, typ+"/*", "*/*")
+
+	sorted := slices.Sorted(slices.Values(keys))
+	for _, key := range sorted {
+		if keyFull, _ := splitMediaType(key); keyFull == full {
+			return key, true
+		}
+	}
+	for _, r := range ranges {
+		for _, key := range sorted {
+			if _, keyBase := splitMediaType(key); keyBase == r {
+				return key, true
+			}
+		}
+	}
+	return "", false
+}
+
+// splitMediaType returns a media type, and its type and subtype without
+// parameters, in lower case and without whitespace
+func splitMediaType(mediaType string) (full, base string) {
+	full = strings.ToLower(strings.Join(strings.Fields(mediaType), ""))
+	base, _, _ = strings.Cut(full, ";")
+	return full, base
+}
+
+// GetMediaType returns the content of the request body for mediaType, matched
+// with MatchMediaType, or nil
+func (rb *RequestBody) GetMediaType(mediaType string) *MediaType {
+	if rb == nil {
+		return nil
+	}
+	key, ok := MatchMediaType(slices.Collect(maps.Keys(rb.Content)), mediaType)
+	if !ok {
+		return nil
+	}
+	return rb.Content[key]
+}
+
+// GetMediaType returns the content of the response for mediaType, matched
+// with MatchMediaType, or nil
+func (r *Response) GetMediaType(mediaType string) *MediaType {
+	if r == nil {
+		return nil
+	}
+	key, ok := MatchMediaType(slices.Collect(maps.Keys(r.Content)), mediaType)
+	if !ok {
+		return nil
+	}
+	return r.Content[key]
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const streamingDoc = `{
+	"openapi": "3.2.0",
+	"$self": "https://example.com/openapi.json",
+	"info": {"title": "Streaming API", "version": "1.0.0"},
+	"servers": [{"url": "https://api.example.com", "name": "production"}],
+	"tags": [
+		{"name": "pets", "summary": "Pets", "kind": "nav"},
+		{"name": "cats", "parent": "pets", "kind": "nav"}
+	],
+	"paths": {
+		"/pets": {
+			"query": {
+				"tags": ["cats"],
+				"parameters": [
+					{"name": "filter", "in": "querystring", "content": {"application/x-www-form-urlencoded": {"schema": {"type": "object"}}}}
+				],
+				"responses": {
+					"200": {
+						"summary": "Matching pets",
+						"content": {
+							"application/jsonl": {
+								"itemSchema": {"$ref": "#/components/schemas/Pet"}
+							},
+							"application/json": {"$ref": "#/components/mediaTypes/PetList"}
+						}
+					}
+				}
+			},
+			"additionalOperations": {
+				"LINK": {"responses": {"204": {"description": "Linked"}}}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"Pet": {
+				"type": "object",
+				"properties": {"kind": {"type": "string"}},
+				"discriminator": {"propertyName": "kind", "defaultMapping": "#/components/schemas/Pet"},
+				"xml": {"nodeType": "element"}
+			}
+		},
+		"mediaTypes": {
+			"PetList": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}
+		},
+		"examples": {
+			"Cat": {"dataValue": {"kind": "cat"}, "serializedValue": "{\"kind\":\"cat\"}"}
+		},
+		"securitySchemes": {
+			"device": {
+				"type": "oauth2",
+				"oauth2MetadataUrl": "https://auth.example.com/.well-known/oauth-authorization-server",
+				"flows": {
+					"deviceAuthorization": {
+						"deviceAuthorizationUrl": "https://auth.example.com/device",
+						"tokenUrl": "https://auth.example.com/token",
+						"scopes": {}
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestParse32Fields(t *testing.T) {
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(streamingDoc), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+
+	if api.Self != "https://example.com/openapi.json" {
+		t.Errorf("Expected $self, got %s", api.Self)
+	}
+	if api.Servers[0].Name != "production" {
+		t.Errorf("Expected server name 'production', got %s", api.Servers[0].Name)
+	}
+	if tag := api.Tags[1]; tag.Parent != "pets" || tag.Kind != "nav" {
+		t.Errorf("Expected nested nav tag, got parent=%s kind=%s", tag.Parent, tag.Kind)
+	}
+
+	item := api.Paths.Get("/pets")
+	if item.Query == nil {
+		t.Fatal("Expected query operation")
+	}
+	if item.AdditionalOperations["LINK"] == nil {
+		t.Error("Expected LINK additional operation")
+	}
+	if len(item.Extensions) != 0 {
+		t.Errorf("Expected no extensions, got %v", item.Extensions)
+	}
+	if p := item.Query.Parameters[0]; p.In != "querystring" {
+		t.Errorf("Expected querystring parameter, got %s", p.In)
+	}
+
+	resp := item.Query.Responses.StatusCode["200"]
+	if resp.Summary != "Matching pets" {
+		t.Errorf("Expected response summary, got %s", resp.Summary)
+	}
+	if mt := resp.Content["application/jsonl"]; mt.ItemSchema == nil || mt.ItemSchema.Ref != "#/components/schemas/Pet" {
+		t.Errorf("Expected itemSchema reference, got %+v", mt.ItemSchema)
+	}
+	if mt := resp.Content["application/json"]; !mt.IsReference() || mt.Ref != "#/components/mediaTypes/PetList" {
+		t.Errorf("Expected media type reference, got %+v", mt)
+	}
+
+	pet := api.Components.Schemas["Pet"]
+	if pet.Discriminator.DefaultMapping != "#/components/schemas/Pet" {
+		t.Errorf("Expected defaultMapping, got %s", pet.Discriminator.DefaultMapping)
+	}
+	if pet.XML.NodeType != "element" {
+		t.Errorf("Expected nodeType 'element', got %s", pet.XML.NodeType)
+	}
+	if api.Components.MediaTypes["PetList"] == nil {
+		t.Error("Expected PetList media type component")
+	}
+	if ex := api.Components.Examples["Cat"]; ex.SerializedValue != `{"kind":"cat"}` || ex.DataValue == nil {
+		t.Errorf("Expected dataValue and serializedValue, got %+v", ex)
+	}
+
+	device := api.Components.SecuritySchemes["device"]
+	if device.OAuth2MetadataUrl == "" || device.Flows.DeviceAuthorization == nil ||
+		device.Flows.DeviceAuthorization.DeviceAuthorizationUrl != "https://auth.example.com/device" {
+		t.Errorf("Expected device authorization flow, got %+v", device)
+	}
+
+	if result := api.Validate(); !result.Valid() {
+		t.Errorf("Expected valid document, got errors: %v", result.Error())
+	}
+}
+
+func TestRoundTrip32(t *testing.T) {
+	var api OpenAPI
+	if err := json.Unmarshal([]byte(streamingDoc), &api); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	data, err := json.Marshal(&api)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	var original, roundTripped any
+	if err := json.Unmarshal([]byte(streamingDoc), &original); err != nil {
+		t.Fatalf("Failed to parse original: %v", err)
+	}
+	if err := json.Unmarshal(data, &roundTripped); err != nil {
+		t.Fatalf("Failed to parse output: %v", err)
+	}
+	if !reflect.DeepEqual(original, roundTripped) {
+		t.Errorf("Round trip mismatch:\n%s", data)
+	}
+}
+
+func TestConstructors(t *testing.T) {
+	op := NewOperation("listEvents")
+	resp := NewResponse("Events")
+	resp.Content = map[string]*MediaType{"application/json": {Schema: NewArraySchema(NewObjectSchema(map[string]*Schema{
+		"at":   NewStringSchema("date-time"),
+		"name": NewStringSchema(""),
+	}, "at"))}}
+	op.Responses.StatusCode["200"] = resp
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var got, want map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	expected := `{"operationId": "listEvents", "responses": {"200": {"description": "Events", "content": {"application/json": {"schema": {"type": "array", "items": {"type": "object", "required": ["at"], "properties": {"at": {"type": "string", "format": "date-time"}, "name": {"type": "string"}}}}}}}}}`
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestPathItemOperations(t *testing.T) {
+	item := &PathItem{}
+	if !item.SetOperation("GET", NewOperation("list")) || !item.SetOperation(MethodQuery, NewOperation("search")) {
+		t.Fatal("Expected fixed methods to be set")
+	}
+	if !item.SetOperation("LINK", NewOperation("link")) {
+		t.Fatal("Expected an additional operation to be set")
+	}
+	if item.SetOperation("", NewOperation("none")) {
+		t.Error("Expected an empty method to be rejected")
+	}
+	if item.Get == nil || item.Query == nil || item.AdditionalOperations["LINK"] == nil {
+		t.Fatalf("Expected get, query and LINK, got %+v", item)
+	}
+	if op := item.GetOperation("link"); op == nil || op.OperationID != "link" {
+		t.Errorf("Expected case-insensitive lookup of LINK, got %+v", op)
+	}
+
+	var methods []string
+	for method := range item.Operations() {
+		methods = append(methods, method)
+	}
+	if want := []string{"get", "query", "LINK"}; !reflect.DeepEqual(methods, want) {
+		t.Errorf("Expected %v, got %v", want, methods)
+	}
+
+	item.SetOperation("Link", nil)
+	item.SetOperation(MethodGet, nil)
+	if len(item.AdditionalOperations) != 0 || item.Get != nil {
+		t.Errorf("Expected LINK and get to be removed, got %+v", item)
+	}
+	if !IsFixedMethod("TRACE") || IsFixedMethod("LINK") {
+		t.Error("Unexpected IsFixedMethod result")
+	}
+}
+
+func TestComponentsAdd(t *testing.T) {
+	var c Components
+	if ref, err := c.AddMediaType("Json", &MediaType{}); err != nil || ref != "#/components/mediaTypes/Json" {
+		t.Errorf("AddMediaType() = %q, %v", ref, err)
+	}
+	if ref, err := c.AddPathItem("Pets", &PathItem{}); err != nil || ref != "#/components/pathItems/Pets" {
+		t.Errorf("AddPathItem() = %q, %v", ref, err)
+	}
+	if _, err := c.AddPathItem("Pets", &PathItem{}); err == nil {
+		t.Error("Expected an error for a name in use")
+	}
+	if _, err := c.AddSecurityScheme("api/key", &SecurityScheme{Type: "apiKey"}); err == nil {
+		t.Error("Expected an error for an invalid name")
+	}
+}
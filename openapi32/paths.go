@@ -0,0 +1,258 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"encoding/json"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Paths holds the relative paths to the individual endpoints and their operations
+type Paths struct {
+	Paths      map[string]*PathItem `json:"-"`
+	Extensions map[string]any       `json:"-"`
+}
+
+// Get returns the PathItem for the given path
+func (p *Paths) Get(path string) *PathItem {
+	if p == nil || p.Paths == nil {
+		return nil
+	}
+	return p.Paths[path]
+}
+
+// Set sets the PathItem for the given path
+func (p *Paths) Set(path string, item *PathItem) {
+	if p.Paths == nil {
+		p.Paths = make(map[string]*PathItem)
+	}
+	p.Paths[path] = item
+}
+
+func (p *Paths) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	p.Paths = make(map[string]*PathItem)
+	p.Extensions = make(map[string]any)
+
+	for key, value := range raw {
+		if strings.HasPrefix(key, "x-") {
+			var ext any
+			if err := json.Unmarshal(value, &ext); err != nil {
+				return err
+			}
+			p.Extensions[key] = ext
+		} else if strings.HasPrefix(key, "/") {
+			var pathItem PathItem
+			if err := json.Unmarshal(value, &pathItem); err != nil {
+				return err
+			}
+			p.Paths[key] = &pathItem
+		}
+	}
+
+	if len(p.Extensions) == 0 {
+		p.Extensions = nil
+	}
+	return nil
+}
+
+func (p Paths) MarshalJSON() ([]byte, error) {
+	result := make(map[string]any)
+	for key, value := range p.Paths {
+		result[key] = value
+	}
+	for key, value := range p.Extensions {
+		result[key] = value
+	}
+	return json.Marshal(result)
+}
+
+// PathItem describes the operations available on a single path.
+// AdditionalOperations holds operations for methods without a fixed field,
+// keyed by the method name as it is sent on the wire (e.g. "LINK").
+type PathItem struct {
+	Ref                  string                `json:"$ref,omitempty"`
+	Summary              string                `json:"summary,omitempty"`
+	Description          string                `json:"description,omitempty"`
+	Servers              []*Server             `json:"servers,omitempty"`
+	Parameters           []*Parameter          `json:"parameters,omitempty"`
+	Get                  *Operation            `json:"get,omitempty"`
+	Put                  *Operation            `json:"put,omitempty"`
+	Post                 *Operation            `json:"post,omitempty"`
+	Delete               *Operation            `json:"delete,omitempty"`
+	Options              *Operation            `json:"options,omitempty"`
+	Head                 *Operation            `json:"head,omitempty"`
+	Patch                *Operation            `json:"patch,omitempty"`
+	Trace                *Operation            `json:"trace,omitempty"`
+	Query                *Operation            `json:"query,omitempty"`
+	AdditionalOperations map[string]*Operation `json:"additionalOperations,omitempty"`
+	Extensions           map[string]any        `json:"-"`
+}
+
+var pathItemKnownFields = []string{
+	"$ref", "summary", "description", "servers", "parameters",
+	"get", "put", "post", "delete", "options", "head", "patch", "trace", "query",
+	"additionalOperations",
+}
+
+type pathItemAlias PathItem
+
+func (pi *PathItem) UnmarshalJSON(data []byte) error {
+	var alias pathItemAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*pi = PathItem(alias)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	pi.Extensions = extractExtensions(raw, pathItemKnownFields)
+	return nil
+}
+
+func (pi PathItem) MarshalJSON() ([]byte, error) {
+	alias := pathItemAlias(pi)
+	return marshalWithExtensions(&alias, pi.Extensions)
+}
+
+// HasRef returns true if this PathItem has a $ref
+func (pi *PathItem) HasRef() bool {
+	return pi != nil && pi.Ref != ""
+}
+
+// GetRef returns the $ref value
+func (pi *PathItem) GetRef() string {
+	if pi == nil {
+		return ""
+	}
+	return pi.Ref
+}
+
+// The HTTP methods with a fixed field in a PathItem
+const (
+	MethodGet     = "get"
+	MethodPut     = "put"
+	MethodPost    = "post"
+	MethodDelete  = "delete"
+	MethodOptions = "options"
+	MethodHead    = "head"
+	MethodPatch   = "patch"
+	MethodTrace   = "trace"
+	MethodQuery   = "query"
+)
+
+// fixedMethods lists the fixed-field methods in specification order
+var fixedMethods = []string{MethodGet, MethodPut, MethodPost, MethodDelete, MethodOptions, MethodHead, MethodPatch, MethodTrace, MethodQuery}
+
+// IsFixedMethod reports whether a PathItem has a field for the method,
+// matched case-insensitively
+func IsFixedMethod(method string) bool {
+	return slices.Contains(fixedMethods, strings.ToLower(method))
+}
+
+// operationField returns the field holding the operation for the method, or
+// nil if the PathItem has no field for it
+func (pi *PathItem) operationField(method string) **Operation {
+	switch strings.ToLower(method) {
+	case MethodGet:
+		return &pi.Get
+	case MethodPut:
+		return &pi.Put
+	case MethodPost:
+		return &pi.Post
+	case MethodDelete:
+		return &pi.Delete
+	case MethodOptions:
+		return &pi.Options
+	case MethodHead:
+		return &pi.Head
+	case MethodPatch:
+		return &pi.Patch
+	case MethodTrace:
+		return &pi.Trace
+	case MethodQuery:
+		return &pi.Query
+	}
+	return nil
+}
+
+// additionalOperation returns the key of the additional operation for the
+// method, matched case-insensitively, or "" if there is none
+func (pi *PathItem) additionalOperation(method string) string {
+	for name, op := range pi.AdditionalOperations {
+		if op != nil && strings.EqualFold(name, method) {
+			return name
+		}
+	}
+	return ""
+}
+
+// GetOperation returns the operation for the method, matched
+// case-insensitively: a fixed field or else an additional operation, or nil
+func (pi *PathItem) GetOperation(method string) *Operation {
+	if pi == nil {
+		return nil
+	}
+	if field := pi.operationField(method); field != nil {
+		return *field
+	}
+	if name := pi.additionalOperation(method); name != "" {
+		return pi.AdditionalOperations[name]
+	}
+	return nil
+}
+
+// SetOperation sets the operation for the method, matched case-insensitively;
+// a nil operation removes it. A method without a fixed field is an additional
+// operation, keyed by the method as given. It reports false, changing
+// nothing, when the method is empty.
+func (pi *PathItem) SetOperation(method string, op *Operation) bool {
+	if field := pi.operationField(method); field != nil {
+		*field = op
+		return true
+	}
+	if method == "" {
+		return false
+	}
+	if name := pi.additionalOperation(method); name != "" {
+		delete(pi.AdditionalOperations, name)
+	}
+	if op != nil {
+		if pi.AdditionalOperations == nil {
+			pi.AdditionalOperations = make(map[string]*Operation)
+		}
+		pi.AdditionalOperations[method] = op
+	}
+	return true
+}
+
+// Operations iterates the operations of the PathItem by method: the fixed
+// fields in specification order, then the additional operations in key order
+func (pi *PathItem) Operations() iter.Seq2[string, *Operation] {
+	return func(yield func(string, *Operation) bool) {
+		if pi == nil {
+			return
+		}
+		for _, method := range fixedMethods {
+			if op := *pi.operationField(method); op != nil && !yield(method, op) {
+				return
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(pi.AdditionalOperations)) {
+			if op := pi.AdditionalOperations[name]; op != nil && !yield(name, op) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// exampleFiles returns the JSON example files
+func exampleFiles(t *testing.T) []string {
+	t.Helper()
+	entries, err := os.ReadDir("oas-examples/json")
+	if err != nil {
+		t.Fatalf("Failed to read examples directory: %v", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			files = append(files, filepath.Join("oas-examples/json", entry.Name()))
+		}
+	}
+	if len(files) == 0 {
+		t.Fatal("No JSON files found in examples directory")
+	}
+	return files
+}
+
+// TestJSONRoundTrip tests that JSON can be unmarshaled and marshaled back without loss.
+// Note: We compare JSON output rather than Go structs because empty slices/maps
+// with omitempty become nil after a full round-trip, which is semantically equivalent.
+func TestJSONRoundTrip(t *testing.T) {
+	for _, file := range exampleFiles(t) {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			originalData, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Failed to read file %s: %v", file, err)
+			}
+
+			var api1 OpenAPI
+			if err := json.Unmarshal(originalData, &api1); err != nil {
+				t.Fatalf("Failed to unmarshal %s: %v", file, err)
+			}
+			marshaled1, err := json.Marshal(&api1)
+			if err != nil {
+				t.Fatalf("Failed to marshal %s: %v", file, err)
+			}
+
+			var api2 OpenAPI
+			if err := json.Unmarshal(marshaled1, &api2); err != nil {
+				t.Fatalf("Failed to unmarshal marshaled JSON for %s: %v", file, err)
+			}
+			marshaled2, err := json.Marshal(&api2)
+			if err != nil {
+				t.Fatalf("Failed to marshal second time %s: %v", file, err)
+			}
+
+			// after one round-trip, subsequent round-trips should be identical
+			if string(marshaled1) != string(marshaled2) {
+				t.Errorf("Round-trip mismatch for %s", file)
+				t.Logf("First marshal:\n%s", marshaled1)
+				t.Logf("Second marshal:\n%s", marshaled2)
+			}
+		})
+	}
+}
+
+// TestAllExampleFilesParseSuccessfully ensures all example files can be parsed
+func TestAllExampleFilesParseSuccessfully(t *testing.T) {
+	for _, file := range exampleFiles(t) {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+
+			var api OpenAPI
+			if err := json.Unmarshal(data, &api); err != nil {
+				t.Fatalf("Failed to parse %s: %v", file, err)
+			}
+			if api.OpenAPI != "3.2.0" {
+				t.Errorf("Expected openapi 3.2.0, got %q", api.OpenAPI)
+			}
+			if api.Info == nil {
+				t.Error("Info is nil")
+			}
+		})
+	}
+}
+
+// TestFeaturesRoundTrip tests that the fields new in 3.2 survive a round-trip
+func TestFeaturesRoundTrip(t *testing.T) {
+	data, err := os.ReadFile("oas-examples/json/features-3-2.json")
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	var api OpenAPI
+	if err := json.Unmarshal(data, &api); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	marshaled, err := json.Marshal(&api)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	var back OpenAPI
+	if err := json.Unmarshal(marshaled, &back); err != nil {
+		t.Fatalf("Failed to unmarshal marshaled JSON: %v", err)
+	}
+
+	if back.Self != "https://example.com/openapi.json" || back.Servers[0].Name != "production" {
+		t.Errorf("Expected $self and the server name, got %q %q", back.Self, back.Servers[0].Name)
+	}
+	if tag := back.Tags[1]; tag.Parent != "pets" || tag.Kind != "nav" || tag.Summary != "Cats" {
+		t.Errorf("Expected the tag hierarchy, got %+v", tag)
+	}
+	item := back.Paths.Get("/pets")
+	if item.Query == nil || item.Query.Parameters[0].In != "querystring" {
+		t.Error("Expected the query operation and its querystring parameter")
+	}
+	if item.GetOperation("LINK") == nil {
+		t.Error("Expected the LINK additional operation")
+	}
+	response := item.Get.Responses.StatusCode["200"]
+	if response.Summary != "The pets" || response.Content["application/jsonl"].ItemSchema == nil {
+		t.Errorf("Expected the response summary and itemSchema, got %+v", response)
+	}
+	if ref := response.Content["application/json"].Ref; ref != "#/components/mediaTypes/Pets" {
+		t.Errorf("Expected the media type reference, got %q", ref)
+	}
+	if ex := back.Components.MediaTypes["Pets"].Examples["one"]; ex.DataValue == nil || ex.SerializedValue == "" {
+		t.Errorf("Expected dataValue and serializedValue, got %+v", ex)
+	}
+	pet := back.Components.Schemas["Pet"]
+	if pet.Discriminator.DefaultMapping != "#/components/schemas/Pet" || pet.Properties["id"].XML.NodeType != "attribute" {
+		t.Error("Expected defaultMapping and nodeType")
+	}
+	schemes := back.Components.SecuritySchemes
+	if schemes["device"].Flows.DeviceAuthorization.DeviceAuthorizationUrl == "" || !schemes["legacy"].Deprecated {
+		t.Error("Expected the device authorization flow and the deprecated scheme")
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+// spdxLicenseIDs are the license identifiers of the SPDX License List known
+// to this package, including the deprecated ones such as GPL-2.0.
+// Identifiers are matched case-insensitively.
+var spdxLicenseIDs = []string{
+	"0BSD", "AAL", "AFL-1.1", "AFL-1.2", "AFL-2.0",
+	"AFL-2.1", "AFL-3.0", "AGPL-1.0", "AGPL-1.0-only", "AGPL-1.0-or-later",
+	"AGPL-3.0", "AGPL-3.0-only", "AGPL-3.0-or-later", "AMPAS", "APL-1.0",
+	"APSL-1.0", "APSL-1.1", "APSL-1.2", "APSL-2.0", "Apache-1.0",
+	"Apache-1.1", "Apache-2.0", "Artistic-1.0", "Artistic-1.0-Perl", "Artistic-1.0-cl8",
+	"Artistic-2.0", "BSD-1-Clause", "BSD-2-Clause", "BSD-2-Clause-Patent", "BSD-2-Clause-Views",
+	"BSD-3-Clause", "BSD-3-Clause-Attribution", "BSD-3-Clause-Clear", "BSD-3-Clause-LBNL", "BSD-3-Clause-No-Nuclear-License",
+	"BSD-3-Clause-No-Nuclear-Warranty", "BSD-3-Clause-Open-MPI", "BSD-4-Clause", "BSD-4-Clause-UC", "BSD-Protection",
+	"BSD-Source-Code", "BSL-1.0", "BUSL-1.1", "Beerware", "BlueOak-1.0.0",
+	"CAL-1.0", "CAL-1.0-Combined-Work-Exception", "CATOSL-1.1", "CC-BY-1.0", "CC-BY-2.0",
+	"CC-BY-2.5", "CC-BY-3.0", "CC-BY-3.0-US", "CC-BY-4.0", "CC-BY-NC-1.0",
+	"CC-BY-NC-2.0", "CC-BY-NC-2.5", "CC-BY-NC-3.0", "CC-BY-NC-4.0", "CC-BY-NC-ND-1.0",
+	"CC-BY-NC-ND-2.0", "CC-BY-NC-ND-2.5", "CC-BY-NC-ND-3.0", "CC-BY-NC-ND-4.0", "CC-BY-NC-SA-1.0",
+	"CC-BY-NC-SA-2.0", "CC-BY-NC-SA-2.5", "CC-BY-NC-SA-3.0", "CC-BY-NC-SA-4.0", "CC-BY-ND-1.0",
+	"CC-BY-ND-2.0", "CC-BY-ND-2.5", "CC-BY-ND-3.0", "CC-BY-ND-4.0", "CC-BY-SA-1.0",
+	"CC-BY-SA-2.0", "CC-BY-SA-2.5", "CC-BY-SA-3.0", "CC-BY-SA-4.0", "CC-PDDC",
+	"CC0-1.0", "CDDL-1.0", "CDDL-1.1", "CDLA-Permissive-1.0", "CDLA-Permissive-2.0",
+	"CDLA-Sharing-1.0", "CECILL-1.0", "CECILL-1.1", "CECILL-2.0", "CECILL-2.1",
+	"CECILL-B", "CECILL-C", "CERN-OHL-1.1", "CERN-OHL-1.2", "CERN-OHL-P-2.0",
+	"CERN-OHL-S-2.0", "CERN-OHL-W-2.0", "CNRI-Python", "CPAL-1.0", "CPL-1.0",
+	"CUA-OPL-1.0", "ECL-1.0", "ECL-2.0", "EFL-1.0", "EFL-2.0",
+	"EPL-1.0", "EPL-2.0", "EUDatagrid", "EUPL-1.0", "EUPL-1.1",
+	"EUPL-1.2", "Elastic-2.0", "Entessa", "FSFAP", "FTL",
+	"Fair", "Frameworx-1.0", "GFDL-1.1", "GFDL-1.1-only", "GFDL-1.1-or-later",
+	"GFDL-1.2", "GFDL-1.2-only", "GFDL-1.2-or-later", "GFDL-1.3", "GFDL-1.3-only",
+	"GFDL-1.3-or-later", "GPL-1.0", "GPL-1.0-only", "GPL-1.0-or-later",
+	"GPL-2.0", "GPL-2.0-only", "GPL-2.0-or-later", "GPL-2.0-with-classpath-exception",
+	"GPL-3.0", "GPL-3.0-only", "GPL-3.0-or-later", "HPND",
+	"Hippocratic-2.1", "ICU", "IJG", "IPA", "IPL-1.0",
+	"ISC", "Imlib2", "Intel", "JSON", "LGPL-2.0",
+	"LGPL-2.0-only", "LGPL-2.0-or-later", "LGPL-2.1",
+	"LGPL-2.1-only", "LGPL-2.1-or-later", "LGPL-3.0", "LGPL-3.0-only",
+	"LGPL-3.0-or-later", "LGPLLR", "LPL-1.0", "LPL-1.02", "LPPL-1.3c",
+	"LiLiQ-P-1.1", "LiLiQ-R-1.1", "LiLiQ-Rplus-1.1", "Libpng", "MIT",
+	"MIT-0", "MIT-CMU", "MIT-Modern-Variant", "MIT-advertising", "MIT-enna",
+	"MIT-feh", "MITNFA", "MPL-1.0", "MPL-1.1", "MPL-2.0",
+	"MPL-2.0-no-copyleft-exception", "MS-PL", "MS-RL", "MirOS", "Motosoto",
+	"MulanPSL-1.0", "MulanPSL-2.0", "Multics", "NASA-1.3", "NCSA",
+	"NGPL", "NPOSL-3.0", "NTP", "Naumen", "Nokia",
+	"OCLC-2.0", "ODC-By-1.0", "ODbL-1.0", "OFL-1.0", "OFL-1.1",
+	"OGL-UK-1.0", "OGL-UK-2.0", "OGL-UK-3.0", "OGTSL", "OLDAP-2.8",
+	"OPL-1.0", "OSET-PL-2.1", "OSL-1.0", "OSL-1.1", "OSL-2.0",
+	"OSL-2.1", "OSL-3.0", "OpenSSL", "PDDL-1.0", "PHP-3.0",
+	"PHP-3.01", "PSF-2.0", "Parity-7.0.0", "PolyForm-Noncommercial-1.0.0", "PolyForm-Small-Business-1.0.0",
+	"PostgreSQL", "Python-2.0", "QPL-1.0", "RPL-1.1", "RPL-1.5",
+	"RPSL-1.0", "RSCPL", "Ruby", "SISSL", "SMLNJ",
+	"SPL-1.0", "SSPL-1.0", "Sleepycat", "UCL-1.0", "UPL-1.0",
+	"Unicode-3.0", "Unicode-DFS-2015", "Unicode-DFS-2016", "Unlicense", "Vim",
+	"VSL-1.0", "W3C", "W3C-19980720", "W3C-20150513", "WTFPL",
+	"Watcom-1.0", "X11", "XFree86-1.1", "Xnet", "YPL-1.1",
+	"ZPL-1.1", "ZPL-2.0", "ZPL-2.1", "Zend-2.0", "Zlib",
+	"bzip2-1.0.6", "curl", "eCos-2.0", "libpng-2.0", "wxWindows",
+	"zlib-acknowledgement",
+}
+
+// spdxExceptionIDs are the license exception identifiers of the SPDX License
+// List known to this package, used after WITH
+var spdxExceptionIDs = []string{
+	"389-exception", "Autoconf-exception-2.0", "Autoconf-exception-3.0", "Bison-exception-2.2",
+	"Bootloader-exception", "CLISP-exception-2.0", "Classpath-exception-2.0", "DigiRule-FOSS-exception",
+	"FLTK-exception", "Fawkes-Runtime-exception", "Font-exception-2.0", "GCC-exception-2.0",
+	"GCC-exception-3.1", "GPL-3.0-linking-exception", "GPL-3.0-linking-source-exception", "GPL-CC-1.0",
+	"LGPL-3.0-linking-exception", "LLVM-exception", "LZMA-exception", "Libtool-exception",
+	"Linux-syscall-note", "Nokia-Qt-exception-1.1", "OCCT-exception-1.0", "OCaml-LGPL-linking-exception",
+	"OpenJDK-assembly-exception-1.0", "PS-or-PDF-font-exception-20170817", "Qt-GPL-exception-1.0", "Qt-LGPL-exception-1.1",
+	"Qwt-exception-1.0", "Swift-exception", "Universal-FOSS-exception-1.0", "WxWindows-exception-3.1",
+	"eCos-exception-2.0", "freertos-exception-2.0", "gnu-javamail-exception", "i2p-gpl-java-exception",
+	"mif-exception", "openvpn-openssl-exception", "u-boot-exception-2.0",
+}
+
+// wellKnownLicenseNames maps common spellings of license names, in lower case,
+// to their SPDX identifier. Names that are identifiers themselves are found in
+// spdxLicenseIDs.
+var wellKnownLicenseNames = map[string]string{
+	"mit":                                    "MIT",
+	"mit license":                            "MIT",
+	"the mit license":                        "MIT",
+	"apache 2.0":                             "Apache-2.0",
+	"apache 2":                               "Apache-2.0",
+	"apache license 2.0":                     "Apache-2.0",
+	"apache license, version 2.0":            "Apache-2.0",
+	"apache license version 2.0":             "Apache-2.0",
+	"apache software license 2.0":            "Apache-2.0",
+	"bsd 2-clause":                           "BSD-2-Clause",
+	"simplified bsd license":                 "BSD-2-Clause",
+	"freebsd license":                        "BSD-2-Clause",
+	"bsd 3-clause":                           "BSD-3-Clause",
+	"new bsd license":                        "BSD-3-Clause",
+	"modified bsd license":                   "BSD-3-Clause",
+	"gplv2":                                  "GPL-2.0-only",
+	"gnu gpl v2":                             "GPL-2.0-only",
+	"gnu general public license v2.0":        "GPL-2.0-only",
+	"gplv3":                                  "GPL-3.0-only",
+	"gnu gpl v3":                             "GPL-3.0-only",
+	"gnu general public license v3.0":        "GPL-3.0-only",
+	"lgplv2.1":                               "LGPL-2.1-only",
+	"gnu lesser general public license v2.1": "LGPL-2.1-only",
+	"lgplv3":                                 "LGPL-3.0-only",
+	"gnu lesser general public license v3.0": "LGPL-3.0-only",
+	"agplv3":                                 "AGPL-3.0-only",
+	"gnu affero general public license v3.0": "AGPL-3.0-only",
+	"mozilla public license 2.0":             "MPL-2.0",
+	"isc license":                            "ISC",
+	"the unlicense":                          "Unlicense",
+	"eclipse public license 2.0":             "EPL-2.0",
+	"eclipse public license 1.0":             "EPL-1.0",
+	"boost software license 1.0":             "BSL-1.0",
+	"cc0":                                    "CC0-1.0",
+	"cc0 1.0 universal":                      "CC0-1.0",
+	"creative commons attribution 4.0 international":             "CC-BY-4.0",
+	"creative commons attribution 4.0":                           "CC-BY-4.0",
+	"creative commons attribution share alike 4.0 international": "CC-BY-SA-4.0",
+	"european union public license 1.2":                          "EUPL-1.2",
+	"universal permissive license 1.0":                           "UPL-1.0",
+	"zlib license":                                               "Zlib",
+	"postgresql license":                                         "PostgreSQL",
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import "encoding/json"
+
+// Tag adds metadata to a single tag used by Operation.
+// Parent names the tag this one is nested under, and Kind categorizes the
+// tag (e.g. "nav", "badge", "audience").
+type Tag struct {
+	Name         string                 `json:"name"`
+	Summary      string                 `json:"summary,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocumentation `json:"externalDocs,omitempty"`
+	Parent       string                 `json:"parent,omitempty"`
+	Kind         string                 `json:"kind,omitempty"`
+	Extensions   map[string]any         `json:"-"`
+}
+
+var tagKnownFields = []string{"name", "summary", "description", "externalDocs", "parent", "kind"}
+
+type tagAlias Tag
+
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	var alias tagAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*t = Tag(alias)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	t.Extensions = extractExtensions(raw, tagKnownFields)
+	return nil
+}
+
+func (t Tag) MarshalJSON() ([]byte, error) {
+	alias := tagAlias(t)
+	return marshalWithExtensions(&alias, t.Extensions)
+}
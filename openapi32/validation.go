@@ -0,0 +1,1217 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Severity classifies a validation finding. The zero value is SeverityError,
+// so findings are errors unless a rule is downgraded in ValidationOptions.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityInfo
+)
+
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// Rule identifiers reported in ValidationError.Rule. They can be disabled or
+// downgraded through ValidationOptions.
+const (
+	RuleDocument              = "document"                // document-level structure
+	RuleRequired              = "required"                // required fields
+	RuleVersion               = "version"                 // openapi version string
+	RuleLicense               = "license"                 // license identifier expression and url exclusion
+	RuleLicenseName           = "license-name"            // identifier set for well-known license names (warning)
+	RuleServerURL             = "server-url"              // server url templates
+	RuleServerVariable        = "server-variable"         // server variable defaults and usage
+	RulePathFormat            = "path-format"             // path keys starting with /
+	RuleAdditionalOperation   = "additional-operation"    // additionalOperations method names
+	RuleResponses             = "responses"               // non-empty responses object
+	RuleStatusCode            = "status-code"             // response status code keys
+	RuleParameterIn           = "parameter-in"            // parameter locations
+	RulePathParameterRequired = "path-parameter-required" // path parameters marked required
+	RulePathParameters        = "path-parameters"         // path template placeholders matching path parameters
+	RuleStyle                 = "style"                   // parameter, header and encoding styles
+	RuleIgnoredHeader         = "ignored-header"          // headers the specification says to ignore (warning)
+	RuleSchemaOrContent       = "schema-or-content"       // schema and content exclusion
+	RuleExampleOrExamples     = "example-or-examples"     // example and examples exclusion
+	RuleExampleSchema         = "example-schema"          // example values matching their schema (see ValidateExamples)
+	RuleEncoding              = "encoding"                // encoding properties, media types and positional encoding exclusion
+	RuleMediaType             = "media-type"              // media type syntax (RFC 6838)
+	RuleSchemaType            = "schema-type"             // schema type names
+	RuleArrayItems            = "array-items"             // items on array schemas
+	RuleSchemaRange           = "schema-range"            // consistent schema bounds
+	RulePattern               = "pattern"                 // valid regular expressions
+	RuleRequiredProperty      = "required-property"       // required properties defined in properties
+	RuleReadWrite             = "read-write"              // readOnly and writeOnly exclusion
+	RuleRequiredReadWrite     = "required-read-write"     // required properties a request or response can carry (warning)
+	RuleDiscriminator         = "discriminator"           // discriminator property and mapping
+	RuleLinkOperation         = "link-operation"          // link operationId and operationRef exclusion
+	RuleRef                   = "ref"                     // references that resolve to the expected kind
+	RuleTagParent             = "tag-parent"              // tag parents and hierarchy
+	RuleComponentName         = "component-name"          // component name characters
+	RuleSecuritySchemeType    = "security-scheme-type"    // security scheme types
+	RuleSecurityScheme        = "security-scheme"         // type-specific security scheme fields
+	RuleSecurityRequirement   = "security-requirement"    // security requirements naming declared schemes
+	RuleOAuthFlow             = "oauth-flow"              // OAuth flow fields
+	RuleOAuthScope            = "oauth-scope"             // oauth2 scopes declared by a flow
+	RuleUndeclaredTag         = "undeclared-tag"          // operation tags declared in the top-level tags (warning)
+	RuleUnusedTag             = "unused-tag"              // declared tags used by an operation (warning, see UnusedTags)
+	RuleUnusedComponent       = "unused-component"        // components referenced from the document (warning, see UnusedComponents)
+	RuleFormat                = "format"                  // formats known to the registry (warning, see RegisterFormat)
+)
+
+// defaultSeverities lists the rules that are not reported as SeverityError
+// unless overridden
+var defaultSeverities = map[string]Severity{
+	RuleLicenseName:       SeverityWarning,
+	RuleRequiredReadWrite: SeverityWarning,
+	RuleIgnoredHeader:     SeverityWarning,
+	RuleUndeclaredTag:     SeverityWarning,
+	RuleUnusedTag:         SeverityWarning,
+	RuleUnusedComponent:   SeverityWarning,
+	RuleFormat:            SeverityWarning,
+}
+
+// ValidationError represents a validation finding with path context
+type ValidationError struct {
+	Path     string
+	Message  string
+	Rule     string
+	Severity Severity
+	// Line and Column locate the finding in ValidationOptions.Source,
+	// starting at 1. They are zero when no source is given.
+	Line   int
+	Column int
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationOptions configures ValidateWithOptions
+type ValidationOptions struct {
+	// Disabled lists rules that are not checked
+	Disabled []string
+	// Severities overrides the severity of rules, which is SeverityError by
+	// default and SeverityWarning for the rules documented as warnings
+	Severities map[string]Severity
+	// ResolveRefs checks that every local $ref resolves and points at a
+	// component of the expected kind. References to other documents are not loaded.
+	ResolveRefs bool
+	// Source is the JSON text the document was parsed from. When set, findings
+	// carry the line and column of the value they are about, or of the closest
+	// enclosing value for missing fields.
+	Source []byte
+	// ValidateExamples checks example and examples values of parameters,
+	// headers and media types against their schema
+	ValidateExamples bool
+	// UnusedTags reports declared tags that no operation uses
+	UnusedTags bool
+	// UnusedComponents reports components that cannot be reached from the
+	// paths, webhooks or other parts of the document, see UnusedComponents
+	UnusedComponents bool
+	// Workers bounds the goroutines of ValidateContext. Zero uses
+	// runtime.GOMAXPROCS.
+	Workers int
+}
+
+// ValidationResult contains all validation findings.
+// Errors holds every finding whatever its severity; only findings with
+// SeverityError make the result invalid.
+type ValidationResult struct {
+	Errors []ValidationError
+	opts   *ValidationOptions
+	root   any              // the document as generic JSON when references are resolved
+	source *sourceMap       // the positions of the values of ValidationOptions.Source
+	tasks  *validationTasks // runs the parts of ValidateContext concurrently
+	// components resolves the schemas and examples of the document
+	components *Components
+}
+
+// Valid returns true if there are no findings with SeverityError
+func (r *ValidationResult) Valid() bool {
+	return len(r.BySeverity(SeverityError)) == 0
+}
+
+// BySeverity returns the findings with the given severity
+func (r *ValidationResult) BySeverity(severity Severity) []ValidationError {
+	var found []ValidationError
+	for _, e := range r.Errors {
+		if e.Severity == severity {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// Warnings returns the findings with SeverityWarning
+func (r *ValidationResult) Warnings() []ValidationError {
+	return r.BySeverity(SeverityWarning)
+}
+
+// Error returns a combined message of the findings with SeverityError
+func (r *ValidationResult) Error() string {
+	var msgs []string
+	for _, e := range r.BySeverity(SeverityError) {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (r *ValidationResult) addError(rule, path, message string) {
+	severity, ok := defaultSeverities[rule]
+	if !ok {
+		severity = SeverityError
+	}
+	if r.opts != nil {
+		for _, disabled := range r.opts.Disabled {
+			if disabled == rule {
+				return
+			}
+		}
+		if s, ok := r.opts.Severities[rule]; ok {
+			severity = s
+		}
+	}
+	e := ValidationError{Path: path, Message: message, Rule: rule, Severity: severity}
+	if r.source != nil {
+		e.Line, e.Column = r.source.position(e.Pointer())
+	}
+	r.Errors = append(r.Errors, e)
+}
+
+// Validate validates the OpenAPI document against the OpenAPI 3.2 specification
+func (o *OpenAPI) Validate() *ValidationResult {
+	return o.ValidateWithOptions(nil)
+}
+
+// ValidateWithOptions validates the OpenAPI document like Validate, skipping
+// disabled rules and reporting rules with the configured severities.
+// A nil opts checks every rule at its default severity.
+func (o *OpenAPI) ValidateWithOptions(opts *ValidationOptions) *ValidationResult {
+	result := &ValidationResult{opts: opts}
+	o.validateDocument(result)
+	return result
+}
+
+// validateDocument runs every check of the document into result
+func (o *OpenAPI) validateDocument(result *ValidationResult) {
+	if o == nil {
+		result.addError(RuleDocument, "", "OpenAPI document is nil")
+		return
+	}
+	if result.opts != nil && result.opts.Source != nil {
+		result.source = newSourceMap(result.opts.Source)
+	}
+	if result.opts != nil && result.opts.ResolveRefs {
+		result.loadRoot(o)
+	}
+	result.components = o.Components
+
+	// Required: openapi
+	if o.OpenAPI == "" {
+		result.addError(RuleRequired, "openapi", "required field is missing")
+	} else if !strings.HasPrefix(o.OpenAPI, "3.2") {
+		result.addError(RuleVersion, "openapi", fmt.Sprintf("expected 3.2.x version, got %s", o.OpenAPI))
+	}
+
+	// Required: info
+	if o.Info == nil {
+		result.addError(RuleRequired, "info", "required field is missing")
+	} else {
+		o.Info.validate("info", result)
+	}
+
+	// At least one of: paths, webhooks, or components
+	hasPaths := o.Paths != nil && len(o.Paths.Paths) > 0
+	hasWebhooks := len(o.Webhooks) > 0
+	hasComponents := o.Components != nil
+	if !hasPaths && !hasWebhooks && !hasComponents {
+		result.addError(RuleDocument, "", "must have at least one of: paths, webhooks, or components")
+	}
+
+	// Optional: paths
+	if o.Paths != nil {
+		o.Paths.validate("paths", result)
+	}
+	o.validatePathParameters(result)
+	o.validateSecurityRequirements(result)
+	o.validateTags(result)
+	o.validateUnusedComponents(result)
+
+	// Optional: webhooks
+	for name, pathItem := range o.Webhooks {
+		if pathItem != nil {
+			result.run(func(result *ValidationResult) {
+				pathItem.validate(fmt.Sprintf("webhooks[%s]", name), result)
+			})
+		}
+	}
+
+	// Optional: servers
+	for i, server := range o.Servers {
+		if server != nil {
+			server.validate(fmt.Sprintf("servers[%d]", i), result)
+		}
+	}
+
+	// Optional: components
+	if o.Components != nil {
+		o.Components.validate("components", result)
+	}
+
+	// Optional: tags
+	for i, tag := range o.Tags {
+		if tag != nil {
+			tag.validate(fmt.Sprintf("tags[%d]", i), result)
+		}
+	}
+	validateTagParents(o.Tags, result)
+}
+
+// validateTagParents checks that every tag parent names a declared tag and
+// that the hierarchy has no cycles
+func validateTagParents(tags []*Tag, result *ValidationResult) {
+	parents := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		if tag != nil {
+			parents[tag.Name] = tag.Parent
+		}
+	}
+	for i, tag := range tags {
+		if tag == nil || tag.Parent == "" {
+			continue
+		}
+		path := fmt.Sprintf("tags[%d].parent", i)
+		if _, ok := parents[tag.Parent]; !ok {
+			result.addError(RuleTagParent, path, fmt.Sprintf("parent tag '%s' is not defined", tag.Parent))
+			continue
+		}
+		seen := map[string]bool{tag.Name: true}
+		for parent := tag.Parent; parent != ""; parent = parents[parent] {
+			if seen[parent] {
+				result.addError(RuleTagParent, path, "tag hierarchy contains a cycle")
+				break
+			}
+			seen[parent] = true
+		}
+	}
+}
+
+func (i *Info) validate(path string, result *ValidationResult) {
+	// Required: title
+	if i.Title == "" {
+		result.addError(RuleRequired, path+".title", "required field is missing")
+	}
+	// Required: version
+	if i.Version == "" {
+		result.addError(RuleRequired, path+".version", "required field is missing")
+	}
+	// Optional: license
+	if i.License != nil {
+		i.License.validate(path+".license", result)
+	}
+}
+
+func (l *License) validate(path string, result *ValidationResult) {
+	// Required: name
+	if l.Name == "" {
+		result.addError(RuleRequired, path+".name", "required field is missing")
+	}
+	// Mutual exclusion: identifier and url cannot both be present
+	if l.Identifier != "" && l.URL != "" {
+		result.addError(RuleLicense, path, "identifier and url are mutually exclusive")
+	}
+	l.validateIdentifier(path, result)
+}
+
+func (s *Server) validate(path string, result *ValidationResult) {
+	// Required: url
+	if s.URL == "" {
+		result.addError(RuleRequired, path+".url", "required field is missing")
+	}
+	// Validate variables
+	for name, v := range s.Variables {
+		if v != nil {
+			v.validate(fmt.Sprintf("%s.variables[%s]", path, name), result)
+		}
+	}
+	s.validateTemplate(path, result)
+}
+
+func (v *ServerVariable) validate(path string, result *ValidationResult) {
+	// Required: default
+	if v.Default == "" {
+		result.addError(RuleRequired, path+".default", "required field is missing")
+	}
+	// If enum is provided, default must be in enum
+	if len(v.Enum) > 0 {
+		found := false
+		for _, e := range v.Enum {
+			if e == v.Default {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.addError(RuleServerVariable, path+".default", "default value must be one of the enum values")
+		}
+	}
+}
+
+func (p *Paths) validate(path string, result *ValidationResult) {
+	for pathPattern, pathItem := range p.Paths {
+		// Path must start with /
+		if !strings.HasPrefix(pathPattern, "/") {
+			result.addError(RulePathFormat, path+"."+pathPattern, "path must start with /")
+		}
+		if pathItem != nil {
+			result.run(func(result *ValidationResult) {
+				pathItem.validate(fmt.Sprintf("%s[%s]", path, pathPattern), result)
+			})
+		}
+	}
+}
+
+func (p *PathItem) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if p.Ref != "" {
+		result.checkRef(path, p.Ref, "pathItems")
+		return
+	}
+
+	// Validate parameters at path level
+	for i, param := range p.Parameters {
+		if param != nil {
+			param.validate(fmt.Sprintf("%s.parameters[%d]", path, i), result)
+		}
+	}
+
+	// Validate servers at path level
+	for i, server := range p.Servers {
+		if server != nil {
+			server.validate(fmt.Sprintf("%s.servers[%d]", path, i), result)
+		}
+	}
+
+	// Validate operations
+	if p.Get != nil {
+		p.Get.validate(path+".get", result)
+	}
+	if p.Put != nil {
+		p.Put.validate(path+".put", result)
+	}
+	if p.Post != nil {
+		p.Post.validate(path+".post", result)
+	}
+	if p.Delete != nil {
+		p.Delete.validate(path+".delete", result)
+	}
+	if p.Options != nil {
+		p.Options.validate(path+".options", result)
+	}
+	if p.Head != nil {
+		p.Head.validate(path+".head", result)
+	}
+	if p.Patch != nil {
+		p.Patch.validate(path+".patch", result)
+	}
+	if p.Trace != nil {
+		p.Trace.validate(path+".trace", result)
+	}
+	if p.Query != nil {
+		p.Query.validate(path+".query", result)
+	}
+
+	// Methods with a fixed field must not be repeated in additionalOperations
+	fixed := map[string]bool{
+		"GET": true, "PUT": true, "POST": true, "DELETE": true, "OPTIONS": true,
+		"HEAD": true, "PATCH": true, "TRACE": true, "QUERY": true,
+	}
+	for method, op := range p.AdditionalOperations {
+		opPath := fmt.Sprintf("%s.additionalOperations[%s]", path, method)
+		if fixed[strings.ToUpper(method)] {
+			result.addError(RuleAdditionalOperation, opPath, "method has a fixed field and must not be an additional operation")
+		}
+		if op != nil {
+			op.validate(opPath, result)
+		}
+	}
+}
+
+func (o *Operation) validate(path string, result *ValidationResult) {
+	// Required: responses (unless it's a webhook)
+	if o.Responses == nil {
+		result.addError(RuleRequired, path+".responses", "required field is missing")
+	} else {
+		o.Responses.validate(path+".responses", result)
+	}
+
+	// Validate parameters
+	for i, param := range o.Parameters {
+		if param != nil {
+			param.validate(fmt.Sprintf("%s.parameters[%d]", path, i), result)
+		}
+	}
+
+	// Validate requestBody
+	if o.RequestBody != nil {
+		o.RequestBody.validate(path+".requestBody", result)
+	}
+
+	// Validate servers
+	for i, server := range o.Servers {
+		if server != nil {
+			server.validate(fmt.Sprintf("%s.servers[%d]", path, i), result)
+		}
+	}
+
+	// Validate callbacks
+	for name, callback := range o.Callbacks {
+		if callback != nil {
+			callback.validate(fmt.Sprintf("%s.callbacks[%s]", path, name), result)
+		}
+	}
+}
+
+func (r *Responses) validate(path string, result *ValidationResult) {
+	// minProperties: 1 - must have at least one response
+	hasResponse := r.Default != nil || len(r.StatusCode) > 0
+	if !hasResponse {
+		result.addError(RuleResponses, path, "must contain at least one response")
+	}
+
+	// Validate status code pattern
+	statusCodePattern := regexp.MustCompile(`^[1-5][0-9][0-9]$|^[1-5]XX$`)
+	for code, resp := range r.StatusCode {
+		if !statusCodePattern.MatchString(code) {
+			result.addError(RuleStatusCode, path+"."+code, "invalid status code pattern, must be 3-digit code or pattern like 2XX")
+		}
+		if resp != nil {
+			resp.validate(path+"."+code, result)
+		}
+	}
+
+	if r.Default != nil {
+		r.Default.validate(path+".default", result)
+	}
+}
+
+func (r *Response) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if r.IsReference() {
+		result.checkRef(path, r.Ref, "responses")
+		return
+	}
+
+	// Validate headers
+	result.checkContentTypeHeader(path, r.Headers, "content")
+	for name, header := range r.Headers {
+		if header != nil {
+			header.validate(fmt.Sprintf("%s.headers[%s]", path, name), result)
+		}
+	}
+
+	// Validate content
+	for mediaType, mt := range r.Content {
+		result.checkMediaType(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType)
+		if mt != nil {
+			mt.validate(fmt.Sprintf("%s.content[%s]", path, mediaType), result)
+			result.checkEncoding(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType, mt)
+			result.checkDirection(fmt.Sprintf("%s.content[%s].schema", path, mediaType), mt.Schema, false)
+			result.checkDirection(fmt.Sprintf("%s.content[%s].itemSchema", path, mediaType), mt.ItemSchema, false)
+		}
+	}
+
+	// Validate links
+	for name, link := range r.Links {
+		if link != nil {
+			link.validate(fmt.Sprintf("%s.links[%s]", path, name), result)
+		}
+	}
+}
+
+func (p *Parameter) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if p.IsReference() {
+		result.checkRef(path, p.Ref, "parameters")
+		return
+	}
+
+	// Required: name
+	if p.Name == "" {
+		result.addError(RuleRequired, path+".name", "required field is missing")
+	}
+
+	// Required: in
+	if p.In == "" {
+		result.addError(RuleRequired, path+".in", "required field is missing")
+	} else {
+		validIn := map[string]bool{"query": true, "querystring": true, "header": true, "path": true, "cookie": true}
+		if !validIn[p.In] {
+			result.addError(RuleParameterIn, path+".in", fmt.Sprintf("must be one of: query, querystring, header, path, cookie; got %s", p.In))
+		}
+	}
+
+	// The whole query string is described by content, never by schema
+	if p.In == "querystring" && p.Schema != nil {
+		result.addError(RuleSchemaOrContent, path+".schema", "querystring parameters must use 'content'")
+	}
+
+	// Accept, Content-Type and Authorization header parameters are ignored
+	if p.In == "header" {
+		result.checkHeaderParameter(path, p.Name)
+	}
+
+	// Path parameters must have required: true
+	if p.In == "path" && !p.Required {
+		result.addError(RulePathParameterRequired, path+".required", "path parameters must have required: true")
+	}
+
+	// Validate style based on 'in' value
+	if p.Style != "" {
+		validStyles := map[string][]string{
+			"path":   {"matrix", "label", "simple"},
+			"query":  {"form", "spaceDelimited", "pipeDelimited", "deepObject"},
+			"header": {"simple"},
+			"cookie": {"form"},
+		}
+		if styles, ok := validStyles[p.In]; ok {
+			valid := false
+			for _, s := range styles {
+				if s == p.Style {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				result.addError(RuleStyle, path+".style", fmt.Sprintf("invalid style '%s' for parameter in '%s'", p.Style, p.In))
+			}
+		}
+	}
+
+	// Schema XOR Content - must have one but not both
+	hasSchema := p.Schema != nil
+	hasContent := len(p.Content) > 0
+	if !hasSchema && !hasContent {
+		result.addError(RuleSchemaOrContent, path, "must have either 'schema' or 'content'")
+	}
+	if hasSchema && hasContent {
+		result.addError(RuleSchemaOrContent, path, "cannot have both 'schema' and 'content'")
+	}
+
+	// Example XOR Examples - cannot have both
+	if p.Example != nil && len(p.Examples) > 0 {
+		result.addError(RuleExampleOrExamples, path, "cannot have both 'example' and 'examples'")
+	}
+	result.checkExampleRefs(path, p.Examples)
+	result.checkExamples(path, p.Schema, p.Example, p.Examples)
+
+	// Validate schema or content
+	if p.Schema != nil {
+		p.Schema.validate(path+".schema", result)
+	}
+	for mediaType, mt := range p.Content {
+		result.checkMediaType(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType)
+		if mt != nil {
+			mt.validate(fmt.Sprintf("%s.content[%s]", path, mediaType), result)
+			result.checkEncoding(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType, mt)
+		}
+	}
+}
+
+func (h *Header) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if h.IsReference() {
+		result.checkRef(path, h.Ref, "headers")
+		return
+	}
+
+	// Schema XOR Content
+	hasSchema := h.Schema != nil
+	hasContent := len(h.Content) > 0
+	if !hasSchema && !hasContent {
+		result.addError(RuleSchemaOrContent, path, "must have either 'schema' or 'content'")
+	}
+	if hasSchema && hasContent {
+		result.addError(RuleSchemaOrContent, path, "cannot have both 'schema' and 'content'")
+	}
+
+	// Example XOR Examples
+	if h.Example != nil && len(h.Examples) > 0 {
+		result.addError(RuleExampleOrExamples, path, "cannot have both 'example' and 'examples'")
+	}
+
+	// Style must be 'simple' for headers
+	if h.Style != "" && h.Style != "simple" {
+		result.addError(RuleStyle, path+".style", "header style must be 'simple'")
+	}
+	result.checkExampleRefs(path, h.Examples)
+	result.checkExamples(path, h.Schema, h.Example, h.Examples)
+
+	// Validate schema or content
+	if h.Schema != nil {
+		h.Schema.validate(path+".schema", result)
+	}
+	for mediaType, mt := range h.Content {
+		result.checkMediaType(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType)
+		if mt != nil {
+			mt.validate(fmt.Sprintf("%s.content[%s]", path, mediaType), result)
+			result.checkEncoding(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType, mt)
+		}
+	}
+}
+
+func (r *RequestBody) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if r.IsReference() {
+		result.checkRef(path, r.Ref, "requestBodies")
+		return
+	}
+
+	// Required: content
+	if len(r.Content) == 0 {
+		result.addError(RuleRequired, path+".content", "required field is missing")
+	}
+
+	// Validate content
+	for mediaType, mt := range r.Content {
+		result.checkMediaType(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType)
+		if mt != nil {
+			mt.validate(fmt.Sprintf("%s.content[%s]", path, mediaType), result)
+			result.checkEncoding(fmt.Sprintf("%s.content[%s]", path, mediaType), mediaType, mt)
+			result.checkDirection(fmt.Sprintf("%s.content[%s].schema", path, mediaType), mt.Schema, true)
+			result.checkDirection(fmt.Sprintf("%s.content[%s].itemSchema", path, mediaType), mt.ItemSchema, true)
+		}
+	}
+}
+
+func (m *MediaType) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if m.IsReference() {
+		result.checkRef(path, m.Ref, "mediaTypes")
+		return
+	}
+
+	// Example XOR Examples
+	if m.Example != nil && len(m.Examples) > 0 {
+		result.addError(RuleExampleOrExamples, path, "cannot have both 'example' and 'examples'")
+	}
+	result.checkExampleRefs(path, m.Examples)
+	result.checkExamples(path, m.Schema, m.Example, m.Examples)
+
+	// Validate schemas
+	if m.Schema != nil {
+		m.Schema.validate(path+".schema", result)
+	}
+	if m.ItemSchema != nil {
+		m.ItemSchema.validate(path+".itemSchema", result)
+	}
+
+	// Encoding is exclusive with the positional prefixEncoding and itemEncoding
+	if len(m.Encoding) > 0 && (len(m.PrefixEncoding) > 0 || m.ItemEncoding != nil) {
+		result.addError(RuleEncoding, path, "cannot have 'encoding' with 'prefixEncoding' or 'itemEncoding'")
+	}
+	for name, enc := range m.Encoding {
+		if enc != nil {
+			enc.validate(fmt.Sprintf("%s.encoding[%s]", path, name), result)
+		}
+	}
+	for i, enc := range m.PrefixEncoding {
+		if enc != nil {
+			enc.validate(fmt.Sprintf("%s.prefixEncoding[%d]", path, i), result)
+		}
+	}
+	if m.ItemEncoding != nil {
+		m.ItemEncoding.validate(path+".itemEncoding", result)
+	}
+}
+
+func (e *Encoding) validate(path string, result *ValidationResult) {
+	// Validate content types, a comma-separated list
+	if e.ContentType != "" {
+		for _, contentType := range strings.Split(e.ContentType, ",") {
+			result.checkMediaType(path+".contentType", strings.TrimSpace(contentType))
+		}
+	}
+
+	// Validate style
+	if e.Style != "" {
+		validStyles := []string{"form", "spaceDelimited", "pipeDelimited", "deepObject"}
+		valid := false
+		for _, s := range validStyles {
+			if s == e.Style {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			result.addError(RuleStyle, path+".style", fmt.Sprintf("invalid encoding style '%s'", e.Style))
+		}
+	}
+
+	// Validate headers
+	result.checkContentTypeHeader(path, e.Headers, "contentType")
+	for name, header := range e.Headers {
+		if header != nil {
+			header.validate(fmt.Sprintf("%s.headers[%s]", path, name), result)
+		}
+	}
+
+	// Validate nested encodings
+	if len(e.Encoding) > 0 && (len(e.PrefixEncoding) > 0 || e.ItemEncoding != nil) {
+		result.addError(RuleEncoding, path, "cannot have 'encoding' with 'prefixEncoding' or 'itemEncoding'")
+	}
+	for name, enc := range e.Encoding {
+		if enc != nil {
+			enc.validate(fmt.Sprintf("%s.encoding[%s]", path, name), result)
+		}
+	}
+	for i, enc := range e.PrefixEncoding {
+		if enc != nil {
+			enc.validate(fmt.Sprintf("%s.prefixEncoding[%d]", path, i), result)
+		}
+	}
+	if e.ItemEncoding != nil {
+		e.ItemEncoding.validate(path+".itemEncoding", result)
+	}
+}
+
+func (s *Schema) validate(path string, result *ValidationResult) {
+	// Boolean schemas are always valid
+	if s.IsBooleanSchema() {
+		return
+	}
+
+	// References are valid (resolution is separate concern)
+	if s.Ref != "" {
+		result.checkRef(path, s.Ref, "schemas")
+		return
+	}
+
+	// Validate type
+	if s.Type != nil && !s.Type.IsEmpty() {
+		validTypes := []string{"string", "number", "integer", "boolean", "array", "object", "null"}
+		// Get all types from the StringOrStringArray
+		var types []string
+		if s.Type.String != "" {
+			types = []string{s.Type.String}
+		} else {
+			types = s.Type.Array
+		}
+		for _, t := range types {
+			valid := false
+			for _, vt := range validTypes {
+				if t == vt {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				result.addError(RuleSchemaType, path+".type", fmt.Sprintf("invalid type '%s'", t))
+			}
+		}
+	}
+
+	// Array type must have items or prefixItems
+	if s.Type != nil && s.Type.Contains("array") && s.Items == nil && len(s.PrefixItems) == 0 {
+		result.addError(RuleArrayItems, path, "array type should have items or prefixItems defined")
+	}
+
+	// Validate numeric constraints
+	if s.Minimum != nil && s.Maximum != nil {
+		if *s.Minimum > *s.Maximum {
+			result.addError(RuleSchemaRange, path, "minimum cannot be greater than maximum")
+		}
+	}
+	if s.ExclusiveMinimum != nil && s.ExclusiveMaximum != nil {
+		if *s.ExclusiveMinimum >= *s.ExclusiveMaximum {
+			result.addError(RuleSchemaRange, path, "exclusiveMinimum must be less than exclusiveMaximum")
+		}
+	}
+	if s.MinLength != nil && s.MaxLength != nil {
+		if *s.MinLength > *s.MaxLength {
+			result.addError(RuleSchemaRange, path, "minLength cannot be greater than maxLength")
+		}
+	}
+	if s.MinItems != nil && s.MaxItems != nil {
+		if *s.MinItems > *s.MaxItems {
+			result.addError(RuleSchemaRange, path, "minItems cannot be greater than maxItems")
+		}
+	}
+	if s.MinProperties != nil && s.MaxProperties != nil {
+		if *s.MinProperties > *s.MaxProperties {
+			result.addError(RuleSchemaRange, path, "minProperties cannot be greater than maxProperties")
+		}
+	}
+	if s.MinContains != nil && s.MaxContains != nil {
+		if *s.MinContains > *s.MaxContains {
+			result.addError(RuleSchemaRange, path, "minContains cannot be greater than maxContains")
+		}
+	}
+
+	// Validate pattern is valid regex
+	if s.Pattern != "" {
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			result.addError(RulePattern, path+".pattern", fmt.Sprintf("invalid regex pattern: %v", err))
+		}
+	}
+
+	if s.ReadOnly && s.WriteOnly {
+		result.addError(RuleReadWrite, path, "schema cannot be both readOnly and writeOnly")
+	}
+
+	// Validate required fields exist in properties
+	if len(s.Required) > 0 && len(s.Properties) > 0 {
+		for _, req := range s.Required {
+			if _, exists := s.Properties[req]; !exists {
+				result.addError(RuleRequiredProperty, path+".required", fmt.Sprintf("required property '%s' not defined in properties", req))
+			}
+		}
+	}
+
+	result.checkFormat(path+".format", s.Format)
+
+	// Validate discriminator
+	if s.Discriminator != nil {
+		s.validateDiscriminator(path, result)
+	}
+
+	// Validate nested schemas
+	if s.Items != nil {
+		s.Items.validate(path+".items", result)
+	}
+	for i, schema := range s.PrefixItems {
+		if schema != nil {
+			schema.validate(fmt.Sprintf("%s.prefixItems[%d]", path, i), result)
+		}
+	}
+	for name, prop := range s.Properties {
+		if prop != nil {
+			prop.validate(fmt.Sprintf("%s.properties[%s]", path, name), result)
+		}
+	}
+	if s.AdditionalProperties != nil {
+		s.AdditionalProperties.validate(path+".additionalProperties", result)
+	}
+	for i, schema := range s.AllOf {
+		if schema != nil {
+			schema.validate(fmt.Sprintf("%s.allOf[%d]", path, i), result)
+		}
+	}
+	for i, schema := range s.AnyOf {
+		if schema != nil {
+			schema.validate(fmt.Sprintf("%s.anyOf[%d]", path, i), result)
+		}
+	}
+	for i, schema := range s.OneOf {
+		if schema != nil {
+			schema.validate(fmt.Sprintf("%s.oneOf[%d]", path, i), result)
+		}
+	}
+	if s.Not != nil {
+		s.Not.validate(path+".not", result)
+	}
+	if s.If != nil {
+		s.If.validate(path+".if", result)
+	}
+	if s.Then != nil {
+		s.Then.validate(path+".then", result)
+	}
+	if s.Else != nil {
+		s.Else.validate(path+".else", result)
+	}
+	if s.Contains != nil {
+		s.Contains.validate(path+".contains", result)
+	}
+	if s.UnevaluatedItems != nil {
+		s.UnevaluatedItems.validate(path+".unevaluatedItems", result)
+	}
+	if s.UnevaluatedProperties != nil {
+		s.UnevaluatedProperties.validate(path+".unevaluatedProperties", result)
+	}
+}
+
+func (l *Link) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if l.IsReference() {
+		result.checkRef(path, l.Ref, "links")
+		return
+	}
+
+	// operationId XOR operationRef - cannot have both
+	if l.OperationId != "" && l.OperationRef != "" {
+		result.addError(RuleLinkOperation, path, "cannot have both 'operationId' and 'operationRef'")
+	}
+}
+
+func (c *Callback) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if c.IsReference() {
+		result.checkRef(path, c.Ref, "callbacks")
+		return
+	}
+
+	for expr, pathItem := range c.Paths {
+		if pathItem != nil {
+			pathItem.validate(fmt.Sprintf("%s[%s]", path, expr), result)
+		}
+	}
+}
+
+func (t *Tag) validate(path string, result *ValidationResult) {
+	// Required: name
+	if t.Name == "" {
+		result.addError(RuleRequired, path+".name", "required field is missing")
+	}
+}
+
+func (c *Components) validate(path string, result *ValidationResult) {
+	// Validate schemas
+	for name, schema := range c.Schemas {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.schemas[%s]", path, name), "component name contains invalid characters")
+		}
+		if schema != nil {
+			result.run(func(result *ValidationResult) {
+				schema.validate(fmt.Sprintf("%s.schemas[%s]", path, name), result)
+			})
+		}
+	}
+
+	// Validate responses
+	for name, resp := range c.Responses {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.responses[%s]", path, name), "component name contains invalid characters")
+		}
+		if resp != nil {
+			resp.validate(fmt.Sprintf("%s.responses[%s]", path, name), result)
+		}
+	}
+
+	// Validate parameters
+	for name, param := range c.Parameters {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.parameters[%s]", path, name), "component name contains invalid characters")
+		}
+		if param != nil {
+			param.validate(fmt.Sprintf("%s.parameters[%s]", path, name), result)
+		}
+	}
+
+	// Validate requestBodies
+	for name, rb := range c.RequestBodies {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.requestBodies[%s]", path, name), "component name contains invalid characters")
+		}
+		if rb != nil {
+			rb.validate(fmt.Sprintf("%s.requestBodies[%s]", path, name), result)
+		}
+	}
+
+	// Validate examples
+	for name, ex := range c.Examples {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.examples[%s]", path, name), "component name contains invalid characters")
+		}
+		if ex != nil && ex.IsReference() {
+			result.checkRef(fmt.Sprintf("%s.examples[%s]", path, name), ex.Ref, "examples")
+		}
+	}
+
+	// Validate headers
+	for name, header := range c.Headers {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.headers[%s]", path, name), "component name contains invalid characters")
+		}
+		if header != nil {
+			header.validate(fmt.Sprintf("%s.headers[%s]", path, name), result)
+		}
+	}
+
+	// Validate securitySchemes
+	for name, ss := range c.SecuritySchemes {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.securitySchemes[%s]", path, name), "component name contains invalid characters")
+		}
+		if ss != nil {
+			ss.validate(fmt.Sprintf("%s.securitySchemes[%s]", path, name), result)
+		}
+	}
+
+	// Validate links
+	for name, link := range c.Links {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.links[%s]", path, name), "component name contains invalid characters")
+		}
+		if link != nil {
+			link.validate(fmt.Sprintf("%s.links[%s]", path, name), result)
+		}
+	}
+
+	// Validate callbacks
+	for name, cb := range c.Callbacks {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.callbacks[%s]", path, name), "component name contains invalid characters")
+		}
+		if cb != nil {
+			cb.validate(fmt.Sprintf("%s.callbacks[%s]", path, name), result)
+		}
+	}
+
+	// Validate pathItems
+	for name, pathItem := range c.PathItems {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.pathItems[%s]", path, name), "component name contains invalid characters")
+		}
+		if pathItem != nil {
+			pathItem.validate(fmt.Sprintf("%s.pathItems[%s]", path, name), result)
+		}
+	}
+
+	// Validate mediaTypes (OpenAPI 3.2 specific)
+	for name, mt := range c.MediaTypes {
+		if !componentNamePattern.MatchString(name) {
+			result.addError(RuleComponentName, fmt.Sprintf("%s.mediaTypes[%s]", path, name), "component name contains invalid characters")
+		}
+		if mt != nil {
+			mt.validate(fmt.Sprintf("%s.mediaTypes[%s]", path, name), result)
+		}
+	}
+}
+
+func (ss *SecurityScheme) validate(path string, result *ValidationResult) {
+	// Skip validation for references
+	if ss.IsReference() {
+		result.checkRef(path, ss.Ref, "securitySchemes")
+		return
+	}
+
+	// Required: type
+	if ss.Type == "" {
+		result.addError(RuleRequired, path+".type", "required field is missing")
+	} else {
+		validTypes := map[string]bool{"apiKey": true, "http": true, "mutualTLS": true, "oauth2": true, "openIdConnect": true}
+		if !validTypes[ss.Type] {
+			result.addError(RuleSecuritySchemeType, path+".type", fmt.Sprintf("must be one of: apiKey, http, mutualTLS, oauth2, openIdConnect; got %s", ss.Type))
+		}
+	}
+
+	// Type-specific requirements
+	switch ss.Type {
+	case "apiKey":
+		if ss.Name == "" {
+			result.addError(RuleSecurityScheme, path+".name", "required for apiKey type")
+		}
+		if ss.In == "" {
+			result.addError(RuleSecurityScheme, path+".in", "required for apiKey type")
+		} else {
+			validIn := map[string]bool{"query": true, "header": true, "cookie": true}
+			if !validIn[ss.In] {
+				result.addError(RuleSecurityScheme, path+".in", "must be one of: query, header, cookie")
+			}
+		}
+	case "http":
+		if ss.Scheme == "" {
+			result.addError(RuleSecurityScheme, path+".scheme", "required for http type")
+		}
+	case "oauth2":
+		if ss.Flows == nil {
+			result.addError(RuleSecurityScheme, path+".flows", "required for oauth2 type")
+		} else {
+			ss.Flows.validate(path+".flows", result)
+		}
+	case "openIdConnect":
+		if ss.OpenIdConnectUrl == "" {
+			result.addError(RuleSecurityScheme, path+".openIdConnectUrl", "required for openIdConnect type")
+		}
+	}
+}
+
+func (f *OAuthFlows) validate(path string, result *ValidationResult) {
+	// At least one flow must be defined
+	hasFlow := f.Implicit != nil || f.Password != nil || f.ClientCredentials != nil || f.AuthorizationCode != nil ||
+		f.DeviceAuthorization != nil
+	if !hasFlow {
+		result.addError(RuleOAuthFlow, path, "at least one OAuth flow must be defined")
+	}
+
+	if f.Implicit != nil {
+		// Implicit requires authorizationUrl
+		if f.Implicit.AuthorizationUrl == "" {
+			result.addError(RuleOAuthFlow, path+".implicit.authorizationUrl", "required for implicit flow")
+		}
+		if f.Implicit.Scopes == nil {
+			result.addError(RuleRequired, path+".implicit.scopes", "required field is missing")
+		}
+	}
+
+	if f.Password != nil {
+		// Password requires tokenUrl
+		if f.Password.TokenUrl == "" {
+			result.addError(RuleOAuthFlow, path+".password.tokenUrl", "required for password flow")
+		}
+		if f.Password.Scopes == nil {
+			result.addError(RuleRequired, path+".password.scopes", "required field is missing")
+		}
+	}
+
+	if f.ClientCredentials != nil {
+		// ClientCredentials requires tokenUrl
+		if f.ClientCredentials.TokenUrl == "" {
+			result.addError(RuleOAuthFlow, path+".clientCredentials.tokenUrl", "required for clientCredentials flow")
+		}
+		if f.ClientCredentials.Scopes == nil {
+			result.addError(RuleRequired, path+".clientCredentials.scopes", "required field is missing")
+		}
+	}
+
+	if f.AuthorizationCode != nil {
+		// AuthorizationCode requires both authorizationUrl and tokenUrl
+		if f.AuthorizationCode.AuthorizationUrl == "" {
+			result.addError(RuleOAuthFlow, path+".authorizationCode.authorizationUrl", "required for authorizationCode flow")
+		}
+		if f.AuthorizationCode.TokenUrl == "" {
+			result.addError(RuleOAuthFlow, path+".authorizationCode.tokenUrl", "required for authorizationCode flow")
+		}
+		if f.AuthorizationCode.Scopes == nil {
+			result.addError(RuleRequired, path+".authorizationCode.scopes", "required field is missing")
+		}
+	}
+
+	if f.DeviceAuthorization != nil {
+		// DeviceAuthorization requires both deviceAuthorizationUrl and tokenUrl
+		if f.DeviceAuthorization.DeviceAuthorizationUrl == "" {
+			result.addError(RuleOAuthFlow, path+".deviceAuthorization.deviceAuthorizationUrl", "required for deviceAuthorization flow")
+		}
+		if f.DeviceAuthorization.TokenUrl == "" {
+			result.addError(RuleOAuthFlow, path+".deviceAuthorization.tokenUrl", "required for deviceAuthorization flow")
+		}
+		if f.DeviceAuthorization.Scopes == nil {
+			result.addError(RuleRequired, path+".deviceAuthorization.scopes", "required field is missing")
+		}
+	}
+}
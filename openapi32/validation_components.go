@@ -0,0 +1,213 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// componentsPrefix starts the local references to components
+const componentsPrefix = "#/components/"
+
+// componentRef names a component by its section and name
+type componentRef struct {
+	kind string // schemas, responses, parameters, ...
+	name string
+}
+
+func (c componentRef) String() string {
+	return componentsPrefix + escapePointer(c.kind) + "/" + escapePointer(c.name)
+}
+
+// UnusedComponents returns references to the components that cannot be reached
+// from the paths, webhooks or any other part of the document outside
+// components, sorted, such as "#/components/schemas/Pet". Components only
+// referenced by unused components are unused as well. Security schemes are used when a security
+// requirement names them, and schemas extending a used schema with a
+// discriminator through allOf are used.
+func (o *OpenAPI) UnusedComponents() []string {
+	var unused []string
+	for _, c := range o.unusedComponents() {
+		unused = append(unused, c.String())
+	}
+	return unused
+}
+
+func (o *OpenAPI) unusedComponents() []componentRef {
+	if o == nil || o.Components == nil {
+		return nil
+	}
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil
+	}
+
+	u := &componentUsage{used: make(map[componentRef]bool)}
+	u.components, _ = root["components"].(map[string]any)
+	delete(root, "components")
+	u.walk(root)
+	u.useSubschemas()
+
+	var unused []componentRef
+	for kind, section := range u.components {
+		entries, ok := section.(map[string]any)
+		if !ok || strings.HasPrefix(kind, "x-") {
+			continue
+		}
+		for name := range entries {
+			if c := (componentRef{kind, name}); !u.used[c] {
+				unused = append(unused, c)
+			}
+		}
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].kind != unused[j].kind {
+			return unused[i].kind < unused[j].kind
+		}
+		return unused[i].name < unused[j].name
+	})
+	return unused
+}
+
+// validateUnusedComponents reports, with UnusedComponents, the components
+// that are never used
+func (o *OpenAPI) validateUnusedComponents(result *ValidationResult) {
+	if result.opts == nil || !result.opts.UnusedComponents {
+		return
+	}
+	for _, c := range o.unusedComponents() {
+		result.addError(RuleUnusedComponent, fmt.Sprintf("components.%s[%s]", c.kind, c.name),
+			fmt.Sprintf("component '%s' is never referenced", c.name))
+	}
+}
+
+// componentUsage collects the components reachable from a document as generic JSON
+type componentUsage struct {
+	components map[string]any
+	used       map[componentRef]bool
+}
+
+// use marks a component as used and follows its references
+func (u *componentUsage) use(c componentRef) {
+	if u.used[c] {
+		return
+	}
+	section, _ := u.components[c.kind].(map[string]any)
+	value, ok := section[c.name]
+	if !ok {
+		return
+	}
+	u.used[c] = true
+	u.walk(value)
+}
+
+// useRef marks the component a local reference points into
+func (u *componentUsage) useRef(ref string) {
+	if c, ok := parseComponentRef(ref); ok {
+		u.use(c)
+	}
+}
+
+// parseComponentRef returns the component a local reference points into
+func parseComponentRef(ref string) (componentRef, bool) {
+	fragment, err := url.PathUnescape(ref)
+	if err != nil || !strings.HasPrefix(fragment, componentsPrefix) {
+		return componentRef{}, false
+	}
+	tokens := strings.SplitN(strings.TrimPrefix(fragment, componentsPrefix), "/", 3)
+	if len(tokens) < 2 {
+		return componentRef{}, false
+	}
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	return componentRef{unescape.Replace(tokens[0]), unescape.Replace(tokens[1])}, true
+}
+
+func (u *componentUsage) walk(node any) {
+	switch v := node.(type) {
+	case []any:
+		for _, item := range v {
+			u.walk(item)
+		}
+	case map[string]any:
+		for key, value := range v {
+			switch key {
+			case "$ref":
+				if ref, ok := value.(string); ok {
+					u.useRef(ref)
+				}
+			case "security":
+				// Security requirements name their schemes
+				requirements, _ := value.([]any)
+				for _, req := range requirements {
+					if names, ok := req.(map[string]any); ok {
+						for name := range names {
+							u.use(componentRef{"securitySchemes", name})
+						}
+					}
+				}
+			case "discriminator":
+				// Mapping values are schema names or references
+				d, _ := value.(map[string]any)
+				mapping, _ := d["mapping"].(map[string]any)
+				for _, target := range mapping {
+					if s, ok := target.(string); ok {
+						if strings.HasPrefix(s, "#") {
+							u.useRef(s)
+						} else {
+							u.use(componentRef{"schemas", s})
+						}
+					}
+				}
+			}
+			u.walk(value)
+		}
+	}
+}
+
+// useSubschemas marks the schemas that extend a used schema having a
+// discriminator, since the discriminator selects them by name
+func (u *componentUsage) useSubschemas() {
+	schemas, _ := u.components["schemas"].(map[string]any)
+	for changed := true; changed; {
+		changed = false
+		for name, schema := range schemas {
+			c := componentRef{"schemas", name}
+			if u.used[c] || !u.extendsDiscriminator(schema) {
+				continue
+			}
+			u.use(c)
+			changed = true
+		}
+	}
+}
+
+// extendsDiscriminator reports whether a schema lists a used schema with a
+// discriminator in its allOf
+func (u *componentUsage) extendsDiscriminator(schema any) bool {
+	s, _ := schema.(map[string]any)
+	allOf, _ := s["allOf"].([]any)
+	for _, part := range allOf {
+		p, _ := part.(map[string]any)
+		ref, _ := p["$ref"].(string)
+		c, ok := parseComponentRef(ref)
+		if !ok || c.kind != "schemas" || !u.used[c] {
+			continue
+		}
+		schemas, _ := u.components["schemas"].(map[string]any)
+		parent, _ := schemas[c.name].(map[string]any)
+		if _, ok := parent["discriminator"]; ok {
+			return true
+		}
+	}
+	return false
+}
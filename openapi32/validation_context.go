@@ -0,0 +1,96 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ValidateContext validates the document like ValidateWithOptions, checking
+// paths, webhooks and component schemas concurrently on at most
+// ValidationOptions.Workers goroutines, and reports the findings in the same
+// order. It stops early and returns the context error when ctx is done.
+func (o *OpenAPI) ValidateContext(ctx context.Context, opts *ValidationOptions) (*ValidationResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if opts != nil && opts.Workers > 0 {
+		workers = opts.Workers
+	}
+	tasks := &validationTasks{ctx: ctx, slots: make(chan struct{}, workers)}
+	result := &ValidationResult{opts: opts, tasks: tasks}
+	o.validateDocument(result)
+	if err := tasks.wait(); err != nil {
+		return nil, err
+	}
+	result.tasks = nil
+	// Put the findings of each task where ValidateWithOptions finds them
+	var errs []ValidationError
+	last := 0
+	for _, part := range tasks.parts {
+		errs = append(errs, result.Errors[last:part.at]...)
+		errs = append(errs, part.result.Errors...)
+		last = part.at
+	}
+	result.Errors = append(errs, result.Errors[last:]...)
+	return result, nil
+}
+
+// validationTasks runs the independent parts of a validation concurrently
+type validationTasks struct {
+	ctx   context.Context
+	slots chan struct{} // bounds the running tasks
+	wg    sync.WaitGroup
+	parts []validationPart // the results of the tasks, in start order
+}
+
+// validationPart is the result of a task, and the number of findings of the
+// document before the task started
+type validationPart struct {
+	result *ValidationResult
+	at     int
+}
+
+// run validates an independent part of the document. It runs task at once,
+// or on its own goroutine and result under ValidateContext.
+func (r *ValidationResult) run(task func(result *ValidationResult)) {
+	if r.tasks == nil {
+		task(r)
+		return
+	}
+	r.tasks.start(r, task)
+}
+
+// start waits for a free slot and runs task on a copy of parent without
+// findings. Tasks run their own parts at once, so they never wait for a slot.
+func (t *validationTasks) start(parent *ValidationResult, task func(result *ValidationResult)) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-t.ctx.Done():
+		return
+	}
+	part := *parent
+	part.Errors, part.tasks = nil, nil
+	t.parts = append(t.parts, validationPart{result: &part, at: len(parent.Errors)})
+	t.wg.Add(1)
+	go func() {
+		defer func() {
+			<-t.slots
+			t.wg.Done()
+		}()
+		if t.ctx.Err() == nil {
+			task(&part)
+		}
+	}()
+}
+
+// wait waits for the started tasks and returns the context error, if any
+func (t *validationTasks) wait() error {
+	t.wg.Wait()
+	return t.ctx.Err()
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+// RuleError is the sentinel error of a rule. A ValidationError is a RuleError
+// of its rule, so errors.Is(result.Err(), RuleError(RuleRequired)) reports
+// whether a required field is missing.
+type RuleError string
+
+func (e RuleError) Error() string {
+	return "openapi32: " + string(e) + " rule"
+}
+
+// Is reports whether target is the RuleError of the rule of the finding
+func (e ValidationError) Is(target error) bool {
+	rule, ok := target.(RuleError)
+	return ok && string(rule) == e.Rule
+}
+
+// Err returns the result as an error when it has findings with
+// SeverityError, and nil otherwise
+func (r *ValidationResult) Err() error {
+	if r == nil || r.Valid() {
+		return nil
+	}
+	return r
+}
+
+// Unwrap returns the findings with SeverityError as ValidationError values,
+// like the errors of errors.Join, so errors.Is and errors.As look into them
+func (r *ValidationResult) Unwrap() []error {
+	var errs []error
+	for _, e := range r.BySeverity(SeverityError) {
+		errs = append(errs, e)
+	}
+	return errs
+}
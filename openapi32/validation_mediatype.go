@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"fmt"
+	"mime"
+	"regexp"
+	"strings"
+)
+
+// mediaTypeNamePattern matches an RFC 6838 restricted-name
+var mediaTypeNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+
+// checkMediaType reports a media type string that is not a valid RFC 6838
+// type/subtype with optional parameters. Wildcards are accepted as a whole
+// type (*/*), a whole subtype (image/*) or a suffixed subtype (application/*+json).
+func (r *ValidationResult) checkMediaType(path, mediaType string) {
+	if msg := mediaTypeProblem(mediaType); msg != "" {
+		r.addError(RuleMediaType, path, fmt.Sprintf("invalid media type '%s': %s", mediaType, msg))
+	}
+}
+
+// mediaTypeProblem describes what is wrong with a media type, or returns ""
+func mediaTypeProblem(mediaType string) string {
+	essence, params, hasParams := strings.Cut(mediaType, ";")
+	essence = strings.TrimSpace(essence)
+	typ, subtype, ok := strings.Cut(essence, "/")
+	if !ok {
+		return "expected type/subtype"
+	}
+	switch {
+	case typ == "*":
+		if subtype != "*" {
+			return "a wildcard type requires a wildcard subtype"
+		}
+	case !mediaTypeNamePattern.MatchString(typ):
+		return fmt.Sprintf("invalid type '%s'", typ)
+	case subtype == "*":
+	case strings.HasPrefix(subtype, "*+"):
+		if !mediaTypeNamePattern.MatchString(subtype[2:]) {
+			return fmt.Sprintf("invalid suffix '%s'", subtype[2:])
+		}
+	case !mediaTypeNamePattern.MatchString(subtype):
+		return fmt.Sprintf("invalid subtype '%s'", subtype)
+	}
+	if hasParams {
+		if _, _, err := mime.ParseMediaType("type/subtype;" + params); err != nil {
+			return "invalid parameters"
+		}
+	}
+	return ""
+}
+
+// checkEncoding reports encoding on media types other than multipart and
+// application/x-www-form-urlencoded, the only ones it applies to, and encoding
+// keys that do not name a property of the media type schema
+func (r *ValidationResult) checkEncoding(path, mediaType string, m *MediaType) {
+	if m == nil || len(m.Encoding) == 0 || m.IsReference() {
+		return
+	}
+	if !encodesProperties(mediaType) {
+		r.addError(RuleEncoding, path+".encoding",
+			fmt.Sprintf("encoding only applies to multipart and application/x-www-form-urlencoded content, not '%s'", mediaType))
+	}
+	if m.Schema == nil {
+		return
+	}
+	for name := range m.Encoding {
+		if !r.definesProperty(m.Schema, name, 0) {
+			r.addError(RuleEncoding, fmt.Sprintf("%s.encoding[%s]", path, name),
+				fmt.Sprintf("property '%s' is not defined in the schema", name))
+		}
+	}
+}
+
+// encodesProperties reports whether a media type sends the schema properties
+// as separate parts or fields
+func encodesProperties(mediaType string) bool {
+	essence, _, _ := strings.Cut(mediaType, ";")
+	essence = strings.ToLower(strings.TrimSpace(essence))
+	return strings.HasPrefix(essence, "multipart/") || essence == "application/x-www-form-urlencoded"
+}
+
+// definesProperty reports whether s, its allOf, oneOf or anyOf members, or the
+// local schemas they reference define the property. Schemas that cannot be
+// followed are assumed to define it.
+func (r *ValidationResult) definesProperty(s *Schema, name string, depth int) bool {
+	if s == nil {
+		return false
+	}
+	if depth == maxRefDepth {
+		return true
+	}
+	if v := s.BooleanValue(); v != nil {
+		return *v
+	}
+	if s.Ref != "" {
+		target := r.localSchema(s.Ref)
+		return target == nil || r.definesProperty(target, name, depth+1)
+	}
+	if _, ok := s.Properties[name]; ok {
+		return true
+	}
+	for _, members := range [][]*Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, sub := range members {
+			if r.definesProperty(sub, name, depth+1) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import "fmt"
+
+// checkDirection reports the required properties a message cannot carry:
+// readOnly properties of a request body schema and writeOnly properties of a
+// response schema. Generated clients and servers cannot satisfy them.
+func (r *ValidationResult) checkDirection(path string, s *Schema, request bool) {
+	r.walkDirection(path, s, request, make(map[*Schema]bool), 0)
+}
+
+func (r *ValidationResult) walkDirection(path string, s *Schema, request bool, seen map[*Schema]bool, depth int) {
+	if s == nil || seen[s] || depth == maxRefDepth {
+		return
+	}
+	seen[s] = true
+	if s.Ref != "" {
+		r.walkDirection(path, r.localSchema(s.Ref), request, seen, depth+1)
+		return
+	}
+
+	for _, name := range s.Required {
+		prop := r.resolveSchema(r.lookupProperty(s, name, 0).schema)
+		switch {
+		case prop == nil:
+		case request && prop.ReadOnly:
+			r.addError(RuleRequiredReadWrite, path+".required",
+				fmt.Sprintf("required property '%s' is readOnly and cannot be sent in a request", name))
+		case !request && prop.WriteOnly:
+			r.addError(RuleRequiredReadWrite, path+".required",
+				fmt.Sprintf("required property '%s' is writeOnly and cannot be returned in a response", name))
+		}
+	}
+
+	r.walkDirection(path+".items", s.Items, request, seen, depth)
+	r.walkDirection(path+".additionalProperties", s.AdditionalProperties, request, seen, depth)
+	for name, prop := range s.Properties {
+		r.walkDirection(fmt.Sprintf("%s.properties[%s]", path, name), prop, request, seen, depth)
+	}
+	for i, sub := range s.AllOf {
+		r.walkDirection(fmt.Sprintf("%s.allOf[%d]", path, i), sub, request, seen, depth)
+	}
+	for i, sub := range s.AnyOf {
+		r.walkDirection(fmt.Sprintf("%s.anyOf[%d]", path, i), sub, request, seen, depth)
+	}
+	for i, sub := range s.OneOf {
+		r.walkDirection(fmt.Sprintf("%s.oneOf[%d]", path, i), sub, request, seen, depth)
+	}
+}
+
+// resolveSchema follows the local references of a schema
+func (r *ValidationResult) resolveSchema(s *Schema) *Schema {
+	for depth := 0; s != nil && s.Ref != "" && depth < maxRefDepth; depth++ {
+		s = r.localSchema(s.Ref)
+	}
+	return s
+}
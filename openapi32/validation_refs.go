@@ -0,0 +1,90 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// loadRoot keeps the document as generic JSON so that references can be
+// resolved as JSON pointers
+func (r *ValidationResult) loadRoot(o *OpenAPI) {
+	data, err := json.Marshal(o)
+	if err == nil {
+		err = json.Unmarshal(data, &r.root)
+	}
+	if err != nil {
+		r.addError(RuleRef, "", fmt.Sprintf("document could not be encoded to resolve references: %v", err))
+		r.root = nil
+	}
+}
+
+// checkRef reports a local reference that does not resolve, or that points
+// directly at a component of another kind than expected. An empty kind only
+// checks that the reference resolves. References into other documents and
+// plain-name fragments are skipped. It does nothing unless references are resolved.
+func (r *ValidationResult) checkRef(path, ref, kind string) {
+	if r.root == nil || !strings.HasPrefix(ref, "#") {
+		return
+	}
+	fragment, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		r.addError(RuleRef, path+".$ref", fmt.Sprintf("invalid reference %s: %v", ref, err))
+		return
+	}
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		return
+	}
+
+	var tokens []string
+	if fragment != "" {
+		for _, token := range strings.Split(fragment[1:], "/") {
+			tokens = append(tokens, strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~"))
+		}
+	}
+	if kind != "" && len(tokens) == 3 && tokens[0] == "components" && tokens[1] != kind {
+		r.addError(RuleRef, path+".$ref", fmt.Sprintf("reference %s points at %s, expected %s", ref, tokens[1], kind))
+		return
+	}
+	if _, ok := resolvePointer(r.root, tokens); !ok {
+		r.addError(RuleRef, path+".$ref", fmt.Sprintf("reference %s does not resolve", ref))
+	}
+}
+
+// checkExampleRefs checks the references in an examples map
+func (r *ValidationResult) checkExampleRefs(path string, examples map[string]*Example) {
+	for name, ex := range examples {
+		if ex != nil && ex.IsReference() {
+			r.checkRef(fmt.Sprintf("%s.examples[%s]", path, name), ex.Ref, "examples")
+		}
+	}
+}
+
+// resolvePointer follows unescaped JSON pointer tokens through generic JSON
+func resolvePointer(node any, tokens []string) (any, bool) {
+	for _, token := range tokens {
+		switch v := node.(type) {
+		case map[string]any:
+			next, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			node = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			node = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"fmt"
+	"strings"
+)
+
+// securityScheme looks up a security scheme by the name used in a security
+// requirement, following a local reference. The scheme is nil when it is
+// declared but cannot be resolved.
+func (o *OpenAPI) securityScheme(name string) (*SecurityScheme, bool) {
+	const prefix = "#/components/securitySchemes/"
+	if o.Components == nil {
+		return nil, false
+	}
+	scheme, ok := o.Components.SecuritySchemes[name]
+	if !ok {
+		return nil, false
+	}
+	if scheme != nil && scheme.IsReference() {
+		if !strings.HasPrefix(scheme.Ref, prefix) {
+			return nil, true
+		}
+		scheme = o.Components.SecuritySchemes[strings.TrimPrefix(scheme.Ref, prefix)]
+		if scheme != nil && scheme.IsReference() {
+			return nil, true
+		}
+	}
+	return scheme, true
+}
+
+// validateSecurityRequirements checks the document-level and operation-level
+// security requirements against components.securitySchemes
+func (o *OpenAPI) validateSecurityRequirements(result *ValidationResult) {
+	o.validateSecurity("security", o.Security, result)
+	o.eachOperation(func(path string, op *Operation) {
+		o.validateSecurity(path+".security", op.Security, result)
+	})
+}
+
+// validateSecurity checks that each scheme named in the requirements is
+// declared. Names that are URIs are not resolved. Since 3.1 schemes other than oauth2 and openIdConnect may list
+// role names, so scopes are not restricted by scheme type; oauth2 scopes
+// must still be declared by one of the scheme's flows.
+func (o *OpenAPI) validateSecurity(path string, requirements []SecurityRequirement, result *ValidationResult) {
+	for i, requirement := range requirements {
+		for name, scopes := range requirement {
+			reqPath := fmt.Sprintf("%s[%d][%s]", path, i, name)
+			if strings.ContainsAny(name, ":/#") {
+				continue
+			}
+			scheme, declared := o.securityScheme(name)
+			if !declared {
+				result.addError(RuleSecurityRequirement, reqPath,
+					fmt.Sprintf("security scheme '%s' is not declared in components.securitySchemes", name))
+				continue
+			}
+			if scheme == nil {
+				continue
+			}
+			if scheme.Type == "oauth2" && scheme.Flows != nil {
+				for j, scope := range scopes {
+					if !scheme.Flows.declaresScope(scope) {
+						result.addError(RuleOAuthScope, fmt.Sprintf("%s[%d]", reqPath, j),
+							fmt.Sprintf("scope '%s' is not declared by any flow of '%s'", scope, name))
+					}
+				}
+			}
+		}
+	}
+}
+
+// declaresScope reports whether any flow declares the scope
+func (f *OAuthFlows) declaresScope(scope string) bool {
+	for _, flow := range []*OAuthFlow{f.Implicit, f.Password, f.ClientCredentials, f.AuthorizationCode, f.DeviceAuthorization} {
+		if flow == nil {
+			continue
+		}
+		if _, ok := flow.Scopes[scope]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package openapi32
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// validateTemplate checks that the url template is well-formed, that every
+// {variable} it uses is declared and that every declared variable is used
+func (s *Server) validateTemplate(path string, result *ValidationResult) {
+	if s.URL == "" {
+		return
+	}
+	names, problem := serverURLVariables(s.URL)
+	if problem != "" {
+		result.addError(RuleServerURL, path+".url", "malformed url template: "+problem)
+		return
+	}
+
+	used := make(map[string]bool, len(names))
+	substituted := s.URL
+	for _, name := range names {
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		value := "x"
+		if v, ok := s.Variables[name]; !ok {
+			result.addError(RuleServerVariable, path+".url", fmt.Sprintf("variable '%s' is not declared in variables", name))
+		} else if v != nil && v.Default != "" {
+			value = v.Default
+		}
+		substituted = strings.ReplaceAll(substituted, "{"+name+"}", value)
+	}
+	for name := range s.Variables {
+		if !used[name] {
+			result.addError(RuleServerVariable, fmt.Sprintf("%s.variables[%s]", path, name), "variable is not used in the url")
+		}
+	}
+
+	if _, err := url.Parse(substituted); err != nil {
+		result.addError(RuleServerURL, path+".url", fmt.Sprintf("invalid url after substituting defaults: %v", err))
+	}
+}
+
+// serverURLVariables returns the variable names of a url template in order,
+// or a description of why the template is malformed
+func serverURLVariables(template string) ([]string, string) {
+	var names []string
+	rest := template
+	for {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			return names, ""
+		}
+		if rest[open] == '}' {
+			return nil, "unmatched '}'"
+		}
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end < 0 || rest[open+1+end] == '{' {
+			return nil, "unmatched '{'"
+		}
+		name := rest[open+1 : open+1+end]
+		if name == "" {
+			return nil, "empty variable name"
+		}
+		names = append(names, name)
+		rest = rest[open+end+2:]
+	}
+}
@@ -0,0 +1,404 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package router matches HTTP requests to the operations of an OpenAPI
+// document of any version, the foundation of validation middleware, mock
+// servers and the like.
+package router
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// ErrNotFound is returned when no path of the document matches a request
+var ErrNotFound = errors.New("no path matches the request")
+
+// ErrMethodNotAllowed is matched by a MethodNotAllowedError
+var ErrMethodNotAllowed = errors.New("method not allowed")
+
+// MethodNotAllowedError is returned when a path matches a request but has no
+// operation for its method
+type MethodNotAllowedError struct {
+	Method  string
+	Path    string   // the path template
+	Allowed []string // the methods of the path, upper case and sorted
+}
+
+func (e *MethodNotAllowedError) Error() string {
+	return fmt.Sprintf("method %s not allowed for %s, allowed: %s", e.Method, e.Path, strings.Join(e.Allowed, ", "))
+}
+
+// Is makes errors.Is match ErrMethodNotAllowed
+func (e *MethodNotAllowedError) Is(target error) bool {
+	return target == ErrMethodNotAllowed
+}
+
+// Options configures a Router
+type Options struct {
+	// IgnoreHost matches servers by their path only, for a router behind a
+	// gateway that changes the host of requests
+	IgnoreHost bool
+}
+
+// Match is an operation matching a request
+type Match struct {
+	Path       string // the path template, as in /pets/{petId}
+	Method     string // upper case
+	PathItem   unified.PathItem
+	Operation  unified.Operation
+	PathParams map[string]string // unescaped values keyed by name
+
+	// Server is the server of the operation the request was sent to, nil
+	// for a document without servers, served from "/"
+	Server          unified.Server
+	ServerVariables map[string]string
+}
+
+// Router matches requests to the operations of a document. Servers are
+// matched by host, when their URL has one, and path; the scheme is not
+// compared, since a proxy in front of an API often terminates TLS.
+type Router struct {
+	doc    unified.Document
+	routes []*route
+	opts   Options
+}
+
+// route is a path of the document
+type route struct {
+	path     string
+	item     unified.PathItem
+	segments []segment
+	ops      map[string]*operation // by upper-case method
+	servers  []*server             // of all operations, once each
+}
+
+// operation is an operation of a route with its effective servers
+type operation struct {
+	op      unified.Operation
+	servers []*server
+}
+
+// New returns a router for the paths of a document
+func New(doc unified.Document, opts Options) (*Router, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to route")
+	}
+	r := &Router{doc: doc, opts: opts}
+	compiled := make(map[string]*server)
+	paths := doc.GetPaths()
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		item := paths[path]
+		if item == nil {
+			continue
+		}
+		segments, err := parseTemplate(path)
+		if err != nil {
+			return nil, err
+		}
+		rt := &route{path: path, item: item, segments: segments, ops: make(map[string]*operation)}
+		for method, op := range item.GetAllOperations() {
+			o := &operation{op: op}
+			servers := unified.EffectiveServers(doc, item, op)
+			if len(servers) == 0 {
+				servers = []unified.Server{nil}
+			}
+			for _, s := range servers {
+				key := ""
+				if s != nil {
+					key = s.GetURL()
+				}
+				c, ok := compiled[key]
+				if !ok {
+					if c, err = compileServer(s); err != nil {
+						return nil, err
+					}
+					compiled[key] = c
+				}
+				o.servers = append(o.servers, c)
+				if !slices.Contains(rt.servers, c) {
+					rt.servers = append(rt.servers, c)
+				}
+			}
+			rt.ops[strings.ToUpper(method)] = o
+		}
+		r.routes = append(r.routes, rt)
+	}
+	return r, nil
+}
+
+// Find returns the operation matching a request. A path made of literal
+// segments takes precedence over a templated one: /pets/mine is matched
+// before /pets/{petId}. It returns an error matching ErrNotFound when no path
+// matches, and a *MethodNotAllowedError when no operation of a matching path
+// has the method.
+func (r *Router) Find(req *http.Request) (*Match, error) {
+	method := strings.ToUpper(req.Method)
+	escaped := req.URL.EscapedPath()
+	var best *Match
+	var bestRank []int
+	var bestPrefix int
+	var notAllowed *MethodNotAllowedError
+	for _, rt := range r.routes {
+		for _, s := range rt.servers {
+			rest, variables, ok := s.match(req, escaped, r.opts.IgnoreHost)
+			if !ok {
+				continue
+			}
+			params, rank, ok := matchSegments(rt.segments, rest)
+			if !ok {
+				continue
+			}
+			o := rt.ops[method]
+			if o == nil || !slices.Contains(o.servers, s) {
+				if notAllowed == nil {
+					notAllowed = &MethodNotAllowedError{Method: method, Path: rt.path}
+				}
+				for m, other := range rt.ops {
+					if slices.Contains(other.servers, s) && !slices.Contains(notAllowed.Allowed, m) {
+						notAllowed.Allowed = append(notAllowed.Allowed, m)
+					}
+				}
+				continue
+			}
+			prefix := len(escaped) - len(rest)
+			if best != nil && !better(rank, prefix, bestRank, bestPrefix) {
+				continue
+			}
+			best = &Match{
+				Path:            rt.path,
+				Method:          method,
+				PathItem:        rt.item,
+				Operation:       o.op,
+				PathParams:      params,
+				Server:          s.server,
+				ServerVariables: variables,
+			}
+			bestRank, bestPrefix = rank, prefix
+		}
+	}
+	if best != nil {
+		return best, nil
+	}
+	if notAllowed != nil {
+		slices.Sort(notAllowed.Allowed)
+		return nil, notAllowed
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNotFound, method, req.URL.Path)
+}
+
+// better reports whether a match ranks before another: by its segments,
+// literal before templated from the first, then by the longer server path
+func better(rank []int, prefix int, other []int, otherPrefix int) bool {
+	if c := slices.Compare(rank, other); c != 0 {
+		return c > 0
+	}
+	return prefix > otherPrefix
+}
+
+// segment is a segment of a path template. A literal segment has no
+// pattern; the others have the names of their parameters.
+type segment struct {
+	literal string
+	pattern *regexp.Regexp
+	names   []string
+	rank    int
+}
+
+// Ranks of segments, the more specific the higher
+const (
+	rankParameter = iota // only a parameter, as in {petId}
+	rankMixed            // text and parameters, as in {name}.json
+	rankLiteral
+)
+
+// templateParameter finds the parameters of a path or server template
+var templateParameter = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// parseTemplate splits a path template into segments
+func parseTemplate(path string) ([]segment, error) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("path %q must start with /", path)
+	}
+	var segments []segment
+	for _, part := range strings.Split(path[1:], "/") {
+		locs := templateParameter.FindAllStringSubmatchIndex(part, -1)
+		if locs == nil {
+			if strings.ContainsAny(part, "{}") {
+				return nil, fmt.Errorf("invalid template in path %q", path)
+			}
+			segments = append(segments, segment{literal: part, rank: rankLiteral})
+			continue
+		}
+		var expr strings.Builder
+		var names []string
+		last := 0
+		for _, loc := range locs {
+			expr.WriteString(regexp.QuoteMeta(part[last:loc[0]]))
+			expr.WriteString("(.+?)")
+			names = append(names, part[loc[2]:loc[3]])
+			last = loc[1]
+		}
+		expr.WriteString(regexp.QuoteMeta(part[last:]))
+		rank := rankMixed
+		if len(locs) == 1 && locs[0][0] == 0 && locs[0][1] == len(part) {
+			rank = rankParameter
+		}
+		segments = append(segments, segment{pattern: regexp.MustCompile("^" + expr.String() + "$"), names: names, rank: rank})
+	}
+	return segments, nil
+}
+
+// matchSegments matches an escaped path against the segments of a template,
+// returning the parameters and the rank of each segment
+func matchSegments(segments []segment, path string) (map[string]string, []int, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != len(segments) {
+		return nil, nil, false
+	}
+	params := make(map[string]string)
+	rank := make([]int, len(segments))
+	for i, s := range segments {
+		if s.pattern == nil {
+			if unescape(parts[i]) != s.literal {
+				return nil, nil, false
+			}
+			rank[i] = s.rank
+			continue
+		}
+		values := s.pattern.FindStringSubmatch(parts[i])
+		if values == nil {
+			return nil, nil, false
+		}
+		for j, name := range s.names {
+			params[name] = unescape(values[j+1])
+		}
+		rank[i] = s.rank
+	}
+	return params, rank, true
+}
+
+// unescape unescapes a path segment, keeping it as is when it is invalid
+func unescape(s string) string {
+	if u, err := url.PathUnescape(s); err == nil {
+		return u
+	}
+	return s
+}
+
+// server is a server URL compiled to match requests
+type server struct {
+	server    unified.Server
+	host      *regexp.Regexp // nil for a URL without host
+	hostNames []string       // the variables of the host
+	port      bool           // whether the host has a port
+	path      *regexp.Regexp // the base path, as a prefix
+	pathNames []string       // the variables of the path
+}
+
+// compileServer compiles the URL of a server, nil for the implicit "/"
+func compileServer(s unified.Server) (*server, error) {
+	c := &server{server: s}
+	raw := "/"
+	var variables map[string]unified.ServerVariable
+	if s != nil {
+		raw, variables = s.GetURL(), s.GetVariables()
+	}
+	rest := raw
+	if i := strings.Index(rest, "://"); i >= 0 {
+		rest = rest[i+3:]
+		host := rest
+		if j := strings.Index(rest, "/"); j >= 0 {
+			host, rest = rest[:j], rest[j:]
+		} else {
+			rest = ""
+		}
+		expr, names, err := serverPattern(raw, host, variables)
+		if err != nil {
+			return nil, err
+		}
+		c.host, c.hostNames = regexp.MustCompile("(?i)^"+expr+"$"), names
+		c.port = strings.Contains(templateParameter.ReplaceAllString(host, ""), ":")
+	} else if !strings.HasPrefix(rest, "/") {
+		rest = "/" + rest
+	}
+	expr, names, err := serverPattern(raw, strings.TrimSuffix(rest, "/"), variables)
+	if err != nil {
+		return nil, err
+	}
+	c.path, c.pathNames = regexp.MustCompile("^"+expr), names
+	return c, nil
+}
+
+// serverPattern turns part of a server URL into a regular expression with a
+// group for each variable, and returns the names of the variables. A
+// variable with an enum matches its values, any other what a segment can be.
+func serverPattern(raw, part string, variables map[string]unified.ServerVariable) (string, []string, error) {
+	var expr strings.Builder
+	var names []string
+	last := 0
+	for _, loc := range templateParameter.FindAllStringSubmatchIndex(part, -1) {
+		expr.WriteString(regexp.QuoteMeta(part[last:loc[0]]))
+		name := part[loc[2]:loc[3]]
+		variable, ok := variables[name]
+		if !ok || variable == nil {
+			return "", nil, fmt.Errorf("server %q has no variable %s", raw, name)
+		}
+		pattern := "[^/]*"
+		if enum := variable.GetEnum(); len(enum) > 0 {
+			quoted := make([]string, len(enum))
+			for i, value := range enum {
+				quoted[i] = regexp.QuoteMeta(value)
+			}
+			pattern = strings.Join(quoted, "|")
+		}
+		expr.WriteString("(" + pattern + ")")
+		names = append(names, name)
+		last = loc[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(part[last:]))
+	return expr.String(), names, nil
+}
+
+// match matches the host and escaped path of a request against the server,
+// returning the rest of the path and the values of the variables
+func (s *server) match(req *http.Request, path string, ignoreHost bool) (string, map[string]string, bool) {
+	variables := make(map[string]string)
+	if s.host != nil && !ignoreHost {
+		host := strings.ToLower(req.Host)
+		if h, _, err := net.SplitHostPort(host); err == nil && !s.port {
+			host = h
+		}
+		values := s.host.FindStringSubmatch(host)
+		if values == nil {
+			return "", nil, false
+		}
+		for i, name := range s.hostNames {
+			variables[name] = values[i+1]
+		}
+	}
+	values := s.path.FindStringSubmatch(path)
+	if values == nil {
+		return "", nil, false
+	}
+	rest := path[len(values[0]):]
+	if rest == "" {
+		rest = "/"
+	} else if !strings.HasPrefix(rest, "/") {
+		return "", nil, false
+	}
+	for i, name := range s.pathNames {
+		variables[name] = unescape(values[i+1])
+	}
+	return rest, variables, true
+}
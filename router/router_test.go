@@ -0,0 +1,139 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package router
+
+import (
+	"errors"
+	"maps"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+)
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return doc
+}
+
+const pets = `{
+	"openapi": "3.1.0",
+	"info": {"title": "Pets", "version": "1"},
+	"servers": [
+		{"url": "https://{region}.example.com/{version}", "variables": {
+			"region": {"default": "eu", "enum": ["eu", "us"]},
+			"version": {"default": "v1"}
+		}}
+	],
+	"paths": {
+		"/pets": {"get": {"operationId": "listPets", "responses": {}}, "post": {"operationId": "createPet", "responses": {}}},
+		"/pets/{petId}": {"get": {"operationId": "getPet", "responses": {}}},
+		"/pets/mine": {"get": {"operationId": "getMyPet", "responses": {}}},
+		"/files/{name}.{ext}": {"get": {"operationId": "getFile", "responses": {}}},
+		"/status": {"get": {"operationId": "status", "servers": [{"url": "/internal"}], "responses": {}}}
+	}
+}`
+
+func TestFind(t *testing.T) {
+	r, err := New(parse(t, pets), Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tests := []struct {
+		method, url string
+		id          string
+		params      map[string]string
+	}{
+		{"GET", "https://eu.example.com/v1/pets", "listPets", map[string]string{}},
+		{"post", "http://us.example.com:8080/v2/pets", "createPet", map[string]string{}},
+		{"GET", "https://eu.example.com/v1/pets/mine", "getMyPet", map[string]string{}},
+		{"GET", "https://eu.example.com/v1/pets/a%2Fb", "getPet", map[string]string{"petId": "a/b"}},
+		{"GET", "https://eu.example.com/v1/files/report.tar.gz", "getFile", map[string]string{"name": "report", "ext": "tar.gz"}},
+		{"GET", "https://anything.test/internal/status", "status", map[string]string{}},
+	}
+	for _, tt := range tests {
+		m, err := r.Find(httptest.NewRequest(tt.method, tt.url, nil))
+		if err != nil {
+			t.Errorf("Find(%s %s) error = %v", tt.method, tt.url, err)
+			continue
+		}
+		if m.Operation.GetOperationID() != tt.id || !maps.Equal(m.PathParams, tt.params) {
+			t.Errorf("Find(%s %s) = %s %v, want %s %v", tt.method, tt.url, m.Operation.GetOperationID(), m.PathParams, tt.id, tt.params)
+		}
+	}
+
+	m, err := r.Find(httptest.NewRequest("GET", "https://US.example.com/v2/pets/7", nil))
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if m.Path != "/pets/{petId}" || m.Method != "GET" || m.Server == nil || m.ServerVariables["region"] != "us" || m.ServerVariables["version"] != "v2" {
+		t.Errorf("Unexpected match: %+v", m)
+	}
+
+	for _, url := range []string{
+		"https://asia.example.com/v1/pets", // not in the enum
+		"https://eu.example.com/v1/pet",    // no such path
+		"https://eu.example.com/v1/pets/",  // trailing slash
+		"https://eu.example.com/internal/status/x",
+		"https://eu.example.com/v1/status", // the operation overrides the servers
+	} {
+		if _, err := r.Find(httptest.NewRequest("GET", url, nil)); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Find(%s) error = %v, want ErrNotFound", url, err)
+		}
+	}
+
+	_, err = r.Find(httptest.NewRequest("DELETE", "https://eu.example.com/v1/pets", nil))
+	var notAllowed *MethodNotAllowedError
+	if !errors.As(err, &notAllowed) || !errors.Is(err, ErrMethodNotAllowed) || !slices.Equal(notAllowed.Allowed, []string{"GET", "POST"}) {
+		t.Errorf("Expected a method not allowed error, got %v", err)
+	}
+}
+
+func TestFindOptions(t *testing.T) {
+	r, err := New(parse(t, pets), Options{IgnoreHost: true})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if m, err := r.Find(httptest.NewRequest("GET", "/v1/pets/mine", nil)); err != nil || m.Operation.GetOperationID() != "getMyPet" {
+		t.Errorf("Expected getMyPet ignoring the host, got %v", err)
+	}
+
+	swagger := parse(t, `{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1"},
+		"host": "api.example.com",
+		"basePath": "/api",
+		"paths": {"/pets/{petId}": {"get": {"operationId": "getPet", "responses": {"200": {"description": "OK"}}}}}
+	}`)
+	r, err = New(swagger, Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	m, err := r.Find(httptest.NewRequest("GET", "http://api.example.com/api/pets/1", nil))
+	if err != nil || m.PathParams["petId"] != "1" || m.Server.GetURL() != "https://api.example.com/api" {
+		t.Errorf("Unexpected 2.0 match %+v, %v", m, err)
+	}
+
+	// Without servers, a document is served from the root
+	bare := parse(t, `{"openapi": "3.0.3", "info": {"title": "T", "version": "1"}, "paths": {"/": {"get": {"responses": {}}}}}`)
+	r, err = New(bare, Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if m, err := r.Find(httptest.NewRequest("GET", "/", nil)); err != nil || m.Server != nil || m.Path != "/" {
+		t.Errorf("Unexpected match %+v, %v", m, err)
+	}
+
+	broken := parse(t, `{"openapi": "3.0.3", "info": {"title": "T", "version": "1"},
+		"servers": [{"url": "https://{host}/"}], "paths": {"/": {"get": {"responses": {}}}}}`)
+	if _, err := New(broken, Options{}); err == nil {
+		t.Error("Expected an error for an undefined server variable")
+	}
+}
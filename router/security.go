@@ -0,0 +1,253 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// ErrUnauthorized is returned when a request has no valid credentials for
+// any security requirement of its operation
+var ErrUnauthorized = errors.New("missing or invalid credentials")
+
+// ErrForbidden is for verifiers to wrap when valid credentials lack a
+// permission, such as a scope
+var ErrForbidden = errors.New("insufficient permissions")
+
+// Credential is a credential extracted from a request for a security scheme
+type Credential struct {
+	Scheme string // the name of the security scheme
+	// Type is the type of the scheme: apiKey, http, oauth2, openIdConnect or
+	// mutualTLS. The 2.0 basic type is http.
+	Type string
+	In   string // where the credential was: header, query or cookie
+	Name string // the header, query parameter or cookie
+
+	// Value is the API key, the token of bearer, OAuth2 and OpenID Connect,
+	// or the credentials of another HTTP authentication scheme
+	Value              string
+	Username, Password string   // HTTP basic
+	Scopes             []string // the scopes the requirement asks for
+}
+
+// VerifyFunc verifies a credential. It returns an error wrapping
+// ErrForbidden when the credential is valid without the permissions the
+// operation needs.
+type VerifyFunc func(r *http.Request, c *Credential) error
+
+// SecurityOptions configures how credentials are verified
+type SecurityOptions struct {
+	// Verifiers verify the credentials by scheme name; Verify those of the
+	// schemes without a verifier. A credential without verifier is refused.
+	Verifiers map[string]VerifyFunc
+	Verify    VerifyFunc
+
+	// OnError writes the response to a request that is refused, or whose
+	// operation is not found. By default the status is 404 for ErrNotFound,
+	// 405 for ErrMethodNotAllowed, 403 for ErrForbidden and 401 otherwise.
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// SecurityContext is the outcome of the security of a request
+type SecurityContext struct {
+	Match *Match
+
+	// Requirement is the security requirement the request satisfied: nil
+	// when the operation is not secured, empty for anonymous access
+	Requirement unified.EffectiveRequirement
+
+	// Credentials are the verified credentials by scheme name
+	Credentials map[string]*Credential
+}
+
+type securityKey struct{}
+
+// SecurityFromContext returns the security context Secure stored in the
+// context of a request, or nil
+func SecurityFromContext(ctx context.Context) *SecurityContext {
+	sc, _ := ctx.Value(securityKey{}).(*SecurityContext)
+	return sc
+}
+
+// Secure returns middleware that matches each request to its operation,
+// authenticates it and hands it, with its SecurityContext in the context, to
+// the next handler
+func (r *Router) Secure(opts SecurityOptions) func(http.Handler) http.Handler {
+	onError := opts.OnError
+	if onError == nil {
+		onError = writeError
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			m, err := r.Find(req)
+			if err != nil {
+				onError(w, req, err)
+				return
+			}
+			sc, err := r.Authenticate(req, m, opts)
+			if err != nil {
+				onError(w, req, err)
+				return
+			}
+			next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), securityKey{}, sc)))
+		})
+	}
+}
+
+// writeError responds with the status of an error
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var notAllowed *MethodNotAllowedError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.As(err, &notAllowed):
+		w.Header().Set("Allow", strings.Join(notAllowed.Allowed, ", "))
+		http.Error(w, err.Error(), http.StatusMethodNotAllowed)
+	case errors.Is(err, ErrForbidden):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	}
+}
+
+// Authenticate extracts the credentials of a request for the effective
+// security requirements of its operation and verifies them. Requirements
+// with schemes are tried in order before an empty one, which allows
+// anonymous access. The error of the last requirement tried is returned
+// when none is satisfied.
+func (r *Router) Authenticate(req *http.Request, m *Match, opts SecurityOptions) (*SecurityContext, error) {
+	sc := &SecurityContext{Match: m, Credentials: make(map[string]*Credential)}
+	requirements := m.Operation.EffectiveSecurity(r.doc)
+	if len(requirements) == 0 {
+		return sc, nil
+	}
+	err := fmt.Errorf("%w for %s %s", ErrUnauthorized, m.Method, m.Path)
+	anonymous := false
+	for _, requirement := range requirements {
+		if len(requirement) == 0 {
+			anonymous = true
+			continue
+		}
+		credentials, e := authenticate(req, requirement, opts)
+		if e != nil {
+			err = e
+			continue
+		}
+		sc.Requirement, sc.Credentials = requirement, credentials
+		return sc, nil
+	}
+	if anonymous {
+		sc.Requirement = unified.EffectiveRequirement{}
+		return sc, nil
+	}
+	return nil, err
+}
+
+// authenticate extracts and verifies the credentials of a requirement
+func authenticate(req *http.Request, requirement unified.EffectiveRequirement, opts SecurityOptions) (map[string]*Credential, error) {
+	credentials := make(map[string]*Credential, len(requirement))
+	for _, s := range requirement {
+		c := Extract(req, s)
+		if c == nil {
+			return nil, fmt.Errorf("%w: no credential for %s", ErrUnauthorized, s.Name)
+		}
+		verify := opts.Verifiers[s.Name]
+		if verify == nil {
+			verify = opts.Verify
+		}
+		if verify == nil {
+			return nil, fmt.Errorf("%w: no verifier for %s", ErrUnauthorized, s.Name)
+		}
+		if err := verify(req, c); err != nil {
+			if errors.Is(err, ErrForbidden) || errors.Is(err, ErrUnauthorized) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %s: %w", ErrUnauthorized, s.Name, err)
+		}
+		credentials[s.Name] = c
+	}
+	return credentials, nil
+}
+
+// Extract returns the credential of a request for a security scheme, nil
+// when the request has none or the document does not define the scheme
+func Extract(req *http.Request, s unified.EffectiveScheme) *Credential {
+	if s.Scheme == nil {
+		return nil
+	}
+	c := &Credential{Scheme: s.Name, Type: s.Scheme.GetType(), Scopes: s.Scopes}
+	switch c.Type {
+	case "apiKey":
+		c.In, c.Name = s.Scheme.GetIn(), s.Scheme.GetName()
+		switch c.In {
+		case "header":
+			if _, ok := req.Header[http.CanonicalHeaderKey(c.Name)]; !ok {
+				return nil
+			}
+			c.Value = req.Header.Get(c.Name)
+		case "query":
+			if !req.URL.Query().Has(c.Name) {
+				return nil
+			}
+			c.Value = req.URL.Query().Get(c.Name)
+		case "cookie":
+			cookie, err := req.Cookie(c.Name)
+			if err != nil {
+				return nil
+			}
+			c.Value = cookie.Value
+		default:
+			return nil
+		}
+		return c
+	case "http":
+		c.In, c.Name = "header", "Authorization"
+		if strings.EqualFold(s.Scheme.GetScheme(), "basic") || s.Scheme.GetScheme() == "" {
+			username, password, ok := req.BasicAuth()
+			if !ok {
+				return nil
+			}
+			c.Username, c.Password = username, password
+			return c
+		}
+		value, ok := authorization(req, s.Scheme.GetScheme())
+		if !ok {
+			return nil
+		}
+		c.Value = value
+		return c
+	case "oauth2", "openIdConnect":
+		c.In, c.Name = "header", "Authorization"
+		value, ok := authorization(req, "bearer")
+		if !ok {
+			return nil
+		}
+		c.Value = value
+		return c
+	case "mutualTLS":
+		if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+			return nil
+		}
+		return c
+	}
+	return nil
+}
+
+// authorization returns the credentials of the Authorization header for an
+// authentication scheme, compared without case
+func authorization(req *http.Request, scheme string) (string, bool) {
+	name, value, ok := strings.Cut(req.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(name, scheme) {
+		return "", false
+	}
+	value = strings.TrimSpace(value)
+	return value, value != ""
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package router
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+)
+
+const secured = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Pets", "version": "1"},
+	"security": [{"bearer": []}],
+	"components": {"securitySchemes": {
+		"bearer": {"type": "http", "scheme": "bearer"},
+		"basic": {"type": "http", "scheme": "basic"},
+		"key": {"type": "apiKey", "in": "header", "name": "X-API-Key"},
+		"session": {"type": "apiKey", "in": "cookie", "name": "session"},
+		"oauth": {"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://example.com/token", "scopes": {"write": "Write"}}}}
+	}},
+	"paths": {
+		"/pets": {
+			"get": {"security": [{}, {"session": []}], "responses": {}},
+			"post": {"security": [{"key": [], "oauth": ["write"]}, {"basic": []}], "responses": {}}
+		},
+		"/me": {"get": {"responses": {}}},
+		"/health": {"get": {"security": [], "responses": {}}}
+	}
+}`
+
+func TestSecure(t *testing.T) {
+	r, err := New(parse(t, secured), Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	opts := SecurityOptions{
+		Verifiers: map[string]VerifyFunc{
+			"oauth": func(_ *http.Request, c *Credential) error {
+				if c.Value != "token" {
+					return errors.New("unknown token")
+				}
+				if !slices.Equal(c.Scopes, []string{"write"}) {
+					return fmt.Errorf("%w: scopes %v", ErrForbidden, c.Scopes)
+				}
+				return nil
+			},
+			"basic": func(_ *http.Request, c *Credential) error {
+				if c.Username != "admin" || c.Password != "secret" {
+					return ErrForbidden
+				}
+				return nil
+			},
+		},
+		Verify: func(_ *http.Request, c *Credential) error { return nil },
+	}
+	var got *SecurityContext
+	handler := r.Secure(opts)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = SecurityFromContext(req.Context())
+	}))
+
+	tests := []struct {
+		name, method, path string
+		header             map[string]string
+		status             int
+		schemes            []string
+	}{
+		{"anonymous", "GET", "/pets", nil, 200, nil},
+		{"cookie", "GET", "/pets", map[string]string{"Cookie": "session=abc"}, 200, []string{"session"}},
+		{"document security", "GET", "/me", map[string]string{"Authorization": "bearer xyz"}, 200, []string{"bearer"}},
+		{"missing bearer", "GET", "/me", nil, 401, nil},
+		{"security removed", "GET", "/health", nil, 200, nil},
+		{"key and oauth", "POST", "/pets", map[string]string{"X-API-Key": "k", "Authorization": "Bearer token"}, 200, []string{"key", "oauth"}},
+		{"bad token", "POST", "/pets", map[string]string{"X-API-Key": "k", "Authorization": "Bearer other"}, 401, nil},
+		{"basic fallback", "POST", "/pets", map[string]string{"Authorization": "Basic YWRtaW46c2VjcmV0"}, 200, []string{"basic"}},
+		{"basic forbidden", "POST", "/pets", map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}, 403, nil},
+		{"not found", "GET", "/toys", nil, 404, nil},
+		{"not allowed", "DELETE", "/pets", nil, 405, nil},
+	}
+	for _, tt := range tests {
+		got = nil
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		for k, v := range tt.header {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d: %s", tt.name, w.Code, tt.status, w.Body.String())
+			continue
+		}
+		if tt.status != 200 {
+			continue
+		}
+		var schemes []string
+		for _, s := range got.Requirement {
+			schemes = append(schemes, s.Name)
+			if got.Credentials[s.Name] == nil {
+				t.Errorf("%s: no credential for %s", tt.name, s.Name)
+			}
+		}
+		if !slices.Equal(schemes, tt.schemes) {
+			t.Errorf("%s: satisfied %v, want %v", tt.name, schemes, tt.schemes)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("PUT", "/pets", nil))
+	if allow := w.Header().Get("Allow"); allow != "GET, POST" {
+		t.Errorf("Allow = %q, want GET, POST", allow)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	doc := parse(t, secured)
+	schemes := doc.GetSecuritySchemes()
+	req := httptest.NewRequest("GET", "/pets?key=1", nil)
+	req.Header.Set("X-API-Key", "abc")
+	req.Header.Set("Authorization", "Bearer  t0k ")
+
+	effective := func(name string) unified.EffectiveScheme {
+		return unified.EffectiveScheme{Name: name, Scheme: schemes[name]}
+	}
+	c := Extract(req, effective("key"))
+	if c == nil || c.Value != "abc" || c.In != "header" || c.Name != "X-API-Key" || c.Type != "apiKey" {
+		t.Errorf("Unexpected API key credential: %+v", c)
+	}
+	if c := Extract(req, effective("bearer")); c == nil || c.Value != "t0k" {
+		t.Errorf("Unexpected bearer credential: %+v", c)
+	}
+	if c := Extract(req, effective("basic")); c != nil {
+		t.Errorf("Expected no basic credential, got %+v", c)
+	}
+	if c := Extract(req, effective("session")); c != nil {
+		t.Errorf("Expected no cookie credential, got %+v", c)
+	}
+	if c := Extract(req, effective("undefined")); c != nil {
+		t.Errorf("Expected no credential for an undefined scheme, got %+v", c)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package snippet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// placeholders are the tokens standing for values a command leaves to its
+// reader. Tokens are letters and digits, so that they survive the escaping
+// of URLs and bodies, and are replaced by <name> in the command.
+type placeholders struct {
+	names []string
+}
+
+// add returns the token of a new placeholder
+func (p *placeholders) add(name string) string {
+	p.names = append(p.names, name)
+	return fmt.Sprintf("oasplaceholder%dx", len(p.names)-1)
+}
+
+// replace replaces the tokens of a command by their placeholders
+func (p *placeholders) replace(command string) string {
+	// The x closing each token keeps oasplaceholder1x from matching the
+	// start of oasplaceholder10x
+	for i := range p.names {
+		command = strings.ReplaceAll(command, fmt.Sprintf("oasplaceholder%dx", i), "<"+p.names[i]+">")
+	}
+	return command
+}
+
+// command is a request to render as a command line
+type command struct {
+	format      Format
+	method, url string
+	headers     [][2]string
+	body        string
+	fields      []field
+}
+
+// render writes the command on a line, or on a line per option
+func (c *command) render(multiline bool) string {
+	var head string
+	var args []string
+	switch c.format {
+	case HTTPie:
+		head, args = c.httpie()
+	default:
+		head, args = c.curl()
+	}
+	if !multiline {
+		return strings.Join(append([]string{head}, args...), " ")
+	}
+	// Options and their values stay together
+	lines := []string{head}
+	for i := 0; i < len(args); i++ {
+		line := args[i]
+		if strings.HasPrefix(line, "-") && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			i++
+			line += " " + args[i]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+// curl returns the program and method of a curl command, and its other
+// arguments
+func (c *command) curl() (string, []string) {
+	head := "curl"
+	if c.method != "GET" {
+		head += " -X " + c.method
+	}
+	args := []string{quote(c.url)}
+	for _, h := range c.headers {
+		args = append(args, "-H", quote(h[0]+": "+h[1]))
+	}
+	for _, f := range c.fields {
+		// -F reads a leading @ or < and a ;type= or ;filename= as
+		// directives, so only files and typed parts use it
+		switch {
+		case f.file:
+			args = append(args, "-F", quote(f.name+"=@"+f.value))
+		case f.contentType != "":
+			args = append(args, "-F", quote(f.name+"="+f.value+";type="+f.contentType))
+		default:
+			args = append(args, "--form-string", quote(f.name+"="+f.value))
+		}
+	}
+	if c.body != "" {
+		args = append(args, "-d", quote(c.body))
+	}
+	return head, args
+}
+
+// httpie returns the program and method of an HTTPie command, and its other
+// arguments
+func (c *command) httpie() (string, []string) {
+	head := "http"
+	if c.fields != nil {
+		head += " --multipart"
+	}
+	head += " " + c.method
+	args := []string{quote(c.url)}
+	for _, h := range c.headers {
+		args = append(args, quote(h[0]+":"+h[1]))
+	}
+	for _, f := range c.fields {
+		separator := "="
+		if f.file {
+			separator = "@"
+		}
+		args = append(args, quote(f.name+separator+f.value))
+	}
+	if c.body != "" {
+		args = append(args, "--raw", quote(c.body))
+	}
+	return head, args
+}
+
+// quote quotes an argument for POSIX shells
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,344 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package snippet generates ready-to-run curl and HTTPie commands for the
+// operations of an OpenAPI document, with example or placeholder values, for
+// embedding in documentation.
+package snippet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/genelet/oas/client"
+	"github.com/genelet/oas/fake"
+	"github.com/genelet/oas/form"
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+	"github.com/genelet/oas/validate"
+)
+
+// Format is the command line tool of the snippets
+type Format int
+
+const (
+	Curl Format = iota
+	HTTPie
+)
+
+// Options configures a Generator
+type Options struct {
+	Format Format
+	// BaseURL replaces the servers of the document; the first server of an
+	// operation is used otherwise, with the defaults of its variables
+	BaseURL string
+	// Header is added to every command, such as a User-Agent
+	Header http.Header
+	// Optional adds the optional parameters that have an example
+	Optional bool
+	// Multiline breaks commands into a line per option, with backslashes
+	Multiline bool
+}
+
+// Snippet is the command of an operation
+type Snippet struct {
+	Operation string `json:"operation"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+	Command   string `json:"command"`
+}
+
+// Generator generates the commands of the operations of a document
+type Generator struct {
+	doc    unified.Document
+	client *client.Client
+	ops    map[string]*router.Match
+	opts   Options
+}
+
+// baseToken is the base URL requests are built with, replaced by the server
+// of each operation in commands
+const baseToken = "http://oas-base.invalid"
+
+// New returns a generator for a document
+func New(doc unified.Document, opts Options) (*Generator, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to generate snippets for")
+	}
+	resolved, err := unified.Resolve(doc)
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(resolved, client.Options{BaseURL: baseToken, SkipValidation: true})
+	if err != nil {
+		return nil, err
+	}
+	g := &Generator{doc: resolved, client: c, ops: make(map[string]*router.Match), opts: opts}
+	for path, item := range resolved.GetPaths() {
+		if item == nil {
+			continue
+		}
+		for method, op := range item.GetAllOperations() {
+			key := op.GetOperationID()
+			if key == "" {
+				key = strings.ToUpper(method) + " " + path
+			}
+			g.ops[key] = &router.Match{Path: path, Method: strings.ToUpper(method), PathItem: item, Operation: op}
+		}
+	}
+	return g, nil
+}
+
+// All returns the commands of every operation, sorted by operationId, or by
+// method and path as "GET /pets" for operations without one
+func (g *Generator) All() ([]Snippet, error) {
+	var snippets []Snippet
+	for _, key := range slices.Sorted(maps.Keys(g.ops)) {
+		command, err := g.Command(key)
+		if err != nil {
+			return nil, err
+		}
+		m := g.ops[key]
+		snippets = append(snippets, Snippet{Operation: key, Method: m.Method, Path: m.Path, Command: command})
+	}
+	return snippets, nil
+}
+
+// Command returns the command of an operation. Required parameters take
+// their example, or the example, default or first enum value of their
+// schema, and are written as placeholders such as <petId> otherwise. The
+// body is the example of its media type, JSON preferred, or is generated
+// from its schema. The credentials of the first security requirement are
+// placeholders named after their scheme.
+func (g *Generator) Command(operationID string) (string, error) {
+	m, ok := g.ops[operationID]
+	if !ok {
+		return "", fmt.Errorf("%w %q", client.ErrUnknownOperation, operationID)
+	}
+	ph := &placeholders{}
+	params := make(map[string]any)
+	for _, p := range parameters(m) {
+		value := exampleOf(p)
+		switch {
+		case value != nil && (p.GetRequired() || g.opts.Optional):
+			params[p.GetName()] = value
+		case p.GetRequired():
+			params[p.GetName()] = ph.add(p.GetName())
+		}
+	}
+	mediaType, mt, body := g.body(m.Operation.GetRequestBody())
+
+	req, err := g.client.Request(context.Background(), operationID, params, body)
+	if err != nil {
+		return "", err
+	}
+	target := strings.Replace(req.URL.String(), baseToken, g.base(m), 1)
+	var query []string
+	for _, k := range slices.Sorted(maps.Keys(g.opts.Header)) {
+		req.Header[k] = g.opts.Header[k]
+	}
+	for _, scheme := range security(g.doc, m.Operation) {
+		token := ph.add(scheme.Name)
+		switch t := scheme.Scheme.GetType(); {
+		case t == "apiKey" && scheme.Scheme.GetIn() == "query":
+			query = append(query, scheme.Scheme.GetName()+"="+token)
+		case t == "apiKey" && scheme.Scheme.GetIn() == "cookie":
+			req.AddCookie(&http.Cookie{Name: scheme.Scheme.GetName(), Value: token})
+		case t == "apiKey":
+			req.Header.Set(scheme.Scheme.GetName(), token)
+		case t == "basic" || t == "http" && strings.EqualFold(scheme.Scheme.GetScheme(), "basic"):
+			req.Header.Set("Authorization", "Basic "+token)
+		case t == "http" && !strings.EqualFold(scheme.Scheme.GetScheme(), "bearer"):
+			req.Header.Set("Authorization", scheme.Scheme.GetScheme()+" "+token)
+		case t != "mutualTLS":
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+	if len(query) > 0 {
+		separator := "?"
+		if strings.Contains(target, "?") {
+			separator = "&"
+		}
+		target += separator + strings.Join(query, "&")
+	}
+
+	c := &command{format: g.opts.Format, method: req.Method, url: target}
+	multipart := strings.HasPrefix(mediaType, form.Multipart)
+	for _, k := range slices.Sorted(maps.Keys(req.Header)) {
+		if multipart && k == "Content-Type" {
+			continue
+		}
+		for _, v := range req.Header[k] {
+			c.headers = append(c.headers, [2]string{k, v})
+		}
+	}
+	switch {
+	case multipart:
+		c.fields = fields(mt, body)
+	case req.Body != nil:
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return "", err
+		}
+		c.body = string(data)
+	}
+	return ph.replace(c.render(g.opts.Multiline)), nil
+}
+
+// parameters returns the parameters of an operation, with the formData
+// parameters of 2.0
+func parameters(m *router.Match) []unified.Parameter {
+	params := validate.Parameters(m)
+	for _, p := range append(slices.Clone(m.PathItem.GetParameters()), m.Operation.GetParameters()...) {
+		if p != nil && p.GetIn() == "formData" {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// exampleOf returns the example of a parameter, or of its schema
+func exampleOf(p unified.Parameter) any {
+	if ex := p.GetExample(); ex != nil {
+		return ex
+	}
+	examples := p.GetExamples()
+	for _, key := range slices.Sorted(maps.Keys(examples)) {
+		if ex := examples[key]; ex != nil && ex.GetValue() != nil {
+			return ex.GetValue()
+		}
+	}
+	s := p.GetSchema()
+	if s == nil || s.IsNil() {
+		return nil
+	}
+	if ex := s.GetExample(); ex != nil {
+		return ex
+	}
+	if d := s.GetDefault(); d != nil {
+		return d
+	}
+	if enum := s.GetEnum(); len(enum) > 0 {
+		return enum[0]
+	}
+	return nil
+}
+
+// body returns the media type, JSON preferred, and the body of a request
+func (g *Generator) body(rb unified.RequestBody) (string, unified.MediaType, any) {
+	if rb == nil || rb.IsNil() {
+		return "", nil, nil
+	}
+	content := rb.GetContent()
+	types := slices.Sorted(maps.Keys(content))
+	if len(types) == 0 {
+		return "", nil, nil
+	}
+	mediaType := types[0]
+	if i := slices.IndexFunc(types, validate.IsJSON); i >= 0 {
+		mediaType = types[i]
+	}
+	mt := content[mediaType]
+	if mt == nil {
+		return mediaType, nil, nil
+	}
+	if ex := mt.GetExample(); ex != nil {
+		return mediaType, mt, ex
+	}
+	for _, key := range slices.Sorted(maps.Keys(mt.GetExamples())) {
+		if ex := mt.GetExamples()[key]; ex != nil && ex.GetValue() != nil {
+			return mediaType, mt, ex.GetValue()
+		}
+	}
+	if s := mt.GetSchema(); s != nil && !s.IsNil() {
+		value, _ := fake.New(fake.Options{Examples: true, Direction: validate.DirectionRequest}).Generate(s)
+		return mediaType, mt, value
+	}
+	return mediaType, mt, nil
+}
+
+// security returns the schemes of the first security requirement of an
+// operation that is not anonymous
+func security(doc unified.Document, op unified.Operation) []unified.EffectiveScheme {
+	for _, requirement := range op.EffectiveSecurity(doc) {
+		if len(requirement) > 0 {
+			return requirement
+		}
+	}
+	return nil
+}
+
+// base returns the base URL of an operation: Options.BaseURL, or its first
+// server with the defaults of its variables, made absolute on localhost
+func (g *Generator) base(m *router.Match) string {
+	if g.opts.BaseURL != "" {
+		return strings.TrimSuffix(g.opts.BaseURL, "/")
+	}
+	servers := unified.EffectiveServers(g.doc, m.PathItem, m.Operation)
+	if len(servers) == 0 || servers[0] == nil {
+		return "http://localhost"
+	}
+	raw := servers[0].GetURL()
+	for name, v := range servers[0].GetVariables() {
+		if v != nil {
+			raw = strings.ReplaceAll(raw, "{"+name+"}", v.GetDefault())
+		}
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "http://localhost" + "/" + strings.TrimPrefix(raw, "/")
+	}
+	return strings.TrimSuffix(raw, "/")
+}
+
+// field is a field of a multipart body; a file when its value is a path
+type field struct {
+	name, value, contentType string
+	file                     bool
+}
+
+// fields returns the fields of a multipart body: binary properties are
+// files, and objects JSON
+func fields(mt unified.MediaType, body any) []field {
+	object, ok := body.(map[string]any)
+	if !ok {
+		return nil
+	}
+	var schema unified.Schema = unified.NilSchema{}
+	if s := mt.GetSchema(); s != nil {
+		schema = s
+	}
+	var found []field
+	for _, name := range slices.Sorted(maps.Keys(object)) {
+		prop := schema.GetProperties()[name]
+		if prop != nil && prop.GetFormat() == "binary" {
+			found = append(found, field{name: name, value: "<" + name + ">", file: true})
+			continue
+		}
+		values := []any{object[name]}
+		if array, ok := object[name].([]any); ok {
+			values = array
+		}
+		for _, v := range values {
+			f := field{name: name}
+			switch v.(type) {
+			case map[string]any, []any:
+				data, _ := json.Marshal(v)
+				f.value, f.contentType = string(data), "application/json"
+			case string:
+				f.value = v.(string)
+			default:
+				data, _ := json.Marshal(v)
+				f.value = string(data)
+			}
+			found = append(found, f)
+		}
+	}
+	return found
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package snippet
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/genelet/oas/unified"
+)
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	return doc
+}
+
+const pets = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Pets", "version": "1"},
+	"servers": [{"url": "https://{region}.example.com/v1", "variables": {"region": {"default": "eu"}}}],
+	"security": [{}, {"bearer": []}],
+	"paths": {
+		"/pets": {
+			"get": {
+				"operationId": "listPets",
+				"security": [],
+				"parameters": [
+					{"name": "limit", "in": "query", "schema": {"type": "integer", "example": 10}},
+					{"name": "kind", "in": "query", "required": true, "schema": {"type": "string", "enum": ["cat", "dog"]}}
+				],
+				"responses": {"200": {"description": "OK"}}
+			},
+			"post": {
+				"operationId": "createPet",
+				"requestBody": {"content": {"application/json": {"example": {"name": "O'Malley"}}}},
+				"responses": {"201": {"description": "Created"}}
+			}
+		},
+		"/pets/{petId}/photo": {
+			"put": {
+				"operationId": "uploadPhoto",
+				"security": [{"apiKey": []}],
+				"parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "integer"}}],
+				"requestBody": {"content": {"multipart/form-data": {"schema": {"type": "object", "properties": {
+					"photo": {"type": "string", "format": "binary"},
+					"caption": {"type": "string", "example": "Rex"}
+				}}}}},
+				"responses": {"204": {"description": "Uploaded"}}
+			}
+		}
+	},
+	"components": {"securitySchemes": {
+		"bearer": {"type": "http", "scheme": "bearer"},
+		"apiKey": {"type": "apiKey", "in": "header", "name": "X-API-Key"}
+	}}
+}`
+
+func TestCommand(t *testing.T) {
+	doc := parse(t, pets)
+	tests := []struct {
+		name, operation string
+		opts            Options
+		want            string
+	}{
+		{"enum", "listPets", Options{}, `curl 'https://eu.example.com/v1/pets?kind=cat'`},
+		{"optional", "listPets", Options{Optional: true, BaseURL: "http://localhost:8080/"},
+			`curl 'http://localhost:8080/pets?limit=10&kind=cat'`},
+		{"body", "createPet", Options{}, `curl -X POST 'https://eu.example.com/v1/pets' -H 'Authorization: Bearer <bearer>' ` +
+			`-H 'Content-Type: application/json' -d '{"name":"O'\''Malley"}'`},
+		{"multiline", "createPet", Options{Multiline: true, Header: http.Header{"User-Agent": {"docs"}}}, `curl -X POST \
+  'https://eu.example.com/v1/pets' \
+  -H 'Authorization: Bearer <bearer>' \
+  -H 'Content-Type: application/json' \
+  -H 'User-Agent: docs' \
+  -d '{"name":"O'\''Malley"}'`},
+		{"multipart", "uploadPhoto", Options{}, `curl -X PUT 'https://eu.example.com/v1/pets/<petId>/photo' ` +
+			`-H 'X-Api-Key: <apiKey>' --form-string 'caption=Rex' -F 'photo=@<photo>'`},
+		{"httpie", "createPet", Options{Format: HTTPie}, `http POST 'https://eu.example.com/v1/pets' ` +
+			`'Authorization:Bearer <bearer>' 'Content-Type:application/json' --raw '{"name":"O'\''Malley"}'`},
+		{"httpie multipart", "uploadPhoto", Options{Format: HTTPie}, `http --multipart PUT 'https://eu.example.com/v1/pets/<petId>/photo' ` +
+			`'X-Api-Key:<apiKey>' 'caption=Rex' 'photo@<photo>'`},
+	}
+	for _, tt := range tests {
+		g, err := New(doc, tt.opts)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		got, err := g.Command(tt.operation)
+		if err != nil {
+			t.Errorf("%s: Command() error = %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: Command() =\n%s\nwant:\n%s", tt.name, got, tt.want)
+		}
+	}
+
+	g, _ := New(doc, Options{})
+	snippets, err := g.All()
+	if err != nil || len(snippets) != 3 || snippets[0].Operation != "createPet" || snippets[2].Path != "/pets/{petId}/photo" {
+		t.Errorf("All() = %+v, %v", snippets, err)
+	}
+	if _, err := g.Command("deletePet"); err == nil {
+		t.Errorf("Expected an error for an unknown operation")
+	}
+}
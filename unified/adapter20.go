@@ -6,12 +6,15 @@ package unified
 import (
 	"strings"
 
+	"github.com/genelet/oas/convert"
 	oa2 "github.com/genelet/oas/openapi20"
 )
 
 // Document20 wraps an OpenAPI 2.0 document and implements Document
 type Document20 struct {
 	doc *oa2.Swagger
+	r   *resolver20
+	ops operationIndex
 }
 
 // NewDocument20 creates a new Document adapter for OpenAPI 2.0
@@ -42,6 +45,16 @@ func (d *Document20) GetServerURL() string {
 	return scheme + "://" + host + basePath
 }
 
+// GetServers returns the server of GetServerURL, the first scheme with the
+// host and basePath, or nil when the document sets neither
+func (d *Document20) GetServers() []Server {
+	url := d.GetServerURL()
+	if url == "" {
+		return nil
+	}
+	return []Server{BaseServer{URL: url}}
+}
+
 func (d *Document20) GetInfo() DocumentInfo {
 	if d.doc.Info == nil {
 		return &documentInfo20{}
@@ -56,12 +69,45 @@ func (d *Document20) GetPaths() map[string]PathItem {
 	result := make(map[string]PathItem)
 	for path, item := range d.doc.Paths.Paths {
 		if item != nil {
-			result[path] = &pathItem20{item: item, doc: d.doc}
+			result[path] = &pathItem20{item: item, doc: d.doc, r: d.r}
 		}
 	}
 	return result
 }
 
+func (d *Document20) GetOperationByID(id string) (string, string, Operation) {
+	return d.ops.lookup(d, id)
+}
+
+// pathItem returns the path item of a path, or nil
+func (d *Document20) pathItem(path string) PathItem {
+	item := d.doc.Paths.Get(path)
+	if item == nil {
+		return nil
+	}
+	return &pathItem20{item: item, doc: d.doc, r: d.r}
+}
+
+func (d *Document20) pathsStamp() pathsStamp {
+	if d.doc.Paths == nil {
+		return pathsStamp{}
+	}
+	return pathsStamp{paths: d.doc.Paths, count: len(d.doc.Paths.Paths)}
+}
+
+// GetWebhooks returns nil, Swagger 2.0 has no webhooks
+func (d *Document20) GetWebhooks() map[string]PathItem {
+	return nil
+}
+
+// GetComponents maps the definitions, parameters and responses of the document
+func (d *Document20) GetComponents() Components {
+	if d.doc.Definitions == nil && d.doc.Parameters == nil && d.doc.Responses == nil {
+		return NilComponents{}
+	}
+	return &components20{doc: d.doc, r: d.r}
+}
+
 func (d *Document20) GetSecuritySchemes() map[string]SecurityScheme {
 	if d.doc.SecurityDefinitions == nil {
 		return nil
@@ -86,6 +132,25 @@ func (d *Document20) GetGlobalSecurity() []SecurityRequirement {
 	return result
 }
 
+func (d *Document20) GetTags() []Tag {
+	var result []Tag
+	for _, tag := range d.doc.Tags {
+		if tag != nil {
+			result = append(result, BaseTag{
+				Name:         tag.Name,
+				Description:  tag.Description,
+				ExternalDocs: externalDocs20(tag.ExternalDocs),
+				Extensions:   tag.Extensions,
+			})
+		}
+	}
+	return result
+}
+
+func (d *Document20) GetExternalDocs() ExternalDocumentation {
+	return externalDocs20(d.doc.ExternalDocs)
+}
+
 func (d *Document20) GetExtensions() map[string]any {
 	return d.doc.Extensions
 }
@@ -116,6 +181,29 @@ func (i *documentInfo20) GetDescription() string {
 	return i.info.Description
 }
 
+func (i *documentInfo20) GetTermsOfService() string {
+	if i.info == nil {
+		return ""
+	}
+	return i.info.TermsOfService
+}
+
+func (i *documentInfo20) GetContact() Contact {
+	if i.info == nil || i.info.Contact == nil {
+		return nil
+	}
+	c := i.info.Contact
+	return BaseContact{Name: c.Name, URL: c.URL, Email: c.Email, Extensions: c.Extensions}
+}
+
+func (i *documentInfo20) GetLicense() License {
+	if i.info == nil || i.info.License == nil {
+		return nil
+	}
+	l := i.info.License
+	return BaseLicense{Name: l.Name, URL: l.URL, Extensions: l.Extensions}
+}
+
 func (i *documentInfo20) GetExtensions() map[string]any {
 	if i.info == nil {
 		return nil
@@ -127,6 +215,7 @@ func (i *documentInfo20) GetExtensions() map[string]any {
 type pathItem20 struct {
 	item *oa2.PathItem
 	doc  *oa2.Swagger
+	r    *resolver20
 }
 
 func (p *pathItem20) HasRef() bool {
@@ -141,31 +230,11 @@ func (p *pathItem20) GetRef() string {
 }
 
 func (p *pathItem20) GetOperation(method string) Operation {
-	if p.item == nil {
-		return NilOperation{}
-	}
-	method = strings.ToLower(method)
-	var op *oa2.Operation
-	switch method {
-	case "get":
-		op = p.item.Get
-	case "put":
-		op = p.item.Put
-	case "post":
-		op = p.item.Post
-	case "delete":
-		op = p.item.Delete
-	case "options":
-		op = p.item.Options
-	case "head":
-		op = p.item.Head
-	case "patch":
-		op = p.item.Patch
-	}
+	op := p.item.GetOperation(method)
 	if op == nil {
 		return NilOperation{}
 	}
-	return &operation20{op: op, doc: p.doc}
+	return &operation20{op: op, doc: p.doc, r: p.r}
 }
 
 func (p *pathItem20) GetAllOperations() map[string]Operation {
@@ -173,26 +242,8 @@ func (p *pathItem20) GetAllOperations() map[string]Operation {
 		return nil
 	}
 	result := make(map[string]Operation)
-	if p.item.Get != nil {
-		result["get"] = &operation20{op: p.item.Get, doc: p.doc}
-	}
-	if p.item.Put != nil {
-		result["put"] = &operation20{op: p.item.Put, doc: p.doc}
-	}
-	if p.item.Post != nil {
-		result["post"] = &operation20{op: p.item.Post, doc: p.doc}
-	}
-	if p.item.Delete != nil {
-		result["delete"] = &operation20{op: p.item.Delete, doc: p.doc}
-	}
-	if p.item.Options != nil {
-		result["options"] = &operation20{op: p.item.Options, doc: p.doc}
-	}
-	if p.item.Head != nil {
-		result["head"] = &operation20{op: p.item.Head, doc: p.doc}
-	}
-	if p.item.Patch != nil {
-		result["patch"] = &operation20{op: p.item.Patch, doc: p.doc}
+	for method, op := range p.item.Operations() {
+		result[method] = &operation20{op: op, doc: p.doc, r: p.r}
 	}
 	return result
 }
@@ -204,7 +255,7 @@ func (p *pathItem20) GetParameters() []Parameter {
 	result := make([]Parameter, 0, len(p.item.Parameters))
 	for _, param := range p.item.Parameters {
 		if param != nil {
-			result = append(result, &parameter20{param: param})
+			result = append(result, p.r.parameter(param))
 		}
 	}
 	return result
@@ -217,10 +268,15 @@ func (p *pathItem20) GetExtensions() map[string]any {
 	return p.item.Extensions
 }
 
+func (p *pathItem20) GetServers() []Server {
+	return nil // Not supported in Swagger 2.0
+}
+
 // operation20 wraps OpenAPI 2.0 Operation
 type operation20 struct {
 	op  *oa2.Operation
 	doc *oa2.Swagger
+	r   *resolver20
 }
 
 func (o *operation20) IsNil() bool {
@@ -255,7 +311,7 @@ func (o *operation20) GetParameters() []Parameter {
 	result := make([]Parameter, 0, len(o.op.Parameters))
 	for _, param := range o.op.Parameters {
 		if param != nil && !param.IsBodyParameter() {
-			result = append(result, &parameter20{param: param})
+			result = append(result, o.r.parameter(param))
 		}
 	}
 	return result
@@ -265,20 +321,47 @@ func (o *operation20) GetRequestBody() RequestBody {
 	if o.op == nil || o.op.Parameters == nil {
 		return NilRequestBody{}
 	}
-	// In Swagger 2.0, request body is a parameter with in=body
+	// In Swagger 2.0, request body is a parameter with in=body, or else the
+	// in=formData parameters together
+	var form []*oa2.Parameter
 	for _, param := range o.op.Parameters {
 		if param != nil && param.IsBodyParameter() {
-			return &requestBody20{param: param, doc: o.doc}
+			return &requestBody20{param: param, consumes: o.mediaTypes(o.op.Consumes, o.doc.Consumes), r: o.r}
 		}
+		if target := o.formParameter(param); target != nil {
+			form = append(form, target)
+		}
+	}
+	if form != nil {
+		return &requestBody20{form: form, consumes: o.mediaTypes(o.op.Consumes, o.doc.Consumes), r: o.r}
 	}
 	return NilRequestBody{}
 }
 
+// formParameter returns the formData parameter param is or refers to, or nil
+func (o *operation20) formParameter(param *oa2.Parameter) *oa2.Parameter {
+	if param != nil && param.IsReference() && o.doc != nil {
+		param = o.doc.Parameters[strings.TrimPrefix(param.Ref, "#/parameters/")]
+	}
+	if param == nil || param.In != "formData" {
+		return nil
+	}
+	return param
+}
+
 func (o *operation20) GetResponses() Responses {
 	if o.op == nil || o.op.Responses == nil {
 		return NilResponses{}
 	}
-	return &responses20{responses: o.op.Responses}
+	return &responses20{responses: o.op.Responses, produces: o.mediaTypes(o.op.Produces, o.doc.Produces), r: o.r}
+}
+
+// mediaTypes returns the consumes or produces in effect for the operation
+func (o *operation20) mediaTypes(operation, global []string) []string {
+	if o.doc == nil {
+		return convert.EffectiveMediaTypes(operation, nil)
+	}
+	return convert.EffectiveMediaTypes(operation, global)
 }
 
 func (o *operation20) GetSecurity() []SecurityRequirement {
@@ -323,9 +406,35 @@ func (o *operation20) GetDeprecated() bool {
 	return o.op.Deprecated
 }
 
+// GetCallbacks returns nil, OpenAPI 2.0 has no callbacks
+func (o *operation20) GetCallbacks() map[string]Callback {
+	return nil
+}
+
+func (o *operation20) GetServers() []Server {
+	return nil // Not supported in Swagger 2.0
+}
+
+func (o *operation20) EffectiveSecurity(doc Document) []EffectiveRequirement {
+	return effectiveSecurity(o, doc)
+}
+
 // parameter20 wraps OpenAPI 2.0 Parameter (non-body)
 type parameter20 struct {
 	param *oa2.Parameter
+	r     *resolver20
+	refSource
+}
+
+func (p *parameter20) HasRef() bool {
+	return p.param != nil && p.param.IsReference()
+}
+
+func (p *parameter20) GetRef() string {
+	if p.param == nil {
+		return ""
+	}
+	return p.param.Ref
 }
 
 func (p *parameter20) GetName() string {
@@ -360,6 +469,9 @@ func (p *parameter20) GetSchema() Schema {
 	if p.param == nil {
 		return NilSchema{}
 	}
+	if p.param.Schema != nil {
+		return p.r.schema(p.param.Schema)
+	}
 	// In Swagger 2.0, non-body parameters have type/format directly, not schema
 	// We create a synthetic schema from these fields
 	if p.param.Type != "" {
@@ -392,24 +504,14 @@ func (p *parameter20) GetAllowEmptyValue() bool {
 }
 
 func (p *parameter20) GetStyle() string {
-	// Swagger 2.0 collectionFormat -> style adaptation could be done, but keeping simple for now
-	if p.param == nil {
+	if p.param == nil || p.param.CollectionFormat == "" {
 		return ""
 	}
-	// Basic mapping
-	switch p.param.CollectionFormat {
-	case "csv":
-		return "form" // roughly equivalent
-	case "ssv":
-		return "spaceDelimited"
-	case "tsv":
-		return "tabDelimited"
-	case "pipes":
-		return "pipeDelimited"
-	case "multi":
-		return "form" // with explode=true
-	}
-	return ""
+	return style20(p.param.CollectionFormat, p.param.In)
+}
+
+func (p *parameter20) HasExplode() bool {
+	return p.param != nil
 }
 
 func (p *parameter20) GetExplode() bool {
@@ -417,36 +519,65 @@ func (p *parameter20) GetExplode() bool {
 		return false
 	}
 	// In Swagger 2.0, collectionFormat=multi implies explode=true
-	return p.param.CollectionFormat == "multi"
+	_, explode, _ := convert.CollectionFormatToStyle(p.param.CollectionFormat, p.param.In)
+	return explode
 }
 
 func (p *parameter20) GetAllowReserved() bool {
 	return false // Not supported in Swagger 2.0
 }
 
+func (p *parameter20) GetExample() any {
+	if p.param == nil {
+		return nil
+	}
+	// Swagger 2.0 parameters have no example; x-example is the usual stand-in
+	return p.param.Extensions["x-example"]
+}
+
+func (p *parameter20) GetExamples() map[string]Example {
+	return nil // Not supported in Swagger 2.0
+}
+
+func (p *parameter20) GetContent() map[string]MediaType {
+	return nil // Not supported in Swagger 2.0
+}
+
 // parameterSchema20 creates a schema from parameter fields
 type parameterSchema20 struct {
 	param *oa2.Parameter
 }
 
-func (s *parameterSchema20) IsNil() bool                      { return s.param == nil }
-func (s *parameterSchema20) GetRef() string                   { return "" }
-func (s *parameterSchema20) GetType() string                  { return s.param.Type }
-func (s *parameterSchema20) GetFormat() string                { return s.param.Format }
-func (s *parameterSchema20) GetDescription() string           { return s.param.Description }
-func (s *parameterSchema20) GetProperties() map[string]Schema { return nil }
+func (s *parameterSchema20) IsNil() bool                             { return s.param == nil }
+func (s *parameterSchema20) GetRef() string                          { return "" }
+func (s *parameterSchema20) GetType() string                         { return s.param.Type }
+func (s *parameterSchema20) GetTypes() []string                      { return singleType(s.param.Type) }
+func (s *parameterSchema20) IsNullable() bool                        { return xNullable(s.param.Extensions) }
+func (s *parameterSchema20) GetFormat() string                       { return s.param.Format }
+func (s *parameterSchema20) GetDescription() string                  { return s.param.Description }
+func (s *parameterSchema20) GetProperties() map[string]Schema        { return nil }
+func (s *parameterSchema20) GetAdditionalProperties() Schema         { return NilSchema{} }
+func (s *parameterSchema20) GetPatternProperties() map[string]Schema { return nil }
 func (s *parameterSchema20) GetItems() Schema {
 	if s.param.Items == nil {
 		return NilSchema{}
 	}
 	return &itemsSchema20{items: s.param.Items}
 }
-func (s *parameterSchema20) GetRequired() []string  { return nil }
-func (s *parameterSchema20) GetAllOf() []Schema     { return nil }
-func (s *parameterSchema20) GetOneOf() []Schema     { return nil }
-func (s *parameterSchema20) GetAnyOf() []Schema     { return nil }
-func (s *parameterSchema20) IsBooleanSchema() bool  { return false }
-func (s *parameterSchema20) GetBooleanValue() *bool { return nil }
+func (s *parameterSchema20) GetRequired() []string                     { return nil }
+func (s *parameterSchema20) GetAllOf() []Schema                        { return nil }
+func (s *parameterSchema20) GetOneOf() []Schema                        { return nil }
+func (s *parameterSchema20) GetAnyOf() []Schema                        { return nil }
+func (s *parameterSchema20) GetNot() Schema                            { return NilSchema{} }
+func (s *parameterSchema20) GetIf() Schema                             { return NilSchema{} }
+func (s *parameterSchema20) GetThen() Schema                           { return NilSchema{} }
+func (s *parameterSchema20) GetElse() Schema                           { return NilSchema{} }
+func (s *parameterSchema20) GetPrefixItems() []Schema                  { return nil }
+func (s *parameterSchema20) GetContains() Schema                       { return NilSchema{} }
+func (s *parameterSchema20) GetDependentSchemas() map[string]Schema    { return nil }
+func (s *parameterSchema20) GetDependentRequired() map[string][]string { return nil }
+func (s *parameterSchema20) IsBooleanSchema() bool                     { return false }
+func (s *parameterSchema20) GetBooleanValue() *bool                    { return nil }
 func (s *parameterSchema20) GetExtensions() map[string]any {
 	if s.param == nil {
 		return nil
@@ -463,33 +594,59 @@ func (s *parameterSchema20) GetDefault() any {
 	}
 	return s.param.Default
 }
-func (s *parameterSchema20) GetDeprecated() bool { return false }
-func (s *parameterSchema20) GetReadOnly() bool   { return false }
-func (s *parameterSchema20) GetWriteOnly() bool  { return false }
+func (s *parameterSchema20) GetDeprecated() bool       { return false }
+func (s *parameterSchema20) GetReadOnly() bool         { return false }
+func (s *parameterSchema20) GetWriteOnly() bool        { return false }
+func (s *parameterSchema20) GetTitle() string          { return "" }
+func (s *parameterSchema20) GetEnum() []any            { return s.param.Enum }
+func (s *parameterSchema20) GetConst() any             { return nil }
+func (s *parameterSchema20) GetMinimum() *float64      { return s.param.Minimum }
+func (s *parameterSchema20) GetMaximum() *float64      { return s.param.Maximum }
+func (s *parameterSchema20) GetExclusiveMinimum() bool { return s.param.ExclusiveMinimum }
+func (s *parameterSchema20) GetExclusiveMaximum() bool { return s.param.ExclusiveMaximum }
+func (s *parameterSchema20) GetMinLength() *int        { return s.param.MinLength }
+func (s *parameterSchema20) GetMaxLength() *int        { return s.param.MaxLength }
+func (s *parameterSchema20) GetPattern() string        { return s.param.Pattern }
+func (s *parameterSchema20) GetMinItems() *int         { return s.param.MinItems }
+func (s *parameterSchema20) GetMaxItems() *int         { return s.param.MaxItems }
+func (s *parameterSchema20) GetMultipleOf() *float64   { return s.param.MultipleOf }
+func (s *parameterSchema20) GetUniqueItems() bool      { return s.param.UniqueItems }
 
 // itemsSchema20 wraps Items as a Schema
 type itemsSchema20 struct {
 	items *oa2.Items
 }
 
-func (s *itemsSchema20) IsNil() bool                      { return s.items == nil }
-func (s *itemsSchema20) GetRef() string                   { return "" }
-func (s *itemsSchema20) GetType() string                  { return s.items.Type }
-func (s *itemsSchema20) GetFormat() string                { return s.items.Format }
-func (s *itemsSchema20) GetDescription() string           { return "" }
-func (s *itemsSchema20) GetProperties() map[string]Schema { return nil }
+func (s *itemsSchema20) IsNil() bool                             { return s.items == nil }
+func (s *itemsSchema20) GetRef() string                          { return "" }
+func (s *itemsSchema20) GetType() string                         { return s.items.Type }
+func (s *itemsSchema20) GetTypes() []string                      { return singleType(s.items.Type) }
+func (s *itemsSchema20) IsNullable() bool                        { return false }
+func (s *itemsSchema20) GetFormat() string                       { return s.items.Format }
+func (s *itemsSchema20) GetDescription() string                  { return "" }
+func (s *itemsSchema20) GetProperties() map[string]Schema        { return nil }
+func (s *itemsSchema20) GetAdditionalProperties() Schema         { return NilSchema{} }
+func (s *itemsSchema20) GetPatternProperties() map[string]Schema { return nil }
 func (s *itemsSchema20) GetItems() Schema {
 	if s.items.Items == nil {
 		return NilSchema{}
 	}
 	return &itemsSchema20{items: s.items.Items}
 }
-func (s *itemsSchema20) GetRequired() []string  { return nil }
-func (s *itemsSchema20) GetAllOf() []Schema     { return nil }
-func (s *itemsSchema20) GetOneOf() []Schema     { return nil }
-func (s *itemsSchema20) GetAnyOf() []Schema     { return nil }
-func (s *itemsSchema20) IsBooleanSchema() bool  { return false }
-func (s *itemsSchema20) GetBooleanValue() *bool { return nil }
+func (s *itemsSchema20) GetRequired() []string                     { return nil }
+func (s *itemsSchema20) GetAllOf() []Schema                        { return nil }
+func (s *itemsSchema20) GetOneOf() []Schema                        { return nil }
+func (s *itemsSchema20) GetAnyOf() []Schema                        { return nil }
+func (s *itemsSchema20) GetNot() Schema                            { return NilSchema{} }
+func (s *itemsSchema20) GetIf() Schema                             { return NilSchema{} }
+func (s *itemsSchema20) GetThen() Schema                           { return NilSchema{} }
+func (s *itemsSchema20) GetElse() Schema                           { return NilSchema{} }
+func (s *itemsSchema20) GetPrefixItems() []Schema                  { return nil }
+func (s *itemsSchema20) GetContains() Schema                       { return NilSchema{} }
+func (s *itemsSchema20) GetDependentSchemas() map[string]Schema    { return nil }
+func (s *itemsSchema20) GetDependentRequired() map[string][]string { return nil }
+func (s *itemsSchema20) IsBooleanSchema() bool                     { return false }
+func (s *itemsSchema20) GetBooleanValue() *bool                    { return nil }
 func (s *itemsSchema20) GetExtensions() map[string]any {
 	// Items in Swagger 2.0 don't formally support extensions but some parsers might add them
 	return nil
@@ -504,21 +661,54 @@ func (s *itemsSchema20) GetDefault() any {
 	}
 	return s.items.Default
 }
-func (s *itemsSchema20) GetDeprecated() bool { return false }
-func (s *itemsSchema20) GetReadOnly() bool   { return false }
-func (s *itemsSchema20) GetWriteOnly() bool  { return false }
-
-// requestBody20 wraps a body parameter as RequestBody
+func (s *itemsSchema20) GetDeprecated() bool       { return false }
+func (s *itemsSchema20) GetReadOnly() bool         { return false }
+func (s *itemsSchema20) GetWriteOnly() bool        { return false }
+func (s *itemsSchema20) GetTitle() string          { return "" }
+func (s *itemsSchema20) GetEnum() []any            { return s.items.Enum }
+func (s *itemsSchema20) GetConst() any             { return nil }
+func (s *itemsSchema20) GetMinimum() *float64      { return s.items.Minimum }
+func (s *itemsSchema20) GetMaximum() *float64      { return s.items.Maximum }
+func (s *itemsSchema20) GetExclusiveMinimum() bool { return s.items.ExclusiveMinimum }
+func (s *itemsSchema20) GetExclusiveMaximum() bool { return s.items.ExclusiveMaximum }
+func (s *itemsSchema20) GetMinLength() *int        { return s.items.MinLength }
+func (s *itemsSchema20) GetMaxLength() *int        { return s.items.MaxLength }
+func (s *itemsSchema20) GetPattern() string        { return s.items.Pattern }
+func (s *itemsSchema20) GetMinItems() *int         { return s.items.MinItems }
+func (s *itemsSchema20) GetMaxItems() *int         { return s.items.MaxItems }
+func (s *itemsSchema20) GetMultipleOf() *float64   { return s.items.MultipleOf }
+func (s *itemsSchema20) GetUniqueItems() bool      { return s.items.UniqueItems }
+
+// requestBody20 wraps a body parameter, or the formData parameters of an
+// operation, as RequestBody
 type requestBody20 struct {
-	param *oa2.Parameter
-	doc   *oa2.Swagger
+	param    *oa2.Parameter
+	form     []*oa2.Parameter
+	consumes []string
+	r        *resolver20
+}
+
+func (r *requestBody20) HasRef() bool {
+	return r.param != nil && r.param.IsReference()
+}
+
+func (r *requestBody20) GetRef() string {
+	if r.param == nil {
+		return ""
+	}
+	return r.param.Ref
 }
 
 func (r *requestBody20) IsNil() bool {
-	return r.param == nil
+	return r.param == nil && r.form == nil
 }
 
 func (r *requestBody20) GetRequired() bool {
+	for _, param := range r.form {
+		if param.Required {
+			return true
+		}
+	}
 	if r.param == nil {
 		return false
 	}
@@ -526,22 +716,29 @@ func (r *requestBody20) GetRequired() bool {
 }
 
 func (r *requestBody20) GetContent() map[string]MediaType {
+	if r.form != nil {
+		schema := formSchema20(r.form)
+		result := make(map[string]MediaType)
+		for _, ct := range formMediaTypes20(r.consumes, r.form) {
+			result[ct] = &mediaType20{schema: schema, r: r.r}
+		}
+		return result
+	}
 	if r.param == nil || r.param.Schema == nil {
 		return nil
 	}
 	// In Swagger 2.0, content types come from consumes
-	// Default to application/json if not specified
-	consumes := []string{"application/json"}
-	if r.doc != nil && len(r.doc.Consumes) > 0 {
-		consumes = r.doc.Consumes
-	}
 	result := make(map[string]MediaType)
-	for _, ct := range consumes {
-		result[ct] = &mediaType20{schema: r.param.Schema}
+	for _, ct := range r.consumes {
+		result[ct] = &mediaType20{schema: r.param.Schema, r: r.r}
 	}
 	return result
 }
 
+func (r *requestBody20) GetMediaType(mediaType string) MediaType {
+	return mediaTypeOf(r.GetContent(), mediaType)
+}
+
 func (r *requestBody20) GetDescription() string {
 	if r.param == nil {
 		return ""
@@ -556,16 +753,110 @@ func (r *requestBody20) GetExtensions() map[string]any {
 	return r.param.Extensions
 }
 
+// formMediaTypes20 returns the form media types of consumes. When there are
+// none, formData parameters are sent as multipart/form-data if one of them is
+// a file, and as application/x-www-form-urlencoded otherwise.
+func formMediaTypes20(consumes []string, form []*oa2.Parameter) []string {
+	var mediaTypes []string
+	for _, ct := range consumes {
+		if ct == "multipart/form-data" || ct == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, ct)
+		}
+	}
+	if len(mediaTypes) > 0 {
+		return mediaTypes
+	}
+	for _, param := range form {
+		if param.Type == "file" {
+			return []string{"multipart/form-data"}
+		}
+	}
+	return []string{"application/x-www-form-urlencoded"}
+}
+
+// formSchema20 combines formData parameters into the object schema of the
+// body they describe
+func formSchema20(form []*oa2.Parameter) *oa2.Schema {
+	schema := &oa2.Schema{Type: "object", Properties: make(map[string]*oa2.Schema, len(form))}
+	for _, param := range form {
+		prop := itemsToSchema20(&oa2.Items{
+			Type:             param.Type,
+			Format:           param.Format,
+			Items:            param.Items,
+			Default:          param.Default,
+			Maximum:          param.Maximum,
+			ExclusiveMaximum: param.ExclusiveMaximum,
+			Minimum:          param.Minimum,
+			ExclusiveMinimum: param.ExclusiveMinimum,
+			MaxLength:        param.MaxLength,
+			MinLength:        param.MinLength,
+			Pattern:          param.Pattern,
+			MaxItems:         param.MaxItems,
+			MinItems:         param.MinItems,
+			UniqueItems:      param.UniqueItems,
+			Enum:             param.Enum,
+			MultipleOf:       param.MultipleOf,
+		})
+		prop.Description = param.Description
+		schema.Properties[param.Name] = prop
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+	}
+	return schema
+}
+
+// itemsToSchema20 converts the type fields of a non-body parameter to a schema
+func itemsToSchema20(items *oa2.Items) *oa2.Schema {
+	schema := &oa2.Schema{
+		Type:             items.Type,
+		Format:           items.Format,
+		Default:          items.Default,
+		Maximum:          items.Maximum,
+		ExclusiveMaximum: items.ExclusiveMaximum,
+		Minimum:          items.Minimum,
+		ExclusiveMinimum: items.ExclusiveMinimum,
+		MaxLength:        items.MaxLength,
+		MinLength:        items.MinLength,
+		Pattern:          items.Pattern,
+		MaxItems:         items.MaxItems,
+		MinItems:         items.MinItems,
+		UniqueItems:      items.UniqueItems,
+		Enum:             items.Enum,
+		MultipleOf:       items.MultipleOf,
+	}
+	if items.Items != nil {
+		schema.Items = itemsToSchema20(items.Items)
+	}
+	return schema
+}
+
 // mediaType20 wraps a schema as MediaType
 type mediaType20 struct {
-	schema *oa2.Schema
+	schema  *oa2.Schema
+	example any
+	r       *resolver20
 }
 
 func (m *mediaType20) GetSchema() Schema {
 	if m.schema == nil {
 		return NilSchema{}
 	}
-	return &schema20{schema: m.schema}
+	return m.r.schema(m.schema)
+}
+
+func (m *mediaType20) GetExample() any {
+	return m.example
+}
+
+// GetExamples returns nil, 2.0 has no named examples
+func (m *mediaType20) GetExamples() map[string]Example {
+	return nil
+}
+
+// GetEncoding returns nil, 2.0 has no encoding
+func (m *mediaType20) GetEncoding() map[string]Encoding {
+	return nil
 }
 
 func (m *mediaType20) GetExtensions() map[string]any {
@@ -576,13 +867,22 @@ func (m *mediaType20) GetExtensions() map[string]any {
 // responses20 wraps OpenAPI 2.0 Responses
 type responses20 struct {
 	responses *oa2.Responses
+	produces  []string
+	r         *resolver20
 }
 
 func (r *responses20) GetDefault() Response {
 	if r.responses == nil || r.responses.Default == nil {
 		return NilResponse{}
 	}
-	return &response20{resp: r.responses.Default}
+	return r.r.response(r.responses.Default, r.produces)
+}
+
+func (r *responses20) GetByStatus(status int) Response {
+	if resp := r.responses.GetByStatus(status); resp != nil {
+		return r.r.response(resp, r.produces)
+	}
+	return NilResponse{}
 }
 
 func (r *responses20) GetStatusCodes() map[string]Response {
@@ -592,7 +892,7 @@ func (r *responses20) GetStatusCodes() map[string]Response {
 	result := make(map[string]Response)
 	for code, resp := range r.responses.StatusCode {
 		if resp != nil {
-			result[code] = &response20{resp: resp}
+			result[code] = r.r.response(resp, r.produces)
 		}
 	}
 	return result
@@ -607,7 +907,10 @@ func (r *responses20) GetExtensions() map[string]any {
 
 // response20 wraps OpenAPI 2.0 Response
 type response20 struct {
-	resp *oa2.Response
+	resp     *oa2.Response
+	produces []string
+	refSource
+	r *resolver20
 }
 
 func (r *response20) IsNil() bool {
@@ -646,16 +949,26 @@ func (r *response20) GetHeaders() map[string]Header {
 }
 
 func (r *response20) GetContent() map[string]MediaType {
-	// In Swagger 2.0, response has schema directly, not content
-	// We still return nil since GetSchema handles this
-	return nil
+	// In Swagger 2.0, response has schema directly, content types come from produces
+	if r.resp == nil || r.resp.Schema == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for _, ct := range r.produces {
+		result[ct] = &mediaType20{schema: r.resp.Schema, example: r.resp.Examples[ct], r: r.r}
+	}
+	return result
+}
+
+func (r *response20) GetMediaType(mediaType string) MediaType {
+	return mediaTypeOf(r.GetContent(), mediaType)
 }
 
 func (r *response20) GetSchema() Schema {
 	if r.resp == nil || r.resp.Schema == nil {
 		return NilSchema{}
 	}
-	return &schema20{schema: r.resp.Schema}
+	return r.r.schema(r.resp.Schema)
 }
 
 func (r *response20) GetExtensions() map[string]any {
@@ -702,47 +1015,108 @@ func (h *header20) GetDeprecated() bool {
 	return false
 }
 
+func (h *header20) GetStyle() string {
+	if h.header == nil || h.header.CollectionFormat == "" {
+		return ""
+	}
+	return style20(h.header.CollectionFormat, "header")
+}
+
+// style20 maps a collectionFormat to its 3.x style. tsv has no 3.x
+// equivalent, so it keeps the tabDelimited name it has always been given.
+func style20(collectionFormat, in string) string {
+	if collectionFormat == "tsv" {
+		return "tabDelimited"
+	}
+	style, _, _ := convert.CollectionFormatToStyle(collectionFormat, in)
+	return style
+}
+
+func (h *header20) HasExplode() bool {
+	return h.header != nil
+}
+
+func (h *header20) GetExplode() bool {
+	if h.header == nil {
+		return false
+	}
+	_, explode, _ := convert.CollectionFormatToStyle(h.header.CollectionFormat, "header")
+	return explode
+}
+
+func (h *header20) GetContent() map[string]MediaType {
+	return nil // Not supported in Swagger 2.0
+}
+
 // headerSchema20 creates a schema from header fields
 type headerSchema20 struct {
 	header *oa2.Header
 }
 
-func (s *headerSchema20) IsNil() bool                      { return s.header == nil }
-func (s *headerSchema20) GetRef() string                   { return "" }
-func (s *headerSchema20) GetType() string                  { return s.header.Type }
-func (s *headerSchema20) GetFormat() string                { return s.header.Format }
-func (s *headerSchema20) GetDescription() string           { return s.header.Description }
-func (s *headerSchema20) GetProperties() map[string]Schema { return nil }
+func (s *headerSchema20) IsNil() bool                             { return s.header == nil }
+func (s *headerSchema20) GetRef() string                          { return "" }
+func (s *headerSchema20) GetType() string                         { return s.header.Type }
+func (s *headerSchema20) GetTypes() []string                      { return singleType(s.header.Type) }
+func (s *headerSchema20) IsNullable() bool                        { return xNullable(s.header.Extensions) }
+func (s *headerSchema20) GetFormat() string                       { return s.header.Format }
+func (s *headerSchema20) GetDescription() string                  { return s.header.Description }
+func (s *headerSchema20) GetProperties() map[string]Schema        { return nil }
+func (s *headerSchema20) GetAdditionalProperties() Schema         { return NilSchema{} }
+func (s *headerSchema20) GetPatternProperties() map[string]Schema { return nil }
 func (s *headerSchema20) GetItems() Schema {
 	if s.header.Items == nil {
 		return NilSchema{}
 	}
 	return &itemsSchema20{items: s.header.Items}
 }
-func (s *headerSchema20) GetRequired() []string                  { return nil }
-func (s *headerSchema20) GetAllOf() []Schema                     { return nil }
-func (s *headerSchema20) GetOneOf() []Schema                     { return nil }
-func (s *headerSchema20) GetAnyOf() []Schema                     { return nil }
-func (s *headerSchema20) IsBooleanSchema() bool                  { return false }
-func (s *headerSchema20) GetBooleanValue() *bool                 { return nil }
-func (s *headerSchema20) GetExtensions() map[string]any          { return nil }
-func (s *headerSchema20) GetDiscriminator() Discriminator        { return nil }
-func (s *headerSchema20) GetXML() XML                            { return nil }
-func (s *headerSchema20) GetExternalDocs() ExternalDocumentation { return nil }
-func (s *headerSchema20) GetExample() any                        { return nil }
+func (s *headerSchema20) GetRequired() []string                     { return nil }
+func (s *headerSchema20) GetAllOf() []Schema                        { return nil }
+func (s *headerSchema20) GetOneOf() []Schema                        { return nil }
+func (s *headerSchema20) GetAnyOf() []Schema                        { return nil }
+func (s *headerSchema20) GetNot() Schema                            { return NilSchema{} }
+func (s *headerSchema20) GetIf() Schema                             { return NilSchema{} }
+func (s *headerSchema20) GetThen() Schema                           { return NilSchema{} }
+func (s *headerSchema20) GetElse() Schema                           { return NilSchema{} }
+func (s *headerSchema20) GetPrefixItems() []Schema                  { return nil }
+func (s *headerSchema20) GetContains() Schema                       { return NilSchema{} }
+func (s *headerSchema20) GetDependentSchemas() map[string]Schema    { return nil }
+func (s *headerSchema20) GetDependentRequired() map[string][]string { return nil }
+func (s *headerSchema20) IsBooleanSchema() bool                     { return false }
+func (s *headerSchema20) GetBooleanValue() *bool                    { return nil }
+func (s *headerSchema20) GetExtensions() map[string]any             { return nil }
+func (s *headerSchema20) GetDiscriminator() Discriminator           { return nil }
+func (s *headerSchema20) GetXML() XML                               { return nil }
+func (s *headerSchema20) GetExternalDocs() ExternalDocumentation    { return nil }
+func (s *headerSchema20) GetExample() any                           { return nil }
 func (s *headerSchema20) GetDefault() any {
 	if s.header == nil {
 		return nil
 	}
 	return s.header.Default
 }
-func (s *headerSchema20) GetDeprecated() bool { return false }
-func (s *headerSchema20) GetReadOnly() bool   { return false }
-func (s *headerSchema20) GetWriteOnly() bool  { return false }
+func (s *headerSchema20) GetDeprecated() bool       { return false }
+func (s *headerSchema20) GetReadOnly() bool         { return false }
+func (s *headerSchema20) GetWriteOnly() bool        { return false }
+func (s *headerSchema20) GetTitle() string          { return "" }
+func (s *headerSchema20) GetEnum() []any            { return s.header.Enum }
+func (s *headerSchema20) GetConst() any             { return nil }
+func (s *headerSchema20) GetMinimum() *float64      { return s.header.Minimum }
+func (s *headerSchema20) GetMaximum() *float64      { return s.header.Maximum }
+func (s *headerSchema20) GetExclusiveMinimum() bool { return s.header.ExclusiveMinimum }
+func (s *headerSchema20) GetExclusiveMaximum() bool { return s.header.ExclusiveMaximum }
+func (s *headerSchema20) GetMinLength() *int        { return s.header.MinLength }
+func (s *headerSchema20) GetMaxLength() *int        { return s.header.MaxLength }
+func (s *headerSchema20) GetPattern() string        { return s.header.Pattern }
+func (s *headerSchema20) GetMinItems() *int         { return s.header.MinItems }
+func (s *headerSchema20) GetMaxItems() *int         { return s.header.MaxItems }
+func (s *headerSchema20) GetMultipleOf() *float64   { return s.header.MultipleOf }
+func (s *headerSchema20) GetUniqueItems() bool      { return s.header.UniqueItems }
 
 // schema20 wraps OpenAPI 2.0 Schema
 type schema20 struct {
 	schema *oa2.Schema
+	refSource
+	r *resolver20
 }
 
 func (s *schema20) IsNil() bool {
@@ -763,6 +1137,17 @@ func (s *schema20) GetType() string {
 	return s.schema.Type
 }
 
+func (s *schema20) GetTypes() []string {
+	return singleType(s.GetType())
+}
+
+func (s *schema20) IsNullable() bool {
+	if s.schema == nil {
+		return false
+	}
+	return xNullable(s.schema.Extensions)
+}
+
 func (s *schema20) GetFormat() string {
 	if s.schema == nil {
 		return ""
@@ -784,7 +1169,7 @@ func (s *schema20) GetProperties() map[string]Schema {
 	result := make(map[string]Schema)
 	for name, prop := range s.schema.Properties {
 		if prop != nil {
-			result[name] = &schema20{schema: prop}
+			result[name] = s.r.schema(prop)
 		}
 	}
 	return result
@@ -794,7 +1179,19 @@ func (s *schema20) GetItems() Schema {
 	if s.schema == nil || s.schema.Items == nil {
 		return NilSchema{}
 	}
-	return &schema20{schema: s.schema.Items}
+	return s.r.schema(s.schema.Items)
+}
+
+func (s *schema20) GetAdditionalProperties() Schema {
+	if s.schema == nil || s.schema.AdditionalProperties == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.AdditionalProperties)
+}
+
+func (s *schema20) GetPatternProperties() map[string]Schema {
+	// Not in Swagger 2.0
+	return nil
 }
 
 func (s *schema20) GetRequired() []string {
@@ -811,7 +1208,7 @@ func (s *schema20) GetAllOf() []Schema {
 	result := make([]Schema, 0, len(s.schema.AllOf))
 	for _, sub := range s.schema.AllOf {
 		if sub != nil {
-			result = append(result, &schema20{schema: sub})
+			result = append(result, s.r.schema(sub))
 		}
 	}
 	return result
@@ -827,6 +1224,17 @@ func (s *schema20) GetAnyOf() []Schema {
 	return nil
 }
 
+// GetNot returns NilSchema, the conditional and tuple keywords are not in
+// Swagger 2.0
+func (s *schema20) GetNot() Schema                            { return NilSchema{} }
+func (s *schema20) GetIf() Schema                             { return NilSchema{} }
+func (s *schema20) GetThen() Schema                           { return NilSchema{} }
+func (s *schema20) GetElse() Schema                           { return NilSchema{} }
+func (s *schema20) GetPrefixItems() []Schema                  { return nil }
+func (s *schema20) GetContains() Schema                       { return NilSchema{} }
+func (s *schema20) GetDependentSchemas() map[string]Schema    { return nil }
+func (s *schema20) GetDependentRequired() map[string][]string { return nil }
+
 func (s *schema20) IsBooleanSchema() bool {
 	if s.schema == nil {
 		return false
@@ -849,17 +1257,14 @@ func (s *schema20) GetExtensions() map[string]any {
 }
 
 func (s *schema20) GetDiscriminator() Discriminator {
-	if s.schema == nil {
-		// Swagger 2.0 discriminator is just a string (property name)
-		if s.schema.Discriminator == "" {
-			return nil
-		}
-		return BaseDiscriminator{
-			PropertyName: s.schema.Discriminator,
-			// No mapping in 2.0
-		}
+	// Swagger 2.0 discriminator is just a string (property name)
+	if s.schema == nil || s.schema.Discriminator == "" {
+		return nil
+	}
+	return BaseDiscriminator{
+		PropertyName: s.schema.Discriminator,
+		// No mapping in 2.0
 	}
-	return nil
 }
 
 func (s *schema20) GetXML() XML {
@@ -917,6 +1322,116 @@ func (s *schema20) GetWriteOnly() bool {
 	return false
 }
 
+func (s *schema20) GetTitle() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Title
+}
+
+func (s *schema20) GetEnum() []any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Enum
+}
+
+func (s *schema20) GetConst() any {
+	// Not in Swagger 2.0
+	return nil
+}
+
+func (s *schema20) GetMinimum() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Minimum
+}
+
+func (s *schema20) GetMaximum() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Maximum
+}
+
+func (s *schema20) GetExclusiveMinimum() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.ExclusiveMinimum
+}
+
+func (s *schema20) GetExclusiveMaximum() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.ExclusiveMaximum
+}
+
+func (s *schema20) GetMinLength() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MinLength
+}
+
+func (s *schema20) GetMaxLength() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MaxLength
+}
+
+func (s *schema20) GetPattern() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Pattern
+}
+
+func (s *schema20) GetMinItems() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MinItems
+}
+
+func (s *schema20) GetMaxItems() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MaxItems
+}
+
+func (s *schema20) GetMultipleOf() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MultipleOf
+}
+
+func (s *schema20) GetUniqueItems() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.UniqueItems
+}
+
+// singleType returns the type as the one element of GetTypes, or nil
+func singleType(typ string) []string {
+	if typ == "" {
+		return nil
+	}
+	return []string{typ}
+}
+
+// xNullable reports the x-nullable extension, the 2.0 stand-in for nullable
+func xNullable(extensions map[string]any) bool {
+	nullable, _ := extensions["x-nullable"].(bool)
+	return nullable
+}
+
 // securityScheme20 wraps OpenAPI 2.0 SecurityScheme
 type securityScheme20 struct {
 	scheme *oa2.SecurityScheme
@@ -999,3 +1514,58 @@ func (s *securityScheme20) GetExtensions() map[string]any {
 	}
 	return s.scheme.Extensions
 }
+
+// components20 wraps the reusable objects of a Swagger 2.0 document. Body
+// parameters stay parameters; 2.0 has no other component sections.
+type components20 struct {
+	NilComponents
+	doc *oa2.Swagger
+	r   *resolver20
+}
+
+func (c *components20) GetSchemas() map[string]Schema {
+	if c.doc.Definitions == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for name, schema := range c.doc.Definitions {
+		if schema != nil {
+			result[name] = c.r.schema(schema)
+		}
+	}
+	return result
+}
+
+func (c *components20) GetResponses() map[string]Response {
+	if c.doc.Responses == nil {
+		return nil
+	}
+	result := make(map[string]Response)
+	for name, resp := range c.doc.Responses {
+		if resp != nil {
+			result[name] = c.r.response(resp, c.doc.Produces)
+		}
+	}
+	return result
+}
+
+func (c *components20) GetParameters() map[string]Parameter {
+	if c.doc.Parameters == nil {
+		return nil
+	}
+	result := make(map[string]Parameter)
+	for name, param := range c.doc.Parameters {
+		if param != nil {
+			result[name] = c.r.parameter(param)
+		}
+	}
+	return result
+}
+
+// externalDocs20 converts external documentation, keeping nil as nil
+func externalDocs20(docs *oa2.ExternalDocumentation) ExternalDocumentation {
+	if docs == nil {
+		return nil
+	}
+	return BaseExternalDocs{Description: docs.Description, URL: docs.URL}
+}
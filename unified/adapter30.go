@@ -4,14 +4,14 @@
 package unified
 
 import (
-	"strings"
-
 	oa3 "github.com/genelet/oas/openapi30"
 )
 
 // Document30 wraps an OpenAPI 3.0 document and implements Document
 type Document30 struct {
 	doc *oa3.OpenAPI
+	r   *resolver30
+	ops operationIndex
 }
 
 // NewDocument30 creates a new Document adapter for OpenAPI 3.0
@@ -35,6 +35,42 @@ func (d *Document30) GetServerURL() string {
 	return ""
 }
 
+func (d *Document30) GetServers() []Server {
+	return servers30(d.doc.Servers)
+}
+
+// servers30 wraps a list of servers of the document, a path item or an
+// operation
+func servers30(servers []*oa3.Server) []Server {
+	var result []Server
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		var variables map[string]ServerVariable
+		if server.Variables != nil {
+			variables = make(map[string]ServerVariable)
+			for name, variable := range server.Variables {
+				if variable != nil {
+					variables[name] = BaseServerVariable{
+						Enum:        variable.Enum,
+						Default:     variable.Default,
+						Description: variable.Description,
+						Extensions:  variable.Extensions,
+					}
+				}
+			}
+		}
+		result = append(result, BaseServer{
+			URL:         server.URL,
+			Description: server.Description,
+			Variables:   variables,
+			Extensions:  server.Extensions,
+		})
+	}
+	return result
+}
+
 func (d *Document30) GetInfo() DocumentInfo {
 	if d.doc.Info == nil {
 		return &documentInfo30{}
@@ -49,12 +85,44 @@ func (d *Document30) GetPaths() map[string]PathItem {
 	result := make(map[string]PathItem)
 	for path, item := range d.doc.Paths.Paths {
 		if item != nil {
-			result[path] = &pathItem30{item: item}
+			result[path] = &pathItem30{item: item, r: d.r}
 		}
 	}
 	return result
 }
 
+func (d *Document30) GetOperationByID(id string) (string, string, Operation) {
+	return d.ops.lookup(d, id)
+}
+
+// pathItem returns the path item of a path, or nil
+func (d *Document30) pathItem(path string) PathItem {
+	item := d.doc.Paths.Get(path)
+	if item == nil {
+		return nil
+	}
+	return &pathItem30{item: item, r: d.r}
+}
+
+func (d *Document30) pathsStamp() pathsStamp {
+	if d.doc.Paths == nil {
+		return pathsStamp{}
+	}
+	return pathsStamp{paths: d.doc.Paths, count: len(d.doc.Paths.Paths)}
+}
+
+// GetWebhooks returns nil, OpenAPI 3.0 has no webhooks
+func (d *Document30) GetWebhooks() map[string]PathItem {
+	return nil
+}
+
+func (d *Document30) GetComponents() Components {
+	if d.doc.Components == nil {
+		return NilComponents{}
+	}
+	return &components30{components: d.doc.Components, r: d.r}
+}
+
 func (d *Document30) GetSecuritySchemes() map[string]SecurityScheme {
 	if d.doc.Components == nil || d.doc.Components.SecuritySchemes == nil {
 		return nil
@@ -79,6 +147,25 @@ func (d *Document30) GetGlobalSecurity() []SecurityRequirement {
 	return result
 }
 
+func (d *Document30) GetTags() []Tag {
+	var result []Tag
+	for _, tag := range d.doc.Tags {
+		if tag != nil {
+			result = append(result, BaseTag{
+				Name:         tag.Name,
+				Description:  tag.Description,
+				ExternalDocs: externalDocs30(tag.ExternalDocs),
+				Extensions:   tag.Extensions,
+			})
+		}
+	}
+	return result
+}
+
+func (d *Document30) GetExternalDocs() ExternalDocumentation {
+	return externalDocs30(d.doc.ExternalDocs)
+}
+
 func (d *Document30) GetExtensions() map[string]any {
 	return d.doc.Extensions
 }
@@ -109,6 +196,29 @@ func (i *documentInfo30) GetDescription() string {
 	return i.info.Description
 }
 
+func (i *documentInfo30) GetTermsOfService() string {
+	if i.info == nil {
+		return ""
+	}
+	return i.info.TermsOfService
+}
+
+func (i *documentInfo30) GetContact() Contact {
+	if i.info == nil || i.info.Contact == nil {
+		return nil
+	}
+	c := i.info.Contact
+	return BaseContact{Name: c.Name, URL: c.URL, Email: c.Email, Extensions: c.Extensions}
+}
+
+func (i *documentInfo30) GetLicense() License {
+	if i.info == nil || i.info.License == nil {
+		return nil
+	}
+	l := i.info.License
+	return BaseLicense{Name: l.Name, URL: l.URL, Extensions: l.Extensions}
+}
+
 func (i *documentInfo30) GetExtensions() map[string]any {
 	if i.info == nil {
 		return nil
@@ -119,6 +229,7 @@ func (i *documentInfo30) GetExtensions() map[string]any {
 // pathItem30 wraps OpenAPI 3.0 PathItem
 type pathItem30 struct {
 	item *oa3.PathItem
+	r    *resolver30
 }
 
 func (p *pathItem30) HasRef() bool {
@@ -133,33 +244,11 @@ func (p *pathItem30) GetRef() string {
 }
 
 func (p *pathItem30) GetOperation(method string) Operation {
-	if p.item == nil {
-		return NilOperation{}
-	}
-	method = strings.ToLower(method)
-	var op *oa3.Operation
-	switch method {
-	case "get":
-		op = p.item.Get
-	case "put":
-		op = p.item.Put
-	case "post":
-		op = p.item.Post
-	case "delete":
-		op = p.item.Delete
-	case "options":
-		op = p.item.Options
-	case "head":
-		op = p.item.Head
-	case "patch":
-		op = p.item.Patch
-	case "trace":
-		op = p.item.Trace
-	}
+	op := p.item.GetOperation(method)
 	if op == nil {
 		return NilOperation{}
 	}
-	return &operation30{op: op}
+	return &operation30{op: op, r: p.r}
 }
 
 func (p *pathItem30) GetAllOperations() map[string]Operation {
@@ -167,29 +256,8 @@ func (p *pathItem30) GetAllOperations() map[string]Operation {
 		return nil
 	}
 	result := make(map[string]Operation)
-	if p.item.Get != nil {
-		result["get"] = &operation30{op: p.item.Get}
-	}
-	if p.item.Put != nil {
-		result["put"] = &operation30{op: p.item.Put}
-	}
-	if p.item.Post != nil {
-		result["post"] = &operation30{op: p.item.Post}
-	}
-	if p.item.Delete != nil {
-		result["delete"] = &operation30{op: p.item.Delete}
-	}
-	if p.item.Options != nil {
-		result["options"] = &operation30{op: p.item.Options}
-	}
-	if p.item.Head != nil {
-		result["head"] = &operation30{op: p.item.Head}
-	}
-	if p.item.Patch != nil {
-		result["patch"] = &operation30{op: p.item.Patch}
-	}
-	if p.item.Trace != nil {
-		result["trace"] = &operation30{op: p.item.Trace}
+	for method, op := range p.item.Operations() {
+		result[method] = &operation30{op: op, r: p.r}
 	}
 	return result
 }
@@ -201,7 +269,7 @@ func (p *pathItem30) GetParameters() []Parameter {
 	result := make([]Parameter, 0, len(p.item.Parameters))
 	for _, param := range p.item.Parameters {
 		if param != nil {
-			result = append(result, &parameter30{param: param})
+			result = append(result, p.r.parameter(param))
 		}
 	}
 	return result
@@ -214,9 +282,17 @@ func (p *pathItem30) GetExtensions() map[string]any {
 	return p.item.Extensions
 }
 
+func (p *pathItem30) GetServers() []Server {
+	if p.item == nil {
+		return nil
+	}
+	return servers30(p.item.Servers)
+}
+
 // operation30 wraps OpenAPI 3.0 Operation
 type operation30 struct {
 	op *oa3.Operation
+	r  *resolver30
 }
 
 func (o *operation30) IsNil() bool {
@@ -251,7 +327,7 @@ func (o *operation30) GetParameters() []Parameter {
 	result := make([]Parameter, 0, len(o.op.Parameters))
 	for _, param := range o.op.Parameters {
 		if param != nil {
-			result = append(result, &parameter30{param: param})
+			result = append(result, o.r.parameter(param))
 		}
 	}
 	return result
@@ -261,14 +337,14 @@ func (o *operation30) GetRequestBody() RequestBody {
 	if o.op == nil || o.op.RequestBody == nil {
 		return NilRequestBody{}
 	}
-	return &requestBody30{rb: o.op.RequestBody}
+	return o.r.requestBody(o.op.RequestBody)
 }
 
 func (o *operation30) GetResponses() Responses {
 	if o.op == nil || o.op.Responses == nil {
 		return NilResponses{}
 	}
-	return &responses30{responses: o.op.Responses}
+	return &responses30{responses: o.op.Responses, r: o.r}
 }
 
 func (o *operation30) GetSecurity() []SecurityRequirement {
@@ -313,9 +389,46 @@ func (o *operation30) GetDeprecated() bool {
 	return o.op.Deprecated
 }
 
+func (o *operation30) GetCallbacks() map[string]Callback {
+	if o.op == nil || o.op.Callbacks == nil {
+		return nil
+	}
+	result := make(map[string]Callback)
+	for name, cb := range o.op.Callbacks {
+		if cb != nil {
+			result[name] = &callback30{callback: cb, r: o.r}
+		}
+	}
+	return result
+}
+
+func (o *operation30) GetServers() []Server {
+	if o.op == nil {
+		return nil
+	}
+	return servers30(o.op.Servers)
+}
+
+func (o *operation30) EffectiveSecurity(doc Document) []EffectiveRequirement {
+	return effectiveSecurity(o, doc)
+}
+
 // parameter30 wraps OpenAPI 3.0 Parameter
 type parameter30 struct {
 	param *oa3.Parameter
+	refSource
+	r *resolver30
+}
+
+func (p *parameter30) HasRef() bool {
+	return p.param != nil && p.param.IsReference()
+}
+
+func (p *parameter30) GetRef() string {
+	if p.param == nil {
+		return ""
+	}
+	return p.param.Ref
 }
 
 func (p *parameter30) GetName() string {
@@ -350,7 +463,7 @@ func (p *parameter30) GetSchema() Schema {
 	if p.param == nil || p.param.Schema == nil {
 		return NilSchema{}
 	}
-	return &schema30{schema: p.param.Schema}
+	return p.r.schema(p.param.Schema)
 }
 
 func (p *parameter30) IsBodyParameter() bool {
@@ -385,6 +498,10 @@ func (p *parameter30) GetStyle() string {
 	return p.param.Style
 }
 
+func (p *parameter30) HasExplode() bool {
+	return p.param != nil && p.param.Explode != nil
+}
+
 func (p *parameter30) GetExplode() bool {
 	if p.param == nil || p.param.Explode == nil {
 		return false
@@ -399,9 +516,55 @@ func (p *parameter30) GetAllowReserved() bool {
 	return p.param.AllowReserved
 }
 
+func (p *parameter30) GetExample() any {
+	if p.param == nil {
+		return nil
+	}
+	return p.param.Example
+}
+
+func (p *parameter30) GetExamples() map[string]Example {
+	if p.param == nil || p.param.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, example := range p.param.Examples {
+		if example != nil {
+			result[name] = &example30{example: example}
+		}
+	}
+	return result
+}
+
+func (p *parameter30) GetContent() map[string]MediaType {
+	if p.param == nil || p.param.Content == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for mt, content := range p.param.Content {
+		if content != nil {
+			result[mt] = &mediaType30{mt: content, r: p.r}
+		}
+	}
+	return result
+}
+
 // requestBody30 wraps OpenAPI 3.0 RequestBody
 type requestBody30 struct {
 	rb *oa3.RequestBody
+	refSource
+	r *resolver30
+}
+
+func (r *requestBody30) HasRef() bool {
+	return r.rb != nil && r.rb.IsReference()
+}
+
+func (r *requestBody30) GetRef() string {
+	if r.rb == nil {
+		return ""
+	}
+	return r.rb.Ref
 }
 
 func (r *requestBody30) IsNil() bool {
@@ -422,12 +585,16 @@ func (r *requestBody30) GetContent() map[string]MediaType {
 	result := make(map[string]MediaType)
 	for mt, content := range r.rb.Content {
 		if content != nil {
-			result[mt] = &mediaType30{mt: content}
+			result[mt] = &mediaType30{mt: content, r: r.r}
 		}
 	}
 	return result
 }
 
+func (r *requestBody30) GetMediaType(mediaType string) MediaType {
+	return mediaTypeOf(r.GetContent(), mediaType)
+}
+
 func (r *requestBody30) GetDescription() string {
 	if r.rb == nil {
 		return ""
@@ -445,13 +612,47 @@ func (r *requestBody30) GetExtensions() map[string]any {
 // mediaType30 wraps OpenAPI 3.0 MediaType
 type mediaType30 struct {
 	mt *oa3.MediaType
+	r  *resolver30
 }
 
 func (m *mediaType30) GetSchema() Schema {
 	if m.mt == nil || m.mt.Schema == nil {
 		return NilSchema{}
 	}
-	return &schema30{schema: m.mt.Schema}
+	return m.r.schema(m.mt.Schema)
+}
+
+func (m *mediaType30) GetExample() any {
+	if m.mt == nil {
+		return nil
+	}
+	return m.mt.Example
+}
+
+func (m *mediaType30) GetExamples() map[string]Example {
+	if m.mt == nil || m.mt.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, example := range m.mt.Examples {
+		if example != nil {
+			result[name] = &example30{example: example}
+		}
+	}
+	return result
+}
+
+func (m *mediaType30) GetEncoding() map[string]Encoding {
+	if m.mt == nil || m.mt.Encoding == nil {
+		return nil
+	}
+	result := make(map[string]Encoding)
+	for name, encoding := range m.mt.Encoding {
+		if encoding != nil {
+			result[name] = &encoding30{encoding: encoding, r: m.r}
+		}
+	}
+	return result
 }
 
 func (m *mediaType30) GetExtensions() map[string]any {
@@ -461,16 +662,70 @@ func (m *mediaType30) GetExtensions() map[string]any {
 	return m.mt.Extensions
 }
 
+// encoding30 wraps OpenAPI 3.0 Encoding
+type encoding30 struct {
+	encoding *oa3.Encoding
+	r        *resolver30
+}
+
+func (e *encoding30) GetContentType() string {
+	return e.encoding.ContentType
+}
+
+func (e *encoding30) GetHeaders() map[string]Header {
+	if e.encoding.Headers == nil {
+		return nil
+	}
+	result := make(map[string]Header)
+	for name, header := range e.encoding.Headers {
+		if header != nil {
+			result[name] = &header30{header: header, r: e.r}
+		}
+	}
+	return result
+}
+
+func (e *encoding30) GetStyle() string {
+	return e.encoding.Style
+}
+
+func (e *encoding30) GetExplode() bool {
+	if e.encoding.Explode == nil {
+		return false
+	}
+	return *e.encoding.Explode
+}
+
+func (e *encoding30) HasExplode() bool {
+	return e.encoding.Explode != nil
+}
+
+func (e *encoding30) GetAllowReserved() bool {
+	return e.encoding.AllowReserved
+}
+
+func (e *encoding30) GetExtensions() map[string]any {
+	return e.encoding.Extensions
+}
+
 // responses30 wraps OpenAPI 3.0 Responses
 type responses30 struct {
 	responses *oa3.Responses
+	r         *resolver30
 }
 
 func (r *responses30) GetDefault() Response {
 	if r.responses == nil || r.responses.Default == nil {
 		return NilResponse{}
 	}
-	return &response30{resp: r.responses.Default}
+	return r.r.response(r.responses.Default)
+}
+
+func (r *responses30) GetByStatus(status int) Response {
+	if resp := r.responses.GetByStatus(status); resp != nil {
+		return r.r.response(resp)
+	}
+	return NilResponse{}
 }
 
 func (r *responses30) GetStatusCodes() map[string]Response {
@@ -480,7 +735,7 @@ func (r *responses30) GetStatusCodes() map[string]Response {
 	result := make(map[string]Response)
 	for code, resp := range r.responses.StatusCode {
 		if resp != nil {
-			result[code] = &response30{resp: resp}
+			result[code] = r.r.response(resp)
 		}
 	}
 	return result
@@ -496,6 +751,8 @@ func (r *responses30) GetExtensions() map[string]any {
 // response30 wraps OpenAPI 3.0 Response
 type response30 struct {
 	resp *oa3.Response
+	refSource
+	r *resolver30
 }
 
 func (r *response30) IsNil() bool {
@@ -527,7 +784,7 @@ func (r *response30) GetHeaders() map[string]Header {
 	result := make(map[string]Header)
 	for name, header := range r.resp.Headers {
 		if header != nil {
-			result[name] = &header30{header: header}
+			result[name] = &header30{header: header, r: r.r}
 		}
 	}
 	return result
@@ -540,12 +797,16 @@ func (r *response30) GetContent() map[string]MediaType {
 	result := make(map[string]MediaType)
 	for mt, content := range r.resp.Content {
 		if content != nil {
-			result[mt] = &mediaType30{mt: content}
+			result[mt] = &mediaType30{mt: content, r: r.r}
 		}
 	}
 	return result
 }
 
+func (r *response30) GetMediaType(mediaType string) MediaType {
+	return mediaTypeOf(r.GetContent(), mediaType)
+}
+
 func (r *response30) GetSchema() Schema {
 	// OpenAPI 3.0 doesn't have schema directly on response
 	return NilSchema{}
@@ -561,13 +822,14 @@ func (r *response30) GetExtensions() map[string]any {
 // header30 wraps OpenAPI 3.0 Header
 type header30 struct {
 	header *oa3.Header
+	r      *resolver30
 }
 
 func (h *header30) GetSchema() Schema {
 	if h.header == nil || h.header.Schema == nil {
 		return NilSchema{}
 	}
-	return &schema30{schema: h.header.Schema}
+	return h.r.schema(h.header.Schema)
 }
 
 func (h *header30) GetRequired() bool {
@@ -598,9 +860,42 @@ func (h *header30) GetDeprecated() bool {
 	return h.header.Deprecated
 }
 
+func (h *header30) GetStyle() string {
+	if h.header == nil {
+		return ""
+	}
+	return h.header.Style
+}
+
+func (h *header30) HasExplode() bool {
+	return h.header != nil && h.header.Explode != nil
+}
+
+func (h *header30) GetExplode() bool {
+	if h.header == nil || h.header.Explode == nil {
+		return false
+	}
+	return *h.header.Explode
+}
+
+func (h *header30) GetContent() map[string]MediaType {
+	if h.header == nil || h.header.Content == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for mt, content := range h.header.Content {
+		if content != nil {
+			result[mt] = &mediaType30{mt: content, r: h.r}
+		}
+	}
+	return result
+}
+
 // schema30 wraps OpenAPI 3.0 Schema
 type schema30 struct {
 	schema *oa3.Schema
+	refSource
+	r *resolver30
 }
 
 func (s *schema30) IsNil() bool {
@@ -621,6 +916,17 @@ func (s *schema30) GetType() string {
 	return s.schema.Type
 }
 
+func (s *schema30) GetTypes() []string {
+	return singleType(s.GetType())
+}
+
+func (s *schema30) IsNullable() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.Nullable
+}
+
 func (s *schema30) GetFormat() string {
 	if s.schema == nil {
 		return ""
@@ -642,7 +948,7 @@ func (s *schema30) GetProperties() map[string]Schema {
 	result := make(map[string]Schema)
 	for name, prop := range s.schema.Properties {
 		if prop != nil {
-			result[name] = &schema30{schema: prop}
+			result[name] = s.r.schema(prop)
 		}
 	}
 	return result
@@ -652,7 +958,19 @@ func (s *schema30) GetItems() Schema {
 	if s.schema == nil || s.schema.Items == nil {
 		return NilSchema{}
 	}
-	return &schema30{schema: s.schema.Items}
+	return s.r.schema(s.schema.Items)
+}
+
+func (s *schema30) GetAdditionalProperties() Schema {
+	if s.schema == nil || s.schema.AdditionalProperties == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.AdditionalProperties)
+}
+
+func (s *schema30) GetPatternProperties() map[string]Schema {
+	// Not in OpenAPI 3.0
+	return nil
 }
 
 func (s *schema30) GetRequired() []string {
@@ -669,7 +987,7 @@ func (s *schema30) GetAllOf() []Schema {
 	result := make([]Schema, 0, len(s.schema.AllOf))
 	for _, sub := range s.schema.AllOf {
 		if sub != nil {
-			result = append(result, &schema30{schema: sub})
+			result = append(result, s.r.schema(sub))
 		}
 	}
 	return result
@@ -682,7 +1000,7 @@ func (s *schema30) GetOneOf() []Schema {
 	result := make([]Schema, 0, len(s.schema.OneOf))
 	for _, sub := range s.schema.OneOf {
 		if sub != nil {
-			result = append(result, &schema30{schema: sub})
+			result = append(result, s.r.schema(sub))
 		}
 	}
 	return result
@@ -695,12 +1013,29 @@ func (s *schema30) GetAnyOf() []Schema {
 	result := make([]Schema, 0, len(s.schema.AnyOf))
 	for _, sub := range s.schema.AnyOf {
 		if sub != nil {
-			result = append(result, &schema30{schema: sub})
+			result = append(result, s.r.schema(sub))
 		}
 	}
 	return result
 }
 
+func (s *schema30) GetNot() Schema {
+	if s.schema == nil || s.schema.Not == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Not)
+}
+
+// GetIf returns NilSchema, the other conditional and tuple keywords are not
+// in OpenAPI 3.0
+func (s *schema30) GetIf() Schema                             { return NilSchema{} }
+func (s *schema30) GetThen() Schema                           { return NilSchema{} }
+func (s *schema30) GetElse() Schema                           { return NilSchema{} }
+func (s *schema30) GetPrefixItems() []Schema                  { return nil }
+func (s *schema30) GetContains() Schema                       { return NilSchema{} }
+func (s *schema30) GetDependentSchemas() map[string]Schema    { return nil }
+func (s *schema30) GetDependentRequired() map[string][]string { return nil }
+
 func (s *schema30) IsBooleanSchema() bool {
 	if s.schema == nil {
 		return false
@@ -790,6 +1125,102 @@ func (s *schema30) GetWriteOnly() bool {
 	return s.schema.WriteOnly
 }
 
+func (s *schema30) GetTitle() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Title
+}
+
+func (s *schema30) GetEnum() []any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Enum
+}
+
+func (s *schema30) GetConst() any {
+	// Not in OpenAPI 3.0
+	return nil
+}
+
+func (s *schema30) GetMinimum() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Minimum
+}
+
+func (s *schema30) GetMaximum() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Maximum
+}
+
+func (s *schema30) GetExclusiveMinimum() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.ExclusiveMinimum
+}
+
+func (s *schema30) GetExclusiveMaximum() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.ExclusiveMaximum
+}
+
+func (s *schema30) GetMinLength() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MinLength
+}
+
+func (s *schema30) GetMaxLength() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MaxLength
+}
+
+func (s *schema30) GetPattern() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Pattern
+}
+
+func (s *schema30) GetMinItems() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MinItems
+}
+
+func (s *schema30) GetMaxItems() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MaxItems
+}
+
+func (s *schema30) GetMultipleOf() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MultipleOf
+}
+
+func (s *schema30) GetUniqueItems() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.UniqueItems
+}
+
 // securityScheme30 wraps OpenAPI 3.0 SecurityScheme
 type securityScheme30 struct {
 	scheme *oa3.SecurityScheme
@@ -905,3 +1336,231 @@ func (s *securityScheme30) GetExtensions() map[string]any {
 	}
 	return s.scheme.Extensions
 }
+
+// components30 wraps OpenAPI 3.0 Components
+type components30 struct {
+	components *oa3.Components
+	r          *resolver30
+}
+
+func (c *components30) GetSchemas() map[string]Schema {
+	if c.components.Schemas == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for name, schema := range c.components.Schemas {
+		if schema != nil {
+			result[name] = c.r.schema(schema)
+		}
+	}
+	return result
+}
+
+func (c *components30) GetResponses() map[string]Response {
+	if c.components.Responses == nil {
+		return nil
+	}
+	result := make(map[string]Response)
+	for name, resp := range c.components.Responses {
+		if resp != nil {
+			result[name] = c.r.response(resp)
+		}
+	}
+	return result
+}
+
+func (c *components30) GetParameters() map[string]Parameter {
+	if c.components.Parameters == nil {
+		return nil
+	}
+	result := make(map[string]Parameter)
+	for name, param := range c.components.Parameters {
+		if param != nil {
+			result[name] = c.r.parameter(param)
+		}
+	}
+	return result
+}
+
+func (c *components30) GetRequestBodies() map[string]RequestBody {
+	if c.components.RequestBodies == nil {
+		return nil
+	}
+	result := make(map[string]RequestBody)
+	for name, rb := range c.components.RequestBodies {
+		if rb != nil {
+			result[name] = c.r.requestBody(rb)
+		}
+	}
+	return result
+}
+
+func (c *components30) GetHeaders() map[string]Header {
+	if c.components.Headers == nil {
+		return nil
+	}
+	result := make(map[string]Header)
+	for name, header := range c.components.Headers {
+		if header != nil {
+			result[name] = &header30{header: header, r: c.r}
+		}
+	}
+	return result
+}
+
+func (c *components30) GetExamples() map[string]Example {
+	if c.components.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, ex := range c.components.Examples {
+		if ex != nil {
+			result[name] = &example30{example: ex}
+		}
+	}
+	return result
+}
+
+func (c *components30) GetLinks() map[string]Link {
+	if c.components.Links == nil {
+		return nil
+	}
+	result := make(map[string]Link)
+	for name, link := range c.components.Links {
+		if link != nil {
+			result[name] = &link30{link: link}
+		}
+	}
+	return result
+}
+
+func (c *components30) GetCallbacks() map[string]Callback {
+	if c.components.Callbacks == nil {
+		return nil
+	}
+	result := make(map[string]Callback)
+	for name, cb := range c.components.Callbacks {
+		if cb != nil {
+			result[name] = &callback30{callback: cb, r: c.r}
+		}
+	}
+	return result
+}
+
+// GetPathItems returns nil, OpenAPI 3.0 has no path item components
+func (c *components30) GetPathItems() map[string]PathItem {
+	return nil
+}
+
+func (c *components30) GetExtensions() map[string]any {
+	return c.components.Extensions
+}
+
+// example30 wraps OpenAPI 3.0 Example
+type example30 struct {
+	example *oa3.Example
+}
+
+func (e *example30) HasRef() bool {
+	return e.example.IsReference()
+}
+
+func (e *example30) GetRef() string {
+	return e.example.Ref
+}
+
+func (e *example30) GetSummary() string {
+	return e.example.Summary
+}
+
+func (e *example30) GetDescription() string {
+	return e.example.Description
+}
+
+func (e *example30) GetValue() any {
+	return e.example.Value
+}
+
+func (e *example30) GetExternalValue() string {
+	return e.example.ExternalValue
+}
+
+func (e *example30) GetExtensions() map[string]any {
+	return e.example.Extensions
+}
+
+// link30 wraps OpenAPI 3.0 Link
+type link30 struct {
+	link *oa3.Link
+}
+
+func (l *link30) HasRef() bool {
+	return l.link.IsReference()
+}
+
+func (l *link30) GetRef() string {
+	return l.link.Ref
+}
+
+func (l *link30) GetOperationRef() string {
+	return l.link.OperationRef
+}
+
+func (l *link30) GetOperationID() string {
+	return l.link.OperationId
+}
+
+func (l *link30) GetParameters() map[string]any {
+	return l.link.Parameters
+}
+
+func (l *link30) GetRequestBody() any {
+	return l.link.RequestBody
+}
+
+func (l *link30) GetDescription() string {
+	return l.link.Description
+}
+
+func (l *link30) GetExtensions() map[string]any {
+	return l.link.Extensions
+}
+
+// callback30 wraps OpenAPI 3.0 Callback
+type callback30 struct {
+	callback *oa3.Callback
+	r        *resolver30
+}
+
+func (c *callback30) HasRef() bool {
+	return c.callback.IsReference()
+}
+
+func (c *callback30) GetRef() string {
+	return c.callback.Ref
+}
+
+func (c *callback30) GetPathItems() map[string]PathItem {
+	if c.callback.Paths == nil {
+		return nil
+	}
+	result := make(map[string]PathItem)
+	for expr, item := range c.callback.Paths {
+		if item != nil {
+			result[expr] = &pathItem30{item: item, r: c.r}
+		}
+	}
+	return result
+}
+
+func (c *callback30) GetExtensions() map[string]any {
+	return c.callback.Extensions
+}
+
+// externalDocs30 converts external documentation, keeping nil as nil
+func externalDocs30(docs *oa3.ExternalDocumentation) ExternalDocumentation {
+	if docs == nil {
+		return nil
+	}
+	return BaseExternalDocs{Description: docs.Description, URL: docs.URL}
+}
@@ -4,14 +4,14 @@
 package unified
 
 import (
-	"strings"
-
 	oa31 "github.com/genelet/oas/openapi31"
 )
 
 // Document31 wraps an OpenAPI 3.1 document and implements Document
 type Document31 struct {
 	doc *oa31.OpenAPI
+	r   *resolver31
+	ops operationIndex
 }
 
 // NewDocument31 creates a new Document adapter for OpenAPI 3.1
@@ -35,6 +35,42 @@ func (d *Document31) GetServerURL() string {
 	return ""
 }
 
+func (d *Document31) GetServers() []Server {
+	return servers31(d.doc.Servers)
+}
+
+// servers31 wraps a list of servers of the document, a path item or an
+// operation
+func servers31(servers []*oa31.Server) []Server {
+	var result []Server
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		var variables map[string]ServerVariable
+		if server.Variables != nil {
+			variables = make(map[string]ServerVariable)
+			for name, variable := range server.Variables {
+				if variable != nil {
+					variables[name] = BaseServerVariable{
+						Enum:        variable.Enum,
+						Default:     variable.Default,
+						Description: variable.Description,
+						Extensions:  variable.Extensions,
+					}
+				}
+			}
+		}
+		result = append(result, BaseServer{
+			URL:         server.URL,
+			Description: server.Description,
+			Variables:   variables,
+			Extensions:  server.Extensions,
+		})
+	}
+	return result
+}
+
 func (d *Document31) GetInfo() DocumentInfo {
 	if d.doc.Info == nil {
 		return &documentInfo31{}
@@ -49,12 +85,52 @@ func (d *Document31) GetPaths() map[string]PathItem {
 	result := make(map[string]PathItem)
 	for path, item := range d.doc.Paths.Paths {
 		if item != nil {
-			result[path] = &pathItem31{item: item}
+			result[path] = d.r.pathItem(item)
 		}
 	}
 	return result
 }
 
+func (d *Document31) GetOperationByID(id string) (string, string, Operation) {
+	return d.ops.lookup(d, id)
+}
+
+// pathItem returns the path item of a path, or nil
+func (d *Document31) pathItem(path string) PathItem {
+	item := d.doc.Paths.Get(path)
+	if item == nil {
+		return nil
+	}
+	return d.r.pathItem(item)
+}
+
+func (d *Document31) pathsStamp() pathsStamp {
+	if d.doc.Paths == nil {
+		return pathsStamp{}
+	}
+	return pathsStamp{paths: d.doc.Paths, count: len(d.doc.Paths.Paths)}
+}
+
+func (d *Document31) GetWebhooks() map[string]PathItem {
+	if d.doc.Webhooks == nil {
+		return nil
+	}
+	result := make(map[string]PathItem)
+	for name, item := range d.doc.Webhooks {
+		if item != nil {
+			result[name] = d.r.pathItem(item)
+		}
+	}
+	return result
+}
+
+func (d *Document31) GetComponents() Components {
+	if d.doc.Components == nil {
+		return NilComponents{}
+	}
+	return &components31{components: d.doc.Components, r: d.r}
+}
+
 func (d *Document31) GetSecuritySchemes() map[string]SecurityScheme {
 	if d.doc.Components == nil || d.doc.Components.SecuritySchemes == nil {
 		return nil
@@ -79,6 +155,25 @@ func (d *Document31) GetGlobalSecurity() []SecurityRequirement {
 	return result
 }
 
+func (d *Document31) GetTags() []Tag {
+	var result []Tag
+	for _, tag := range d.doc.Tags {
+		if tag != nil {
+			result = append(result, BaseTag{
+				Name:         tag.Name,
+				Description:  tag.Description,
+				ExternalDocs: externalDocs31(tag.ExternalDocs),
+				Extensions:   tag.Extensions,
+			})
+		}
+	}
+	return result
+}
+
+func (d *Document31) GetExternalDocs() ExternalDocumentation {
+	return externalDocs31(d.doc.ExternalDocs)
+}
+
 func (d *Document31) GetExtensions() map[string]any {
 	return d.doc.Extensions
 }
@@ -109,6 +204,29 @@ func (i *documentInfo31) GetDescription() string {
 	return i.info.Description
 }
 
+func (i *documentInfo31) GetTermsOfService() string {
+	if i.info == nil {
+		return ""
+	}
+	return i.info.TermsOfService
+}
+
+func (i *documentInfo31) GetContact() Contact {
+	if i.info == nil || i.info.Contact == nil {
+		return nil
+	}
+	c := i.info.Contact
+	return BaseContact{Name: c.Name, URL: c.URL, Email: c.Email, Extensions: c.Extensions}
+}
+
+func (i *documentInfo31) GetLicense() License {
+	if i.info == nil || i.info.License == nil {
+		return nil
+	}
+	l := i.info.License
+	return BaseLicense{Name: l.Name, Identifier: l.Identifier, URL: l.URL, Extensions: l.Extensions}
+}
+
 func (i *documentInfo31) GetExtensions() map[string]any {
 	if i.info == nil {
 		return nil
@@ -119,6 +237,8 @@ func (i *documentInfo31) GetExtensions() map[string]any {
 // pathItem31 wraps OpenAPI 3.1 PathItem
 type pathItem31 struct {
 	item *oa31.PathItem
+	refSource
+	r *resolver31
 }
 
 func (p *pathItem31) HasRef() bool {
@@ -133,33 +253,11 @@ func (p *pathItem31) GetRef() string {
 }
 
 func (p *pathItem31) GetOperation(method string) Operation {
-	if p.item == nil {
-		return NilOperation{}
-	}
-	method = strings.ToLower(method)
-	var op *oa31.Operation
-	switch method {
-	case "get":
-		op = p.item.Get
-	case "put":
-		op = p.item.Put
-	case "post":
-		op = p.item.Post
-	case "delete":
-		op = p.item.Delete
-	case "options":
-		op = p.item.Options
-	case "head":
-		op = p.item.Head
-	case "patch":
-		op = p.item.Patch
-	case "trace":
-		op = p.item.Trace
-	}
+	op := p.item.GetOperation(method)
 	if op == nil {
 		return NilOperation{}
 	}
-	return &operation31{op: op}
+	return &operation31{op: op, r: p.r}
 }
 
 func (p *pathItem31) GetAllOperations() map[string]Operation {
@@ -167,29 +265,8 @@ func (p *pathItem31) GetAllOperations() map[string]Operation {
 		return nil
 	}
 	result := make(map[string]Operation)
-	if p.item.Get != nil {
-		result["get"] = &operation31{op: p.item.Get}
-	}
-	if p.item.Put != nil {
-		result["put"] = &operation31{op: p.item.Put}
-	}
-	if p.item.Post != nil {
-		result["post"] = &operation31{op: p.item.Post}
-	}
-	if p.item.Delete != nil {
-		result["delete"] = &operation31{op: p.item.Delete}
-	}
-	if p.item.Options != nil {
-		result["options"] = &operation31{op: p.item.Options}
-	}
-	if p.item.Head != nil {
-		result["head"] = &operation31{op: p.item.Head}
-	}
-	if p.item.Patch != nil {
-		result["patch"] = &operation31{op: p.item.Patch}
-	}
-	if p.item.Trace != nil {
-		result["trace"] = &operation31{op: p.item.Trace}
+	for method, op := range p.item.Operations() {
+		result[method] = &operation31{op: op, r: p.r}
 	}
 	return result
 }
@@ -201,7 +278,7 @@ func (p *pathItem31) GetParameters() []Parameter {
 	result := make([]Parameter, 0, len(p.item.Parameters))
 	for _, param := range p.item.Parameters {
 		if param != nil {
-			result = append(result, &parameter31{param: param})
+			result = append(result, p.r.parameter(param))
 		}
 	}
 	return result
@@ -214,9 +291,17 @@ func (p *pathItem31) GetExtensions() map[string]any {
 	return p.item.Extensions
 }
 
+func (p *pathItem31) GetServers() []Server {
+	if p.item == nil {
+		return nil
+	}
+	return servers31(p.item.Servers)
+}
+
 // operation31 wraps OpenAPI 3.1 Operation
 type operation31 struct {
 	op *oa31.Operation
+	r  *resolver31
 }
 
 func (o *operation31) IsNil() bool {
@@ -251,7 +336,7 @@ func (o *operation31) GetParameters() []Parameter {
 	result := make([]Parameter, 0, len(o.op.Parameters))
 	for _, param := range o.op.Parameters {
 		if param != nil {
-			result = append(result, &parameter31{param: param})
+			result = append(result, o.r.parameter(param))
 		}
 	}
 	return result
@@ -261,14 +346,14 @@ func (o *operation31) GetRequestBody() RequestBody {
 	if o.op == nil || o.op.RequestBody == nil {
 		return NilRequestBody{}
 	}
-	return &requestBody31{rb: o.op.RequestBody}
+	return o.r.requestBody(o.op.RequestBody)
 }
 
 func (o *operation31) GetResponses() Responses {
 	if o.op == nil || o.op.Responses == nil {
 		return NilResponses{}
 	}
-	return &responses31{responses: o.op.Responses}
+	return &responses31{responses: o.op.Responses, r: o.r}
 }
 
 func (o *operation31) GetSecurity() []SecurityRequirement {
@@ -310,13 +395,49 @@ func (o *operation31) GetDeprecated() bool {
 	if o.op == nil {
 		return false
 	}
-	// Missing in 3.1 schema struct, returning false as default
-	return false
+	return o.op.Deprecated
+}
+
+func (o *operation31) GetCallbacks() map[string]Callback {
+	if o.op == nil || o.op.Callbacks == nil {
+		return nil
+	}
+	result := make(map[string]Callback)
+	for name, cb := range o.op.Callbacks {
+		if cb != nil {
+			result[name] = &callback31{callback: cb, r: o.r}
+		}
+	}
+	return result
+}
+
+func (o *operation31) GetServers() []Server {
+	if o.op == nil {
+		return nil
+	}
+	return servers31(o.op.Servers)
+}
+
+func (o *operation31) EffectiveSecurity(doc Document) []EffectiveRequirement {
+	return effectiveSecurity(o, doc)
 }
 
 // parameter31 wraps OpenAPI 3.1 Parameter
 type parameter31 struct {
 	param *oa31.Parameter
+	refSource
+	r *resolver31
+}
+
+func (p *parameter31) HasRef() bool {
+	return p.param != nil && p.param.IsReference()
+}
+
+func (p *parameter31) GetRef() string {
+	if p.param == nil {
+		return ""
+	}
+	return p.param.Ref
 }
 
 func (p *parameter31) GetName() string {
@@ -351,7 +472,7 @@ func (p *parameter31) GetSchema() Schema {
 	if p.param == nil || p.param.Schema == nil {
 		return NilSchema{}
 	}
-	return &schema31{schema: p.param.Schema}
+	return p.r.schema(p.param.Schema)
 }
 
 func (p *parameter31) IsBodyParameter() bool {
@@ -386,6 +507,10 @@ func (p *parameter31) GetStyle() string {
 	return p.param.Style
 }
 
+func (p *parameter31) HasExplode() bool {
+	return p.param != nil && p.param.Explode != nil
+}
+
 func (p *parameter31) GetExplode() bool {
 	if p.param == nil || p.param.Explode == nil {
 		// Default values for explode depend on style, but for now defaulting to false if nil
@@ -403,9 +528,55 @@ func (p *parameter31) GetAllowReserved() bool {
 	return p.param.AllowReserved
 }
 
+func (p *parameter31) GetExample() any {
+	if p.param == nil {
+		return nil
+	}
+	return p.param.Example
+}
+
+func (p *parameter31) GetExamples() map[string]Example {
+	if p.param == nil || p.param.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, example := range p.param.Examples {
+		if example != nil {
+			result[name] = &example31{example: example}
+		}
+	}
+	return result
+}
+
+func (p *parameter31) GetContent() map[string]MediaType {
+	if p.param == nil || p.param.Content == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for mt, content := range p.param.Content {
+		if content != nil {
+			result[mt] = &mediaType31{mt: content, r: p.r}
+		}
+	}
+	return result
+}
+
 // requestBody31 wraps OpenAPI 3.1 RequestBody
 type requestBody31 struct {
 	rb *oa31.RequestBody
+	refSource
+	r *resolver31
+}
+
+func (r *requestBody31) HasRef() bool {
+	return r.rb != nil && r.rb.IsReference()
+}
+
+func (r *requestBody31) GetRef() string {
+	if r.rb == nil {
+		return ""
+	}
+	return r.rb.Ref
 }
 
 func (r *requestBody31) IsNil() bool {
@@ -426,12 +597,16 @@ func (r *requestBody31) GetContent() map[string]MediaType {
 	result := make(map[string]MediaType)
 	for mt, content := range r.rb.Content {
 		if content != nil {
-			result[mt] = &mediaType31{mt: content}
+			result[mt] = &mediaType31{mt: content, r: r.r}
 		}
 	}
 	return result
 }
 
+func (r *requestBody31) GetMediaType(mediaType string) MediaType {
+	return mediaTypeOf(r.GetContent(), mediaType)
+}
+
 func (r *requestBody31) GetDescription() string {
 	if r.rb == nil {
 		return ""
@@ -449,13 +624,47 @@ func (r *requestBody31) GetExtensions() map[string]any {
 // mediaType31 wraps OpenAPI 3.1 MediaType
 type mediaType31 struct {
 	mt *oa31.MediaType
+	r  *resolver31
 }
 
 func (m *mediaType31) GetSchema() Schema {
 	if m.mt == nil || m.mt.Schema == nil {
 		return NilSchema{}
 	}
-	return &schema31{schema: m.mt.Schema}
+	return m.r.schema(m.mt.Schema)
+}
+
+func (m *mediaType31) GetExample() any {
+	if m.mt == nil {
+		return nil
+	}
+	return m.mt.Example
+}
+
+func (m *mediaType31) GetExamples() map[string]Example {
+	if m.mt == nil || m.mt.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, example := range m.mt.Examples {
+		if example != nil {
+			result[name] = &example31{example: example}
+		}
+	}
+	return result
+}
+
+func (m *mediaType31) GetEncoding() map[string]Encoding {
+	if m.mt == nil || m.mt.Encoding == nil {
+		return nil
+	}
+	result := make(map[string]Encoding)
+	for name, encoding := range m.mt.Encoding {
+		if encoding != nil {
+			result[name] = &encoding31{encoding: encoding, r: m.r}
+		}
+	}
+	return result
 }
 
 func (m *mediaType31) GetExtensions() map[string]any {
@@ -465,16 +674,70 @@ func (m *mediaType31) GetExtensions() map[string]any {
 	return m.mt.Extensions
 }
 
+// encoding31 wraps OpenAPI 3.1 Encoding
+type encoding31 struct {
+	encoding *oa31.Encoding
+	r        *resolver31
+}
+
+func (e *encoding31) GetContentType() string {
+	return e.encoding.ContentType
+}
+
+func (e *encoding31) GetHeaders() map[string]Header {
+	if e.encoding.Headers == nil {
+		return nil
+	}
+	result := make(map[string]Header)
+	for name, header := range e.encoding.Headers {
+		if header != nil {
+			result[name] = &header31{header: header, r: e.r}
+		}
+	}
+	return result
+}
+
+func (e *encoding31) GetStyle() string {
+	return e.encoding.Style
+}
+
+func (e *encoding31) GetExplode() bool {
+	if e.encoding.Explode == nil {
+		return false
+	}
+	return *e.encoding.Explode
+}
+
+func (e *encoding31) HasExplode() bool {
+	return e.encoding.Explode != nil
+}
+
+func (e *encoding31) GetAllowReserved() bool {
+	return e.encoding.AllowReserved
+}
+
+func (e *encoding31) GetExtensions() map[string]any {
+	return e.encoding.Extensions
+}
+
 // responses31 wraps OpenAPI 3.1 Responses
 type responses31 struct {
 	responses *oa31.Responses
+	r         *resolver31
 }
 
 func (r *responses31) GetDefault() Response {
 	if r.responses == nil || r.responses.Default == nil {
 		return NilResponse{}
 	}
-	return &response31{resp: r.responses.Default}
+	return r.r.response(r.responses.Default)
+}
+
+func (r *responses31) GetByStatus(status int) Response {
+	if resp := r.responses.GetByStatus(status); resp != nil {
+		return r.r.response(resp)
+	}
+	return NilResponse{}
 }
 
 func (r *responses31) GetStatusCodes() map[string]Response {
@@ -484,7 +747,7 @@ func (r *responses31) GetStatusCodes() map[string]Response {
 	result := make(map[string]Response)
 	for code, resp := range r.responses.StatusCode {
 		if resp != nil {
-			result[code] = &response31{resp: resp}
+			result[code] = r.r.response(resp)
 		}
 	}
 	return result
@@ -500,6 +763,8 @@ func (r *responses31) GetExtensions() map[string]any {
 // response31 wraps OpenAPI 3.1 Response
 type response31 struct {
 	resp *oa31.Response
+	refSource
+	r *resolver31
 }
 
 func (r *response31) IsNil() bool {
@@ -531,7 +796,7 @@ func (r *response31) GetHeaders() map[string]Header {
 	result := make(map[string]Header)
 	for name, header := range r.resp.Headers {
 		if header != nil {
-			result[name] = &header31{header: header}
+			result[name] = &header31{header: header, r: r.r}
 		}
 	}
 	return result
@@ -544,12 +809,16 @@ func (r *response31) GetContent() map[string]MediaType {
 	result := make(map[string]MediaType)
 	for mt, content := range r.resp.Content {
 		if content != nil {
-			result[mt] = &mediaType31{mt: content}
+			result[mt] = &mediaType31{mt: content, r: r.r}
 		}
 	}
 	return result
 }
 
+func (r *response31) GetMediaType(mediaType string) MediaType {
+	return mediaTypeOf(r.GetContent(), mediaType)
+}
+
 func (r *response31) GetSchema() Schema {
 	// OpenAPI 3.1 doesn't have schema directly on response
 	return NilSchema{}
@@ -565,13 +834,14 @@ func (r *response31) GetExtensions() map[string]any {
 // header31 wraps OpenAPI 3.1 Header
 type header31 struct {
 	header *oa31.Header
+	r      *resolver31
 }
 
 func (h *header31) GetSchema() Schema {
 	if h.header == nil || h.header.Schema == nil {
 		return NilSchema{}
 	}
-	return &schema31{schema: h.header.Schema}
+	return h.r.schema(h.header.Schema)
 }
 
 func (h *header31) GetRequired() bool {
@@ -602,9 +872,42 @@ func (h *header31) GetDeprecated() bool {
 	return h.header.Deprecated
 }
 
+func (h *header31) GetStyle() string {
+	if h.header == nil {
+		return ""
+	}
+	return h.header.Style
+}
+
+func (h *header31) HasExplode() bool {
+	return h.header != nil && h.header.Explode != nil
+}
+
+func (h *header31) GetExplode() bool {
+	if h.header == nil || h.header.Explode == nil {
+		return false
+	}
+	return *h.header.Explode
+}
+
+func (h *header31) GetContent() map[string]MediaType {
+	if h.header == nil || h.header.Content == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for mt, content := range h.header.Content {
+		if content != nil {
+			result[mt] = &mediaType31{mt: content, r: h.r}
+		}
+	}
+	return result
+}
+
 // schema31 wraps OpenAPI 3.1 Schema
 type schema31 struct {
 	schema *oa31.Schema
+	refSource
+	r *resolver31
 }
 
 func (s *schema31) IsNil() bool {
@@ -638,6 +941,23 @@ func (s *schema31) GetType() string {
 	return ""
 }
 
+func (s *schema31) GetTypes() []string {
+	if s.schema == nil || s.schema.Type == nil {
+		return nil
+	}
+	if s.schema.Type.String != "" {
+		return []string{s.schema.Type.String}
+	}
+	return s.schema.Type.Array
+}
+
+func (s *schema31) IsNullable() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.Type.Contains("null")
+}
+
 func (s *schema31) GetFormat() string {
 	if s.schema == nil {
 		return ""
@@ -659,7 +979,7 @@ func (s *schema31) GetProperties() map[string]Schema {
 	result := make(map[string]Schema)
 	for name, prop := range s.schema.Properties {
 		if prop != nil {
-			result[name] = &schema31{schema: prop}
+			result[name] = s.r.schema(prop)
 		}
 	}
 	return result
@@ -669,7 +989,27 @@ func (s *schema31) GetItems() Schema {
 	if s.schema == nil || s.schema.Items == nil {
 		return NilSchema{}
 	}
-	return &schema31{schema: s.schema.Items}
+	return s.r.schema(s.schema.Items)
+}
+
+func (s *schema31) GetAdditionalProperties() Schema {
+	if s.schema == nil || s.schema.AdditionalProperties == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.AdditionalProperties)
+}
+
+func (s *schema31) GetPatternProperties() map[string]Schema {
+	if s.schema == nil || s.schema.PatternProperties == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for pattern, prop := range s.schema.PatternProperties {
+		if prop != nil {
+			result[pattern] = s.r.schema(prop)
+		}
+	}
+	return result
 }
 
 func (s *schema31) GetRequired() []string {
@@ -686,7 +1026,7 @@ func (s *schema31) GetAllOf() []Schema {
 	result := make([]Schema, 0, len(s.schema.AllOf))
 	for _, sub := range s.schema.AllOf {
 		if sub != nil {
-			result = append(result, &schema31{schema: sub})
+			result = append(result, s.r.schema(sub))
 		}
 	}
 	return result
@@ -699,7 +1039,7 @@ func (s *schema31) GetOneOf() []Schema {
 	result := make([]Schema, 0, len(s.schema.OneOf))
 	for _, sub := range s.schema.OneOf {
 		if sub != nil {
-			result = append(result, &schema31{schema: sub})
+			result = append(result, s.r.schema(sub))
 		}
 	}
 	return result
@@ -712,12 +1052,80 @@ func (s *schema31) GetAnyOf() []Schema {
 	result := make([]Schema, 0, len(s.schema.AnyOf))
 	for _, sub := range s.schema.AnyOf {
 		if sub != nil {
-			result = append(result, &schema31{schema: sub})
+			result = append(result, s.r.schema(sub))
+		}
+	}
+	return result
+}
+
+func (s *schema31) GetNot() Schema {
+	if s.schema == nil || s.schema.Not == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Not)
+}
+
+func (s *schema31) GetIf() Schema {
+	if s.schema == nil || s.schema.If == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.If)
+}
+
+func (s *schema31) GetThen() Schema {
+	if s.schema == nil || s.schema.Then == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Then)
+}
+
+func (s *schema31) GetElse() Schema {
+	if s.schema == nil || s.schema.Else == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Else)
+}
+
+func (s *schema31) GetPrefixItems() []Schema {
+	if s.schema == nil || s.schema.PrefixItems == nil {
+		return nil
+	}
+	result := make([]Schema, 0, len(s.schema.PrefixItems))
+	for _, sub := range s.schema.PrefixItems {
+		if sub != nil {
+			result = append(result, s.r.schema(sub))
 		}
 	}
 	return result
 }
 
+func (s *schema31) GetContains() Schema {
+	if s.schema == nil || s.schema.Contains == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Contains)
+}
+
+func (s *schema31) GetDependentSchemas() map[string]Schema {
+	if s.schema == nil || s.schema.DependentSchemas == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for name, sub := range s.schema.DependentSchemas {
+		if sub != nil {
+			result[name] = s.r.schema(sub)
+		}
+	}
+	return result
+}
+
+func (s *schema31) GetDependentRequired() map[string][]string {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.DependentRequired
+}
+
 func (s *schema31) IsBooleanSchema() bool {
 	if s.schema == nil {
 		return false
@@ -807,6 +1215,122 @@ func (s *schema31) GetWriteOnly() bool {
 	return s.schema.WriteOnly
 }
 
+func (s *schema31) GetTitle() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Title
+}
+
+func (s *schema31) GetEnum() []any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Enum
+}
+
+func (s *schema31) GetConst() any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Const
+}
+
+func (s *schema31) GetMinimum() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	bound, _ := exclusiveBound(s.schema.Minimum, s.schema.ExclusiveMinimum, true)
+	return bound
+}
+
+func (s *schema31) GetMaximum() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	bound, _ := exclusiveBound(s.schema.Maximum, s.schema.ExclusiveMaximum, false)
+	return bound
+}
+
+func (s *schema31) GetExclusiveMinimum() bool {
+	if s.schema == nil {
+		return false
+	}
+	_, exclusive := exclusiveBound(s.schema.Minimum, s.schema.ExclusiveMinimum, true)
+	return exclusive
+}
+
+func (s *schema31) GetExclusiveMaximum() bool {
+	if s.schema == nil {
+		return false
+	}
+	_, exclusive := exclusiveBound(s.schema.Maximum, s.schema.ExclusiveMaximum, false)
+	return exclusive
+}
+
+func (s *schema31) GetMinLength() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MinLength
+}
+
+func (s *schema31) GetMaxLength() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MaxLength
+}
+
+func (s *schema31) GetPattern() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Pattern
+}
+
+func (s *schema31) GetMinItems() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MinItems
+}
+
+func (s *schema31) GetMaxItems() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MaxItems
+}
+
+func (s *schema31) GetMultipleOf() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MultipleOf
+}
+
+func (s *schema31) GetUniqueItems() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.UniqueItems
+}
+
+// exclusiveBound returns the stricter of the inclusive and the numeric
+// exclusive bound of a 3.1+ schema, and whether it is the exclusive one
+func exclusiveBound(inclusive, exclusive *float64, lower bool) (*float64, bool) {
+	switch {
+	case exclusive == nil:
+		return inclusive, false
+	case inclusive == nil:
+		return exclusive, true
+	case lower && *inclusive > *exclusive, !lower && *inclusive < *exclusive:
+		return inclusive, false
+	}
+	return exclusive, true
+}
+
 // securityScheme31 wraps OpenAPI 3.1 SecurityScheme
 type securityScheme31 struct {
 	scheme *oa31.SecurityScheme
@@ -922,3 +1446,246 @@ func (s *securityScheme31) GetExtensions() map[string]any {
 	}
 	return s.scheme.Extensions
 }
+
+// components31 wraps OpenAPI 3.1 Components
+type components31 struct {
+	components *oa31.Components
+	r          *resolver31
+}
+
+func (c *components31) GetSchemas() map[string]Schema {
+	if c.components.Schemas == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for name, schema := range c.components.Schemas {
+		if schema != nil {
+			result[name] = c.r.schema(schema)
+		}
+	}
+	return result
+}
+
+func (c *components31) GetResponses() map[string]Response {
+	if c.components.Responses == nil {
+		return nil
+	}
+	result := make(map[string]Response)
+	for name, resp := range c.components.Responses {
+		if resp != nil {
+			result[name] = c.r.response(resp)
+		}
+	}
+	return result
+}
+
+func (c *components31) GetParameters() map[string]Parameter {
+	if c.components.Parameters == nil {
+		return nil
+	}
+	result := make(map[string]Parameter)
+	for name, param := range c.components.Parameters {
+		if param != nil {
+			result[name] = c.r.parameter(param)
+		}
+	}
+	return result
+}
+
+func (c *components31) GetRequestBodies() map[string]RequestBody {
+	if c.components.RequestBodies == nil {
+		return nil
+	}
+	result := make(map[string]RequestBody)
+	for name, rb := range c.components.RequestBodies {
+		if rb != nil {
+			result[name] = c.r.requestBody(rb)
+		}
+	}
+	return result
+}
+
+func (c *components31) GetHeaders() map[string]Header {
+	if c.components.Headers == nil {
+		return nil
+	}
+	result := make(map[string]Header)
+	for name, header := range c.components.Headers {
+		if header != nil {
+			result[name] = &header31{header: header, r: c.r}
+		}
+	}
+	return result
+}
+
+func (c *components31) GetExamples() map[string]Example {
+	if c.components.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, ex := range c.components.Examples {
+		if ex != nil {
+			result[name] = &example31{example: ex}
+		}
+	}
+	return result
+}
+
+func (c *components31) GetLinks() map[string]Link {
+	if c.components.Links == nil {
+		return nil
+	}
+	result := make(map[string]Link)
+	for name, link := range c.components.Links {
+		if link != nil {
+			result[name] = &link31{link: link}
+		}
+	}
+	return result
+}
+
+func (c *components31) GetCallbacks() map[string]Callback {
+	if c.components.Callbacks == nil {
+		return nil
+	}
+	result := make(map[string]Callback)
+	for name, cb := range c.components.Callbacks {
+		if cb != nil {
+			result[name] = &callback31{callback: cb, r: c.r}
+		}
+	}
+	return result
+}
+
+func (c *components31) GetPathItems() map[string]PathItem {
+	if c.components.PathItems == nil {
+		return nil
+	}
+	result := make(map[string]PathItem)
+	for name, item := range c.components.PathItems {
+		if item != nil {
+			result[name] = c.r.pathItem(item)
+		}
+	}
+	return result
+}
+
+func (c *components31) GetExtensions() map[string]any {
+	return c.components.Extensions
+}
+
+// example31 wraps OpenAPI 3.1 Example
+type example31 struct {
+	example *oa31.Example
+}
+
+func (e *example31) HasRef() bool {
+	return e.example.IsReference()
+}
+
+func (e *example31) GetRef() string {
+	return e.example.Ref
+}
+
+func (e *example31) GetSummary() string {
+	return e.example.Summary
+}
+
+func (e *example31) GetDescription() string {
+	return e.example.Description
+}
+
+func (e *example31) GetValue() any {
+	return e.example.Value
+}
+
+func (e *example31) GetExternalValue() string {
+	return e.example.ExternalValue
+}
+
+func (e *example31) GetExtensions() map[string]any {
+	return e.example.Extensions
+}
+
+// link31 wraps OpenAPI 3.1 Link
+type link31 struct {
+	link *oa31.Link
+}
+
+func (l *link31) HasRef() bool {
+	return l.link.IsReference()
+}
+
+func (l *link31) GetRef() string {
+	return l.link.Ref
+}
+
+func (l *link31) GetOperationRef() string {
+	return l.link.OperationRef
+}
+
+func (l *link31) GetOperationID() string {
+	return l.link.OperationId
+}
+
+func (l *link31) GetParameters() map[string]any {
+	if l.link.Parameters == nil {
+		return nil
+	}
+	result := make(map[string]any, len(l.link.Parameters))
+	for name, expr := range l.link.Parameters {
+		result[name] = expr
+	}
+	return result
+}
+
+func (l *link31) GetRequestBody() any {
+	return l.link.RequestBody
+}
+
+func (l *link31) GetDescription() string {
+	return l.link.Description
+}
+
+func (l *link31) GetExtensions() map[string]any {
+	return l.link.Extensions
+}
+
+// callback31 wraps OpenAPI 3.1 Callback
+type callback31 struct {
+	callback *oa31.Callback
+	r        *resolver31
+}
+
+func (c *callback31) HasRef() bool {
+	return c.callback.IsReference()
+}
+
+func (c *callback31) GetRef() string {
+	return c.callback.Ref
+}
+
+func (c *callback31) GetPathItems() map[string]PathItem {
+	if c.callback.Paths == nil {
+		return nil
+	}
+	result := make(map[string]PathItem)
+	for expr, item := range c.callback.Paths {
+		if item != nil {
+			result[expr] = c.r.pathItem(item)
+		}
+	}
+	return result
+}
+
+func (c *callback31) GetExtensions() map[string]any {
+	return c.callback.Extensions
+}
+
+// externalDocs31 converts external documentation, keeping nil as nil
+func externalDocs31(docs *oa31.ExternalDocumentation) ExternalDocumentation {
+	if docs == nil {
+		return nil
+	}
+	return BaseExternalDocs{Description: docs.Description, URL: docs.URL}
+}
@@ -0,0 +1,1682 @@
+// Package unified provides an adapter for OpenAPI 3.2 documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"strings"
+
+	oa32 "github.com/genelet/oas/openapi32"
+)
+
+// Document32 wraps an OpenAPI 3.2 document and implements Document
+type Document32 struct {
+	doc *oa32.OpenAPI
+	r   *resolver32
+	ops operationIndex
+}
+
+// NewDocument32 creates a new Document adapter for OpenAPI 3.2
+func NewDocument32(doc *oa32.OpenAPI) Document {
+	return &Document32{doc: doc}
+}
+
+// GetRaw returns the underlying OpenAPI 3.2 document
+func (d *Document32) GetRaw() *oa32.OpenAPI {
+	return d.doc
+}
+
+func (d *Document32) Version() string {
+	return d.doc.OpenAPI
+}
+
+func (d *Document32) GetServerURL() string {
+	if len(d.doc.Servers) > 0 && d.doc.Servers[0] != nil {
+		return d.doc.Servers[0].URL
+	}
+	return ""
+}
+
+func (d *Document32) GetServers() []Server {
+	return servers32(d.doc.Servers)
+}
+
+// servers32 wraps a list of servers of the document, a path item or an
+// operation
+func servers32(servers []*oa32.Server) []Server {
+	var result []Server
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		var variables map[string]ServerVariable
+		if server.Variables != nil {
+			variables = make(map[string]ServerVariable)
+			for name, variable := range server.Variables {
+				if variable != nil {
+					variables[name] = BaseServerVariable{
+						Enum:        variable.Enum,
+						Default:     variable.Default,
+						Description: variable.Description,
+						Extensions:  variable.Extensions,
+					}
+				}
+			}
+		}
+		result = append(result, BaseServer{
+			URL:         server.URL,
+			Description: server.Description,
+			Variables:   variables,
+			Extensions:  server.Extensions,
+		})
+	}
+	return result
+}
+
+func (d *Document32) GetInfo() DocumentInfo {
+	if d.doc.Info == nil {
+		return &documentInfo32{}
+	}
+	return &documentInfo32{info: d.doc.Info}
+}
+
+func (d *Document32) GetPaths() map[string]PathItem {
+	if d.doc.Paths == nil || d.doc.Paths.Paths == nil {
+		return nil
+	}
+	result := make(map[string]PathItem)
+	for path, item := range d.doc.Paths.Paths {
+		if item != nil {
+			result[path] = d.r.pathItem(item)
+		}
+	}
+	return result
+}
+
+func (d *Document32) GetOperationByID(id string) (string, string, Operation) {
+	return d.ops.lookup(d, id)
+}
+
+// pathItem returns the path item of a path, or nil
+func (d *Document32) pathItem(path string) PathItem {
+	item := d.doc.Paths.Get(path)
+	if item == nil {
+		return nil
+	}
+	return d.r.pathItem(item)
+}
+
+func (d *Document32) pathsStamp() pathsStamp {
+	if d.doc.Paths == nil {
+		return pathsStamp{}
+	}
+	return pathsStamp{paths: d.doc.Paths, count: len(d.doc.Paths.Paths)}
+}
+
+func (d *Document32) GetWebhooks() map[string]PathItem {
+	if d.doc.Webhooks == nil {
+		return nil
+	}
+	result := make(map[string]PathItem)
+	for name, item := range d.doc.Webhooks {
+		if item != nil {
+			result[name] = d.r.pathItem(item)
+		}
+	}
+	return result
+}
+
+func (d *Document32) GetComponents() Components {
+	if d.doc.Components == nil {
+		return NilComponents{}
+	}
+	return &components32{components: d.doc.Components, r: d.r}
+}
+
+func (d *Document32) GetSecuritySchemes() map[string]SecurityScheme {
+	if d.doc.Components == nil || d.doc.Components.SecuritySchemes == nil {
+		return nil
+	}
+	result := make(map[string]SecurityScheme)
+	for name, scheme := range d.doc.Components.SecuritySchemes {
+		if scheme != nil {
+			result[name] = &securityScheme32{scheme: scheme}
+		}
+	}
+	return result
+}
+
+func (d *Document32) GetGlobalSecurity() []SecurityRequirement {
+	if d.doc.Security == nil {
+		return nil
+	}
+	result := make([]SecurityRequirement, len(d.doc.Security))
+	for i, sec := range d.doc.Security {
+		result[i] = SecurityRequirement(sec)
+	}
+	return result
+}
+
+func (d *Document32) GetTags() []Tag {
+	var result []Tag
+	for _, tag := range d.doc.Tags {
+		if tag != nil {
+			result = append(result, BaseTag{
+				Name:         tag.Name,
+				Description:  tag.Description,
+				ExternalDocs: externalDocs32(tag.ExternalDocs),
+				Extensions:   tag.Extensions,
+			})
+		}
+	}
+	return result
+}
+
+func (d *Document32) GetExternalDocs() ExternalDocumentation {
+	return externalDocs32(d.doc.ExternalDocs)
+}
+
+func (d *Document32) GetExtensions() map[string]any {
+	return d.doc.Extensions
+}
+
+// documentInfo32 wraps OpenAPI 3.2 Info
+type documentInfo32 struct {
+	info *oa32.Info
+}
+
+func (i *documentInfo32) GetTitle() string {
+	if i.info == nil {
+		return ""
+	}
+	return i.info.Title
+}
+
+func (i *documentInfo32) GetVersion() string {
+	if i.info == nil {
+		return ""
+	}
+	return i.info.Version
+}
+
+func (i *documentInfo32) GetDescription() string {
+	if i.info == nil {
+		return ""
+	}
+	return i.info.Description
+}
+
+func (i *documentInfo32) GetTermsOfService() string {
+	if i.info == nil {
+		return ""
+	}
+	return i.info.TermsOfService
+}
+
+func (i *documentInfo32) GetContact() Contact {
+	if i.info == nil || i.info.Contact == nil {
+		return nil
+	}
+	c := i.info.Contact
+	return BaseContact{Name: c.Name, URL: c.URL, Email: c.Email, Extensions: c.Extensions}
+}
+
+func (i *documentInfo32) GetLicense() License {
+	if i.info == nil || i.info.License == nil {
+		return nil
+	}
+	l := i.info.License
+	return BaseLicense{Name: l.Name, Identifier: l.Identifier, URL: l.URL, Extensions: l.Extensions}
+}
+
+func (i *documentInfo32) GetExtensions() map[string]any {
+	if i.info == nil {
+		return nil
+	}
+	return i.info.Extensions
+}
+
+// pathItem32 wraps OpenAPI 3.2 PathItem
+type pathItem32 struct {
+	item *oa32.PathItem
+	refSource
+	r *resolver32
+}
+
+func (p *pathItem32) HasRef() bool {
+	return p.item != nil && p.item.HasRef()
+}
+
+func (p *pathItem32) GetRef() string {
+	if p.item == nil {
+		return ""
+	}
+	return p.item.GetRef()
+}
+
+func (p *pathItem32) GetOperation(method string) Operation {
+	op := p.item.GetOperation(method)
+	if op == nil {
+		return NilOperation{}
+	}
+	return &operation32{op: op, r: p.r}
+}
+
+func (p *pathItem32) GetAllOperations() map[string]Operation {
+	if p.item == nil {
+		return nil
+	}
+	result := make(map[string]Operation)
+	for method, op := range p.item.Operations() {
+		result[strings.ToLower(method)] = &operation32{op: op, r: p.r}
+	}
+	return result
+}
+
+func (p *pathItem32) GetParameters() []Parameter {
+	if p.item == nil || p.item.Parameters == nil {
+		return nil
+	}
+	result := make([]Parameter, 0, len(p.item.Parameters))
+	for _, param := range p.item.Parameters {
+		if param != nil {
+			result = append(result, p.r.parameter(param))
+		}
+	}
+	return result
+}
+
+func (p *pathItem32) GetExtensions() map[string]any {
+	if p.item == nil {
+		return nil
+	}
+	return p.item.Extensions
+}
+
+func (p *pathItem32) GetServers() []Server {
+	if p.item == nil {
+		return nil
+	}
+	return servers32(p.item.Servers)
+}
+
+// operation32 wraps OpenAPI 3.2 Operation
+type operation32 struct {
+	op *oa32.Operation
+	r  *resolver32
+}
+
+func (o *operation32) IsNil() bool {
+	return o.op == nil
+}
+
+func (o *operation32) GetOperationID() string {
+	if o.op == nil {
+		return ""
+	}
+	return o.op.OperationID
+}
+
+func (o *operation32) GetSummary() string {
+	if o.op == nil {
+		return ""
+	}
+	return o.op.Summary
+}
+
+func (o *operation32) GetDescription() string {
+	if o.op == nil {
+		return ""
+	}
+	return o.op.Description
+}
+
+func (o *operation32) GetParameters() []Parameter {
+	if o.op == nil || o.op.Parameters == nil {
+		return nil
+	}
+	result := make([]Parameter, 0, len(o.op.Parameters))
+	for _, param := range o.op.Parameters {
+		if param != nil {
+			result = append(result, o.r.parameter(param))
+		}
+	}
+	return result
+}
+
+func (o *operation32) GetRequestBody() RequestBody {
+	if o.op == nil || o.op.RequestBody == nil {
+		return NilRequestBody{}
+	}
+	return o.r.requestBody(o.op.RequestBody)
+}
+
+func (o *operation32) GetResponses() Responses {
+	if o.op == nil || o.op.Responses == nil {
+		return NilResponses{}
+	}
+	return &responses32{responses: o.op.Responses, r: o.r}
+}
+
+func (o *operation32) GetSecurity() []SecurityRequirement {
+	if o.op == nil || o.op.Security == nil {
+		return nil
+	}
+	result := make([]SecurityRequirement, len(o.op.Security))
+	for i, sec := range o.op.Security {
+		result[i] = SecurityRequirement(sec)
+	}
+	return result
+}
+
+func (o *operation32) GetTags() []string {
+	if o.op == nil {
+		return nil
+	}
+	return o.op.Tags
+}
+
+func (o *operation32) GetExtensions() map[string]any {
+	if o.op == nil {
+		return nil
+	}
+	return o.op.Extensions
+}
+
+func (o *operation32) GetExternalDocs() ExternalDocumentation {
+	if o.op == nil || o.op.ExternalDocs == nil {
+		return nil
+	}
+	return BaseExternalDocs{
+		Description: o.op.ExternalDocs.Description,
+		URL:         o.op.ExternalDocs.URL,
+	}
+}
+
+func (o *operation32) GetDeprecated() bool {
+	if o.op == nil {
+		return false
+	}
+	return o.op.Deprecated
+}
+
+func (o *operation32) GetCallbacks() map[string]Callback {
+	if o.op == nil || o.op.Callbacks == nil {
+		return nil
+	}
+	result := make(map[string]Callback)
+	for name, cb := range o.op.Callbacks {
+		if cb != nil {
+			result[name] = &callback32{callback: cb, r: o.r}
+		}
+	}
+	return result
+}
+
+func (o *operation32) GetServers() []Server {
+	if o.op == nil {
+		return nil
+	}
+	return servers32(o.op.Servers)
+}
+
+func (o *operation32) EffectiveSecurity(doc Document) []EffectiveRequirement {
+	return effectiveSecurity(o, doc)
+}
+
+// parameter32 wraps OpenAPI 3.2 Parameter
+type parameter32 struct {
+	param *oa32.Parameter
+	refSource
+	r *resolver32
+}
+
+func (p *parameter32) HasRef() bool {
+	return p.param != nil && p.param.IsReference()
+}
+
+func (p *parameter32) GetRef() string {
+	if p.param == nil {
+		return ""
+	}
+	return p.param.Ref
+}
+
+func (p *parameter32) GetName() string {
+	if p.param == nil {
+		return ""
+	}
+	return p.param.Name
+}
+
+func (p *parameter32) GetIn() string {
+	if p.param == nil {
+		return ""
+	}
+	return p.param.In
+}
+
+func (p *parameter32) GetRequired() bool {
+	if p.param == nil {
+		return false
+	}
+	return p.param.Required
+}
+
+func (p *parameter32) GetDescription() string {
+	if p.param == nil {
+		return ""
+	}
+	return p.param.Description
+}
+
+func (p *parameter32) GetSchema() Schema {
+	if p.param == nil || p.param.Schema == nil {
+		return NilSchema{}
+	}
+	return p.r.schema(p.param.Schema)
+}
+
+func (p *parameter32) IsBodyParameter() bool {
+	return false // OpenAPI 3.2 doesn't have body parameters
+}
+
+func (p *parameter32) GetExtensions() map[string]any {
+	if p.param == nil {
+		return nil
+	}
+	return p.param.Extensions
+}
+
+func (p *parameter32) GetDeprecated() bool {
+	if p.param == nil {
+		return false
+	}
+	return p.param.Deprecated
+}
+
+func (p *parameter32) GetAllowEmptyValue() bool {
+	if p.param == nil {
+		return false
+	}
+	return p.param.AllowEmptyValue
+}
+
+func (p *parameter32) GetStyle() string {
+	if p.param == nil {
+		return ""
+	}
+	return p.param.Style
+}
+
+func (p *parameter32) HasExplode() bool {
+	return p.param != nil && p.param.Explode != nil
+}
+
+func (p *parameter32) GetExplode() bool {
+	if p.param == nil || p.param.Explode == nil {
+		// Default values for explode depend on style, but for now defaulting to false if nil
+		// OpenApi spec says: "When style is form, the default value is true. For all other styles, the default value is false."
+		// However, adhering to simplifcation here:
+		return false
+	}
+	return *p.param.Explode
+}
+
+func (p *parameter32) GetAllowReserved() bool {
+	if p.param == nil {
+		return false
+	}
+	return p.param.AllowReserved
+}
+
+func (p *parameter32) GetExample() any {
+	if p.param == nil {
+		return nil
+	}
+	return p.param.Example
+}
+
+func (p *parameter32) GetExamples() map[string]Example {
+	if p.param == nil || p.param.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, example := range p.param.Examples {
+		if example != nil {
+			result[name] = &example32{example: example}
+		}
+	}
+	return result
+}
+
+func (p *parameter32) GetContent() map[string]MediaType {
+	if p.param == nil || p.param.Content == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for mt, content := range p.param.Content {
+		if content != nil {
+			result[mt] = &mediaType32{mt: content, r: p.r}
+		}
+	}
+	return result
+}
+
+// requestBody32 wraps OpenAPI 3.2 RequestBody
+type requestBody32 struct {
+	rb *oa32.RequestBody
+	refSource
+	r *resolver32
+}
+
+func (r *requestBody32) HasRef() bool {
+	return r.rb != nil && r.rb.IsReference()
+}
+
+func (r *requestBody32) GetRef() string {
+	if r.rb == nil {
+		return ""
+	}
+	return r.rb.Ref
+}
+
+func (r *requestBody32) IsNil() bool {
+	return r.rb == nil
+}
+
+func (r *requestBody32) GetRequired() bool {
+	if r.rb == nil {
+		return false
+	}
+	return r.rb.Required
+}
+
+func (r *requestBody32) GetContent() map[string]MediaType {
+	if r.rb == nil || r.rb.Content == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for mt, content := range r.rb.Content {
+		if content != nil {
+			result[mt] = &mediaType32{mt: content, r: r.r}
+		}
+	}
+	return result
+}
+
+func (r *requestBody32) GetMediaType(mediaType string) MediaType {
+	return mediaTypeOf(r.GetContent(), mediaType)
+}
+
+func (r *requestBody32) GetDescription() string {
+	if r.rb == nil {
+		return ""
+	}
+	return r.rb.Description
+}
+
+func (r *requestBody32) GetExtensions() map[string]any {
+	if r.rb == nil {
+		return nil
+	}
+	return r.rb.Extensions
+}
+
+// mediaType32 wraps OpenAPI 3.2 MediaType
+type mediaType32 struct {
+	mt *oa32.MediaType
+	r  *resolver32
+}
+
+func (m *mediaType32) GetSchema() Schema {
+	if m.mt == nil || m.mt.Schema == nil {
+		return NilSchema{}
+	}
+	return m.r.schema(m.mt.Schema)
+}
+
+func (m *mediaType32) GetExample() any {
+	if m.mt == nil {
+		return nil
+	}
+	return m.mt.Example
+}
+
+func (m *mediaType32) GetExamples() map[string]Example {
+	if m.mt == nil || m.mt.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, example := range m.mt.Examples {
+		if example != nil {
+			result[name] = &example32{example: example}
+		}
+	}
+	return result
+}
+
+func (m *mediaType32) GetEncoding() map[string]Encoding {
+	if m.mt == nil || m.mt.Encoding == nil {
+		return nil
+	}
+	result := make(map[string]Encoding)
+	for name, encoding := range m.mt.Encoding {
+		if encoding != nil {
+			result[name] = &encoding32{encoding: encoding, r: m.r}
+		}
+	}
+	return result
+}
+
+func (m *mediaType32) GetExtensions() map[string]any {
+	if m.mt == nil {
+		return nil
+	}
+	return m.mt.Extensions
+}
+
+// encoding32 wraps OpenAPI 3.2 Encoding
+type encoding32 struct {
+	encoding *oa32.Encoding
+	r        *resolver32
+}
+
+func (e *encoding32) GetContentType() string {
+	return e.encoding.ContentType
+}
+
+func (e *encoding32) GetHeaders() map[string]Header {
+	if e.encoding.Headers == nil {
+		return nil
+	}
+	result := make(map[string]Header)
+	for name, header := range e.encoding.Headers {
+		if header != nil {
+			result[name] = &header32{header: header, r: e.r}
+		}
+	}
+	return result
+}
+
+func (e *encoding32) GetStyle() string {
+	return e.encoding.Style
+}
+
+func (e *encoding32) GetExplode() bool {
+	if e.encoding.Explode == nil {
+		return false
+	}
+	return *e.encoding.Explode
+}
+
+func (e *encoding32) HasExplode() bool {
+	return e.encoding.Explode != nil
+}
+
+func (e *encoding32) GetAllowReserved() bool {
+	return e.encoding.AllowReserved
+}
+
+func (e *encoding32) GetExtensions() map[string]any {
+	return e.encoding.Extensions
+}
+
+// responses32 wraps OpenAPI 3.2 Responses
+type responses32 struct {
+	responses *oa32.Responses
+	r         *resolver32
+}
+
+func (r *responses32) GetDefault() Response {
+	if r.responses == nil || r.responses.Default == nil {
+		return NilResponse{}
+	}
+	return r.r.response(r.responses.Default)
+}
+
+func (r *responses32) GetByStatus(status int) Response {
+	if resp := r.responses.GetByStatus(status); resp != nil {
+		return r.r.response(resp)
+	}
+	return NilResponse{}
+}
+
+func (r *responses32) GetStatusCodes() map[string]Response {
+	if r.responses == nil || r.responses.StatusCode == nil {
+		return nil
+	}
+	result := make(map[string]Response)
+	for code, resp := range r.responses.StatusCode {
+		if resp != nil {
+			result[code] = r.r.response(resp)
+		}
+	}
+	return result
+}
+
+func (r *responses32) GetExtensions() map[string]any {
+	if r.responses == nil {
+		return nil
+	}
+	return r.responses.Extensions
+}
+
+// response32 wraps OpenAPI 3.2 Response
+type response32 struct {
+	resp *oa32.Response
+	refSource
+	r *resolver32
+}
+
+func (r *response32) IsNil() bool {
+	return r.resp == nil
+}
+
+func (r *response32) HasRef() bool {
+	return r.resp != nil && r.resp.IsReference()
+}
+
+func (r *response32) GetRef() string {
+	if r.resp == nil {
+		return ""
+	}
+	return r.resp.Ref
+}
+
+func (r *response32) GetDescription() string {
+	if r.resp == nil {
+		return ""
+	}
+	return r.resp.Description
+}
+
+func (r *response32) GetHeaders() map[string]Header {
+	if r.resp == nil || r.resp.Headers == nil {
+		return nil
+	}
+	result := make(map[string]Header)
+	for name, header := range r.resp.Headers {
+		if header != nil {
+			result[name] = &header32{header: header, r: r.r}
+		}
+	}
+	return result
+}
+
+func (r *response32) GetContent() map[string]MediaType {
+	if r.resp == nil || r.resp.Content == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for mt, content := range r.resp.Content {
+		if content != nil {
+			result[mt] = &mediaType32{mt: content, r: r.r}
+		}
+	}
+	return result
+}
+
+func (r *response32) GetMediaType(mediaType string) MediaType {
+	return mediaTypeOf(r.GetContent(), mediaType)
+}
+
+func (r *response32) GetSchema() Schema {
+	// OpenAPI 3.2 doesn't have schema directly on response
+	return NilSchema{}
+}
+
+func (r *response32) GetExtensions() map[string]any {
+	if r.resp == nil {
+		return nil
+	}
+	return r.resp.Extensions
+}
+
+// header32 wraps OpenAPI 3.2 Header
+type header32 struct {
+	header *oa32.Header
+	r      *resolver32
+}
+
+func (h *header32) GetSchema() Schema {
+	if h.header == nil || h.header.Schema == nil {
+		return NilSchema{}
+	}
+	return h.r.schema(h.header.Schema)
+}
+
+func (h *header32) GetRequired() bool {
+	if h.header == nil {
+		return false
+	}
+	return h.header.Required
+}
+
+func (h *header32) GetDescription() string {
+	if h.header == nil {
+		return ""
+	}
+	return h.header.Description
+}
+
+func (h *header32) GetExtensions() map[string]any {
+	if h.header == nil {
+		return nil
+	}
+	return h.header.Extensions
+}
+
+func (h *header32) GetDeprecated() bool {
+	if h.header == nil {
+		return false
+	}
+	return h.header.Deprecated
+}
+
+func (h *header32) GetStyle() string {
+	if h.header == nil {
+		return ""
+	}
+	return h.header.Style
+}
+
+func (h *header32) HasExplode() bool {
+	return h.header != nil && h.header.Explode != nil
+}
+
+func (h *header32) GetExplode() bool {
+	if h.header == nil || h.header.Explode == nil {
+		return false
+	}
+	return *h.header.Explode
+}
+
+func (h *header32) GetContent() map[string]MediaType {
+	if h.header == nil || h.header.Content == nil {
+		return nil
+	}
+	result := make(map[string]MediaType)
+	for mt, content := range h.header.Content {
+		if content != nil {
+			result[mt] = &mediaType32{mt: content, r: h.r}
+		}
+	}
+	return result
+}
+
+// schema32 wraps OpenAPI 3.2 Schema
+type schema32 struct {
+	schema *oa32.Schema
+	refSource
+	r *resolver32
+}
+
+func (s *schema32) IsNil() bool {
+	return s.schema == nil
+}
+
+func (s *schema32) GetRef() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Ref
+}
+
+func (s *schema32) GetType() string {
+	if s.schema == nil || s.schema.Type == nil {
+		return ""
+	}
+	// In 3.2, type can be a string or array of strings
+	// Return the first non-null type for compatibility
+	if s.schema.Type.String != "" {
+		return s.schema.Type.String
+	}
+	for _, t := range s.schema.Type.Array {
+		if t != "null" {
+			return t
+		}
+	}
+	if len(s.schema.Type.Array) > 0 {
+		return s.schema.Type.Array[0]
+	}
+	return ""
+}
+
+func (s *schema32) GetTypes() []string {
+	if s.schema == nil || s.schema.Type == nil {
+		return nil
+	}
+	if s.schema.Type.String != "" {
+		return []string{s.schema.Type.String}
+	}
+	return s.schema.Type.Array
+}
+
+func (s *schema32) IsNullable() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.Type.Contains("null")
+}
+
+func (s *schema32) GetFormat() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Format
+}
+
+func (s *schema32) GetDescription() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Description
+}
+
+func (s *schema32) GetProperties() map[string]Schema {
+	if s.schema == nil || s.schema.Properties == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for name, prop := range s.schema.Properties {
+		if prop != nil {
+			result[name] = s.r.schema(prop)
+		}
+	}
+	return result
+}
+
+func (s *schema32) GetItems() Schema {
+	if s.schema == nil || s.schema.Items == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Items)
+}
+
+func (s *schema32) GetAdditionalProperties() Schema {
+	if s.schema == nil || s.schema.AdditionalProperties == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.AdditionalProperties)
+}
+
+func (s *schema32) GetPatternProperties() map[string]Schema {
+	if s.schema == nil || s.schema.PatternProperties == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for pattern, prop := range s.schema.PatternProperties {
+		if prop != nil {
+			result[pattern] = s.r.schema(prop)
+		}
+	}
+	return result
+}
+
+func (s *schema32) GetRequired() []string {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Required
+}
+
+func (s *schema32) GetAllOf() []Schema {
+	if s.schema == nil || s.schema.AllOf == nil {
+		return nil
+	}
+	result := make([]Schema, 0, len(s.schema.AllOf))
+	for _, sub := range s.schema.AllOf {
+		if sub != nil {
+			result = append(result, s.r.schema(sub))
+		}
+	}
+	return result
+}
+
+func (s *schema32) GetOneOf() []Schema {
+	if s.schema == nil || s.schema.OneOf == nil {
+		return nil
+	}
+	result := make([]Schema, 0, len(s.schema.OneOf))
+	for _, sub := range s.schema.OneOf {
+		if sub != nil {
+			result = append(result, s.r.schema(sub))
+		}
+	}
+	return result
+}
+
+func (s *schema32) GetAnyOf() []Schema {
+	if s.schema == nil || s.schema.AnyOf == nil {
+		return nil
+	}
+	result := make([]Schema, 0, len(s.schema.AnyOf))
+	for _, sub := range s.schema.AnyOf {
+		if sub != nil {
+			result = append(result, s.r.schema(sub))
+		}
+	}
+	return result
+}
+
+func (s *schema32) GetNot() Schema {
+	if s.schema == nil || s.schema.Not == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Not)
+}
+
+func (s *schema32) GetIf() Schema {
+	if s.schema == nil || s.schema.If == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.If)
+}
+
+func (s *schema32) GetThen() Schema {
+	if s.schema == nil || s.schema.Then == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Then)
+}
+
+func (s *schema32) GetElse() Schema {
+	if s.schema == nil || s.schema.Else == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Else)
+}
+
+func (s *schema32) GetPrefixItems() []Schema {
+	if s.schema == nil || s.schema.PrefixItems == nil {
+		return nil
+	}
+	result := make([]Schema, 0, len(s.schema.PrefixItems))
+	for _, sub := range s.schema.PrefixItems {
+		if sub != nil {
+			result = append(result, s.r.schema(sub))
+		}
+	}
+	return result
+}
+
+func (s *schema32) GetContains() Schema {
+	if s.schema == nil || s.schema.Contains == nil {
+		return NilSchema{}
+	}
+	return s.r.schema(s.schema.Contains)
+}
+
+func (s *schema32) GetDependentSchemas() map[string]Schema {
+	if s.schema == nil || s.schema.DependentSchemas == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for name, sub := range s.schema.DependentSchemas {
+		if sub != nil {
+			result[name] = s.r.schema(sub)
+		}
+	}
+	return result
+}
+
+func (s *schema32) GetDependentRequired() map[string][]string {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.DependentRequired
+}
+
+func (s *schema32) IsBooleanSchema() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.IsBooleanSchema()
+}
+
+func (s *schema32) GetBooleanValue() *bool {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.BooleanValue()
+}
+
+func (s *schema32) GetExtensions() map[string]any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Extensions
+}
+
+func (s *schema32) GetDiscriminator() Discriminator {
+	if s.schema == nil || s.schema.Discriminator == nil {
+		return nil
+	}
+	return BaseDiscriminator{
+		PropertyName: s.schema.Discriminator.PropertyName,
+		Mapping:      s.schema.Discriminator.Mapping,
+	}
+}
+
+func (s *schema32) GetXML() XML {
+	if s.schema == nil || s.schema.XML == nil {
+		return nil
+	}
+	return BaseXML{
+		Name:      s.schema.XML.Name,
+		Namespace: s.schema.XML.Namespace,
+		Prefix:    s.schema.XML.Prefix,
+		Attribute: s.schema.XML.Attribute,
+		Wrapped:   s.schema.XML.Wrapped,
+	}
+}
+
+func (s *schema32) GetExternalDocs() ExternalDocumentation {
+	if s.schema == nil || s.schema.ExternalDocs == nil {
+		return nil
+	}
+	return BaseExternalDocs{
+		Description: s.schema.ExternalDocs.Description,
+		URL:         s.schema.ExternalDocs.URL,
+	}
+}
+
+func (s *schema32) GetExample() any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Example
+}
+
+func (s *schema32) GetDefault() any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Default
+}
+
+func (s *schema32) GetDeprecated() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.Deprecated
+}
+
+func (s *schema32) GetReadOnly() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.ReadOnly
+}
+
+func (s *schema32) GetWriteOnly() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.WriteOnly
+}
+
+func (s *schema32) GetTitle() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Title
+}
+
+func (s *schema32) GetEnum() []any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Enum
+}
+
+func (s *schema32) GetConst() any {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.Const
+}
+
+func (s *schema32) GetMinimum() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	bound, _ := exclusiveBound(s.schema.Minimum, s.schema.ExclusiveMinimum, true)
+	return bound
+}
+
+func (s *schema32) GetMaximum() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	bound, _ := exclusiveBound(s.schema.Maximum, s.schema.ExclusiveMaximum, false)
+	return bound
+}
+
+func (s *schema32) GetExclusiveMinimum() bool {
+	if s.schema == nil {
+		return false
+	}
+	_, exclusive := exclusiveBound(s.schema.Minimum, s.schema.ExclusiveMinimum, true)
+	return exclusive
+}
+
+func (s *schema32) GetExclusiveMaximum() bool {
+	if s.schema == nil {
+		return false
+	}
+	_, exclusive := exclusiveBound(s.schema.Maximum, s.schema.ExclusiveMaximum, false)
+	return exclusive
+}
+
+func (s *schema32) GetMinLength() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MinLength
+}
+
+func (s *schema32) GetMaxLength() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MaxLength
+}
+
+func (s *schema32) GetPattern() string {
+	if s.schema == nil {
+		return ""
+	}
+	return s.schema.Pattern
+}
+
+func (s *schema32) GetMinItems() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MinItems
+}
+
+func (s *schema32) GetMaxItems() *int {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MaxItems
+}
+
+func (s *schema32) GetMultipleOf() *float64 {
+	if s.schema == nil {
+		return nil
+	}
+	return s.schema.MultipleOf
+}
+
+func (s *schema32) GetUniqueItems() bool {
+	if s.schema == nil {
+		return false
+	}
+	return s.schema.UniqueItems
+}
+
+// securityScheme32 wraps OpenAPI 3.2 SecurityScheme
+type securityScheme32 struct {
+	scheme *oa32.SecurityScheme
+}
+
+func (s *securityScheme32) GetType() string {
+	if s.scheme == nil {
+		return ""
+	}
+	return s.scheme.Type
+}
+
+func (s *securityScheme32) GetName() string {
+	if s.scheme == nil {
+		return ""
+	}
+	return s.scheme.Name
+}
+
+func (s *securityScheme32) GetIn() string {
+	if s.scheme == nil {
+		return ""
+	}
+	return s.scheme.In
+}
+
+func (s *securityScheme32) GetScheme() string {
+	if s.scheme == nil {
+		return ""
+	}
+	return s.scheme.Scheme
+}
+
+func (s *securityScheme32) GetDescription() string {
+	if s.scheme == nil {
+		return ""
+	}
+	return s.scheme.Description
+}
+
+func (s *securityScheme32) GetFlow() string {
+	// OpenAPI 3.2 uses flows object, not a single flow string
+	// Return the first available flow type
+	if s.scheme == nil || s.scheme.Flows == nil {
+		return ""
+	}
+	if s.scheme.Flows.Implicit != nil {
+		return "implicit"
+	}
+	if s.scheme.Flows.Password != nil {
+		return "password"
+	}
+	if s.scheme.Flows.ClientCredentials != nil {
+		return "clientCredentials"
+	}
+	if s.scheme.Flows.AuthorizationCode != nil {
+		return "authorizationCode"
+	}
+	return ""
+}
+
+func (s *securityScheme32) GetAuthorizationURL() string {
+	if s.scheme == nil || s.scheme.Flows == nil {
+		return ""
+	}
+	if s.scheme.Flows.Implicit != nil {
+		return s.scheme.Flows.Implicit.AuthorizationUrl
+	}
+	if s.scheme.Flows.AuthorizationCode != nil {
+		return s.scheme.Flows.AuthorizationCode.AuthorizationUrl
+	}
+	return ""
+}
+
+func (s *securityScheme32) GetTokenURL() string {
+	if s.scheme == nil || s.scheme.Flows == nil {
+		return ""
+	}
+	if s.scheme.Flows.Password != nil {
+		return s.scheme.Flows.Password.TokenUrl
+	}
+	if s.scheme.Flows.ClientCredentials != nil {
+		return s.scheme.Flows.ClientCredentials.TokenUrl
+	}
+	if s.scheme.Flows.AuthorizationCode != nil {
+		return s.scheme.Flows.AuthorizationCode.TokenUrl
+	}
+	return ""
+}
+
+func (s *securityScheme32) GetScopes() map[string]string {
+	if s.scheme == nil || s.scheme.Flows == nil {
+		return nil
+	}
+	if s.scheme.Flows.Implicit != nil && s.scheme.Flows.Implicit.Scopes != nil {
+		return s.scheme.Flows.Implicit.Scopes
+	}
+	if s.scheme.Flows.Password != nil && s.scheme.Flows.Password.Scopes != nil {
+		return s.scheme.Flows.Password.Scopes
+	}
+	if s.scheme.Flows.ClientCredentials != nil && s.scheme.Flows.ClientCredentials.Scopes != nil {
+		return s.scheme.Flows.ClientCredentials.Scopes
+	}
+	if s.scheme.Flows.AuthorizationCode != nil && s.scheme.Flows.AuthorizationCode.Scopes != nil {
+		return s.scheme.Flows.AuthorizationCode.Scopes
+	}
+	return nil
+}
+
+func (s *securityScheme32) GetExtensions() map[string]any {
+	if s.scheme == nil {
+		return nil
+	}
+	return s.scheme.Extensions
+}
+
+// components32 wraps OpenAPI 3.2 Components
+type components32 struct {
+	components *oa32.Components
+	r          *resolver32
+}
+
+func (c *components32) GetSchemas() map[string]Schema {
+	if c.components.Schemas == nil {
+		return nil
+	}
+	result := make(map[string]Schema)
+	for name, schema := range c.components.Schemas {
+		if schema != nil {
+			result[name] = c.r.schema(schema)
+		}
+	}
+	return result
+}
+
+func (c *components32) GetResponses() map[string]Response {
+	if c.components.Responses == nil {
+		return nil
+	}
+	result := make(map[string]Response)
+	for name, resp := range c.components.Responses {
+		if resp != nil {
+			result[name] = c.r.response(resp)
+		}
+	}
+	return result
+}
+
+func (c *components32) GetParameters() map[string]Parameter {
+	if c.components.Parameters == nil {
+		return nil
+	}
+	result := make(map[string]Parameter)
+	for name, param := range c.components.Parameters {
+		if param != nil {
+			result[name] = c.r.parameter(param)
+		}
+	}
+	return result
+}
+
+func (c *components32) GetRequestBodies() map[string]RequestBody {
+	if c.components.RequestBodies == nil {
+		return nil
+	}
+	result := make(map[string]RequestBody)
+	for name, rb := range c.components.RequestBodies {
+		if rb != nil {
+			result[name] = c.r.requestBody(rb)
+		}
+	}
+	return result
+}
+
+func (c *components32) GetHeaders() map[string]Header {
+	if c.components.Headers == nil {
+		return nil
+	}
+	result := make(map[string]Header)
+	for name, header := range c.components.Headers {
+		if header != nil {
+			result[name] = &header32{header: header, r: c.r}
+		}
+	}
+	return result
+}
+
+func (c *components32) GetExamples() map[string]Example {
+	if c.components.Examples == nil {
+		return nil
+	}
+	result := make(map[string]Example)
+	for name, ex := range c.components.Examples {
+		if ex != nil {
+			result[name] = &example32{example: ex}
+		}
+	}
+	return result
+}
+
+func (c *components32) GetLinks() map[string]Link {
+	if c.components.Links == nil {
+		return nil
+	}
+	result := make(map[string]Link)
+	for name, link := range c.components.Links {
+		if link != nil {
+			result[name] = &link32{link: link}
+		}
+	}
+	return result
+}
+
+func (c *components32) GetCallbacks() map[string]Callback {
+	if c.components.Callbacks == nil {
+		return nil
+	}
+	result := make(map[string]Callback)
+	for name, cb := range c.components.Callbacks {
+		if cb != nil {
+			result[name] = &callback32{callback: cb, r: c.r}
+		}
+	}
+	return result
+}
+
+func (c *components32) GetPathItems() map[string]PathItem {
+	if c.components.PathItems == nil {
+		return nil
+	}
+	result := make(map[string]PathItem)
+	for name, item := range c.components.PathItems {
+		if item != nil {
+			result[name] = c.r.pathItem(item)
+		}
+	}
+	return result
+}
+
+func (c *components32) GetExtensions() map[string]any {
+	return c.components.Extensions
+}
+
+// example32 wraps OpenAPI 3.2 Example
+type example32 struct {
+	example *oa32.Example
+}
+
+func (e *example32) HasRef() bool {
+	return e.example.IsReference()
+}
+
+func (e *example32) GetRef() string {
+	return e.example.Ref
+}
+
+func (e *example32) GetSummary() string {
+	return e.example.Summary
+}
+
+func (e *example32) GetDescription() string {
+	return e.example.Description
+}
+
+func (e *example32) GetValue() any {
+	if e.example.Value == nil {
+		return e.example.DataValue
+	}
+	return e.example.Value
+}
+
+func (e *example32) GetExternalValue() string {
+	return e.example.ExternalValue
+}
+
+func (e *example32) GetExtensions() map[string]any {
+	return e.example.Extensions
+}
+
+// link32 wraps OpenAPI 3.2 Link
+type link32 struct {
+	link *oa32.Link
+}
+
+func (l *link32) HasRef() bool {
+	return l.link.IsReference()
+}
+
+func (l *link32) GetRef() string {
+	return l.link.Ref
+}
+
+func (l *link32) GetOperationRef() string {
+	return l.link.OperationRef
+}
+
+func (l *link32) GetOperationID() string {
+	return l.link.OperationId
+}
+
+func (l *link32) GetParameters() map[string]any {
+	if l.link.Parameters == nil {
+		return nil
+	}
+	result := make(map[string]any, len(l.link.Parameters))
+	for name, expr := range l.link.Parameters {
+		result[name] = expr
+	}
+	return result
+}
+
+func (l *link32) GetRequestBody() any {
+	return l.link.RequestBody
+}
+
+func (l *link32) GetDescription() string {
+	return l.link.Description
+}
+
+func (l *link32) GetExtensions() map[string]any {
+	return l.link.Extensions
+}
+
+// callback32 wraps OpenAPI 3.2 Callback
+type callback32 struct {
+	callback *oa32.Callback
+	r        *resolver32
+}
+
+func (c *callback32) HasRef() bool {
+	return c.callback.IsReference()
+}
+
+func (c *callback32) GetRef() string {
+	return c.callback.Ref
+}
+
+func (c *callback32) GetPathItems() map[string]PathItem {
+	if c.callback.Paths == nil {
+		return nil
+	}
+	result := make(map[string]PathItem)
+	for expr, item := range c.callback.Paths {
+		if item != nil {
+			result[expr] = c.r.pathItem(item)
+		}
+	}
+	return result
+}
+
+func (c *callback32) GetExtensions() map[string]any {
+	return c.callback.Extensions
+}
+
+// externalDocs32 converts external documentation, keeping nil as nil
+func externalDocs32(docs *oa32.ExternalDocumentation) ExternalDocumentation {
+	if docs == nil {
+		return nil
+	}
+	return BaseExternalDocs{Description: docs.Description, URL: docs.URL}
+}
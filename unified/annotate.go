@@ -0,0 +1,67 @@
+// Package unified provides the bulk annotation of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Annotation sets extensions on the operations its selector picks, the way
+// Filter picks the operations it keeps. An empty selector picks every
+// operation.
+type Annotation struct {
+	Select     FilterOptions
+	Extensions map[string]any
+}
+
+// all reports whether the selector of the annotation is empty
+func (a Annotation) all() bool {
+	return len(a.Select.Tags) == 0 && len(a.Select.Paths) == 0 && len(a.Select.OperationIDs) == 0
+}
+
+// Annotate sets, in place, the extensions of the annotations on the
+// operations of the paths and webhooks they select, replacing the values
+// the operations have. The annotations apply in order, so a later one wins
+// on an extension both set. It returns the number of operations changed.
+func Annotate(doc Document, annotations ...Annotation) (int, error) {
+	if doc == nil {
+		return 0, fmt.Errorf("no document to annotate")
+	}
+	for _, a := range annotations {
+		for name := range a.Extensions {
+			if err := checkExtension(name); err != nil {
+				return 0, err
+			}
+		}
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for _, section := range []string{"paths", "webhooks"} {
+		items := object(root, false, section)
+		for _, path := range slices.Sorted(maps.Keys(items)) {
+			item, _ := items[path].(map[string]any)
+			for _, o := range operations(item) {
+				selected := false
+				for _, a := range annotations {
+					if a.all() || a.Select.selects(path, o.op) {
+						maps.Copy(o.op, a.Extensions)
+						selected = selected || len(a.Extensions) > 0
+					}
+				}
+				if selected {
+					changed++
+				}
+			}
+		}
+	}
+	if changed == 0 {
+		return 0, nil
+	}
+	return changed, setDocumentObject(doc, root)
+}
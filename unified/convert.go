@@ -0,0 +1,201 @@
+// Package unified provides version conversion for unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/genelet/oas/convert"
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+)
+
+// parseTargetVersion splits a ConvertTo target into its major.minor line
+// ("2.0", "3.0", "3.1" or "3.2") and the full version to write, if one was given
+func parseTargetVersion(version string) (line, full string, err error) {
+	switch {
+	case version == "2.0":
+		return "2.0", "", nil
+	case version == "3.0" || version == "3.1" || version == "3.2":
+		return version, "", nil
+	case strings.HasPrefix(version, "3.0.") || strings.HasPrefix(version, "3.1.") || strings.HasPrefix(version, "3.2."):
+		return version[:3], version, nil
+	default:
+		return "", "", fmt.Errorf("unsupported target version: %q", version)
+	}
+}
+
+// errConvert32 is returned for conversions into 3.2
+func errConvert32(from, to string) error {
+	return fmt.Errorf("conversion from %s to %s is not supported", from, to)
+}
+
+// ConvertTo converts the document to the target version.
+// Converting to 2.0 returns a new adapter over the same document.
+func (d *Document20) ConvertTo(version string) (Document, *convert.ConversionReport, error) {
+	line, full, err := parseTargetVersion(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := &convert.UpgradeOptions{OpenAPIVersion: full}
+	switch line {
+	case "3.0":
+		doc, report := convert.Upgrade20To30(d.doc, opts)
+		return NewDocument30(doc), report, nil
+	case "3.1":
+		doc, report := convert.Upgrade20To31(d.doc, opts)
+		return NewDocument31(doc), report, nil
+	case "3.2":
+		return nil, nil, errConvert32("2.0", version)
+	}
+	return NewDocument20(d.doc), &convert.ConversionReport{}, nil
+}
+
+// ConvertTo converts the document to the target version.
+// Converting to 3.0 returns a new adapter over the same document, unless a
+// different full version is requested.
+func (d *Document30) ConvertTo(version string) (Document, *convert.ConversionReport, error) {
+	line, full, err := parseTargetVersion(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch line {
+	case "2.0":
+		doc, report := convert.Downgrade30To20(d.doc)
+		return NewDocument20(doc), report, nil
+	case "3.1":
+		doc, report := convert.Upgrade30To31(d.doc, &convert.UpgradeOptions{OpenAPIVersion: full})
+		return NewDocument31(doc), report, nil
+	case "3.2":
+		return nil, nil, errConvert32("3.0", version)
+	}
+	if full != "" && d.doc != nil && full != d.doc.OpenAPI {
+		doc := *d.doc
+		doc.OpenAPI = full
+		return NewDocument30(&doc), &convert.ConversionReport{}, nil
+	}
+	return NewDocument30(d.doc), &convert.ConversionReport{}, nil
+}
+
+// ConvertTo converts the document to the target version.
+// Converting to 3.1 returns a new adapter over the same document, unless a
+// different full version is requested.
+func (d *Document31) ConvertTo(version string) (Document, *convert.ConversionReport, error) {
+	line, full, err := parseTargetVersion(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch line {
+	case "2.0":
+		doc, report := convert.Downgrade31To20(d.doc, nil)
+		return NewDocument20(doc), report, nil
+	case "3.0":
+		doc, report := convert.Downgrade31To30(d.doc, &convert.DowngradeOptions{OpenAPIVersion: full})
+		return NewDocument30(doc), report, nil
+	case "3.2":
+		return nil, nil, errConvert32("3.1", version)
+	}
+	if full != "" && d.doc != nil && full != d.doc.OpenAPI {
+		doc := *d.doc
+		doc.OpenAPI = full
+		return NewDocument31(&doc), &convert.ConversionReport{}, nil
+	}
+	return NewDocument31(d.doc), &convert.ConversionReport{}, nil
+}
+
+// ConvertTo converts the document to the target version. Conversions to 3.0
+// and 2.0 go through 3.1, and their report lists the issues of both steps.
+// Converting to 3.2 returns a new adapter over the same document, unless a
+// different full version is requested.
+func (d *Document32) ConvertTo(version string) (Document, *convert.ConversionReport, error) {
+	line, full, err := parseTargetVersion(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if line == "3.2" {
+		if full != "" && d.doc != nil && full != d.doc.OpenAPI {
+			doc := *d.doc
+			doc.OpenAPI = full
+			return NewDocument32(&doc), &convert.ConversionReport{}, nil
+		}
+		return NewDocument32(d.doc), &convert.ConversionReport{}, nil
+	}
+	opts := &convert.DowngradeOptions{}
+	if line == "3.1" {
+		opts.OpenAPIVersion = full
+	}
+	doc, report := convert.Downgrade32To31(d.doc, opts)
+	if doc == nil {
+		return nil, report, fmt.Errorf("conversion from 3.2 to %s failed", version)
+	}
+	switch line {
+	case "2.0":
+		out, more := convert.Downgrade31To20(doc, nil)
+		report.Issues = append(report.Issues, more.Issues...)
+		return NewDocument20(out), report, nil
+	case "3.0":
+		out, more := convert.Downgrade31To30(doc, &convert.DowngradeOptions{OpenAPIVersion: full})
+		report.Issues = append(report.Issues, more.Issues...)
+		return NewDocument30(out), report, nil
+	}
+	return NewDocument31(doc), report, nil
+}
+
+// To20 materializes any document as a Swagger 2.0 struct, converting it with
+// ConvertTo. A 2.0 document is returned as is, not copied.
+func To20(doc Document) (*oa2.Swagger, *convert.ConversionReport, error) {
+	converted, report, err := convertDocument(doc, "2.0")
+	if err != nil {
+		return nil, nil, err
+	}
+	d, ok := converted.(*Document20)
+	if !ok {
+		return nil, nil, errMaterialize(converted, "2.0")
+	}
+	return d.GetRaw(), report, nil
+}
+
+// To30 materializes any document as an OpenAPI 3.0 struct, converting it with
+// ConvertTo. A 3.0 document is returned as is, not copied.
+func To30(doc Document) (*oa3.OpenAPI, *convert.ConversionReport, error) {
+	converted, report, err := convertDocument(doc, "3.0")
+	if err != nil {
+		return nil, nil, err
+	}
+	d, ok := converted.(*Document30)
+	if !ok {
+		return nil, nil, errMaterialize(converted, "3.0")
+	}
+	return d.GetRaw(), report, nil
+}
+
+// To31 materializes any document as an OpenAPI 3.1 struct, converting it with
+// ConvertTo. A 3.1 document is returned as is, not copied.
+func To31(doc Document) (*oa31.OpenAPI, *convert.ConversionReport, error) {
+	converted, report, err := convertDocument(doc, "3.1")
+	if err != nil {
+		return nil, nil, err
+	}
+	d, ok := converted.(*Document31)
+	if !ok {
+		return nil, nil, errMaterialize(converted, "3.1")
+	}
+	return d.GetRaw(), report, nil
+}
+
+// convertDocument converts doc to the target version line
+func convertDocument(doc Document, version string) (Document, *convert.ConversionReport, error) {
+	if doc == nil {
+		return nil, nil, fmt.Errorf("no document to convert to %s", version)
+	}
+	return doc.ConvertTo(version)
+}
+
+// errMaterialize is returned when ConvertTo did not return an adapter of this
+// package
+func errMaterialize(doc Document, version string) error {
+	return fmt.Errorf("cannot materialize %T as %s", doc, version)
+}
@@ -0,0 +1,381 @@
+// Package unified provides the deduplication of the schemas of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SchemaDuplicates is a set of structurally identical schemas: component
+// schemas, and inline object schemas with properties
+type SchemaDuplicates struct {
+	// Schema is the reference of the component schema the duplicates are
+	// consolidated into. FindDuplicateSchemas leaves it empty for inline
+	// schemas no component matches.
+	Schema string
+
+	// Duplicates are the JSON pointers of the schemas that are replaced
+	// with a reference to Schema, or removed for components
+	Duplicates []string
+}
+
+// FindDuplicateSchemas returns the sets of structurally identical schemas
+// of a document, in pointer order. Schemas are identical when Fingerprint
+// finds them so; only the alphabetically first of identical components is
+// kept, and the others join the inline schemas as duplicates. Alternatives
+// of the same oneOf, anyOf or discriminator mapping are not duplicates.
+func FindDuplicateSchemas(doc Document) ([]SchemaDuplicates, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to deduplicate")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	d := newDeduper(doc, root)
+	groups, err := d.groups(doc)
+	if err != nil {
+		return nil, err
+	}
+	var result []SchemaDuplicates
+	for _, g := range groups {
+		result = append(result, SchemaDuplicates{Schema: g.schema, Duplicates: g.duplicates})
+	}
+	return result, nil
+}
+
+// DedupeSchemas consolidates, in place, the structurally identical schemas
+// of a document: duplicate components are removed, and inline duplicates
+// replaced, by a reference to the one component kept. Inline schemas no
+// component matches become a new component, named after their title or
+// their place in the document. Identical branches of a oneOf, anyOf or
+// discriminator mapping are kept apart, as one branch could no longer be told
+// from the other. As consolidation can make more schemas identical, it
+// repeats until none are. It returns what it consolidated.
+func DedupeSchemas(doc Document) ([]SchemaDuplicates, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to deduplicate")
+	}
+	var result []SchemaDuplicates
+	for {
+		root, err := documentObject(doc)
+		if err != nil {
+			return nil, err
+		}
+		d := newDeduper(doc, root)
+		groups, err := d.groups(doc)
+		if err != nil {
+			return nil, err
+		}
+		if len(groups) == 0 {
+			return result, nil
+		}
+		result = append(result, d.consolidate(groups)...)
+		if err := setDocumentObject(doc, root); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// deduper finds and consolidates the duplicate schemas of the generic JSON
+// form of a document
+type deduper struct {
+	root    map[string]any
+	section []string // the tokens of the component schemas
+	ref     string   // the reference prefix of the component schemas
+}
+
+// schemaGroup is a set of identical schemas
+type schemaGroup struct {
+	schema     string // the reference of the component kept, if any
+	duplicates []string
+	size       int // the length of the JSON form of the schemas
+}
+
+func newDeduper(doc Document, root map[string]any) *deduper {
+	if strings.HasPrefix(doc.Version(), "2.") {
+		return &deduper{root: root, section: []string{"definitions"}, ref: "#/definitions"}
+	}
+	return &deduper{root: root, section: []string{"components", "schemas"}, ref: "#/components/schemas"}
+}
+
+// groups returns the sets of identical schemas of the document, in the
+// pointer order of their first schema
+func (d *deduper) groups(doc Document) ([]schemaGroup, error) {
+	components := pointer("", d.section...)
+	type member struct {
+		ptr       string
+		component string // the name, for a component schema
+	}
+	byPrint := make(map[string][]member)
+	sizes := make(map[string]int)
+	var prints []string
+	var err error
+	seen := make(map[string]bool)
+	Walk(doc, Visitor{Schema: func(ptr string, _ Schema) {
+		if err != nil || seen[ptr] {
+			return
+		}
+		seen[ptr] = true
+		tokens, _ := pointerTokens(ptr)
+		value, _ := pointerValue(d.root, tokens)
+		schema, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		var m member
+		if name, ok := strings.CutPrefix(ptr, components+"/"); ok && !strings.Contains(name, "/") {
+			m = member{ptr: ptr, component: tokens[len(tokens)-1]}
+		} else if properties, _ := schema["properties"].(map[string]any); len(properties) > 0 {
+			m = member{ptr: ptr}
+		} else {
+			return
+		}
+		key, e := fingerprint(schema)
+		if e != nil {
+			err = e
+			return
+		}
+		if _, ok := byPrint[key]; !ok {
+			prints = append(prints, key)
+			text, _ := json.Marshal(schema)
+			sizes[key] = len(text)
+		}
+		byPrint[key] = append(byPrint[key], m)
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	sets := d.siblings()
+	var groups []schemaGroup
+	for _, key := range prints {
+		members := byPrint[key]
+		if len(members) < 2 {
+			continue
+		}
+		// Identical branches of a oneOf, anyOf or discriminator mapping
+		// stay apart, as merging them leaves branches no instance can tell
+		// apart
+		apart := make(map[string]bool)
+		for _, set := range sets {
+			in := slices.DeleteFunc(slices.Clone(set), func(ptr string) bool {
+				return !slices.ContainsFunc(members, func(m member) bool { return m.ptr == ptr })
+			})
+			if len(in) > 1 {
+				for _, ptr := range in {
+					apart[ptr] = true
+				}
+			}
+		}
+		g := schemaGroup{size: sizes[key]}
+		var names []string
+		for _, m := range members {
+			if m.component != "" {
+				names = append(names, m.component)
+			}
+		}
+		slices.Sort(names)
+		if len(names) > 0 {
+			g.schema = pointer(d.ref, names[0])
+		}
+		for _, m := range members {
+			if (len(names) == 0 || m.component != names[0]) && !apart[m.ptr] {
+				g.duplicates = append(g.duplicates, m.ptr)
+			}
+		}
+		if len(g.duplicates) == 0 || g.schema == "" && len(g.duplicates) < 2 {
+			continue
+		}
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+// siblings returns the sets of schemas that are alternatives of each other:
+// the branches of each oneOf and anyOf, and the targets of each
+// discriminator mapping, as the pointers of their component schemas or of
+// the inline branches
+func (d *deduper) siblings() [][]string {
+	var sets [][]string
+	target := func(ref string) string {
+		if !strings.HasPrefix(ref, "#") {
+			ref = pointer(d.ref, ref)
+		}
+		return strings.TrimPrefix(ref, "#")
+	}
+	var visit func(v any, ptr string)
+	visit = func(v any, ptr string) {
+		switch x := v.(type) {
+		case map[string]any:
+			for _, keyword := range []string{"oneOf", "anyOf"} {
+				branches, _ := x[keyword].([]any)
+				var set []string
+				for i, branch := range branches {
+					if ref, ok := branch.(map[string]any)["$ref"].(string); ok {
+						set = append(set, target(ref))
+					} else {
+						set = append(set, pointer(ptr, keyword, strconv.Itoa(i)))
+					}
+				}
+				if len(set) > 1 {
+					sets = append(sets, set)
+				}
+			}
+			if discriminator, ok := x["discriminator"].(map[string]any); ok {
+				mapping, _ := discriminator["mapping"].(map[string]any)
+				var set []string
+				for _, value := range mapping {
+					if ref, ok := value.(string); ok {
+						set = append(set, target(ref))
+					}
+				}
+				if len(set) > 1 {
+					sets = append(sets, set)
+				}
+			}
+			for key, value := range x {
+				visit(value, pointer(ptr, key))
+			}
+		case []any:
+			for i, value := range x {
+				visit(value, pointer(ptr, strconv.Itoa(i)))
+			}
+		}
+	}
+	visit(d.root, "")
+	return sets
+}
+
+// consolidate consolidates the groups whose schemas are all still in place,
+// the largest first, and returns them
+func (d *deduper) consolidate(groups []schemaGroup) []SchemaDuplicates {
+	slices.SortStableFunc(groups, func(a, b schemaGroup) int { return b.size - a.size })
+	var replaced []string
+	within := func(ptr string) bool {
+		return slices.ContainsFunc(replaced, func(r string) bool {
+			return ptr == r || strings.HasPrefix(ptr, r+"/") || strings.HasPrefix(r, ptr+"/")
+		})
+	}
+	section := object(d.root, true, d.section...)
+	renamed := make(map[string]string)
+	bare := make(map[string]string)
+	var result []SchemaDuplicates
+	for _, g := range groups {
+		if slices.ContainsFunc(g.duplicates, within) || (g.schema != "" && within(strings.TrimPrefix(g.schema, "#"))) {
+			continue
+		}
+		if g.schema == "" {
+			// A schema within a component gives the most telling name
+			first := g.duplicates[0]
+			if i := slices.IndexFunc(g.duplicates, func(ptr string) bool {
+				return strings.HasPrefix(ptr, pointer("", d.section...)+"/")
+			}); i >= 0 {
+				first = g.duplicates[i]
+			}
+			tokens, _ := pointerTokens(first)
+			value, _ := pointerValue(d.root, tokens)
+			name := d.name(tokens, value)
+			section[name] = copyValue(value)
+			g.schema = pointer(d.ref, name)
+		}
+		kept, _ := strings.CutPrefix(g.schema, d.ref+"/")
+		for _, ptr := range g.duplicates {
+			replaced = append(replaced, ptr)
+			tokens, _ := pointerTokens(ptr)
+			if len(tokens) == len(d.section)+1 && slices.Equal(tokens[:len(d.section)], d.section) {
+				delete(section, tokens[len(tokens)-1])
+				renamed["#"+ptr] = g.schema
+				bare[tokens[len(tokens)-1]] = kept
+				continue
+			}
+			patchAt(d.root, tokens, func(container any, token string) (any, error) {
+				if x, ok := container.(map[string]any); ok {
+					x[token] = map[string]any{"$ref": g.schema}
+				} else {
+					i, _ := strconv.Atoi(token)
+					container.([]any)[i] = map[string]any{"$ref": g.schema}
+				}
+				return container, nil
+			})
+		}
+		result = append(result, SchemaDuplicates{Schema: g.schema, Duplicates: g.duplicates})
+	}
+	if len(renamed) > 0 {
+		m := &merger{refs: renamed}
+		m.rewrite(d.root, false)
+		renameMappings(d.root, bare, false)
+	}
+	return result
+}
+
+// renameMappings points the discriminator mapping values that are bare
+// schema names at the renamed schemas. named tells whether v is a map keyed
+// by names.
+func renameMappings(v any, bare map[string]string, named bool) {
+	switch x := v.(type) {
+	case map[string]any:
+		for key, value := range x {
+			if named {
+				renameMappings(value, bare, false)
+				continue
+			}
+			if mapping, ok := value.(map[string]any); ok && key == "mapping" {
+				for name, target := range mapping {
+					if s, ok := target.(string); ok && bare[s] != "" {
+						mapping[name] = bare[s]
+					}
+				}
+			}
+			if !literalKeywords[key] {
+				renameMappings(value, bare, namedKeywords[key])
+			}
+		}
+	case []any:
+		for _, value := range x {
+			renameMappings(value, bare, false)
+		}
+	}
+}
+
+// name returns a free component name for an inline schema: its title, or
+// else the component and properties it is nested in
+func (d *deduper) name(tokens []string, value any) string {
+	var words []string
+	if title, ok := value.(map[string]any)["title"].(string); ok {
+		words = identifierWords(title)
+	}
+	if len(words) == 0 {
+		rest := tokens
+		if len(tokens) > len(d.section) && slices.Equal(tokens[:len(d.section)], d.section) {
+			words = identifierWords(tokens[len(d.section)])
+			rest = tokens[len(d.section)+1:]
+		}
+		for i := 0; i < len(rest); i++ {
+			switch {
+			case rest[i] == "properties" && i+1 < len(rest):
+				i++
+				words = append(words, identifierWords(rest[i])...)
+			case rest[i] == "items":
+				words = append(words, "Item")
+			}
+		}
+	}
+	if len(words) == 0 {
+		words = []string{"Schema"}
+	}
+	base := []rune(camelCase(words))
+	base[0] = unicode.ToUpper(base[0])
+	name := string(base)
+	section := object(d.root, false, d.section...)
+	for n := 2; section[name] != nil; n++ {
+		name = string(base) + strconv.Itoa(n)
+	}
+	return name
+}
@@ -0,0 +1,224 @@
+// Package unified provides the deprecation of parts of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// DeprecationOptions selects what Deprecate flags: the operations with one
+// of the Tags, on a path under one of the PathPrefixes, or with the
+// Extension, and the component schemas with the Extension. An extension
+// set to false does not select.
+type DeprecationOptions struct {
+	Tags         []string
+	PathPrefixes []string // "/v1" selects /v1 and /v1/pets, not /v10
+	Extension    string
+
+	// Sunset, when set, is the date the deprecated parts go away, written
+	// to them as x-sunset
+	Sunset string
+
+	// SunsetHeader also describes the Sunset header (RFC 8594) on the
+	// responses of the deprecated operations
+	SunsetHeader bool
+}
+
+// DeprecationReport lists the JSON pointers of what Deprecate flagged,
+// including what was already deprecated, in document order
+type DeprecationReport struct {
+	Operations []string
+	Schemas    []string // empty for 2.0, whose schemas cannot be deprecated
+}
+
+// Deprecate marks, in place, the operations of the paths and webhooks, and
+// the component schemas, the options select as deprecated, and reports them.
+func Deprecate(doc Document, opts DeprecationOptions) (*DeprecationReport, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to deprecate in")
+	}
+	if opts.Extension != "" {
+		if err := checkExtension(opts.Extension); err != nil {
+			return nil, err
+		}
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	v20 := strings.HasPrefix(doc.Version(), "2.")
+	report := &DeprecationReport{}
+	for _, section := range []string{"paths", "webhooks"} {
+		items := object(root, false, section)
+		for _, path := range slices.Sorted(maps.Keys(items)) {
+			item, _ := items[path].(map[string]any)
+			for _, o := range operations(item) {
+				if !opts.selects(section == "paths", path, o.op) {
+					continue
+				}
+				opts.deprecate(o.op)
+				if opts.SunsetHeader {
+					sunsetHeaders(o.op, v20)
+				}
+				tokens := []string{section, path, o.method}
+				if !slices.Contains(pathItemMethods, o.method) {
+					tokens = []string{section, path, "additionalOperations", o.method}
+				}
+				report.Operations = append(report.Operations, pointer("", tokens...))
+			}
+		}
+	}
+	if !v20 && opts.Extension != "" {
+		schemas := object(root, false, "components", "schemas")
+		for _, name := range slices.Sorted(maps.Keys(schemas)) {
+			schema, _ := schemas[name].(map[string]any)
+			if marked(schema, opts.Extension) {
+				opts.deprecate(schema)
+				report.Schemas = append(report.Schemas, pointer("", "components", "schemas", name))
+			}
+		}
+	}
+	if report.Operations == nil && report.Schemas == nil {
+		return report, nil
+	}
+	return report, setDocumentObject(doc, root)
+}
+
+// selects reports whether the options select an operation on a path, or on
+// a webhook, to which path prefixes do not apply
+func (o DeprecationOptions) selects(onPath bool, path string, op map[string]any) bool {
+	if o.Extension != "" && marked(op, o.Extension) {
+		return true
+	}
+	tags, _ := op["tags"].([]any)
+	for _, tag := range tags {
+		if name, ok := tag.(string); ok && slices.Contains(o.Tags, name) {
+			return true
+		}
+	}
+	return onPath && slices.ContainsFunc(o.PathPrefixes, func(prefix string) bool {
+		prefix = strings.TrimSuffix(prefix, "/")
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	})
+}
+
+// deprecate flags an operation or a schema as deprecated
+func (o DeprecationOptions) deprecate(target map[string]any) {
+	target["deprecated"] = true
+	if o.Sunset != "" {
+		target["x-sunset"] = o.Sunset
+	}
+}
+
+// marked reports whether an object has an extension set to other than false
+func marked(target map[string]any, extension string) bool {
+	value, ok := target[extension]
+	return ok && value != false
+}
+
+// The kinds of a DeprecatedElement
+const (
+	DeprecatedOperation = "operation"
+	DeprecatedParameter = "parameter"
+	DeprecatedSchema    = "schema" // a schema, or a property
+)
+
+// DeprecatedElement is a deprecated part of a document, with the dates of
+// its x-sunset and x-deprecated-at extensions
+type DeprecatedElement struct {
+	Kind    string `json:"kind"`
+	Pointer string `json:"pointer"`
+
+	// Name is the operationId of an operation, or its method and path, the
+	// name of a parameter, and the name of a component schema or a property
+	Name string `json:"name,omitempty"`
+
+	Sunset       string `json:"sunset,omitempty"`
+	DeprecatedAt string `json:"deprecatedAt,omitempty"`
+}
+
+// Deprecations lists the deprecated operations, parameters and schemas of
+// a document, where they are defined, in the order Walk visits them. An
+// x-deprecated extension set to true, the way 2.0 parameters and schemas are
+// marked, counts as deprecated too.
+func Deprecations(doc Document) []DeprecatedElement {
+	var result []DeprecatedElement
+	seen := make(map[string]bool) // 2.0 parameter schemas share the pointer of their parameter
+	add := func(kind, ptr, name string, deprecated bool, extensions map[string]any) {
+		if !deprecated && extensions["x-deprecated"] != true || seen[ptr] {
+			return
+		}
+		seen[ptr] = true
+		result = append(result, DeprecatedElement{
+			Kind:         kind,
+			Pointer:      ptr,
+			Name:         name,
+			Sunset:       extensionText(extensions["x-sunset"]),
+			DeprecatedAt: extensionText(extensions["x-deprecated-at"]),
+		})
+	}
+	Walk(doc, Visitor{
+		Operation: func(ptr string, op Operation) {
+			name := op.GetOperationID()
+			if tokens, _ := pointerTokens(ptr); name == "" && len(tokens) >= 2 {
+				path, method := tokens[len(tokens)-2], tokens[len(tokens)-1]
+				if path == "additionalOperations" && len(tokens) >= 3 {
+					path = tokens[len(tokens)-3]
+				}
+				name = strings.ToUpper(method) + " " + path
+			}
+			add(DeprecatedOperation, ptr, name, op.GetDeprecated(), op.GetExtensions())
+		},
+		Parameter: func(ptr string, param Parameter) {
+			add(DeprecatedParameter, ptr, param.GetName(), param.GetDeprecated(), param.GetExtensions())
+		},
+		Schema: func(ptr string, schema Schema) {
+			var name string
+			if tokens, _ := pointerTokens(ptr); len(tokens) >= 2 {
+				switch tokens[len(tokens)-2] {
+				case "properties", "schemas", "definitions":
+					name = tokens[len(tokens)-1]
+				}
+			}
+			add(DeprecatedSchema, ptr, name, schema.GetDeprecated(), schema.GetExtensions())
+		},
+	})
+	return result
+}
+
+// extensionText returns the text of an extension value, such as a date
+func extensionText(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	return fmt.Sprint(value)
+}
+
+// sunsetHeaders describes the Sunset header on the responses of an
+// operation that are not references and do not describe it yet
+func sunsetHeaders(op map[string]any, v20 bool) {
+	header := map[string]any{
+		"description": "The date after which the operation is no longer available",
+		"schema":      map[string]any{"type": "string"},
+	}
+	if v20 {
+		header = map[string]any{"description": header["description"], "type": "string"}
+	}
+	for code, resp := range object(op, false, "responses") {
+		r, _ := resp.(map[string]any)
+		if r == nil || r["$ref"] != nil || strings.HasPrefix(code, "x-") {
+			continue
+		}
+		headers := object(r, true, "headers")
+		if _, ok := headers["Sunset"]; !ok {
+			headers["Sunset"] = copyValue(header)
+		}
+	}
+}
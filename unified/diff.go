@@ -0,0 +1,186 @@
+// Package unified provides semantic comparison of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// The kinds of a Difference
+const (
+	DiffAdded   = "added"   // the value is only in the second document
+	DiffRemoved = "removed" // the value is only in the first document
+	DiffChanged = "changed" // the documents have different values
+)
+
+// Difference is a value that differs between two documents, located by its
+// JSON pointer in their common 3.1 form
+type Difference struct {
+	Kind    string
+	Pointer string
+	A       any // the value in the first document, nil when added
+	B       any // the value in the second document, nil when removed
+}
+
+func (d Difference) String() string {
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("added %s: %s", d.Pointer, jsonText(d.B))
+	case DiffRemoved:
+		return fmt.Sprintf("removed %s: %s", d.Pointer, jsonText(d.A))
+	}
+	return fmt.Sprintf("changed %s: %s -> %s", d.Pointer, jsonText(d.A), jsonText(d.B))
+}
+
+// jsonText formats a value of a Difference
+func jsonText(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// Equal reports whether two documents, of any versions, describe the same
+// API. It is false when either cannot be compared; see Diff.
+func Equal(a, b Document) bool {
+	diffs, err := Diff(a, b)
+	return err == nil && len(diffs) == 0
+}
+
+// Diff compares two documents, of any versions, semantically. Both are
+// brought to the 3.1 line, so that 2.0 and 3.0 constructs compare equal to
+// their 3.1 counterparts, such as nullable and a "null" type; 3.2 documents
+// are compared as they are. Key order, the version fields, the order of type
+// and required lists and empty objects are ignored. The differences are in
+// pointer order.
+func Diff(a, b Document) ([]Difference, error) {
+	left, err := canonical(a)
+	if err != nil {
+		return nil, err
+	}
+	right, err := canonical(b)
+	if err != nil {
+		return nil, err
+	}
+	delete(left, "openapi")
+	delete(right, "openapi")
+	var diffs []Difference
+	compare("", normalize("", left), normalize("", right), &diffs)
+	return diffs, nil
+}
+
+// canonical returns the generic JSON form of a document on the 3.1 line
+func canonical(doc Document) (map[string]any, error) {
+	switch doc.(type) {
+	case *Document32:
+		return documentObject(doc)
+	case nil:
+		return nil, fmt.Errorf("no document to compare")
+	}
+	doc31, _, err := To31(doc)
+	if err != nil {
+		return nil, err
+	}
+	return documentObject(NewDocument31(doc31))
+}
+
+// normalize removes the representation differences of a generic JSON value:
+// it drops empty objects, writes a single type as a string and sorts type and
+// required lists
+func normalize(key string, v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(x))
+		for k, value := range x {
+			value = normalize(k, value)
+			if m, ok := value.(map[string]any); ok && len(m) == 0 {
+				continue
+			}
+			result[k] = value
+		}
+		return result
+	case []any:
+		result := make([]any, len(x))
+		for i, value := range x {
+			result[i] = normalize("", value)
+		}
+		if key != "type" && key != "required" {
+			return result
+		}
+		names := make([]string, 0, len(result))
+		for _, value := range result {
+			s, ok := value.(string)
+			if !ok {
+				return result
+			}
+			names = append(names, s)
+		}
+		if key == "type" && len(names) == 1 {
+			return names[0]
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			result[i] = name
+		}
+		return result
+	}
+	return v
+}
+
+// compare appends the differences between two generic JSON values
+func compare(ptr string, a, b any, diffs *[]Difference) {
+	switch x := a.(type) {
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := slices.Collect(maps.Keys(x))
+		for k := range y {
+			if _, ok := x[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			av, inA := x[k]
+			bv, inB := y[k]
+			switch {
+			case !inB:
+				*diffs = append(*diffs, Difference{Kind: DiffRemoved, Pointer: pointer(ptr, k), A: av})
+			case !inA:
+				*diffs = append(*diffs, Difference{Kind: DiffAdded, Pointer: pointer(ptr, k), B: bv})
+			default:
+				compare(pointer(ptr, k), av, bv, diffs)
+			}
+		}
+		return
+	case []any:
+		y, ok := b.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < len(x) || i < len(y); i++ {
+			switch {
+			case i >= len(y):
+				*diffs = append(*diffs, Difference{Kind: DiffRemoved, Pointer: pointer(ptr, strconv.Itoa(i)), A: x[i]})
+			case i >= len(x):
+				*diffs = append(*diffs, Difference{Kind: DiffAdded, Pointer: pointer(ptr, strconv.Itoa(i)), B: y[i]})
+			default:
+				compare(pointer(ptr, strconv.Itoa(i)), x[i], y[i], diffs)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, Difference{Kind: DiffChanged, Pointer: ptr, A: a, B: b})
+	}
+}
@@ -0,0 +1,606 @@
+// Package unified provides a writable counterpart to the unified interfaces
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+	oa32 "github.com/genelet/oas/openapi32"
+)
+
+// Editor changes a document in place. Every change writes through to the
+// version-specific document of the adapter, so the Document, and the values
+// it returned, see it at once.
+//
+// Operations, schemas and security schemes are given in the JSON form of the
+// document version: as a map[string]any, a json.RawMessage or []byte, or as a
+// struct of the version package. 2.0 schemas are stored as definitions and
+// security schemes as securityDefinitions.
+type Editor interface {
+	// SetTitle sets the title of the document info
+	SetTitle(title string)
+
+	// SetDescription sets the description of the document info
+	SetDescription(description string)
+
+	// SetExtension sets a document extension; the name must start with x-
+	SetExtension(name string, value any) error
+
+	// AddTag declares a tag, or updates the description of a declared one
+	AddTag(name, description string)
+
+	// AddOperation adds the operation to the path, creating the path item if
+	// needed. It fails if the path already has an operation for the method, or
+	// if the version has no such method.
+	AddOperation(path, method string, operation any) error
+
+	// RemoveOperation removes the operation of the path for the method and
+	// reports whether there was one
+	RemoveOperation(path, method string) bool
+
+	// SetSchema adds the named schema, or replaces it
+	SetSchema(name string, schema any) error
+
+	// RemoveSchema removes the named schema and reports whether there was one
+	RemoveSchema(name string) bool
+
+	// AddSecurityScheme adds the named security scheme. It fails if the name
+	// is already taken.
+	AddSecurityScheme(name string, scheme any) error
+
+	// AddSecurityRequirement appends a document-level security requirement
+	AddSecurityRequirement(requirement SecurityRequirement)
+}
+
+// NewEditor returns an Editor for a document created by this package
+func NewEditor(doc Document) (Editor, error) {
+	switch d := doc.(type) {
+	case *Document20:
+		return &editor20{doc: d.doc, ops: &d.ops}, nil
+	case *Document30:
+		return &editor30{doc: d.doc, ops: &d.ops}, nil
+	case *Document31:
+		return &editor31{doc: d.doc, ops: &d.ops}, nil
+	case *Document32:
+		return &editor32{doc: d.doc, ops: &d.ops}, nil
+	}
+	return nil, fmt.Errorf("unsupported document type %T", doc)
+}
+
+// decode stores the JSON form of value into target
+func decode(kind string, value, target any) error {
+	data, ok := value.([]byte)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return fmt.Errorf("invalid %s: %w", kind, err)
+		}
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("invalid %s: %w", kind, err)
+	}
+	return nil
+}
+
+// checkExtension returns an error unless name is an extension name
+func checkExtension(name string) error {
+	if !strings.HasPrefix(name, "x-") {
+		return fmt.Errorf("extension name %q does not start with x-", name)
+	}
+	return nil
+}
+
+func errMethod(method string) error {
+	return fmt.Errorf("unsupported method %q", method)
+}
+
+func errOperationExists(path, method string) error {
+	return fmt.Errorf("operation %s %s already exists", strings.ToUpper(method), path)
+}
+
+func errSecuritySchemeExists(name string) error {
+	return fmt.Errorf("security scheme %q already exists", name)
+}
+
+// editor20 edits an 2.0 document
+type editor20 struct {
+	doc *oa2.Swagger
+	ops *operationIndex
+}
+
+func (e *editor20) info() *oa2.Info {
+	if e.doc.Info == nil {
+		e.doc.Info = &oa2.Info{}
+	}
+	return e.doc.Info
+}
+
+func (e *editor20) SetTitle(title string) {
+	e.info().Title = title
+}
+
+func (e *editor20) SetDescription(description string) {
+	e.info().Description = description
+}
+
+func (e *editor20) SetExtension(name string, value any) error {
+	if err := checkExtension(name); err != nil {
+		return err
+	}
+	if e.doc.Extensions == nil {
+		e.doc.Extensions = make(map[string]any)
+	}
+	e.doc.Extensions[name] = value
+	return nil
+}
+
+func (e *editor20) AddTag(name, description string) {
+	for _, tag := range e.doc.Tags {
+		if tag != nil && tag.Name == name {
+			tag.Description = description
+			return
+		}
+	}
+	e.doc.Tags = append(e.doc.Tags, &oa2.Tag{Name: name, Description: description})
+}
+
+func (e *editor20) AddOperation(path, method string, operation any) error {
+	op := new(oa2.Operation)
+	if err := decode("operation", operation, op); err != nil {
+		return err
+	}
+	if e.doc.Paths == nil {
+		e.doc.Paths = &oa2.Paths{}
+	}
+	item := e.doc.Paths.Get(path)
+	if item == nil {
+		item = &oa2.PathItem{}
+	}
+	if item.GetOperation(method) != nil {
+		return errOperationExists(path, method)
+	}
+	if !item.SetOperation(method, op) {
+		return errMethod(method)
+	}
+	e.doc.Paths.Set(path, item)
+	e.ops.invalidate()
+	return nil
+}
+
+func (e *editor20) RemoveOperation(path, method string) bool {
+	item := e.doc.Paths.Get(path)
+	if item == nil {
+		return false
+	}
+	if item.GetOperation(method) == nil {
+		return false
+	}
+	return item.SetOperation(method, nil)
+}
+
+func (e *editor20) SetSchema(name string, schema any) error {
+	s := new(oa2.Schema)
+	if err := decode("schema", schema, s); err != nil {
+		return err
+	}
+	if e.doc.Definitions == nil {
+		e.doc.Definitions = make(map[string]*oa2.Schema)
+	}
+	e.doc.Definitions[name] = s
+	return nil
+}
+
+func (e *editor20) RemoveSchema(name string) bool {
+	if _, ok := e.doc.Definitions[name]; !ok {
+		return false
+	}
+	delete(e.doc.Definitions, name)
+	return true
+}
+
+func (e *editor20) AddSecurityScheme(name string, scheme any) error {
+	s := new(oa2.SecurityScheme)
+	if err := decode("security scheme", scheme, s); err != nil {
+		return err
+	}
+	if _, ok := e.doc.SecurityDefinitions[name]; ok {
+		return errSecuritySchemeExists(name)
+	}
+	if e.doc.SecurityDefinitions == nil {
+		e.doc.SecurityDefinitions = make(map[string]*oa2.SecurityScheme)
+	}
+	e.doc.SecurityDefinitions[name] = s
+	return nil
+}
+
+func (e *editor20) AddSecurityRequirement(requirement SecurityRequirement) {
+	e.doc.Security = append(e.doc.Security, oa2.SecurityRequirement(requirement))
+}
+
+// editor30 edits an OpenAPI 3.0 document
+type editor30 struct {
+	doc *oa3.OpenAPI
+	ops *operationIndex
+}
+
+func (e *editor30) info() *oa3.Info {
+	if e.doc.Info == nil {
+		e.doc.Info = &oa3.Info{}
+	}
+	return e.doc.Info
+}
+
+func (e *editor30) SetTitle(title string) {
+	e.info().Title = title
+}
+
+func (e *editor30) SetDescription(description string) {
+	e.info().Description = description
+}
+
+func (e *editor30) SetExtension(name string, value any) error {
+	if err := checkExtension(name); err != nil {
+		return err
+	}
+	if e.doc.Extensions == nil {
+		e.doc.Extensions = make(map[string]any)
+	}
+	e.doc.Extensions[name] = value
+	return nil
+}
+
+func (e *editor30) AddTag(name, description string) {
+	for _, tag := range e.doc.Tags {
+		if tag != nil && tag.Name == name {
+			tag.Description = description
+			return
+		}
+	}
+	e.doc.Tags = append(e.doc.Tags, &oa3.Tag{Name: name, Description: description})
+}
+
+func (e *editor30) AddOperation(path, method string, operation any) error {
+	op := new(oa3.Operation)
+	if err := decode("operation", operation, op); err != nil {
+		return err
+	}
+	if e.doc.Paths == nil {
+		e.doc.Paths = &oa3.Paths{}
+	}
+	item := e.doc.Paths.Get(path)
+	if item == nil {
+		item = &oa3.PathItem{}
+	}
+	if item.GetOperation(method) != nil {
+		return errOperationExists(path, method)
+	}
+	if !item.SetOperation(method, op) {
+		return errMethod(method)
+	}
+	e.doc.Paths.Set(path, item)
+	e.ops.invalidate()
+	return nil
+}
+
+func (e *editor30) RemoveOperation(path, method string) bool {
+	item := e.doc.Paths.Get(path)
+	if item == nil {
+		return false
+	}
+	if item.GetOperation(method) == nil {
+		return false
+	}
+	return item.SetOperation(method, nil)
+}
+
+func (e *editor30) components() *oa3.Components {
+	if e.doc.Components == nil {
+		e.doc.Components = &oa3.Components{}
+	}
+	return e.doc.Components
+}
+
+func (e *editor30) SetSchema(name string, schema any) error {
+	s := new(oa3.Schema)
+	if err := decode("schema", schema, s); err != nil {
+		return err
+	}
+	c := e.components()
+	if c.Schemas == nil {
+		c.Schemas = make(map[string]*oa3.Schema)
+	}
+	c.Schemas[name] = s
+	return nil
+}
+
+func (e *editor30) RemoveSchema(name string) bool {
+	if e.doc.Components == nil {
+		return false
+	}
+	if _, ok := e.doc.Components.Schemas[name]; !ok {
+		return false
+	}
+	delete(e.doc.Components.Schemas, name)
+	return true
+}
+
+func (e *editor30) AddSecurityScheme(name string, scheme any) error {
+	s := new(oa3.SecurityScheme)
+	if err := decode("security scheme", scheme, s); err != nil {
+		return err
+	}
+	c := e.components()
+	if _, ok := c.SecuritySchemes[name]; ok {
+		return errSecuritySchemeExists(name)
+	}
+	if c.SecuritySchemes == nil {
+		c.SecuritySchemes = make(map[string]*oa3.SecurityScheme)
+	}
+	c.SecuritySchemes[name] = s
+	return nil
+}
+
+func (e *editor30) AddSecurityRequirement(requirement SecurityRequirement) {
+	e.doc.Security = append(e.doc.Security, oa3.SecurityRequirement(requirement))
+}
+
+// editor31 edits an OpenAPI 3.1 document
+type editor31 struct {
+	doc *oa31.OpenAPI
+	ops *operationIndex
+}
+
+func (e *editor31) info() *oa31.Info {
+	if e.doc.Info == nil {
+		e.doc.Info = &oa31.Info{}
+	}
+	return e.doc.Info
+}
+
+func (e *editor31) SetTitle(title string) {
+	e.info().Title = title
+}
+
+func (e *editor31) SetDescription(description string) {
+	e.info().Description = description
+}
+
+func (e *editor31) SetExtension(name string, value any) error {
+	if err := checkExtension(name); err != nil {
+		return err
+	}
+	if e.doc.Extensions == nil {
+		e.doc.Extensions = make(map[string]any)
+	}
+	e.doc.Extensions[name] = value
+	return nil
+}
+
+func (e *editor31) AddTag(name, description string) {
+	for _, tag := range e.doc.Tags {
+		if tag != nil && tag.Name == name {
+			tag.Description = description
+			return
+		}
+	}
+	e.doc.Tags = append(e.doc.Tags, &oa31.Tag{Name: name, Description: description})
+}
+
+func (e *editor31) AddOperation(path, method string, operation any) error {
+	op := new(oa31.Operation)
+	if err := decode("operation", operation, op); err != nil {
+		return err
+	}
+	if e.doc.Paths == nil {
+		e.doc.Paths = &oa31.Paths{}
+	}
+	item := e.doc.Paths.Get(path)
+	if item == nil {
+		item = &oa31.PathItem{}
+	}
+	if item.GetOperation(method) != nil {
+		return errOperationExists(path, method)
+	}
+	if !item.SetOperation(method, op) {
+		return errMethod(method)
+	}
+	e.doc.Paths.Set(path, item)
+	e.ops.invalidate()
+	return nil
+}
+
+func (e *editor31) RemoveOperation(path, method string) bool {
+	item := e.doc.Paths.Get(path)
+	if item == nil {
+		return false
+	}
+	if item.GetOperation(method) == nil {
+		return false
+	}
+	return item.SetOperation(method, nil)
+}
+
+func (e *editor31) components() *oa31.Components {
+	if e.doc.Components == nil {
+		e.doc.Components = &oa31.Components{}
+	}
+	return e.doc.Components
+}
+
+func (e *editor31) SetSchema(name string, schema any) error {
+	s := new(oa31.Schema)
+	if err := decode("schema", schema, s); err != nil {
+		return err
+	}
+	c := e.components()
+	if c.Schemas == nil {
+		c.Schemas = make(map[string]*oa31.Schema)
+	}
+	c.Schemas[name] = s
+	return nil
+}
+
+func (e *editor31) RemoveSchema(name string) bool {
+	if e.doc.Components == nil {
+		return false
+	}
+	if _, ok := e.doc.Components.Schemas[name]; !ok {
+		return false
+	}
+	delete(e.doc.Components.Schemas, name)
+	return true
+}
+
+func (e *editor31) AddSecurityScheme(name string, scheme any) error {
+	s := new(oa31.SecurityScheme)
+	if err := decode("security scheme", scheme, s); err != nil {
+		return err
+	}
+	c := e.components()
+	if _, ok := c.SecuritySchemes[name]; ok {
+		return errSecuritySchemeExists(name)
+	}
+	if c.SecuritySchemes == nil {
+		c.SecuritySchemes = make(map[string]*oa31.SecurityScheme)
+	}
+	c.SecuritySchemes[name] = s
+	return nil
+}
+
+func (e *editor31) AddSecurityRequirement(requirement SecurityRequirement) {
+	e.doc.Security = append(e.doc.Security, oa31.SecurityRequirement(requirement))
+}
+
+// editor32 edits an OpenAPI 3.2 document
+type editor32 struct {
+	doc *oa32.OpenAPI
+	ops *operationIndex
+}
+
+func (e *editor32) info() *oa32.Info {
+	if e.doc.Info == nil {
+		e.doc.Info = &oa32.Info{}
+	}
+	return e.doc.Info
+}
+
+func (e *editor32) SetTitle(title string) {
+	e.info().Title = title
+}
+
+func (e *editor32) SetDescription(description string) {
+	e.info().Description = description
+}
+
+func (e *editor32) SetExtension(name string, value any) error {
+	if err := checkExtension(name); err != nil {
+		return err
+	}
+	if e.doc.Extensions == nil {
+		e.doc.Extensions = make(map[string]any)
+	}
+	e.doc.Extensions[name] = value
+	return nil
+}
+
+func (e *editor32) AddTag(name, description string) {
+	for _, tag := range e.doc.Tags {
+		if tag != nil && tag.Name == name {
+			tag.Description = description
+			return
+		}
+	}
+	e.doc.Tags = append(e.doc.Tags, &oa32.Tag{Name: name, Description: description})
+}
+
+func (e *editor32) AddOperation(path, method string, operation any) error {
+	op := new(oa32.Operation)
+	if err := decode("operation", operation, op); err != nil {
+		return err
+	}
+	if method == "" {
+		return errMethod(method)
+	}
+	if e.doc.Paths == nil {
+		e.doc.Paths = &oa32.Paths{}
+	}
+	item := e.doc.Paths.Get(path)
+	if item == nil {
+		item = &oa32.PathItem{}
+	}
+	if item.GetOperation(method) != nil {
+		return errOperationExists(path, method)
+	}
+	item.SetOperation(method, op)
+	e.doc.Paths.Set(path, item)
+	e.ops.invalidate()
+	return nil
+}
+
+func (e *editor32) RemoveOperation(path, method string) bool {
+	item := e.doc.Paths.Get(path)
+	if item == nil {
+		return false
+	}
+	if item.GetOperation(method) == nil {
+		return false
+	}
+	return item.SetOperation(method, nil)
+}
+
+func (e *editor32) components() *oa32.Components {
+	if e.doc.Components == nil {
+		e.doc.Components = &oa32.Components{}
+	}
+	return e.doc.Components
+}
+
+func (e *editor32) SetSchema(name string, schema any) error {
+	s := new(oa32.Schema)
+	if err := decode("schema", schema, s); err != nil {
+		return err
+	}
+	c := e.components()
+	if c.Schemas == nil {
+		c.Schemas = make(map[string]*oa32.Schema)
+	}
+	c.Schemas[name] = s
+	return nil
+}
+
+func (e *editor32) RemoveSchema(name string) bool {
+	if e.doc.Components == nil {
+		return false
+	}
+	if _, ok := e.doc.Components.Schemas[name]; !ok {
+		return false
+	}
+	delete(e.doc.Components.Schemas, name)
+	return true
+}
+
+func (e *editor32) AddSecurityScheme(name string, scheme any) error {
+	s := new(oa32.SecurityScheme)
+	if err := decode("security scheme", scheme, s); err != nil {
+		return err
+	}
+	c := e.components()
+	if _, ok := c.SecuritySchemes[name]; ok {
+		return errSecuritySchemeExists(name)
+	}
+	if c.SecuritySchemes == nil {
+		c.SecuritySchemes = make(map[string]*oa32.SecurityScheme)
+	}
+	c.SecuritySchemes[name] = s
+	return nil
+}
+
+func (e *editor32) AddSecurityRequirement(requirement SecurityRequirement) {
+	e.doc.Security = append(e.doc.Security, oa32.SecurityRequirement(requirement))
+}
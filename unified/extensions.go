@@ -0,0 +1,37 @@
+// Package unified provides typed access to the extensions of unified values
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import oa3 "github.com/genelet/oas/openapi30"
+
+// Extensible is a unified value with x- extensions, such as a Document, an
+// Operation or a Schema
+type Extensible interface {
+	GetExtensions() map[string]any
+}
+
+// ErrExtensionNotFound is returned by the GetExtension helpers when the value
+// has no such extension
+var ErrExtensionNotFound = oa3.ErrExtensionNotFound
+
+// GetExtensionString returns the extension key of v as a string
+func GetExtensionString(v Extensible, key string) (string, error) {
+	return oa3.GetExtensionString(v.GetExtensions(), key)
+}
+
+// GetExtensionBool returns the extension key of v as a bool
+func GetExtensionBool(v Extensible, key string) (bool, error) {
+	return oa3.GetExtensionBool(v.GetExtensions(), key)
+}
+
+// GetExtensionInt returns the extension key of v as an int
+func GetExtensionInt(v Extensible, key string) (int, error) {
+	return oa3.GetExtensionInt(v.GetExtensions(), key)
+}
+
+// GetExtensionObject decodes the extension key of v into out, which must be a
+// pointer, through its JSON form
+func GetExtensionObject(v Extensible, key string, out any) error {
+	return oa3.GetExtensionObject(v.GetExtensions(), key, out)
+}
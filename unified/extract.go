@@ -0,0 +1,229 @@
+// Package unified provides the extraction of inline schemas of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SchemaNaming decides how ExtractInlineSchemas names the components it
+// creates
+type SchemaNaming int
+
+const (
+	// SchemaNamingOperation names a schema after where it is: the
+	// operationId, or the name GenerateOperationIDs would give, with
+	// "Request", "Response", a status code or a parameter name, followed by
+	// the properties it is nested in. A schema nested in a component starts
+	// with the component name: the owner of Pet is PetOwner.
+	SchemaNamingOperation SchemaNaming = iota
+
+	// SchemaNamingTitle names a schema after its title, and a schema without
+	// one as with SchemaNamingOperation
+	SchemaNamingTitle
+)
+
+// ExtractedSchema is an inline schema ExtractInlineSchemas moved into the
+// components
+type ExtractedSchema struct {
+	Pointer string // where the schema was, now a reference
+	Schema  string // the reference of the new component
+}
+
+// ExtractInlineSchemas moves, in place, the inline object schemas with
+// properties of a document, those of request and response bodies,
+// parameters and headers and those nested in other schemas, into named
+// component schemas, and replaces them with a reference. Component schemas
+// stay where they are, but their nested object schemas are extracted too. A
+// name already taken gets the first free numeric suffix, starting at 2. It
+// returns the extracted schemas in document order.
+func ExtractInlineSchemas(doc Document, naming SchemaNaming) ([]ExtractedSchema, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to extract schemas from")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	x := &extractor{root: root, naming: naming, section: []string{"components", "schemas"}}
+	if strings.HasPrefix(doc.Version(), "2.") {
+		x.section = []string{"definitions"}
+	}
+	components := pointer("", x.section...)
+
+	// Walk visits a schema before the schemas nested in it
+	var extracted []ExtractedSchema
+	names := make(map[string]string)
+	taken := make(map[string]bool)
+	for name := range object(root, false, x.section...) {
+		taken[name] = true
+	}
+	seen := make(map[string]bool)
+	Walk(doc, Visitor{Schema: func(ptr string, _ Schema) {
+		if seen[ptr] {
+			return
+		}
+		seen[ptr] = true
+		if name, ok := strings.CutPrefix(ptr, components+"/"); ok && !strings.Contains(name, "/") {
+			return
+		}
+		tokens, _ := pointerTokens(ptr)
+		value, _ := pointerValue(root, tokens)
+		schema, _ := value.(map[string]any)
+		if properties, _ := schema["properties"].(map[string]any); len(properties) == 0 {
+			return
+		}
+		base := x.name(tokens, schema, names)
+		name := base
+		for n := 2; taken[name]; n++ {
+			name = base + strconv.Itoa(n)
+		}
+		taken[name] = true
+		names[ptr] = name
+		extracted = append(extracted, ExtractedSchema{Pointer: ptr, Schema: pointer(pointer("#", x.section...), name)})
+	}})
+	if len(extracted) == 0 {
+		return nil, nil
+	}
+
+	// The nested schemas go first, so that their owners move with references
+	section := object(root, true, x.section...)
+	for _, e := range slices.Backward(extracted) {
+		tokens, _ := pointerTokens(e.Pointer)
+		value, _ := pointerValue(root, tokens)
+		section[names[e.Pointer]] = value
+		patchAt(root, tokens, func(container any, token string) (any, error) {
+			if m, ok := container.(map[string]any); ok {
+				m[token] = map[string]any{"$ref": e.Schema}
+			} else {
+				i, _ := strconv.Atoi(token)
+				container.([]any)[i] = map[string]any{"$ref": e.Schema}
+			}
+			return container, nil
+		})
+	}
+	return extracted, setDocumentObject(doc, root)
+}
+
+// extractor names the inline schemas of the generic JSON form of a document
+type extractor struct {
+	root    map[string]any
+	naming  SchemaNaming
+	section []string // the tokens of the component schemas
+}
+
+// name returns the name of the schema at the tokens, before any numeric
+// suffix. names holds the names of the schemas extracted so far, which
+// include the schemas it is nested in.
+func (x *extractor) name(tokens []string, schema map[string]any, names map[string]string) string {
+	var words []string
+	if title, ok := schema["title"].(string); ok && x.naming == SchemaNamingTitle {
+		words = identifierWords(title)
+	}
+	if len(words) == 0 {
+		// Start from the nearest schema it is nested in that has a name
+		start := 0
+		for i := len(tokens) - 1; i > 0; i-- {
+			if name, ok := names[pointer("", tokens[:i]...)]; ok {
+				words, start = []string{name}, i
+				break
+			}
+		}
+		if start == 0 && len(tokens) > len(x.section) && slices.Equal(tokens[:len(x.section)], x.section) {
+			words, start = identifierWords(tokens[len(x.section)]), len(x.section)+1
+		}
+		node, _ := pointerValue(x.root, tokens[:start])
+		words = append(words, x.placeWords(node, tokens[start:], start == 0)...)
+	}
+	if len(words) == 0 {
+		words = []string{"Schema"}
+	}
+	name := []rune(camelCase(words))
+	name[0] = unicode.ToUpper(name[0])
+	return string(name)
+}
+
+// placeWords returns the words naming the place the tokens lead to from a
+// node of the document, top when the node is the document itself
+func (x *extractor) placeWords(node any, tokens []string, top bool) []string {
+	var words []string
+	at := func(i int) any {
+		value, _ := pointerValue(node, tokens[:i+1])
+		return value
+	}
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case top && i == 0 && (token == "paths" || token == "webhooks"), token == "callbacks":
+			// A path item and one of its operations
+			if i+2 >= len(tokens) {
+				return words
+			}
+			path, method := tokens[i+1], tokens[i+2]
+			i += 2
+			if token == "callbacks" {
+				if i+1 >= len(tokens) {
+					return words
+				}
+				path, method = tokens[i], tokens[i+1]
+				i++
+			}
+			if method == "additionalOperations" && i+1 < len(tokens) {
+				i++
+				method = tokens[i]
+			}
+			op, _ := at(i).(map[string]any)
+			if id, ok := op["operationId"].(string); ok && id != "" {
+				words = identifierWords(id)
+			} else {
+				words = identifierWords(operationID(path, pathOperation{method, op}, OperationIDMethodPath))
+			}
+		case top && i == 0 && (token == "components" || token == "definitions" || token == "parameters" || token == "responses"):
+			// A reusable object, under its section
+			if token == "components" {
+				i++
+			}
+			if i+1 < len(tokens) {
+				i++
+				words = identifierWords(tokens[i])
+			}
+		case token == "requestBody":
+			words = append(words, "Request")
+		case token == "responses" && i+1 < len(tokens):
+			i++
+			switch code := tokens[i]; {
+			case code == "default":
+				words = append(words, "Default", "Response")
+			case strings.HasPrefix(code, "2"):
+				words = append(words, "Response")
+			default:
+				words = append(words, code, "Response")
+			}
+		case token == "parameters" && i+1 < len(tokens):
+			i++
+			param, _ := at(i).(map[string]any)
+			if param["in"] == "body" {
+				words = append(words, "Request")
+			} else if name, ok := param["name"].(string); ok {
+				words = append(words, identifierWords(name)...)
+			}
+		case (token == "headers" || token == "properties" || token == "dependentSchemas") && i+1 < len(tokens):
+			i++
+			words = append(words, identifierWords(tokens[i])...)
+		case token == "items":
+			words = append(words, "Item")
+		case token == "additionalProperties":
+			words = append(words, "Value")
+		case (token == "allOf" || token == "anyOf" || token == "oneOf" || token == "prefixItems") && i+1 < len(tokens):
+			i++
+			n, _ := strconv.Atoi(tokens[i])
+			words = append(words, strconv.Itoa(n+1))
+		}
+	}
+	return words
+}
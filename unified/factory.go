@@ -4,6 +4,7 @@
 package unified
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	oa2 "github.com/genelet/oas/openapi20"
 	oa3 "github.com/genelet/oas/openapi30"
 	oa31 "github.com/genelet/oas/openapi31"
+	oa32 "github.com/genelet/oas/openapi32"
 )
 
 // versionProbe is used to detect OpenAPI version
@@ -20,7 +22,7 @@ type versionProbe struct {
 }
 
 // NewDocument parses a JSON-encoded OpenAPI document and returns a unified Document interface.
-// It automatically detects the version (2.0, 3.0.x, 3.1.x) and returns the appropriate adapter.
+// It automatically detects the version (2.0, 3.0.x, 3.1.x, 3.2.x) and returns the appropriate adapter.
 // Note: This function only accepts JSON. YAML must be converted to JSON before calling this.
 func NewDocument(data []byte) (Document, error) {
 	// Detect version
@@ -39,12 +41,26 @@ func NewDocument(data []byte) (Document, error) {
 	case strings.HasPrefix(probe.OpenAPI, "3.1"):
 		return parseOpenAPI31(data)
 
+	case strings.HasPrefix(probe.OpenAPI, "3.2"):
+		return parseOpenAPI32(data)
+
 	default:
 		return nil, fmt.Errorf("unsupported OpenAPI version: swagger=%q openapi=%q",
 			probe.Swagger, probe.OpenAPI)
 	}
 }
 
+// Parse detects the version of a JSON-encoded OpenAPI document, unmarshals it
+// with the package of that version, and returns the matching adapter: a
+// *Document20, *Document30, *Document31 or *Document32. It is NewDocument,
+// except that a leading UTF-8 byte order mark is ignored.
+func Parse(data []byte) (Document, error) {
+	return NewDocument(bytes.TrimPrefix(data, utf8BOM))
+}
+
+// utf8BOM is the byte order mark some editors write at the start of a file
+var utf8BOM = []byte("\xef\xbb\xbf")
+
 // parseOpenAPI30 parses an OpenAPI 3.0 document
 func parseOpenAPI30(jsonData []byte) (Document, error) {
 	var doc oa3.OpenAPI
@@ -71,3 +87,12 @@ func parseOpenAPI31(jsonData []byte) (Document, error) {
 	}
 	return NewDocument31(&doc), nil
 }
+
+// parseOpenAPI32 parses an OpenAPI 3.2 document
+func parseOpenAPI32(jsonData []byte) (Document, error) {
+	var doc oa32.OpenAPI
+	if err := json.Unmarshal(jsonData, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI 3.2: %w", err)
+	}
+	return NewDocument32(&doc), nil
+}
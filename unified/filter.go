@@ -0,0 +1,499 @@
+// Package unified provides the filtering of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// FilterOptions selects the operations Filter keeps: those on one of the
+// Paths, with one of the OperationIDs or with one of the Tags
+type FilterOptions struct {
+	Tags         []string
+	Paths        []string
+	OperationIDs []string
+}
+
+// selects reports whether the options keep the operation on the path
+func (o FilterOptions) selects(path string, op map[string]any) bool {
+	if slices.Contains(o.Paths, path) {
+		return true
+	}
+	if id, ok := op["operationId"].(string); ok && slices.Contains(o.OperationIDs, id) {
+		return true
+	}
+	tags, _ := op["tags"].([]any)
+	for _, tag := range tags {
+		if name, ok := tag.(string); ok && slices.Contains(o.Tags, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns a new document, of the same version, with the operations of
+// the paths and webhooks the options select. Path items left without
+// operations are dropped; a path item that is a reference is kept when its
+// path is selected. Of the components, only those the kept document
+// references, directly or through other components, remain, and of the tag
+// declarations those the kept operations use. doc is not changed.
+func Filter(doc Document, opts FilterOptions) (Document, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to filter")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"paths", "webhooks"} {
+		items := object(root, false, name)
+		for path, item := range items {
+			i, _ := item.(map[string]any)
+			if !filterPathItem(i, path, opts) {
+				delete(items, path)
+			}
+		}
+	}
+	f := newFilter(root, doc)
+	f.collectOutside()
+	f.prune(func(i int, name string) bool { return f.used[i][name] })
+	f.pruneTags()
+	data, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	return NewDocument(data)
+}
+
+// filterPathItem removes the operations of a path item the options do not
+// select, and reports whether the path item is to be kept
+func filterPathItem(item map[string]any, path string, opts FilterOptions) bool {
+	if item == nil {
+		return false
+	}
+	if _, ok := item["$ref"]; ok {
+		return slices.Contains(opts.Paths, path)
+	}
+	kept := false
+	for _, method := range pathItemMethods {
+		if op, ok := item[method].(map[string]any); ok {
+			if opts.selects(path, op) {
+				kept = true
+			} else {
+				delete(item, method)
+			}
+		}
+	}
+	if additional, ok := item["additionalOperations"].(map[string]any); ok {
+		for name, op := range additional {
+			if o, _ := op.(map[string]any); o != nil && opts.selects(path, o) {
+				kept = true
+			} else {
+				delete(additional, name)
+			}
+		}
+		if len(additional) == 0 {
+			delete(item, "additionalOperations")
+		}
+	}
+	return kept
+}
+
+// filter collects the components and tags a document uses
+type filter struct {
+	root     map[string]any
+	sections []componentSection
+	used     []map[string]bool // the used names of each section
+	tags     map[string]bool
+}
+
+// newFilter returns a filter of the generic JSON form of a document
+func newFilter(root map[string]any, doc Document) *filter {
+	f := &filter{
+		root:     root,
+		sections: componentSections(root, strings.HasPrefix(doc.Version(), "2.")),
+	}
+	f.reset()
+	return f
+}
+
+// reset forgets the collected components and tags
+func (f *filter) reset() {
+	f.used = make([]map[string]bool, len(f.sections))
+	for i := range f.used {
+		f.used[i] = make(map[string]bool)
+	}
+	f.tags = make(map[string]bool)
+}
+
+// collectOutside collects the components and tags used outside the component
+// sections, and through them
+func (f *filter) collectOutside() {
+	outside := make(map[string]any, len(f.root))
+	for key, value := range f.root {
+		outside[key] = value
+	}
+	for _, section := range f.sections {
+		delete(outside, section.tokens[0])
+	}
+	f.collect(outside)
+}
+
+// prune removes the components keep rejects, and the sections left empty
+func (f *filter) prune(keep func(i int, name string) bool) {
+	for i, section := range f.sections {
+		values := object(f.root, false, section.tokens...)
+		for name := range values {
+			if !keep(i, name) {
+				delete(values, name)
+			}
+		}
+		if len(values) == 0 {
+			delete(object(f.root, false, section.tokens[:len(section.tokens)-1]...), section.tokens[len(section.tokens)-1])
+		}
+	}
+	if components, ok := f.root["components"].(map[string]any); ok && len(components) == 0 {
+		delete(f.root, "components")
+	}
+}
+
+// pruneTags removes the tag declarations no operation uses
+func (f *filter) pruneTags() {
+	tags, _ := f.root["tags"].([]any)
+	var kept []any
+	for _, tag := range tags {
+		if f.tags[tagName(tag)] {
+			kept = append(kept, tag)
+		}
+	}
+	if len(kept) > 0 {
+		f.root["tags"] = kept
+	} else {
+		delete(f.root, "tags")
+	}
+}
+
+// component returns the section index and name of the component a local
+// reference points into
+func (f *filter) component(ref string) (int, string, bool) {
+	for i, section := range f.sections {
+		if section.ref == "" {
+			continue
+		}
+		rest, ok := strings.CutPrefix(ref, section.ref+"/")
+		if !ok {
+			continue
+		}
+		escaped, _, _ := strings.Cut(rest, "/")
+		name, err := url.PathUnescape(escaped)
+		if err != nil {
+			return 0, "", false
+		}
+		return i, strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~"), true
+	}
+	return 0, "", false
+}
+
+// use marks a component as used and collects what it uses in turn
+func (f *filter) use(i int, name string) {
+	if f.used[i][name] {
+		return
+	}
+	f.used[i][name] = true
+	if value, ok := object(f.root, false, f.sections[i].tokens...)[name]; ok {
+		f.collect(value)
+	}
+}
+
+// useRef marks the component a local reference points into as used
+func (f *filter) useRef(ref string) {
+	if i, name, ok := f.component(ref); ok {
+		f.use(i, name)
+	}
+}
+
+// useScheme marks a security scheme as used
+func (f *filter) useScheme(name string) {
+	for i, section := range f.sections {
+		if section.ref == "" {
+			f.use(i, name)
+		}
+	}
+}
+
+// collect finds the references, discriminator mappings, security requirements
+// and operation tags of a generic JSON value
+func (f *filter) collect(v any) {
+	switch x := v.(type) {
+	case map[string]any:
+		for key, value := range x {
+			switch key {
+			case "$ref":
+				if ref, ok := value.(string); ok {
+					f.useRef(ref)
+				}
+			case "mapping":
+				mapping, _ := value.(map[string]any)
+				for _, target := range mapping {
+					ref, _ := target.(string)
+					if ref != "" && !strings.Contains(ref, "/") {
+						// A bare name in a mapping is the name of a schema
+						ref = pointer("#", "components", "schemas", ref)
+					}
+					f.useRef(ref)
+				}
+			case "security":
+				requirements, _ := value.([]any)
+				for _, requirement := range requirements {
+					r, _ := requirement.(map[string]any)
+					for name := range r {
+						f.useScheme(name)
+					}
+				}
+			case "tags":
+				tags, _ := value.([]any)
+				for _, tag := range tags {
+					if name, ok := tag.(string); ok {
+						f.tags[name] = true
+					}
+				}
+			}
+			f.collect(value)
+		}
+	case []any:
+		for _, value := range x {
+			f.collect(value)
+		}
+	}
+}
+
+// PruneUnused removes, in place, the components nothing outside the
+// components references, directly or through other components, and returns
+// the JSON pointers of the removed ones in order. A security scheme is used
+// when a security requirement names it.
+func PruneUnused(doc Document) ([]string, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to prune")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	f := newFilter(root, doc)
+	f.collectOutside()
+	var removed []string
+	f.prune(func(i int, name string) bool {
+		if f.used[i][name] {
+			return true
+		}
+		removed = append(removed, pointer("", append(f.sections[i].tokens, name)...))
+		return false
+	})
+	if len(removed) == 0 {
+		return nil, nil
+	}
+	slices.Sort(removed)
+	return removed, setDocumentObject(doc, root)
+}
+
+// FilterExtension returns a new document without the objects marked with the
+// extension set to true, such as x-internal: operations, path items,
+// parameters, responses, schemas, properties, components and so on. An object
+// referencing a removed component is removed too, as is a path item left
+// without operations. Removed properties are no longer required. Components
+// only the removed objects used are dropped; components nothing used before
+// are kept. doc is not changed.
+func FilterExtension(doc Document, extension string) (Document, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to filter")
+	}
+	if err := checkExtension(extension); err != nil {
+		return nil, err
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	f := newFilter(root, doc)
+	f.collectOutside()
+	before := f.used
+	m := &marker{f: f, extension: extension, removed: make([]map[string]bool, len(f.sections))}
+	for i := range m.removed {
+		m.removed[i] = make(map[string]bool)
+	}
+	m.remove()
+	f.reset()
+	f.collectOutside()
+	f.prune(func(i int, name string) bool { return f.used[i][name] || !before[i][name] })
+	data, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	return NewDocument(data)
+}
+
+// dataKeywords holds the keywords whose values are data, not objects of the
+// document
+var dataKeywords = map[string]bool{"example": true, "default": true, "enum": true, "const": true, "value": true}
+
+// marker removes the objects of a document marked with an extension
+type marker struct {
+	f         *filter
+	extension string
+	removed   []map[string]bool // the removed components of each section
+}
+
+// marked reports whether a value is an object marked with the extension or a
+// reference to a removed component
+func (m *marker) marked(v any) bool {
+	x, ok := v.(map[string]any)
+	if !ok {
+		return false
+	}
+	if x[m.extension] == true {
+		return true
+	}
+	ref, ok := x["$ref"].(string)
+	if !ok {
+		return false
+	}
+	i, name, ok := m.f.component(ref)
+	return ok && m.removed[i][name]
+}
+
+// remove removes the marked components, until no more references to removed
+// ones are left among them, and then the marked objects of the document
+func (m *marker) remove() {
+	for changed := true; changed; {
+		changed = false
+		for i, section := range m.f.sections {
+			values := object(m.f.root, false, section.tokens...)
+			for name, value := range values {
+				if m.marked(value) {
+					delete(values, name)
+					m.removed[i][name] = true
+					changed = true
+				}
+			}
+		}
+	}
+	for _, name := range []string{"paths", "webhooks"} {
+		items := object(m.f.root, false, name)
+		for path, item := range items {
+			i, _ := item.(map[string]any)
+			if m.marked(item) {
+				delete(items, path)
+				continue
+			}
+			had := len(operations(i)) > 0
+			m.strip(i)
+			if had && len(operations(i)) == 0 {
+				delete(items, path)
+			}
+		}
+	}
+	m.strip(m.f.root)
+}
+
+// strip removes the marked objects within a generic JSON value
+func (m *marker) strip(v any) {
+	x, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	for key, value := range x {
+		if strings.HasPrefix(key, "x-") || dataKeywords[key] {
+			continue
+		}
+		if m.marked(value) {
+			delete(x, key)
+			continue
+		}
+		switch key {
+		case "properties":
+			m.stripProperties(x)
+			properties, _ := x[key].(map[string]any)
+			for _, property := range properties {
+				m.strip(property)
+			}
+			continue
+		case "security":
+			m.stripSecurity(x)
+		}
+		if list, ok := x[key].([]any); ok {
+			x[key] = m.stripList(list)
+			continue
+		}
+		m.strip(x[key])
+	}
+}
+
+// stripList returns the elements of a list that are not marked, stripped
+func (m *marker) stripList(list []any) []any {
+	kept := make([]any, 0, len(list))
+	for _, value := range list {
+		if m.marked(value) {
+			continue
+		}
+		if inner, ok := value.([]any); ok {
+			value = m.stripList(inner)
+		}
+		m.strip(value)
+		kept = append(kept, value)
+	}
+	return kept
+}
+
+// stripProperties removes the marked properties of a schema, also from its
+// required list
+func (m *marker) stripProperties(schema map[string]any) {
+	properties, _ := schema["properties"].(map[string]any)
+	for name, property := range properties {
+		if !m.marked(property) {
+			continue
+		}
+		delete(properties, name)
+		if required, ok := schema["required"].([]any); ok {
+			schema["required"] = slices.DeleteFunc(required, func(r any) bool { return r == name })
+			if len(schema["required"].([]any)) == 0 {
+				delete(schema, "required")
+			}
+		}
+	}
+}
+
+// stripSecurity removes the removed security schemes from the requirements of
+// an object, and the requirements left without schemes
+func (m *marker) stripSecurity(x map[string]any) {
+	requirements, ok := x["security"].([]any)
+	if !ok {
+		return
+	}
+	kept := requirements[:0]
+	for _, requirement := range requirements {
+		r, _ := requirement.(map[string]any)
+		if len(r) == 0 {
+			kept = append(kept, requirement)
+			continue
+		}
+		for i, section := range m.f.sections {
+			if section.ref != "" {
+				continue
+			}
+			for name := range r {
+				if m.removed[i][name] {
+					delete(r, name)
+				}
+			}
+		}
+		if len(r) > 0 {
+			kept = append(kept, requirement)
+		}
+	}
+	x["security"] = kept
+}
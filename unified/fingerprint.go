@@ -0,0 +1,65 @@
+// Package unified provides content fingerprints of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Fingerprint returns a stable hash of the content of a document, as 64 hex
+// digits, for cache keys, ETags and change detection. It hashes the
+// canonical form Diff compares, so that documents Diff finds no difference
+// between in the same version have the same fingerprint, whatever the key
+// order or formatting of their source.
+func Fingerprint(doc Document) (string, error) {
+	if doc == nil {
+		return "", fmt.Errorf("no document to fingerprint")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return "", err
+	}
+	return fingerprint(root)
+}
+
+// ComponentFingerprint returns the Fingerprint of the value a local
+// reference points at in a document, such as "#/components/schemas/Pet" or
+// "#/paths/~1pets/get". The reference itself is not followed.
+func ComponentFingerprint(doc Document, ref string) (string, error) {
+	if doc == nil {
+		return "", fmt.Errorf("no document to fingerprint")
+	}
+	ptr, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return "", fmt.Errorf("%q is not a local reference", ref)
+	}
+	tokens, err := pointerTokens(ptr)
+	if err != nil {
+		return "", err
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return "", err
+	}
+	value, err := pointerValue(root, tokens)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+	return fingerprint(value)
+}
+
+// fingerprint hashes the normalized JSON text of a generic JSON value, whose
+// object keys encoding/json writes in order
+func fingerprint(v any) (string, error) {
+	data, err := json.Marshal(normalize("", v))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
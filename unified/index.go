@@ -0,0 +1,110 @@
+// Package unified provides the operationId index of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// indexedDocument is a document whose operations are indexed. It looks up a
+// single path item without wrapping the others, and stamps its paths so the
+// index can tell when they were replaced or gained or lost a path.
+type indexedDocument interface {
+	Document
+	pathItem(path string) PathItem
+	pathsStamp() pathsStamp
+}
+
+// pathsStamp identifies the paths of a document: the version-specific paths
+// object and its number of path items
+type pathsStamp struct {
+	paths any
+	count int
+}
+
+// operationIndex locates the operations of a document's paths by operationId.
+// It is built on first use. An Editor, or a change to the version-specific
+// document, can make it stale, so every hit is checked against its path item
+// and a stale entry rebuilds it once. A miss is remembered until the paths
+// change or an Editor adds an operation.
+type operationIndex struct {
+	mu      sync.Mutex
+	entries map[string]operationLocation
+	missing map[string]bool
+	stamp   pathsStamp
+}
+
+// operationLocation is the path and method of an indexed operation
+type operationLocation struct {
+	path   string
+	method string
+}
+
+// lookup returns the path, method and operation with the operationId
+func (x *operationIndex) lookup(doc indexedDocument, id string) (string, string, Operation) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.entries != nil {
+		if path, method, op, ok := x.find(doc, id); ok {
+			return path, method, op
+		}
+		if _, ok := x.entries[id]; !ok && x.missing[id] && x.stamp == doc.pathsStamp() {
+			return "", "", NilOperation{}
+		}
+	}
+	x.build(doc)
+	if path, method, op, ok := x.find(doc, id); ok {
+		return path, method, op
+	}
+	x.missing[id] = true
+	return "", "", NilOperation{}
+}
+
+// invalidate forgets the misses, for a change the stamp of the paths does
+// not show
+func (x *operationIndex) invalidate() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.missing = nil
+}
+
+// find returns the indexed operation if it still has the operationId
+func (x *operationIndex) find(doc indexedDocument, id string) (string, string, Operation, bool) {
+	loc, ok := x.entries[id]
+	if !ok {
+		return "", "", nil, false
+	}
+	item := doc.pathItem(loc.path)
+	if item == nil {
+		return "", "", nil, false
+	}
+	op := item.GetOperation(loc.method)
+	if op.IsNil() || op.GetOperationID() != id {
+		return "", "", nil, false
+	}
+	return loc.path, loc.method, op, true
+}
+
+// build indexes every operation of the paths. An operationId used more than
+// once is indexed at its first operation in path and method order.
+func (x *operationIndex) build(doc indexedDocument) {
+	x.entries = make(map[string]operationLocation)
+	x.missing = make(map[string]bool)
+	x.stamp = doc.pathsStamp()
+	paths := doc.GetPaths()
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		if paths[path] == nil {
+			continue
+		}
+		operations := paths[path].GetAllOperations()
+		for _, method := range slices.Sorted(maps.Keys(operations)) {
+			id := operations[method].GetOperationID()
+			if _, ok := x.entries[id]; id != "" && !ok {
+				x.entries[id] = operationLocation{path: path, method: method}
+			}
+		}
+	}
+}
@@ -3,28 +3,66 @@
 
 package unified
 
-// Document is a unified interface for OpenAPI documents of any version (2.0, 3.0, 3.1).
+import (
+	"maps"
+	"slices"
+
+	"github.com/genelet/oas/convert"
+	oa3 "github.com/genelet/oas/openapi30"
+)
+
+// Document is a unified interface for OpenAPI documents of any version (2.0, 3.0, 3.1, 3.2).
 type Document interface {
-	// Version returns the OpenAPI/Swagger version string (e.g., "2.0", "3.0.0", "3.1.0")
+	// Version returns the OpenAPI/Swagger version string (e.g., "2.0", "3.0.0", "3.1.0", "3.2.0")
 	Version() string
 
 	// GetServerURL returns the base server URL
 	GetServerURL() string
 
+	// GetServers returns all servers. Swagger 2.0 documents have a single
+	// server built from schemes, host and basePath.
+	GetServers() []Server
+
 	// GetInfo returns document metadata
 	GetInfo() DocumentInfo
 
 	// GetPaths returns all path items keyed by path string
 	GetPaths() map[string]PathItem
 
+	// GetOperationByID returns the path, method and operation of the paths
+	// with the operationId, or empty strings and a NilOperation if there is
+	// none. The lookup is backed by an index built on first use.
+	GetOperationByID(id string) (path, method string, op Operation)
+
+	// GetWebhooks returns all webhooks keyed by name. Only 3.1 and later
+	// documents have webhooks; 2.0 and 3.0 documents return nil.
+	GetWebhooks() map[string]PathItem
+
+	// GetComponents returns the reusable objects of the document: the 3.x
+	// components, or the 2.0 definitions, parameters and responses
+	GetComponents() Components
+
 	// GetSecuritySchemes returns all security scheme definitions
 	GetSecuritySchemes() map[string]SecurityScheme
 
 	// GetGlobalSecurity returns document-level security requirements
 	GetGlobalSecurity() []SecurityRequirement
 
+	// GetTags returns the document-level tag declarations
+	GetTags() []Tag
+
+	// GetExternalDocs returns the document external documentation, or nil
+	GetExternalDocs() ExternalDocumentation
+
 	// GetExtensions returns the extensions map (x-...)
 	GetExtensions() map[string]any
+
+	// ConvertTo converts the document to the target version ("2.0", "3.0",
+	// "3.1", "3.2", or a full version such as "3.0.3") and returns a new adapter
+	// with a report of everything that could not be represented.
+	// A 3.2 document converts to every other version, but converting into 3.2
+	// from another version is not supported yet.
+	ConvertTo(version string) (Document, *convert.ConversionReport, error)
 }
 
 // DocumentInfo provides metadata about the API
@@ -32,6 +70,11 @@ type DocumentInfo interface {
 	GetTitle() string
 	GetVersion() string
 	GetDescription() string
+	GetTermsOfService() string
+	// GetContact returns the contact information, or nil
+	GetContact() Contact
+	// GetLicense returns the license, or nil
+	GetLicense() License
 	GetExtensions() map[string]any
 }
 
@@ -43,6 +86,9 @@ type PathItem interface {
 	GetAllOperations() map[string]Operation
 	GetParameters() []Parameter
 	GetExtensions() map[string]any
+	// GetServers returns the servers that override the document ones for
+	// the path; 2.0 path items have none
+	GetServers() []Server
 }
 
 // Operation abstracts an operation across OpenAPI versions
@@ -59,10 +105,19 @@ type Operation interface {
 	GetExtensions() map[string]any
 	GetExternalDocs() ExternalDocumentation
 	GetDeprecated() bool
+	GetCallbacks() map[string]Callback
+	// EffectiveSecurity returns the security requirements that apply to the
+	// operation in doc, with their schemes resolved
+	EffectiveSecurity(doc Document) []EffectiveRequirement
+	// GetServers returns the servers that override the path item and document
+	// ones for the operation; 2.0 operations have none
+	GetServers() []Server
 }
 
 // Parameter abstracts a parameter across OpenAPI versions
 type Parameter interface {
+	HasRef() bool
+	GetRef() string
 	GetName() string
 	GetIn() string
 	GetRequired() bool
@@ -75,14 +130,29 @@ type Parameter interface {
 	GetAllowEmptyValue() bool
 	GetStyle() string
 	GetExplode() bool
+	// HasExplode reports whether explode is set, since it defaults to true
+	// for the form style and to false otherwise. A 2.0 parameter always has
+	// it, from its collectionFormat.
+	HasExplode() bool
 	GetAllowReserved() bool
+	GetExample() any
+	GetExamples() map[string]Example
+	// GetContent returns the media types of a 3.x parameter that is
+	// serialized with content instead of a schema
+	GetContent() map[string]MediaType
 }
 
 // RequestBody abstracts a request body across OpenAPI versions
 type RequestBody interface {
 	IsNil() bool
+	HasRef() bool
+	GetRef() string
 	GetRequired() bool
 	GetContent() map[string]MediaType
+	// GetMediaType returns the content for a media type, such as the
+	// Content-Type of a message, preferring an exact match, then a
+	// type/*+suffix range, then type/* and then */*; nil if none applies
+	GetMediaType(mediaType string) MediaType
 	GetDescription() string
 	GetExtensions() map[string]any
 }
@@ -90,6 +160,23 @@ type RequestBody interface {
 // MediaType abstracts media type content
 type MediaType interface {
 	GetSchema() Schema
+	// GetExample returns the example, or the 2.0 response example of the media type
+	GetExample() any
+	GetExamples() map[string]Example
+	GetEncoding() map[string]Encoding
+	GetExtensions() map[string]any
+}
+
+// Encoding abstracts the encoding of a media type property (3.0+)
+type Encoding interface {
+	GetContentType() string
+	GetHeaders() map[string]Header
+	GetStyle() string
+	GetExplode() bool
+	// HasExplode reports whether explode is set, since it defaults to true
+	// for the form style and to false otherwise
+	HasExplode() bool
+	GetAllowReserved() bool
 	GetExtensions() map[string]any
 }
 
@@ -97,6 +184,9 @@ type MediaType interface {
 type Responses interface {
 	GetDefault() Response
 	GetStatusCodes() map[string]Response
+	// GetByStatus returns the response for an HTTP status: the one for its
+	// code, else for its range in 3.x, else the default response
+	GetByStatus(status int) Response
 	GetExtensions() map[string]any
 }
 
@@ -108,6 +198,10 @@ type Response interface {
 	GetDescription() string
 	GetHeaders() map[string]Header
 	GetContent() map[string]MediaType
+	// GetMediaType returns the content for a media type, such as the
+	// Content-Type of a message, preferring an exact match, then a
+	// type/*+suffix range, then type/* and then */*; nil if none applies
+	GetMediaType(mediaType string) MediaType
 	// For Swagger 2.0 which has schema directly on response
 	GetSchema() Schema
 	GetExtensions() map[string]any
@@ -120,6 +214,15 @@ type Header interface {
 	GetDescription() string
 	GetExtensions() map[string]any
 	GetDeprecated() bool
+	// GetStyle and GetExplode return the 3.x serialization, or the one
+	// equivalent to the 2.0 collectionFormat
+	GetStyle() string
+	GetExplode() bool
+	// HasExplode reports whether explode is set; a 2.0 header always has it
+	HasExplode() bool
+	// GetContent returns the media types of a 3.x header that is serialized
+	// with content instead of a schema
+	GetContent() map[string]MediaType
 }
 
 // Schema abstracts a schema across OpenAPI versions
@@ -127,14 +230,36 @@ type Schema interface {
 	IsNil() bool
 	GetRef() string
 	GetType() string
+	// GetTypes returns every type, including "null", of a 3.1+ type array, or
+	// the single type of earlier versions
+	GetTypes() []string
+	// IsNullable reports 3.0 nullable, a 3.1+ type that includes "null", or
+	// the 2.0 x-nullable extension
+	IsNullable() bool
 	GetFormat() string
 	GetDescription() string
 	GetProperties() map[string]Schema
 	GetItems() Schema
+	// GetAdditionalProperties returns NilSchema when the keyword is absent;
+	// additionalProperties: false is a boolean schema
+	GetAdditionalProperties() Schema
+	// GetPatternProperties returns the 3.1+ pattern properties
+	GetPatternProperties() map[string]Schema
 	GetRequired() []string
 	GetAllOf() []Schema
 	GetOneOf() []Schema
 	GetAnyOf() []Schema
+	// Conditional and tuple keywords. Single schemas are NilSchema and the
+	// others nil when absent, or when the version lacks them: 2.0 has none
+	// and 3.0 only has not.
+	GetNot() Schema
+	GetIf() Schema
+	GetThen() Schema
+	GetElse() Schema
+	GetPrefixItems() []Schema
+	GetContains() Schema
+	GetDependentSchemas() map[string]Schema
+	GetDependentRequired() map[string][]string
 	// For boolean schemas (additionalProperties: false)
 	IsBooleanSchema() bool
 	GetBooleanValue() *bool
@@ -148,6 +273,26 @@ type Schema interface {
 	GetDeprecated() bool
 	GetReadOnly() bool
 	GetWriteOnly() bool
+	GetTitle() string
+	GetEnum() []any
+	// GetConst returns the 3.1+ const value, nil when there is none
+	GetConst() any
+
+	// Validation keywords. The 3.1+ numeric exclusiveMinimum and
+	// exclusiveMaximum are normalized to the 2.0 and 3.0 form: the bound is
+	// returned by GetMinimum or GetMaximum, and the boolean tells whether it is
+	// exclusive.
+	GetMinimum() *float64
+	GetMaximum() *float64
+	GetExclusiveMinimum() bool
+	GetExclusiveMaximum() bool
+	GetMinLength() *int
+	GetMaxLength() *int
+	GetPattern() string
+	GetMinItems() *int
+	GetMaxItems() *int
+	GetMultipleOf() *float64
+	GetUniqueItems() bool
 }
 
 // SecurityScheme abstracts a security scheme across OpenAPI versions
@@ -168,6 +313,126 @@ type SecurityScheme interface {
 // SecurityRequirement is a map of security scheme names to required scopes
 type SecurityRequirement = map[string][]string
 
+// EffectiveRequirement is a security requirement with its schemes resolved, in
+// name order. All of its schemes apply together; an empty requirement allows
+// anonymous access.
+type EffectiveRequirement []EffectiveScheme
+
+// EffectiveScheme is a scheme of an EffectiveRequirement with the scopes the
+// requirement asks for. Scheme is nil when the document does not define Name.
+type EffectiveScheme struct {
+	Name   string
+	Scheme SecurityScheme
+	Scopes []string
+}
+
+// EffectiveServers applies the override rules of the specification to the
+// servers of an operation: its own servers, or else those of its path item,
+// or else those of the document. item and op may be nil.
+func EffectiveServers(doc Document, item PathItem, op Operation) []Server {
+	if op != nil {
+		if servers := op.GetServers(); len(servers) > 0 {
+			return servers
+		}
+	}
+	if item != nil {
+		if servers := item.GetServers(); len(servers) > 0 {
+			return servers
+		}
+	}
+	if doc == nil {
+		return nil
+	}
+	return doc.GetServers()
+}
+
+// mediaTypeOf returns the content for a media type, matched with
+// openapi30.MatchMediaType
+func mediaTypeOf(content map[string]MediaType, mediaType string) MediaType {
+	key, ok := oa3.MatchMediaType(slices.Collect(maps.Keys(content)), mediaType)
+	if !ok {
+		return nil
+	}
+	return content[key]
+}
+
+// effectiveSecurity applies the override rules of the specification: the
+// operation requirements, even an empty list that removes security, replace
+// the document ones. Any one of the returned requirements satisfies the
+// operation; nil means it is not secured.
+func effectiveSecurity(op Operation, doc Document) []EffectiveRequirement {
+	requirements := op.GetSecurity()
+	if requirements == nil && doc != nil {
+		requirements = doc.GetGlobalSecurity()
+	}
+	if len(requirements) == 0 {
+		return nil
+	}
+	var schemes map[string]SecurityScheme
+	if doc != nil {
+		schemes = doc.GetSecuritySchemes()
+	}
+	result := make([]EffectiveRequirement, 0, len(requirements))
+	for _, req := range requirements {
+		effective := make(EffectiveRequirement, 0, len(req))
+		for _, name := range slices.Sorted(maps.Keys(req)) {
+			effective = append(effective, EffectiveScheme{Name: name, Scheme: schemes[name], Scopes: req[name]})
+		}
+		result = append(result, effective)
+	}
+	return result
+}
+
+// Components abstracts the reusable objects of a document. Security schemes
+// are reached through Document.GetSecuritySchemes. Sections a version does not
+// have return nil.
+type Components interface {
+	GetSchemas() map[string]Schema
+	GetResponses() map[string]Response
+	GetParameters() map[string]Parameter
+	GetRequestBodies() map[string]RequestBody
+	GetHeaders() map[string]Header
+	GetExamples() map[string]Example
+	GetLinks() map[string]Link
+	GetCallbacks() map[string]Callback
+	// GetPathItems returns the 3.1+ reusable path items
+	GetPathItems() map[string]PathItem
+	GetExtensions() map[string]any
+}
+
+// Example abstracts an example object (3.0+)
+type Example interface {
+	HasRef() bool
+	GetRef() string
+	GetSummary() string
+	GetDescription() string
+	// GetValue returns the example value, or the 3.2 dataValue
+	GetValue() any
+	GetExternalValue() string
+	GetExtensions() map[string]any
+}
+
+// Link abstracts a link from a response to an operation (3.0+)
+type Link interface {
+	HasRef() bool
+	GetRef() string
+	GetOperationRef() string
+	GetOperationID() string
+	GetParameters() map[string]any
+	GetRequestBody() any
+	GetDescription() string
+	GetExtensions() map[string]any
+}
+
+// Callback abstracts a callback: the requests the API may send, keyed by
+// runtime expression (3.0+)
+type Callback interface {
+	HasRef() bool
+	GetRef() string
+	GetPathItems() map[string]PathItem
+	GetExtensions() map[string]any
+}
+
 // Discriminator abstracts polymorphism discriminator
 type Discriminator interface {
 	GetPropertyName() string
@@ -183,82 +448,170 @@ type XML interface {
 	GetWrapped() bool
 }
 
+// Server abstracts a server hosting the API
+type Server interface {
+	GetURL() string
+	GetDescription() string
+	GetVariables() map[string]ServerVariable
+	GetExtensions() map[string]any
+}
+
+// ServerVariable abstracts a variable of a server URL template
+type ServerVariable interface {
+	GetEnum() []string
+	GetDefault() string
+	GetDescription() string
+	GetExtensions() map[string]any
+}
+
+// Tag abstracts a tag declaration
+type Tag interface {
+	GetName() string
+	GetDescription() string
+	GetExternalDocs() ExternalDocumentation
+	GetExtensions() map[string]any
+}
+
 // ExternalDocumentation abstracts external documentation
 type ExternalDocumentation interface {
 	GetDescription() string
 	GetURL() string
 }
 
+// Contact abstracts the contact information of the API
+type Contact interface {
+	GetName() string
+	GetURL() string
+	GetEmail() string
+	GetExtensions() map[string]any
+}
+
+// License abstracts the license of the API
+type License interface {
+	GetName() string
+	GetIdentifier() string // SPDX expression, 3.1 and later
+	GetURL() string
+	GetExtensions() map[string]any
+}
+
 // NilRequestBody is returned when there is no request body
 type NilRequestBody struct{}
 
-func (n NilRequestBody) IsNil() bool                      { return true }
-func (n NilRequestBody) GetRequired() bool                { return false }
-func (n NilRequestBody) GetContent() map[string]MediaType { return nil }
-func (n NilRequestBody) GetDescription() string           { return "" }
-func (n NilRequestBody) GetExtensions() map[string]any    { return nil }
+func (n NilRequestBody) IsNil() bool                             { return true }
+func (n NilRequestBody) HasRef() bool                            { return false }
+func (n NilRequestBody) GetRef() string                          { return "" }
+func (n NilRequestBody) GetRequired() bool                       { return false }
+func (n NilRequestBody) GetContent() map[string]MediaType        { return nil }
+func (n NilRequestBody) GetMediaType(mediaType string) MediaType { return nil }
+func (n NilRequestBody) GetDescription() string                  { return "" }
+func (n NilRequestBody) GetExtensions() map[string]any           { return nil }
 
 // NilResponse is returned when there is no response
 type NilResponse struct{}
 
-func (n NilResponse) IsNil() bool                      { return true }
-func (n NilResponse) HasRef() bool                     { return false }
-func (n NilResponse) GetRef() string                   { return "" }
-func (n NilResponse) GetDescription() string           { return "" }
-func (n NilResponse) GetHeaders() map[string]Header    { return nil }
-func (n NilResponse) GetContent() map[string]MediaType { return nil }
-func (n NilResponse) GetSchema() Schema                { return nil }
-func (n NilResponse) GetExtensions() map[string]any    { return nil }
+func (n NilResponse) IsNil() bool                             { return true }
+func (n NilResponse) HasRef() bool                            { return false }
+func (n NilResponse) GetRef() string                          { return "" }
+func (n NilResponse) GetDescription() string                  { return "" }
+func (n NilResponse) GetHeaders() map[string]Header           { return nil }
+func (n NilResponse) GetContent() map[string]MediaType        { return nil }
+func (n NilResponse) GetMediaType(mediaType string) MediaType { return nil }
+func (n NilResponse) GetSchema() Schema                       { return nil }
+func (n NilResponse) GetExtensions() map[string]any           { return nil }
 
 // NilSchema is returned when there is no schema
 type NilSchema struct{}
 
-func (n NilSchema) IsNil() bool                            { return true }
-func (n NilSchema) GetRef() string                         { return "" }
-func (n NilSchema) GetType() string                        { return "" }
-func (n NilSchema) GetFormat() string                      { return "" }
-func (n NilSchema) GetDescription() string                 { return "" }
-func (n NilSchema) GetProperties() map[string]Schema       { return nil }
-func (n NilSchema) GetItems() Schema                       { return nil }
-func (n NilSchema) GetRequired() []string                  { return nil }
-func (n NilSchema) GetAllOf() []Schema                     { return nil }
-func (n NilSchema) GetOneOf() []Schema                     { return nil }
-func (n NilSchema) GetAnyOf() []Schema                     { return nil }
-func (n NilSchema) IsBooleanSchema() bool                  { return false }
-func (n NilSchema) GetBooleanValue() *bool                 { return nil }
-func (n NilSchema) GetExtensions() map[string]any          { return nil }
-func (n NilSchema) GetDiscriminator() Discriminator        { return nil }
-func (n NilSchema) GetXML() XML                            { return nil }
-func (n NilSchema) GetExternalDocs() ExternalDocumentation { return nil }
-func (n NilSchema) GetExample() any                        { return nil }
-func (n NilSchema) GetDefault() any                        { return nil }
-func (n NilSchema) GetDeprecated() bool                    { return false }
-func (n NilSchema) GetReadOnly() bool                      { return false }
-func (n NilSchema) GetWriteOnly() bool                     { return false }
+func (n NilSchema) IsNil() bool                               { return true }
+func (n NilSchema) GetRef() string                            { return "" }
+func (n NilSchema) GetType() string                           { return "" }
+func (n NilSchema) GetTypes() []string                        { return nil }
+func (n NilSchema) IsNullable() bool                          { return false }
+func (n NilSchema) GetFormat() string                         { return "" }
+func (n NilSchema) GetDescription() string                    { return "" }
+func (n NilSchema) GetProperties() map[string]Schema          { return nil }
+func (n NilSchema) GetItems() Schema                          { return nil }
+func (n NilSchema) GetAdditionalProperties() Schema           { return NilSchema{} }
+func (n NilSchema) GetPatternProperties() map[string]Schema   { return nil }
+func (n NilSchema) GetRequired() []string                     { return nil }
+func (n NilSchema) GetAllOf() []Schema                        { return nil }
+func (n NilSchema) GetOneOf() []Schema                        { return nil }
+func (n NilSchema) GetAnyOf() []Schema                        { return nil }
+func (n NilSchema) GetNot() Schema                            { return NilSchema{} }
+func (n NilSchema) GetIf() Schema                             { return NilSchema{} }
+func (n NilSchema) GetThen() Schema                           { return NilSchema{} }
+func (n NilSchema) GetElse() Schema                           { return NilSchema{} }
+func (n NilSchema) GetPrefixItems() []Schema                  { return nil }
+func (n NilSchema) GetContains() Schema                       { return NilSchema{} }
+func (n NilSchema) GetDependentSchemas() map[string]Schema    { return nil }
+func (n NilSchema) GetDependentRequired() map[string][]string { return nil }
+func (n NilSchema) IsBooleanSchema() bool                     { return false }
+func (n NilSchema) GetBooleanValue() *bool                    { return nil }
+func (n NilSchema) GetExtensions() map[string]any             { return nil }
+func (n NilSchema) GetDiscriminator() Discriminator           { return nil }
+func (n NilSchema) GetXML() XML                               { return nil }
+func (n NilSchema) GetExternalDocs() ExternalDocumentation    { return nil }
+func (n NilSchema) GetExample() any                           { return nil }
+func (n NilSchema) GetDefault() any                           { return nil }
+func (n NilSchema) GetDeprecated() bool                       { return false }
+func (n NilSchema) GetReadOnly() bool                         { return false }
+func (n NilSchema) GetWriteOnly() bool                        { return false }
+func (n NilSchema) GetTitle() string                          { return "" }
+func (n NilSchema) GetEnum() []any                            { return nil }
+func (n NilSchema) GetConst() any                             { return nil }
+func (n NilSchema) GetMinimum() *float64                      { return nil }
+func (n NilSchema) GetMaximum() *float64                      { return nil }
+func (n NilSchema) GetExclusiveMinimum() bool                 { return false }
+func (n NilSchema) GetExclusiveMaximum() bool                 { return false }
+func (n NilSchema) GetMinLength() *int                        { return nil }
+func (n NilSchema) GetMaxLength() *int                        { return nil }
+func (n NilSchema) GetPattern() string                        { return "" }
+func (n NilSchema) GetMinItems() *int                         { return nil }
+func (n NilSchema) GetMaxItems() *int                         { return nil }
+func (n NilSchema) GetMultipleOf() *float64                   { return nil }
+func (n NilSchema) GetUniqueItems() bool                      { return false }
 
 // NilOperation is returned when there is no operation
 type NilOperation struct{}
 
-func (n NilOperation) IsNil() bool                            { return true }
-func (n NilOperation) GetOperationID() string                 { return "" }
-func (n NilOperation) GetSummary() string                     { return "" }
-func (n NilOperation) GetDescription() string                 { return "" }
-func (n NilOperation) GetParameters() []Parameter             { return nil }
-func (n NilOperation) GetRequestBody() RequestBody            { return NilRequestBody{} }
-func (n NilOperation) GetResponses() Responses                { return nil }
-func (n NilOperation) GetSecurity() []SecurityRequirement     { return nil }
-func (n NilOperation) GetTags() []string                      { return nil }
-func (n NilOperation) GetExtensions() map[string]any          { return nil }
-func (n NilOperation) GetExternalDocs() ExternalDocumentation { return nil }
-func (n NilOperation) GetDeprecated() bool                    { return false }
+func (n NilOperation) IsNil() bool                                           { return true }
+func (n NilOperation) GetOperationID() string                                { return "" }
+func (n NilOperation) GetSummary() string                                    { return "" }
+func (n NilOperation) GetDescription() string                                { return "" }
+func (n NilOperation) GetParameters() []Parameter                            { return nil }
+func (n NilOperation) GetRequestBody() RequestBody                           { return NilRequestBody{} }
+func (n NilOperation) GetResponses() Responses                               { return nil }
+func (n NilOperation) GetSecurity() []SecurityRequirement                    { return nil }
+func (n NilOperation) GetTags() []string                                     { return nil }
+func (n NilOperation) GetExtensions() map[string]any                         { return nil }
+func (n NilOperation) GetExternalDocs() ExternalDocumentation                { return nil }
+func (n NilOperation) GetDeprecated() bool                                   { return false }
+func (n NilOperation) GetCallbacks() map[string]Callback                     { return nil }
+func (n NilOperation) EffectiveSecurity(doc Document) []EffectiveRequirement { return nil }
+func (n NilOperation) GetServers() []Server                                  { return nil }
 
 // NilResponses is returned when there are no responses
 type NilResponses struct{}
 
 func (n NilResponses) GetDefault() Response                { return NilResponse{} }
 func (n NilResponses) GetStatusCodes() map[string]Response { return nil }
+func (n NilResponses) GetByStatus(status int) Response     { return NilResponse{} }
 func (n NilResponses) GetExtensions() map[string]any       { return nil }
 
+// NilComponents is returned when a document has no components
+type NilComponents struct{}
+
+func (n NilComponents) GetSchemas() map[string]Schema            { return nil }
+func (n NilComponents) GetResponses() map[string]Response        { return nil }
+func (n NilComponents) GetParameters() map[string]Parameter      { return nil }
+func (n NilComponents) GetRequestBodies() map[string]RequestBody { return nil }
+func (n NilComponents) GetHeaders() map[string]Header            { return nil }
+func (n NilComponents) GetExamples() map[string]Example          { return nil }
+func (n NilComponents) GetLinks() map[string]Link                { return nil }
+func (n NilComponents) GetCallbacks() map[string]Callback        { return nil }
+func (n NilComponents) GetPathItems() map[string]PathItem        { return nil }
+func (n NilComponents) GetExtensions() map[string]any            { return nil }
+
 // BaseDiscriminator provides a default implementation for Discriminator
 type BaseDiscriminator struct {
 	PropertyName string
@@ -283,6 +636,45 @@ func (x BaseXML) GetPrefix() string    { return x.Prefix }
 func (x BaseXML) GetAttribute() bool   { return x.Attribute }
 func (x BaseXML) GetWrapped() bool     { return x.Wrapped }
 
+// BaseServer provides a default implementation for Server
+type BaseServer struct {
+	URL         string
+	Description string
+	Variables   map[string]ServerVariable
+	Extensions  map[string]any
+}
+
+func (s BaseServer) GetURL() string                          { return s.URL }
+func (s BaseServer) GetDescription() string                  { return s.Description }
+func (s BaseServer) GetVariables() map[string]ServerVariable { return s.Variables }
+func (s BaseServer) GetExtensions() map[string]any           { return s.Extensions }
+
+// BaseServerVariable provides a default implementation for ServerVariable
+type BaseServerVariable struct {
+	Enum        []string
+	Default     string
+	Description string
+	Extensions  map[string]any
+}
+
+func (v BaseServerVariable) GetEnum() []string             { return v.Enum }
+func (v BaseServerVariable) GetDefault() string            { return v.Default }
+func (v BaseServerVariable) GetDescription() string        { return v.Description }
+func (v BaseServerVariable) GetExtensions() map[string]any { return v.Extensions }
+
+// BaseTag provides a default implementation for Tag
+type BaseTag struct {
+	Name         string
+	Description  string
+	ExternalDocs ExternalDocumentation
+	Extensions   map[string]any
+}
+
+func (t BaseTag) GetName() string                        { return t.Name }
+func (t BaseTag) GetDescription() string                 { return t.Description }
+func (t BaseTag) GetExternalDocs() ExternalDocumentation { return t.ExternalDocs }
+func (t BaseTag) GetExtensions() map[string]any          { return t.Extensions }
+
 // BaseExternalDocs provides a default implementation for ExternalDocumentation
 type BaseExternalDocs struct {
 	Description string
@@ -291,3 +683,29 @@ type BaseExternalDocs struct {
 
 func (e BaseExternalDocs) GetDescription() string { return e.Description }
 func (e BaseExternalDocs) GetURL() string         { return e.URL }
+
+// BaseContact provides a default implementation for Contact
+type BaseContact struct {
+	Name       string
+	URL        string
+	Email      string
+	Extensions map[string]any
+}
+
+func (c BaseContact) GetName() string               { return c.Name }
+func (c BaseContact) GetURL() string                { return c.URL }
+func (c BaseContact) GetEmail() string              { return c.Email }
+func (c BaseContact) GetExtensions() map[string]any { return c.Extensions }
+
+// BaseLicense provides a default implementation for License
+type BaseLicense struct {
+	Name       string
+	Identifier string
+	URL        string
+	Extensions map[string]any
+}
+
+func (l BaseLicense) GetName() string               { return l.Name }
+func (l BaseLicense) GetIdentifier() string         { return l.Identifier }
+func (l BaseLicense) GetURL() string                { return l.URL }
+func (l BaseLicense) GetExtensions() map[string]any { return l.Extensions }
@@ -0,0 +1,608 @@
+// Package unified provides the merging of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+	oa32 "github.com/genelet/oas/openapi32"
+)
+
+// MergeStrategy decides what Merge does when both documents define a path
+// field, a component, a security scheme or a tag differently
+type MergeStrategy int
+
+const (
+	// MergeError fails the merge
+	MergeError MergeStrategy = iota
+
+	// MergeRename keeps both values: the component, security scheme, tag or
+	// operationId of the source is renamed with MergeOptions.Prefix, and the
+	// references, security requirements, operation tags and links of the
+	// source follow it. Paths cannot be renamed, so a conflicting path field
+	// is still an error.
+	MergeRename
+
+	// MergePreferDst keeps the value of the destination; a source operation
+	// with the operationId of another destination operation loses its
+	// operationId
+	MergePreferDst
+)
+
+// MergeOptions configures Merge
+type MergeOptions struct {
+	Strategy MergeStrategy
+
+	// Prefix is prepended to the names that MergeRename changes
+	Prefix string
+}
+
+// Merge adds the paths, webhooks, components, security schemes and tags of
+// src to dst, which changes in place. src is first converted to the version of
+// dst. A value defined the same way in both documents is not a conflict; the
+// strategy of opts settles the others, including a source operation with the
+// operationId of another operation of dst. When the global security
+// requirements differ, the operations of src that rely on them get them as
+// their own, so that merging does not change who may call them.
+//
+// The servers, info and other top-level fields of src are not merged. On
+// error dst is left unchanged.
+func Merge(dst, src Document, opts MergeOptions) error {
+	if dst == nil || src == nil {
+		return fmt.Errorf("no document to merge")
+	}
+	if opts.Strategy == MergeRename && opts.Prefix == "" {
+		return fmt.Errorf("MergeRename needs a prefix")
+	}
+	converted, _, err := convertDocument(src, dst.Version())
+	if err != nil {
+		return err
+	}
+	target, err := documentObject(dst)
+	if err != nil {
+		return err
+	}
+	source, err := documentObject(converted)
+	if err != nil {
+		return err
+	}
+	m := &merger{
+		dst:     target,
+		src:     source,
+		opts:    opts,
+		refs:    make(map[string]string),
+		schemes: make(map[string]string),
+		tags:    make(map[string]string),
+		ids:     make(map[string]string),
+	}
+	if err := m.merge(strings.HasPrefix(dst.Version(), "2.")); err != nil {
+		return err
+	}
+	return setDocumentObject(dst, target)
+}
+
+// documentObject returns the generic JSON form of the version-specific
+// document of an adapter
+func documentObject(doc Document) (map[string]any, error) {
+	var raw any
+	switch d := doc.(type) {
+	case *Document20:
+		raw = d.doc
+	case *Document30:
+		raw = d.doc
+	case *Document31:
+		raw = d.doc
+	case *Document32:
+		raw = d.doc
+	default:
+		return nil, fmt.Errorf("unsupported document type %T", doc)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("empty %T", doc)
+	}
+	return root, nil
+}
+
+// setDocumentObject replaces the version-specific document of an adapter with
+// a generic JSON form, keeping its pointer so that the adapter sees the change
+func setDocumentObject(doc Document, root map[string]any) error {
+	data, err := json.Marshal(root)
+	if err != nil {
+		return err
+	}
+	switch d := doc.(type) {
+	case *Document20:
+		var raw oa2.Swagger
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		*d.doc = raw
+	case *Document30:
+		var raw oa3.OpenAPI
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		*d.doc = raw
+	case *Document31:
+		var raw oa31.OpenAPI
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		*d.doc = raw
+	case *Document32:
+		var raw oa32.OpenAPI
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		*d.doc = raw
+	}
+	return nil
+}
+
+// errMergeConflict reports a value both documents define differently
+func errMergeConflict(ptr string) error {
+	return fmt.Errorf("merge conflict at %s", ptr)
+}
+
+// merger merges the generic JSON form of a source document into that of a
+// destination document
+type merger struct {
+	dst, src map[string]any
+	opts     MergeOptions
+	refs     map[string]string // renamed component references of the source
+	schemes  map[string]string // renamed security schemes of the source
+	tags     map[string]string // renamed tags of the source
+	ids      map[string]string // renamed operationIds of the source
+}
+
+// componentSection is a map of named, reusable values
+type componentSection struct {
+	tokens []string // the location of the section in the document
+	ref    string   // the reference prefix of its values, "" for security schemes
+}
+
+// componentSections returns the component sections of a document
+func componentSections(root map[string]any, v20 bool) []componentSection {
+	if v20 {
+		return []componentSection{
+			{[]string{"definitions"}, "#/definitions"},
+			{[]string{"parameters"}, "#/parameters"},
+			{[]string{"responses"}, "#/responses"},
+			{[]string{"securityDefinitions"}, ""},
+		}
+	}
+	var sections []componentSection
+	components, _ := root["components"].(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(components)) {
+		if strings.HasPrefix(name, "x-") {
+			continue
+		}
+		section := componentSection{[]string{"components", name}, pointer("#", "components", name)}
+		if name == "securitySchemes" {
+			section.ref = ""
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+func (m *merger) merge(v20 bool) error {
+	sections := componentSections(m.src, v20)
+	for _, section := range sections {
+		if err := m.resolveSection(section); err != nil {
+			return err
+		}
+	}
+	if err := m.resolveTags(); err != nil {
+		return err
+	}
+	if err := m.resolveOperationIDs(); err != nil {
+		return err
+	}
+	// The global requirements are compared once their schemes are renamed
+	m.rewrite(m.src, false)
+	m.keepSecurity()
+	for _, section := range sections {
+		m.copySection(section)
+	}
+	m.copyTags()
+	for _, name := range []string{"paths", "webhooks"} {
+		if err := m.paths(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// object returns the object of a document at tokens, creating the missing
+// objects when create is true
+func object(root map[string]any, create bool, tokens ...string) map[string]any {
+	current := root
+	for _, token := range tokens {
+		next, ok := current[token].(map[string]any)
+		if !ok {
+			if !create {
+				return nil
+			}
+			next = make(map[string]any)
+			current[token] = next
+		}
+		current = next
+	}
+	return current
+}
+
+// resolveSection settles the conflicts of a component section in the source:
+// it drops the values the destination keeps and renames the others
+func (m *merger) resolveSection(section componentSection) error {
+	src := object(m.src, false, section.tokens...)
+	dst := object(m.dst, false, section.tokens...)
+	for _, name := range slices.Sorted(maps.Keys(src)) {
+		existing, ok := dst[name]
+		if !ok || reflect.DeepEqual(existing, src[name]) {
+			continue
+		}
+		switch m.opts.Strategy {
+		case MergePreferDst:
+			delete(src, name)
+			continue
+		case MergeRename:
+			renamed := m.opts.Prefix + name
+			if _, taken := dst[renamed]; taken {
+				return errMergeConflict(pointer("", append(section.tokens, renamed)...))
+			}
+			if _, taken := src[renamed]; taken {
+				return errMergeConflict(pointer("", append(section.tokens, renamed)...))
+			}
+			src[renamed] = src[name]
+			delete(src, name)
+			if section.ref == "" {
+				m.schemes[name] = renamed
+			} else {
+				m.refs[pointer(section.ref, name)] = pointer(section.ref, renamed)
+			}
+			continue
+		}
+		return errMergeConflict(pointer("", append(section.tokens, name)...))
+	}
+	return nil
+}
+
+// copySection adds the values of a component section of the source to the
+// destination
+func (m *merger) copySection(section componentSection) {
+	src := object(m.src, false, section.tokens...)
+	if len(src) == 0 {
+		return
+	}
+	dst := object(m.dst, true, section.tokens...)
+	for name, value := range src {
+		dst[name] = value
+	}
+}
+
+// tagName returns the name of a tag object
+func tagName(tag any) string {
+	t, _ := tag.(map[string]any)
+	name, _ := t["name"].(string)
+	return name
+}
+
+// tagIndex returns the index of the tag with the name, or -1
+func tagIndex(tags []any, name string) int {
+	return slices.IndexFunc(tags, func(tag any) bool { return tagName(tag) == name })
+}
+
+// resolveTags settles the conflicts of the tags of the source like those of
+// the components
+func (m *merger) resolveTags() error {
+	src, _ := m.src["tags"].([]any)
+	dst, _ := m.dst["tags"].([]any)
+	var kept []any
+	for _, tag := range src {
+		name := tagName(tag)
+		i := tagIndex(dst, name)
+		if i < 0 || reflect.DeepEqual(dst[i], tag) {
+			kept = append(kept, tag)
+			continue
+		}
+		switch m.opts.Strategy {
+		case MergePreferDst:
+			continue
+		case MergeRename:
+			renamed := m.opts.Prefix + name
+			if tagIndex(dst, renamed) >= 0 || tagIndex(src, renamed) >= 0 {
+				return errMergeConflict(pointer("", "tags", renamed))
+			}
+			tag.(map[string]any)["name"] = renamed
+			m.tags[name] = renamed
+			kept = append(kept, tag)
+			continue
+		}
+		return errMergeConflict(pointer("", "tags", name))
+	}
+	if src != nil {
+		m.src["tags"] = kept
+	}
+	return nil
+}
+
+// copyTags appends the tags of the source the destination does not declare
+func (m *merger) copyTags() {
+	src, _ := m.src["tags"].([]any)
+	dst, _ := m.dst["tags"].([]any)
+	for _, tag := range src {
+		if tagIndex(dst, tagName(tag)) < 0 {
+			dst = append(dst, tag)
+		}
+	}
+	if len(dst) > 0 {
+		m.dst["tags"] = dst
+	}
+}
+
+// pathItemMethods are the operation fields of a path item
+var pathItemMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace", "query"}
+
+// pathOperation is an operation of a path item in generic JSON form
+type pathOperation struct {
+	method string
+	op     map[string]any
+}
+
+// operations returns the operations of a path item, the fixed fields first
+func operations(item map[string]any) []pathOperation {
+	var result []pathOperation
+	for _, method := range pathItemMethods {
+		if op, ok := item[method].(map[string]any); ok {
+			result = append(result, pathOperation{method, op})
+		}
+	}
+	additional, _ := item["additionalOperations"].(map[string]any)
+	for _, name := range slices.Sorted(maps.Keys(additional)) {
+		if op, ok := additional[name].(map[string]any); ok {
+			result = append(result, pathOperation{name, op})
+		}
+	}
+	return result
+}
+
+// namedOperation is an operation with an operationId, and its pointer
+type namedOperation struct {
+	ptr string
+	op  map[string]any
+}
+
+// operationIDs returns the operations of the paths and webhooks of a
+// document by operationId
+func operationIDs(root map[string]any) map[string]namedOperation {
+	ids := make(map[string]namedOperation)
+	for _, name := range []string{"paths", "webhooks"} {
+		for path, item := range object(root, false, name) {
+			i, _ := item.(map[string]any)
+			for _, o := range operations(i) {
+				id, ok := o.op["operationId"].(string)
+				if !ok || id == "" {
+					continue
+				}
+				ptr := pointer("", name, path, o.method)
+				if !slices.Contains(pathItemMethods, o.method) {
+					ptr = pointer("", name, path, "additionalOperations", o.method)
+				}
+				ids[id] = namedOperation{ptr, o.op}
+			}
+		}
+	}
+	return ids
+}
+
+// resolveOperationIDs settles the operationIds of the source that name
+// another operation of the destination, as those would no longer be unique
+func (m *merger) resolveOperationIDs() error {
+	dst := operationIDs(m.dst)
+	src := operationIDs(m.src)
+	for _, id := range slices.Sorted(maps.Keys(src)) {
+		named := src[id]
+		if existing, ok := dst[id]; !ok || existing.ptr == named.ptr {
+			continue
+		}
+		switch m.opts.Strategy {
+		case MergeRename:
+			renamed := m.opts.Prefix + id
+			_, inDst := dst[renamed]
+			_, inSrc := src[renamed]
+			if inDst || inSrc {
+				return errMergeConflict(pointer(named.ptr, "operationId"))
+			}
+			m.ids[id] = renamed
+			continue
+		case MergePreferDst:
+			delete(named.op, "operationId")
+			continue
+		}
+		return errMergeConflict(pointer(named.ptr, "operationId"))
+	}
+	return nil
+}
+
+// keepSecurity gives the global security requirements of the source to its
+// operations without their own, when the destination has different ones
+func (m *merger) keepSecurity() {
+	global, ok := m.src["security"]
+	if reflect.DeepEqual(global, m.dst["security"]) {
+		return
+	}
+	if !ok {
+		global = []any{}
+	}
+	for _, name := range []string{"paths", "webhooks"} {
+		for _, item := range object(m.src, false, name) {
+			i, _ := item.(map[string]any)
+			for _, o := range operations(i) {
+				if _, ok := o.op["security"]; !ok {
+					o.op["security"] = global
+				}
+			}
+		}
+	}
+}
+
+// rename returns a reference with a renamed component as its target, or the
+// reference unchanged
+func (m *merger) rename(ref string) string {
+	for old, renamed := range m.refs {
+		if ref == old || strings.HasPrefix(ref, old+"/") {
+			return renamed + ref[len(old):]
+		}
+	}
+	return ref
+}
+
+// literalKeywords hold instance data, such as examples and defaults, which
+// are left as they are even when they look like references
+var literalKeywords = map[string]bool{
+	"default": true, "enum": true, "const": true, "example": true, "examples": true,
+}
+
+// namedKeywords hold maps keyed by names, such as the default response or a
+// property called example, rather than by keywords
+var namedKeywords = map[string]bool{
+	"paths": true, "webhooks": true, "responses": true, "callbacks": true,
+	"content": true, "encoding": true, "headers": true, "links": true,
+	"schemas": true, "definitions": true, "$defs": true, "properties": true,
+	"patternProperties": true, "dependentSchemas": true, "parameters": true,
+	"requestBodies": true, "securitySchemes": true, "securityDefinitions": true,
+	"pathItems": true, "mediaTypes": true,
+}
+
+// rewrite points the references, discriminator mappings, security
+// requirements, operation tags and operationIds of the source at the
+// renamed values. named tells whether v is a map keyed by names.
+func (m *merger) rewrite(v any, named bool) {
+	switch x := v.(type) {
+	case map[string]any:
+		for key, value := range x {
+			if named {
+				m.rewrite(value, false)
+				continue
+			}
+			switch key {
+			case "$ref":
+				if ref, ok := value.(string); ok {
+					x[key] = m.rename(ref)
+				}
+			case "mapping":
+				if mapping, ok := value.(map[string]any); ok {
+					for name, target := range mapping {
+						if ref, ok := target.(string); ok {
+							mapping[name] = m.rename(ref)
+						}
+					}
+				}
+			case "security":
+				requirements, _ := value.([]any)
+				for _, requirement := range requirements {
+					r, _ := requirement.(map[string]any)
+					for old, renamed := range m.schemes {
+						if scopes, ok := r[old]; ok {
+							delete(r, old)
+							r[renamed] = scopes
+						}
+					}
+				}
+			case "operationId":
+				// Operations, and the links naming them
+				if id, ok := value.(string); ok && m.ids[id] != "" {
+					x[key] = m.ids[id]
+				}
+			case "tags":
+				tags, _ := value.([]any)
+				for i, tag := range tags {
+					if name, ok := tag.(string); ok && m.tags[name] != "" {
+						tags[i] = m.tags[name]
+					}
+				}
+			case "examples":
+				// Only the references of example objects, not their values
+				examples, _ := value.(map[string]any)
+				for _, example := range examples {
+					e, _ := example.(map[string]any)
+					if ref, ok := e["$ref"].(string); ok {
+						e["$ref"] = m.rename(ref)
+					}
+				}
+			}
+			if !literalKeywords[key] {
+				m.rewrite(value, namedKeywords[key])
+			}
+		}
+	case []any:
+		for _, value := range x {
+			m.rewrite(value, false)
+		}
+	}
+}
+
+// paths adds the path items of a section of the source to the destination,
+// field by field when both documents have the path
+func (m *merger) paths(name string) error {
+	src := object(m.src, false, name)
+	if len(src) == 0 {
+		return nil
+	}
+	dst := object(m.dst, true, name)
+	for _, path := range slices.Sorted(maps.Keys(src)) {
+		item, _ := src[path].(map[string]any)
+		existing, ok := dst[path].(map[string]any)
+		if !ok || item == nil {
+			if _, taken := dst[path]; !taken {
+				dst[path] = src[path]
+			} else if !reflect.DeepEqual(dst[path], src[path]) && m.opts.Strategy != MergePreferDst {
+				return errMergeConflict(pointer("", name, path))
+			}
+			continue
+		}
+		if err := m.fields(existing, item, pointer("", name, path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fields adds the fields of a source path item to a destination one
+func (m *merger) fields(dst, src map[string]any, ptr string) error {
+	for _, key := range slices.Sorted(maps.Keys(src)) {
+		existing, ok := dst[key]
+		d, dok := existing.(map[string]any)
+		s, sok := src[key].(map[string]any)
+		switch {
+		case !ok:
+			dst[key] = src[key]
+		case reflect.DeepEqual(existing, src[key]):
+		case key == "additionalOperations" && dok && sok:
+			if err := m.fields(d, s, pointer(ptr, key)); err != nil {
+				return err
+			}
+		case m.opts.Strategy != MergePreferDst:
+			return errMergeConflict(pointer(ptr, key))
+		}
+	}
+	return nil
+}
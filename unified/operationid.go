@@ -0,0 +1,137 @@
+// Package unified provides the generation of operationIds for unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// OperationIDStrategy decides how GenerateOperationIDs names an operation
+type OperationIDStrategy int
+
+const (
+	// OperationIDMethodPath joins the method and the path in camel case:
+	// GET /pets/{petId}/toys is getPetsByPetIdToys
+	OperationIDMethodPath OperationIDStrategy = iota
+
+	// OperationIDTagPrefixed puts the first tag of the operation in front of
+	// OperationIDMethodPath: petsGetPetsByPetIdToys for the tag "pets".
+	// Operations without tags are named as with OperationIDMethodPath.
+	OperationIDTagPrefixed
+)
+
+// OperationIDCollision is a generated operationId another operation already
+// had, or was given first, so that the operation got a numbered one instead
+type OperationIDCollision struct {
+	Path     string // the path, or the webhook name
+	Method   string
+	ID       string // the generated operationId
+	Assigned string // the operationId given instead
+}
+
+func (c OperationIDCollision) String() string {
+	return fmt.Sprintf("%s %s: %s is taken, assigned %s", strings.ToUpper(c.Method), c.Path, c.ID, c.Assigned)
+}
+
+// GenerateOperationIDs gives, in place, an operationId to every operation of
+// the paths and webhooks without one. The names depend only on the document,
+// not on map order: operations are named in path and method order, and a
+// name already taken gets the first free numeric suffix, starting at 2, and
+// is reported as a collision.
+func GenerateOperationIDs(doc Document, strategy OperationIDStrategy) ([]OperationIDCollision, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to name operations in")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	sections := []string{"paths", "webhooks"}
+	taken := make(map[string]bool)
+	for _, section := range sections {
+		for _, item := range object(root, false, section) {
+			i, _ := item.(map[string]any)
+			for _, o := range operations(i) {
+				if id, ok := o.op["operationId"].(string); ok && id != "" {
+					taken[id] = true
+				}
+			}
+		}
+	}
+
+	var collisions []OperationIDCollision
+	changed := false
+	for _, section := range sections {
+		items := object(root, false, section)
+		for _, path := range slices.Sorted(maps.Keys(items)) {
+			i, _ := items[path].(map[string]any)
+			for _, o := range operations(i) {
+				if id, ok := o.op["operationId"].(string); ok && id != "" {
+					continue
+				}
+				id := operationID(path, o, strategy)
+				assigned := id
+				for n := 2; taken[assigned]; n++ {
+					assigned = id + strconv.Itoa(n)
+				}
+				if assigned != id {
+					collisions = append(collisions, OperationIDCollision{Path: path, Method: o.method, ID: id, Assigned: assigned})
+				}
+				taken[assigned] = true
+				o.op["operationId"] = assigned
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	return collisions, setDocumentObject(doc, root)
+}
+
+// operationID names an operation with a strategy
+func operationID(path string, o pathOperation, strategy OperationIDStrategy) string {
+	words := []string{strings.ToLower(o.method)}
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := strings.CutPrefix(segment, "{"); ok {
+			words = append(words, "by")
+			segment = strings.TrimSuffix(name, "}")
+		}
+		words = append(words, identifierWords(segment)...)
+	}
+	if tags, _ := o.op["tags"].([]any); strategy == OperationIDTagPrefixed && len(tags) > 0 {
+		if tag, ok := tags[0].(string); ok {
+			words = append(identifierWords(tag), words...)
+		}
+	}
+	return camelCase(words)
+}
+
+// identifierWords splits text into its runs of letters and digits
+func identifierWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// camelCase joins words, lower-casing the first letter of the first one and
+// upper-casing that of the others
+func camelCase(words []string) string {
+	var b strings.Builder
+	for i, word := range words {
+		runes := []rune(word)
+		if i == 0 {
+			runes[0] = unicode.ToLower(runes[0])
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
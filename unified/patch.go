@@ -0,0 +1,344 @@
+// Package unified provides JSON Patch and JSON Merge Patch for unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// patchOperation is an operation of a JSON Patch (RFC 6902)
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// ApplyJSONPatch applies, in place, a JSON Patch (RFC 6902) to a document.
+// The operations address the document by JSON pointer and apply in order;
+// if one fails, or the patched document does not validate, the document is
+// left unchanged and the error returned.
+func ApplyJSONPatch(doc Document, patch []byte) error {
+	if doc == nil {
+		return fmt.Errorf("no document to patch")
+	}
+	var ops []patchOperation
+	if err := json.Unmarshal(patch, &ops); err != nil {
+		return fmt.Errorf("invalid JSON patch: %w", err)
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return err
+	}
+	var patched any = root
+	for i, op := range ops {
+		if patched, err = op.apply(patched); err != nil {
+			return fmt.Errorf("patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return setPatched(doc, patched)
+}
+
+// ApplyMergePatch applies, in place, a JSON Merge Patch (RFC 7396) to a
+// document: the objects of the patch merge into those of the document, and
+// its null members remove theirs. If the patched document does not validate,
+// the document is left unchanged and the error returned.
+func ApplyMergePatch(doc Document, patch []byte) error {
+	if doc == nil {
+		return fmt.Errorf("no document to patch")
+	}
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return fmt.Errorf("invalid merge patch: %w", err)
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return err
+	}
+	return setPatched(doc, mergePatch(root, p))
+}
+
+// setPatched replaces a document with its patched generic JSON form, unless
+// it is not an object or does not validate
+func setPatched(doc Document, patched any) error {
+	root, ok := patched.(map[string]any)
+	if !ok {
+		return fmt.Errorf("patched document is not an object")
+	}
+	original, err := documentObject(doc)
+	if err != nil {
+		return err
+	}
+	if err := setDocumentObject(doc, root); err != nil {
+		return err
+	}
+	if err := validateDocument(doc); err != nil {
+		if restore := setDocumentObject(doc, original); restore != nil {
+			return restore
+		}
+		return fmt.Errorf("patched document is invalid: %w", err)
+	}
+	return nil
+}
+
+// validateDocument validates the version-specific document of an adapter,
+// returning its findings with SeverityError as an error
+func validateDocument(doc Document) error {
+	switch d := doc.(type) {
+	case *Document20:
+		return d.doc.Validate().Err()
+	case *Document30:
+		return d.doc.Validate().Err()
+	case *Document31:
+		return d.doc.Validate().Err()
+	case *Document32:
+		return d.doc.Validate().Err()
+	}
+	return fmt.Errorf("unsupported document type %T", doc)
+}
+
+// mergePatch merges a merge patch into a generic JSON value
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any)
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+		} else {
+			t[key] = mergePatch(t[key], value)
+		}
+	}
+	return t
+}
+
+// apply applies the operation to a generic JSON value and returns the result
+func (op patchOperation) apply(root any) (any, error) {
+	path, err := pointerTokens(op.Path)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, err
+		}
+	case "move", "copy":
+		from, err := pointerTokens(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = pointerValue(root, from); err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			value = copyValue(value)
+		} else {
+			if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+				return nil, fmt.Errorf("cannot move %s into itself", op.From)
+			}
+			if root, err = patchRemove(root, from); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch op.Op {
+	case "add", "move", "copy":
+		return patchAdd(root, path, value)
+	case "remove":
+		return patchRemove(root, path)
+	case "replace":
+		return patchReplace(root, path, value)
+	case "test":
+		current, err := pointerValue(root, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return root, nil
+	}
+	return nil, fmt.Errorf("unknown operation %q", op.Op)
+}
+
+// pointerTokens splits a JSON pointer into its unescaped reference tokens
+func pointerTokens(ptr string) ([]string, error) {
+	if ptr == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(ptr, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", ptr)
+	}
+	tokens := strings.Split(ptr[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses the reference token of an array element; size allows
+// the index one past the end, where add appends
+func arrayIndex(token string, length int, size bool) (int, error) {
+	if token == "-" && size {
+		return length, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > length || (i == length && !size) {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+// pointerValue returns the value at the reference tokens
+func pointerValue(node any, tokens []string) (any, error) {
+	for _, token := range tokens {
+		switch x := node.(type) {
+		case map[string]any:
+			value, ok := x[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			node = value
+		case []any:
+			i, err := arrayIndex(token, len(x), false)
+			if err != nil {
+				return nil, err
+			}
+			node = x[i]
+		default:
+			return nil, fmt.Errorf("no member %q in a scalar", token)
+		}
+	}
+	return node, nil
+}
+
+// patchAt changes the container holding the last reference token, and
+// returns node with the changed container in place
+func patchAt(node any, tokens []string, change func(container any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return change(node, tokens[0])
+	}
+	child, err := pointerValue(node, tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+	if child, err = patchAt(child, tokens[1:], change); err != nil {
+		return nil, err
+	}
+	if x, ok := node.(map[string]any); ok {
+		x[tokens[0]] = child
+	} else {
+		i, _ := strconv.Atoi(tokens[0])
+		node.([]any)[i] = child
+	}
+	return node, nil
+}
+
+// patchReplace replaces the value at the reference tokens, which must exist
+func patchReplace(root any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return patchAt(root, tokens, func(container any, token string) (any, error) {
+		switch x := container.(type) {
+		case map[string]any:
+			if _, ok := x[token]; !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			x[token] = value
+			return x, nil
+		case []any:
+			i, err := arrayIndex(token, len(x), false)
+			if err != nil {
+				return nil, err
+			}
+			x[i] = value
+			return x, nil
+		}
+		return nil, fmt.Errorf("no member %q in a scalar", token)
+	})
+}
+
+// patchAdd adds or replaces the value at the reference tokens
+func patchAdd(root any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return patchAt(root, tokens, func(container any, token string) (any, error) {
+		switch x := container.(type) {
+		case map[string]any:
+			x[token] = value
+			return x, nil
+		case []any:
+			i, err := arrayIndex(token, len(x), true)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Insert(x, i, value), nil
+		}
+		return nil, fmt.Errorf("cannot add %q to a scalar", token)
+	})
+}
+
+// patchRemove removes the value at the reference tokens
+func patchRemove(root any, tokens []string) (any, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("cannot remove the document")
+	}
+	return patchAt(root, tokens, func(container any, token string) (any, error) {
+		switch x := container.(type) {
+		case map[string]any:
+			if _, ok := x[token]; !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			delete(x, token)
+			return x, nil
+		case []any:
+			i, err := arrayIndex(token, len(x), false)
+			if err != nil {
+				return nil, err
+			}
+			return slices.Delete(x, i, i+1), nil
+		}
+		return nil, fmt.Errorf("no member %q in a scalar", token)
+	})
+}
+
+// copyValue deep-copies a generic JSON value
+func copyValue(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(x))
+		for key, value := range x {
+			result[key] = copyValue(value)
+		}
+		return result
+	case []any:
+		result := make([]any, len(x))
+		for i, value := range x {
+			result[i] = copyValue(value)
+		}
+		return result
+	}
+	return v
+}
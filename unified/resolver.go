@@ -0,0 +1,415 @@
+// Package unified provides reference resolution for unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	oa2 "github.com/genelet/oas/openapi20"
+	oa3 "github.com/genelet/oas/openapi30"
+	oa31 "github.com/genelet/oas/openapi31"
+	oa32 "github.com/genelet/oas/openapi32"
+)
+
+// Resolve returns an adapter over the same version-specific document whose
+// accessors follow local references to components. A schema, parameter,
+// response, request body or 3.1+ path item that is such a reference is
+// returned as the component it points at, so it no longer HasRef; RawRef
+// returns the reference it replaced. References into other documents, to
+// missing components or in a cycle are returned as they are.
+func Resolve(doc Document) (Document, error) {
+	switch d := doc.(type) {
+	case *Document20:
+		return &Document20{doc: d.doc, r: &resolver20{doc: d.doc}}, nil
+	case *Document30:
+		return &Document30{doc: d.doc, r: &resolver30{doc: d.doc}}, nil
+	case *Document31:
+		return &Document31{doc: d.doc, r: &resolver31{doc: d.doc}}, nil
+	case *Document32:
+		return &Document32{doc: d.doc, r: &resolver32{doc: d.doc}}, nil
+	}
+	return nil, fmt.Errorf("unsupported document type %T", doc)
+}
+
+// RawRef returns the reference that a value of a resolving document was
+// reached through, or "" if it was not reached through one
+func RawRef(value any) string {
+	if v, ok := value.(interface{ rawRef() string }); ok {
+		return v.rawRef()
+	}
+	return ""
+}
+
+// refSource is the reference a resolved value was reached through
+type refSource string
+
+func (s refSource) rawRef() string {
+	return string(s)
+}
+
+// maxRefDepth bounds the references followed through a chain of components
+const maxRefDepth = 64
+
+// follow resolves a local reference to a component of a section, such as
+// "#/components/schemas/". lookup returns the reference of the named
+// component, "" when it is not a reference, and false when there is no such
+// component. follow returns the name of the component at the end of the
+// chain, and false for other references, missing components and cycles.
+func follow(ref, prefix string, lookup func(name string) (string, bool)) (string, bool) {
+	for depth := 0; depth < maxRefDepth; depth++ {
+		escaped, ok := strings.CutPrefix(ref, prefix)
+		if !ok {
+			return "", false
+		}
+		name, err := url.PathUnescape(escaped)
+		if err != nil {
+			return "", false
+		}
+		name = strings.ReplaceAll(strings.ReplaceAll(name, "~1", "/"), "~0", "~")
+		next, ok := lookup(name)
+		if !ok {
+			return "", false
+		}
+		if next == "" {
+			return name, true
+		}
+		ref = next
+	}
+	return "", false
+}
+
+// resolver20 follows the local references of an OpenAPI 2.0 document
+type resolver20 struct {
+	doc *oa2.Swagger
+}
+
+func (r *resolver20) schema(s *oa2.Schema) *schema20 {
+	if r != nil && s != nil && s.Ref != "" {
+		components := r.doc.Definitions
+		name, ok := follow(s.Ref, "#/definitions/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &schema20{schema: components[name], refSource: refSource(s.Ref), r: r}
+		}
+	}
+	return &schema20{schema: s, r: r}
+}
+
+func (r *resolver20) parameter(p *oa2.Parameter) *parameter20 {
+	if r != nil && p.IsReference() {
+		components := r.doc.Parameters
+		name, ok := follow(p.Ref, "#/parameters/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &parameter20{param: components[name], r: r, refSource: refSource(p.Ref)}
+		}
+	}
+	return &parameter20{param: p, r: r}
+}
+
+func (r *resolver20) response(resp *oa2.Response, produces []string) *response20 {
+	if r != nil && resp.IsReference() {
+		components := r.doc.Responses
+		name, ok := follow(resp.Ref, "#/responses/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &response20{resp: components[name], produces: produces, refSource: refSource(resp.Ref), r: r}
+		}
+	}
+	return &response20{resp: resp, produces: produces, r: r}
+}
+
+// resolver30 follows the local references of an OpenAPI 3.0 document
+type resolver30 struct {
+	doc *oa3.OpenAPI
+}
+
+// components returns the components of the document, or empty ones
+func (r *resolver30) components() *oa3.Components {
+	if r.doc.Components == nil {
+		return &oa3.Components{}
+	}
+	return r.doc.Components
+}
+
+func (r *resolver30) schema(s *oa3.Schema) *schema30 {
+	if r != nil && s != nil && s.Ref != "" {
+		components := r.components().Schemas
+		name, ok := follow(s.Ref, "#/components/schemas/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &schema30{schema: components[name], refSource: refSource(s.Ref), r: r}
+		}
+	}
+	return &schema30{schema: s, r: r}
+}
+
+func (r *resolver30) parameter(p *oa3.Parameter) *parameter30 {
+	if r != nil && p.IsReference() {
+		components := r.components().Parameters
+		name, ok := follow(p.Ref, "#/components/parameters/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &parameter30{param: components[name], refSource: refSource(p.Ref), r: r}
+		}
+	}
+	return &parameter30{param: p, r: r}
+}
+
+func (r *resolver30) response(resp *oa3.Response) *response30 {
+	if r != nil && resp.IsReference() {
+		components := r.components().Responses
+		name, ok := follow(resp.Ref, "#/components/responses/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &response30{resp: components[name], refSource: refSource(resp.Ref), r: r}
+		}
+	}
+	return &response30{resp: resp, r: r}
+}
+
+func (r *resolver30) requestBody(rb *oa3.RequestBody) *requestBody30 {
+	if r != nil && rb.IsReference() {
+		components := r.components().RequestBodies
+		name, ok := follow(rb.Ref, "#/components/requestBodies/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &requestBody30{rb: components[name], refSource: refSource(rb.Ref), r: r}
+		}
+	}
+	return &requestBody30{rb: rb, r: r}
+}
+
+// resolver31 follows the local references of an OpenAPI 3.1 document
+type resolver31 struct {
+	doc *oa31.OpenAPI
+}
+
+// components returns the components of the document, or empty ones
+func (r *resolver31) components() *oa31.Components {
+	if r.doc.Components == nil {
+		return &oa31.Components{}
+	}
+	return r.doc.Components
+}
+
+func (r *resolver31) schema(s *oa31.Schema) *schema31 {
+	if r != nil && s != nil && s.Ref != "" {
+		components := r.components().Schemas
+		name, ok := follow(s.Ref, "#/components/schemas/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &schema31{schema: components[name], refSource: refSource(s.Ref), r: r}
+		}
+	}
+	return &schema31{schema: s, r: r}
+}
+
+func (r *resolver31) parameter(p *oa31.Parameter) *parameter31 {
+	if r != nil && p.IsReference() {
+		components := r.components().Parameters
+		name, ok := follow(p.Ref, "#/components/parameters/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &parameter31{param: components[name], refSource: refSource(p.Ref), r: r}
+		}
+	}
+	return &parameter31{param: p, r: r}
+}
+
+func (r *resolver31) response(resp *oa31.Response) *response31 {
+	if r != nil && resp.IsReference() {
+		components := r.components().Responses
+		name, ok := follow(resp.Ref, "#/components/responses/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &response31{resp: components[name], refSource: refSource(resp.Ref), r: r}
+		}
+	}
+	return &response31{resp: resp, r: r}
+}
+
+func (r *resolver31) requestBody(rb *oa31.RequestBody) *requestBody31 {
+	if r != nil && rb.IsReference() {
+		components := r.components().RequestBodies
+		name, ok := follow(rb.Ref, "#/components/requestBodies/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &requestBody31{rb: components[name], refSource: refSource(rb.Ref), r: r}
+		}
+	}
+	return &requestBody31{rb: rb, r: r}
+}
+
+func (r *resolver31) pathItem(item *oa31.PathItem) *pathItem31 {
+	if r != nil && item.HasRef() {
+		components := r.components().PathItems
+		name, ok := follow(item.GetRef(), "#/components/pathItems/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.GetRef(), true
+		})
+		if ok {
+			return &pathItem31{item: components[name], refSource: refSource(item.GetRef()), r: r}
+		}
+	}
+	return &pathItem31{item: item, r: r}
+}
+
+// resolver32 follows the local references of an OpenAPI 3.2 document
+type resolver32 struct {
+	doc *oa32.OpenAPI
+}
+
+// components returns the components of the document, or empty ones
+func (r *resolver32) components() *oa32.Components {
+	if r.doc.Components == nil {
+		return &oa32.Components{}
+	}
+	return r.doc.Components
+}
+
+func (r *resolver32) schema(s *oa32.Schema) *schema32 {
+	if r != nil && s != nil && s.Ref != "" {
+		components := r.components().Schemas
+		name, ok := follow(s.Ref, "#/components/schemas/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &schema32{schema: components[name], refSource: refSource(s.Ref), r: r}
+		}
+	}
+	return &schema32{schema: s, r: r}
+}
+
+func (r *resolver32) parameter(p *oa32.Parameter) *parameter32 {
+	if r != nil && p.IsReference() {
+		components := r.components().Parameters
+		name, ok := follow(p.Ref, "#/components/parameters/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &parameter32{param: components[name], refSource: refSource(p.Ref), r: r}
+		}
+	}
+	return &parameter32{param: p, r: r}
+}
+
+func (r *resolver32) response(resp *oa32.Response) *response32 {
+	if r != nil && resp.IsReference() {
+		components := r.components().Responses
+		name, ok := follow(resp.Ref, "#/components/responses/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &response32{resp: components[name], refSource: refSource(resp.Ref), r: r}
+		}
+	}
+	return &response32{resp: resp, r: r}
+}
+
+func (r *resolver32) requestBody(rb *oa32.RequestBody) *requestBody32 {
+	if r != nil && rb.IsReference() {
+		components := r.components().RequestBodies
+		name, ok := follow(rb.Ref, "#/components/requestBodies/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.Ref, true
+		})
+		if ok {
+			return &requestBody32{rb: components[name], refSource: refSource(rb.Ref), r: r}
+		}
+	}
+	return &requestBody32{rb: rb, r: r}
+}
+
+func (r *resolver32) pathItem(item *oa32.PathItem) *pathItem32 {
+	if r != nil && item.HasRef() {
+		components := r.components().PathItems
+		name, ok := follow(item.GetRef(), "#/components/pathItems/", func(name string) (string, bool) {
+			target := components[name]
+			if target == nil {
+				return "", false
+			}
+			return target.GetRef(), true
+		})
+		if ok {
+			return &pathItem32{item: components[name], refSource: refSource(item.GetRef()), r: r}
+		}
+	}
+	return &pathItem32{item: item, r: r}
+}
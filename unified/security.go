@@ -0,0 +1,78 @@
+// Package unified provides the overriding of the security of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SecurityMode decides which security ApplySecurity overrides
+type SecurityMode int
+
+const (
+	// SecurityDocument sets the security of the document, which operations
+	// with their own security do not use
+	SecurityDocument SecurityMode = iota
+
+	// SecurityAllOperations sets the security of the document and of every
+	// operation, except those opted out of security with an empty list
+	SecurityAllOperations
+)
+
+// ApplySecurity sets, in place, the security requirements of a document, and
+// with SecurityAllOperations of its operations, as when a gateway in front
+// of the API authenticates every request. The schemes of the requirements
+// must be defined. No requirements remove the security.
+func ApplySecurity(doc Document, requirements []SecurityRequirement, mode SecurityMode) error {
+	if doc == nil {
+		return fmt.Errorf("no document to secure")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return err
+	}
+	schemes := object(root, false, "components", "securitySchemes")
+	if strings.HasPrefix(doc.Version(), "2.") {
+		schemes = object(root, false, "securityDefinitions")
+	}
+	security := make([]any, 0, len(requirements))
+	for _, requirement := range requirements {
+		r := make(map[string]any, len(requirement))
+		for name, scopes := range requirement {
+			if _, ok := schemes[name]; !ok {
+				return fmt.Errorf("security scheme %q is not defined", name)
+			}
+			list := make([]any, 0, len(scopes))
+			for _, scope := range scopes {
+				list = append(list, scope)
+			}
+			r[name] = list
+		}
+		security = append(security, r)
+	}
+
+	set := func(target map[string]any) {
+		if len(security) == 0 {
+			delete(target, "security")
+		} else {
+			target["security"] = security
+		}
+	}
+	set(root)
+	if mode == SecurityAllOperations {
+		for _, name := range []string{"paths", "webhooks"} {
+			for _, item := range object(root, false, name) {
+				i, _ := item.(map[string]any)
+				for _, o := range operations(i) {
+					if list, ok := o.op["security"].([]any); ok && len(list) == 0 {
+						continue
+					}
+					set(o.op)
+				}
+			}
+		}
+	}
+	return setDocumentObject(doc, root)
+}
@@ -0,0 +1,240 @@
+// Package unified provides the rewriting of the servers of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ServerRewrite describes how RewriteServers changes where a document is
+// served. Empty fields keep what the document has.
+type ServerRewrite struct {
+	// Scheme replaces the scheme of every server URL with a host, or the
+	// schemes of a 2.0 document and its operations
+	Scheme string
+
+	// Host replaces the host, with its port, of every server URL, or the
+	// host of a 2.0 document
+	Host string
+
+	// BasePath replaces the path of every server URL, or the basePath of a
+	// 2.0 document; "/" serves from the root
+	BasePath string
+
+	// PathPrefix is put in front of every path, as in /api/v2 for /pets to
+	// become /api/v2/pets. Local references into the paths follow.
+	PathPrefix string
+}
+
+// RewriteServers rewrites, in place, the servers of a document: in 3.x the
+// servers of the document, its path items, operations and links, and in 2.0
+// the host, basePath and schemes. A 3.x document without servers, which is
+// served from "/", gets one when Scheme, Host or BasePath is set. Server
+// variables no longer used in their URL are removed.
+func RewriteServers(doc Document, rewrite ServerRewrite) error {
+	if doc == nil {
+		return fmt.Errorf("no document to rewrite")
+	}
+	if err := rewrite.check(); err != nil {
+		return err
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(doc.Version(), "2.") {
+		rewrite.swagger(root)
+	} else if rewrite.Scheme != "" || rewrite.Host != "" || rewrite.BasePath != "" {
+		if servers, ok := root["servers"].([]any); !ok || len(servers) == 0 {
+			root["servers"] = []any{map[string]any{"url": "/"}}
+		}
+	}
+	if rewrite.PathPrefix != "" {
+		if paths := object(root, false, "paths"); paths != nil {
+			prefixed := make(map[string]any, len(paths))
+			for path, item := range paths {
+				prefixed[rewrite.PathPrefix+path] = item
+			}
+			root["paths"] = prefixed
+		}
+	}
+	rewrite.rewrite(root)
+	return setDocumentObject(doc, root)
+}
+
+// check rejects the parts of a rewrite that do not fit in a URL
+func (r ServerRewrite) check() error {
+	if strings.ContainsAny(r.Scheme, ":/") {
+		return fmt.Errorf("invalid scheme %q", r.Scheme)
+	}
+	if strings.Contains(r.Host, "/") {
+		return fmt.Errorf("invalid host %q", r.Host)
+	}
+	if r.BasePath != "" && !strings.HasPrefix(r.BasePath, "/") {
+		return fmt.Errorf("base path %q must start with /", r.BasePath)
+	}
+	if r.PathPrefix != "" && (!strings.HasPrefix(r.PathPrefix, "/") || strings.HasSuffix(r.PathPrefix, "/")) {
+		return fmt.Errorf("path prefix %q must start and not end with /", r.PathPrefix)
+	}
+	return nil
+}
+
+// swagger rewrites the host, basePath and schemes of a 2.0 document
+func (r ServerRewrite) swagger(root map[string]any) {
+	if r.Host != "" {
+		root["host"] = r.Host
+	}
+	if r.BasePath == "/" {
+		delete(root, "basePath")
+	} else if r.BasePath != "" {
+		root["basePath"] = strings.TrimSuffix(r.BasePath, "/")
+	}
+	if r.Scheme == "" {
+		return
+	}
+	root["schemes"] = []any{r.Scheme}
+	for _, item := range object(root, false, "paths") {
+		i, _ := item.(map[string]any)
+		for _, o := range operations(i) {
+			if _, ok := o.op["schemes"]; ok {
+				o.op["schemes"] = []any{r.Scheme}
+			}
+		}
+	}
+}
+
+// rewrite rewrites the server URLs and the local references into the paths
+// within a generic JSON value
+func (r ServerRewrite) rewrite(v any) {
+	switch x := v.(type) {
+	case map[string]any:
+		for key, value := range x {
+			switch {
+			case strings.HasPrefix(key, "x-") || dataKeywords[key]:
+				continue
+			case key == "$ref" || key == "operationRef":
+				if ref, ok := value.(string); ok {
+					x[key] = r.ref(ref)
+				}
+				continue
+			case key == "servers":
+				if servers, ok := value.([]any); ok {
+					for _, server := range servers {
+						r.server(server)
+					}
+					continue
+				}
+			case key == "server":
+				if server, ok := value.(map[string]any); ok {
+					if _, ok := server["url"].(string); ok {
+						r.server(server)
+						continue
+					}
+				}
+			}
+			r.rewrite(value)
+		}
+	case []any:
+		for _, value := range x {
+			r.rewrite(value)
+		}
+	}
+}
+
+// ref puts the path prefix in front of the path of a local reference into
+// the paths
+func (r ServerRewrite) ref(ref string) string {
+	rest, ok := strings.CutPrefix(ref, "#/paths/")
+	if !ok || r.PathPrefix == "" {
+		return ref
+	}
+	token, tail, _ := strings.Cut(rest, "/")
+	path := strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	ref = pointer("#/paths", r.PathPrefix+path)
+	if tail != "" {
+		ref += "/" + tail
+	}
+	return ref
+}
+
+// serverVariable matches a variable in a server URL
+var serverVariable = regexp.MustCompile(`\{([^}]*)\}`)
+
+// server rewrites the URL of a server object and drops the variables it no
+// longer uses
+func (r ServerRewrite) server(v any) {
+	server, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	u, ok := server["url"].(string)
+	if !ok || (r.Scheme == "" && r.Host == "" && r.BasePath == "") {
+		return
+	}
+	scheme, host, path := splitServerURL(u)
+	if r.Host != "" {
+		host = r.Host
+	}
+	if r.Scheme != "" {
+		scheme = r.Scheme
+	}
+	if r.BasePath != "" {
+		path = r.BasePath
+	}
+	server["url"] = joinServerURL(scheme, host, path)
+
+	variables, _ := server["variables"].(map[string]any)
+	if variables == nil {
+		return
+	}
+	used := make(map[string]bool)
+	for _, match := range serverVariable.FindAllStringSubmatch(server["url"].(string), -1) {
+		used[match[1]] = true
+	}
+	for name := range variables {
+		if !used[name] {
+			delete(variables, name)
+		}
+	}
+	if len(variables) == 0 {
+		delete(server, "variables")
+	}
+}
+
+// splitServerURL splits a server URL, which may hold variables and so is not
+// parsed as a URL, into its scheme, host and path
+func splitServerURL(u string) (scheme, host, path string) {
+	rest := u
+	if before, after, ok := strings.Cut(u, "://"); ok && !strings.Contains(before, "/") {
+		scheme, rest = before, after
+	} else if after, ok := strings.CutPrefix(u, "//"); ok {
+		rest = after
+	} else {
+		return "", "", u
+	}
+	if i := strings.IndexByte(rest, '/'); i >= 0 {
+		return scheme, rest[:i], rest[i:]
+	}
+	return scheme, rest, ""
+}
+
+// joinServerURL builds a server URL from its scheme, host and path. Without
+// a host the URL is relative and has no scheme.
+func joinServerURL(scheme, host, path string) string {
+	if host == "" {
+		if path == "" {
+			return "/"
+		}
+		return path
+	}
+	if path == "/" {
+		path = ""
+	}
+	if scheme == "" {
+		return "//" + host + path
+	}
+	return scheme + "://" + host + path
+}
@@ -0,0 +1,70 @@
+// Package unified provides the deterministic ordering of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Sort orders, in place, the lists of a document whose order carries no
+// meaning for tooling: the tag declarations by name, and the required and
+// enum lists of schemas by value. compare orders two names or values, which
+// are compared as their JSON text unless they are strings; nil compares
+// alphabetically. The sort is stable, so equal values keep their order.
+//
+// Paths and component keys need no sorting: the JSON form of a document
+// always lists them in alphabetical order.
+func Sort(doc Document, compare func(a, b string) int) error {
+	if doc == nil {
+		return fmt.Errorf("no document to sort")
+	}
+	if compare == nil {
+		compare = strings.Compare
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return err
+	}
+	if tags, ok := root["tags"].([]any); ok {
+		slices.SortStableFunc(tags, func(a, b any) int { return compare(tagName(a), tagName(b)) })
+	}
+	sortLists(root, compare)
+	return setDocumentObject(doc, root)
+}
+
+// sortLists sorts the required and enum lists within a generic JSON value
+func sortLists(v any, compare func(a, b string) int) {
+	switch x := v.(type) {
+	case map[string]any:
+		for key, value := range x {
+			if list, ok := value.([]any); ok && (key == "required" || key == "enum") {
+				slices.SortStableFunc(list, func(a, b any) int { return compare(sortText(a), sortText(b)) })
+				continue
+			}
+			if strings.HasPrefix(key, "x-") || dataKeywords[key] {
+				continue
+			}
+			sortLists(value, compare)
+		}
+	case []any:
+		for _, value := range x {
+			sortLists(value, compare)
+		}
+	}
+}
+
+// sortText returns the text a value is sorted by
+func sortText(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,126 @@
+// Package unified provides statistics of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DocumentStats are figures about the size and shape of a document, for metrics
+type DocumentStats struct {
+	Paths      int `json:"paths"`
+	Webhooks   int `json:"webhooks"`
+	Operations int `json:"operations"` // of the paths and webhooks
+
+	// OperationsByMethod counts the operations by lower-case method
+	OperationsByMethod   map[string]int `json:"operationsByMethod"`
+	DeprecatedOperations int            `json:"deprecatedOperations"`
+
+	Tags            int `json:"tags"`            // declared at the top level
+	SecuritySchemes int `json:"securitySchemes"` // defined
+	Schemas         int `json:"schemas"`         // component schemas, or 2.0 definitions
+
+	// Parameters, RequestBodies and Responses count the objects wherever
+	// they are: in path items, operations, callbacks and components,
+	// references to components included
+	Parameters    int `json:"parameters"`
+	RequestBodies int `json:"requestBodies"`
+	Responses     int `json:"responses"`
+
+	// Enums counts the schemas with an enum, EnumValues their values and
+	// MaxEnumSize the values of the largest
+	Enums       int `json:"enums"`
+	EnumValues  int `json:"enumValues"`
+	MaxEnumSize int `json:"maxEnumSize"`
+
+	// MaxSchemaDepth is the most schemas nested in each other, a schema
+	// without nested schemas being 1. References are not followed.
+	MaxSchemaDepth int `json:"maxSchemaDepth"`
+
+	// Refs counts the $ref values, and RefTargets how many of them there
+	// are for each reference
+	Refs       int            `json:"refs"`
+	RefTargets map[string]int `json:"refTargets"`
+}
+
+// Stats returns the statistics of a document
+func Stats(doc Document) (*DocumentStats, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to compute statistics of")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	s := &DocumentStats{
+		Paths:              len(doc.GetPaths()),
+		Webhooks:           len(doc.GetWebhooks()),
+		OperationsByMethod: make(map[string]int),
+		Tags:               len(doc.GetTags()),
+		SecuritySchemes:    len(doc.GetSecuritySchemes()),
+		Schemas:            len(doc.GetComponents().GetSchemas()),
+		RefTargets:         make(map[string]int),
+	}
+	for _, items := range []map[string]PathItem{doc.GetPaths(), doc.GetWebhooks()} {
+		for _, item := range items {
+			if item == nil {
+				continue
+			}
+			for method, op := range item.GetAllOperations() {
+				s.Operations++
+				s.OperationsByMethod[method]++
+				if op.GetDeprecated() {
+					s.DeprecatedOperations++
+				}
+			}
+		}
+	}
+
+	// Walk visits a schema before the schemas nested in it
+	depths := make(map[string]int)
+	Walk(doc, Visitor{
+		Parameter:   func(string, Parameter) { s.Parameters++ },
+		RequestBody: func(string, RequestBody) { s.RequestBodies++ },
+		Response:    func(string, Response) { s.Responses++ },
+		Schema: func(ptr string, schema Schema) {
+			if n := len(schema.GetEnum()); n > 0 {
+				s.Enums++
+				s.EnumValues += n
+				s.MaxEnumSize = max(s.MaxEnumSize, n)
+			}
+			depth := 1
+			for parent := ptr; strings.Contains(parent, "/"); {
+				parent = parent[:strings.LastIndex(parent, "/")]
+				if d, ok := depths[parent]; ok {
+					depth = d + 1
+					break
+				}
+			}
+			depths[ptr] = depth
+			s.MaxSchemaDepth = max(s.MaxSchemaDepth, depth)
+		},
+	})
+	s.countRefs(root)
+	return s, nil
+}
+
+// countRefs counts the $ref values of a generic JSON value
+func (s *DocumentStats) countRefs(v any) {
+	switch x := v.(type) {
+	case map[string]any:
+		for key, value := range x {
+			if ref, ok := value.(string); ok && key == "$ref" {
+				s.Refs++
+				s.RefTargets[ref]++
+				continue
+			}
+			s.countRefs(value)
+		}
+	case []any:
+		for _, value := range x {
+			s.countRefs(value)
+		}
+	}
+}
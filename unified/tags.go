@@ -0,0 +1,178 @@
+// Package unified provides the normalization of the tags of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// MergeDuplicateTags merges, in place, the tag declarations sharing a name
+// into the first one, which gains the fields only the others have, and drops
+// the repeated names of operation tag lists. It returns the names that were
+// declared more than once.
+func MergeDuplicateTags(doc Document) ([]string, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to merge tags in")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	tags, _ := root["tags"].([]any)
+	var kept []any
+	var merged []string
+	for _, tag := range tags {
+		name := tagName(tag)
+		i := tagIndex(kept, name)
+		if i < 0 {
+			kept = append(kept, tag)
+			continue
+		}
+		first, _ := kept[i].(map[string]any)
+		duplicate, _ := tag.(map[string]any)
+		for field, value := range duplicate {
+			if _, ok := first[field]; !ok {
+				first[field] = value
+			}
+		}
+		if !slices.Contains(merged, name) {
+			merged = append(merged, name)
+		}
+	}
+	if merged != nil {
+		root["tags"] = kept
+	}
+	for _, op := range documentOperations(root) {
+		list, _ := op["tags"].([]any)
+		var unique []any
+		for _, tag := range list {
+			if !slices.Contains(unique, tag) {
+				unique = append(unique, tag)
+			}
+		}
+		if len(unique) != len(list) {
+			op["tags"] = unique
+		}
+	}
+	return merged, setDocumentObject(doc, root)
+}
+
+// DeclareTags declares, in place, the tags operations use without a
+// declaration, appending them in the order they are first used. It returns
+// the names it declared.
+func DeclareTags(doc Document) ([]string, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("no document to declare tags in")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return nil, err
+	}
+	tags, _ := root["tags"].([]any)
+	var declared []string
+	for _, op := range documentOperations(root) {
+		list, _ := op["tags"].([]any)
+		for _, tag := range list {
+			name, ok := tag.(string)
+			if !ok || tagIndex(tags, name) >= 0 {
+				continue
+			}
+			tags = append(tags, map[string]any{"name": name})
+			declared = append(declared, name)
+		}
+	}
+	if declared == nil {
+		return nil, nil
+	}
+	root["tags"] = tags
+	return declared, setDocumentObject(doc, root)
+}
+
+// ReorderTags moves, in place, the declarations of the named tags to the
+// front in the order given; the others follow in their current order.
+func ReorderTags(doc Document, names ...string) error {
+	if doc == nil {
+		return fmt.Errorf("no document to reorder tags in")
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return err
+	}
+	tags, _ := root["tags"].([]any)
+	ordered := make([]any, 0, len(tags))
+	for _, name := range names {
+		i := tagIndex(tags, name)
+		if i < 0 {
+			return fmt.Errorf("tag %q is not declared", name)
+		}
+		ordered = append(ordered, tags[i])
+	}
+	for _, tag := range tags {
+		if !slices.Contains(names, tagName(tag)) {
+			ordered = append(ordered, tag)
+		}
+	}
+	root["tags"] = ordered
+	return setDocumentObject(doc, root)
+}
+
+// RenameTag renames, in place, a tag in its declaration, in the operations
+// using it and, in 3.2, in the tags nested under it. An operation already
+// using the new name lists it once.
+func RenameTag(doc Document, from, to string) error {
+	if doc == nil {
+		return fmt.Errorf("no document to rename a tag in")
+	}
+	if to == "" {
+		return fmt.Errorf("no new name for tag %q", from)
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		return err
+	}
+	tags, _ := root["tags"].([]any)
+	if tagIndex(tags, to) >= 0 && tagIndex(tags, from) >= 0 {
+		return fmt.Errorf("tag %q already exists", to)
+	}
+	for _, tag := range tags {
+		t, _ := tag.(map[string]any)
+		if tagName(t) == from {
+			t["name"] = to
+		}
+		if t["parent"] == from {
+			t["parent"] = to
+		}
+	}
+	for _, op := range documentOperations(root) {
+		list, _ := op["tags"].([]any)
+		i := slices.Index(list, any(from))
+		if i < 0 {
+			continue
+		}
+		if slices.Contains(list, any(to)) {
+			op["tags"] = slices.Delete(list, i, i+1)
+		} else {
+			list[i] = to
+		}
+	}
+	return setDocumentObject(doc, root)
+}
+
+// documentOperations returns the operations of the paths and webhooks of a
+// document in generic JSON form, in path and method order
+func documentOperations(root map[string]any) []map[string]any {
+	var result []map[string]any
+	for _, section := range []string{"paths", "webhooks"} {
+		items := object(root, false, section)
+		for _, path := range slices.Sorted(maps.Keys(items)) {
+			item, _ := items[path].(map[string]any)
+			for _, o := range operations(item) {
+				result = append(result, o.op)
+			}
+		}
+	}
+	return result
+}
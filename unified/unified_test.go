@@ -4,7 +4,14 @@
 package unified
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/genelet/oas/convert"
 )
 
 func TestUnified(t *testing.T) {
@@ -93,3 +100,2872 @@ func TestExtension(t *testing.T) {
 		t.Errorf("GetInfo().GetExtensions() x-logo = %v, want logo.png", infoExt["x-logo"])
 	}
 }
+
+func TestParameterStyle20(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "Style Test", "version": "1.0"},
+		"paths": {
+			"/items/{ids}": {
+				"get": {
+					"parameters": [
+						{"name": "ids", "in": "path", "required": true, "type": "array", "items": {"type": "string"}, "collectionFormat": "csv"},
+						{"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"},
+						{"name": "codes", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "pipes"},
+						{"name": "flags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "tsv"}
+					],
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`
+
+	doc, err := NewDocument([]byte(spec))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+
+	expected := map[string]struct {
+		style   string
+		explode bool
+	}{
+		"ids":   {"simple", false},
+		"tags":  {"form", true},
+		"codes": {"pipeDelimited", false},
+		"flags": {"tabDelimited", false},
+	}
+	for _, p := range doc.GetPaths()["/items/{ids}"].GetOperation("get").GetParameters() {
+		want := expected[p.GetName()]
+		if p.GetStyle() != want.style || p.GetExplode() != want.explode || !p.HasExplode() {
+			t.Errorf("Parameter %s: expected style=%q explode=%v, got style=%q explode=%v",
+				p.GetName(), want.style, want.explode, p.GetStyle(), p.GetExplode())
+		}
+	}
+}
+
+func TestContent20(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "Content Test", "version": "1.0"},
+		"consumes": ["application/xml"],
+		"produces": ["application/xml"],
+		"paths": {
+			"/pets": {
+				"post": {
+					"consumes": ["application/json"],
+					"parameters": [{"name": "body", "in": "body", "schema": {"type": "object"}}],
+					"responses": {"200": {"description": "OK", "schema": {"type": "object"}}}
+				}
+			}
+		}
+	}`
+
+	doc, err := NewDocument([]byte(spec))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	op := doc.GetPaths()["/pets"].GetOperation("post")
+
+	body := op.GetRequestBody().GetContent()
+	if _, ok := body["application/json"]; !ok || len(body) != 1 {
+		t.Errorf("Expected operation consumes to override global, got %v", body)
+	}
+	content := op.GetResponses().GetStatusCodes()["200"].GetContent()
+	if _, ok := content["application/xml"]; !ok || len(content) != 1 {
+		t.Errorf("Expected global produces for response content, got %v", content)
+	}
+}
+
+func TestDiscriminator20(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "Discriminator Test", "version": "1.0"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"responses": {"200": {"description": "OK", "schema": {"type": "object", "discriminator": "petType"}}}
+				}
+			}
+		}
+	}`
+
+	doc, err := NewDocument([]byte(spec))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	schema := doc.GetPaths()["/pets"].GetOperation("get").GetResponses().GetStatusCodes()["200"].GetSchema()
+	d := schema.GetDiscriminator()
+	if d == nil || d.GetPropertyName() != "petType" {
+		t.Errorf("Expected discriminator petType, got %v", d)
+	}
+	if schema.GetItems().GetDiscriminator() != nil {
+		t.Error("Expected nil discriminator for missing schema")
+	}
+}
+
+func TestConvertTo(t *testing.T) {
+	spec := `{
+		"swagger": "2.0",
+		"info": {"title": "Convert Test", "version": "1.0"},
+		"host": "api.example.com",
+		"basePath": "/v1",
+		"schemes": ["https"],
+		"paths": {
+			"/pets": {
+				"get": {
+					"produces": ["application/json"],
+					"responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"type": "string"}}}}
+				}
+			}
+		}
+	}`
+
+	doc, err := NewDocument([]byte(spec))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+
+	tests := []struct {
+		target  string
+		version string
+	}{
+		{"2.0", "2.0"},
+		{"3.0", "3.0.3"},
+		{"3.0.1", "3.0.1"},
+		{"3.1", "3.1.0"},
+	}
+	for _, tt := range tests {
+		converted, report, err := doc.ConvertTo(tt.target)
+		if err != nil {
+			t.Fatalf("ConvertTo(%q) error = %v", tt.target, err)
+		}
+		if converted.Version() != tt.version {
+			t.Errorf("ConvertTo(%q): Expected version %s, got %s", tt.target, tt.version, converted.Version())
+		}
+		if report.HasSeverity(convert.SeverityWarning) {
+			t.Errorf("ConvertTo(%q): Expected no warnings, got %s", tt.target, report)
+		}
+		if url := converted.GetServerURL(); url != "https://api.example.com/v1" {
+			t.Errorf("ConvertTo(%q): Expected server URL, got %s", tt.target, url)
+		}
+		if converted.GetPaths()["/pets"].GetOperation("get") == nil {
+			t.Errorf("ConvertTo(%q): Expected get operation", tt.target)
+		}
+
+		back, _, err := converted.ConvertTo("2.0")
+		if err != nil {
+			t.Fatalf("ConvertTo(2.0) error = %v", err)
+		}
+		if back.Version() != "2.0" {
+			t.Errorf("Expected round trip to 2.0, got %s", back.Version())
+		}
+	}
+
+	if _, _, err := doc.ConvertTo("4.0"); err == nil {
+		t.Error("Expected error for unsupported version")
+	}
+}
+
+func TestDocument32(t *testing.T) {
+	spec := `{
+		"openapi": "3.2.0",
+		"info": {"title": "3.2 Test", "version": "1.0"},
+		"servers": [{"url": "https://api.example.com", "name": "production"}],
+		"paths": {
+			"/pets": {
+				"query": {
+					"deprecated": true,
+					"responses": {"200": {"content": {"application/json": {"schema": {"type": "array", "items": {"type": "string"}}}}}}
+				},
+				"additionalOperations": {
+					"LINK": {"responses": {"204": {"description": "Linked"}}}
+				}
+			}
+		}
+	}`
+
+	doc, err := NewDocument([]byte(spec))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if _, ok := doc.(*Document32); !ok {
+		t.Fatalf("Expected *Document32, got %T", doc)
+	}
+	if doc.GetServerURL() != "https://api.example.com" {
+		t.Errorf("Expected server URL, got %s", doc.GetServerURL())
+	}
+
+	item := doc.GetPaths()["/pets"]
+	query := item.GetOperation("QUERY")
+	if !query.GetDeprecated() {
+		t.Error("Expected deprecated query operation")
+	}
+	if item.GetOperation("link").GetResponses().GetStatusCodes()["204"] == nil {
+		t.Error("Expected LINK operation through GetOperation")
+	}
+	if ops := item.GetAllOperations(); len(ops) != 2 || ops["query"] == nil || ops["link"] == nil {
+		t.Errorf("Expected query and link operations, got %v", ops)
+	}
+
+	down, report, err := doc.ConvertTo("3.1")
+	if err != nil || down.Version() != "3.1.1" {
+		t.Fatalf("ConvertTo(3.1) = %v, %v", down, err)
+	}
+	if ops := down.GetPaths()["/pets"].GetAllOperations(); len(ops) != 0 {
+		t.Errorf("Expected the query and LINK operations dropped, got %v", ops)
+	}
+	if !report.HasSeverity(convert.SeverityError) || len(report.Issues) != 3 {
+		t.Errorf("Expected the dropped operations and server name reported, got %v", report.Issues)
+	}
+	if _, report, err := doc.ConvertTo("2.0"); err != nil || report.Lossless() {
+		t.Errorf("Expected a 2.0 conversion with issues, got %v, %v", report, err)
+	}
+	if same, _, err := doc.ConvertTo("3.2"); err != nil || same.Version() != "3.2.0" {
+		t.Errorf("Expected same-version conversion, got %v", err)
+	}
+}
+
+func TestGetWebhooks(t *testing.T) {
+	docs := map[string]string{
+		"3.1": `{"openapi": "3.1.0", "info": {"title": "T", "version": "1"}, "webhooks": {"newPet": {"post": {"operationId": "newPet", "responses": {"200": {"description": "OK"}}}}}}`,
+		"3.2": `{"openapi": "3.2.0", "info": {"title": "T", "version": "1"}, "webhooks": {"newPet": {"post": {"operationId": "newPet", "responses": {"200": {"description": "OK"}}}}}}`,
+	}
+	for version, data := range docs {
+		doc, err := NewDocument([]byte(data))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		webhooks := doc.GetWebhooks()
+		if len(webhooks) != 1 {
+			t.Fatalf("%s: expected 1 webhook, got %d", version, len(webhooks))
+		}
+		if id := webhooks["newPet"].GetOperation("post").GetOperationID(); id != "newPet" {
+			t.Errorf("%s: expected operation newPet, got %q", version, id)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{"openapi": "3.0.3", "info": {"title": "T", "version": "1"}, "paths": {}}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if webhooks := doc.GetWebhooks(); len(webhooks) != 0 {
+		t.Errorf("Expected no webhooks for 3.0, got %v", webhooks)
+	}
+}
+
+func TestGetComponents(t *testing.T) {
+	components := `"components": {
+		"schemas": {"Pet": {"type": "object"}},
+		"responses": {"NotFound": {"description": "Not found"}},
+		"parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}},
+		"requestBodies": {"PetBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}},
+		"headers": {"RateLimit": {"schema": {"type": "integer"}}},
+		"examples": {"Cat": {"summary": "A cat", "value": {"name": "Tom"}}},
+		"links": {"GetPet": {"operationId": "getPet", "parameters": {"petId": "$response.body#/id"}}},
+		"callbacks": {"OnEvent": {"{$request.body#/url}": {"post": {"responses": {"200": {"description": "OK"}}}}}}
+	}`
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{"openapi": "` + version + `", "info": {"title": "T", "version": "1"}, "paths": {}, ` + components + `}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		c := doc.GetComponents()
+		if c.GetSchemas()["Pet"].GetType() != "object" || c.GetResponses()["NotFound"].GetDescription() != "Not found" {
+			t.Errorf("%s: unexpected schemas or responses", version)
+		}
+		if c.GetParameters()["Limit"].GetIn() != "query" || c.GetRequestBodies()["PetBody"].GetContent()["application/json"] == nil {
+			t.Errorf("%s: unexpected parameters or request bodies", version)
+		}
+		if c.GetHeaders()["RateLimit"].GetSchema().GetType() != "integer" || c.GetExamples()["Cat"].GetSummary() != "A cat" {
+			t.Errorf("%s: unexpected headers or examples", version)
+		}
+		link := c.GetLinks()["GetPet"]
+		if link.GetOperationID() != "getPet" || link.GetParameters()["petId"] != "$response.body#/id" {
+			t.Errorf("%s: unexpected link %v", version, link.GetParameters())
+		}
+		if op := c.GetCallbacks()["OnEvent"].GetPathItems()["{$request.body#/url}"].GetOperation("post"); op == nil || op.IsNil() {
+			t.Errorf("%s: expected the callback operation", version)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"paths": {},
+		"definitions": {"Pet": {"type": "object"}},
+		"parameters": {"Body": {"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Pet"}}},
+		"responses": {"NotFound": {"description": "Not found"}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	c := doc.GetComponents()
+	if c.GetSchemas()["Pet"].GetType() != "object" || !c.GetParameters()["Body"].IsBodyParameter() {
+		t.Errorf("Expected 2.0 definitions and parameters")
+	}
+	if c.GetResponses()["NotFound"].GetDescription() != "Not found" || c.GetExamples() != nil {
+		t.Errorf("Expected 2.0 responses and no examples")
+	}
+
+	doc, err = NewDocument([]byte(`{"openapi": "3.1.0", "info": {"title": "T", "version": "1"}, "paths": {}}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if schemas := doc.GetComponents().GetSchemas(); schemas != nil {
+		t.Errorf("Expected no schemas, got %v", schemas)
+	}
+}
+
+func TestGetTagsAndExternalDocs(t *testing.T) {
+	tail := `"info": {"title": "T", "version": "1"}, "paths": {},
+		"tags": [{"name": "pets", "description": "Pet operations", "externalDocs": {"url": "https://example.com/pets"}, "x-order": 1}, {"name": "store"}],
+		"externalDocs": {"description": "Guide", "url": "https://example.com"}}`
+	for _, head := range []string{`{"swagger": "2.0", `, `{"openapi": "3.0.3", `, `{"openapi": "3.1.0", `, `{"openapi": "3.2.0", `} {
+		doc, err := NewDocument([]byte(head + tail))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", head, err)
+		}
+		tags := doc.GetTags()
+		if len(tags) != 2 || tags[0].GetName() != "pets" || tags[0].GetDescription() != "Pet operations" {
+			t.Fatalf("%s: unexpected tags %v", head, tags)
+		}
+		if tags[0].GetExternalDocs().GetURL() != "https://example.com/pets" || tags[0].GetExtensions()["x-order"] != float64(1) {
+			t.Errorf("%s: unexpected tag details %v", head, tags[0])
+		}
+		if tags[1].GetExternalDocs() != nil {
+			t.Errorf("%s: expected no external docs for store", head)
+		}
+		if docs := doc.GetExternalDocs(); docs == nil || docs.GetDescription() != "Guide" || docs.GetURL() != "https://example.com" {
+			t.Errorf("%s: unexpected external docs %v", head, docs)
+		}
+	}
+}
+
+func TestGetServers(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"paths": {},
+			"servers": [
+				{"url": "https://{region}.example.com/{version}", "description": "Production", "variables": {
+					"region": {"enum": ["eu", "us"], "default": "eu"},
+					"version": {"default": "v1", "description": "API version"}
+				}},
+				{"url": "http://localhost:8080"}
+			]
+		}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		servers := doc.GetServers()
+		if len(servers) != 2 || servers[0].GetDescription() != "Production" || servers[1].GetURL() != "http://localhost:8080" {
+			t.Fatalf("%s: unexpected servers %v", version, servers)
+		}
+		region := servers[0].GetVariables()["region"]
+		if region.GetDefault() != "eu" || len(region.GetEnum()) != 2 {
+			t.Errorf("%s: unexpected region variable %v", version, region)
+		}
+		if servers[0].GetVariables()["version"].GetDescription() != "API version" || servers[1].GetVariables() != nil {
+			t.Errorf("%s: unexpected variables", version)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {}, "schemes": ["http", "https"], "host": "api.example.com", "basePath": "/v1"}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if servers := doc.GetServers(); len(servers) != 1 || servers[0].GetURL() != "http://api.example.com/v1" {
+		t.Errorf("Expected one 2.0 server, got %v", servers)
+	}
+}
+
+func TestGetCallbacks(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"paths": {"/subscribe": {"post": {
+				"responses": {"201": {"description": "Subscribed"}},
+				"callbacks": {"onEvent": {"{$request.body#/callbackUrl}": {"post": {"operationId": "event", "responses": {"200": {"description": "OK"}}}}}}
+			}}}
+		}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		callbacks := doc.GetPaths()["/subscribe"].GetOperation("post").GetCallbacks()
+		if len(callbacks) != 1 || callbacks["onEvent"].HasRef() {
+			t.Fatalf("%s: unexpected callbacks %v", version, callbacks)
+		}
+		item := callbacks["onEvent"].GetPathItems()["{$request.body#/callbackUrl}"]
+		if item == nil || item.GetOperation("post").GetOperationID() != "event" {
+			t.Errorf("%s: expected the event callback operation", version)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}}}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if callbacks := doc.GetPaths()["/pets"].GetOperation("get").GetCallbacks(); callbacks != nil {
+		t.Errorf("Expected no callbacks for 2.0, got %v", callbacks)
+	}
+}
+
+func TestMediaTypeExamplesAndEncoding(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"paths": {"/pets": {"post": {
+				"requestBody": {"content": {"multipart/form-data": {
+					"schema": {"type": "object", "properties": {"photo": {"type": "string"}}},
+					"examples": {"cat": {"summary": "A cat", "externalValue": "https://example.com/cat.png"}},
+					"encoding": {"photo": {"contentType": "image/png", "style": "form", "explode": true, "headers": {"X-Rate": {"schema": {"type": "integer"}}}}}
+				}}},
+				"responses": {"200": {"description": "OK", "content": {"application/json": {"example": {"name": "Tom"}}}}}
+			}}}
+		}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		op := doc.GetPaths()["/pets"].GetOperation("post")
+		mt := op.GetRequestBody().GetContent()["multipart/form-data"]
+		if example := mt.GetExamples()["cat"]; example == nil || example.GetSummary() != "A cat" || example.GetExternalValue() != "https://example.com/cat.png" {
+			t.Errorf("%s: unexpected examples %v", version, mt.GetExamples())
+		}
+		encoding := mt.GetEncoding()["photo"]
+		if encoding == nil || encoding.GetContentType() != "image/png" || encoding.GetStyle() != "form" || !encoding.GetExplode() {
+			t.Fatalf("%s: unexpected encoding %v", version, mt.GetEncoding())
+		}
+		if encoding.GetHeaders()["X-Rate"].GetSchema().GetType() != "integer" {
+			t.Errorf("%s: expected the encoding header", version)
+		}
+		response := op.GetResponses().GetStatusCodes()["200"].GetContent()["application/json"]
+		if example, ok := response.GetExample().(map[string]any); !ok || example["name"] != "Tom" {
+			t.Errorf("%s: unexpected example %v", version, response.GetExample())
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"produces": ["application/json", "application/xml"],
+		"paths": {"/pets": {"get": {"responses": {"200": {
+			"description": "OK",
+			"schema": {"type": "object"},
+			"examples": {"application/json": {"name": "Tom"}}
+		}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	content := doc.GetPaths()["/pets"].GetOperation("get").GetResponses().GetStatusCodes()["200"].GetContent()
+	if example, ok := content["application/json"].GetExample().(map[string]any); !ok || example["name"] != "Tom" {
+		t.Errorf("Expected the 2.0 response example, got %v", content["application/json"].GetExample())
+	}
+	if content["application/xml"].GetExample() != nil || content["application/json"].GetEncoding() != nil {
+		t.Errorf("Expected no xml example and no encoding for 2.0")
+	}
+}
+
+func TestSchemaValidationKeywords(t *testing.T) {
+	for _, tt := range []struct{ head, tail, bounds string }{
+		{`{"swagger": "2.0", "definitions": {`, `}`, `"minimum": 1, "exclusiveMinimum": true, "maximum": 10`},
+		{`{"openapi": "3.0.3", "components": {"schemas": {`, `}}`, `"minimum": 1, "exclusiveMinimum": true, "maximum": 10`},
+		{`{"openapi": "3.1.0", "components": {"schemas": {`, `}}`, `"exclusiveMinimum": 1, "maximum": 10, "exclusiveMaximum": 12`},
+		{`{"openapi": "3.2.0", "components": {"schemas": {`, `}}`, `"minimum": 0, "exclusiveMinimum": 1, "maximum": 10`},
+	} {
+		doc, err := NewDocument([]byte(tt.head + `
+			"Count": {"type": "number", "multipleOf": 0.5, ` + tt.bounds + `},
+			"Name": {"type": "string", "minLength": 2, "maxLength": 20, "pattern": "^[a-z]+$"},
+			"Tags": {"type": "array", "minItems": 1, "maxItems": 5, "uniqueItems": true, "items": {"type": "string"}}
+		` + tt.tail + `, "info": {"title": "T", "version": "1"}, "paths": {}}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", tt.head, err)
+		}
+		schemas := doc.GetComponents().GetSchemas()
+		count := schemas["Count"]
+		if min := count.GetMinimum(); min == nil || *min != 1 || !count.GetExclusiveMinimum() {
+			t.Errorf("%s: expected exclusive minimum 1, got %v %v", tt.head, min, count.GetExclusiveMinimum())
+		}
+		if max := count.GetMaximum(); max == nil || *max != 10 || count.GetExclusiveMaximum() {
+			t.Errorf("%s: expected inclusive maximum 10, got %v %v", tt.head, max, count.GetExclusiveMaximum())
+		}
+		if m := count.GetMultipleOf(); m == nil || *m != 0.5 {
+			t.Errorf("%s: expected multipleOf 0.5, got %v", tt.head, m)
+		}
+		name := schemas["Name"]
+		if name.GetMinLength() == nil || *name.GetMinLength() != 2 || *name.GetMaxLength() != 20 || name.GetPattern() != "^[a-z]+$" {
+			t.Errorf("%s: unexpected string keywords", tt.head)
+		}
+		tags := schemas["Tags"]
+		if tags.GetMinItems() == nil || *tags.GetMinItems() != 1 || *tags.GetMaxItems() != 5 || !tags.GetUniqueItems() {
+			t.Errorf("%s: unexpected array keywords", tt.head)
+		}
+		if name.GetMinimum() != nil || name.GetMinItems() != nil {
+			t.Errorf("%s: expected no numeric or array keywords on Name", tt.head)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {"/pets": {"get": {
+		"parameters": [{"name": "limit", "in": "query", "type": "integer", "minimum": 1, "maximum": 100, "exclusiveMaximum": true}],
+		"responses": {"200": {"description": "OK"}}
+	}}}}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	schema := doc.GetPaths()["/pets"].GetOperation("get").GetParameters()[0].GetSchema()
+	if max := schema.GetMaximum(); max == nil || *max != 100 || !schema.GetExclusiveMaximum() || *schema.GetMinimum() != 1 {
+		t.Errorf("Expected the 2.0 parameter bounds, got %v", max)
+	}
+}
+
+func TestSchemaEnumConstAndTitle(t *testing.T) {
+	for _, tt := range []struct {
+		head, tail string
+		constant   any
+	}{
+		{`{"swagger": "2.0", "definitions": {`, `}`, nil},
+		{`{"openapi": "3.0.3", "components": {"schemas": {`, `}}`, nil},
+		{`{"openapi": "3.1.0", "components": {"schemas": {`, `}}`, "cat"},
+		{`{"openapi": "3.2.0", "components": {"schemas": {`, `}}`, "cat"},
+	} {
+		doc, err := NewDocument([]byte(tt.head + `
+			"Kind": {"title": "Pet kind", "type": "string", "enum": ["cat", "dog"], "const": "cat"}
+		` + tt.tail + `, "info": {"title": "T", "version": "1"}, "paths": {}}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", tt.head, err)
+		}
+		kind := doc.GetComponents().GetSchemas()["Kind"]
+		if kind.GetTitle() != "Pet kind" {
+			t.Errorf("%s: expected title, got %q", tt.head, kind.GetTitle())
+		}
+		if enum := kind.GetEnum(); len(enum) != 2 || enum[1] != "dog" {
+			t.Errorf("%s: unexpected enum %v", tt.head, enum)
+		}
+		if kind.GetConst() != tt.constant {
+			t.Errorf("%s: expected const %v, got %v", tt.head, tt.constant, kind.GetConst())
+		}
+	}
+
+	var schema Schema = NilSchema{}
+	if schema.GetTitle() != "" || schema.GetEnum() != nil || schema.GetConst() != nil {
+		t.Error("Expected NilSchema to have no title, enum or const")
+	}
+}
+
+func TestSchemaAdditionalAndPatternProperties(t *testing.T) {
+	for _, tt := range []struct {
+		head, tail string
+		patterns   bool
+	}{
+		{`{"swagger": "2.0", "definitions": {`, `}`, false},
+		{`{"openapi": "3.0.3", "components": {"schemas": {`, `}}`, false},
+		{`{"openapi": "3.1.0", "components": {"schemas": {`, `}}`, true},
+		{`{"openapi": "3.2.0", "components": {"schemas": {`, `}}`, true},
+	} {
+		doc, err := NewDocument([]byte(tt.head + `
+			"Labels": {"type": "object", "additionalProperties": {"type": "string"}, "patternProperties": {"^x-": {"type": "integer"}}},
+			"Strict": {"type": "object", "properties": {"id": {"type": "string"}}, "additionalProperties": false},
+			"Open": {"type": "object"}
+		` + tt.tail + `, "info": {"title": "T", "version": "1"}, "paths": {}}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", tt.head, err)
+		}
+		schemas := doc.GetComponents().GetSchemas()
+		if typ := schemas["Labels"].GetAdditionalProperties().GetType(); typ != "string" {
+			t.Errorf("%s: expected string map values, got %q", tt.head, typ)
+		}
+		strict := schemas["Strict"].GetAdditionalProperties()
+		if value := strict.GetBooleanValue(); !strict.IsBooleanSchema() || value == nil || *value {
+			t.Errorf("%s: expected additionalProperties false", tt.head)
+		}
+		if !schemas["Open"].GetAdditionalProperties().IsNil() {
+			t.Errorf("%s: expected no additionalProperties", tt.head)
+		}
+		patterns := schemas["Labels"].GetPatternProperties()
+		if tt.patterns && (len(patterns) != 1 || patterns["^x-"].GetType() != "integer") {
+			t.Errorf("%s: unexpected pattern properties %v", tt.head, patterns)
+		}
+		if !tt.patterns && patterns != nil {
+			t.Errorf("%s: expected no pattern properties, got %v", tt.head, patterns)
+		}
+	}
+}
+
+func TestSchemaConditionalAndTupleKeywords(t *testing.T) {
+	schemas := `
+		"Shape": {
+			"not": {"type": "null"},
+			"if": {"properties": {"kind": {"const": "circle"}}},
+			"then": {"required": ["radius"]},
+			"else": {"required": ["width"]},
+			"prefixItems": [{"type": "number"}, {"type": "number"}],
+			"contains": {"type": "number"},
+			"dependentSchemas": {"radius": {"properties": {"unit": {"type": "string"}}}},
+			"dependentRequired": {"width": ["height"]}
+		}`
+	for _, version := range []string{"3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{"openapi": "` + version + `", "info": {"title": "T", "version": "1"}, "paths": {}, "components": {"schemas": {` + schemas + `}}}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		shape := doc.GetComponents().GetSchemas()["Shape"]
+		if shape.GetNot().GetType() != "null" || shape.GetIf().GetProperties()["kind"].GetConst() != "circle" {
+			t.Errorf("%s: unexpected not or if", version)
+		}
+		if shape.GetThen().GetRequired()[0] != "radius" || shape.GetElse().GetRequired()[0] != "width" {
+			t.Errorf("%s: unexpected then or else", version)
+		}
+		if items := shape.GetPrefixItems(); len(items) != 2 || shape.GetContains().GetType() != "number" {
+			t.Errorf("%s: unexpected prefixItems %v or contains", version, items)
+		}
+		if shape.GetDependentSchemas()["radius"].GetProperties()["unit"] == nil || shape.GetDependentRequired()["width"][0] != "height" {
+			t.Errorf("%s: unexpected dependent keywords", version)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{"openapi": "3.0.3", "info": {"title": "T", "version": "1"}, "paths": {}, "components": {"schemas": {"Name": {"type": "string", "not": {"enum": [""]}}}}}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	name := doc.GetComponents().GetSchemas()["Name"]
+	if name.GetNot().IsNil() || !name.GetIf().IsNil() || name.GetPrefixItems() != nil || name.GetDependentRequired() != nil {
+		t.Error("Expected only not for 3.0")
+	}
+}
+
+func TestSchemaIsNullable(t *testing.T) {
+	for _, tt := range []struct{ head, tail, nullable string }{
+		{`{"swagger": "2.0", "definitions": {`, `}`, `"type": "string", "x-nullable": true`},
+		{`{"openapi": "3.0.3", "components": {"schemas": {`, `}}`, `"type": "string", "nullable": true`},
+		{`{"openapi": "3.1.0", "components": {"schemas": {`, `}}`, `"type": ["string", "null"]`},
+		{`{"openapi": "3.2.0", "components": {"schemas": {`, `}}`, `"type": ["null", "string"]`},
+	} {
+		doc, err := NewDocument([]byte(tt.head + `
+			"Nickname": {` + tt.nullable + `},
+			"Name": {"type": "string"}
+		` + tt.tail + `, "info": {"title": "T", "version": "1"}, "paths": {}}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", tt.head, err)
+		}
+		schemas := doc.GetComponents().GetSchemas()
+		if !schemas["Nickname"].IsNullable() || schemas["Nickname"].GetType() != "string" {
+			t.Errorf("%s: expected a nullable string", tt.head)
+		}
+		if schemas["Name"].IsNullable() {
+			t.Errorf("%s: expected Name not to be nullable", tt.head)
+		}
+	}
+}
+
+func TestSchemaGetTypes(t *testing.T) {
+	for _, version := range []string{"3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{"openapi": "` + version + `", "info": {"title": "T", "version": "1"}, "paths": {}, "components": {"schemas": {
+			"Value": {"type": ["null", "string", "integer"]},
+			"Name": {"type": "string"},
+			"Any": {}
+		}}}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		schemas := doc.GetComponents().GetSchemas()
+		if types := schemas["Value"].GetTypes(); len(types) != 3 || types[0] != "null" || types[2] != "integer" {
+			t.Errorf("%s: unexpected types %v", version, types)
+		}
+		if types := schemas["Name"].GetTypes(); len(types) != 1 || types[0] != "string" {
+			t.Errorf("%s: unexpected types %v", version, types)
+		}
+		if types := schemas["Any"].GetTypes(); types != nil {
+			t.Errorf("%s: expected no types, got %v", version, types)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {}, "definitions": {"Name": {"type": "string"}, "Any": {}}}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	schemas := doc.GetComponents().GetSchemas()
+	if types := schemas["Name"].GetTypes(); len(types) != 1 || types[0] != "string" || schemas["Any"].GetTypes() != nil {
+		t.Errorf("Expected the single 2.0 type, got %v", types)
+	}
+}
+
+func TestEditor(t *testing.T) {
+	heads := map[string]string{
+		"2.0": `{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {}}`,
+		"3.0": `{"openapi": "3.0.3", "info": {"title": "T", "version": "1"}, "paths": {}}`,
+		"3.1": `{"openapi": "3.1.0", "info": {"title": "T", "version": "1"}}`,
+		"3.2": `{"openapi": "3.2.0", "info": {"title": "T", "version": "1"}}`,
+	}
+	for version, data := range heads {
+		doc, err := NewDocument([]byte(data))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		editor, err := NewEditor(doc)
+		if err != nil {
+			t.Fatalf("%s: NewEditor() error = %v", version, err)
+		}
+		editor.SetTitle("Pets")
+		editor.SetDescription("Pet store")
+		editor.AddTag("pets", "Pet operations")
+		editor.AddTag("pets", "All pet operations")
+		if err := editor.SetExtension("x-owner", "team"); err != nil {
+			t.Errorf("%s: SetExtension() error = %v", version, err)
+		}
+		if err := editor.SetExtension("owner", "team"); err == nil {
+			t.Errorf("%s: expected an error for a name without x-", version)
+		}
+		op := map[string]any{"operationId": "listPets", "tags": []string{"pets"}, "responses": map[string]any{"200": map[string]any{"description": "OK"}}}
+		if err := editor.AddOperation("/pets", "GET", op); err != nil {
+			t.Fatalf("%s: AddOperation() error = %v", version, err)
+		}
+		if err := editor.AddOperation("/pets", "get", op); err == nil {
+			t.Errorf("%s: expected an error for an existing operation", version)
+		}
+		if err := editor.AddOperation("/pets", "post", []byte(`{"operationId": "createPet", "responses": {"201": {"description": "Created"}}}`)); err != nil {
+			t.Errorf("%s: AddOperation() error = %v", version, err)
+		}
+		if err := editor.SetSchema("Pet", map[string]any{"type": "object", "required": []string{"name"}}); err != nil {
+			t.Errorf("%s: SetSchema() error = %v", version, err)
+		}
+		if err := editor.SetSchema("Bad", `"type"`); err == nil {
+			t.Errorf("%s: expected an error for a schema that is not an object", version)
+		}
+		scheme := map[string]any{"type": "apiKey", "name": "key", "in": "header"}
+		if err := editor.AddSecurityScheme("key", scheme); err != nil {
+			t.Errorf("%s: AddSecurityScheme() error = %v", version, err)
+		}
+		if err := editor.AddSecurityScheme("key", scheme); err == nil {
+			t.Errorf("%s: expected an error for an existing security scheme", version)
+		}
+		editor.AddSecurityRequirement(SecurityRequirement{"key": {}})
+
+		if info := doc.GetInfo(); info.GetTitle() != "Pets" || info.GetDescription() != "Pet store" {
+			t.Errorf("%s: unexpected info %q %q", version, info.GetTitle(), info.GetDescription())
+		}
+		if tags := doc.GetTags(); len(tags) != 1 || tags[0].GetDescription() != "All pet operations" {
+			t.Errorf("%s: unexpected tags %v", version, tags)
+		}
+		if doc.GetExtensions()["x-owner"] != "team" {
+			t.Errorf("%s: expected the x-owner extension", version)
+		}
+		item := doc.GetPaths()["/pets"]
+		if item == nil || item.GetOperation("get").GetOperationID() != "listPets" || item.GetOperation("post").GetOperationID() != "createPet" {
+			t.Fatalf("%s: expected the added operations", version)
+		}
+		if required := doc.GetComponents().GetSchemas()["Pet"].GetRequired(); len(required) != 1 || required[0] != "name" {
+			t.Errorf("%s: unexpected schema required %v", version, required)
+		}
+		if doc.GetSecuritySchemes()["key"].GetIn() != "header" || len(doc.GetGlobalSecurity()) != 1 {
+			t.Errorf("%s: expected the security scheme and requirement", version)
+		}
+
+		if !editor.RemoveOperation("/pets", "post") || editor.RemoveOperation("/pets", "post") || editor.RemoveOperation("/none", "get") {
+			t.Errorf("%s: unexpected RemoveOperation results", version)
+		}
+		if !item.GetOperation("post").IsNil() {
+			t.Errorf("%s: expected post to be removed", version)
+		}
+		if !editor.RemoveSchema("Pet") || editor.RemoveSchema("Pet") {
+			t.Errorf("%s: unexpected RemoveSchema results", version)
+		}
+	}
+
+	doc, err := NewDocument([]byte(heads["2.0"]))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	editor, _ := NewEditor(doc)
+	if err := editor.AddOperation("/pets", "trace", map[string]any{}); err == nil {
+		t.Error("Expected an error for trace in 2.0")
+	}
+	if doc.GetPaths()["/pets"] != nil {
+		t.Error("Expected no path item for a failed operation")
+	}
+
+	doc, err = NewDocument([]byte(heads["3.2"]))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	editor, _ = NewEditor(doc)
+	if err := editor.AddOperation("/pets", "COPY", map[string]any{"operationId": "copyPets"}); err != nil {
+		t.Fatalf("AddOperation() error = %v", err)
+	}
+	if id := doc.GetPaths()["/pets"].GetOperation("copy").GetOperationID(); id != "copyPets" {
+		t.Errorf("Expected the additional operation, got %q", id)
+	}
+	if !editor.RemoveOperation("/pets", "copy") {
+		t.Error("Expected to remove the additional operation")
+	}
+
+	if _, err := NewEditor(nil); err == nil {
+		t.Error("Expected an error for an unknown document")
+	}
+}
+
+func TestMaterialize(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1"},
+		"servers": [{"url": "https://api.example.com/v1"}],
+		"paths": {"/pets": {"get": {"operationId": "listPets", "responses": {"200": {"description": "OK"}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+
+	swagger, report, err := To20(doc)
+	if err != nil || report == nil {
+		t.Fatalf("To20() error = %v", err)
+	}
+	if swagger.Swagger != "2.0" || swagger.Host != "api.example.com" || swagger.Paths.Get("/pets").Get.OperationID != "listPets" {
+		t.Errorf("Unexpected 2.0 document %+v", swagger)
+	}
+	api30, _, err := To30(doc)
+	if err != nil {
+		t.Fatalf("To30() error = %v", err)
+	}
+	if api30.OpenAPI != "3.0.3" || api30.Info.Title != "Pets" {
+		t.Errorf("Unexpected 3.0 document %s %+v", api30.OpenAPI, api30.Info)
+	}
+	api31, _, err := To31(doc)
+	if err != nil {
+		t.Fatalf("To31() error = %v", err)
+	}
+	if api31 != doc.(*Document31).GetRaw() {
+		t.Error("Expected To31 to return the 3.1 document itself")
+	}
+
+	doc32, err := NewDocument([]byte(`{"openapi": "3.2.0", "info": {"title": "T", "version": "1"}}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if api31, report, err := To31(doc32); err != nil || api31.OpenAPI != "3.1.1" || !report.Lossless() {
+		t.Errorf("Expected 3.2 materialized as 3.1, got %v, %v", report, err)
+	}
+	if api30, _, err := To30(doc32); err != nil || api30.OpenAPI != "3.0.3" {
+		t.Errorf("Expected 3.2 materialized as 3.0, got %v", err)
+	}
+	if _, _, err := To20(nil); err == nil {
+		t.Error("Expected an error for no document")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		data string
+		want Document
+	}{
+		{`{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "paths": {}}`, &Document20{}},
+		{`{"openapi": "3.0.3", "info": {"title": "T", "version": "1"}, "paths": {}}`, &Document30{}},
+		{"\xef\xbb\xbf" + `{"openapi": "3.1.0", "info": {"title": "T", "version": "1"}}`, &Document31{}},
+		{`{"openapi": "3.2.0", "info": {"title": "T", "version": "1"}}`, &Document32{}},
+	}
+	for _, tt := range tests {
+		doc, err := Parse([]byte(tt.data))
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if got, want := fmt.Sprintf("%T", doc), fmt.Sprintf("%T", tt.want); got != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	}
+
+	for _, data := range []string{`{"openapi": "4.0.0"}`, `{"info": {}}`, `not json`} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Expected an error for %s", data)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		data := `{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"paths": {"/pets": {"post": {
+				"parameters": [{"$ref": "#/components/parameters/Limit"}],
+				"requestBody": {"$ref": "#/components/requestBodies/PetBody"},
+				"responses": {"200": {"$ref": "#/components/responses/Pets"}, "404": {"$ref": "#/components/responses/Missing"}}
+			}}},
+			"components": {
+				"schemas": {
+					"Pet": {"$ref": "#/components/schemas/Animal"},
+					"Animal": {"type": "object", "properties": {"parent": {"$ref": "#/components/schemas/Animal"}}},
+					"Loop": {"$ref": "#/components/schemas/Loop"}
+				},
+				"parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}},
+				"requestBodies": {"PetBody": {"required": true, "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}},
+				"responses": {"Pets": {"description": "Pets"}}
+			}
+		}`
+		plain, err := NewDocument([]byte(data))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		doc, err := Resolve(plain)
+		if err != nil {
+			t.Fatalf("%s: Resolve() error = %v", version, err)
+		}
+
+		op := doc.GetPaths()["/pets"].GetOperation("post")
+		param := op.GetParameters()[0]
+		if param.HasRef() || param.GetName() != "limit" || RawRef(param) != "#/components/parameters/Limit" {
+			t.Errorf("%s: unexpected parameter %q %q", version, param.GetName(), RawRef(param))
+		}
+		body := op.GetRequestBody()
+		if body.HasRef() || !body.GetRequired() {
+			t.Errorf("%s: expected the resolved request body", version)
+		}
+		schema := body.GetContent()["application/json"].GetSchema()
+		if schema.GetRef() != "" || schema.GetType() != "object" || RawRef(schema) != "#/components/schemas/Pet" {
+			t.Errorf("%s: expected Pet to resolve to Animal, got %q", version, RawRef(schema))
+		}
+		if parent := schema.GetProperties()["parent"]; parent.GetType() != "object" {
+			t.Errorf("%s: expected the recursive property to resolve", version)
+		}
+		responses := op.GetResponses().GetStatusCodes()
+		if responses["200"].HasRef() || responses["200"].GetDescription() != "Pets" {
+			t.Errorf("%s: expected the resolved response", version)
+		}
+		if !responses["404"].HasRef() || RawRef(responses["404"]) != "" {
+			t.Errorf("%s: expected the missing response to stay a reference", version)
+		}
+		if loop := doc.GetComponents().GetSchemas()["Loop"]; loop.GetRef() != "#/components/schemas/Loop" {
+			t.Errorf("%s: expected the cycle to stay a reference", version)
+		}
+
+		if param := plain.GetPaths()["/pets"].GetOperation("post").GetParameters()[0]; !param.HasRef() || param.GetName() != "" || RawRef(param) != "" {
+			t.Errorf("%s: expected the plain document not to resolve", version)
+		}
+	}
+
+	for _, version := range []string{"3.1.0", "3.2.0"} {
+		plain, err := NewDocument([]byte(`{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"paths": {"/pets": {"$ref": "#/components/pathItems/Pets"}},
+			"components": {"pathItems": {"Pets": {"get": {"operationId": "listPets", "responses": {"200": {"description": "OK"}}}}}}
+		}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		doc, _ := Resolve(plain)
+		item := doc.GetPaths()["/pets"]
+		if item.HasRef() || item.GetOperation("get").GetOperationID() != "listPets" || RawRef(item) != "#/components/pathItems/Pets" {
+			t.Errorf("%s: expected the resolved path item", version)
+		}
+	}
+
+	plain, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"paths": {"/pets": {"get": {
+			"parameters": [{"$ref": "#/parameters/Limit"}],
+			"responses": {"200": {"$ref": "#/responses/Pets"}}
+		}}},
+		"definitions": {"Pet": {"type": "object"}},
+		"parameters": {"Limit": {"name": "limit", "in": "query", "type": "integer"}},
+		"responses": {"Pets": {"description": "Pets", "schema": {"$ref": "#/definitions/Pet"}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	doc, _ := Resolve(plain)
+	op := doc.GetPaths()["/pets"].GetOperation("get")
+	if param := op.GetParameters()[0]; param.HasRef() || param.GetName() != "limit" {
+		t.Errorf("Expected the resolved 2.0 parameter")
+	}
+	response := op.GetResponses().GetStatusCodes()["200"]
+	if response.HasRef() || response.GetSchema().GetType() != "object" {
+		t.Errorf("Expected the resolved 2.0 response and schema")
+	}
+
+	if _, err := Resolve(nil); err == nil {
+		t.Error("Expected an error for an unknown document")
+	}
+}
+
+func TestWalk(t *testing.T) {
+	record := func(doc Document) []string {
+		var visited []string
+		Walk(doc, Visitor{
+			Operation:   func(ptr string, op Operation) { visited = append(visited, "operation "+ptr) },
+			Parameter:   func(ptr string, param Parameter) { visited = append(visited, "parameter "+ptr) },
+			RequestBody: func(ptr string, body RequestBody) { visited = append(visited, "requestBody "+ptr) },
+			Response:    func(ptr string, resp Response) { visited = append(visited, "response "+ptr) },
+			Header:      func(ptr string, header Header) { visited = append(visited, "header "+ptr) },
+			Schema:      func(ptr string, schema Schema) { visited = append(visited, "schema "+ptr) },
+		})
+		return visited
+	}
+
+	doc, err := NewDocument([]byte(`{
+		"openapi": "3.2.0",
+		"info": {"title": "T", "version": "1"},
+		"paths": {"/pets/{id}": {
+			"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+			"post": {
+				"requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}},
+				"responses": {"default": {"$ref": "#/components/responses/Error"}},
+				"callbacks": {"onEvent": {"{$request.body#/url}": {"post": {"responses": {"200": {"description": "OK"}}}}}}
+			},
+			"additionalOperations": {"COPY": {"responses": {"204": {"description": "Copied"}}}}
+		}},
+		"webhooks": {"newPet": {"post": {"responses": {"200": {"description": "OK", "headers": {"X-Rate": {"schema": {"type": "integer"}}}}}}}},
+		"components": {
+			"schemas": {"Pet": {"type": "object", "properties": {"tags": {"type": "array", "items": {"type": "string"}}, "parent": {"$ref": "#/components/schemas/Pet"}}}},
+			"responses": {"Error": {"description": "Error"}}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	want := []string{
+		"parameter /paths/~1pets~1{id}/parameters/0",
+		"schema /paths/~1pets~1{id}/parameters/0/schema",
+		"operation /paths/~1pets~1{id}/additionalOperations/COPY",
+		"response /paths/~1pets~1{id}/additionalOperations/COPY/responses/204",
+		"operation /paths/~1pets~1{id}/post",
+		"requestBody /paths/~1pets~1{id}/post/requestBody",
+		"schema /paths/~1pets~1{id}/post/requestBody/content/application~1json/schema",
+		"response /paths/~1pets~1{id}/post/responses/default",
+		"operation /paths/~1pets~1{id}/post/callbacks/onEvent/{$request.body#~1url}/post",
+		"response /paths/~1pets~1{id}/post/callbacks/onEvent/{$request.body#~1url}/post/responses/200",
+		"operation /webhooks/newPet/post",
+		"response /webhooks/newPet/post/responses/200",
+		"header /webhooks/newPet/post/responses/200/headers/X-Rate",
+		"schema /webhooks/newPet/post/responses/200/headers/X-Rate/schema",
+		"schema /components/schemas/Pet",
+		"schema /components/schemas/Pet/properties/parent",
+		"schema /components/schemas/Pet/properties/tags",
+		"schema /components/schemas/Pet/properties/tags/items",
+		"response /components/responses/Error",
+	}
+	if got := record(doc); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Walk() visited\n%v\nwant\n%v", got, want)
+	}
+	resolved, _ := Resolve(doc)
+	if got := record(resolved); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Walk() of the resolved document visited\n%v\nwant\n%v", got, want)
+	}
+
+	doc, err = NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"paths": {"/pets": {"post": {
+			"parameters": [
+				{"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Pet"}},
+				{"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}}
+			],
+			"responses": {"200": {"description": "OK", "headers": {"X-Rate": {"type": "integer"}}, "schema": {"type": "object"}}}
+		}}},
+		"definitions": {"Pet": {"type": "object"}},
+		"parameters": {
+			"Body": {"name": "body", "in": "body", "schema": {"type": "object"}},
+			"Limit": {"name": "limit", "in": "query", "type": "integer"}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	want = []string{
+		"operation /paths/~1pets/post",
+		"requestBody /paths/~1pets/post/parameters/0",
+		"schema /paths/~1pets/post/parameters/0/schema",
+		"parameter /paths/~1pets/post/parameters/1",
+		"schema /paths/~1pets/post/parameters/1",
+		"schema /paths/~1pets/post/parameters/1/items",
+		"response /paths/~1pets/post/responses/200",
+		"header /paths/~1pets/post/responses/200/headers/X-Rate",
+		"schema /paths/~1pets/post/responses/200/headers/X-Rate",
+		"schema /paths/~1pets/post/responses/200/schema",
+		"schema /definitions/Pet",
+		"parameter /parameters/Body",
+		"schema /parameters/Body/schema",
+		"parameter /parameters/Limit",
+		"schema /parameters/Limit",
+	}
+	if got := record(doc); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Walk() of 2.0 visited\n%v\nwant\n%v", got, want)
+	}
+
+	Walk(nil, Visitor{})
+	Walk(doc, Visitor{})
+}
+
+func TestGetOperationByID(t *testing.T) {
+	for _, tt := range []struct{ head, tail string }{
+		{`"swagger": "2.0"`, ``},
+		{`"openapi": "3.0.3"`, ``},
+		{`"openapi": "3.1.0"`, `, "webhooks": {"hook": {"post": {"operationId": "onHook", "responses": {"200": {"description": "OK"}}}}}`},
+		{`"openapi": "3.2.0"`, ``},
+	} {
+		doc, err := NewDocument([]byte(`{
+			` + tt.head + `,
+			"info": {"title": "T", "version": "1"},
+			"paths": {
+				"/pets": {
+					"get": {"operationId": "listPets", "responses": {"200": {"description": "OK"}}},
+					"post": {"operationId": "createPet", "responses": {"201": {"description": "Created"}}}
+				},
+				"/zoo": {"get": {"operationId": "listPets", "responses": {"200": {"description": "OK"}}}}
+			}` + tt.tail + `
+		}`))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		version := doc.Version()
+
+		path, method, op := doc.GetOperationByID("createPet")
+		if path != "/pets" || method != "post" || op.GetOperationID() != "createPet" {
+			t.Errorf("%s: GetOperationByID() = %q, %q", version, path, method)
+		}
+		if path, method, _ := doc.GetOperationByID("listPets"); path != "/pets" || method != "get" {
+			t.Errorf("%s: expected the first duplicate, got %q, %q", version, path, method)
+		}
+		if path, _, op := doc.GetOperationByID("onHook"); path != "" || !op.IsNil() {
+			t.Errorf("%s: expected webhooks not to be indexed", version)
+		}
+
+		editor, _ := NewEditor(doc)
+		if err := editor.AddOperation("/owners", "get", map[string]any{"operationId": "listOwners", "responses": map[string]any{"200": map[string]any{"description": "OK"}}}); err != nil {
+			t.Fatalf("%s: AddOperation() error = %v", version, err)
+		}
+		if path, _, _ := doc.GetOperationByID("listOwners"); path != "/owners" {
+			t.Errorf("%s: expected the index to see the added operation", version)
+		}
+		if path, _, op := doc.GetOperationByID("deletePets"); path != "" || !op.IsNil() {
+			t.Errorf("%s: expected no deletePets yet", version)
+		}
+		if err := editor.AddOperation("/pets", "delete", map[string]any{"operationId": "deletePets", "responses": map[string]any{"204": map[string]any{"description": "Deleted"}}}); err != nil {
+			t.Fatalf("%s: AddOperation() error = %v", version, err)
+		}
+		if path, method, _ := doc.GetOperationByID("deletePets"); path != "/pets" || method != "delete" {
+			t.Errorf("%s: expected the cached miss forgotten after the edit, got %q, %q", version, path, method)
+		}
+		if path, _, _ := doc.GetOperationByID("listCats"); path != "" {
+			t.Errorf("%s: expected no listCats yet", version)
+		}
+		if err := ApplyJSONPatch(doc, []byte(`[{"op": "copy", "from": "/paths/~1zoo", "path": "/paths/~1cats"}, {"op": "replace", "path": "/paths/~1cats/get/operationId", "value": "listCats"}]`)); err != nil {
+			t.Fatalf("%s: ApplyJSONPatch() error = %v", version, err)
+		}
+		if path, _, _ := doc.GetOperationByID("listCats"); path != "/cats" {
+			t.Errorf("%s: expected the cached miss forgotten after the patch, got %q", version, path)
+		}
+		if !editor.RemoveOperation("/pets", "post") {
+			t.Fatalf("%s: RemoveOperation() = false", version)
+		}
+		if path, method, op := doc.GetOperationByID("createPet"); path != "" || method != "" || !op.IsNil() {
+			t.Errorf("%s: expected the removed operation to be gone", version)
+		}
+	}
+}
+
+func TestEffectiveSecurity(t *testing.T) {
+	for _, tt := range []struct{ head, tail string }{
+		{`"swagger": "2.0", "securityDefinitions": {"key": {"type": "apiKey", "name": "key", "in": "header"}, "oauth": {"type": "oauth2", "flow": "implicit", "authorizationUrl": "https://example.com/auth", "scopes": {"read": "Read"}}}`, ``},
+		{`"openapi": "3.0.3", "components": {"securitySchemes": {"key": {"type": "apiKey", "name": "key", "in": "header"}, "oauth": {"type": "oauth2", "flows": {"implicit": {"authorizationUrl": "https://example.com/auth", "scopes": {"read": "Read"}}}}}}`, ``},
+		{`"openapi": "3.1.0", "components": {"securitySchemes": {"key": {"type": "apiKey", "name": "key", "in": "header"}, "oauth": {"type": "oauth2", "flows": {"implicit": {"authorizationUrl": "https://example.com/auth", "scopes": {"read": "Read"}}}}}}`, ``},
+	} {
+		doc, err := NewDocument([]byte(`{
+			` + tt.head + `,
+			"info": {"title": "T", "version": "1"},
+			"security": [{"key": []}],
+			"paths": {"/pets": {
+				"get": {"responses": {"200": {"description": "OK"}}},
+				"post": {"security": [{"oauth": ["read"], "key": []}, {}], "responses": {"200": {"description": "OK"}}},
+				"put": {"security": [{"missing": []}], "responses": {"200": {"description": "OK"}}},
+				"delete": {"security": [], "responses": {"200": {"description": "OK"}}}
+			}}
+		}`))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		version := doc.Version()
+		item := doc.GetPaths()["/pets"]
+
+		got := item.GetOperation("get").EffectiveSecurity(doc)
+		if len(got) != 1 || len(got[0]) != 1 || got[0][0].Name != "key" || got[0][0].Scheme.GetType() != "apiKey" {
+			t.Errorf("%s: expected the global requirement, got %v", version, got)
+		}
+
+		got = item.GetOperation("post").EffectiveSecurity(doc)
+		if len(got) != 2 || len(got[0]) != 2 || len(got[1]) != 0 {
+			t.Fatalf("%s: expected two alternatives, got %v", version, got)
+		}
+		if got[0][0].Name != "key" || got[0][1].Name != "oauth" || got[0][1].Scheme.GetFlow() != "implicit" || fmt.Sprint(got[0][1].Scopes) != "[read]" {
+			t.Errorf("%s: unexpected operation requirement %v", version, got[0])
+		}
+
+		if got := item.GetOperation("put").EffectiveSecurity(doc); len(got) != 1 || got[0][0].Name != "missing" || got[0][0].Scheme != nil {
+			t.Errorf("%s: expected an undefined scheme to be nil, got %v", version, got)
+		}
+		if got := item.GetOperation("delete").EffectiveSecurity(doc); got != nil {
+			t.Errorf("%s: expected an empty list to remove security, got %v", version, got)
+		}
+		if got := (NilOperation{}).EffectiveSecurity(doc); got != nil {
+			t.Errorf("%s: expected nil for NilOperation", version)
+		}
+	}
+}
+
+func TestExtensionHelpers(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1", "x-audience": "public"},
+		"x-rate-limit": 100,
+		"paths": {"/pets": {"get": {"x-beta": true, "responses": {"200": {"description": "OK"}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if n, err := GetExtensionInt(doc, "x-rate-limit"); err != nil || n != 100 {
+		t.Errorf("GetExtensionInt() = %d, %v", n, err)
+	}
+	if s, err := GetExtensionString(doc.GetInfo(), "x-audience"); err != nil || s != "public" {
+		t.Errorf("GetExtensionString() = %q, %v", s, err)
+	}
+	op := doc.GetPaths()["/pets"].GetOperation("get")
+	if b, err := GetExtensionBool(op, "x-beta"); err != nil || !b {
+		t.Errorf("GetExtensionBool() = %v, %v", b, err)
+	}
+	var out map[string]any
+	if err := GetExtensionObject(op, "x-missing", &out); !errors.Is(err, ErrExtensionNotFound) {
+		t.Errorf("Expected ErrExtensionNotFound, got %v", err)
+	}
+}
+
+func TestParameterExamplesAndContent(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"paths": {"/pets": {"get": {
+				"parameters": [
+					{"name": "limit", "in": "query", "schema": {"type": "integer"}, "example": 10},
+					{"name": "filter", "in": "query", "content": {"application/json": {
+						"schema": {"type": "object", "properties": {"tag": {"type": "string"}}},
+						"examples": {"byTag": {"value": {"tag": "dog"}}}
+					}}, "examples": {"dogs": {"summary": "Dogs", "value": "{\"tag\":\"dog\"}"}}}
+				],
+				"responses": {"200": {"description": "OK"}}
+			}}}
+		}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		params := doc.GetPaths()["/pets"].GetOperation("get").GetParameters()
+		if params[0].GetExample() != float64(10) || params[0].GetExamples() != nil || params[0].GetContent() != nil {
+			t.Errorf("%s: unexpected limit parameter", version)
+		}
+		if params[1].GetExamples()["dogs"].GetSummary() != "Dogs" {
+			t.Errorf("%s: expected the dogs example", version)
+		}
+		content := params[1].GetContent()["application/json"]
+		if content == nil || content.GetSchema().GetProperties()["tag"].GetType() != "string" || content.GetExamples()["byTag"] == nil {
+			t.Errorf("%s: expected the application/json content", version)
+		}
+
+		var schemas []string
+		Walk(doc, Visitor{Schema: func(ptr string, schema Schema) { schemas = append(schemas, ptr) }})
+		if len(schemas) != 3 || schemas[1] != "/paths/~1pets/get/parameters/1/content/application~1json/schema" {
+			t.Errorf("%s: expected Walk to visit the content schema, got %v", version, schemas)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"paths": {"/pets": {"get": {
+			"parameters": [{"name": "limit", "in": "query", "type": "integer", "x-example": 10}],
+			"responses": {"200": {"description": "OK"}}
+		}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	param := doc.GetPaths()["/pets"].GetOperation("get").GetParameters()[0]
+	if param.GetExample() != float64(10) || param.GetExamples() != nil || param.GetContent() != nil {
+		t.Error("Expected the 2.0 x-example and no examples or content")
+	}
+}
+
+func TestHeaderSerialization(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"paths": {"/pets": {"get": {
+				"parameters": [
+					{"name": "tags", "in": "query", "explode": false, "schema": {"type": "array", "items": {"type": "string"}}},
+					{"name": "ids", "in": "query", "style": "form", "schema": {"type": "array", "items": {"type": "string"}}}
+				],
+				"responses": {"200": {
+					"description": "OK",
+					"headers": {
+						"X-Ids": {"style": "simple", "explode": true, "schema": {"type": "array", "items": {"type": "integer"}}},
+						"X-Meta": {"content": {"application/json": {"schema": {"type": "object"}}}}
+					}
+			}}}}}
+		}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		headers := doc.GetPaths()["/pets"].GetOperation("get").GetResponses().GetStatusCodes()["200"].GetHeaders()
+		if h := headers["X-Ids"]; h.GetStyle() != "simple" || !h.GetExplode() || h.GetContent() != nil {
+			t.Errorf("%s: unexpected X-Ids serialization", version)
+		}
+		if h := headers["X-Meta"]; h.GetStyle() != "" || h.GetExplode() || h.GetContent()["application/json"].GetSchema().GetType() != "object" {
+			t.Errorf("%s: expected the X-Meta content", version)
+		}
+		if !headers["X-Ids"].HasExplode() || headers["X-Meta"].HasExplode() {
+			t.Errorf("%s: expected explode set on X-Ids only", version)
+		}
+		params := doc.GetPaths()["/pets"].GetOperation("get").GetParameters()
+		if tags, ids := params[0], params[1]; !tags.HasExplode() || tags.GetExplode() || ids.HasExplode() {
+			t.Errorf("%s: expected an explicit explode false on tags and none on ids", version)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"paths": {"/pets": {"get": {"responses": {"200": {
+			"description": "OK",
+			"headers": {
+				"X-Ids": {"type": "array", "items": {"type": "integer"}, "collectionFormat": "pipes"},
+				"X-Count": {"type": "integer"}
+			}
+		}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	headers := doc.GetPaths()["/pets"].GetOperation("get").GetResponses().GetStatusCodes()["200"].GetHeaders()
+	if h := headers["X-Ids"]; h.GetStyle() != "pipeDelimited" || h.GetExplode() || h.GetContent() != nil {
+		t.Errorf("Unexpected 2.0 X-Ids serialization %q", h.GetStyle())
+	}
+	if h := headers["X-Count"]; h.GetStyle() != "" || h.GetExplode() {
+		t.Errorf("Expected no 2.0 X-Count collectionFormat")
+	}
+}
+
+func TestOperationDeprecated(t *testing.T) {
+	for _, head := range []string{`"swagger": "2.0"`, `"openapi": "3.0.3"`, `"openapi": "3.1.0"`, `"openapi": "3.2.0"`} {
+		doc, err := NewDocument([]byte(`{
+			` + head + `,
+			"info": {"title": "T", "version": "1"},
+			"paths": {"/pets": {
+				"get": {"deprecated": true, "responses": {"200": {"description": "OK"}}},
+				"post": {"responses": {"200": {"description": "OK"}}}
+			}}
+		}`))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		item := doc.GetPaths()["/pets"]
+		if !item.GetOperation("get").GetDeprecated() || item.GetOperation("post").GetDeprecated() {
+			t.Errorf("%s: unexpected deprecated operations", doc.Version())
+		}
+	}
+}
+
+func TestEffectiveServers(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"servers": [{"url": "https://api.example.com"}],
+			"paths": {
+				"/pets": {
+					"servers": [{"url": "https://pets.example.com", "variables": {"region": {"default": "eu"}}}],
+					"get": {"responses": {"200": {"description": "OK"}}},
+					"post": {"servers": [{"url": "https://write.example.com"}], "responses": {"200": {"description": "OK"}}}
+				},
+				"/owners": {"get": {"responses": {"200": {"description": "OK"}}}}
+			}
+		}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		pets := doc.GetPaths()["/pets"]
+		if servers := pets.GetServers(); len(servers) != 1 || servers[0].GetVariables()["region"].GetDefault() != "eu" {
+			t.Errorf("%s: unexpected path item servers", version)
+		}
+		if servers := EffectiveServers(doc, pets, pets.GetOperation("post")); len(servers) != 1 || servers[0].GetURL() != "https://write.example.com" {
+			t.Errorf("%s: expected the operation servers", version)
+		}
+		if servers := EffectiveServers(doc, pets, pets.GetOperation("get")); len(servers) != 1 || servers[0].GetURL() != "https://pets.example.com" {
+			t.Errorf("%s: expected the path item servers", version)
+		}
+		owners := doc.GetPaths()["/owners"]
+		if servers := EffectiveServers(doc, owners, owners.GetOperation("get")); len(servers) != 1 || servers[0].GetURL() != "https://api.example.com" {
+			t.Errorf("%s: expected the document servers", version)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"host": "api.example.com",
+		"schemes": ["https"],
+		"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK"}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	pets := doc.GetPaths()["/pets"]
+	if pets.GetServers() != nil || pets.GetOperation("get").GetServers() != nil {
+		t.Error("Expected no 2.0 path item or operation servers")
+	}
+	if servers := EffectiveServers(doc, pets, pets.GetOperation("get")); len(servers) != 1 || servers[0].GetURL() != "https://api.example.com" {
+		t.Errorf("Expected the 2.0 document server, got %v", servers)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	doc30, err := NewDocument([]byte(`{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}}}},
+		"components": {"schemas": {"Pet": {"type": "object", "required": ["name", "id"], "properties": {"id": {"type": "integer"}, "name": {"type": "string", "nullable": true}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	doc31, err := NewDocument([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}}}},
+		"components": {"schemas": {"Pet": {"required": ["id", "name"], "type": ["object"], "properties": {"id": {"type": "integer"}, "name": {"type": ["null", "string"]}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if diffs, err := Diff(doc30, doc31); err != nil || len(diffs) != 0 {
+		t.Errorf("Diff() = %v, %v; want no differences", diffs, err)
+	}
+	if !Equal(doc30, doc31) {
+		t.Error("Expected the 3.0 and 3.1 documents to be equal")
+	}
+
+	doc20, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"get": {"produces": ["application/json"], "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pet"}}}}}},
+		"definitions": {"Pet": {"type": "object", "properties": {"id": {"type": "integer"}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	converted, _, err := doc20.ConvertTo("3.0")
+	if err != nil {
+		t.Fatalf("ConvertTo() error = %v", err)
+	}
+	if diffs, err := Diff(doc20, converted); err != nil || len(diffs) != 0 {
+		t.Errorf("Diff() of a converted document = %v, %v; want no differences", diffs, err)
+	}
+
+	diffs, err := Diff(doc20, doc30)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	var got []string
+	for _, d := range diffs {
+		got = append(got, d.String())
+	}
+	want := `[added /components/schemas/Pet/properties/name: {"type":["null","string"]} added /components/schemas/Pet/required: ["id","name"]]`
+	if fmt.Sprint(got) != want {
+		t.Errorf("Diff() = %v, want %s", got, want)
+	}
+	if diffs[0].Kind != DiffAdded || diffs[0].A != nil {
+		t.Errorf("Unexpected difference %+v", diffs[0])
+	}
+
+	edited, _ := NewDocument([]byte(`{"openapi": "3.1.0", "info": {"title": "Dogs", "version": "1"}}`))
+	diffs, _ = Diff(doc31, edited)
+	if len(diffs) != 3 || diffs[0].Pointer != "/components" || diffs[1].String() != `changed /info/title: "Pets" -> "Dogs"` || diffs[2].Kind != DiffRemoved || diffs[2].Pointer != "/paths" {
+		t.Errorf("Unexpected differences %v", diffs)
+	}
+
+	if Equal(doc30, nil) {
+		t.Error("Expected a nil document not to be equal")
+	}
+	if _, err := Diff(nil, doc30); err == nil {
+		t.Error("Expected an error for a nil document")
+	}
+}
+
+func TestFormDataRequestBody(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"consumes": ["application/json"],
+		"paths": {
+			"/upload": {"post": {
+				"parameters": [
+					{"name": "file", "in": "formData", "type": "file", "required": true},
+					{"$ref": "#/parameters/Note"},
+					{"name": "tags", "in": "formData", "type": "array", "items": {"type": "string", "maxLength": 8}},
+					{"name": "id", "in": "query", "type": "integer"}
+				],
+				"responses": {"200": {"description": "OK"}}
+			}},
+			"/login": {"post": {
+				"consumes": ["application/x-www-form-urlencoded", "multipart/form-data", "application/json"],
+				"parameters": [{"name": "user", "in": "formData", "type": "string"}],
+				"responses": {"200": {"description": "OK"}}
+			}},
+			"/search": {"get": {
+				"parameters": [{"name": "q", "in": "query", "type": "string"}],
+				"responses": {"200": {"description": "OK"}}
+			}}
+		},
+		"parameters": {"Note": {"name": "note", "in": "formData", "type": "string", "description": "A note"}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+
+	body := doc.GetPaths()["/upload"].GetOperation("post").GetRequestBody()
+	if body.IsNil() || body.HasRef() || !body.GetRequired() {
+		t.Fatal("Expected a required request body from the formData parameters")
+	}
+	content := body.GetContent()
+	if len(content) != 1 || content["multipart/form-data"] == nil {
+		t.Fatalf("Expected multipart/form-data for a file upload, got %v", content)
+	}
+	schema := content["multipart/form-data"].GetSchema()
+	props := schema.GetProperties()
+	if schema.GetType() != "object" || len(props) != 3 || fmt.Sprint(schema.GetRequired()) != "[file]" {
+		t.Errorf("Unexpected form schema %q %v %v", schema.GetType(), props, schema.GetRequired())
+	}
+	if props["file"].GetType() != "file" || props["note"].GetDescription() != "A note" {
+		t.Error("Expected the file and the referenced note properties")
+	}
+	if items := props["tags"].GetItems(); items.GetType() != "string" || *items.GetMaxLength() != 8 {
+		t.Error("Expected the tags items")
+	}
+
+	login := doc.GetPaths()["/login"].GetOperation("post").GetRequestBody()
+	if login.GetRequired() || len(login.GetContent()) != 2 || login.GetContent()["application/json"] != nil {
+		t.Errorf("Expected the two form media types of consumes, got %v", login.GetContent())
+	}
+
+	if body := doc.GetPaths()["/search"].GetOperation("get").GetRequestBody(); !body.IsNil() {
+		t.Error("Expected no request body without body or formData parameters")
+	}
+}
+
+func TestInfoContactAndLicense(t *testing.T) {
+	for _, tt := range []struct {
+		head       string
+		identifier string
+	}{
+		{`"swagger": "2.0"`, ""},
+		{`"openapi": "3.0.3"`, ""},
+		{`"openapi": "3.1.0"`, "MIT"},
+		{`"openapi": "3.2.0"`, "MIT"},
+	} {
+		license := `{"name": "MIT", "url": "https://opensource.org/licenses/MIT"}`
+		if tt.identifier != "" {
+			license = `{"name": "MIT", "identifier": "MIT"}`
+		}
+		doc, err := NewDocument([]byte(`{
+			` + tt.head + `,
+			"info": {
+				"title": "T", "version": "1",
+				"termsOfService": "https://example.com/terms",
+				"contact": {"name": "API Team", "email": "api@example.com", "url": "https://example.com", "x-team": "pets"},
+				"license": ` + license + `
+			},
+			"paths": {}
+		}`))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		info := doc.GetInfo()
+		version := doc.Version()
+		if info.GetTermsOfService() != "https://example.com/terms" {
+			t.Errorf("%s: unexpected terms of service %q", version, info.GetTermsOfService())
+		}
+		contact := info.GetContact()
+		if contact == nil || contact.GetName() != "API Team" || contact.GetEmail() != "api@example.com" || contact.GetURL() != "https://example.com" || contact.GetExtensions()["x-team"] != "pets" {
+			t.Errorf("%s: unexpected contact %v", version, contact)
+		}
+		l := info.GetLicense()
+		if l == nil || l.GetName() != "MIT" || l.GetIdentifier() != tt.identifier {
+			t.Errorf("%s: unexpected license %v", version, l)
+		}
+		if tt.identifier == "" && l.GetURL() != "https://opensource.org/licenses/MIT" {
+			t.Errorf("%s: unexpected license URL %q", version, l.GetURL())
+		}
+	}
+
+	doc, _ := NewDocument([]byte(`{"openapi": "3.0.3", "info": {"title": "T", "version": "1"}, "paths": {}}`))
+	if doc.GetInfo().GetContact() != nil || doc.GetInfo().GetLicense() != nil || doc.GetInfo().GetTermsOfService() != "" {
+		t.Error("Expected no contact, license or terms of service")
+	}
+}
+
+func TestGetMediaType(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0", "3.2.0"} {
+		doc, err := NewDocument([]byte(`{
+			"openapi": "` + version + `",
+			"info": {"title": "T", "version": "1"},
+			"paths": {"/pets": {"patch": {
+				"requestBody": {"content": {"application/*+json": {"schema": {"type": "object"}}, "*/*": {"schema": {"type": "string", "format": "binary"}}}},
+				"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array"}}, "application/*": {"schema": {"type": "string"}}}}}
+			}}}
+		}`))
+		if err != nil {
+			t.Fatalf("%s: NewDocument() error = %v", version, err)
+		}
+		op := doc.GetPaths()["/pets"].GetOperation("patch")
+		body := op.GetRequestBody()
+		if mt := body.GetMediaType("application/merge-patch+json"); mt == nil || mt.GetSchema().GetType() != "object" {
+			t.Errorf("%s: expected the +json range", version)
+		}
+		if mt := body.GetMediaType("image/png"); mt == nil || mt.GetSchema().GetFormat() != "binary" {
+			t.Errorf("%s: expected the */* range", version)
+		}
+		resp := op.GetResponses().GetStatusCodes()["200"]
+		if mt := resp.GetMediaType("application/json; charset=utf-8"); mt == nil || mt.GetSchema().GetType() != "array" {
+			t.Errorf("%s: expected application/json", version)
+		}
+		if mt := resp.GetMediaType("application/xml"); mt == nil || mt.GetSchema().GetType() != "string" {
+			t.Errorf("%s: expected application/*", version)
+		}
+		if resp.GetMediaType("text/html") != nil {
+			t.Errorf("%s: expected no match for text/html", version)
+		}
+	}
+
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "T", "version": "1"},
+		"produces": ["application/json"],
+		"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK", "schema": {"type": "array"}}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	resp := doc.GetPaths()["/pets"].GetOperation("get").GetResponses().GetStatusCodes()["200"]
+	if mt := resp.GetMediaType("Application/Json"); mt == nil || mt.GetSchema().GetType() != "array" {
+		t.Error("Expected the 2.0 produces media type")
+	}
+	if (NilResponse{}).GetMediaType("application/json") != nil {
+		t.Error("Expected nil for NilResponse")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	const gateway = `{
+		"openapi": "3.1.0",
+		"info": {"title": "Gateway", "version": "1"},
+		"security": [{"apiKey": []}],
+		"tags": [{"name": "pets", "description": "Pets"}],
+		"paths": {"/pets": {"get": {"operationId": "listPets", "tags": ["pets"], "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}}}},
+		"components": {
+			"schemas": {"Pet": {"type": "object", "properties": {"name": {"type": "string"}}}, "Error": {"type": "string"}},
+			"securitySchemes": {"apiKey": {"type": "apiKey", "in": "header", "name": "X-Key"}}
+		}
+	}`
+	team, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Team", "version": "1"},
+		"tags": [{"name": "pets", "description": "Team pets"}],
+		"paths": {
+			"/pets": {"post": {"operationId": "createPet", "tags": ["pets"], "parameters": [{"in": "body", "name": "pet", "schema": {"$ref": "#/definitions/Pet"}}], "responses": {"201": {"description": "Created"}}}},
+			"/owners": {"get": {"operationId": "listOwners", "security": [{"apiKey": []}], "responses": {"default": {"description": "Error", "schema": {"$ref": "#/definitions/Error"}}}}}
+		},
+		"definitions": {"Pet": {"type": "object", "properties": {"id": {"type": "integer"}}}, "Error": {"type": "string"}},
+		"securityDefinitions": {"apiKey": {"type": "apiKey", "in": "query", "name": "key"}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	parse := func() Document {
+		doc, err := NewDocument([]byte(gateway))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		return doc
+	}
+
+	dst := parse()
+	if err := Merge(dst, team, MergeOptions{}); err == nil {
+		t.Error("Expected a conflict with MergeError")
+	} else if !Equal(dst, parse()) {
+		t.Error("Expected a failed merge to leave the destination unchanged")
+	}
+	if err := Merge(dst, team, MergeOptions{Strategy: MergeRename}); err == nil {
+		t.Error("Expected MergeRename without a prefix to fail")
+	}
+
+	if err := Merge(dst, team, MergeOptions{Strategy: MergeRename, Prefix: "Team"}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	schemas := dst.GetComponents().GetSchemas()
+	if schemas["Pet"] == nil || schemas["TeamPet"] == nil || schemas["TeamError"] != nil {
+		t.Errorf("Expected Pet, TeamPet and the shared Error, got %v", slices.Sorted(maps.Keys(schemas)))
+	}
+	if dst.GetSecuritySchemes()["TeamapiKey"] == nil {
+		t.Error("Expected the renamed security scheme")
+	}
+	create := dst.GetPaths()["/pets"].GetOperation("post")
+	if create.IsNil() || dst.GetPaths()["/pets"].GetOperation("get").IsNil() {
+		t.Fatal("Expected both /pets operations")
+	}
+	if ref := create.GetRequestBody().GetContent()["application/json"].GetSchema().GetRef(); ref != "#/components/schemas/TeamPet" {
+		t.Errorf("Expected the request body to reference TeamPet, got %q", ref)
+	}
+	if tags := create.GetTags(); len(tags) != 1 || tags[0] != "Teampets" {
+		t.Errorf("Expected the renamed tag, got %v", tags)
+	}
+	// The team document had no global security, so its operations stay open
+	if security := create.GetSecurity(); security == nil || len(security) != 0 {
+		t.Errorf("Expected an empty security list, got %v", security)
+	}
+	_, _, owners := dst.GetOperationByID("listOwners")
+	if security := owners.GetSecurity(); len(security) != 1 || security[0]["TeamapiKey"] == nil {
+		t.Errorf("Expected the renamed scheme in the operation security, got %v", security)
+	}
+
+	dst = parse()
+	if err := Merge(dst, team, MergeOptions{Strategy: MergePreferDst}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if len(dst.GetComponents().GetSchemas()["Pet"].GetProperties()) != 1 || dst.GetComponents().GetSchemas()["Pet"].GetProperties()["name"] == nil {
+		t.Error("Expected the destination Pet to be kept")
+	}
+	if len(dst.GetTags()) != 1 || dst.GetTags()[0].GetDescription() != "Pets" {
+		t.Errorf("Expected the destination tag to be kept, got %v", dst.GetTags())
+	}
+}
+
+func TestMergeRenamedSecurity(t *testing.T) {
+	const gateway = `{
+		"openapi": "3.1.0",
+		"info": {"title": "Gateway", "version": "1"},
+		"security": [{"k": []}],
+		"paths": {"/dogs": {"get": {"operationId": "dogs", "responses": {"200": {"description": "OK"}}}}},
+		"components": {"securitySchemes": {"k": {"type": "apiKey", "in": "header", "name": "X-A"}}}
+	}`
+	const team = `{
+		"openapi": "3.1.0",
+		"info": {"title": "Team", "version": "1"},
+		"security": [{"k": []}],
+		"paths": {"/dogs": {"post": {"operationId": "dogs", "responses": {"201": {"description": "Created",
+			"content": {"application/json": {"example": {"operationId": "dogs", "security": [{"k": []}]}}},
+			"links": {"self": {"operationId": "dogs"}}}}}}},
+		"components": {"securitySchemes": {"k": {"type": "apiKey", "in": "header", "name": "X-B"}}}
+	}`
+	parse := func(source string) Document {
+		doc, err := NewDocument([]byte(source))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		return doc
+	}
+
+	dst := parse(gateway)
+	// Only the operationIds conflict once the schemes are the same
+	if err := Merge(dst, parse(strings.Replace(team, "X-B", "X-A", 1)), MergeOptions{}); err == nil ||
+		!strings.Contains(err.Error(), "/paths/~1dogs/post/operationId") {
+		t.Errorf("Expected a conflict on the operationId, got %v", err)
+	}
+
+	if err := Merge(dst, parse(team), MergeOptions{Strategy: MergeRename, Prefix: "B"}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	item := dst.GetPaths()["/dogs"]
+	post := item.GetOperation("post")
+	if security := post.GetSecurity(); len(security) != 1 || security[0]["Bk"] == nil {
+		t.Errorf("Expected the renamed scheme kept on the source operation, got %v", security)
+	}
+	if security := item.GetOperation("get").GetSecurity(); security != nil {
+		t.Errorf("Expected the destination operation to keep the global security, got %v", security)
+	}
+	if id := post.GetOperationID(); id != "Bdogs" {
+		t.Errorf("Expected the operationId prefixed, got %q", id)
+	}
+	root, err := documentObject(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link := object(root, false, "paths", "/dogs", "post", "responses", "201", "links", "self"); link["operationId"] != "Bdogs" {
+		t.Errorf("Expected the link to follow the operationId, got %v", link)
+	}
+	example := object(root, false, "paths", "/dogs", "post", "responses", "201", "content", "application/json", "example")
+	if security, _ := example["security"].([]any); example["operationId"] != "dogs" || len(security) != 1 || security[0].(map[string]any)["k"] == nil {
+		t.Errorf("Expected the example left alone, got %v", example)
+	}
+
+	dst = parse(gateway)
+	if err := Merge(dst, parse(team), MergeOptions{Strategy: MergePreferDst}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if id := dst.GetPaths()["/dogs"].GetOperation("post").GetOperationID(); id != "" {
+		t.Errorf("Expected the source operationId dropped, got %q", id)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	for _, source := range []string{`{
+		"openapi": "3.0.3",
+		"info": {"title": "Internal", "version": "1"},
+		"tags": [{"name": "pets"}, {"name": "admin"}],
+		"paths": {
+			"/pets": {
+				"get": {"operationId": "listPets", "tags": ["pets"], "security": [{"oauth": ["read"]}], "responses": {"200": {"$ref": "#/components/responses/Pets"}}},
+				"delete": {"operationId": "purgePets", "tags": ["admin"], "responses": {"204": {"description": "Purged"}}}
+			},
+			"/admin/users": {"get": {"operationId": "listUsers", "tags": ["admin"], "security": [{"basic": []}], "responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}}}}
+		},
+		"components": {
+			"responses": {"Pets": {"description": "Pets", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}},
+			"schemas": {
+				"Pet": {"type": "object", "properties": {"owner": {"$ref": "#/components/schemas/Owner"}}, "discriminator": {"propertyName": "kind", "mapping": {"dog": "Dog"}}},
+				"Owner": {"type": "object"},
+				"Dog": {"type": "object"},
+				"User": {"type": "object"}
+			},
+			"securitySchemes": {"oauth": {"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://example.com/token", "scopes": {"read": "Read"}}}}, "basic": {"type": "http", "scheme": "basic"}}
+		}
+	}`, `{
+		"swagger": "2.0",
+		"info": {"title": "Internal", "version": "1"},
+		"tags": [{"name": "pets"}, {"name": "admin"}],
+		"paths": {
+			"/pets": {
+				"get": {"operationId": "listPets", "tags": ["pets"], "security": [{"oauth": ["read"]}], "responses": {"200": {"$ref": "#/responses/Pets"}}},
+				"delete": {"operationId": "purgePets", "tags": ["admin"], "responses": {"204": {"description": "Purged"}}}
+			},
+			"/admin/users": {"get": {"operationId": "listUsers", "tags": ["admin"], "security": [{"basic": []}], "responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/User"}}}}}
+		},
+		"responses": {"Pets": {"description": "Pets", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}}},
+		"definitions": {
+			"Pet": {"type": "object", "properties": {"owner": {"$ref": "#/definitions/Owner"}}},
+			"Owner": {"type": "object"},
+			"Dog": {"type": "object"},
+			"User": {"type": "object"}
+		},
+		"securityDefinitions": {"oauth": {"type": "oauth2", "flow": "application", "tokenUrl": "https://example.com/token", "scopes": {"read": "Read"}}, "basic": {"type": "basic"}}
+	}`} {
+		doc, err := NewDocument([]byte(source))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		filtered, err := Filter(doc, FilterOptions{Tags: []string{"pets"}})
+		if err != nil {
+			t.Fatalf("Filter() error = %v", err)
+		}
+		v := doc.Version()
+		paths := filtered.GetPaths()
+		if len(paths) != 1 || paths["/pets"] == nil || len(paths["/pets"].GetAllOperations()) != 1 {
+			t.Fatalf("%s: expected only GET /pets, got %v", v, slices.Sorted(maps.Keys(paths)))
+		}
+		schemas := slices.Sorted(maps.Keys(filtered.GetComponents().GetSchemas()))
+		want := []string{"Dog", "Owner", "Pet"}
+		if strings.HasPrefix(v, "2.") {
+			want = []string{"Owner", "Pet"}
+		}
+		if !slices.Equal(schemas, want) {
+			t.Errorf("%s: expected schemas %v, got %v", v, want, schemas)
+		}
+		if responses := filtered.GetComponents().GetResponses(); len(responses) != 1 {
+			t.Errorf("%s: expected the Pets response, got %d", v, len(responses))
+		}
+		if schemes := filtered.GetSecuritySchemes(); len(schemes) != 1 || schemes["oauth"] == nil {
+			t.Errorf("%s: expected only the oauth scheme, got %v", v, slices.Sorted(maps.Keys(schemes)))
+		}
+		if tags := filtered.GetTags(); len(tags) != 1 || tags[0].GetName() != "pets" {
+			t.Errorf("%s: expected only the pets tag, got %v", v, tags)
+		}
+		if len(doc.GetPaths()) != 2 {
+			t.Errorf("%s: expected the source document to be unchanged", v)
+		}
+
+		byID, err := Filter(doc, FilterOptions{OperationIDs: []string{"listUsers"}, Paths: []string{"/pets"}})
+		if err != nil {
+			t.Fatalf("Filter() error = %v", err)
+		}
+		if len(byID.GetPaths()) != 2 || len(byID.GetPaths()["/pets"].GetAllOperations()) != 2 {
+			t.Errorf("%s: expected both paths with all /pets operations", v)
+		}
+	}
+}
+
+func TestFilterExtension(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Internal", "version": "1"},
+		"paths": {
+			"/pets": {
+				"get": {
+					"parameters": [{"name": "debug", "in": "query", "x-internal": true, "schema": {"type": "boolean"}}, {"$ref": "#/components/parameters/Limit"}],
+					"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}
+				},
+				"delete": {"x-internal": true, "responses": {"204": {"$ref": "#/components/responses/Audit"}}}
+			},
+			"/internal": {"get": {"x-internal": true, "responses": {"200": {"description": "OK"}}}}
+		},
+		"components": {
+			"parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}},
+			"responses": {"Audit": {"description": "Audited", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Audit"}}}}},
+			"schemas": {
+				"Pet": {
+					"type": "object",
+					"required": ["name", "cost"],
+					"properties": {"name": {"type": "string"}, "cost": {"type": "number", "x-internal": true}, "secret": {"$ref": "#/components/schemas/Secret"}}
+				},
+				"Secret": {"type": "string", "x-internal": false, "x-internal-note": "kept"},
+				"Hidden": {"x-internal": true, "type": "string"},
+				"Alias": {"$ref": "#/components/schemas/Hidden"},
+				"Audit": {"type": "object"},
+				"Unused": {"type": "object"}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	public, err := FilterExtension(doc, "x-internal")
+	if err != nil {
+		t.Fatalf("FilterExtension() error = %v", err)
+	}
+	if _, err := FilterExtension(doc, "internal"); err == nil {
+		t.Error("Expected an error for a name without x-")
+	}
+
+	paths := public.GetPaths()
+	if len(paths) != 1 || len(paths["/pets"].GetAllOperations()) != 1 {
+		t.Fatalf("Expected only GET /pets, got %v", slices.Sorted(maps.Keys(paths)))
+	}
+	params := paths["/pets"].GetOperation("get").GetParameters()
+	if len(params) != 1 || params[0].GetRef() != "#/components/parameters/Limit" {
+		t.Errorf("Expected only the limit parameter, got %d", len(params))
+	}
+	schemas := public.GetComponents().GetSchemas()
+	if got := slices.Sorted(maps.Keys(schemas)); !slices.Equal(got, []string{"Pet", "Secret", "Unused"}) {
+		t.Errorf("Expected Pet, Secret and Unused, got %v", got)
+	}
+	pet := schemas["Pet"]
+	if got := slices.Sorted(maps.Keys(pet.GetProperties())); !slices.Equal(got, []string{"name", "secret"}) {
+		t.Errorf("Expected the name and secret properties, got %v", got)
+	}
+	if required := pet.GetRequired(); !slices.Equal(required, []string{"name"}) {
+		t.Errorf("Expected only name to be required, got %v", required)
+	}
+	if len(public.GetComponents().GetResponses()) != 0 {
+		t.Error("Expected the response only the internal operation used to be dropped")
+	}
+	if len(doc.GetPaths()) != 2 {
+		t.Error("Expected the source document to be unchanged")
+	}
+}
+
+func TestPruneUnused(t *testing.T) {
+	for _, source := range []string{`{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"security": [{"apiKey": []}],
+		"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pets"}}}}}}}},
+		"components": {
+			"schemas": {"Pets": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}, "Pet": {"type": "object"}, "Owner": {"type": "object"}},
+			"parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}},
+			"securitySchemes": {"apiKey": {"type": "apiKey", "in": "header", "name": "X-Key"}, "basic": {"type": "http", "scheme": "basic"}}
+		}
+	}`, `{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1"},
+		"security": [{"apiKey": []}],
+		"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK", "schema": {"$ref": "#/definitions/Pets"}}}}}},
+		"definitions": {"Pets": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}, "Pet": {"type": "object"}, "Owner": {"type": "object"}},
+		"parameters": {"Limit": {"name": "limit", "in": "query", "type": "integer"}},
+		"securityDefinitions": {"apiKey": {"type": "apiKey", "in": "header", "name": "X-Key"}, "basic": {"type": "basic"}}
+	}`} {
+		doc, err := NewDocument([]byte(source))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		removed, err := PruneUnused(doc)
+		if err != nil {
+			t.Fatalf("PruneUnused() error = %v", err)
+		}
+		want := []string{"/components/parameters/Limit", "/components/schemas/Owner", "/components/securitySchemes/basic"}
+		if strings.HasPrefix(doc.Version(), "2.") {
+			want = []string{"/definitions/Owner", "/parameters/Limit", "/securityDefinitions/basic"}
+		}
+		if !slices.Equal(removed, want) {
+			t.Errorf("%s: PruneUnused() = %v, want %v", doc.Version(), removed, want)
+		}
+		if got := slices.Sorted(maps.Keys(doc.GetComponents().GetSchemas())); !slices.Equal(got, []string{"Pet", "Pets"}) {
+			t.Errorf("%s: expected Pet and Pets to remain, got %v", doc.Version(), got)
+		}
+		if len(doc.GetComponents().GetParameters()) != 0 || len(doc.GetSecuritySchemes()) != 1 {
+			t.Errorf("%s: expected the unused parameter and scheme to be removed", doc.Version())
+		}
+		if removed, err := PruneUnused(doc); err != nil || removed != nil {
+			t.Errorf("%s: second PruneUnused() = %v, %v; want nothing", doc.Version(), removed, err)
+		}
+	}
+}
+
+func TestGenerateOperationIDs(t *testing.T) {
+	const source = `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {
+			"/pets": {
+				"get": {"tags": ["Pet Store"], "responses": {"200": {"description": "OK"}}},
+				"post": {"operationId": "postPets", "responses": {"201": {"description": "Created"}}}
+			},
+			"/pets/{petId}/vaccination-records": {"get": {"responses": {"200": {"description": "OK"}}}},
+			"/pets/": {"post": {"responses": {"201": {"description": "Created"}}}}
+		}
+	}`
+	tests := []struct {
+		strategy   OperationIDStrategy
+		want       map[string]string
+		collisions int
+	}{
+		{OperationIDMethodPath, map[string]string{
+			"GET /pets":                             "getPets",
+			"GET /pets/{petId}/vaccination-records": "getPetsByPetIdVaccinationRecords",
+			"POST /pets/":                           "postPets2",
+		}, 1},
+		{OperationIDTagPrefixed, map[string]string{
+			"GET /pets": "petStoreGetPets",
+		}, 1},
+	}
+	for _, tt := range tests {
+		doc, err := NewDocument([]byte(source))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		collisions, err := GenerateOperationIDs(doc, tt.strategy)
+		if err != nil {
+			t.Fatalf("GenerateOperationIDs() error = %v", err)
+		}
+		if len(collisions) != tt.collisions {
+			t.Errorf("strategy %d: expected %d collisions, got %v", tt.strategy, tt.collisions, collisions)
+		} else if c := collisions[0]; c.ID != "postPets" || c.Assigned != "postPets2" || c.Path != "/pets/" {
+			t.Errorf("strategy %d: unexpected collision %v", tt.strategy, c)
+		}
+		for key, want := range tt.want {
+			method, path, _ := strings.Cut(key, " ")
+			if got := doc.GetPaths()[path].GetOperation(method).GetOperationID(); got != want {
+				t.Errorf("strategy %d: %s operationId = %q, want %q", tt.strategy, key, got, want)
+			}
+		}
+		if path, _, _ := doc.GetOperationByID("postPets"); path != "/pets" {
+			t.Errorf("Expected the existing operationId to be kept, found it at %q", path)
+		}
+		if again, err := GenerateOperationIDs(doc, tt.strategy); err != nil || again != nil {
+			t.Errorf("second GenerateOperationIDs() = %v, %v; want nothing", again, err)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	const source = `{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1"},
+		"tags": [{"name": "store"}, {"name": "pets"}],
+		"paths": {"/pets": {"get": {"parameters": [{"name": "size", "in": "query", "required": true, "schema": {"enum": ["small", "large", "medium"]}}], "responses": {"200": {"description": "OK"}}}}},
+		"components": {"schemas": {"Pet": {"type": "object", "required": ["name", "id"], "properties": {"id": {"type": "integer"}, "name": {"type": "string", "default": "b", "x-order": ["z", "a"]}}}}}
+	}`
+	doc, err := NewDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if err := Sort(doc, nil); err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+	if tags := doc.GetTags(); tags[0].GetName() != "pets" || tags[1].GetName() != "store" {
+		t.Errorf("Expected tags in name order, got %v", tags)
+	}
+	pet := doc.GetComponents().GetSchemas()["Pet"]
+	if !slices.Equal(pet.GetRequired(), []string{"id", "name"}) {
+		t.Errorf("Expected sorted required list, got %v", pet.GetRequired())
+	}
+	enum := doc.GetPaths()["/pets"].GetOperation("get").GetParameters()[0].GetSchema().GetEnum()
+	if fmt.Sprint(enum) != "[large medium small]" {
+		t.Errorf("Expected sorted enum, got %v", enum)
+	}
+	if order := pet.GetProperties()["name"].GetExtensions()["x-order"]; fmt.Sprint(order) != "[z a]" {
+		t.Errorf("Expected extensions to be left alone, got %v", order)
+	}
+
+	descending := func(a, b string) int { return strings.Compare(b, a) }
+	if err := Sort(doc, descending); err != nil {
+		t.Fatalf("Sort() error = %v", err)
+	}
+	if tags := doc.GetTags(); tags[0].GetName() != "store" {
+		t.Errorf("Expected tags in reverse order, got %v", tags)
+	}
+}
+
+func TestRewriteServers(t *testing.T) {
+	const source = `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"servers": [{"url": "https://{region}.example.com/v1", "variables": {"region": {"default": "eu"}}}, {"url": "/v1"}],
+		"paths": {"/pets": {
+			"servers": [{"url": "http://pets.example.com"}],
+			"get": {"operationId": "listPets", "responses": {"200": {"description": "OK", "links": {"self": {"operationRef": "#/paths/~1pets/get", "server": {"url": "https://other.example.com/v1"}}}}}}
+		}}
+	}`
+	doc, err := NewDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if err := RewriteServers(doc, ServerRewrite{Host: "api.example.com", BasePath: "/", PathPrefix: "/api/v2"}); err != nil {
+		t.Fatalf("RewriteServers() error = %v", err)
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	servers := root["servers"].([]any)
+	if first := servers[0].(map[string]any); first["url"] != "https://api.example.com" || first["variables"] != nil {
+		t.Errorf("Expected the host swapped and the unused variable dropped, got %v", first)
+	}
+	if second := servers[1].(map[string]any); second["url"] != "//api.example.com" {
+		t.Errorf("Expected a relative server to get the host, got %v", second)
+	}
+	item := object(root, false, "paths", "/api/v2/pets")
+	if item == nil {
+		t.Fatalf("Expected the path to be prefixed, got %v", object(root, false, "paths"))
+	}
+	if url := item["servers"].([]any)[0].(map[string]any)["url"]; url != "http://api.example.com" {
+		t.Errorf("Expected the path item server rewritten, got %v", url)
+	}
+	link := object(item, false, "get", "responses", "200", "links", "self")
+	if link["operationRef"] != "#/paths/~1api~1v2~1pets/get" {
+		t.Errorf("Expected the operationRef to follow the path, got %v", link["operationRef"])
+	}
+	if url := object(link, false, "server")["url"]; url != "https://api.example.com" {
+		t.Errorf("Expected the link server rewritten, got %v", url)
+	}
+
+	bare, err := NewDocument([]byte(`{"openapi": "3.1.0", "info": {"title": "T", "version": "1"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RewriteServers(bare, ServerRewrite{Scheme: "https", Host: "api.example.com", BasePath: "/v2"}); err != nil {
+		t.Fatalf("RewriteServers() error = %v", err)
+	}
+	if servers := bare.GetServers(); len(servers) != 1 || servers[0].GetURL() != "https://api.example.com/v2" {
+		t.Errorf("Expected a server for the default one, got %v", servers)
+	}
+
+	swagger, err := NewDocument([]byte(`{"swagger": "2.0", "info": {"title": "T", "version": "1"}, "host": "old.example.com", "basePath": "/v1", "schemes": ["http"],
+		"paths": {"/pets": {"get": {"schemes": ["http"], "responses": {"200": {"description": "OK"}}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RewriteServers(swagger, ServerRewrite{Scheme: "https", Host: "api.example.com", BasePath: "/v2/", PathPrefix: "/pets-api"}); err != nil {
+		t.Fatalf("RewriteServers() error = %v", err)
+	}
+	if servers := swagger.GetServers(); len(servers) != 1 || servers[0].GetURL() != "https://api.example.com/v2" {
+		t.Errorf("Expected the 2.0 host, basePath and schemes rewritten, got %v", servers)
+	}
+	op := swagger.GetPaths()["/pets-api/pets"].GetOperation("get")
+	if op == nil {
+		t.Fatalf("Expected the 2.0 path to be prefixed, got %v", swagger.GetPaths())
+	}
+
+	for _, rewrite := range []ServerRewrite{{Scheme: "https://"}, {Host: "a/b"}, {BasePath: "v1"}, {PathPrefix: "/api/"}} {
+		if err := RewriteServers(doc, rewrite); err == nil {
+			t.Errorf("Expected an error for %+v", rewrite)
+		}
+	}
+}
+
+func TestApplySecurity(t *testing.T) {
+	const source = `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"security": [{"apiKey": []}],
+		"paths": {"/pets": {
+			"get": {"operationId": "listPets", "security": [{"apiKey": []}], "responses": {"200": {"description": "OK"}}},
+			"post": {"operationId": "createPet", "responses": {"201": {"description": "Created"}}}
+		}, "/health": {
+			"get": {"operationId": "health", "security": [], "responses": {"200": {"description": "OK"}}}
+		}},
+		"components": {"securitySchemes": {
+			"apiKey": {"type": "apiKey", "in": "header", "name": "X-Key"},
+			"gateway": {"type": "oauth2", "flows": {"clientCredentials": {"tokenUrl": "https://auth.example.com/token", "scopes": {"read": "Read"}}}}
+		}}
+	}`
+	gateway := []SecurityRequirement{{"gateway": {"read"}}}
+
+	doc, err := NewDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if err := ApplySecurity(doc, gateway, SecurityDocument); err != nil {
+		t.Fatalf("ApplySecurity() error = %v", err)
+	}
+	if security := doc.GetGlobalSecurity(); fmt.Sprint(security) != "[map[gateway:[read]]]" {
+		t.Errorf("Expected the gateway security, got %v", security)
+	}
+	if security := doc.GetPaths()["/pets"].GetOperation("get").GetSecurity(); fmt.Sprint(security) != "[map[apiKey:[]]]" {
+		t.Errorf("Expected operations to keep their security, got %v", security)
+	}
+
+	doc, err = NewDocument([]byte(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplySecurity(doc, gateway, SecurityAllOperations); err != nil {
+		t.Fatalf("ApplySecurity() error = %v", err)
+	}
+	for _, method := range []string{"get", "post"} {
+		if security := doc.GetPaths()["/pets"].GetOperation(method).GetSecurity(); fmt.Sprint(security) != "[map[gateway:[read]]]" {
+			t.Errorf("Expected %s to get the gateway security, got %v", method, security)
+		}
+	}
+	if security := doc.GetPaths()["/health"].GetOperation("get").GetSecurity(); security == nil || len(security) != 0 {
+		t.Errorf("Expected the opted out operation to stay public, got %v", security)
+	}
+
+	if err := ApplySecurity(doc, nil, SecurityDocument); err != nil {
+		t.Fatalf("ApplySecurity() error = %v", err)
+	}
+	if security := doc.GetGlobalSecurity(); len(security) != 0 {
+		t.Errorf("Expected the security removed, got %v", security)
+	}
+	if err := ApplySecurity(doc, []SecurityRequirement{{"missing": nil}}, SecurityDocument); err == nil {
+		t.Error("Expected an error for an undefined scheme")
+	}
+}
+
+func TestTagNormalization(t *testing.T) {
+	const source = `{
+		"openapi": "3.2.0",
+		"info": {"title": "Pets", "version": "1"},
+		"tags": [{"name": "pets"}, {"name": "store", "description": "Store"}, {"name": "pets", "description": "Pets"}, {"name": "cats", "parent": "pets"}],
+		"paths": {
+			"/pets": {"get": {"operationId": "listPets", "tags": ["pets", "animals", "pets"], "responses": {"200": {"description": "OK"}}}},
+			"/orders": {"get": {"operationId": "listOrders", "tags": ["orders", "store"], "responses": {"200": {"description": "OK"}}}}
+		}
+	}`
+	doc, err := NewDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	names := func() []string {
+		var result []string
+		for _, tag := range doc.GetTags() {
+			result = append(result, tag.GetName())
+		}
+		return result
+	}
+
+	merged, err := MergeDuplicateTags(doc)
+	if err != nil {
+		t.Fatalf("MergeDuplicateTags() error = %v", err)
+	}
+	if !slices.Equal(merged, []string{"pets"}) || !slices.Equal(names(), []string{"pets", "store", "cats"}) {
+		t.Errorf("Expected pets merged, got %v and tags %v", merged, names())
+	}
+	if description := doc.GetTags()[0].GetDescription(); description != "Pets" {
+		t.Errorf("Expected the merged tag to gain the description, got %q", description)
+	}
+	if tags := doc.GetPaths()["/pets"].GetOperation("get").GetTags(); !slices.Equal(tags, []string{"pets", "animals"}) {
+		t.Errorf("Expected repeated operation tags dropped, got %v", tags)
+	}
+
+	declared, err := DeclareTags(doc)
+	if err != nil {
+		t.Fatalf("DeclareTags() error = %v", err)
+	}
+	if !slices.Equal(declared, []string{"orders", "animals"}) || !slices.Equal(names(), []string{"pets", "store", "cats", "orders", "animals"}) {
+		t.Errorf("Expected orders and animals declared, got %v and tags %v", declared, names())
+	}
+
+	if err := ReorderTags(doc, "store", "orders"); err != nil {
+		t.Fatalf("ReorderTags() error = %v", err)
+	}
+	if !slices.Equal(names(), []string{"store", "orders", "pets", "cats", "animals"}) {
+		t.Errorf("Expected store and orders first, got %v", names())
+	}
+	if err := ReorderTags(doc, "missing"); err == nil {
+		t.Error("Expected an error for an undeclared tag")
+	}
+
+	if err := RenameTag(doc, "pets", "animals"); err == nil {
+		t.Error("Expected an error for renaming onto a declared tag")
+	}
+	if err := RenameTag(doc, "pets", "pet"); err != nil {
+		t.Fatalf("RenameTag() error = %v", err)
+	}
+	if tags := doc.GetPaths()["/pets"].GetOperation("get").GetTags(); !slices.Equal(tags, []string{"pet", "animals"}) {
+		t.Errorf("Expected the operation tag renamed, got %v", tags)
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cats := root["tags"].([]any)[3].(map[string]any); cats["parent"] != "pet" {
+		t.Errorf("Expected the parent renamed, got %v", cats)
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	const source = `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"tags": [{"name": "pets"}, {"name": "store"}],
+		"paths": {"/pets": {"get": {"operationId": "listPets", "responses": {"200": {"description": "OK"}}}}}
+	}`
+	doc, err := NewDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	patch := `[
+		{"op": "test", "path": "/info/title", "value": "Pets"},
+		{"op": "replace", "path": "/info/title", "value": "Pet Store"},
+		{"op": "add", "path": "/tags/1", "value": {"name": "cats"}},
+		{"op": "remove", "path": "/tags/0"},
+		{"op": "copy", "from": "/paths/~1pets", "path": "/paths/~1cats"},
+		{"op": "replace", "path": "/paths/~1cats/get/operationId", "value": "listCats"},
+		{"op": "move", "from": "/info/title", "path": "/info/x-title"},
+		{"op": "add", "path": "/info/title", "value": "Pets v2"},
+		{"op": "add", "path": "/tags/-", "value": {"name": "last"}},
+		{"op": "replace", "path": "/tags/1", "value": {"name": "dogs"}}
+	]`
+	if err := ApplyJSONPatch(doc, []byte(patch)); err != nil {
+		t.Fatalf("ApplyJSONPatch() error = %v", err)
+	}
+	if title := doc.GetInfo().GetTitle(); title != "Pets v2" {
+		t.Errorf("Expected the new title, got %q", title)
+	}
+	if moved := doc.GetInfo().GetExtensions()["x-title"]; moved != "Pet Store" {
+		t.Errorf("Expected the moved title, got %v", moved)
+	}
+	var names []string
+	for _, tag := range doc.GetTags() {
+		names = append(names, tag.GetName())
+	}
+	if !slices.Equal(names, []string{"cats", "dogs", "last"}) {
+		t.Errorf("Expected tags cats, dogs, last, got %v", names)
+	}
+	if id := doc.GetPaths()["/cats"].GetOperation("get").GetOperationID(); id != "listCats" {
+		t.Errorf("Expected the copied operation renamed, got %q", id)
+	}
+	if id := doc.GetPaths()["/pets"].GetOperation("get").GetOperationID(); id != "listPets" {
+		t.Errorf("Expected the copy to leave the source alone, got %q", id)
+	}
+
+	for name, patch := range map[string]string{
+		"failed test":   `[{"op": "replace", "path": "/info/title", "value": "X"}, {"op": "test", "path": "/info/version", "value": "2"}]`,
+		"missing path":  `[{"op": "remove", "path": "/info/contact"}]`,
+		"bad index":     `[{"op": "add", "path": "/tags/9", "value": {"name": "x"}}]`,
+		"replace end":   `[{"op": "replace", "path": "/tags/-", "value": {"name": "x"}}]`,
+		"replace new":   `[{"op": "replace", "path": "/info/summary", "value": "x"}]`,
+		"into itself":   `[{"op": "move", "from": "/info", "path": "/info/child"}]`,
+		"invalid":       `[{"op": "remove", "path": "/info"}]`,
+		"unknown op":    `[{"op": "rename", "path": "/info"}]`,
+		"missing value": `[{"op": "add", "path": "/info/summary"}]`,
+	} {
+		if err := ApplyJSONPatch(doc, []byte(patch)); err == nil {
+			t.Errorf("Expected an error for %s", name)
+		}
+	}
+	if title := doc.GetInfo().GetTitle(); title != "Pets v2" {
+		t.Errorf("Expected failed patches to leave the document unchanged, got %q", title)
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1", "description": "Old"},
+		"paths": {"/pets": {"get": {"operationId": "listPets", "responses": {"200": {"description": "OK"}}}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	patch := `{"info": {"description": null, "version": "2"}, "paths": {"/pets": {"get": {"summary": "List pets"}}}}`
+	if err := ApplyMergePatch(doc, []byte(patch)); err != nil {
+		t.Fatalf("ApplyMergePatch() error = %v", err)
+	}
+	info := doc.GetInfo()
+	if info.GetVersion() != "2" || info.GetDescription() != "" || info.GetTitle() != "Pets" {
+		t.Errorf("Expected version 2 without a description, got %q %q %q", info.GetTitle(), info.GetVersion(), info.GetDescription())
+	}
+	op := doc.GetPaths()["/pets"].GetOperation("get")
+	if op.GetSummary() != "List pets" || op.GetOperationID() != "listPets" {
+		t.Errorf("Expected the summary merged in, got %q %q", op.GetSummary(), op.GetOperationID())
+	}
+	if err := ApplyMergePatch(doc, []byte(`{"info": null}`)); err == nil {
+		t.Error("Expected an error for a patch that invalidates the document")
+	}
+	if err := ApplyMergePatch(doc, []byte(`[]`)); err == nil {
+		t.Error("Expected an error for a patch that is not an object")
+	}
+	if doc.GetInfo().GetTitle() != "Pets" {
+		t.Error("Expected failed patches to leave the document unchanged")
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {
+			"/pets": {
+				"get": {"operationId": "listPets", "tags": ["public"], "responses": {"200": {"description": "OK"}}},
+				"post": {"operationId": "createPet", "tags": ["admin"], "x-rate-limit-tier": "gold", "responses": {"201": {"description": "Created"}}}
+			},
+			"/health": {"get": {"operationId": "health", "responses": {"200": {"description": "OK"}}}}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	changed, err := Annotate(doc,
+		Annotation{Extensions: map[string]any{"x-owner": "pets-team"}},
+		Annotation{Select: FilterOptions{Tags: []string{"public"}}, Extensions: map[string]any{"x-rate-limit-tier": "free"}},
+		Annotation{Select: FilterOptions{OperationIDs: []string{"health"}}, Extensions: map[string]any{"x-owner": "platform"}},
+	)
+	if err != nil {
+		t.Fatalf("Annotate() error = %v", err)
+	}
+	if changed != 3 {
+		t.Errorf("Expected 3 operations changed, got %d", changed)
+	}
+	for _, tc := range []struct{ path, method, owner, tier string }{
+		{"/pets", "get", "pets-team", "free"},
+		{"/pets", "post", "pets-team", "gold"},
+		{"/health", "get", "platform", ""},
+	} {
+		ext := doc.GetPaths()[tc.path].GetOperation(tc.method).GetExtensions()
+		if ext["x-owner"] != tc.owner || (tc.tier != "" && ext["x-rate-limit-tier"] != tc.tier) {
+			t.Errorf("%s %s: unexpected extensions %v", tc.method, tc.path, ext)
+		}
+	}
+	if _, err := Annotate(doc, Annotation{Extensions: map[string]any{"tier": 1}}); err == nil {
+		t.Error("Expected an error for a name without x-")
+	}
+}
+
+func TestResponsesGetByStatus(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"get": {"responses": {
+			"200": {"description": "OK"},
+			"4XX": {"$ref": "#/components/responses/ClientError"},
+			"default": {"description": "Error"}
+		}}}},
+		"components": {"responses": {"ClientError": {"description": "Client error"}}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	if doc, err = Resolve(doc); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	responses := doc.GetPaths()["/pets"].GetOperation("get").GetResponses()
+	for status, want := range map[int]string{200: "OK", 404: "Client error", 500: "Error"} {
+		if resp := responses.GetByStatus(status); resp.GetDescription() != want {
+			t.Errorf("GetByStatus(%d) = %q, want %q", status, resp.GetDescription(), want)
+		}
+	}
+	if !(NilResponses{}).GetByStatus(200).IsNil() {
+		t.Error("Expected no response from NilResponses")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a, err := NewDocument([]byte(`{"openapi": "3.1.0", "info": {"title": "Pets", "version": "1"},
+		"components": {"schemas": {"Pet": {"type": ["object"], "required": ["name", "id"]}, "Tag": {"type": "string"}}}}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	b, err := NewDocument([]byte(`{"components": {"schemas": {"Tag": {"type": "string"}, "Pet": {"required": ["id", "name"], "type": "object"}}},
+		"info": {"version": "1", "title": "Pets"}, "openapi": "3.1.0"}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	fa, err := Fingerprint(a)
+	if err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+	fb, err := Fingerprint(b)
+	if err != nil {
+		t.Fatalf("Fingerprint() error = %v", err)
+	}
+	if fa != fb || len(fa) != 64 {
+		t.Errorf("Expected equal fingerprints for equal documents, got %s and %s", fa, fb)
+	}
+
+	pet, err := ComponentFingerprint(a, "#/components/schemas/Pet")
+	if err != nil {
+		t.Fatalf("ComponentFingerprint() error = %v", err)
+	}
+	editor, err := NewEditor(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := editor.SetSchema("Tag", map[string]any{"type": "integer"}); err != nil {
+		t.Fatal(err)
+	}
+	if changed, _ := Fingerprint(b); changed == fa {
+		t.Error("Expected the fingerprint to change with the document")
+	}
+	if same, _ := ComponentFingerprint(b, "#/components/schemas/Pet"); same != pet {
+		t.Error("Expected an unchanged component to keep its fingerprint")
+	}
+	if _, err := ComponentFingerprint(a, "#/components/schemas/Missing"); err == nil {
+		t.Error("Expected an error for a missing component")
+	}
+	if _, err := ComponentFingerprint(a, "other.json#/components/schemas/Pet"); err == nil {
+		t.Error("Expected an error for a reference into another document")
+	}
+}
+
+func TestDedupeSchemas(t *testing.T) {
+	const source = `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"post": {
+			"requestBody": {"content": {"application/json": {"schema": {"type": "object", "properties": {"street": {"type": "string"}, "city": {"type": "string"}}},
+				"example": {"$ref": "#/components/schemas/PetCopy", "mapping": {"pet": "PetCopy"}}}}},
+			"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/PetCopy"}}}}}
+		}}},
+		"components": {"schemas": {
+			"Pet": {"type": "object", "properties": {"name": {"type": "string"}, "owner": {"$ref": "#/components/schemas/Owner"}}},
+			"PetCopy": {"type": "object", "properties": {"name": {"type": "string"}, "owner": {"$ref": "#/components/schemas/OwnerCopy"}}},
+			"Owner": {"type": "object", "properties": {"home": {"type": "object", "properties": {"street": {"type": "string"}, "city": {"type": "string"}}}}},
+			"OwnerCopy": {"type": "object", "properties": {"home": {"type": "object", "properties": {"street": {"type": "string"}, "city": {"type": "string"}}}}},
+			"Animal": {"oneOf": [{"$ref": "#/components/schemas/PetCopy"}], "discriminator": {"propertyName": "kind", "mapping": {"pet": "PetCopy"}}}
+		}}
+	}`
+	doc, err := NewDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	found, err := FindDuplicateSchemas(doc)
+	if err != nil {
+		t.Fatalf("FindDuplicateSchemas() error = %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("Expected the owners and the addresses found, got %+v", found)
+	}
+	for _, g := range found {
+		if g.Schema == "#/components/schemas/Owner" && !slices.Equal(g.Duplicates, []string{"/components/schemas/OwnerCopy"}) {
+			t.Errorf("Expected OwnerCopy to duplicate Owner, got %v", g.Duplicates)
+		}
+		if g.Schema == "" && len(g.Duplicates) != 3 {
+			t.Errorf("Expected three inline addresses, got %v", g.Duplicates)
+		}
+	}
+
+	if _, err := DedupeSchemas(doc); err != nil {
+		t.Fatalf("DedupeSchemas() error = %v", err)
+	}
+	schemas := doc.GetComponents().GetSchemas()
+	names := slices.Sorted(maps.Keys(schemas))
+	if !slices.Equal(names, []string{"Animal", "Owner", "OwnerHome", "Pet"}) {
+		t.Fatalf("Expected the copies consolidated and the address named OwnerHome, got %v", names)
+	}
+	if ref := schemas["Owner"].GetProperties()["home"].GetRef(); ref != "#/components/schemas/OwnerHome" {
+		t.Errorf("Expected the owner to reference OwnerHome, got %q", ref)
+	}
+	op := doc.GetPaths()["/pets"].GetOperation("post")
+	if ref := op.GetRequestBody().GetContent()["application/json"].GetSchema().GetRef(); ref != "#/components/schemas/OwnerHome" {
+		t.Errorf("Expected the request body to reference OwnerHome, got %q", ref)
+	}
+	if ref := op.GetResponses().GetStatusCodes()["200"].GetContent()["application/json"].GetSchema().GetRef(); ref != "#/components/schemas/Pet" {
+		t.Errorf("Expected the response to reference Pet, got %q", ref)
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping := object(root, false, "components", "schemas", "Animal", "discriminator", "mapping"); mapping["pet"] != "Pet" {
+		t.Errorf("Expected the mapping renamed, got %v", mapping)
+	}
+	example := object(root, false, "paths", "/pets", "post", "requestBody", "content", "application/json", "example")
+	if example["$ref"] != "#/components/schemas/PetCopy" || object(example, false, "mapping")["pet"] != "PetCopy" {
+		t.Errorf("Expected the $ref-shaped example left alone, got %v", example)
+	}
+	if again, _ := FindDuplicateSchemas(doc); len(again) != 0 {
+		t.Errorf("Expected no duplicates left, got %+v", again)
+	}
+}
+
+func TestDedupeSchemasBranches(t *testing.T) {
+	const source = `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"get": {"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}}}},
+		"components": {"schemas": {
+			"Pet": {"oneOf": [{"$ref": "#/components/schemas/Cat"}, {"$ref": "#/components/schemas/Dog"}],
+				"discriminator": {"propertyName": "kind", "mapping": {"cat": "Cat", "dog": "#/components/schemas/Dog"}}},
+			"Cat": {"type": "object", "properties": {"kind": {"type": "string"}}},
+			"Dog": {"type": "object", "properties": {"kind": {"type": "string"}}},
+			"Kitten": {"type": "object", "properties": {"kind": {"type": "string"}}},
+			"Toy": {"anyOf": [
+				{"type": "object", "properties": {"size": {"type": "integer"}}},
+				{"type": "object", "properties": {"size": {"type": "integer"}}}
+			]}
+		}}
+	}`
+	doc, err := NewDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	result, err := DedupeSchemas(doc)
+	if err != nil {
+		t.Fatalf("DedupeSchemas() error = %v", err)
+	}
+	if len(result) != 1 || result[0].Schema != "#/components/schemas/Cat" || !slices.Equal(result[0].Duplicates, []string{"/components/schemas/Kitten"}) {
+		t.Errorf("Expected only Kitten consolidated into Cat, got %+v", result)
+	}
+	schemas := doc.GetComponents().GetSchemas()
+	if names := slices.Sorted(maps.Keys(schemas)); !slices.Equal(names, []string{"Cat", "Dog", "Pet", "Toy"}) {
+		t.Fatalf("Expected the branches kept apart, got %v", names)
+	}
+	var refs []string
+	for _, branch := range schemas["Pet"].GetOneOf() {
+		refs = append(refs, branch.GetRef())
+	}
+	if !slices.Equal(refs, []string{"#/components/schemas/Cat", "#/components/schemas/Dog"}) {
+		t.Errorf("Expected the oneOf branches unchanged, got %v", refs)
+	}
+	root, err := documentObject(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping := object(root, false, "components", "schemas", "Pet", "discriminator", "mapping"); mapping["dog"] != "#/components/schemas/Dog" {
+		t.Errorf("Expected the mapping unchanged, got %v", mapping)
+	}
+	for _, branch := range schemas["Toy"].GetAnyOf() {
+		if branch.GetRef() != "" {
+			t.Errorf("Expected the inline anyOf branches kept, got %q", branch.GetRef())
+		}
+	}
+}
+
+func TestExtractInlineSchemas(t *testing.T) {
+	const source = `{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {
+			"post": {"operationId": "createPet",
+				"requestBody": {"content": {"application/json": {"schema": {"title": "New pet", "type": "object", "properties": {"name": {"type": "string"}, "owner": {"type": "object", "properties": {"id": {"type": "integer"}}}}}}}},
+				"responses": {"201": {"description": "Created", "content": {"application/json": {"schema": {"type": "object", "properties": {"id": {"type": "integer"}}}}}},
+					"404": {"description": "Not found", "content": {"application/json": {"schema": {"type": "object", "properties": {"code": {"type": "integer"}}}}}}}
+			},
+			"get": {"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {"type": "object", "properties": {"id": {"type": "integer"}}}}}}}}}
+		}},
+		"components": {"schemas": {
+			"Pet": {"type": "object", "properties": {"tag": {"type": "object", "properties": {"label": {"type": "string"}}}}},
+			"CreatePetResponse": {"type": "string"}
+		}}
+	}`
+	names := func(t *testing.T, naming SchemaNaming) (Document, []string) {
+		doc, err := NewDocument([]byte(source))
+		if err != nil {
+			t.Fatalf("NewDocument() error = %v", err)
+		}
+		extracted, err := ExtractInlineSchemas(doc, naming)
+		if err != nil {
+			t.Fatalf("ExtractInlineSchemas() error = %v", err)
+		}
+		var result []string
+		for _, e := range extracted {
+			result = append(result, strings.TrimPrefix(e.Schema, "#/components/schemas/"))
+		}
+		return doc, result
+	}
+
+	doc, extracted := names(t, SchemaNamingOperation)
+	want := []string{"GetPetsResponseItem", "CreatePetRequest", "CreatePetRequestOwner", "CreatePetResponse2", "CreatePet404Response", "PetTag"}
+	if !slices.Equal(extracted, want) {
+		t.Errorf("Expected %v, got %v", want, extracted)
+	}
+	schemas := doc.GetComponents().GetSchemas()
+	request := schemas["CreatePetRequest"]
+	if request == nil || request.GetProperties()["owner"].GetRef() != "#/components/schemas/CreatePetRequestOwner" {
+		t.Fatalf("Expected the request to reference its extracted owner, got %v", request)
+	}
+	op := doc.GetPaths()["/pets"].GetOperation("post")
+	if ref := op.GetRequestBody().GetContent()["application/json"].GetSchema().GetRef(); ref != "#/components/schemas/CreatePetRequest" {
+		t.Errorf("Expected the request body to reference CreatePetRequest, got %q", ref)
+	}
+	if ref := schemas["Pet"].GetProperties()["tag"].GetRef(); ref != "#/components/schemas/PetTag" {
+		t.Errorf("Expected the nested component schema extracted, got %q", ref)
+	}
+	if again, err := ExtractInlineSchemas(doc, SchemaNamingOperation); err != nil || again != nil {
+		t.Errorf("Expected nothing left to extract, got %v, %v", again, err)
+	}
+
+	_, extracted = names(t, SchemaNamingTitle)
+	if !slices.Contains(extracted, "NewPet") || !slices.Contains(extracted, "NewPetOwner") {
+		t.Errorf("Expected title-based names, got %v", extracted)
+	}
+}
+
+func TestDeprecate(t *testing.T) {
+	const source = `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {
+			"/v1/pets": {"get": {"operationId": "listPetsV1", "responses": {"200": {"description": "OK"}, "default": {"$ref": "#/components/responses/Error"}}}},
+			"/v10/pets": {"get": {"operationId": "listPetsV10", "responses": {"200": {"description": "OK"}}}},
+			"/toys": {"get": {"operationId": "listToys", "tags": ["legacy"], "responses": {"200": {"description": "OK"}}},
+				"post": {"operationId": "createToy", "x-retire": false, "responses": {"201": {"description": "Created"}}},
+				"put": {"operationId": "replaceToy", "x-retire": true, "responses": {"200": {"description": "OK"}}}}
+		},
+		"components": {
+			"schemas": {"OldPet": {"type": "object", "x-retire": true}, "Pet": {"type": "object"}},
+			"responses": {"Error": {"description": "Error"}}
+		}
+	}`
+	doc, err := NewDocument([]byte(source))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	report, err := Deprecate(doc, DeprecationOptions{
+		Tags:         []string{"legacy"},
+		PathPrefixes: []string{"/v1/"},
+		Extension:    "x-retire",
+		Sunset:       "2027-01-01",
+		SunsetHeader: true,
+	})
+	if err != nil {
+		t.Fatalf("Deprecate() error = %v", err)
+	}
+	if want := []string{"/paths/~1toys/get", "/paths/~1toys/put", "/paths/~1v1~1pets/get"}; !slices.Equal(report.Operations, want) {
+		t.Errorf("Expected operations %v, got %v", want, report.Operations)
+	}
+	if want := []string{"/components/schemas/OldPet"}; !slices.Equal(report.Schemas, want) {
+		t.Errorf("Expected schemas %v, got %v", want, report.Schemas)
+	}
+	op := doc.GetPaths()["/v1/pets"].GetOperation("get")
+	if !op.GetDeprecated() || op.GetExtensions()["x-sunset"] != "2027-01-01" {
+		t.Errorf("Expected the operation deprecated with a sunset, got %v", op.GetExtensions())
+	}
+	if _, ok := op.GetResponses().GetStatusCodes()["200"].GetHeaders()["Sunset"]; !ok {
+		t.Error("Expected a Sunset header on the response")
+	}
+	for _, path := range []string{"/v10/pets", "/toys"} {
+		method := map[string]string{"/v10/pets": "get", "/toys": "post"}[path]
+		if doc.GetPaths()[path].GetOperation(method).GetDeprecated() {
+			t.Errorf("Expected %s %s not to be deprecated", method, path)
+		}
+	}
+	if !doc.GetComponents().GetSchemas()["OldPet"].GetDeprecated() || doc.GetComponents().GetSchemas()["Pet"].GetDeprecated() {
+		t.Error("Expected only OldPet deprecated")
+	}
+}
+
+func TestStats(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1"},
+		"tags": [{"name": "pets"}],
+		"paths": {
+			"/pets": {
+				"parameters": [{"$ref": "#/components/parameters/Limit"}],
+				"get": {"responses": {"200": {"description": "OK", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}}}},
+				"post": {"deprecated": true, "requestBody": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}, "responses": {"201": {"description": "Created"}}}
+			},
+			"/pets/{id}": {"delete": {"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}], "responses": {"204": {"description": "Deleted"}}}}
+		},
+		"webhooks": {"newPet": {"post": {"responses": {"200": {"description": "OK"}}}}},
+		"components": {
+			"parameters": {"Limit": {"name": "limit", "in": "query", "schema": {"type": "integer"}}},
+			"schemas": {
+				"Pet": {"type": "object", "properties": {"status": {"type": "string", "enum": ["available", "sold", "pending"]}, "owner": {"type": "object", "properties": {"tags": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}}}}}}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	stats, err := Stats(doc)
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.Paths != 2 || stats.Webhooks != 1 || stats.Operations != 4 || stats.DeprecatedOperations != 1 {
+		t.Errorf("Unexpected operation counts: %+v", stats)
+	}
+	if want := map[string]int{"get": 1, "post": 2, "delete": 1}; !maps.Equal(stats.OperationsByMethod, want) {
+		t.Errorf("Expected operations by method %v, got %v", want, stats.OperationsByMethod)
+	}
+	if stats.Tags != 1 || stats.Schemas != 1 || stats.Parameters != 3 || stats.RequestBodies != 1 || stats.Responses != 4 {
+		t.Errorf("Unexpected object counts: %+v", stats)
+	}
+	if stats.Enums != 2 || stats.EnumValues != 5 || stats.MaxEnumSize != 3 {
+		t.Errorf("Unexpected enum counts: %+v", stats)
+	}
+	if stats.MaxSchemaDepth != 4 {
+		t.Errorf("Expected a maximum schema depth of 4, got %d", stats.MaxSchemaDepth)
+	}
+	if want := map[string]int{"#/components/schemas/Pet": 2, "#/components/parameters/Limit": 1}; stats.Refs != 3 || !maps.Equal(stats.RefTargets, want) {
+		t.Errorf("Expected 3 references %v, got %d %v", want, stats.Refs, stats.RefTargets)
+	}
+}
+
+func TestDeprecations(t *testing.T) {
+	doc, err := NewDocument([]byte(`{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {
+			"/pets": {
+				"get": {"operationId": "listPets", "deprecated": true, "x-sunset": "2027-01-01", "x-deprecated-at": "2026-06-01",
+					"parameters": [{"name": "sort", "in": "query", "deprecated": true, "schema": {"type": "string"}}],
+					"responses": {"200": {"description": "OK"}}},
+				"post": {"deprecated": true, "responses": {"201": {"description": "Created"}}}
+			}
+		},
+		"components": {"schemas": {
+			"OldPet": {"type": "object", "deprecated": true, "x-sunset": "2027-01-01"},
+			"Pet": {"type": "object", "properties": {"legacyId": {"type": "integer", "deprecated": true}}}
+		}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	want := []DeprecatedElement{
+		{Kind: DeprecatedOperation, Pointer: "/paths/~1pets/get", Name: "listPets", Sunset: "2027-01-01", DeprecatedAt: "2026-06-01"},
+		{Kind: DeprecatedParameter, Pointer: "/paths/~1pets/get/parameters/0", Name: "sort"},
+		{Kind: DeprecatedOperation, Pointer: "/paths/~1pets/post", Name: "POST /pets"},
+		{Kind: DeprecatedSchema, Pointer: "/components/schemas/OldPet", Name: "OldPet", Sunset: "2027-01-01"},
+		{Kind: DeprecatedSchema, Pointer: "/components/schemas/Pet/properties/legacyId", Name: "legacyId"},
+	}
+	if got := Deprecations(doc); !slices.Equal(got, want) {
+		t.Errorf("Deprecations() =\n%v\nwant\n%v", got, want)
+	}
+
+	doc20, err := NewDocument([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"get": {"parameters": [{"name": "sort", "in": "query", "type": "string", "x-deprecated": true}], "responses": {"200": {"description": "OK"}}}}},
+		"definitions": {"Pet": {"type": "object", "x-deprecated": true}}
+	}`))
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+	want = []DeprecatedElement{
+		{Kind: DeprecatedParameter, Pointer: "/paths/~1pets/get/parameters/0", Name: "sort"},
+		{Kind: DeprecatedSchema, Pointer: "/definitions/Pet", Name: "Pet"},
+	}
+	if got := Deprecations(doc20); !slices.Equal(got, want) {
+		t.Errorf("Deprecations() of 2.0 =\n%v\nwant\n%v", got, want)
+	}
+}
@@ -0,0 +1,330 @@
+// Package unified provides a depth-first traversal of unified documents
+// Copyright (c) Greetingland LLC
+
+package unified
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	oa32 "github.com/genelet/oas/openapi32"
+)
+
+// Visitor holds the callbacks of Walk. Each one receives a node and its JSON
+// pointer in the document; nil callbacks are skipped.
+type Visitor struct {
+	Operation   func(pointer string, op Operation)
+	Parameter   func(pointer string, param Parameter)
+	RequestBody func(pointer string, body RequestBody)
+	Response    func(pointer string, resp Response)
+	Header      func(pointer string, header Header)
+	Schema      func(pointer string, schema Schema)
+}
+
+// Walk traverses the paths, webhooks and components of a document depth-first
+// and in key order, calling the visitor on every node before its children.
+// Operations are reached through path items, callbacks and webhooks.
+//
+// A reference, resolved or not, is visited but not descended into: its target
+// is visited where it is defined, which also keeps recursive schemas finite.
+// In 2.0 documents the request body is the body parameter, and the schemas of
+// other parameters and of headers share the pointer of their owner.
+func Walk(doc Document, v Visitor) {
+	if doc == nil {
+		return
+	}
+	w := &walker{v: v, v20: strings.HasPrefix(doc.Version(), "2.")}
+	for _, path := range slices.Sorted(maps.Keys(doc.GetPaths())) {
+		w.pathItem(doc.GetPaths()[path], pointer("", "paths", path))
+	}
+	for _, name := range slices.Sorted(maps.Keys(doc.GetWebhooks())) {
+		w.pathItem(doc.GetWebhooks()[name], pointer("", "webhooks", name))
+	}
+	w.components(doc.GetComponents())
+}
+
+// walker carries the visitor through a traversal
+type walker struct {
+	v   Visitor
+	v20 bool
+}
+
+// pointerEscaper escapes a reference token per RFC 6901
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pointer appends reference tokens to a JSON pointer
+func pointer(base string, tokens ...string) string {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, t := range tokens {
+		b.WriteByte('/')
+		b.WriteString(pointerEscaper.Replace(t))
+	}
+	return b.String()
+}
+
+func (w *walker) components(c Components) {
+	section := func(name string) string {
+		if w.v20 {
+			switch name {
+			case "schemas":
+				return "/definitions"
+			case "parameters", "responses":
+				return "/" + name
+			}
+		}
+		return pointer("", "components", name)
+	}
+	if c == nil {
+		return
+	}
+	schemas := c.GetSchemas()
+	for _, name := range slices.Sorted(maps.Keys(schemas)) {
+		w.schema(schemas[name], pointer(section("schemas"), name))
+	}
+	responses := c.GetResponses()
+	for _, name := range slices.Sorted(maps.Keys(responses)) {
+		w.response(responses[name], pointer(section("responses"), name))
+	}
+	parameters := c.GetParameters()
+	for _, name := range slices.Sorted(maps.Keys(parameters)) {
+		w.parameter(parameters[name], pointer(section("parameters"), name))
+	}
+	bodies := c.GetRequestBodies()
+	for _, name := range slices.Sorted(maps.Keys(bodies)) {
+		w.requestBody(bodies[name], pointer(section("requestBodies"), name))
+	}
+	headers := c.GetHeaders()
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		w.header(headers[name], pointer(section("headers"), name))
+	}
+	callbacks := c.GetCallbacks()
+	for _, name := range slices.Sorted(maps.Keys(callbacks)) {
+		w.callback(callbacks[name], pointer(section("callbacks"), name))
+	}
+	items := c.GetPathItems()
+	for _, name := range slices.Sorted(maps.Keys(items)) {
+		w.pathItem(items[name], pointer(section("pathItems"), name))
+	}
+}
+
+func (w *walker) pathItem(item PathItem, ptr string) {
+	if item == nil || item.HasRef() || RawRef(item) != "" {
+		return
+	}
+	for i, param := range item.GetParameters() {
+		w.parameter(param, pointer(ptr, "parameters", strconv.Itoa(i)))
+	}
+	operations := item.GetAllOperations()
+	for _, method := range slices.Sorted(maps.Keys(operations)) {
+		w.operation(operations[method], pointer(ptr, operationTokens(item, method)...))
+	}
+}
+
+// operationTokens locates an operation returned by GetAllOperations in its
+// path item: at the method, or at the 3.2 additionalOperations entry
+func operationTokens(item PathItem, method string) []string {
+	if p, ok := item.(*pathItem32); ok && p.item != nil && !oa32.IsFixedMethod(method) {
+		return []string{"additionalOperations", additionalOperation32(p.item, method)}
+	}
+	return []string{method}
+}
+
+// additionalOperation32 returns the key of the additional operation of the
+// path item for the method, or "" if there is none
+func additionalOperation32(item *oa32.PathItem, method string) string {
+	for name, op := range item.AdditionalOperations {
+		if op != nil && strings.EqualFold(name, method) {
+			return name
+		}
+	}
+	return ""
+}
+
+func (w *walker) operation(op Operation, ptr string) {
+	if op == nil || op.IsNil() {
+		return
+	}
+	if w.v.Operation != nil {
+		w.v.Operation(ptr, op)
+	}
+	if o, ok := op.(*operation20); ok {
+		// The 2.0 body parameter is the request body, in the parameter list
+		for i, param := range o.op.Parameters {
+			if param == nil {
+				continue
+			}
+			if param.IsBodyParameter() {
+				w.requestBody(op.GetRequestBody(), pointer(ptr, "parameters", strconv.Itoa(i)))
+			} else {
+				w.parameter(o.r.parameter(param), pointer(ptr, "parameters", strconv.Itoa(i)))
+			}
+		}
+	} else {
+		for i, param := range op.GetParameters() {
+			w.parameter(param, pointer(ptr, "parameters", strconv.Itoa(i)))
+		}
+		w.requestBody(op.GetRequestBody(), pointer(ptr, "requestBody"))
+	}
+	responses := op.GetResponses()
+	if responses != nil {
+		if resp := responses.GetDefault(); resp != nil && !resp.IsNil() {
+			w.response(resp, pointer(ptr, "responses", "default"))
+		}
+		codes := responses.GetStatusCodes()
+		for _, code := range slices.Sorted(maps.Keys(codes)) {
+			w.response(codes[code], pointer(ptr, "responses", code))
+		}
+	}
+	callbacks := op.GetCallbacks()
+	for _, name := range slices.Sorted(maps.Keys(callbacks)) {
+		w.callback(callbacks[name], pointer(ptr, "callbacks", name))
+	}
+}
+
+func (w *walker) callback(cb Callback, ptr string) {
+	if cb == nil || cb.HasRef() {
+		return
+	}
+	items := cb.GetPathItems()
+	for _, expression := range slices.Sorted(maps.Keys(items)) {
+		w.pathItem(items[expression], pointer(ptr, expression))
+	}
+}
+
+func (w *walker) parameter(param Parameter, ptr string) {
+	if param == nil {
+		return
+	}
+	if w.v.Parameter != nil {
+		w.v.Parameter(ptr, param)
+	}
+	if param.HasRef() || RawRef(param) != "" {
+		return
+	}
+	if w.v20 && !param.IsBodyParameter() {
+		w.schema(param.GetSchema(), ptr)
+		return
+	}
+	w.schema(param.GetSchema(), pointer(ptr, "schema"))
+	w.content(param.GetContent(), ptr)
+}
+
+func (w *walker) requestBody(body RequestBody, ptr string) {
+	if body == nil || body.IsNil() {
+		return
+	}
+	if w.v.RequestBody != nil {
+		w.v.RequestBody(ptr, body)
+	}
+	if body.HasRef() || RawRef(body) != "" {
+		return
+	}
+	if w.v20 {
+		// Every 2.0 media type shares the schema of the body parameter
+		for _, mt := range body.GetContent() {
+			w.schema(mt.GetSchema(), pointer(ptr, "schema"))
+			break
+		}
+		return
+	}
+	w.content(body.GetContent(), ptr)
+}
+
+func (w *walker) response(resp Response, ptr string) {
+	if resp == nil || resp.IsNil() {
+		return
+	}
+	if w.v.Response != nil {
+		w.v.Response(ptr, resp)
+	}
+	if resp.HasRef() || RawRef(resp) != "" {
+		return
+	}
+	headers := resp.GetHeaders()
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		w.header(headers[name], pointer(ptr, "headers", name))
+	}
+	if w.v20 {
+		w.schema(resp.GetSchema(), pointer(ptr, "schema"))
+		return
+	}
+	w.content(resp.GetContent(), ptr)
+}
+
+func (w *walker) content(content map[string]MediaType, ptr string) {
+	for _, ct := range slices.Sorted(maps.Keys(content)) {
+		mt := content[ct]
+		if mt == nil {
+			continue
+		}
+		w.schema(mt.GetSchema(), pointer(ptr, "content", ct, "schema"))
+		encoding := mt.GetEncoding()
+		for _, name := range slices.Sorted(maps.Keys(encoding)) {
+			headers := encoding[name].GetHeaders()
+			for _, header := range slices.Sorted(maps.Keys(headers)) {
+				w.header(headers[header], pointer(ptr, "content", ct, "encoding", name, "headers", header))
+			}
+		}
+	}
+}
+
+func (w *walker) header(header Header, ptr string) {
+	if header == nil {
+		return
+	}
+	if w.v.Header != nil {
+		w.v.Header(ptr, header)
+	}
+	if w.v20 {
+		w.schema(header.GetSchema(), ptr)
+		return
+	}
+	w.schema(header.GetSchema(), pointer(ptr, "schema"))
+	w.content(header.GetContent(), ptr)
+}
+
+func (w *walker) schema(schema Schema, ptr string) {
+	if schema == nil || schema.IsNil() {
+		return
+	}
+	if w.v.Schema != nil {
+		w.v.Schema(ptr, schema)
+	}
+	if schema.GetRef() != "" || RawRef(schema) != "" {
+		return
+	}
+	properties := schema.GetProperties()
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		w.schema(properties[name], pointer(ptr, "properties", name))
+	}
+	patterns := schema.GetPatternProperties()
+	for _, pattern := range slices.Sorted(maps.Keys(patterns)) {
+		w.schema(patterns[pattern], pointer(ptr, "patternProperties", pattern))
+	}
+	w.schema(schema.GetAdditionalProperties(), pointer(ptr, "additionalProperties"))
+	w.schema(schema.GetItems(), pointer(ptr, "items"))
+	for i, s := range schema.GetPrefixItems() {
+		w.schema(s, pointer(ptr, "prefixItems", strconv.Itoa(i)))
+	}
+	w.schema(schema.GetContains(), pointer(ptr, "contains"))
+	for i, s := range schema.GetAllOf() {
+		w.schema(s, pointer(ptr, "allOf", strconv.Itoa(i)))
+	}
+	for i, s := range schema.GetAnyOf() {
+		w.schema(s, pointer(ptr, "anyOf", strconv.Itoa(i)))
+	}
+	for i, s := range schema.GetOneOf() {
+		w.schema(s, pointer(ptr, "oneOf", strconv.Itoa(i)))
+	}
+	w.schema(schema.GetNot(), pointer(ptr, "not"))
+	w.schema(schema.GetIf(), pointer(ptr, "if"))
+	w.schema(schema.GetThen(), pointer(ptr, "then"))
+	w.schema(schema.GetElse(), pointer(ptr, "else"))
+	dependent := schema.GetDependentSchemas()
+	for _, name := range slices.Sorted(maps.Keys(dependent)) {
+		w.schema(dependent[name], pointer(ptr, "dependentSchemas", name))
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/form"
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+)
+
+// Request validates a request against the operation the router matched: its
+// path, query, header and cookie parameters, then its body. Parameters are
+// decoded by their style and converted to the types of their schema. A
+// JSON, form-urlencoded or multipart body is decoded and validated; the body
+// is replaced so that handlers can read it again. It returns Errors, or nil when the request
+// matches.
+func Request(req *http.Request, m *router.Match) error {
+	var found []Error
+	for _, p := range Parameters(m) {
+		found = append(found, parameter(req, m, p)...)
+	}
+	found = append(found, requestBody(req, m.Operation.GetRequestBody())...)
+	if len(found) > 0 {
+		return Errors(found)
+	}
+	return nil
+}
+
+// Parameters returns the parameters of a matched operation: those of its
+// path item, overridden by those of the operation with the same location and
+// name. The body and formData parameters of 2.0, which make the request body,
+// and 3.2 querystring parameters are left out.
+func Parameters(m *router.Match) []unified.Parameter {
+	var params []unified.Parameter
+	index := make(map[string]int)
+	add := func(list []unified.Parameter) {
+		for _, p := range list {
+			if p == nil || p.IsBodyParameter() || p.GetIn() == "formData" || p.GetIn() == "querystring" {
+				continue
+			}
+			key := p.GetIn() + " " + p.GetName()
+			if p.GetIn() == "header" {
+				key = strings.ToLower(key)
+			}
+			if i, ok := index[key]; ok {
+				params[i] = p
+				continue
+			}
+			index[key] = len(params)
+			params = append(params, p)
+		}
+	}
+	if m.PathItem != nil {
+		add(m.PathItem.GetParameters())
+	}
+	add(m.Operation.GetParameters())
+	return params
+}
+
+// ignoredHeaders are the header parameters the specification ignores
+var ignoredHeaders = []string{"Accept", "Content-Type", "Authorization"}
+
+// parameter validates a parameter of a request
+func parameter(req *http.Request, m *router.Match, p unified.Parameter) []Error {
+	name, in := p.GetName(), p.GetIn()
+	var values []string
+	switch in {
+	case "path":
+		if raw, ok := m.PathParams[name]; ok {
+			values = []string{raw}
+		}
+	case "query":
+		values = req.URL.Query()[name]
+	case "header":
+		if slices.Contains(ignoredHeaders, http.CanonicalHeaderKey(name)) {
+			return nil
+		}
+		values = req.Header.Values(name)
+	case "cookie":
+		if c, err := req.Cookie(name); err == nil {
+			values = []string{c.Value}
+		}
+	default:
+		return nil
+	}
+	schema := p.GetSchema()
+	style := p.GetStyle()
+	if style == "" {
+		style = defaultStyle(in)
+	}
+	explode := style == "form"
+	if p.HasExplode() {
+		explode = p.GetExplode()
+	}
+
+	var value any
+	switch {
+	case in == "query" && isObject(schema) && (style == "deepObject" || style == "form" && explode):
+		// The properties are query parameters of their own
+		object := make(url.Values)
+		for key, vs := range req.URL.Query() {
+			if style == "deepObject" {
+				if prop, ok := strings.CutPrefix(key, name+"["); ok && strings.HasSuffix(prop, "]") {
+					object[strings.TrimSuffix(prop, "]")] = vs
+				}
+			} else if _, ok := schema.GetProperties()[key]; ok {
+				object[key] = vs
+			}
+		}
+		values = nil
+		if len(object) > 0 {
+			values = []string{name}
+			value = decodeForm(schema, object)
+		}
+	case len(values) == 0:
+	case len(p.GetContent()) > 0:
+		// A parameter with content is a serialized media type, checked when
+		// it is JSON
+		for mediaType, mt := range p.GetContent() {
+			if !IsJSON(mediaType) || mt == nil {
+				return nil
+			}
+			if err := json.Unmarshal([]byte(values[0]), &value); err != nil {
+				return []Error{{In: in, Name: name, Message: "invalid JSON: " + err.Error()}}
+			}
+			schema = mt.GetSchema()
+		}
+	default:
+		value = decodeParameter(schema, name, style, explode, values)
+	}
+
+	if len(values) == 0 {
+		if p.GetRequired() {
+			return []Error{{In: in, Name: name, Message: "missing required parameter"}}
+		}
+		return nil
+	}
+	if s, ok := value.(string); ok && s == "" && in == "query" && p.GetAllowEmptyValue() {
+		return nil
+	}
+	err := Value(schema, value, DirectionRequest)
+	if err == nil {
+		return nil
+	}
+	found := err.(Errors)
+	for i := range found {
+		found[i].In, found[i].Name = in, name
+	}
+	return found
+}
+
+// defaultStyle returns the style of the parameters of a location without one
+func defaultStyle(in string) string {
+	if in == "query" || in == "cookie" {
+		return "form"
+	}
+	return "simple"
+}
+
+// decodeParameter decodes the serialized values of a parameter according to
+// its style and converts them to the types of its schema
+func decodeParameter(schema unified.Schema, name, style string, explode bool, values []string) any {
+	raw := values[0]
+	switch style {
+	case "label":
+		raw = strings.TrimPrefix(raw, ".")
+	case "matrix":
+		raw = strings.TrimPrefix(raw, ";"+name+"=")
+	}
+	switch {
+	case isArray(schema):
+		items := schema.GetItems()
+		parts := values
+		if len(values) == 1 {
+			parts = splitArray(raw, name, style, explode, items)
+		}
+		array := make([]any, len(parts))
+		for i, part := range parts {
+			array[i] = coerce(items, part)
+		}
+		return array
+	case isObject(schema):
+		object := make(url.Values)
+		if explode {
+			// k=v pairs, separated as the style separates items
+			separator := map[string]string{"label": ".", "matrix": ";"}[style]
+			if separator == "" {
+				separator = ","
+			}
+			for _, pair := range strings.Split(raw, separator) {
+				if key, value, ok := strings.Cut(pair, "="); ok {
+					object.Add(key, value)
+				}
+			}
+		} else {
+			parts := strings.Split(raw, ",")
+			for i := 0; i+1 < len(parts); i += 2 {
+				object.Add(parts[i], parts[i+1])
+			}
+		}
+		return decodeForm(schema, object)
+	}
+	return coerce(schema, raw)
+}
+
+// splitArray splits the single value of an array parameter into items. An
+// exploded form array repeats the parameter instead of separating items, so
+// its value is only split when the items cannot contain a comma, as when a
+// 2.0 document leaves the csv collectionFormat implicit.
+func splitArray(raw, name, style string, explode bool, items unified.Schema) []string {
+	switch style {
+	case "spaceDelimited":
+		return strings.Split(raw, " ")
+	case "pipeDelimited":
+		return strings.Split(raw, "|")
+	case "label":
+		if explode {
+			return strings.Split(raw, ".")
+		}
+	case "matrix":
+		if explode {
+			return strings.Split(raw, ";"+name+"=")
+		}
+	case "form":
+		if explode && (items == nil || slices.Contains(items.GetTypes(), "string") || len(items.GetTypes()) == 0) {
+			return []string{raw}
+		}
+	}
+	return strings.Split(raw, ",")
+}
+
+// decodeForm converts form fields, or the properties of an object
+// parameter, to the types of the properties of an object schema
+func decodeForm(schema unified.Schema, fields url.Values) map[string]any {
+	if schema == nil {
+		schema = unified.NilSchema{}
+	}
+	object := make(map[string]any, len(fields))
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		values := fields[key]
+		prop := schema.GetProperties()[key]
+		if prop == nil {
+			prop = schema.GetAdditionalProperties()
+		}
+		if isArray(prop) {
+			array := make([]any, len(values))
+			for i, value := range values {
+				array[i] = coerce(prop.GetItems(), value)
+			}
+			object[key] = array
+			continue
+		}
+		object[key] = coerce(prop, values[0])
+	}
+	return object
+}
+
+// coerce converts a serialized value to the first type of a schema it can
+// be read as, or keeps it as a string for the schema to reject
+func coerce(schema unified.Schema, raw string) any {
+	if schema == nil || schema.IsNil() {
+		return raw
+	}
+	for _, t := range schema.GetTypes() {
+		switch t {
+		case "integer", "number":
+			if f, err := strconv.ParseFloat(raw, 64); err == nil {
+				return f
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(raw); err == nil && (raw == "true" || raw == "false") {
+				return b
+			}
+		case "null":
+			if raw == "" || raw == "null" {
+				return nil
+			}
+		case "string":
+			return raw
+		}
+	}
+	return raw
+}
+
+func isArray(schema unified.Schema) bool {
+	return schema != nil && !schema.IsNil() && slices.Contains(schema.GetTypes(), "array")
+}
+
+func isObject(schema unified.Schema) bool {
+	return schema != nil && !schema.IsNil() && slices.Contains(schema.GetTypes(), "object")
+}
+
+// requestBody validates the body of a request
+func requestBody(req *http.Request, rb unified.RequestBody) []Error {
+	data, err := rebuffer(&req.Body)
+	if err != nil {
+		return []Error{{In: "body", Message: "cannot read the body: " + err.Error()}}
+	}
+	if rb == nil || rb.IsNil() {
+		return nil
+	}
+	if len(data) == 0 {
+		if rb.GetRequired() {
+			return []Error{{In: "body", Message: "missing required body"}}
+		}
+		return nil
+	}
+	contentType := req.Header.Get("Content-Type")
+	mt := rb.GetMediaType(contentType)
+	if mt == nil {
+		return []Error{{In: "body", Name: "Content-Type", Message: "unsupported media type " + strconv.Quote(contentType)}}
+	}
+	value, ok, err := decodeBody(contentType, data, mt)
+	if err != nil {
+		return []Error{{In: "body", Message: err.Error()}}
+	}
+	if !ok {
+		return nil
+	}
+	if err := Value(mt.GetSchema(), value, DirectionRequest); err != nil {
+		found := err.(Errors)
+		for i := range found {
+			found[i].In = "body"
+		}
+		return found
+	}
+	return nil
+}
+
+// rebuffer reads a body and replaces it with a reader of the data read, so
+// that it can be read again
+func rebuffer(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// decodeBody decodes a JSON, form-urlencoded or multipart body, forms as the
+// encoding of their media type describes, and returns false for other media
+// types
+func decodeBody(contentType string, data []byte, mt unified.MediaType) (any, bool, error) {
+	switch {
+	case IsJSON(contentType):
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, false, err
+		}
+		return value, true, nil
+	case form.Supports(contentType):
+		value, err := form.Decode(contentType, mt, data)
+		if err != nil {
+			return nil, false, err
+		}
+		return value, true, nil
+	}
+	return nil, false, nil
+}
+
+// IsJSON reports whether a media type, parameters allowed, is JSON:
+// application/json or a type with the +json suffix
+func IsJSON(mediaType string) bool {
+	if t, _, err := mime.ParseMediaType(mediaType); err == nil {
+		mediaType = t
+	}
+	mediaType = strings.ToLower(mediaType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package validate
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Response validates a response against the operation it answers: its
+// status must be documented, by its code, its range or a default response,
+// its headers must match their schemas and a JSON or form body
+// the schema of its media type. writeOnly properties are not required. The
+// body is replaced so that callers can read it again. It returns Errors, or
+// nil when the response matches.
+func Response(resp *http.Response, op unified.Operation) error {
+	data, err := rebuffer(&resp.Body)
+	if err != nil {
+		return Errors{{In: "body", Message: "cannot read the body: " + err.Error()}}
+	}
+	var documented unified.Response
+	if responses := op.GetResponses(); responses != nil {
+		documented = responses.GetByStatus(resp.StatusCode)
+	}
+	if documented == nil || documented.IsNil() {
+		return Errors{{In: "status", Message: fmt.Sprintf("undocumented status %d", resp.StatusCode)}}
+	}
+
+	var found []Error
+	headers := documented.GetHeaders()
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		found = append(found, header(resp.Header, name, headers[name])...)
+	}
+	found = append(found, responseBody(resp.Header.Get("Content-Type"), data, documented)...)
+	if len(found) > 0 {
+		return Errors(found)
+	}
+	return nil
+}
+
+// header validates a header of a response
+func header(h http.Header, name string, spec unified.Header) []Error {
+	if spec == nil || strings.EqualFold(name, "Content-Type") {
+		return nil
+	}
+	values := h.Values(name)
+	if len(values) == 0 {
+		if spec.GetRequired() {
+			return []Error{{In: "header", Name: name, Message: "missing required header"}}
+		}
+		return nil
+	}
+	schema := spec.GetSchema()
+	if schema == nil || schema.IsNil() {
+		return nil
+	}
+	value := decodeParameter(schema, name, "simple", spec.GetExplode(), values)
+	err := Value(schema, value, DirectionResponse)
+	if err == nil {
+		return nil
+	}
+	found := err.(Errors)
+	for i := range found {
+		found[i].In, found[i].Name = "header", name
+	}
+	return found
+}
+
+// responseBody validates the body of a response
+func responseBody(contentType string, data []byte, resp unified.Response) []Error {
+	if len(data) == 0 || len(resp.GetContent()) == 0 {
+		return nil
+	}
+	mt := resp.GetMediaType(contentType)
+	if mt == nil {
+		return []Error{{In: "body", Name: "Content-Type", Message: "undocumented media type " + strconv.Quote(contentType)}}
+	}
+	value, ok, err := decodeBody(contentType, data, mt)
+	if err != nil {
+		return []Error{{In: "body", Message: err.Error()}}
+	}
+	if !ok {
+		return nil
+	}
+	if err := Value(mt.GetSchema(), value, DirectionResponse); err != nil {
+		found := err.(Errors)
+		for i := range found {
+			found[i].In = "body"
+		}
+		return found
+	}
+	return nil
+}
@@ -0,0 +1,394 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+// Package validate checks values, requests and responses against the
+// schemas of an OpenAPI document of any version. Schemas are read through
+// the unified interfaces; pass those of a document returned by
+// unified.Resolve for references to be followed.
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/genelet/oas/unified"
+)
+
+// Error is a place where a value does not match its schema
+type Error struct {
+	// In is where the value is: path, query, header, cookie or body. It is
+	// empty for a value validated on its own.
+	In string `json:"in,omitempty"`
+	// Name is the parameter or header, empty for a body
+	Name string `json:"name,omitempty"`
+	// Pointer is the JSON pointer of the value within its parameter, header
+	// or body
+	Pointer string `json:"pointer,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e Error) Error() string {
+	var where []string
+	if e.In != "" {
+		where = append(where, e.In)
+	}
+	if e.Name != "" {
+		where = append(where, e.Name)
+	}
+	if e.Pointer != "" {
+		where = append(where, e.Pointer)
+	}
+	if len(where) == 0 {
+		return e.Message
+	}
+	return strings.Join(where, " ") + ": " + e.Message
+}
+
+// Errors lists the mismatches of a value or a message
+type Errors []Error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Direction tells which of readOnly and writeOnly properties a value may
+// lack although they are required
+type Direction int
+
+const (
+	// DirectionNone validates a value as it is
+	DirectionNone Direction = iota
+	// DirectionRequest does not require readOnly properties
+	DirectionRequest
+	// DirectionResponse does not require writeOnly properties
+	DirectionResponse
+)
+
+// maxDepth bounds the schemas nested in each other, against cycles
+const maxDepth = 64
+
+// Value validates a generic JSON value, as decoded by encoding/json, against
+// a schema. It returns Errors, or nil when the value matches.
+func Value(schema unified.Schema, value any, direction Direction) error {
+	v := &validator{direction: direction}
+	if found := v.match(schema, normalize(value), "", 0); len(found) > 0 {
+		return Errors(found)
+	}
+	return nil
+}
+
+// validator collects the places where a value does not match a schema
+type validator struct {
+	direction Direction
+}
+
+// match returns the places where value, at the pointer, does not match s
+func (v *validator) match(s unified.Schema, value any, pointer string, depth int) []Error {
+	if s == nil || s.IsNil() || depth > maxDepth {
+		return nil
+	}
+	var found []Error
+	fail := func(format string, args ...any) {
+		found = append(found, Error{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+	if s.IsBooleanSchema() {
+		if b := s.GetBooleanValue(); b != nil && !*b {
+			fail("no value is allowed")
+		}
+		return found
+	}
+	// A reference the document could not resolve matches anything
+	if s.GetRef() != "" {
+		return nil
+	}
+
+	if value == nil && s.IsNullable() {
+		return nil
+	}
+	if types := s.GetTypes(); len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return hasType(t, value) }) {
+		fail("expected %s, got %s", strings.Join(types, " or "), jsonType(value))
+		return found
+	}
+	if enum := s.GetEnum(); len(enum) > 0 && !slices.ContainsFunc(enum, func(e any) bool { return equal(e, value) }) {
+		fail("value is not one of the enum values")
+	}
+	if c := s.GetConst(); c != nil && !equal(c, value) {
+		fail("value is not the const value")
+	}
+
+	switch x := value.(type) {
+	case float64:
+		if m := s.GetMultipleOf(); m != nil && *m > 0 {
+			if q := x / *m; math.Abs(q-math.Round(q)) > 1e-9 {
+				fail("%v is not a multiple of %v", x, *m)
+			}
+		}
+		if m := s.GetMaximum(); m != nil {
+			if s.GetExclusiveMaximum() && x >= *m {
+				fail("%v is not less than %v", x, *m)
+			} else if x > *m {
+				fail("%v is greater than %v", x, *m)
+			}
+		}
+		if m := s.GetMinimum(); m != nil {
+			if s.GetExclusiveMinimum() && x <= *m {
+				fail("%v is not greater than %v", x, *m)
+			} else if x < *m {
+				fail("%v is less than %v", x, *m)
+			}
+		}
+	case string:
+		length := utf8.RuneCountInString(x)
+		if m := s.GetMaxLength(); m != nil && length > *m {
+			fail("length %d is greater than %d", length, *m)
+		}
+		if m := s.GetMinLength(); m != nil && length < *m {
+			fail("length %d is less than %d", length, *m)
+		}
+		if p := s.GetPattern(); p != "" {
+			if re, err := regexp.Compile(p); err == nil && !re.MatchString(x) {
+				fail("does not match pattern '%s'", p)
+			}
+		}
+		if f := s.GetFormat(); f != "" && !hasFormat(f, x) {
+			fail("is not a valid %s", f)
+		}
+	case []any:
+		found = append(found, v.matchArray(s, x, pointer, depth)...)
+	case map[string]any:
+		found = append(found, v.matchObject(s, x, pointer, depth)...)
+	}
+
+	for _, sub := range s.GetAllOf() {
+		found = append(found, v.match(sub, value, pointer, depth+1)...)
+	}
+	if anyOf := s.GetAnyOf(); len(anyOf) > 0 && v.count(anyOf, value, pointer, depth) == 0 {
+		fail("value matches none of the anyOf schemas")
+	}
+	if oneOf := s.GetOneOf(); len(oneOf) > 0 {
+		if n := v.count(oneOf, value, pointer, depth); n != 1 {
+			fail("value matches %d of the oneOf schemas, expected exactly 1", n)
+		}
+	}
+	if not := s.GetNot(); not != nil && !not.IsNil() && len(v.match(not, value, pointer, depth+1)) == 0 {
+		fail("value matches the not schema")
+	}
+	if cond := s.GetIf(); cond != nil && !cond.IsNil() {
+		if len(v.match(cond, value, pointer, depth+1)) == 0 {
+			found = append(found, v.match(s.GetThen(), value, pointer, depth+1)...)
+		} else {
+			found = append(found, v.match(s.GetElse(), value, pointer, depth+1)...)
+		}
+	}
+	return found
+}
+
+// count returns how many of the schemas value matches
+func (v *validator) count(schemas []unified.Schema, value any, pointer string, depth int) int {
+	n := 0
+	for _, sub := range schemas {
+		if len(v.match(sub, value, pointer, depth+1)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// matchArray applies the array keywords of s
+func (v *validator) matchArray(s unified.Schema, x []any, pointer string, depth int) []Error {
+	var found []Error
+	fail := func(format string, args ...any) {
+		found = append(found, Error{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+	if m := s.GetMaxItems(); m != nil && len(x) > *m {
+		fail("%d items is more than %d", len(x), *m)
+	}
+	if m := s.GetMinItems(); m != nil && len(x) < *m {
+		fail("%d items is fewer than %d", len(x), *m)
+	}
+	if s.GetUniqueItems() {
+		for i := range x {
+			for j := i + 1; j < len(x); j++ {
+				if equal(x[i], x[j]) {
+					fail("items %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+	prefix := s.GetPrefixItems()
+	for i, item := range x {
+		sub := s.GetItems()
+		if i < len(prefix) {
+			sub = prefix[i]
+		}
+		found = append(found, v.match(sub, item, fmt.Sprintf("%s/%d", pointer, i), depth+1)...)
+	}
+	if contains := s.GetContains(); contains != nil && !contains.IsNil() {
+		if !slices.ContainsFunc(x, func(item any) bool { return len(v.match(contains, item, pointer, depth+1)) == 0 }) {
+			fail("no item matches the contains schema")
+		}
+	}
+	return found
+}
+
+// matchObject applies the object keywords of s
+func (v *validator) matchObject(s unified.Schema, x map[string]any, pointer string, depth int) []Error {
+	var found []Error
+	fail := func(format string, args ...any) {
+		found = append(found, Error{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+	properties := s.GetProperties()
+	for _, name := range s.GetRequired() {
+		if _, ok := x[name]; ok {
+			continue
+		}
+		if prop := properties[name]; prop != nil && (v.direction == DirectionRequest && prop.GetReadOnly() ||
+			v.direction == DirectionResponse && prop.GetWriteOnly()) {
+			continue
+		}
+		fail("missing required property '%s'", name)
+	}
+	for name, required := range s.GetDependentRequired() {
+		if _, ok := x[name]; !ok {
+			continue
+		}
+		for _, other := range required {
+			if _, ok := x[other]; !ok {
+				fail("property '%s' requires property '%s'", name, other)
+			}
+		}
+	}
+
+	patterns := s.GetPatternProperties()
+	additional := s.GetAdditionalProperties()
+	for _, name := range slices.Sorted(maps.Keys(x)) {
+		at := pointer + "/" + escapePointer(name)
+		matched := false
+		if prop, ok := properties[name]; ok {
+			found = append(found, v.match(prop, x[name], at, depth+1)...)
+			matched = true
+		}
+		for pattern, sub := range patterns {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(name) {
+				found = append(found, v.match(sub, x[name], at, depth+1)...)
+				matched = true
+			}
+		}
+		if matched || additional == nil || additional.IsNil() {
+			continue
+		}
+		if additional.IsBooleanSchema() {
+			if b := additional.GetBooleanValue(); b != nil && !*b {
+				fail("property '%s' is not allowed", name)
+			}
+			continue
+		}
+		found = append(found, v.match(additional, x[name], at, depth+1)...)
+	}
+	return found
+}
+
+// normalize converts a Go value to its generic JSON form
+func normalize(value any) any {
+	switch value.(type) {
+	case nil, bool, float64, string:
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var generic any
+	if json.Unmarshal(data, &generic) != nil {
+		return value
+	}
+	return generic
+}
+
+// equal compares two values by their JSON encoding
+func equal(a, b any) bool {
+	x, err1 := json.Marshal(normalize(a))
+	y, err2 := json.Marshal(normalize(b))
+	return err1 == nil && err2 == nil && string(x) == string(y)
+}
+
+// jsonType names the JSON type of a generic JSON value
+func jsonType(value any) string {
+	switch x := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if x == math.Trunc(x) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// hasType reports whether a value has a JSON Schema type; an integer is
+// also a number
+func hasType(t string, value any) bool {
+	actual := jsonType(value)
+	return t == actual || t == "number" && actual == "integer"
+}
+
+// uuidPattern matches the textual form of a UUID
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// hasFormat checks the formats that have a well-known syntax; others match
+// anything
+func hasFormat(format, s string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "date":
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	case "email":
+		a, err := mail.ParseAddress(s)
+		return err == nil && a.Address == s
+	case "uuid":
+		return uuidPattern.MatchString(s)
+	case "ipv4":
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	case "ipv6":
+		return net.ParseIP(s) != nil && strings.Contains(s, ":")
+	case "uri":
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	}
+	return true
+}
+
+// pointerEscaper escapes a reference token per RFC 6901
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointer(token string) string {
+	return pointerEscaper.Replace(token)
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2025 Greetingland LLC
+// Created with the help of Claude Code
+// MIT License - see LICENSE file for details
+
+package validate
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/genelet/oas/router"
+	"github.com/genelet/oas/unified"
+)
+
+func parse(t *testing.T, data string) unified.Document {
+	t.Helper()
+	doc, err := unified.NewDocument([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	resolved, err := unified.Resolve(doc)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	return resolved
+}
+
+// messages returns the errors of a validation as strings
+func messages(err error) []string {
+	var found Errors
+	if !errors.As(err, &found) {
+		return nil
+	}
+	var msgs []string
+	for _, e := range found {
+		msgs = append(msgs, e.Error())
+	}
+	return msgs
+}
+
+func TestValue(t *testing.T) {
+	doc := parse(t, `{
+		"openapi": "3.1.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {},
+		"components": {"schemas": {
+			"Pet": {
+				"type": "object",
+				"required": ["id", "name", "password"],
+				"properties": {
+					"id": {"type": "integer", "minimum": 1, "readOnly": true},
+					"name": {"type": "string", "minLength": 1, "pattern": "^[A-Z]"},
+					"password": {"type": "string", "writeOnly": true},
+					"tag": {"type": ["string", "null"], "enum": ["cat", "dog", null]},
+					"born": {"type": "string", "format": "date"},
+					"owner": {"$ref": "#/components/schemas/Owner"},
+					"scores": {"type": "array", "items": {"type": "number", "multipleOf": 0.5}, "maxItems": 2, "uniqueItems": true}
+				},
+				"additionalProperties": false
+			},
+			"Owner": {"oneOf": [
+				{"type": "object", "required": ["email"], "properties": {"email": {"type": "string", "format": "email"}}},
+				{"type": "object", "required": ["phone"]}
+			]}
+		}}
+	}`)
+	pet := doc.GetComponents().GetSchemas()["Pet"]
+
+	valid := map[string]any{"id": 1, "name": "Rex", "password": "x", "tag": nil, "born": "2020-02-29",
+		"owner": map[string]any{"email": "a@example.com"}, "scores": []any{1.5, 2}}
+	if err := Value(pet, valid, DirectionNone); err != nil {
+		t.Errorf("Expected a valid pet, got %v", err)
+	}
+	if err := Value(pet, map[string]any{"name": "Rex", "password": "x"}, DirectionRequest); err != nil {
+		t.Errorf("Expected readOnly id not to be required in a request, got %v", err)
+	}
+	if err := Value(pet, map[string]any{"id": 2, "name": "Rex"}, DirectionResponse); err != nil {
+		t.Errorf("Expected writeOnly password not to be required in a response, got %v", err)
+	}
+
+	invalid := map[string]any{"id": 0.5, "name": "rex", "tag": "bird", "born": "2021-02-29", "color": "red",
+		"owner": map[string]any{"email": "a@example.com", "phone": "1"}, "scores": []any{1.2, 1.2, 3}}
+	want := []string{
+		"missing required property 'password'",
+		"/born: is not a valid date",
+		"property 'color' is not allowed",
+		"/id: expected integer, got number",
+		"/name: does not match pattern '^[A-Z]'",
+		"/owner: value matches 2 of the oneOf schemas, expected exactly 1",
+		"/scores: 3 items is more than 2",
+		"/scores: items 0 and 1 are equal",
+		"/scores/0: 1.2 is not a multiple of 0.5",
+		"/scores/1: 1.2 is not a multiple of 0.5",
+		"/tag: value is not one of the enum values",
+	}
+	if got := messages(Value(pet, invalid, DirectionNone)); !slices.Equal(got, want) {
+		t.Errorf("Unexpected errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRequest(t *testing.T) {
+	doc := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {
+			"/pets/{petId}": {
+				"parameters": [{"name": "petId", "in": "path", "required": true, "schema": {"type": "integer"}}],
+				"put": {
+					"parameters": [
+						{"name": "tags", "in": "query", "schema": {"type": "array", "items": {"type": "string"}, "maxItems": 2}},
+						{"name": "ids", "in": "query", "style": "form", "explode": false, "schema": {"type": "array", "items": {"type": "integer"}}},
+						{"name": "filter", "in": "query", "style": "deepObject", "schema": {"type": "object", "properties": {"age": {"type": "integer"}}}},
+						{"name": "kinds", "in": "query", "explode": false, "schema": {"type": "array", "items": {"type": "string", "enum": ["cat", "dog"]}}},
+						{"name": "codes", "in": "query", "style": "form", "schema": {"type": "array", "items": {"type": "string", "enum": ["x", "y"]}}},
+						{"name": "X-Rate", "in": "header", "required": true, "schema": {"type": "number", "maximum": 10}},
+						{"name": "session", "in": "cookie", "schema": {"type": "string", "minLength": 3}}
+					],
+					"requestBody": {"required": true, "content": {
+						"application/json": {"schema": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}, "id": {"type": "integer", "readOnly": true}}}},
+						"application/x-www-form-urlencoded": {"schema": {"type": "object", "properties": {"age": {"type": "integer"}}}}
+					}},
+					"responses": {"200": {"description": "OK"}}
+				}
+			}
+		}
+	}`)
+	r, err := router.New(doc, router.Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tests := []struct {
+		name, url, contentType, body string
+		header                       map[string]string
+		want                         []string
+	}{
+		{"valid", "/pets/1?tags=a&tags=b&ids=1,2&filter[age]=3&kinds=cat,dog&codes=x&codes=y", "application/json", `{"name": "Rex"}`,
+			map[string]string{"X-Rate": "1.5", "Cookie": "session=abcd"}, nil},
+		{"form", "/pets/1", "application/x-www-form-urlencoded", "age=4", map[string]string{"X-Rate": "1"}, nil},
+		{"invalid parameters", "/pets/x?tags=a&tags=b&tags=c&ids=1,b&filter[age]=old&codes=x,y", "application/json", `{"name": "Rex"}`,
+			map[string]string{"X-Rate": "11", "Cookie": "session=ab"}, []string{
+				"path petId: expected integer, got string",
+				"query tags: 3 items is more than 2",
+				"query ids /1: expected integer, got string",
+				"query filter /age: expected integer, got string",
+				"query codes /0: value is not one of the enum values",
+				"header X-Rate: 11 is greater than 10",
+				"cookie session: length 2 is less than 3",
+			}},
+		{"missing", "/pets/1", "", "", nil, []string{
+			"header X-Rate: missing required parameter",
+			"body: missing required body",
+		}},
+		{"invalid body", "/pets/1", "application/json; charset=utf-8", `{"id": 1}`, map[string]string{"X-Rate": "1"}, []string{
+			"body: missing required property 'name'",
+		}},
+		{"unsupported", "/pets/1", "text/plain", "Rex", map[string]string{"X-Rate": "1"}, []string{
+			`body Content-Type: unsupported media type "text/plain"`,
+		}},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("PUT", tt.url, strings.NewReader(tt.body))
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		for k, v := range tt.header {
+			req.Header.Set(k, v)
+		}
+		m, err := r.Find(req)
+		if err != nil {
+			t.Fatalf("%s: Find() error = %v", tt.name, err)
+		}
+		err = Request(req, m)
+		if got := messages(err); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: unexpected errors:\n%s\nwant:\n%s", tt.name, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+		if body, _ := io.ReadAll(req.Body); string(body) != tt.body {
+			t.Errorf("%s: expected the body to be readable again, got %q", tt.name, body)
+		}
+	}
+}
+
+func TestRequest20(t *testing.T) {
+	doc := parse(t, `{
+		"swagger": "2.0",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"post": {
+			"consumes": ["application/json"],
+			"parameters": [
+				{"name": "ids", "in": "query", "type": "array", "items": {"type": "integer"}},
+				{"name": "pet", "in": "body", "required": true, "schema": {"type": "object", "required": ["name"]}}
+			],
+			"responses": {"200": {"description": "OK"}}
+		}}}
+	}`)
+	r, err := router.New(doc, router.Options{})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	req := httptest.NewRequest("POST", "/pets?ids=1,x", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	m, err := r.Find(req)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	want := []string{"query ids /1: expected integer, got string", "body: missing required property 'name'"}
+	if got := messages(Request(req, m)); !slices.Equal(got, want) {
+		t.Errorf("Unexpected errors %q, want %q", got, want)
+	}
+}
+
+func TestResponse(t *testing.T) {
+	doc := parse(t, `{
+		"openapi": "3.0.3",
+		"info": {"title": "Pets", "version": "1"},
+		"paths": {"/pets": {"get": {"responses": {
+			"2XX": {
+				"description": "OK",
+				"headers": {"X-Total": {"required": true, "schema": {"type": "integer"}}},
+				"content": {"application/json": {"schema": {"type": "object", "required": ["name", "password"],
+					"properties": {"name": {"type": "string"}, "password": {"type": "string", "writeOnly": true}}}}}
+			},
+			"404": {"description": "Not found"}
+		}}}}
+	}`)
+	op := doc.GetPaths()["/pets"].GetAllOperations()["get"]
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+		body   string
+		want   []string
+	}{
+		{"valid", 201, map[string]string{"X-Total": "2", "Content-Type": "application/json"}, `{"name": "Rex"}`, nil},
+		{"no body", 404, nil, "", nil},
+		{"invalid", 200, map[string]string{"X-Total": "two", "Content-Type": "application/json"}, `{"name": 1}`, []string{
+			"header X-Total: expected integer, got string",
+			"body /name: expected string, got integer",
+		}},
+		{"missing header", 200, map[string]string{"Content-Type": "text/plain"}, "Rex", []string{
+			"header X-Total: missing required header",
+			`body Content-Type: undocumented media type "text/plain"`,
+		}},
+		{"undocumented", 500, nil, "", []string{"status: undocumented status 500"}},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		for k, v := range tt.header {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(tt.status)
+		w.WriteString(tt.body)
+		resp := w.Result()
+		if got := messages(Response(resp, op)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: unexpected errors:\n%s\nwant:\n%s", tt.name, strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+		}
+		if body, _ := io.ReadAll(resp.Body); string(body) != tt.body {
+			t.Errorf("%s: expected the body to be readable again, got %q", tt.name, body)
+		}
+	}
+}